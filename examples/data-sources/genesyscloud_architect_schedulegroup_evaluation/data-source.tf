@@ -0,0 +1,17 @@
+data "genesyscloud_architect_schedulegroup_evaluation" "christmas" {
+  schedule_group_id = genesyscloud_architect_schedulegroups.sample_schedule_groups.id
+  window_start      = "2024-12-20T00:00:00"
+  window_end        = "2025-01-03T00:00:00"
+  at                = "2024-12-25T10:00:00"
+
+  lifecycle {
+    postcondition {
+      condition     = self.state_at == "holiday"
+      error_message = "The contact center must be on its holiday schedule on Christmas Day."
+    }
+    postcondition {
+      condition     = length(self.gaps) == 0
+      error_message = "Every hour of the window must be covered by an open, closed or holiday schedule."
+    }
+  }
+}
@@ -0,0 +1,10 @@
+data "genesyscloud_flow_validation" "inbound_call" {
+  filepath = "${path.module}/inboundcall_flow.yaml"
+  substitutions = {
+    flow_name = "Inbound Call Flow"
+  }
+}
+
+output "flow_errors" {
+  value = data.genesyscloud_flow_validation.inbound_call.errors
+}
\ No newline at end of file
@@ -0,0 +1,7 @@
+data "genesyscloud_flow_versions" "inbound_call_flow" {
+  flow_id = genesyscloud_flow.inbound_call_flow.id
+}
+
+output "previous_version" {
+  value = data.genesyscloud_flow_versions.inbound_call_flow.versions[1].version
+}
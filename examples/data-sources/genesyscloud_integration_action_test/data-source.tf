@@ -0,0 +1,29 @@
+data "genesyscloud_integration_action_test" "lookup_user" {
+  contract_input  = jsonencode({ type = "object", required = ["email"], properties = { email = { type = "string" } } })
+  contract_output = jsonencode({ type = "object", required = ["id"], properties = { id = { type = "string" }, name = { type = "string" } } })
+  input           = jsonencode({ email = "ann@example.com" })
+  endpoint_url    = "http://localhost:8080"
+
+  config_request {
+    request_url_template = "https://api.example.com/users?email=$esc.url($${input.email})"
+    request_type         = "GET"
+    headers = {
+      Accept = "application/json"
+    }
+  }
+
+  config_response {
+    translation_map = {
+      id   = "$.users[0].id"
+      name = "$.users[0].name"
+    }
+    translation_map_defaults = {
+      name = "\"Unknown\""
+    }
+    success_template = "{\"id\": $${id}, \"name\": $${name}}"
+  }
+}
+
+output "action_result" {
+  value = data.genesyscloud_integration_action_test.lookup_user.result
+}
@@ -0,0 +1,31 @@
+data "genesyscloud_recording_media_retention_policy_evaluation" "sales_calls" {
+  policy_id = genesyscloud_recording_media_retention_policy.sales.id
+
+  conversations {
+    name             = "inbound sales call"
+    media_type       = "call"
+    direction        = "INBOUND"
+    queue_id         = genesyscloud_routing_queue.sales.id
+    wrapup_code_id   = genesyscloud_routing_wrapupcode.sale.id
+    duration_seconds = 420
+    date             = "2024-05-15T10:30:00+02:00"
+  }
+
+  conversations {
+    name       = "support chat"
+    media_type = "chat"
+    queue_id   = genesyscloud_routing_queue.support.id
+    date       = "2024-05-15T10:30:00+02:00"
+  }
+
+  lifecycle {
+    postcondition {
+      condition     = self.results[0].matched && contains(self.results[0].fired_actions, "assign_evaluations")
+      error_message = "Inbound sales calls must be assigned an evaluation."
+    }
+    postcondition {
+      condition     = !self.results[1].matched
+      error_message = "Support chats must not be handled by the sales policy."
+    }
+  }
+}
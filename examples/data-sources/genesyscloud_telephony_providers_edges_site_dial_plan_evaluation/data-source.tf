@@ -0,0 +1,19 @@
+data "genesyscloud_telephony_providers_edges_site_dial_plan_evaluation" "dial_plan" {
+  site_id        = genesyscloud_telephony_providers_edges_site.site.id
+  dialed_strings = ["911", "(317) 555-0100", "+44 20 7946 0000", "1234"]
+
+  lifecycle {
+    postcondition {
+      condition     = self.results[0].classification == "Emergency" && self.results[0].routable
+      error_message = "Emergency calls must be classified as Emergency and routed to a trunk."
+    }
+    postcondition {
+      condition     = self.results[1].normalized_number == "+13175550100"
+      error_message = "National numbers must be normalized to E.164."
+    }
+    postcondition {
+      condition     = !self.results[3].routable
+      error_message = "Extensions must not be routed to an external trunk."
+    }
+  }
+}
@@ -0,0 +1,12 @@
+resource "genesyscloud_access_profile" "agent" {
+  name        = "Agent"
+  description = "Roles of every agent"
+  roles {
+    role_id      = genesyscloud_auth_role.agent.id
+    division_ids = [genesyscloud_auth_division.sales.id, genesyscloud_auth_division.support.id]
+  }
+  roles {
+    // Granted in the home division
+    role_id = data.genesyscloud_auth_role.employee.id
+  }
+}
@@ -0,0 +1,13 @@
+resource "genesyscloud_access_profile_assignment" "support_agents" {
+  access_profile {
+    id     = genesyscloud_access_profile.agent.id
+    grants = genesyscloud_access_profile.agent.grants
+  }
+  access_profile {
+    id     = genesyscloud_access_profile.supervisor.id
+    grants = genesyscloud_access_profile.supervisor.grants
+  }
+  user_ids  = [genesyscloud_user.example_user.id]
+  group_ids = [genesyscloud_group.support.id]
+  team_ids  = [genesyscloud_team.support.id]
+}
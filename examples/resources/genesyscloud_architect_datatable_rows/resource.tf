@@ -0,0 +1,7 @@
+resource "genesyscloud_architect_datatable_rows" "customers" {
+  datatable_id      = genesyscloud_architect_datatable.customer-table.id
+  filepath          = "${path.module}/customers.csv"
+  file_content_hash = filesha256("${path.module}/customers.csv")
+  authoritative     = true
+  batch_size        = 20
+}
\ No newline at end of file
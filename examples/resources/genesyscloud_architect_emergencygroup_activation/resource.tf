@@ -0,0 +1,5 @@
+resource "genesyscloud_architect_emergencygroup_activation" "emergency-group-activation" {
+  emergency_group_id = genesyscloud_architect_emergencygroup.emergency-group.id
+  enabled            = true
+  expires_at         = "2025-01-01T18:00:00Z"
+}
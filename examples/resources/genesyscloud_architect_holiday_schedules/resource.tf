@@ -0,0 +1,14 @@
+resource "genesyscloud_architect_holiday_schedules" "us_holidays" {
+  name_prefix       = "US Holidays"
+  division_id       = genesyscloud_auth_division.home.id
+  description       = "US federal holidays"
+  country_code      = "US"
+  years             = [2025, 2026]
+  schedule_group_id = genesyscloud_architect_schedulegroups.sample_schedule_groups.id
+}
+
+resource "genesyscloud_architect_holiday_schedules" "company_holidays" {
+  name_prefix = "Company Holidays"
+  ics_file    = "${path.module}/company_holidays.ics"
+  years       = [2025]
+}
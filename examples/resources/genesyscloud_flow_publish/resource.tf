@@ -0,0 +1,4 @@
+resource "genesyscloud_flow_publish" "rollback" {
+  flow_id = genesyscloud_flow.inbound_call_flow.id
+  version = "3.0"
+}
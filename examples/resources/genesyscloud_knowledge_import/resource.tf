@@ -0,0 +1,6 @@
+resource "genesyscloud_knowledge_import" "help_center" {
+  knowledge_base_id = genesyscloud_knowledge_knowledgebase.example_knowledgebase.id
+  directory         = "${path.module}/articles"
+  visible           = true
+  delete_removed    = true
+}
\ No newline at end of file
@@ -0,0 +1,11 @@
+resource "genesyscloud_oauth_client_secret_rotation" "example-client-rotation" {
+  oauth_client_id           = genesyscloud_oauth_client.example-client.id
+  integration_credential_id = genesyscloud_integration_credential.example-credential.id
+  rotation_days             = 90
+  rotate_trigger            = var.secret_rotation_trigger
+}
+
+output "example_client_secret" {
+  value     = genesyscloud_oauth_client_secret_rotation.example-client-rotation.client_secret
+  sensitive = true
+}
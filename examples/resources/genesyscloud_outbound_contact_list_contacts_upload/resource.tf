@@ -0,0 +1,11 @@
+resource "genesyscloud_outbound_contact_list_contacts_upload" "contacts" {
+  contact_list_id   = genesyscloud_outbound_contact_list.contact_list.id
+  filepath          = "${path.module}/contacts.csv"
+  file_content_hash = filesha256("${path.module}/contacts.csv")
+  contact_id_name   = "ContactId"
+  skip_invalid_rows = true
+  column_mapping = {
+    "Customer Number" = "ContactId"
+    "Mobile"          = "Cell"
+  }
+}
@@ -0,0 +1,12 @@
+resource "genesyscloud_routing_queue_members" "example_queue_members" {
+  queue_id      = genesyscloud_routing_queue.example_queue.id
+  authoritative = false
+  members {
+    user_id  = genesyscloud_user.example_user.id
+    ring_num = 1
+  }
+  members {
+    user_id  = genesyscloud_user.example_user2.id
+    ring_num = 2
+  }
+}
@@ -0,0 +1,8 @@
+resource "genesyscloud_telephony_providers_edges_phones_inventory" "hq_desk_phones" {
+  inventory_file = "${path.module}/phones.csv"
+  name_prefix    = "HQ Desk "
+  batch_size     = 10
+  phone_base_settings_ids = {
+    "Polycom VVX 500" = genesyscloud_telephony_providers_edges_phonebasesettings.polycom_vvx_500.id
+  }
+}
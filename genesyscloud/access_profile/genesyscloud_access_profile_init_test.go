@@ -0,0 +1,65 @@
+package access_profile
+
+import (
+	"sync"
+	authRole "terraform-provider-genesyscloud/genesyscloud/auth_role"
+	"terraform-provider-genesyscloud/genesyscloud/user"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+/*
+The genesyscloud_access_profile_init_test.go file is used to initialize the data sources and resources
+used in testing the access profile resources.
+*/
+
+// providerDataSources holds a map of all registered datasources
+var providerDataSources map[string]*schema.Resource
+
+// providerResources holds a map of all registered resources
+var providerResources map[string]*schema.Resource
+
+type registerTestInstance struct {
+	resourceMapMutex   sync.RWMutex
+	datasourceMapMutex sync.RWMutex
+}
+
+// registerTestResources registers all resources used in the tests
+func (r *registerTestInstance) registerTestResources() {
+	r.resourceMapMutex.Lock()
+	defer r.resourceMapMutex.Unlock()
+
+	providerResources[ResourceType] = ResourceAccessProfile()
+	providerResources[AssignmentResourceType] = ResourceAccessProfileAssignment()
+	providerResources[user.ResourceType] = user.ResourceUser()
+	providerResources[authRole.ResourceType] = authRole.ResourceAuthRole()
+}
+
+// registerTestDataSources registers all data sources used in the tests.
+func (r *registerTestInstance) registerTestDataSources() {
+	r.datasourceMapMutex.Lock()
+	defer r.datasourceMapMutex.Unlock()
+
+	providerDataSources[authRole.ResourceType] = authRole.DataSourceAuthRole()
+}
+
+// initTestResources initializes all test resources.
+func initTestResources() {
+	providerResources = make(map[string]*schema.Resource)
+	providerDataSources = make(map[string]*schema.Resource)
+
+	regInstance := &registerTestInstance{}
+
+	regInstance.registerTestResources()
+	regInstance.registerTestDataSources()
+}
+
+// TestMain is a "setup" function called by the testing framework when run the test
+func TestMain(m *testing.M) {
+	// Run setup function before starting the test suite for access_profile package
+	initTestResources()
+
+	// Run the test suite for the access_profile package
+	m.Run()
+}
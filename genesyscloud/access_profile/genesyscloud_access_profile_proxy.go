@@ -0,0 +1,144 @@
+package access_profile
+
+import (
+	"context"
+	"fmt"
+	"terraform-provider-genesyscloud/genesyscloud/util"
+
+	"github.com/mypurecloud/platform-client-sdk-go/v149/platformclientv2"
+)
+
+/*
+The genesyscloud_access_profile_proxy.go file contains the proxy structures and methods that interact with the Genesys Cloud SDK.
+The proxy reads and changes the role grants of users and groups through the authorization subject APIs, and lists the members of teams.
+*/
+
+var internalProxy *accessProfileProxy
+
+type getHomeDivisionIdFunc func(ctx context.Context, p *accessProfileProxy) (string, error)
+type getSubjectGrantsFunc func(ctx context.Context, p *accessProfileProxy, subjectId string) (*[]platformclientv2.Authzgrant, *platformclientv2.APIResponse, error)
+type addSubjectGrantsFunc func(ctx context.Context, p *accessProfileProxy, subjectId string, subjectType string, grants platformclientv2.Roledivisiongrants) (*platformclientv2.APIResponse, error)
+type removeSubjectGrantFunc func(ctx context.Context, p *accessProfileProxy, subjectId string, roleId string, divisionId string) (*platformclientv2.APIResponse, error)
+type getTeamMemberIdsFunc func(ctx context.Context, p *accessProfileProxy, teamId string) (*[]string, *platformclientv2.APIResponse, error)
+
+type accessProfileProxy struct {
+	clientConfig           *platformclientv2.Configuration
+	authorizationApi       *platformclientv2.AuthorizationApi
+	teamsApi               *platformclientv2.TeamsApi
+	getHomeDivisionIdAttr  getHomeDivisionIdFunc
+	getSubjectGrantsAttr   getSubjectGrantsFunc
+	addSubjectGrantsAttr   addSubjectGrantsFunc
+	removeSubjectGrantAttr removeSubjectGrantFunc
+	getTeamMemberIdsAttr   getTeamMemberIdsFunc
+}
+
+func newAccessProfileProxy(clientConfig *platformclientv2.Configuration) *accessProfileProxy {
+	return &accessProfileProxy{
+		clientConfig:           clientConfig,
+		authorizationApi:       platformclientv2.NewAuthorizationApiWithConfig(clientConfig),
+		teamsApi:               platformclientv2.NewTeamsApiWithConfig(clientConfig),
+		getHomeDivisionIdAttr:  getHomeDivisionIdFn,
+		getSubjectGrantsAttr:   getSubjectGrantsFn,
+		addSubjectGrantsAttr:   addSubjectGrantsFn,
+		removeSubjectGrantAttr: removeSubjectGrantFn,
+		getTeamMemberIdsAttr:   getTeamMemberIdsFn,
+	}
+}
+
+func getAccessProfileProxy(clientConfig *platformclientv2.Configuration) *accessProfileProxy {
+	if internalProxy == nil {
+		internalProxy = newAccessProfileProxy(clientConfig)
+	}
+	return internalProxy
+}
+
+func (p *accessProfileProxy) getHomeDivisionId(ctx context.Context) (string, error) {
+	return p.getHomeDivisionIdAttr(ctx, p)
+}
+
+func (p *accessProfileProxy) getSubjectGrants(ctx context.Context, subjectId string) (*[]platformclientv2.Authzgrant, *platformclientv2.APIResponse, error) {
+	return p.getSubjectGrantsAttr(ctx, p, subjectId)
+}
+
+func (p *accessProfileProxy) addSubjectGrants(ctx context.Context, subjectId string, subjectType string, grants platformclientv2.Roledivisiongrants) (*platformclientv2.APIResponse, error) {
+	return p.addSubjectGrantsAttr(ctx, p, subjectId, subjectType, grants)
+}
+
+func (p *accessProfileProxy) removeSubjectGrant(ctx context.Context, subjectId string, roleId string, divisionId string) (*platformclientv2.APIResponse, error) {
+	return p.removeSubjectGrantAttr(ctx, p, subjectId, roleId, divisionId)
+}
+
+func (p *accessProfileProxy) getTeamMemberIds(ctx context.Context, teamId string) (*[]string, *platformclientv2.APIResponse, error) {
+	return p.getTeamMemberIdsAttr(ctx, p, teamId)
+}
+
+func getHomeDivisionIdFn(_ context.Context, _ *accessProfileProxy) (string, error) {
+	homeDivisionId, diagErr := util.GetHomeDivisionID()
+	if diagErr != nil {
+		return "", fmt.Errorf("%v", diagErr)
+	}
+	return homeDivisionId, nil
+}
+
+// getSubjectGrantsFn returns the grants given to the subject itself, leaving out those inherited from its groups
+func getSubjectGrantsFn(_ context.Context, p *accessProfileProxy, subjectId string) (*[]platformclientv2.Authzgrant, *platformclientv2.APIResponse, error) {
+	subject, resp, err := p.authorizationApi.GetAuthorizationSubject(subjectId, true)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	var grants []platformclientv2.Authzgrant
+	if subject.Grants != nil {
+		for _, grant := range *subject.Grants {
+			if grant.SubjectId != nil && *grant.SubjectId == subjectId && grant.Role != nil && grant.Role.Id != nil && grant.Division != nil && grant.Division.Id != nil {
+				grants = append(grants, grant)
+			}
+		}
+	}
+	return &grants, resp, nil
+}
+
+func addSubjectGrantsFn(_ context.Context, p *accessProfileProxy, subjectId string, subjectType string, grants platformclientv2.Roledivisiongrants) (*platformclientv2.APIResponse, error) {
+	return p.authorizationApi.PostAuthorizationSubjectBulkadd(subjectId, grants, subjectType)
+}
+
+func removeSubjectGrantFn(_ context.Context, p *accessProfileProxy, subjectId string, roleId string, divisionId string) (*platformclientv2.APIResponse, error) {
+	return p.authorizationApi.DeleteAuthorizationSubjectDivisionRole(subjectId, divisionId, roleId)
+}
+
+func getTeamMemberIdsFn(_ context.Context, p *accessProfileProxy, teamId string) (*[]string, *platformclientv2.APIResponse, error) {
+	const pageSize = 100
+	var (
+		memberIds []string
+		after     string
+		response  *platformclientv2.APIResponse
+	)
+
+	for {
+		members, resp, err := p.teamsApi.GetTeamMembers(teamId, pageSize, "", after, "")
+		response = resp
+		if err != nil {
+			return nil, resp, err
+		}
+		if members.Entities == nil || len(*members.Entities) == 0 {
+			break
+		}
+		for _, member := range *members.Entities {
+			if member.Id != nil {
+				memberIds = append(memberIds, *member.Id)
+			}
+		}
+		if members.NextUri == nil || *members.NextUri == "" {
+			break
+		}
+
+		after, err = util.GetQueryParamValueFromUri(*members.NextUri, "after")
+		if err != nil {
+			return nil, resp, fmt.Errorf("unable to parse after cursor from members next uri: %v", err)
+		}
+		if after == "" {
+			break
+		}
+	}
+	return &memberIds, response, nil
+}
@@ -0,0 +1,97 @@
+package access_profile
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"regexp"
+	"sort"
+	"terraform-provider-genesyscloud/genesyscloud/provider"
+	"terraform-provider-genesyscloud/genesyscloud/util"
+	"terraform-provider-genesyscloud/genesyscloud/util/lists"
+
+	"github.com/google/uuid"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+/*
+The resource_genesyscloud_access_profile.go contains the genesyscloud_access_profile resource. An access profile has no
+counterpart in Genesys Cloud: it expands its roles into role and division pairs, which assignments grant to their subjects.
+*/
+
+// grantRegex matches the <role_id>:<division_id> grants of an access profile
+var grantRegex = regexp.MustCompile(`^[^:]+:[^:]+$`)
+
+func createAccessProfile(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	d.SetId(uuid.NewString())
+	log.Printf("Creating access profile %s", d.Get("name").(string))
+	return updateAccessProfile(ctx, d, meta)
+}
+
+func readAccessProfile(_ context.Context, d *schema.ResourceData, _ interface{}) diag.Diagnostics {
+	// The access profile only lives in the state
+	log.Printf("Read access profile %s", d.Id())
+	return nil
+}
+
+func updateAccessProfile(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	sdkConfig := meta.(*provider.ProviderMeta).ClientConfig
+	proxy := getAccessProfileProxy(sdkConfig)
+
+	grants, err := buildAccessProfileGrants(ctx, proxy, d.Get("roles").(*schema.Set))
+	if err != nil {
+		return util.BuildDiagnosticError(ResourceType, fmt.Sprintf("Failed to expand the roles of access profile %s", d.Get("name").(string)), err)
+	}
+	_ = d.Set("grants", lists.StringListToSet(grants))
+
+	log.Printf("Access profile %s grants %d role and division pairs", d.Id(), len(grants))
+	return readAccessProfile(ctx, d, meta)
+}
+
+func deleteAccessProfile(_ context.Context, d *schema.ResourceData, _ interface{}) diag.Diagnostics {
+	// Assignments of the access profile remove its grants from their subjects
+	log.Printf("Deleted access profile %s", d.Id())
+	return nil
+}
+
+func customizeAccessProfileDiff(_ context.Context, diff *schema.ResourceDiff, _ interface{}) error {
+	if diff.HasChange("roles") {
+		return diff.SetNewComputed("grants")
+	}
+	return nil
+}
+
+// buildAccessProfileGrants returns the sorted role and division pairs of the roles, using the home division for roles without divisions
+func buildAccessProfileGrants(ctx context.Context, proxy *accessProfileProxy, roles *schema.Set) ([]string, error) {
+	grants := make(map[string]bool)
+	var homeDivisionId string
+	for _, role := range roles.List() {
+		roleMap := role.(map[string]interface{})
+		roleId := roleMap["role_id"].(string)
+
+		var divisionIds []string
+		if divisions, ok := roleMap["division_ids"].(*schema.Set); ok {
+			divisionIds = *lists.SetToStringList(divisions)
+		}
+		if len(divisionIds) == 0 {
+			if homeDivisionId == "" {
+				var err error
+				if homeDivisionId, err = proxy.getHomeDivisionId(ctx); err != nil {
+					return nil, err
+				}
+			}
+			divisionIds = []string{homeDivisionId}
+		}
+		for _, divisionId := range divisionIds {
+			grants[roleId+":"+divisionId] = true
+		}
+	}
+
+	sorted := make([]string, 0, len(grants))
+	for grant := range grants {
+		sorted = append(sorted, grant)
+	}
+	sort.Strings(sorted)
+	return sorted, nil
+}
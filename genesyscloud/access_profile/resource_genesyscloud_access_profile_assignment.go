@@ -0,0 +1,352 @@
+package access_profile
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+	"terraform-provider-genesyscloud/genesyscloud/provider"
+	"terraform-provider-genesyscloud/genesyscloud/util"
+	"terraform-provider-genesyscloud/genesyscloud/util/lists"
+
+	"github.com/google/uuid"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/mypurecloud/platform-client-sdk-go/v149/platformclientv2"
+)
+
+/*
+The resource_genesyscloud_access_profile_assignment.go contains the genesyscloud_access_profile_assignment resource.
+The assignment grants the union of the grants of its access profiles to each of its subjects, and keeps track of the
+grants it added in assigned_grants so that it never removes grants that were given to the subjects by other means.
+*/
+
+// subjectGrant is a role granted in a division to a user or group
+type subjectGrant struct {
+	subjectType string
+	subjectId   string
+	roleId      string
+	divisionId  string
+}
+
+func (g subjectGrant) pair() string {
+	return g.roleId + ":" + g.divisionId
+}
+
+type assignmentSubject struct {
+	subjectType string
+	subjectId   string
+}
+
+// assignmentPlan holds the differences between the grants of the access profiles and those of the subjects
+type assignmentPlan struct {
+	toAdd     []subjectGrant
+	toRemove  []subjectGrant
+	keep      []subjectGrant
+	conflicts []subjectGrant
+}
+
+func (p *assignmentPlan) inSync() bool {
+	return len(p.toAdd) == 0 && len(p.toRemove) == 0
+}
+
+func createAccessProfileAssignment(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	d.SetId(uuid.NewString())
+	log.Printf("Creating access profile assignment %s", d.Id())
+	return updateAccessProfileAssignment(ctx, d, meta)
+}
+
+func readAccessProfileAssignment(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	sdkConfig := meta.(*provider.ProviderMeta).ClientConfig
+	proxy := getAccessProfileProxy(sdkConfig)
+
+	log.Printf("Reading access profile assignment %s", d.Id())
+	plan, diagErr := buildAssignmentPlan(ctx, proxy, d, buildSubjectGrants(d.Get("assigned_grants").([]interface{})))
+	if diagErr != nil {
+		return diagErr
+	}
+
+	// Grants removed by someone else are no longer managed, and those waiting to be removed still are
+	_ = d.Set("assigned_grants", flattenSubjectGrants(append(plan.keep, plan.toRemove...)))
+	_ = d.Set("conflicts", flattenSubjectGrants(plan.conflicts))
+	_ = d.Set("in_sync", plan.inSync())
+
+	log.Printf("Read access profile assignment %s", d.Id())
+	return nil
+}
+
+func updateAccessProfileAssignment(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	sdkConfig := meta.(*provider.ProviderMeta).ClientConfig
+	proxy := getAccessProfileProxy(sdkConfig)
+
+	// The plan marks assigned_grants as unknown, so the grants added by the assignment are read from the prior state
+	priorGrants, _ := d.GetChange("assigned_grants")
+	plan, diagErr := buildAssignmentPlan(ctx, proxy, d, buildSubjectGrants(priorGrants.([]interface{})))
+	if diagErr != nil {
+		return diagErr
+	}
+
+	// The grants are saved even when the assignment fails part way, so that none of the added grants are forgotten
+	assigned, diagErr := applyAssignmentPlan(ctx, proxy, plan)
+	_ = d.Set("assigned_grants", flattenSubjectGrants(assigned))
+	if diagErr != nil {
+		return diagErr
+	}
+
+	var diags diag.Diagnostics
+	if len(plan.conflicts) > 0 {
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Warning,
+			Summary:  fmt.Sprintf("%d grants of access profile assignment %s were already given to their subjects and are not managed by the assignment", len(plan.conflicts), d.Id()),
+			Detail:   describeSubjectGrants(plan.conflicts),
+		})
+	}
+
+	log.Printf("Access profile assignment %s added %d grants and removed %d grants", d.Id(), len(plan.toAdd), len(plan.toRemove))
+	return append(diags, readAccessProfileAssignment(ctx, d, meta)...)
+}
+
+func deleteAccessProfileAssignment(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	sdkConfig := meta.(*provider.ProviderMeta).ClientConfig
+	proxy := getAccessProfileProxy(sdkConfig)
+
+	for _, grant := range buildSubjectGrants(d.Get("assigned_grants").([]interface{})) {
+		log.Printf("Removing role %s in division %s from %s", grant.roleId, grant.divisionId, grant.subjectId)
+		resp, err := proxy.removeSubjectGrant(ctx, grant.subjectId, grant.roleId, grant.divisionId)
+		if err != nil && !util.IsStatus404(resp) {
+			return util.BuildAPIDiagnosticError(AssignmentResourceType, fmt.Sprintf("Failed to remove role %s in division %s from %s | error: %s", grant.roleId, grant.divisionId, grant.subjectId, err), resp)
+		}
+	}
+	log.Printf("Deleted access profile assignment %s", d.Id())
+	return nil
+}
+
+// applyAssignmentPlan removes and adds the grants of the plan, and returns the grants added by the assignment
+func applyAssignmentPlan(ctx context.Context, proxy *accessProfileProxy, plan *assignmentPlan) ([]subjectGrant, diag.Diagnostics) {
+	assigned := append([]subjectGrant(nil), plan.keep...)
+	for i, grant := range plan.toRemove {
+		log.Printf("Removing role %s in division %s from %s", grant.roleId, grant.divisionId, grant.subjectId)
+		resp, err := proxy.removeSubjectGrant(ctx, grant.subjectId, grant.roleId, grant.divisionId)
+		if err != nil && !util.IsStatus404(resp) {
+			return append(assigned, plan.toRemove[i:]...), util.BuildAPIDiagnosticError(AssignmentResourceType, fmt.Sprintf("Failed to remove role %s in division %s from %s | error: %s", grant.roleId, grant.divisionId, grant.subjectId, err), resp)
+		}
+	}
+
+	for _, subjectGrants := range groupBySubject(plan.toAdd) {
+		subject := subjectGrants[0]
+		log.Printf("Granting %d roles to %s", len(subjectGrants), subject.subjectId)
+		// In some cases new roles or divisions have not yet been added to the auth service cache causing 404s that should be retried.
+		diagErr := util.RetryWhen(util.IsStatus404, func() (*platformclientv2.APIResponse, diag.Diagnostics) {
+			resp, err := proxy.addSubjectGrants(ctx, subject.subjectId, subject.subjectType, toRoleDivisionGrants(subjectGrants))
+			if err != nil {
+				return resp, util.BuildAPIDiagnosticError(AssignmentResourceType, fmt.Sprintf("Failed to grant roles to %s | error: %s", subject.subjectId, err), resp)
+			}
+			return resp, nil
+		})
+		if diagErr != nil {
+			return assigned, diagErr
+		}
+		assigned = append(assigned, subjectGrants...)
+	}
+	return assigned, nil
+}
+
+// customizeAccessProfileAssignmentDiff plans an update when the subjects have drifted from the access profiles
+func customizeAccessProfileAssignmentDiff(_ context.Context, diff *schema.ResourceDiff, _ interface{}) error {
+	if diff.Id() == "" {
+		return nil
+	}
+	if diff.Get("in_sync").(bool) && !diff.HasChanges("access_profile", "user_ids", "group_ids", "team_ids") {
+		return nil
+	}
+	for _, key := range []string{"assigned_grants", "conflicts", "in_sync"} {
+		if err := diff.SetNewComputed(key); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// buildAssignmentPlan compares the grants of the access profiles with those of the subjects and with the grants the assignment added
+func buildAssignmentPlan(ctx context.Context, proxy *accessProfileProxy, d *schema.ResourceData, ownedGrants []subjectGrant) (*assignmentPlan, diag.Diagnostics) {
+	desired := accessProfilesGrants(d)
+	subjects, diagErr := resolveAssignmentSubjects(ctx, proxy, d)
+	if diagErr != nil {
+		return nil, diagErr
+	}
+
+	// Subjects removed from the assignment still hold the grants it added
+	owned := make(map[assignmentSubject]map[string]bool)
+	assigned := make(map[assignmentSubject]bool)
+	for _, subject := range subjects {
+		assigned[subject] = true
+	}
+	for _, grant := range ownedGrants {
+		subject := assignmentSubject{subjectType: grant.subjectType, subjectId: grant.subjectId}
+		if owned[subject] == nil {
+			owned[subject] = make(map[string]bool)
+			if !assigned[subject] {
+				subjects = append(subjects, subject)
+			}
+		}
+		owned[subject][grant.pair()] = true
+	}
+
+	plan := &assignmentPlan{}
+	for _, subject := range subjects {
+		grants, resp, err := proxy.getSubjectGrants(ctx, subject.subjectId)
+		if err != nil {
+			if util.IsStatus404(resp) && !assigned[subject] {
+				log.Printf("Subject %s no longer exists", subject.subjectId)
+				continue
+			}
+			return nil, util.BuildAPIDiagnosticError(AssignmentResourceType, fmt.Sprintf("Failed to get the grants of %s | error: %s", subject.subjectId, err), resp)
+		}
+		present := make(map[string]bool)
+		for _, grant := range *grants {
+			present[*grant.Role.Id+":"+*grant.Division.Id] = true
+		}
+
+		newGrant := func(pair string) subjectGrant {
+			roleDivision := strings.SplitN(pair, ":", 2)
+			return subjectGrant{subjectType: subject.subjectType, subjectId: subject.subjectId, roleId: roleDivision[0], divisionId: roleDivision[1]}
+		}
+		wanted := make(map[string]bool)
+		if assigned[subject] {
+			for _, pair := range desired {
+				wanted[pair] = true
+				switch {
+				case !present[pair]:
+					plan.toAdd = append(plan.toAdd, newGrant(pair))
+				case owned[subject][pair]:
+					plan.keep = append(plan.keep, newGrant(pair))
+				default:
+					plan.conflicts = append(plan.conflicts, newGrant(pair))
+				}
+			}
+		}
+		for _, pair := range sortedKeys(owned[subject]) {
+			if present[pair] && !wanted[pair] {
+				plan.toRemove = append(plan.toRemove, newGrant(pair))
+			}
+		}
+	}
+	return plan, nil
+}
+
+// accessProfilesGrants returns the sorted union of the grants of the access profiles
+func accessProfilesGrants(d *schema.ResourceData) []string {
+	grants := make(map[string]bool)
+	for _, profile := range d.Get("access_profile").(*schema.Set).List() {
+		profileMap := profile.(map[string]interface{})
+		for _, grant := range *lists.SetToStringList(profileMap["grants"].(*schema.Set)) {
+			grants[grant] = true
+		}
+	}
+	return sortedKeys(grants)
+}
+
+// resolveAssignmentSubjects returns the users, the members of the teams and the groups of the assignment
+func resolveAssignmentSubjects(ctx context.Context, proxy *accessProfileProxy, d *schema.ResourceData) ([]assignmentSubject, diag.Diagnostics) {
+	userIds := make(map[string]bool)
+	for _, userId := range *lists.SetToStringList(d.Get("user_ids").(*schema.Set)) {
+		userIds[userId] = true
+	}
+	for _, teamId := range *lists.SetToStringList(d.Get("team_ids").(*schema.Set)) {
+		memberIds, resp, err := proxy.getTeamMemberIds(ctx, teamId)
+		if err != nil {
+			return nil, util.BuildAPIDiagnosticError(AssignmentResourceType, fmt.Sprintf("Failed to get the members of team %s | error: %s", teamId, err), resp)
+		}
+		for _, memberId := range *memberIds {
+			userIds[memberId] = true
+		}
+	}
+
+	var subjects []assignmentSubject
+	for _, userId := range sortedKeys(userIds) {
+		subjects = append(subjects, assignmentSubject{subjectType: subjectTypeUser, subjectId: userId})
+	}
+	groupIds := *lists.SetToStringList(d.Get("group_ids").(*schema.Set))
+	sort.Strings(groupIds)
+	for _, groupId := range groupIds {
+		subjects = append(subjects, assignmentSubject{subjectType: subjectTypeGroup, subjectId: groupId})
+	}
+	return subjects, nil
+}
+
+// groupBySubject splits the grants by subject, keeping the order of the subjects
+func groupBySubject(grants []subjectGrant) [][]subjectGrant {
+	var groups [][]subjectGrant
+	for _, grant := range grants {
+		if n := len(groups); n > 0 && groups[n-1][0].subjectId == grant.subjectId {
+			groups[n-1] = append(groups[n-1], grant)
+			continue
+		}
+		groups = append(groups, []subjectGrant{grant})
+	}
+	return groups
+}
+
+func toRoleDivisionGrants(grants []subjectGrant) platformclientv2.Roledivisiongrants {
+	pairs := make([]platformclientv2.Roledivisionpair, len(grants))
+	for i, grant := range grants {
+		pairs[i] = platformclientv2.Roledivisionpair{
+			RoleId:     platformclientv2.String(grant.roleId),
+			DivisionId: platformclientv2.String(grant.divisionId),
+		}
+	}
+	return platformclientv2.Roledivisiongrants{Grants: &pairs}
+}
+
+func buildSubjectGrants(assignedGrants []interface{}) []subjectGrant {
+	var grants []subjectGrant
+	for _, grant := range assignedGrants {
+		grantMap := grant.(map[string]interface{})
+		grants = append(grants, subjectGrant{
+			subjectType: grantMap["subject_type"].(string),
+			subjectId:   grantMap["subject_id"].(string),
+			roleId:      grantMap["role_id"].(string),
+			divisionId:  grantMap["division_id"].(string),
+		})
+	}
+	return grants
+}
+
+func flattenSubjectGrants(grants []subjectGrant) []interface{} {
+	sorted := append([]subjectGrant(nil), grants...)
+	sort.Slice(sorted, func(i, j int) bool {
+		if sorted[i].subjectId != sorted[j].subjectId {
+			return sorted[i].subjectId < sorted[j].subjectId
+		}
+		return sorted[i].pair() < sorted[j].pair()
+	})
+
+	flattened := make([]interface{}, 0, len(sorted))
+	for _, grant := range sorted {
+		flattened = append(flattened, map[string]interface{}{
+			"subject_type": grant.subjectType,
+			"subject_id":   grant.subjectId,
+			"role_id":      grant.roleId,
+			"division_id":  grant.divisionId,
+		})
+	}
+	return flattened
+}
+
+func describeSubjectGrants(grants []subjectGrant) string {
+	lines := make([]string, 0, len(grants))
+	for _, grant := range grants {
+		lines = append(lines, fmt.Sprintf("%s %s: role %s in division %s", grant.subjectType, grant.subjectId, grant.roleId, grant.divisionId))
+	}
+	return strings.Join(lines, "\n")
+}
+
+func sortedKeys(m map[string]bool) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
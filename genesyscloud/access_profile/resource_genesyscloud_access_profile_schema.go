@@ -0,0 +1,191 @@
+package access_profile
+
+import (
+	"terraform-provider-genesyscloud/genesyscloud/provider"
+	registrar "terraform-provider-genesyscloud/genesyscloud/resource_register"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+/*
+resource_genesyscloud_access_profile_schema.go holds four functions within it:
+
+1.  The registration code that registers the resources with the provider.
+2.  The resource schema definitions for the genesyscloud_access_profile and genesyscloud_access_profile_assignment resources.
+3.  The constants used by the resources.
+*/
+
+const (
+	ResourceType           = "genesyscloud_access_profile"
+	AssignmentResourceType = "genesyscloud_access_profile_assignment"
+
+	subjectTypeUser  = "PC_USER"
+	subjectTypeGroup = "PC_GROUP"
+)
+
+// SetRegistrar registers all the resources in the package
+func SetRegistrar(l registrar.Registrar) {
+	l.RegisterResource(ResourceType, ResourceAccessProfile())
+	l.RegisterResource(AssignmentResourceType, ResourceAccessProfileAssignment())
+}
+
+var (
+	roleAssignmentResource = &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"role_id": {
+				Description: "Role ID.",
+				Type:        schema.TypeString,
+				Required:    true,
+			},
+			"division_ids": {
+				Description: "Division IDs the role is granted in. If not set, the home division will be used. '*' may be set for all divisions.",
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+		},
+	}
+
+	subjectGrantResource = &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"subject_type": {
+				Description: "Type of the subject (PC_USER | PC_GROUP).",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"subject_id": {
+				Description: "ID of the user or group.",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"role_id": {
+				Description: "Role ID.",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"division_id": {
+				Description: "Division ID.",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+		},
+	}
+)
+
+// ResourceAccessProfile registers the genesyscloud_access_profile resource with terraform
+func ResourceAccessProfile() *schema.Resource {
+	return &schema.Resource{
+		Description: "Genesys Cloud Access Profile. A named bundle of roles and their divisions, which is assigned to users, groups and teams with the genesyscloud_access_profile_assignment resource. " +
+			"Access profiles only exist in the Terraform state.",
+		CreateContext: provider.CreateWithPooledClient(createAccessProfile),
+		ReadContext:   provider.ReadWithPooledClient(readAccessProfile),
+		UpdateContext: provider.UpdateWithPooledClient(updateAccessProfile),
+		DeleteContext: provider.DeleteWithPooledClient(deleteAccessProfile),
+		SchemaVersion: 1,
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Description: "Name of the access profile.",
+				Type:        schema.TypeString,
+				Required:    true,
+			},
+			"description": {
+				Description: "Description of the access profile.",
+				Type:        schema.TypeString,
+				Optional:    true,
+			},
+			"roles": {
+				Description: "Roles and their divisions granted by the access profile.",
+				Type:        schema.TypeSet,
+				Required:    true,
+				Elem:        roleAssignmentResource,
+			},
+			"grants": {
+				Description: "The role and division pairs of the access profile, as `<role_id>:<division_id>`.",
+				Type:        schema.TypeSet,
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+		},
+		CustomizeDiff: customizeAccessProfileDiff,
+	}
+}
+
+// ResourceAccessProfileAssignment registers the genesyscloud_access_profile_assignment resource with terraform
+func ResourceAccessProfileAssignment() *schema.Resource {
+	return &schema.Resource{
+		Description: "Genesys Cloud Access Profile Assignment. Grants the union of the roles of the access profiles to users, groups and the members of teams. " +
+			"The assignment only removes the grants it added itself: grants of an access profile that a subject already had, for example through genesyscloud_user_roles, are reported in conflicts and left alone. " +
+			"A genesyscloud_user_roles or genesyscloud_group_roles resource managing the same subject removes the grants of the assignment, as it manages all the roles of its subject.",
+		CreateContext: provider.CreateWithPooledClient(createAccessProfileAssignment),
+		ReadContext:   provider.ReadWithPooledClient(readAccessProfileAssignment),
+		UpdateContext: provider.UpdateWithPooledClient(updateAccessProfileAssignment),
+		DeleteContext: provider.DeleteWithPooledClient(deleteAccessProfileAssignment),
+		SchemaVersion: 1,
+		Schema: map[string]*schema.Schema{
+			"access_profile": {
+				Description: "Access profiles to assign.",
+				Type:        schema.TypeSet,
+				Required:    true,
+				MinItems:    1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Description: "ID of the genesyscloud_access_profile.",
+							Type:        schema.TypeString,
+							Required:    true,
+						},
+						"grants": {
+							Description: "The grants of the access profile.",
+							Type:        schema.TypeSet,
+							Required:    true,
+							Elem: &schema.Schema{
+								Type:         schema.TypeString,
+								ValidateFunc: validation.StringMatch(grantRegex, "must be formatted as <role_id>:<division_id>"),
+							},
+						},
+					},
+				},
+			},
+			"user_ids": {
+				Description:  "IDs of the users the access profiles are assigned to.",
+				Type:         schema.TypeSet,
+				Optional:     true,
+				Elem:         &schema.Schema{Type: schema.TypeString},
+				AtLeastOneOf: []string{"user_ids", "group_ids", "team_ids"},
+			},
+			"group_ids": {
+				Description:  "IDs of the groups the access profiles are assigned to.",
+				Type:         schema.TypeSet,
+				Optional:     true,
+				Elem:         &schema.Schema{Type: schema.TypeString},
+				AtLeastOneOf: []string{"user_ids", "group_ids", "team_ids"},
+			},
+			"team_ids": {
+				Description:  "IDs of the teams whose members the access profiles are assigned to. Members who join or leave a team are reconciled on the next apply.",
+				Type:         schema.TypeSet,
+				Optional:     true,
+				Elem:         &schema.Schema{Type: schema.TypeString},
+				AtLeastOneOf: []string{"user_ids", "group_ids", "team_ids"},
+			},
+			"assigned_grants": {
+				Description: "Grants added by the assignment, which it removes when they are no longer assigned.",
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem:        subjectGrantResource,
+			},
+			"conflicts": {
+				Description: "Grants of the access profiles that the subjects already had from elsewhere. They are not managed by the assignment.",
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem:        subjectGrantResource,
+			},
+			"in_sync": {
+				Description: "Whether the subjects have all the grants of the access profiles and none of the grants the assignment no longer assigns.",
+				Type:        schema.TypeBool,
+				Computed:    true,
+			},
+		},
+		CustomizeDiff: customizeAccessProfileAssignmentDiff,
+	}
+}
@@ -0,0 +1,100 @@
+package access_profile
+
+import (
+	"fmt"
+	authRole "terraform-provider-genesyscloud/genesyscloud/auth_role"
+	"terraform-provider-genesyscloud/genesyscloud/provider"
+	"terraform-provider-genesyscloud/genesyscloud/user"
+	"terraform-provider-genesyscloud/genesyscloud/util"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccResourceAccessProfileAssignment(t *testing.T) {
+	var (
+		userResourceLabel1 = "test-user-1"
+		userResourceLabel2 = "test-user-2"
+		roleResourceLabel1 = "test-role-1"
+		roleResourceLabel2 = "test-role-2"
+		profileLabel       = "test-profile"
+		assignmentLabel    = "test-assignment"
+		assignmentPath     = AssignmentResourceType + "." + assignmentLabel
+	)
+
+	baseConfig := user.GenerateBasicUserResource(
+		userResourceLabel1,
+		"terraform-"+uuid.NewString()+"@example.com",
+		"Access Profile Terraform 1",
+	) + user.GenerateBasicUserResource(
+		userResourceLabel2,
+		"terraform-"+uuid.NewString()+"@example.com",
+		"Access Profile Terraform 2",
+	) + authRole.GenerateAuthRoleResource(
+		roleResourceLabel1,
+		"Terraform Access Profile Role 1 "+uuid.NewString(),
+		"Terraform access profile test",
+	) + authRole.GenerateAuthRoleResource(
+		roleResourceLabel2,
+		"Terraform Access Profile Role 2 "+uuid.NewString(),
+		"Terraform access profile test",
+	)
+	generateConfig := func(roleLabels ...string) string {
+		roles := ""
+		for _, roleLabel := range roleLabels {
+			roles += fmt.Sprintf(`
+	roles {
+		role_id = genesyscloud_auth_role.%s.id
+	}`, roleLabel)
+		}
+		return baseConfig + fmt.Sprintf(`resource "%s" "%s" {
+	name = "Terraform access profile"%s
+}
+
+resource "%s" "%s" {
+	access_profile {
+		id     = %s.%s.id
+		grants = %s.%s.grants
+	}
+	user_ids = [genesyscloud_user.%s.id, genesyscloud_user.%s.id]
+}
+`, ResourceType, profileLabel, roles, AssignmentResourceType, assignmentLabel, ResourceType, profileLabel, ResourceType, profileLabel, userResourceLabel1, userResourceLabel2)
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { util.TestAccPreCheck(t) },
+		ProviderFactories: provider.GetProviderFactories(providerResources, providerDataSources),
+		Steps: []resource.TestStep{
+			{
+				// Assign one role to two users
+				Config: generateConfig(roleResourceLabel1),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(ResourceType+"."+profileLabel, "grants.#", "1"),
+					resource.TestCheckResourceAttr(assignmentPath, "assigned_grants.#", "2"),
+					resource.TestCheckResourceAttr(assignmentPath, "conflicts.#", "0"),
+					resource.TestCheckResourceAttr(assignmentPath, "in_sync", "true"),
+					resource.TestCheckTypeSetElemAttrPair(assignmentPath, "assigned_grants.*.role_id", "genesyscloud_auth_role."+roleResourceLabel1, "id"),
+				),
+			},
+			{
+				// Replace the role
+				Config: generateConfig(roleResourceLabel2),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(assignmentPath, "assigned_grants.#", "2"),
+					resource.TestCheckResourceAttr(assignmentPath, "in_sync", "true"),
+					resource.TestCheckTypeSetElemAttrPair(assignmentPath, "assigned_grants.*.role_id", "genesyscloud_auth_role."+roleResourceLabel2, "id"),
+				),
+			},
+			{
+				// Add the first role back
+				Config: generateConfig(roleResourceLabel1, roleResourceLabel2),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(ResourceType+"."+profileLabel, "grants.#", "2"),
+					resource.TestCheckResourceAttr(assignmentPath, "assigned_grants.#", "4"),
+					resource.TestCheckResourceAttr(assignmentPath, "in_sync", "true"),
+				),
+			},
+		},
+	})
+}
@@ -0,0 +1,133 @@
+package access_profile
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"terraform-provider-genesyscloud/genesyscloud/provider"
+	"terraform-provider-genesyscloud/genesyscloud/util/lists"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/mypurecloud/platform-client-sdk-go/v149/platformclientv2"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUnitAccessProfileGrants(t *testing.T) {
+	internalProxy = &accessProfileProxy{
+		getHomeDivisionIdAttr: func(ctx context.Context, p *accessProfileProxy) (string, error) {
+			return "home", nil
+		},
+	}
+	defer func() { internalProxy = nil }()
+
+	d := schema.TestResourceDataRaw(t, ResourceAccessProfile().Schema, map[string]interface{}{
+		"name": "Supervisors",
+		"roles": []interface{}{
+			map[string]interface{}{"role_id": "supervisor", "division_ids": []interface{}{"div-1", "*"}},
+			map[string]interface{}{"role_id": "employee"},
+		},
+	})
+	diags := createAccessProfile(context.Background(), d, &provider.ProviderMeta{ClientConfig: &platformclientv2.Configuration{}})
+	assert.False(t, diags.HasError(), diags)
+	assert.NotEmpty(t, d.Id())
+
+	grants := *lists.SetToStringList(d.Get("grants").(*schema.Set))
+	sort.Strings(grants)
+	assert.Equal(t, []string{"employee:home", "supervisor:*", "supervisor:div-1"}, grants)
+}
+
+func TestUnitAccessProfileAssignment(t *testing.T) {
+	// Grants of each subject, as <role_id>:<division_id>
+	subjectGrants := map[string]map[string]bool{
+		"user-1":  {"role-a:div-1": true, "employee:home": true},
+		"user-2":  {},
+		"group-1": {},
+	}
+	added := make(map[string]string)
+	var removed []string
+
+	internalProxy = &accessProfileProxy{
+		getSubjectGrantsAttr: func(ctx context.Context, p *accessProfileProxy, subjectId string) (*[]platformclientv2.Authzgrant, *platformclientv2.APIResponse, error) {
+			pairs, ok := subjectGrants[subjectId]
+			if !ok {
+				return nil, &platformclientv2.APIResponse{StatusCode: http.StatusNotFound}, fmt.Errorf("subject %s not found", subjectId)
+			}
+			var grants []platformclientv2.Authzgrant
+			for pair := range pairs {
+				roleDivision := strings.SplitN(pair, ":", 2)
+				grants = append(grants, platformclientv2.Authzgrant{
+					SubjectId: platformclientv2.String(subjectId),
+					Role:      &platformclientv2.Authzgrantrole{Id: platformclientv2.String(roleDivision[0])},
+					Division:  &platformclientv2.Authzdivision{Id: platformclientv2.String(roleDivision[1])},
+				})
+			}
+			return &grants, &platformclientv2.APIResponse{StatusCode: http.StatusOK}, nil
+		},
+		addSubjectGrantsAttr: func(ctx context.Context, p *accessProfileProxy, subjectId string, subjectType string, grants platformclientv2.Roledivisiongrants) (*platformclientv2.APIResponse, error) {
+			added[subjectId] = subjectType
+			for _, grant := range *grants.Grants {
+				subjectGrants[subjectId][*grant.RoleId+":"+*grant.DivisionId] = true
+			}
+			return &platformclientv2.APIResponse{StatusCode: http.StatusOK}, nil
+		},
+		removeSubjectGrantAttr: func(ctx context.Context, p *accessProfileProxy, subjectId string, roleId string, divisionId string) (*platformclientv2.APIResponse, error) {
+			delete(subjectGrants[subjectId], roleId+":"+divisionId)
+			removed = append(removed, subjectId+" "+roleId+":"+divisionId)
+			return &platformclientv2.APIResponse{StatusCode: http.StatusOK}, nil
+		},
+		getTeamMemberIdsAttr: func(ctx context.Context, p *accessProfileProxy, teamId string) (*[]string, *platformclientv2.APIResponse, error) {
+			return &[]string{"user-1", "user-2"}, &platformclientv2.APIResponse{StatusCode: http.StatusOK}, nil
+		},
+	}
+	defer func() { internalProxy = nil }()
+	meta := &provider.ProviderMeta{ClientConfig: &platformclientv2.Configuration{}}
+
+	d := schema.TestResourceDataRaw(t, ResourceAccessProfileAssignment().Schema, map[string]interface{}{
+		"access_profile": []interface{}{
+			map[string]interface{}{"id": "agents", "grants": []interface{}{"role-a:div-1", "role-b:div-1"}},
+			map[string]interface{}{"id": "supervisors", "grants": []interface{}{"role-b:div-1", "role-c:*"}},
+		},
+		"user_ids":  []interface{}{"user-1"},
+		"team_ids":  []interface{}{"team-1"},
+		"group_ids": []interface{}{"group-1"},
+	})
+	diags := createAccessProfileAssignment(context.Background(), d, meta)
+	assert.False(t, diags.HasError(), diags)
+
+	// user-1 already had role-a, which is reported as a conflict and left alone
+	assert.Len(t, diags, 1)
+	assert.Contains(t, diags[0].Detail, "PC_USER user-1: role role-a in division div-1")
+	assert.Equal(t, map[string]string{"user-1": subjectTypeUser, "user-2": subjectTypeUser, "group-1": subjectTypeGroup}, added)
+	assert.Equal(t, map[string]bool{"role-a:div-1": true, "role-b:div-1": true, "role-c:*": true}, subjectGrants["group-1"])
+	assert.Len(t, d.Get("assigned_grants").([]interface{}), 8)
+	assert.Equal(t, []interface{}{
+		map[string]interface{}{"subject_type": subjectTypeUser, "subject_id": "user-1", "role_id": "role-a", "division_id": "div-1"},
+	}, d.Get("conflicts"))
+	assert.True(t, d.Get("in_sync").(bool))
+
+	// A grant removed by someone else is no longer managed, and the next apply adds it again
+	delete(subjectGrants["user-2"], "role-c:*")
+	diags = readAccessProfileAssignment(context.Background(), d, meta)
+	assert.False(t, diags.HasError(), diags)
+	assert.Len(t, d.Get("assigned_grants").([]interface{}), 7)
+	assert.False(t, d.Get("in_sync").(bool))
+
+	// Removing a profile and the group removes only the grants added by the assignment
+	assert.Nil(t, d.Set("access_profile", []interface{}{
+		map[string]interface{}{"id": "agents", "grants": []interface{}{"role-a:div-1", "role-b:div-1"}},
+	}))
+	assert.Nil(t, d.Set("group_ids", []interface{}{}))
+	plan, diags := buildAssignmentPlan(context.Background(), internalProxy, d, buildSubjectGrants(d.Get("assigned_grants").([]interface{})))
+	assert.False(t, diags.HasError(), diags)
+	assigned, diags := applyAssignmentPlan(context.Background(), internalProxy, plan)
+	assert.False(t, diags.HasError(), diags)
+
+	sort.Strings(removed)
+	assert.Equal(t, []string{"group-1 role-a:div-1", "group-1 role-b:div-1", "group-1 role-c:*", "user-1 role-c:*"}, removed)
+	assert.Equal(t, map[string]bool{"role-a:div-1": true, "role-b:div-1": true, "employee:home": true}, subjectGrants["user-1"])
+	assert.Equal(t, map[string]bool{"role-a:div-1": true, "role-b:div-1": true}, subjectGrants["user-2"])
+	assert.Len(t, assigned, 3)
+}
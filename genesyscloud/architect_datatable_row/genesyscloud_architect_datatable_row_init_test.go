@@ -24,6 +24,7 @@ func (r *registerTestInstance) registerTestResources() {
 	r.resourceMapMutex.Lock()
 	defer r.resourceMapMutex.Unlock()
 	providerResources[ResourceType] = ResourceArchitectDatatableRow()
+	providerResources[ResourceTypeRows] = ResourceArchitectDatatableRows()
 	providerResources[dt.ResourceType] = dt.ResourceArchitectDatatable()
 }
 
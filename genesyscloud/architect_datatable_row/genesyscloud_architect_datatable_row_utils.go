@@ -79,29 +79,7 @@ func customizeDatatableRowDiff(ctx context.Context, diff *schema.ResourceDiff, m
 	}
 
 	// For each property in the schema, check if a value is set in the config
-	if datatable.Schema != nil && datatable.Schema.Properties != nil {
-		for name, prop := range *datatable.Schema.Properties {
-			if name == "key" {
-				// Skip setting the key value
-				continue
-			}
-			if _, set := configMap[name]; !set {
-				// Property in schema not set. Override diff with expected default.
-				if prop.Default != nil {
-					configMap[name] = *prop.Default
-				} else if *prop.VarType == "boolean" {
-					// Booleans default to false
-					configMap[name] = false
-				} else if *prop.VarType == "string" {
-					// Strings default to empty
-					configMap[name] = ""
-				} else if *prop.VarType == "integer" || *prop.VarType == "number" {
-					// Numbers default to 0
-					configMap[name] = 0
-				}
-			}
-		}
-	}
+	setDatatableRowDefaults(configMap, datatable)
 
 	// Marshal back to string and set as the diff value
 	result, err := json.Marshal(configMap)
@@ -113,6 +91,47 @@ func customizeDatatableRowDiff(ctx context.Context, diff *schema.ResourceDiff, m
 	return nil
 }
 
+// setDatatableRowDefaults sets the default value of every property of the datatable schema missing from a row
+func setDatatableRowDefaults(row map[string]interface{}, datatable *Datatable) {
+	if datatable.Schema == nil || datatable.Schema.Properties == nil {
+		return
+	}
+	for name, prop := range *datatable.Schema.Properties {
+		if name == "key" {
+			// Skip setting the key value
+			continue
+		}
+		if _, set := row[name]; !set {
+			// Property in schema not set. Use the expected default.
+			if defaultValue, ok := datatablePropertyDefault(prop); ok {
+				row[name] = defaultValue
+			}
+		}
+	}
+}
+
+// datatablePropertyDefault returns the value the API uses for a datatable property which is not set
+func datatablePropertyDefault(prop Datatableproperty) (interface{}, bool) {
+	if prop.Default != nil {
+		return *prop.Default, true
+	}
+	if prop.VarType == nil {
+		return nil, false
+	}
+	switch *prop.VarType {
+	case "boolean":
+		// Booleans default to false
+		return false, true
+	case "string":
+		// Strings default to empty
+		return "", true
+	case "integer", "number":
+		// Numbers default to 0
+		return 0, true
+	}
+	return nil, false
+}
+
 // Prevent getting the architect_datatable schema on every row diff
 // by caching the results for the duration of the TF run
 var archDatatableCache sync.Map
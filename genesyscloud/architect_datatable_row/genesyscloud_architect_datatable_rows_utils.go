@@ -0,0 +1,437 @@
+package architect_datatable_row
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/csv"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"terraform-provider-genesyscloud/genesyscloud/util"
+	"terraform-provider-genesyscloud/genesyscloud/util/chunks"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+)
+
+/*
+The genesyscloud_architect_datatable_rows_utils.go file contains the helpers used by the genesyscloud_architect_datatable_rows
+resource to parse the rows file, validate it against the datatable schema and apply the differences with the rows of the datatable.
+*/
+
+const (
+	rowsFormatCsv  = "csv"
+	rowsFormatJson = "json"
+
+	rowChangeCreate = "create"
+	rowChangeUpdate = "update"
+	rowChangeDelete = "delete"
+
+	// maxReportedRowErrors limits the number of row errors returned when the rows file fails validation
+	maxReportedRowErrors = 20
+)
+
+// datatableRowChange is a single row change to apply to a datatable
+type datatableRowChange struct {
+	action string
+	key    string
+	row    map[string]interface{}
+}
+
+// datatableRowRecord is a row read from the rows file, before its values are validated against the datatable schema
+type datatableRowRecord struct {
+	row    int
+	values map[string]interface{}
+}
+
+// getRowsFileFormat returns the format of the rows file, inferred from the file extension when no format is configured
+func getRowsFileFormat(filePath string, format string) (string, error) {
+	if format != "" {
+		return format, nil
+	}
+	switch strings.ToLower(filepath.Ext(filePath)) {
+	case ".csv":
+		return rowsFormatCsv, nil
+	case ".json":
+		return rowsFormatJson, nil
+	}
+	return "", fmt.Errorf("unable to infer the format of rows file %s from its extension. Set format to %s or %s", filePath, rowsFormatCsv, rowsFormatJson)
+}
+
+// readDatatableRowsFile parses the rows file and validates each row against the datatable schema. The rows are returned by key with
+// defaults set on missing properties.
+func readDatatableRowsFile(r io.Reader, format string, datatable *Datatable) (map[string]map[string]interface{}, error) {
+	var (
+		records []datatableRowRecord
+		err     error
+	)
+	switch format {
+	case rowsFormatCsv:
+		records, err = parseDatatableRowsCsv(r, datatable)
+	case rowsFormatJson:
+		records, err = parseDatatableRowsJson(r)
+	default:
+		err = fmt.Errorf("unsupported rows file format %s", format)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return buildDatatableRows(records, datatable)
+}
+
+// parseDatatableRowsCsv reads the rows of a CSV file. The header row may use either the name or the title of each datatable property.
+func parseDatatableRowsCsv(r io.Reader, datatable *Datatable) ([]datatableRowRecord, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+
+	header, err := reader.Read()
+	if err == io.EOF {
+		return nil, fmt.Errorf("the rows file is empty")
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	properties := datatableProperties(datatable)
+	columns := make([]string, len(header))
+	for i, column := range header {
+		column = strings.TrimSpace(strings.TrimPrefix(column, "\ufeff"))
+		columns[i] = column
+		for name, prop := range properties {
+			if prop.Title != nil && *prop.Title == column {
+				columns[i] = name
+				break
+			}
+		}
+	}
+
+	var records []datatableRowRecord
+	for rowNum := 2; ; rowNum++ {
+		values, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("row %d: %w", rowNum, err)
+		}
+		if len(values) != len(columns) {
+			return nil, fmt.Errorf("row %d: expected %d values, found %d", rowNum, len(columns), len(values))
+		}
+
+		record := datatableRowRecord{row: rowNum, values: make(map[string]interface{}, len(columns))}
+		for i, column := range columns {
+			record.values[column] = values[i]
+		}
+		records = append(records, record)
+	}
+	return records, nil
+}
+
+// parseDatatableRowsJson reads the rows of a JSON file holding an array of row objects
+func parseDatatableRowsJson(r io.Reader) ([]datatableRowRecord, error) {
+	var rows []map[string]interface{}
+	if err := json.NewDecoder(r).Decode(&rows); err != nil {
+		if err == io.EOF {
+			return nil, fmt.Errorf("the rows file is empty")
+		}
+		return nil, fmt.Errorf("the rows file must contain an array of row objects: %w", err)
+	}
+
+	records := make([]datatableRowRecord, len(rows))
+	for i, row := range rows {
+		records[i] = datatableRowRecord{row: i + 1, values: row}
+	}
+	return records, nil
+}
+
+// buildDatatableRows converts the values of each record to the type of its datatable property and sets the defaults of missing properties
+func buildDatatableRows(records []datatableRowRecord, datatable *Datatable) (map[string]map[string]interface{}, error) {
+	properties := datatableProperties(datatable)
+	rows := make(map[string]map[string]interface{}, len(records))
+	keyRows := make(map[string]int, len(records))
+
+	var errs []string
+	for _, record := range records {
+		columns := make([]string, 0, len(record.values))
+		for column := range record.values {
+			columns = append(columns, column)
+		}
+		sort.Strings(columns)
+
+		row := make(map[string]interface{}, len(properties))
+		for _, column := range columns {
+			value := record.values[column]
+			prop, ok := properties[column]
+			if !ok {
+				errs = append(errs, fmt.Sprintf("row %d: column '%s' is not a property of the datatable", record.row, column))
+				continue
+			}
+			converted, set, err := convertDatatableValue(prop, value)
+			if err != nil {
+				errs = append(errs, fmt.Sprintf("row %d, column '%s': %s", record.row, column, err))
+				continue
+			}
+			if set {
+				row[column] = converted
+			}
+		}
+
+		key, _ := row["key"].(string)
+		if key == "" {
+			errs = append(errs, fmt.Sprintf("row %d: the key value is missing", record.row))
+			continue
+		}
+		if firstRow, exists := keyRows[key]; exists {
+			errs = append(errs, fmt.Sprintf("row %d: key '%s' is a duplicate of row %d", record.row, key, firstRow))
+			continue
+		}
+		keyRows[key] = record.row
+
+		setDatatableRowDefaults(row, datatable)
+		rows[key] = row
+	}
+
+	if len(errs) > 0 {
+		if len(errs) > maxReportedRowErrors {
+			errs = append(errs[:maxReportedRowErrors], fmt.Sprintf("... and %d more", len(errs)-maxReportedRowErrors))
+		}
+		return nil, errors.New(strings.Join(errs, "\n"))
+	}
+	return rows, nil
+}
+
+// convertDatatableValue converts a value of the rows file to the type of the datatable property. CSV values are strings while JSON
+// values are already typed. Empty and null values are not set, so the property default is used.
+func convertDatatableValue(prop Datatableproperty, value interface{}) (interface{}, bool, error) {
+	propType := ""
+	if prop.VarType != nil {
+		propType = *prop.VarType
+	}
+
+	switch v := value.(type) {
+	case nil:
+		return nil, false, nil
+	case string:
+		if v == "" {
+			return nil, false, nil
+		}
+		if propType == "string" {
+			return v, true, nil
+		}
+		v = strings.TrimSpace(v)
+		switch propType {
+		case "boolean":
+			b, err := strconv.ParseBool(v)
+			if err != nil {
+				return nil, false, fmt.Errorf("'%s' is not a valid boolean", v)
+			}
+			return b, true, nil
+		case "integer":
+			i, err := strconv.ParseInt(v, 10, 64)
+			if err != nil {
+				return nil, false, fmt.Errorf("'%s' is not a valid integer", v)
+			}
+			return i, true, nil
+		case "number":
+			f, err := strconv.ParseFloat(v, 64)
+			if err != nil {
+				return nil, false, fmt.Errorf("'%s' is not a valid number", v)
+			}
+			return f, true, nil
+		}
+	case bool:
+		if propType == "boolean" {
+			return v, true, nil
+		}
+	case float64:
+		switch propType {
+		case "integer":
+			if v != math.Trunc(v) {
+				return nil, false, fmt.Errorf("%v is not a valid integer", v)
+			}
+			return int64(v), true, nil
+		case "number":
+			return v, true, nil
+		}
+	}
+	return nil, false, fmt.Errorf("%v is not a valid %s", value, propType)
+}
+
+// datatableProperties returns the properties of the datatable schema by name
+func datatableProperties(datatable *Datatable) map[string]Datatableproperty {
+	if datatable == nil || datatable.Schema == nil || datatable.Schema.Properties == nil {
+		return map[string]Datatableproperty{}
+	}
+	return *datatable.Schema.Properties
+}
+
+// diffDatatableRows returns the changes to apply to the current rows of the datatable to match the desired rows. Rows which are not
+// desired are deleted when authoritative is true, otherwise only the previously managed keys are deleted.
+func diffDatatableRows(desired map[string]map[string]interface{}, current []map[string]interface{}, managedKeys []string, authoritative bool) []datatableRowChange {
+	var changes []datatableRowChange
+
+	currentRows := make(map[string]map[string]interface{}, len(current))
+	for _, row := range current {
+		if key, ok := row["key"].(string); ok {
+			currentRows[key] = row
+		}
+	}
+
+	for _, key := range sortedRowKeys(desired) {
+		row := desired[key]
+		currentRow, exists := currentRows[key]
+		if !exists {
+			changes = append(changes, datatableRowChange{action: rowChangeCreate, key: key, row: row})
+		} else if !datatableRowsEqual(row, currentRow) {
+			changes = append(changes, datatableRowChange{action: rowChangeUpdate, key: key, row: row})
+		}
+	}
+
+	deleteKeys := managedKeys
+	if authoritative {
+		deleteKeys = make([]string, 0, len(currentRows))
+		for key := range currentRows {
+			deleteKeys = append(deleteKeys, key)
+		}
+		sort.Strings(deleteKeys)
+	}
+	for _, key := range deleteKeys {
+		if _, isDesired := desired[key]; isDesired {
+			continue
+		}
+		if _, exists := currentRows[key]; exists {
+			changes = append(changes, datatableRowChange{action: rowChangeDelete, key: key})
+		}
+	}
+	return changes
+}
+
+// applyDatatableRowChanges applies the row changes to the datatable. The changes of a batch are applied concurrently and the next
+// batch is only started once the previous batch succeeded.
+func applyDatatableRowChanges(ctx context.Context, proxy *architectDatatableRowProxy, tableId string, changes []datatableRowChange, batchSize int) diag.Diagnostics {
+	if len(changes) == 0 {
+		return nil
+	}
+
+	return chunks.ProcessChunks(chunks.ChunkBy(changes, batchSize), func(batch []datatableRowChange) diag.Diagnostics {
+		var (
+			wg   sync.WaitGroup
+			mu   sync.Mutex
+			errs []string
+		)
+		for _, change := range batch {
+			wg.Add(1)
+			go func(change datatableRowChange) {
+				defer wg.Done()
+				if err := applyDatatableRowChange(ctx, proxy, tableId, change); err != nil {
+					mu.Lock()
+					errs = append(errs, err.Error())
+					mu.Unlock()
+				}
+			}(change)
+		}
+		wg.Wait()
+
+		if len(errs) > 0 {
+			sort.Strings(errs)
+			return util.BuildDiagnosticError(ResourceTypeRows, fmt.Sprintf("failed to apply %d row changes to datatable %s", len(errs), tableId), errors.New(strings.Join(errs, "\n")))
+		}
+		return nil
+	})
+}
+
+func applyDatatableRowChange(ctx context.Context, proxy *architectDatatableRowProxy, tableId string, change datatableRowChange) error {
+	switch change.action {
+	case rowChangeCreate:
+		if _, _, err := proxy.createArchitectDatatableRow(ctx, tableId, &change.row); err != nil {
+			return fmt.Errorf("failed to create row %s: %s", change.key, err)
+		}
+	case rowChangeUpdate:
+		if _, _, err := proxy.updateArchitectDatatableRow(ctx, tableId, change.key, &change.row); err != nil {
+			return fmt.Errorf("failed to update row %s: %s", change.key, err)
+		}
+	case rowChangeDelete:
+		if resp, err := proxy.deleteArchitectDatatableRow(ctx, tableId, change.key); err != nil && !util.IsStatus404(resp) {
+			return fmt.Errorf("failed to delete row %s: %s", change.key, err)
+		}
+	}
+	return nil
+}
+
+// datatableRowsEqual compares two rows after normalizing their values through JSON so numbers of different Go types are equal
+func datatableRowsEqual(a, b map[string]interface{}) bool {
+	return reflect.DeepEqual(normalizeDatatableRow(a), normalizeDatatableRow(b))
+}
+
+func normalizeDatatableRow(row map[string]interface{}) interface{} {
+	var normalized interface{}
+	rowBytes, err := json.Marshal(row)
+	if err != nil {
+		return row
+	}
+	if err := json.Unmarshal(rowBytes, &normalized); err != nil {
+		return row
+	}
+	return normalized
+}
+
+// hashDatatableRows returns a hash of the rows which is stable regardless of the order of the rows and of their properties
+func hashDatatableRows(rows map[string]map[string]interface{}) string {
+	hash := sha256.New()
+	for _, key := range sortedRowKeys(rows) {
+		// Maps are marshalled with sorted keys
+		rowBytes, _ := json.Marshal(normalizeDatatableRow(rows[key]))
+		hash.Write(rowBytes)
+		hash.Write([]byte("\n"))
+	}
+	return hex.EncodeToString(hash.Sum(nil))
+}
+
+// rowsByKey returns the rows of the datatable by key. When keys is not nil only the rows of the given keys are returned.
+func rowsByKey(rows []map[string]interface{}, keys []string) map[string]map[string]interface{} {
+	var keySet map[string]bool
+	if keys != nil {
+		keySet = make(map[string]bool, len(keys))
+		for _, key := range keys {
+			keySet[key] = true
+		}
+	}
+
+	result := make(map[string]map[string]interface{}, len(rows))
+	for _, row := range rows {
+		key, ok := row["key"].(string)
+		if !ok || (keySet != nil && !keySet[key]) {
+			continue
+		}
+		result[key] = row
+	}
+	return result
+}
+
+func sortedRowKeys(rows map[string]map[string]interface{}) []string {
+	keys := make([]string, 0, len(rows))
+	for key := range rows {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// GenerateArchitectDatatableRows returns a genesyscloud_architect_datatable_rows resource block
+func GenerateArchitectDatatableRows(resourceLabel string, datatableId string, filePath string, authoritative string) string {
+	return fmt.Sprintf(`resource "%s" "%s" {
+	datatable_id      = %s
+	filepath          = "%s"
+	file_content_hash = filesha256("%s")
+	authoritative     = %s
+}
+`, ResourceTypeRows, resourceLabel, datatableId, filePath, filePath, authoritative)
+}
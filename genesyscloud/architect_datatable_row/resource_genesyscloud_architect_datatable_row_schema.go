@@ -14,6 +14,7 @@ const ResourceType = "genesyscloud_architect_datatable_row"
 // SetRegistrar registers all of the resources, datasources and exporters in the package
 func SetRegistrar(regInstance registrar.Registrar) {
 	regInstance.RegisterResource(ResourceType, ResourceArchitectDatatableRow())
+	regInstance.RegisterResource(ResourceTypeRows, ResourceArchitectDatatableRows())
 	//No Datasource defined
 	regInstance.RegisterExporter(ResourceType, ArchitectDatatableRowExporter())
 }
@@ -0,0 +1,164 @@
+package architect_datatable_row
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+	"terraform-provider-genesyscloud/genesyscloud/provider"
+	"terraform-provider-genesyscloud/genesyscloud/util"
+	"terraform-provider-genesyscloud/genesyscloud/util/lists"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/retry"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func createArchitectDatatableRows(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	sdkConfig := meta.(*provider.ProviderMeta).ClientConfig
+	archProxy := getArchitectDatatableRowProxy(sdkConfig)
+	tableId := d.Get("datatable_id").(string)
+
+	log.Printf("Syncing rows of datatable %s", tableId)
+	if diagErr := syncDatatableRows(ctx, d, archProxy); diagErr != nil {
+		return diagErr
+	}
+
+	d.SetId(tableId)
+	log.Printf("Synced rows of datatable %s", tableId)
+	return readArchitectDatatableRows(ctx, d, meta)
+}
+
+func readArchitectDatatableRows(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	sdkConfig := meta.(*provider.ProviderMeta).ClientConfig
+	archProxy := getArchitectDatatableRowProxy(sdkConfig)
+
+	log.Printf("Reading rows of datatable %s", d.Id())
+
+	return util.WithRetriesForRead(ctx, d, func() *retry.RetryError {
+		rows, resp, err := archProxy.getAllArchitectDatatableRows(ctx, d.Id())
+		if err != nil {
+			if util.IsStatus404(resp) {
+				return retry.RetryableError(util.BuildWithRetriesApiDiagnosticError(ResourceTypeRows, fmt.Sprintf("Failed to read rows of datatable %s | error: %s", d.Id(), err), resp))
+			}
+			return retry.NonRetryableError(util.BuildWithRetriesApiDiagnosticError(ResourceTypeRows, fmt.Sprintf("Failed to read rows of datatable %s | error: %s", d.Id(), err), resp))
+		}
+
+		_ = d.Set("datatable_id", d.Id())
+		flattenManagedDatatableRows(d, *rows)
+
+		log.Printf("Read rows of datatable %s", d.Id())
+		return nil
+	})
+}
+
+// flattenManagedDatatableRows sets the number of rows managed by the resource, and clears file_content_hash when the
+// managed rows changed since the last sync
+func flattenManagedDatatableRows(d *schema.ResourceData, rows []map[string]interface{}) {
+	var managedKeys []string
+	if !d.Get("authoritative").(bool) {
+		managedKeys = lists.InterfaceListToStrings(d.Get("row_keys").(*schema.Set).List())
+	}
+	managedRows := rowsByKey(rows, managedKeys)
+
+	// Rows changed, added or removed outside of Terraform are synced again from the file on the next apply
+	rowsHash := hashDatatableRows(managedRows)
+	if syncedHash := d.Get("rows_hash").(string); syncedHash != "" && syncedHash != rowsHash {
+		log.Printf("Rows of datatable %s changed since the last sync", d.Id())
+		_ = d.Set("file_content_hash", "")
+	}
+	_ = d.Set("row_count", len(managedRows))
+}
+
+func updateArchitectDatatableRows(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	sdkConfig := meta.(*provider.ProviderMeta).ClientConfig
+	archProxy := getArchitectDatatableRowProxy(sdkConfig)
+
+	if !d.HasChanges("filepath", "file_content_hash", "format", "authoritative") {
+		return readArchitectDatatableRows(ctx, d, meta)
+	}
+
+	log.Printf("Syncing rows of datatable %s", d.Id())
+	if diagErr := syncDatatableRows(ctx, d, archProxy); diagErr != nil {
+		// Clear the hash so the sync is attempted again on the next apply
+		_ = d.Set("file_content_hash", nil)
+		return diagErr
+	}
+
+	log.Printf("Synced rows of datatable %s", d.Id())
+	return readArchitectDatatableRows(ctx, d, meta)
+}
+
+func deleteArchitectDatatableRows(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	sdkConfig := meta.(*provider.ProviderMeta).ClientConfig
+	archProxy := getArchitectDatatableRowProxy(sdkConfig)
+
+	rowKeys := lists.InterfaceListToStrings(d.Get("row_keys").(*schema.Set).List())
+	changes := make([]datatableRowChange, len(rowKeys))
+	for i, key := range rowKeys {
+		changes[i] = datatableRowChange{action: rowChangeDelete, key: key}
+	}
+
+	log.Printf("Deleting %d rows of datatable %s", len(changes), d.Id())
+	if diagErr := applyDatatableRowChanges(ctx, archProxy, d.Id(), changes, d.Get("batch_size").(int)); diagErr != nil {
+		return diagErr
+	}
+	log.Printf("Deleted %d rows of datatable %s", len(changes), d.Id())
+	return nil
+}
+
+// syncDatatableRows validates the rows file against the datatable schema and applies the differences with the current rows of the datatable
+func syncDatatableRows(ctx context.Context, d *schema.ResourceData, archProxy *architectDatatableRowProxy) diag.Diagnostics {
+	tableId := d.Get("datatable_id").(string)
+	filePath := d.Get("filepath").(string)
+	authoritative := d.Get("authoritative").(bool)
+	managedKeys := lists.InterfaceListToStrings(d.Get("row_keys").(*schema.Set).List())
+
+	format, err := getRowsFileFormat(filePath, d.Get("format").(string))
+	if err != nil {
+		return util.BuildDiagnosticError(ResourceTypeRows, fmt.Sprintf("Invalid rows file %s", filePath), err)
+	}
+
+	datatable, resp, err := archProxy.getArchitectDatatable(ctx, tableId, "schema")
+	if err != nil {
+		return util.BuildAPIDiagnosticError(ResourceTypeRows, fmt.Sprintf("Failed to read datatable %s error: %s", tableId, err), resp)
+	}
+
+	file, err := os.Open(filePath)
+	if err != nil {
+		return util.BuildDiagnosticError(ResourceTypeRows, fmt.Sprintf("Failed to open rows file %s", filePath), err)
+	}
+	defer file.Close()
+
+	desiredRows, err := readDatatableRowsFile(file, format, datatable)
+	if err != nil {
+		return util.BuildDiagnosticError(ResourceTypeRows, fmt.Sprintf("Rows file %s failed validation against datatable %s", filePath, tableId), err)
+	}
+
+	currentRows, resp, err := archProxy.getAllArchitectDatatableRows(ctx, tableId)
+	if err != nil {
+		return util.BuildAPIDiagnosticError(ResourceTypeRows, fmt.Sprintf("Failed to read rows of datatable %s error: %s", tableId, err), resp)
+	}
+
+	changes := diffDatatableRows(desiredRows, *currentRows, managedKeys, authoritative)
+	log.Printf("Applying %d row changes to datatable %s", len(changes), tableId)
+	if diagErr := applyDatatableRowChanges(ctx, archProxy, tableId, changes, d.Get("batch_size").(int)); diagErr != nil {
+		return diagErr
+	}
+
+	// The hash is computed from the rows read back, as the datatable may store the values differently than the rows file
+	// (e.g. number types, defaults and null columns) and the read compares the hash with the rows of the datatable
+	syncedRows, resp, err := archProxy.getAllArchitectDatatableRows(ctx, tableId)
+	if err != nil {
+		return util.BuildAPIDiagnosticError(ResourceTypeRows, fmt.Sprintf("Failed to read rows of datatable %s error: %s", tableId, err), resp)
+	}
+	var hashedKeys []string
+	if !authoritative {
+		hashedKeys = sortedRowKeys(desiredRows)
+	}
+
+	_ = d.Set("row_keys", sortedRowKeys(desiredRows))
+	_ = d.Set("row_count", len(desiredRows))
+	_ = d.Set("rows_hash", hashDatatableRows(rowsByKey(*syncedRows, hashedKeys)))
+	return nil
+}
@@ -0,0 +1,86 @@
+package architect_datatable_row
+
+import (
+	"terraform-provider-genesyscloud/genesyscloud/provider"
+	"terraform-provider-genesyscloud/genesyscloud/validators"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+const ResourceTypeRows = "genesyscloud_architect_datatable_rows"
+
+func ResourceArchitectDatatableRows() *schema.Resource {
+	return &schema.Resource{
+		Description: `Genesys Cloud Architect Datatable Rows. Syncs the rows of a datatable from a CSV or JSON file keyed by the key property of the datatable.
+When authoritative is false only the rows of the file are added, updated and removed. When authoritative is true any row of the datatable not in the file is removed.
+Do not manage the same rows with genesyscloud_architect_datatable_row.`,
+
+		CreateContext: provider.CreateWithPooledClient(createArchitectDatatableRows),
+		ReadContext:   provider.ReadWithPooledClient(readArchitectDatatableRows),
+		UpdateContext: provider.UpdateWithPooledClient(updateArchitectDatatableRows),
+		DeleteContext: provider.DeleteWithPooledClient(deleteArchitectDatatableRows),
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(20 * time.Minute),
+			Update: schema.DefaultTimeout(20 * time.Minute),
+			Delete: schema.DefaultTimeout(20 * time.Minute),
+		},
+		SchemaVersion: 1,
+		Schema: map[string]*schema.Schema{
+			"datatable_id": {
+				Description: "ID of the datatable the rows are synced to.",
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+			},
+			"filepath": {
+				Description: `Path to the CSV or JSON file of rows. The first row of a CSV file must contain the property names or titles and empty values are set to the property default.
+A JSON file must contain an array of row objects keyed by property name.`,
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validators.ValidatePath,
+			},
+			"file_content_hash": {
+				Description: "Hash value of the rows file content. Used to detect changes.",
+				Type:        schema.TypeString,
+				Required:    true,
+			},
+			"format": {
+				Description:  "Format of the rows file. If not set, the format is inferred from the file extension.",
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.StringInSlice([]string{rowsFormatCsv, rowsFormatJson}, false),
+			},
+			"authoritative": {
+				Description: "If true, rows of the datatable which are not in the file are removed. If false, only the rows of the file are managed.",
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+			},
+			"batch_size": {
+				Description:  "Number of row changes applied concurrently.",
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      10,
+				ValidateFunc: validation.IntBetween(1, 50),
+			},
+			"row_keys": {
+				Description: "Keys of the rows managed by this resource.",
+				Type:        schema.TypeSet,
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			"row_count": {
+				Description: "Number of rows managed by this resource.",
+				Type:        schema.TypeInt,
+				Computed:    true,
+			},
+			"rows_hash": {
+				Description: "Hash of the synced rows. Changes made to the managed rows outside of Terraform are reverted on the next apply.",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+		},
+	}
+}
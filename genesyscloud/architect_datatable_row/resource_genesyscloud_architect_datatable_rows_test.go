@@ -0,0 +1,99 @@
+package architect_datatable_row
+
+import (
+	"fmt"
+	"path/filepath"
+	"strconv"
+	"terraform-provider-genesyscloud/genesyscloud/provider"
+	"terraform-provider-genesyscloud/genesyscloud/util"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/mypurecloud/platform-client-sdk-go/v149/platformclientv2"
+)
+
+func getTestDataPath(elem ...string) string {
+	basePath := filepath.Join("..", "..", "test", "data")
+	subPath := filepath.Join(elem...)
+	return filepath.Join(basePath, subPath)
+}
+
+func TestAccResourceArchitectDatatableRows(t *testing.T) {
+	var (
+		tableResourceLabel = "arch-table"
+		tableName          = "Terraform Table Rows-" + uuid.NewString()
+		tableId            = "genesyscloud_architect_datatable." + tableResourceLabel + ".id"
+		rowsResourceLabel  = "table-rows"
+		fullResourceLabel  = ResourceTypeRows + "." + rowsResourceLabel
+
+		filePath        = getTestDataPath("resource", ResourceTypeRows, "rows.csv")
+		filePathUpdated = getTestDataPath("resource", ResourceTypeRows, "rows_updated.json")
+
+		tableConfig = generateArchitectDatatableResource(
+			tableResourceLabel,
+			tableName,
+			util.NullValue,
+			generateArchitectDatatableProperty("key", "string", strconv.Quote("Country"), util.NullValue),
+			generateArchitectDatatableProperty("code", "integer", strconv.Quote("Dialing Code"), util.NullValue),
+			generateArchitectDatatableProperty("active", "boolean", strconv.Quote("Active"), util.NullValue),
+			generateArchitectDatatableProperty("score", "number", strconv.Quote("Score"), util.NullValue),
+		)
+	)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { util.TestAccPreCheck(t) },
+		ProviderFactories: provider.GetProviderFactories(providerResources, nil),
+		Steps: []resource.TestStep{
+			{
+				Config: tableConfig + GenerateArchitectDatatableRows(rowsResourceLabel, tableId, filePath, util.FalseValue),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(fullResourceLabel, "row_count", "3"),
+					resource.TestCheckTypeSetElemAttr(fullResourceLabel, "row_keys.*", "ie"),
+					resource.TestCheckTypeSetElemAttr(fullResourceLabel, "row_keys.*", "us"),
+					resource.TestCheckTypeSetElemAttr(fullResourceLabel, "row_keys.*", "fr"),
+					testVerifyDatatableRowValue(tableResourceLabel, "us", "score", float64(0)),
+				),
+			},
+			{
+				// Rows removed from the file are deleted from the datatable
+				Config: tableConfig + GenerateArchitectDatatableRows(rowsResourceLabel, tableId, filePathUpdated, util.TrueValue),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(fullResourceLabel, "row_count", "2"),
+					resource.TestCheckTypeSetElemAttr(fullResourceLabel, "row_keys.*", "ie"),
+					resource.TestCheckTypeSetElemAttr(fullResourceLabel, "row_keys.*", "de"),
+					testVerifyDatatableRowValue(tableResourceLabel, "ie", "score", float64(9)),
+					testVerifyDatatableRowValue(tableResourceLabel, "fr", "", nil),
+				),
+			},
+		},
+		CheckDestroy: testVerifyDatatableRowsDestroyed,
+	})
+}
+
+// testVerifyDatatableRowValue checks the value of a row property. If property is empty, the row is expected to not exist.
+func testVerifyDatatableRowValue(tableResourceLabel string, key string, property string, expected interface{}) resource.TestCheckFunc {
+	return func(state *terraform.State) error {
+		tableRes, ok := state.RootModule().Resources["genesyscloud_architect_datatable."+tableResourceLabel]
+		if !ok {
+			return fmt.Errorf("failed to find table resource %s in state", tableResourceLabel)
+		}
+
+		archAPI := platformclientv2.NewArchitectApi()
+		row, resp, err := archAPI.GetFlowsDatatableRow(tableRes.Primary.ID, key, false)
+		if property == "" {
+			if util.IsStatus404(resp) {
+				return nil
+			}
+			return fmt.Errorf("row %s still exists", key)
+		}
+		if err != nil {
+			return fmt.Errorf("failed to read row %s: %s", key, err)
+		}
+		if (*row)[property] != expected {
+			return fmt.Errorf("row %s property %s is %v, expected %v", key, property, (*row)[property], expected)
+		}
+		return nil
+	}
+}
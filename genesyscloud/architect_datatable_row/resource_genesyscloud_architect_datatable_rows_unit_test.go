@@ -0,0 +1,315 @@
+package architect_datatable_row
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/mypurecloud/platform-client-sdk-go/v149/platformclientv2"
+	"github.com/stretchr/testify/assert"
+)
+
+func generateTestDatatable(id string) *Datatable {
+	var defaultScore interface{} = float64(50)
+	return &Datatable{
+		Id: &id,
+		Schema: &Jsonschemadocument{
+			Properties: &map[string]Datatableproperty{
+				"key":    {VarType: platformclientv2.String("string"), Title: platformclientv2.String("Country")},
+				"code":   {VarType: platformclientv2.String("integer"), Title: platformclientv2.String("Dialing Code")},
+				"active": {VarType: platformclientv2.String("boolean"), Title: platformclientv2.String("Active")},
+				"score":  {VarType: platformclientv2.String("number"), Title: platformclientv2.String("Score"), Default: &defaultScore},
+				"name":   {VarType: platformclientv2.String("string"), Title: platformclientv2.String("Name")},
+			},
+		},
+	}
+}
+
+func TestUnitReadDatatableRowsCsv(t *testing.T) {
+	datatable := generateTestDatatable(uuid.NewString())
+	file := "\ufeffCountry,Dialing Code,active,Score,name\n" +
+		"ie,353,true,7.5,Ireland\n" +
+		"us,1,,,\n"
+
+	rows, err := readDatatableRowsFile(strings.NewReader(file), rowsFormatCsv, datatable)
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]map[string]interface{}{
+		"ie": {"key": "ie", "code": int64(353), "active": true, "score": 7.5, "name": "Ireland"},
+		"us": {"key": "us", "code": int64(1), "active": false, "score": float64(50), "name": ""},
+	}, rows)
+}
+
+func TestUnitReadDatatableRowsJson(t *testing.T) {
+	datatable := generateTestDatatable(uuid.NewString())
+	file := `[{"key": "ie", "code": 353, "active": true, "name": "Ireland"}, {"key": "us", "score": null}]`
+
+	rows, err := readDatatableRowsFile(strings.NewReader(file), rowsFormatJson, datatable)
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{"key": "ie", "code": int64(353), "active": true, "score": float64(50), "name": "Ireland"}, rows["ie"])
+	assert.Equal(t, map[string]interface{}{"key": "us", "code": 0, "active": false, "score": float64(50), "name": ""}, rows["us"])
+
+	_, err = readDatatableRowsFile(strings.NewReader(`{"key": "ie"}`), rowsFormatJson, datatable)
+	assert.ErrorContains(t, err, "the rows file must contain an array of row objects")
+}
+
+func TestUnitReadDatatableRowsValidation(t *testing.T) {
+	datatable := generateTestDatatable(uuid.NewString())
+	file := "key,code,active,score,extra\n" +
+		"ie,3.5,yes,high,x\n" +
+		",1,true,1,\n" +
+		"ie,1,true,1,\n"
+
+	_, err := readDatatableRowsFile(strings.NewReader(file), rowsFormatCsv, datatable)
+	assert.EqualError(t, err, strings.Join([]string{
+		"row 2, column 'active': 'yes' is not a valid boolean",
+		"row 2, column 'code': '3.5' is not a valid integer",
+		"row 2: column 'extra' is not a property of the datatable",
+		"row 2, column 'score': 'high' is not a valid number",
+		"row 3: column 'extra' is not a property of the datatable",
+		"row 3: the key value is missing",
+		"row 4: column 'extra' is not a property of the datatable",
+		"row 4: key 'ie' is a duplicate of row 2",
+	}, "\n"))
+
+	_, err = readDatatableRowsFile(strings.NewReader(`[{"key": "ie", "code": 1.5}, {"key": "ie"}, {"key": "us", "active": 1}]`), rowsFormatJson, datatable)
+	assert.EqualError(t, err, strings.Join([]string{
+		"row 1, column 'code': 1.5 is not a valid integer",
+		"row 2: key 'ie' is a duplicate of row 1",
+		"row 3, column 'active': 1 is not a valid boolean",
+	}, "\n"))
+
+	_, err = readDatatableRowsFile(strings.NewReader(""), rowsFormatCsv, datatable)
+	assert.ErrorContains(t, err, "the rows file is empty")
+}
+
+func TestUnitGetRowsFileFormat(t *testing.T) {
+	format, err := getRowsFileFormat("rows.CSV", "")
+	assert.NoError(t, err)
+	assert.Equal(t, rowsFormatCsv, format)
+
+	format, err = getRowsFileFormat("rows.txt", rowsFormatJson)
+	assert.NoError(t, err)
+	assert.Equal(t, rowsFormatJson, format)
+
+	_, err = getRowsFileFormat("rows.txt", "")
+	assert.Error(t, err)
+}
+
+func TestUnitDiffDatatableRows(t *testing.T) {
+	desired := map[string]map[string]interface{}{
+		"a": {"key": "a", "code": int64(1)},
+		"b": {"key": "b", "code": int64(2)},
+		"c": {"key": "c", "code": int64(3)},
+	}
+	current := []map[string]interface{}{
+		{"key": "a", "code": float64(1)},
+		{"key": "b", "code": float64(20)},
+		{"key": "d", "code": float64(4)},
+		{"key": "e", "code": float64(5)},
+	}
+
+	changes := diffDatatableRows(desired, current, []string{"a", "d"}, false)
+	assert.Equal(t, []datatableRowChange{
+		{action: rowChangeUpdate, key: "b", row: desired["b"]},
+		{action: rowChangeCreate, key: "c", row: desired["c"]},
+		{action: rowChangeDelete, key: "d"},
+	}, changes)
+
+	changes = diffDatatableRows(desired, current, nil, true)
+	assert.Equal(t, []datatableRowChange{
+		{action: rowChangeUpdate, key: "b", row: desired["b"]},
+		{action: rowChangeCreate, key: "c", row: desired["c"]},
+		{action: rowChangeDelete, key: "d"},
+		{action: rowChangeDelete, key: "e"},
+	}, changes)
+}
+
+func TestUnitHashDatatableRows(t *testing.T) {
+	desired := map[string]map[string]interface{}{
+		"a": {"key": "a", "code": int64(1), "score": 1.5},
+	}
+	current := map[string]map[string]interface{}{
+		"a": {"score": 1.5, "code": float64(1), "key": "a"},
+	}
+	assert.Equal(t, hashDatatableRows(desired), hashDatatableRows(current))
+
+	current["a"]["code"] = float64(2)
+	assert.NotEqual(t, hashDatatableRows(desired), hashDatatableRows(current))
+}
+
+// buildRowsTestProxy returns a proxy holding the rows of a single datatable in memory
+func buildRowsTestProxy(t *testing.T, datatable *Datatable, rows map[string]map[string]interface{}) *architectDatatableRowProxy {
+	var mu sync.Mutex
+
+	archProxy := &architectDatatableRowProxy{}
+	archProxy.getArchitectDatatableAttr = func(ctx context.Context, p *architectDatatableRowProxy, datatableId string, expanded string) (*Datatable, *platformclientv2.APIResponse, error) {
+		assert.Equal(t, *datatable.Id, datatableId)
+		return datatable, &platformclientv2.APIResponse{StatusCode: http.StatusOK}, nil
+	}
+	archProxy.getAllArchitectDatatableRowsAttr = func(ctx context.Context, p *architectDatatableRowProxy, tableId string) (*[]map[string]interface{}, *platformclientv2.APIResponse, error) {
+		mu.Lock()
+		defer mu.Unlock()
+		var result []map[string]interface{}
+		for _, row := range rows {
+			result = append(result, row)
+		}
+		return &result, &platformclientv2.APIResponse{StatusCode: http.StatusOK}, nil
+	}
+	archProxy.createArchitectDatatableRowAttr = func(ctx context.Context, p *architectDatatableRowProxy, tableId string, row *map[string]interface{}) (*map[string]interface{}, *platformclientv2.APIResponse, error) {
+		mu.Lock()
+		defer mu.Unlock()
+		key := (*row)["key"].(string)
+		if _, exists := rows[key]; exists {
+			return nil, &platformclientv2.APIResponse{StatusCode: http.StatusBadRequest}, fmt.Errorf("row %s already exists", key)
+		}
+		rows[key] = normalizeDatatableRow(*row).(map[string]interface{})
+		return row, &platformclientv2.APIResponse{StatusCode: http.StatusOK}, nil
+	}
+	archProxy.updateArchitectDatatableRowAttr = func(ctx context.Context, p *architectDatatableRowProxy, tableId string, key string, row *map[string]interface{}) (*map[string]interface{}, *platformclientv2.APIResponse, error) {
+		mu.Lock()
+		defer mu.Unlock()
+		rows[key] = normalizeDatatableRow(*row).(map[string]interface{})
+		return row, &platformclientv2.APIResponse{StatusCode: http.StatusOK}, nil
+	}
+	archProxy.deleteArchitectDatatableRowAttr = func(ctx context.Context, p *architectDatatableRowProxy, tableId string, rowId string) (*platformclientv2.APIResponse, error) {
+		mu.Lock()
+		defer mu.Unlock()
+		if _, exists := rows[rowId]; !exists {
+			return &platformclientv2.APIResponse{StatusCode: http.StatusNotFound}, fmt.Errorf("row %s not found", rowId)
+		}
+		delete(rows, rowId)
+		return nil, nil
+	}
+	return archProxy
+}
+
+func TestUnitApplyDatatableRowChanges(t *testing.T) {
+	tableId := uuid.NewString()
+	rows := map[string]map[string]interface{}{
+		"existing": {"key": "existing"},
+	}
+	archProxy := buildRowsTestProxy(t, generateTestDatatable(tableId), rows)
+
+	var changes []datatableRowChange
+	for i := 0; i < 25; i++ {
+		key := fmt.Sprintf("row-%02d", i)
+		changes = append(changes, datatableRowChange{action: rowChangeCreate, key: key, row: map[string]interface{}{"key": key}})
+	}
+	changes = append(changes, datatableRowChange{action: rowChangeDelete, key: "missing"})
+
+	diagErr := applyDatatableRowChanges(context.Background(), archProxy, tableId, changes, 10)
+	assert.Nil(t, diagErr)
+	assert.Len(t, rows, 26)
+
+	changes = []datatableRowChange{
+		{action: rowChangeCreate, key: "existing", row: map[string]interface{}{"key": "existing"}},
+		{action: rowChangeDelete, key: "row-00"},
+	}
+	diagErr = applyDatatableRowChanges(context.Background(), archProxy, tableId, changes, 10)
+	assert.True(t, diagErr.HasError())
+	assert.Contains(t, diagErr[0].Detail, "failed to create row existing")
+	assert.NotContains(t, rows, "row-00")
+}
+
+func TestUnitResourceArchitectDatatableRowsSync(t *testing.T) {
+	tableId := uuid.NewString()
+	rows := map[string]map[string]interface{}{
+		"ie": {"key": "ie", "code": float64(353), "active": true, "score": float64(50), "name": "Ireland"},
+		"fr": {"key": "fr", "code": float64(33), "active": true, "score": float64(50), "name": "France"},
+		"xx": {"key": "xx", "code": float64(0), "active": false, "score": float64(50), "name": "Unmanaged"},
+	}
+	archProxy := buildRowsTestProxy(t, generateTestDatatable(tableId), rows)
+
+	filePath := filepath.Join(t.TempDir(), "rows.csv")
+	assert.NoError(t, os.WriteFile(filePath, []byte("key,code,active,name\nie,353,true,Ireland\nus,1,true,United States\n"), 0644))
+
+	resourceDataMap := map[string]interface{}{
+		"datatable_id":      tableId,
+		"filepath":          filePath,
+		"file_content_hash": "hash",
+		"batch_size":        10,
+	}
+	d := schema.TestResourceDataRaw(t, ResourceArchitectDatatableRows().Schema, resourceDataMap)
+	d.SetId(tableId)
+	// fr was synced from a previous version of the file
+	_ = d.Set("row_keys", []string{"fr", "ie"})
+
+	diagErr := syncDatatableRows(context.Background(), d, archProxy)
+	assert.Nil(t, diagErr)
+
+	keys := make([]string, 0, len(rows))
+	for key := range rows {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	assert.Equal(t, []string{"ie", "us", "xx"}, keys, "only the previously managed rows should be deleted")
+	assert.Equal(t, 2, d.Get("row_count").(int))
+	assert.ElementsMatch(t, []interface{}{"ie", "us"}, d.Get("row_keys").(*schema.Set).List())
+
+	// The read detects rows changed outside of Terraform
+	readRows, _, err := archProxy.getAllArchitectDatatableRows(context.Background(), tableId)
+	assert.NoError(t, err)
+	flattenManagedDatatableRows(d, *readRows)
+	assert.Equal(t, "hash", d.Get("file_content_hash").(string))
+
+	rows["us"]["name"] = "Changed"
+	readRows, _, err = archProxy.getAllArchitectDatatableRows(context.Background(), tableId)
+	assert.NoError(t, err)
+	flattenManagedDatatableRows(d, *readRows)
+	assert.Equal(t, "", d.Get("file_content_hash").(string))
+}
+
+func TestUnitResourceArchitectDatatableRowsHashOfStoredRows(t *testing.T) {
+	for _, authoritative := range []bool{false, true} {
+		t.Run(fmt.Sprintf("authoritative %v", authoritative), func(t *testing.T) {
+			tableId := uuid.NewString()
+			rows := map[string]map[string]interface{}{}
+			archProxy := buildRowsTestProxy(t, generateTestDatatable(tableId), rows)
+
+			// The datatable omits the columns holding the default of their type
+			createRow := archProxy.createArchitectDatatableRowAttr
+			archProxy.createArchitectDatatableRowAttr = func(ctx context.Context, p *architectDatatableRowProxy, tableId string, row *map[string]interface{}) (*map[string]interface{}, *platformclientv2.APIResponse, error) {
+				stored := map[string]interface{}{}
+				for column, value := range *row {
+					if value != "" && value != false && value != 0 {
+						stored[column] = value
+					}
+				}
+				return createRow(ctx, p, tableId, &stored)
+			}
+
+			filePath := filepath.Join(t.TempDir(), "rows.json")
+			assert.NoError(t, os.WriteFile(filePath, []byte(`[{"key": "ie", "code": 353}, {"key": "us", "code": 1.0, "score": 7.5}]`), 0644))
+
+			resourceDataMap := map[string]interface{}{
+				"datatable_id":      tableId,
+				"filepath":          filePath,
+				"file_content_hash": "hash",
+				"authoritative":     authoritative,
+				"batch_size":        10,
+			}
+			d := schema.TestResourceDataRaw(t, ResourceArchitectDatatableRows().Schema, resourceDataMap)
+			d.SetId(tableId)
+
+			diagErr := syncDatatableRows(context.Background(), d, archProxy)
+			assert.Nil(t, diagErr)
+			assert.Equal(t, float64(50), rows["ie"]["score"], "the default of score should be set")
+			assert.NotContains(t, rows["ie"], "active")
+
+			// The stored rows differ from the rows file but were not changed since the sync
+			readRows, _, err := archProxy.getAllArchitectDatatableRows(context.Background(), tableId)
+			assert.NoError(t, err)
+			flattenManagedDatatableRows(d, *readRows)
+			assert.Equal(t, "hash", d.Get("file_content_hash").(string))
+			assert.Equal(t, 2, d.Get("row_count").(int))
+		})
+	}
+}
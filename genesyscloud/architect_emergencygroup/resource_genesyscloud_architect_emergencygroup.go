@@ -116,7 +116,7 @@ func updateEmergencyGroup(ctx context.Context, d *schema.ResourceData, meta inte
 	name := d.Get("name").(string)
 	description := d.Get("description").(string)
 	divisionId := d.Get("division_id").(string)
-	enabled := d.Get("enabled").(bool)
+	enabledConfigured := isEnabledConfigured(d.GetRawConfig())
 
 	sdkConfig := meta.(*provider.ProviderMeta).ClientConfig
 	ap := getArchitectEmergencyGroupProxy(sdkConfig)
@@ -128,6 +128,12 @@ func updateEmergencyGroup(ctx context.Context, d *schema.ResourceData, meta inte
 			return resp, util.BuildAPIDiagnosticError(ResourceType, fmt.Sprintf("Failed to read emergency group %s error: %s", d.Id(), getErr), resp)
 		}
 
+		// Keep the current state unless enabled is set, as it may be toggled by an activation resource
+		enabled := emergencyGroup.Enabled
+		if enabledConfigured {
+			enabled = platformclientv2.Bool(d.Get("enabled").(bool))
+		}
+
 		log.Printf("Updating emergency group %s", name)
 		updatedEmergencyGroup := platformclientv2.Emergencygroup{
 			Name:               &name,
@@ -135,7 +141,7 @@ func updateEmergencyGroup(ctx context.Context, d *schema.ResourceData, meta inte
 			Description:        &description,
 			Version:            emergencyGroup.Version,
 			State:              emergencyGroup.State,
-			Enabled:            &enabled,
+			Enabled:            enabled,
 			EmergencyCallFlows: buildSdkEmergencyGroupCallFlows(d),
 		}
 
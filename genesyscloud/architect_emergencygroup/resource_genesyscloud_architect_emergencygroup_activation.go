@@ -0,0 +1,203 @@
+package architect_emergencygroup
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"terraform-provider-genesyscloud/genesyscloud/provider"
+	"terraform-provider-genesyscloud/genesyscloud/util"
+	"terraform-provider-genesyscloud/genesyscloud/util/resourcedata"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/mypurecloud/platform-client-sdk-go/v149/platformclientv2"
+)
+
+/*
+The resource_genesyscloud_architect_emergencygroup_activation.go file contains the
+genesyscloud_architect_emergencygroup_activation resource. It only changes the enabled state of an existing emergency
+group, and deactivates the group on the first apply after expires_at.
+*/
+
+// activationNow returns the current time. It is replaced in unit tests.
+var activationNow = time.Now
+
+func createEmergencyGroupActivation(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	sdkConfig := meta.(*provider.ProviderMeta).ClientConfig
+	ap := getArchitectEmergencyGroupProxy(sdkConfig)
+	emergencyGroupId := d.Get("emergency_group_id").(string)
+
+	if diagErr := applyEmergencyGroupActivation(ctx, d, ap, emergencyGroupId); diagErr != nil {
+		return diagErr
+	}
+	d.SetId(emergencyGroupId)
+	return readEmergencyGroupActivation(ctx, d, meta)
+}
+
+func readEmergencyGroupActivation(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	sdkConfig := meta.(*provider.ProviderMeta).ClientConfig
+	ap := getArchitectEmergencyGroupProxy(sdkConfig)
+
+	log.Printf("Reading activation of emergency group %s", d.Id())
+	emergencyGroup, resp, err := ap.getArchitectEmergencyGroup(ctx, d.Id())
+	if err != nil {
+		if util.IsStatus404(resp) {
+			log.Printf("Emergency group %s no longer exists", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return util.BuildAPIDiagnosticError(ActivationResourceType, fmt.Sprintf("Failed to read emergency group %s | error: %s", d.Id(), err), resp)
+	}
+	if emergencyGroup.State != nil && *emergencyGroup.State == "deleted" {
+		log.Printf("Emergency group %s no longer exists", d.Id())
+		d.SetId("")
+		return nil
+	}
+
+	_ = d.Set("emergency_group_id", d.Id())
+	// An expired activation no longer manages the emergency group, so changes made since are not reported as drift
+	if !d.Get("expired").(bool) {
+		resourcedata.SetNillableValue(d, "enabled", emergencyGroup.Enabled)
+	}
+	log.Printf("Read activation of emergency group %s", d.Id())
+	return nil
+}
+
+func updateEmergencyGroupActivation(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	sdkConfig := meta.(*provider.ProviderMeta).ClientConfig
+	ap := getArchitectEmergencyGroupProxy(sdkConfig)
+
+	if diagErr := applyEmergencyGroupActivation(ctx, d, ap, d.Id()); diagErr != nil {
+		return diagErr
+	}
+	return readEmergencyGroupActivation(ctx, d, meta)
+}
+
+// deleteEmergencyGroupActivation deactivates the emergency group, unless the activation has expired or the resource
+// was already keeping the group inactive
+func deleteEmergencyGroupActivation(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	if d.Get("expired").(bool) || !d.Get("enabled").(bool) {
+		log.Printf("Stopped managing the activation of emergency group %s", d.Id())
+		return nil
+	}
+
+	sdkConfig := meta.(*provider.ProviderMeta).ClientConfig
+	ap := getArchitectEmergencyGroupProxy(sdkConfig)
+
+	_, _, diagErr := setEmergencyGroupEnabled(ctx, ap, d.Id(), false)
+	if diagErr != nil {
+		return diagErr
+	}
+	log.Printf("Deactivated emergency group %s", d.Id())
+	return nil
+}
+
+// customizeEmergencyGroupActivationDiff plans the deactivation of the emergency group once expires_at has passed
+func customizeEmergencyGroupActivationDiff(ctx context.Context, diff *schema.ResourceDiff, meta interface{}) error {
+	expired := isActivationExpired(diff.Get("expires_at").(string))
+	if diff.Id() == "" || expired == diff.Get("expired").(bool) {
+		if diff.Id() != "" && diff.HasChange("enabled") {
+			return setActivationChangeComputed(diff)
+		}
+		return nil
+	}
+
+	if expired {
+		log.Printf("Activation of emergency group %s has expired and the group will be deactivated", diff.Id())
+	}
+	if err := diff.SetNew("expired", expired); err != nil {
+		return err
+	}
+	return setActivationChangeComputed(diff)
+}
+
+func setActivationChangeComputed(diff *schema.ResourceDiff) error {
+	for _, key := range []string{"changed_by", "changed_at"} {
+		if err := diff.SetNewComputed(key); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// applyEmergencyGroupActivation sets the enabled state of the emergency group, which is inactive once expires_at has
+// passed, and records who changed it and when
+func applyEmergencyGroupActivation(ctx context.Context, d *schema.ResourceData, ap *architectEmergencyGroupProxy, emergencyGroupId string) diag.Diagnostics {
+	expired := isActivationExpired(d.Get("expires_at").(string))
+	enabled := d.Get("enabled").(bool) && !expired
+
+	emergencyGroup, updated, diagErr := setEmergencyGroupEnabled(ctx, ap, emergencyGroupId, enabled)
+	if diagErr != nil {
+		return diagErr
+	}
+	_ = d.Set("expired", expired)
+
+	// The plan marks the last change as unknown, so the previous one is read from the state
+	changedBy, _ := d.GetChange("changed_by")
+	changedAt, _ := d.GetChange("changed_at")
+	if updated || changedAt.(string) == "" {
+		changedBy, changedAt = emergencyGroupLastChange(emergencyGroup)
+	}
+	_ = d.Set("changed_by", changedBy)
+	_ = d.Set("changed_at", changedAt)
+	return nil
+}
+
+// setEmergencyGroupEnabled updates the emergency group with only enabled changed, and returns the group and whether it
+// was updated. The group is not updated if it is already in the requested state.
+func setEmergencyGroupEnabled(ctx context.Context, ap *architectEmergencyGroupProxy, emergencyGroupId string, enabled bool) (*platformclientv2.Emergencygroup, bool, diag.Diagnostics) {
+	var (
+		updatedEmergencyGroup *platformclientv2.Emergencygroup
+		updated               bool
+	)
+	diagErr := util.RetryWhen(util.IsVersionMismatch, func() (*platformclientv2.APIResponse, diag.Diagnostics) {
+		emergencyGroup, resp, getErr := ap.getArchitectEmergencyGroup(ctx, emergencyGroupId)
+		if getErr != nil {
+			return resp, util.BuildAPIDiagnosticError(ActivationResourceType, fmt.Sprintf("Failed to read emergency group %s | error: %s", emergencyGroupId, getErr), resp)
+		}
+		if emergencyGroup.Enabled != nil && *emergencyGroup.Enabled == enabled {
+			log.Printf("Emergency group %s already has enabled set to %t", emergencyGroupId, enabled)
+			updatedEmergencyGroup = emergencyGroup
+			return resp, nil
+		}
+
+		log.Printf("Setting enabled to %t on emergency group %s", enabled, emergencyGroupId)
+		emergencyGroup.Enabled = &enabled
+		putEmergencyGroup, resp, putErr := ap.updateArchitectEmergencyGroup(ctx, emergencyGroupId, *emergencyGroup)
+		if putErr != nil {
+			return resp, util.BuildAPIDiagnosticError(ActivationResourceType, fmt.Sprintf("Failed to update emergency group %s | error: %s", emergencyGroupId, putErr), resp)
+		}
+		updatedEmergencyGroup = putEmergencyGroup
+		updated = true
+		return resp, nil
+	})
+	return updatedEmergencyGroup, updated, diagErr
+}
+
+// emergencyGroupLastChange returns who last modified the emergency group and when. The application is returned when
+// the group was modified with client credentials, and the current time when the API does not return the date.
+func emergencyGroupLastChange(emergencyGroup *platformclientv2.Emergencygroup) (string, string) {
+	changedBy := ""
+	changedAt := activationNow()
+	if emergencyGroup != nil {
+		if emergencyGroup.ModifiedBy != nil {
+			changedBy = *emergencyGroup.ModifiedBy
+		} else if emergencyGroup.ModifiedByApp != nil {
+			changedBy = *emergencyGroup.ModifiedByApp
+		}
+		if emergencyGroup.DateModified != nil {
+			changedAt = *emergencyGroup.DateModified
+		}
+	}
+	return changedBy, changedAt.UTC().Format(time.RFC3339)
+}
+
+// isActivationExpired reports whether expires_at is set and has passed
+func isActivationExpired(expiresAt string) bool {
+	if expiresAt == "" {
+		return false
+	}
+	expires, err := time.Parse(time.RFC3339, expiresAt)
+	return err == nil && !activationNow().Before(expires)
+}
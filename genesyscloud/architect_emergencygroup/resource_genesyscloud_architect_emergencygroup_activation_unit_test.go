@@ -0,0 +1,113 @@
+package architect_emergencygroup
+
+import (
+	"context"
+	"net/http"
+	"terraform-provider-genesyscloud/genesyscloud/provider"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/mypurecloud/platform-client-sdk-go/v149/platformclientv2"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUnitEmergencyGroupActivation(t *testing.T) {
+	tGroupId := uuid.NewString()
+	tUserId := uuid.NewString()
+	tName := "Unit Test Emergency Group"
+	tDivisionId := uuid.NewString()
+	now := time.Date(2026, 3, 1, 8, 0, 0, 0, time.UTC)
+
+	group := platformclientv2.Emergencygroup{
+		Id:          &tGroupId,
+		Name:        &tName,
+		Division:    &platformclientv2.Writabledivision{Id: &tDivisionId},
+		Description: platformclientv2.String("Outage routing"),
+		State:       platformclientv2.String("active"),
+		Version:     platformclientv2.Int(3),
+		Enabled:     platformclientv2.Bool(false),
+	}
+	updates := 0
+	internalProxy = &architectEmergencyGroupProxy{
+		getArchitectEmergencyGroupAttr: func(ctx context.Context, p *architectEmergencyGroupProxy, emergencyGroupId string) (*platformclientv2.Emergencygroup, *platformclientv2.APIResponse, error) {
+			assert.Equal(t, tGroupId, emergencyGroupId)
+			current := group
+			return &current, &platformclientv2.APIResponse{StatusCode: http.StatusOK}, nil
+		},
+		updateArchitectEmergencyGroupAttr: func(ctx context.Context, p *architectEmergencyGroupProxy, emergencyGroupId string, emergencyGroup platformclientv2.Emergencygroup) (*platformclientv2.Emergencygroup, *platformclientv2.APIResponse, error) {
+			updates++
+			// Only enabled is changed, the rest of the group is sent as read
+			assert.Equal(t, tName, *emergencyGroup.Name)
+			assert.Equal(t, tDivisionId, *emergencyGroup.Division.Id)
+			assert.Equal(t, "Outage routing", *emergencyGroup.Description)
+			assert.Equal(t, *group.Version, *emergencyGroup.Version)
+
+			group = emergencyGroup
+			group.Version = platformclientv2.Int(*emergencyGroup.Version + 1)
+			group.ModifiedBy = &tUserId
+			group.DateModified = &now
+			updated := group
+			return &updated, &platformclientv2.APIResponse{StatusCode: http.StatusOK}, nil
+		},
+	}
+	defer func() { internalProxy = nil }()
+
+	activationNow = func() time.Time { return now }
+	defer func() { activationNow = time.Now }()
+
+	gcloud := &provider.ProviderMeta{ClientConfig: &platformclientv2.Configuration{}}
+	d := schema.TestResourceDataRaw(t, ResourceArchitectEmergencyGroupActivation().Schema, map[string]interface{}{
+		"emergency_group_id": tGroupId,
+		"expires_at":         "2026-03-01T20:00:00Z",
+	})
+
+	diag := createEmergencyGroupActivation(context.Background(), d, gcloud)
+	assert.False(t, diag.HasError(), diag)
+	assert.Equal(t, tGroupId, d.Id())
+	assert.Equal(t, 1, updates)
+	assert.True(t, *group.Enabled)
+	assert.True(t, d.Get("enabled").(bool))
+	assert.False(t, d.Get("expired").(bool))
+	assert.Equal(t, tUserId, d.Get("changed_by"))
+	assert.Equal(t, "2026-03-01T08:00:00Z", d.Get("changed_at"))
+
+	// The next run after expires_at deactivates the group and stops reporting drift on it
+	now = time.Date(2026, 3, 1, 21, 0, 0, 0, time.UTC)
+	d = ResourceArchitectEmergencyGroupActivation().Data(&terraform.InstanceState{
+		ID: tGroupId,
+		Attributes: map[string]string{
+			"emergency_group_id": tGroupId,
+			"enabled":            "true",
+			"expires_at":         "2026-03-01T20:00:00Z",
+			"expired":            "false",
+			"changed_by":         tUserId,
+			"changed_at":         "2026-03-01T08:00:00Z",
+		},
+	})
+	diag = updateEmergencyGroupActivation(context.Background(), d, gcloud)
+	assert.False(t, diag.HasError(), diag)
+	assert.Equal(t, 2, updates)
+	assert.False(t, *group.Enabled)
+	assert.True(t, d.Get("enabled").(bool))
+	assert.True(t, d.Get("expired").(bool))
+	assert.Equal(t, "2026-03-01T21:00:00Z", d.Get("changed_at"))
+
+	// Destroying an expired activation leaves the group as it is
+	diag = deleteEmergencyGroupActivation(context.Background(), d, gcloud)
+	assert.False(t, diag.HasError(), diag)
+	assert.Equal(t, 2, updates)
+}
+
+func TestUnitEmergencyGroupActivationExpired(t *testing.T) {
+	now := time.Date(2026, 3, 1, 8, 0, 0, 0, time.UTC)
+	activationNow = func() time.Time { return now }
+	defer func() { activationNow = time.Now }()
+
+	assert.False(t, isActivationExpired(""))
+	assert.False(t, isActivationExpired("2026-03-01T08:00:01Z"))
+	assert.True(t, isActivationExpired("2026-03-01T08:00:00Z"))
+	assert.True(t, isActivationExpired("2026-03-01T09:00:00+02:00"))
+}
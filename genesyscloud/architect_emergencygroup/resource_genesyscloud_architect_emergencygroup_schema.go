@@ -6,13 +6,18 @@ import (
 	registrar "terraform-provider-genesyscloud/genesyscloud/resource_register"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
-const ResourceType = "genesyscloud_architect_emergencygroup"
+const (
+	ResourceType           = "genesyscloud_architect_emergencygroup"
+	ActivationResourceType = "genesyscloud_architect_emergencygroup_activation"
+)
 
 // SetRegistrar registers all of the resources, datasources and exporters in the package
 func SetRegistrar(regInstance registrar.Registrar) {
 	regInstance.RegisterResource(ResourceType, ResourceArchitectEmergencyGroup())
+	regInstance.RegisterResource(ActivationResourceType, ResourceArchitectEmergencyGroupActivation())
 	regInstance.RegisterDataSource(ResourceType, DataSourceArchitectEmergencyGroup())
 	regInstance.RegisterExporter(ResourceType, ArchitectEmergencyGroupExporter())
 }
@@ -48,10 +53,10 @@ func ResourceArchitectEmergencyGroup() *schema.Resource {
 				Optional:    true,
 			},
 			"enabled": {
-				Description: "The state of the emergency group. Defaults to false/inactive.",
+				Description: "The state of the emergency group. New emergency groups are inactive unless set. If not set, the state is not managed by this resource, so that it can be toggled with genesyscloud_architect_emergencygroup_activation without causing drift. The exporter exports the state of the emergency group at the time of the export. Remove it from the exported configuration to leave the state to genesyscloud_architect_emergencygroup_activation.",
 				Type:        schema.TypeBool,
 				Optional:    true,
-				Default:     false,
+				Computed:    true,
 			},
 			"emergency_call_flows": {
 				Description: "The emergency call flows for this emergency group.",
@@ -77,6 +82,57 @@ func ResourceArchitectEmergencyGroup() *schema.Resource {
 	}
 }
 
+func ResourceArchitectEmergencyGroupActivation() *schema.Resource {
+	return &schema.Resource{
+		Description: "Activates or deactivates a Genesys Cloud Architect Emergency Group. Only the enabled state of the emergency group is changed, so that it can be toggled quickly without applying the rest of the configuration. " +
+			"The emergency group is deactivated on the first apply after expires_at. Leave enabled unset on the genesyscloud_architect_emergencygroup resource to avoid drift between the two resources. " +
+			"Destroying the resource deactivates the emergency group, unless the activation has expired.",
+		CreateContext: provider.CreateWithPooledClient(createEmergencyGroupActivation),
+		ReadContext:   provider.ReadWithPooledClient(readEmergencyGroupActivation),
+		UpdateContext: provider.UpdateWithPooledClient(updateEmergencyGroupActivation),
+		DeleteContext: provider.DeleteWithPooledClient(deleteEmergencyGroupActivation),
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+		Schema: map[string]*schema.Schema{
+			"emergency_group_id": {
+				Description: "ID of the emergency group.",
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+			},
+			"enabled": {
+				Description: "Whether the emergency group is active.",
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     true,
+			},
+			"expires_at": {
+				Description:  "Time after which the next apply deactivates the emergency group, in RFC 3339 format. Once expired, the state of the emergency group is no longer managed by this resource until expires_at is moved to the future.",
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.IsRFC3339Time,
+			},
+			"expired": {
+				Description: "Whether expires_at has passed.",
+				Type:        schema.TypeBool,
+				Computed:    true,
+			},
+			"changed_by": {
+				Description: "ID of the user, or name of the application, that last changed the state of the emergency group.",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"changed_at": {
+				Description: "Time of the last change of the state of the emergency group, in RFC 3339 format.",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+		},
+		CustomizeDiff: customizeEmergencyGroupActivationDiff,
+	}
+}
+
 func DataSourceArchitectEmergencyGroup() *schema.Resource {
 	return &schema.Resource{
 		Description: "Data source for Genesys Cloud Emergency Groups. Select an emergency group by name.",
@@ -1,6 +1,7 @@
 package architect_emergencygroup
 
 import (
+	"github.com/hashicorp/go-cty/cty"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/mypurecloud/platform-client-sdk-go/v149/platformclientv2"
 )
@@ -51,3 +52,12 @@ func flattenEmergencyCallFlows(emergencyCallFlows []platformclientv2.Emergencyca
 	}
 	return callFlows
 }
+
+// isEnabledConfigured reports whether enabled is set in the configuration of the emergency group
+func isEnabledConfigured(rawConfig cty.Value) bool {
+	if rawConfig.IsNull() || !rawConfig.IsKnown() || !rawConfig.Type().HasAttribute("enabled") {
+		return false
+	}
+	enabled := rawConfig.GetAttr("enabled")
+	return !enabled.IsNull()
+}
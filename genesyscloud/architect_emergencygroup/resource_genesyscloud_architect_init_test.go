@@ -27,6 +27,7 @@ func (r *registerTestInstance) registerTestResources() {
 	providerResources[architect_ivr.ResourceType] = architect_ivr.ResourceArchitectIvrConfig()
 	providerResources[flow.ResourceType] = flow.ResourceArchitectFlow()
 	providerResources[ResourceType] = ResourceArchitectEmergencyGroup()
+	providerResources[ActivationResourceType] = ResourceArchitectEmergencyGroupActivation()
 }
 
 // registerTestDataSources registers all data sources used in the tests.
@@ -49,7 +49,7 @@ func TestAccDataSourceFlow(t *testing.T) {
 				ResourceName:            ResourceType + "." + flowResourceLabel,
 				ImportState:             true,
 				ImportStateVerify:       true,
-				ImportStateVerifyIgnore: []string{"filepath", "force_unlock", "file_content_hash"},
+				ImportStateVerifyIgnore: []string{"filepath", "force_unlock", "file_content_hash", "last_deployment_job_id", "last_deployment_status", "last_deployment_messages"},
 			},
 		},
 		CheckDestroy: testVerifyFlowDestroyed,
@@ -68,3 +68,34 @@ func generateFlowDataSource(
 	}
 	`, ResourceType, resourceLabel, name, varType, dependsOn)
 }
+
+func TestAccDataSourceFlowValidation(t *testing.T) {
+	var (
+		dataSourceLabel = "flow-validation"
+		fullLabel       = "data." + ValidationResourceType + "." + dataSourceLabel
+		filePath        = filepath.Join("..", "..", "examples", "resources", "genesyscloud_flow", "inboundcall_flow_example_substitutions.yaml")
+		flowName        = "test_validation_flow" + uuid.NewString()
+	)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { util.TestAccPreCheck(t) },
+		ProviderFactories: provider.GetProviderFactories(providerResources, providerDataSources),
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`data "%s" "%s" {
+					filepath = %s
+					substitutions = {
+						flow_name = "%s"
+					}
+				}
+				`, ValidationResourceType, dataSourceLabel, strconv.Quote(filePath), flowName),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(fullLabel, "valid", "true"),
+					resource.TestCheckResourceAttr(fullLabel, "flow_name", flowName),
+					resource.TestCheckResourceAttr(fullLabel, "flow_type", "inboundcall"),
+					resource.TestCheckResourceAttr(fullLabel, "errors.#", "0"),
+				),
+			},
+		},
+	})
+}
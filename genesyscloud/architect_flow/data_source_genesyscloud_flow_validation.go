@@ -0,0 +1,45 @@
+package architect_flow
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"terraform-provider-genesyscloud/genesyscloud/provider"
+	"terraform-provider-genesyscloud/genesyscloud/util"
+	"terraform-provider-genesyscloud/genesyscloud/util/templates"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourceFlowValidationRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	sdkConfig := meta.(*provider.ProviderMeta).ClientConfig
+	proxy := getArchitectFlowProxy(sdkConfig)
+
+	filePath := d.Get("filepath").(string)
+	substitutions := d.Get("substitutions").(map[string]interface{})
+
+	templateEngine := d.Get("template_engine").(string)
+	templateVariables := d.Get("template_variables").(string)
+
+	result, content, diagErr := validateFlowContent(ctx, proxy, filePath, templateEngine, templateVariables, substitutions)
+	if diagErr.HasError() {
+		return diagErr
+	}
+
+	contentHash := templates.HashContent(content)
+	d.SetId(contentHash)
+	_ = d.Set("content_hash", contentHash)
+	_ = d.Set("valid", result.valid())
+	_ = d.Set("flow_name", result.flowName)
+	_ = d.Set("flow_type", result.flowType)
+	_ = d.Set("errors", flattenFlowValidationMessages(result.errors()))
+	_ = d.Set("warnings", flattenFlowValidationMessages(result.warnings()))
+
+	log.Printf("Validated flow file %s: %d errors, %d warnings", filePath, len(result.errors()), len(result.warnings()))
+	if !result.valid() {
+		return append(diagErr, util.BuildDiagnosticError(ValidationResourceType, fmt.Sprintf("flow file %s failed validation", filePath), errors.New(result.summary(validationSeverityError)))...)
+	}
+	return diagErr
+}
@@ -0,0 +1,106 @@
+package architect_flow
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sort"
+	"terraform-provider-genesyscloud/genesyscloud/provider"
+	"terraform-provider-genesyscloud/genesyscloud/util"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/retry"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/mypurecloud/platform-client-sdk-go/v149/platformclientv2"
+)
+
+// maxFlowVersionHistory is the number of versions kept in the version_history attribute of the genesyscloud_flow resource
+const maxFlowVersionHistory = 10
+
+func dataSourceFlowVersionsRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	sdkConfig := m.(*provider.ProviderMeta).ClientConfig
+	p := getArchitectFlowProxy(sdkConfig)
+	flowId := d.Get("flow_id").(string)
+
+	return util.WithRetriesForRead(ctx, d, func() *retry.RetryError {
+		flow, resp, err := p.GetFlow(ctx, flowId)
+		if err != nil {
+			if util.IsStatus404(resp) {
+				return retry.RetryableError(util.BuildWithRetriesApiDiagnosticError(VersionsResourceType, fmt.Sprintf("failed to read flow %s: %s", flowId, err), resp))
+			}
+			return retry.NonRetryableError(util.BuildWithRetriesApiDiagnosticError(VersionsResourceType, fmt.Sprintf("failed to read flow %s: %s", flowId, err), resp))
+		}
+
+		versions, resp, err := p.GetFlowVersions(ctx, flowId)
+		if err != nil {
+			return retry.NonRetryableError(util.BuildWithRetriesApiDiagnosticError(VersionsResourceType, fmt.Sprintf("failed to read versions of flow %s: %s", flowId, err), resp))
+		}
+		sortFlowVersions(*versions)
+
+		d.SetId(flowId)
+		_ = d.Set("published_version", flattenFlowVersionId(flow.PublishedVersion))
+		_ = d.Set("latest_version", "")
+		if len(*versions) > 0 {
+			_ = d.Set("latest_version", flattenFlowVersionId(&(*versions)[0]))
+		}
+		_ = d.Set("versions", flattenFlowVersions(*versions, 0))
+
+		log.Printf("Read %d versions of flow %s", len(*versions), flowId)
+		return nil
+	})
+}
+
+// sortFlowVersions sorts the versions of a flow from the most recent to the oldest
+func sortFlowVersions(versions []platformclientv2.Flowversion) {
+	sort.SliceStable(versions, func(i, j int) bool {
+		return intValue(versions[i].DateCreated) > intValue(versions[j].DateCreated)
+	})
+}
+
+// flattenFlowVersions flattens the versions of a flow. If limit is greater than 0, only the first limit versions are flattened.
+func flattenFlowVersions(versions []platformclientv2.Flowversion, limit int) []interface{} {
+	if limit > 0 && len(versions) > limit {
+		versions = versions[:limit]
+	}
+
+	result := make([]interface{}, len(versions))
+	for i, version := range versions {
+		versionMap := map[string]interface{}{
+			"version":        flattenFlowVersionId(&version),
+			"commit_version": util.StringOrEmpty(version.CommitVersion),
+			"secure":         version.Secure != nil && *version.Secure,
+			"debug":          version.Debug != nil && *version.Debug,
+			"date_created":   formatEpochMillis(version.DateCreated),
+			"date_published": "",
+			"created_by_id":  "",
+			"created_by":     "",
+		}
+		if version.DatePublished != nil {
+			versionMap["date_published"] = version.DatePublished.UTC().Format(time.RFC3339)
+		}
+		if version.CreatedBy != nil {
+			versionMap["created_by_id"] = util.StringOrEmpty(version.CreatedBy.Id)
+			versionMap["created_by"] = util.StringOrEmpty(version.CreatedBy.Name)
+		} else if version.CreatedByClient != nil {
+			versionMap["created_by_id"] = util.StringOrEmpty(version.CreatedByClient.Id)
+			versionMap["created_by"] = util.StringOrEmpty(version.CreatedByClient.Name)
+		}
+		result[i] = versionMap
+	}
+	return result
+}
+
+func formatEpochMillis(millis *int) string {
+	if millis == nil {
+		return ""
+	}
+	return time.UnixMilli(int64(*millis)).UTC().Format(time.RFC3339)
+}
+
+func intValue(value *int) int {
+	if value == nil {
+		return 0
+	}
+	return *value
+}
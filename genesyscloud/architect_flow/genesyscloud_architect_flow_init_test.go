@@ -24,6 +24,7 @@ func (r *registerTestInstance) registerTestResources() {
 	defer r.resourceMapMutex.Unlock()
 
 	providerResources[ResourceType] = ResourceArchitectFlow()
+	providerResources[PublishResourceType] = ResourceFlowPublish()
 
 }
 
@@ -33,6 +34,8 @@ func (r *registerTestInstance) registerTestDataSources() {
 	defer r.datasourceMapMutex.Unlock()
 
 	providerDataSources[ResourceType] = DataSourceArchitectFlow()
+	providerDataSources[ValidationResourceType] = DataSourceFlowValidation()
+	providerDataSources[VersionsResourceType] = DataSourceFlowVersions()
 }
 
 // initTestResources initializes all test resources and data sources.
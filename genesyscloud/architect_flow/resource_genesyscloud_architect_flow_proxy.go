@@ -2,8 +2,12 @@ package architect_flow
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"log"
+	"net/http"
+	"net/url"
 	rc "terraform-provider-genesyscloud/genesyscloud/resource_cache"
 
 	"github.com/mypurecloud/platform-client-sdk-go/v149/platformclientv2"
@@ -17,6 +21,10 @@ type deleteArchitectFlowFunc func(context.Context, *architectFlowProxy, string)
 type createArchitectFlowJobsFunc func(context.Context, *architectFlowProxy) (*platformclientv2.Registerarchitectjobresponse, *platformclientv2.APIResponse, error)
 type getArchitectFlowJobsFunc func(context.Context, *architectFlowProxy, string) (*platformclientv2.Architectjobstateresponse, *platformclientv2.APIResponse, error)
 type getAllArchitectFlowsFunc func(context.Context, *architectFlowProxy, string, []string) (*[]platformclientv2.Flow, *platformclientv2.APIResponse, error)
+type getFlowVersionsFunc func(ctx context.Context, a *architectFlowProxy, flowId string) (*[]platformclientv2.Flowversion, *platformclientv2.APIResponse, error)
+type getLatestFlowVersionsFunc func(ctx context.Context, a *architectFlowProxy, flowId string, pageSize int) (*[]platformclientv2.Flowversion, *platformclientv2.APIResponse, error)
+type getFlowVersionFunc func(ctx context.Context, a *architectFlowProxy, flowId string, versionId string) (*platformclientv2.Flowversion, *platformclientv2.APIResponse, error)
+type publishFlowVersionFunc func(ctx context.Context, a *architectFlowProxy, flowId string, versionId string) (*platformclientv2.Operation, *platformclientv2.APIResponse, error)
 type getFlowIdByNameAndTypeFunc func(ctx context.Context, a *architectFlowProxy, name string, varType string) (id string, resp *platformclientv2.APIResponse, retryable bool, err error)
 
 type architectFlowProxy struct {
@@ -30,6 +38,10 @@ type architectFlowProxy struct {
 	createArchitectFlowJobsAttr createArchitectFlowJobsFunc
 	getArchitectFlowJobsAttr    getArchitectFlowJobsFunc
 	getFlowIdByNameAndTypeAttr  getFlowIdByNameAndTypeFunc
+	getFlowVersionsAttr         getFlowVersionsFunc
+	getLatestFlowVersionsAttr   getLatestFlowVersionsFunc
+	getFlowVersionAttr          getFlowVersionFunc
+	publishFlowVersionAttr      publishFlowVersionFunc
 
 	flowCache rc.CacheInterface[platformclientv2.Flow]
 }
@@ -48,6 +60,10 @@ func newArchitectFlowProxy(clientConfig *platformclientv2.Configuration) *archit
 		createArchitectFlowJobsAttr: createArchitectFlowJobsFn,
 		getArchitectFlowJobsAttr:    getArchitectFlowJobsFn,
 		getFlowIdByNameAndTypeAttr:  getFlowIdByNameAndTypeFn,
+		getFlowVersionsAttr:         getFlowVersionsFn,
+		getLatestFlowVersionsAttr:   getLatestFlowVersionsFn,
+		getFlowVersionAttr:          getFlowVersionFn,
+		publishFlowVersionAttr:      publishFlowVersionFn,
 		flowCache:                   flowCache,
 	}
 }
@@ -87,6 +103,23 @@ func (a *architectFlowProxy) getFlowIdByNameAndType(ctx context.Context, name, v
 	return a.getFlowIdByNameAndTypeAttr(ctx, a, name, varType)
 }
 
+func (a *architectFlowProxy) GetFlowVersions(ctx context.Context, flowId string) (*[]platformclientv2.Flowversion, *platformclientv2.APIResponse, error) {
+	return a.getFlowVersionsAttr(ctx, a, flowId)
+}
+
+// GetLatestFlowVersions returns the pageSize most recent versions of a flow, from the most recent to the oldest
+func (a *architectFlowProxy) GetLatestFlowVersions(ctx context.Context, flowId string, pageSize int) (*[]platformclientv2.Flowversion, *platformclientv2.APIResponse, error) {
+	return a.getLatestFlowVersionsAttr(ctx, a, flowId, pageSize)
+}
+
+func (a *architectFlowProxy) GetFlowVersion(ctx context.Context, flowId, versionId string) (*platformclientv2.Flowversion, *platformclientv2.APIResponse, error) {
+	return a.getFlowVersionAttr(ctx, a, flowId, versionId)
+}
+
+func (a *architectFlowProxy) PublishFlowVersion(ctx context.Context, flowId, versionId string) (*platformclientv2.Operation, *platformclientv2.APIResponse, error) {
+	return a.publishFlowVersionAttr(ctx, a, flowId, versionId)
+}
+
 func getFlowIdByNameAndTypeFn(ctx context.Context, a *architectFlowProxy, name, varType string) (string, *platformclientv2.APIResponse, bool, error) {
 	var (
 		matchedFlowIds []string
@@ -185,3 +218,77 @@ func getAllArchitectFlowsFn(ctx context.Context, p *architectFlowProxy, name str
 
 	return &totalFlows, nil, nil
 }
+
+func getFlowVersionsFn(_ context.Context, p *architectFlowProxy, flowId string) (*[]platformclientv2.Flowversion, *platformclientv2.APIResponse, error) {
+	const pageSize = 100
+	var versions []platformclientv2.Flowversion
+
+	versionListing, resp, err := p.api.GetFlowVersions(flowId, 1, pageSize, false)
+	if err != nil {
+		return nil, resp, fmt.Errorf("failed to get page of versions of flow %s: %v", flowId, err)
+	}
+	if versionListing.Entities == nil || len(*versionListing.Entities) == 0 {
+		return &versions, resp, nil
+	}
+	versions = append(versions, *versionListing.Entities...)
+
+	for pageNum := 2; pageNum <= *versionListing.PageCount; pageNum++ {
+		versionListing, resp, err := p.api.GetFlowVersions(flowId, pageNum, pageSize, false)
+		if err != nil {
+			return nil, resp, fmt.Errorf("failed to get page %d of versions of flow %s: %v", pageNum, flowId, err)
+		}
+		if versionListing.Entities == nil || len(*versionListing.Entities) == 0 {
+			break
+		}
+		versions = append(versions, *versionListing.Entities...)
+	}
+	return &versions, resp, nil
+}
+
+// getLatestFlowVersionsFn requests a single page of versions sorted from the most recent to the oldest. The SDK does not expose the sort
+// parameters of the versions endpoint, so the API is called directly.
+func getLatestFlowVersionsFn(_ context.Context, p *architectFlowProxy, flowId string, pageSize int) (*[]platformclientv2.Flowversion, *platformclientv2.APIResponse, error) {
+	headerParams := make(map[string]string)
+	if p.clientConfig.AccessToken != "" {
+		headerParams["Authorization"] = "Bearer " + p.clientConfig.AccessToken
+	}
+	for key := range p.clientConfig.DefaultHeader {
+		headerParams[key] = p.clientConfig.DefaultHeader[key]
+	}
+	headerParams["Content-Type"] = "application/json"
+	headerParams["Accept"] = "application/json"
+
+	queryParams := make(map[string]string)
+	queryParams["pageNumber"] = "1"
+	queryParams["pageSize"] = p.clientConfig.APIClient.ParameterToString(pageSize, "")
+	queryParams["sortBy"] = "dateCreated"
+	queryParams["sortOrder"] = "desc"
+
+	var versionListing *platformclientv2.Flowversionentitylisting
+	path := p.clientConfig.BasePath + "/api/v2/flows/" + url.PathEscape(flowId) + "/versions"
+	response, err := p.clientConfig.APIClient.CallAPI(path, http.MethodGet, nil, headerParams, queryParams, nil, "", nil, "")
+	if err != nil {
+		return nil, response, fmt.Errorf("failed to get latest versions of flow %s: %v", flowId, err)
+	}
+	if response.Error != nil {
+		return nil, response, fmt.Errorf("failed to get latest versions of flow %s: %v", flowId, errors.New(response.ErrorMessage))
+	}
+	if err := json.Unmarshal(response.RawBody, &versionListing); err != nil {
+		return nil, response, fmt.Errorf("failed to unmarshal latest versions of flow %s: %v", flowId, err)
+	}
+
+	var versions []platformclientv2.Flowversion
+	if versionListing != nil && versionListing.Entities != nil {
+		versions = *versionListing.Entities
+	}
+	return &versions, response, nil
+}
+
+func getFlowVersionFn(_ context.Context, p *architectFlowProxy, flowId string, versionId string) (*platformclientv2.Flowversion, *platformclientv2.APIResponse, error) {
+	return p.api.GetFlowVersion(flowId, versionId, "false")
+}
+
+func publishFlowVersionFn(_ context.Context, p *architectFlowProxy, flowId string, versionId string) (*platformclientv2.Operation, *platformclientv2.APIResponse, error) {
+	log.Printf("Publishing version %s of flow %s", versionId, flowId)
+	return p.api.PostFlowsActionsPublish(flowId, versionId)
+}
@@ -3,6 +3,7 @@ package architect_flow
 import (
 	"strings"
 	"terraform-provider-genesyscloud/genesyscloud/validators"
+	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
@@ -10,16 +11,24 @@ import (
 	"terraform-provider-genesyscloud/genesyscloud/provider"
 	resourceExporter "terraform-provider-genesyscloud/genesyscloud/resource_exporter"
 	registrar "terraform-provider-genesyscloud/genesyscloud/resource_register"
+	"terraform-provider-genesyscloud/genesyscloud/util"
+	"terraform-provider-genesyscloud/genesyscloud/util/templates"
 )
 
 const (
-	ResourceType = "genesyscloud_flow"
+	ResourceType           = "genesyscloud_flow"
+	ValidationResourceType = "genesyscloud_flow_validation"
+	VersionsResourceType   = "genesyscloud_flow_versions"
+	PublishResourceType    = "genesyscloud_flow_publish"
 )
 
 // SetRegistrar registers all resources, data sources and exporters in the package
 func SetRegistrar(l registrar.Registrar) {
 	l.RegisterDataSource(ResourceType, DataSourceArchitectFlow())
+	l.RegisterDataSource(ValidationResourceType, DataSourceFlowValidation())
+	l.RegisterDataSource(VersionsResourceType, DataSourceFlowVersions())
 	l.RegisterResource(ResourceType, ResourceArchitectFlow())
+	l.RegisterResource(PublishResourceType, ResourceFlowPublish())
 	l.RegisterExporter(ResourceType, ArchitectFlowExporter())
 }
 
@@ -47,6 +56,7 @@ func ResourceArchitectFlow() *schema.Resource {
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
+		CustomizeDiff: templates.CustomizeRenderedContentHashDiff,
 		SchemaVersion: 1,
 		Schema: map[string]*schema.Schema{
 			"name": {
@@ -77,16 +87,168 @@ func ResourceArchitectFlow() *schema.Resource {
 				Type:        schema.TypeMap,
 				Optional:    true,
 			},
+			"template_engine": {
+				Description:  "Template engine used to render the YAML file before the substitutions are applied. Valid options: " + strings.Join(templates.Engines, ", ") + ". Go templates reference variables as `{{ .name }}` and include fragments with `{{ include \"path\" }}`. HCL templates reference variables as `${name}` and include fragments with `${include(\"path\")}`. Paths of includes are relative to the file including them. Substitution placeholders like `{{key}}` are kept as is by both engines. If not set, the file is not rendered.",
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.StringInSlice(templates.Engines, false),
+			},
+			"template_variables": {
+				Description:      "JSON encoded object of the variables passed to the template, e.g. `jsonencode({ queues = [\"sales\", \"support\"] })`. Variables keep their JSON types.",
+				Type:             schema.TypeString,
+				Optional:         true,
+				ValidateFunc:     validation.StringIsJSON,
+				DiffSuppressFunc: util.SuppressEquivalentJsonDiffs,
+			},
+			"rendered_content_hash": {
+				Description: "Hash value of the rendered YAML file content after the substitutions are applied. Changes of the template variables or of included fragments update the flow. Empty if no template engine is set.",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
 			"force_unlock": {
 				Description: `Will perform a force unlock on an architect flow before beginning the publication process.  NOTE: The force unlock publishes the 'draft'
 				              architect flow and then publishes the flow named in this resource. This mirrors the behavior found in the archy CLI tool.`,
 				Type:     schema.TypeBool,
 				Optional: true,
 			},
+			"validate_only": {
+				Description: "If true, the YAML file is validated after the substitutions are applied but the flow is not changed. The file is checked by the provider and then validated by Architect, which deploys it as a temporary flow with a unique name and deletes that flow once the validation finished. This requires the permissions needed to publish and delete flows. Errors fail the apply and warnings are reported as warning diagnostics. Changing this value recreates the resource.",
+				Type:        schema.TypeBool,
+				Optional:    true,
+				ForceNew:    true,
+				Default:     false,
+			},
+			"published_version": {
+				Description: "ID of the published version of the flow.",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"checked_in_version": {
+				Description: "ID of the checked in version of the flow.",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"locked": {
+				Description: "True if the flow is checked out by a user or a client.",
+				Type:        schema.TypeBool,
+				Computed:    true,
+			},
+			"last_deployment_job_id": {
+				Description: "ID of the Architect job of the last deployment of the flow by this resource.",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"last_deployment_status": {
+				Description: "Status of the Architect job of the last deployment of the flow by this resource.",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"last_deployment_messages": {
+				Description: "Tracing messages of the Architect job of the last deployment of the flow by this resource.",
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem:        deploymentMessageResource,
+			},
+			"version_history": {
+				Description: "The most recent versions of the flow, from the newest to the oldest. At most 10 versions are listed. The history is left unchanged when it cannot be read. Use the genesyscloud_flow_versions data source to list all the versions.",
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem:        flowVersionResource,
+			},
 		},
 	}
 }
 
+var deploymentMessageResource = &schema.Resource{
+	Schema: map[string]*schema.Schema{
+		"type": {
+			Description: "Type of the message.",
+			Type:        schema.TypeString,
+			Computed:    true,
+		},
+		"text": {
+			Description: "Text of the message.",
+			Type:        schema.TypeString,
+			Computed:    true,
+		},
+		"line": {
+			Description: "Line of the flow file the message refers to. 0 if the message does not reference a line.",
+			Type:        schema.TypeInt,
+			Computed:    true,
+		},
+		"date_time": {
+			Description: "Date time of the message in ISO-8601 format.",
+			Type:        schema.TypeString,
+			Computed:    true,
+		},
+	},
+}
+
+var flowVersionResource = &schema.Resource{
+	Schema: map[string]*schema.Schema{
+		"version": {
+			Description: "ID of the version.",
+			Type:        schema.TypeString,
+			Computed:    true,
+		},
+		"commit_version": {
+			Description: "Commit version of the version.",
+			Type:        schema.TypeString,
+			Computed:    true,
+		},
+		"secure": {
+			Description: "True if the version is a secure flow version.",
+			Type:        schema.TypeBool,
+			Computed:    true,
+		},
+		"debug": {
+			Description: "True if the version is a debug version.",
+			Type:        schema.TypeBool,
+			Computed:    true,
+		},
+		"date_created": {
+			Description: "Date the version was created, in RFC 3339 format.",
+			Type:        schema.TypeString,
+			Computed:    true,
+		},
+		"date_published": {
+			Description: "Date the version was published, in RFC 3339 format. Empty if the version was never published.",
+			Type:        schema.TypeString,
+			Computed:    true,
+		},
+		"created_by_id": {
+			Description: "ID of the user or OAuth client which created the version.",
+			Type:        schema.TypeString,
+			Computed:    true,
+		},
+		"created_by": {
+			Description: "Name of the user or OAuth client which created the version.",
+			Type:        schema.TypeString,
+			Computed:    true,
+		},
+	},
+}
+
+var validationMessageResource = &schema.Resource{
+	Schema: map[string]*schema.Schema{
+		"message": {
+			Description: "Description of the problem.",
+			Type:        schema.TypeString,
+			Computed:    true,
+		},
+		"line": {
+			Description: "Line of the flow file the problem was found at. 0 if the problem does not apply to a specific line.",
+			Type:        schema.TypeInt,
+			Computed:    true,
+		},
+		"column": {
+			Description: "Column of the flow file the problem was found at. 0 if the problem does not apply to a specific column.",
+			Type:        schema.TypeInt,
+			Computed:    true,
+		},
+	},
+}
+
 var validFlowTypes = []string{
 	"bot",
 	"commonmodule",
@@ -128,3 +290,138 @@ func DataSourceArchitectFlow() *schema.Resource {
 		},
 	}
 }
+
+func DataSourceFlowValidation() *schema.Resource {
+	return &schema.Resource{
+		Description: `Data source for validating Genesys Cloud Flow YAML files. The file is validated after the substitutions are applied and the errors and warnings found are returned with the line and column they apply to.
+The file is checked by the provider and then validated by Architect, which deploys it as a temporary flow with a unique name and deletes that flow once the validation finished. This requires the permissions needed to publish and delete flows. Errors fail the read of the data source.`,
+		ReadContext: provider.ReadWithPooledClient(dataSourceFlowValidationRead),
+		Schema: map[string]*schema.Schema{
+			"filepath": {
+				Description:  "YAML file path or URL of the flow configuration.",
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validators.ValidatePath,
+			},
+			"substitutions": {
+				Description: "A substitution is a key value pair where the key is the value you want to replace, and the value is the value to substitute in its place.",
+				Type:        schema.TypeMap,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			"template_engine": {
+				Description:  "Template engine used to render the YAML file before the substitutions are applied. Valid options: " + strings.Join(templates.Engines, ", ") + ". The line and column of the errors and warnings refer to the rendered file.",
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.StringInSlice(templates.Engines, false),
+			},
+			"template_variables": {
+				Description:  "JSON encoded object of the variables passed to the template.",
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.StringIsJSON,
+			},
+			"valid": {
+				Description: "True if no errors were found.",
+				Type:        schema.TypeBool,
+				Computed:    true,
+			},
+			"flow_name": {
+				Description: "Name of the flow defined by the file.",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"flow_type": {
+				Description: "Type of the flow defined by the file.",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"content_hash": {
+				Description: "Hash value of the file content after the substitutions are applied.",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"errors": {
+				Description: "Errors found in the file. The flow would fail to publish. The column of the errors reported by Architect is 0.",
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem:        validationMessageResource,
+			},
+			"warnings": {
+				Description: "Warnings found in the file.",
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem:        validationMessageResource,
+			},
+		},
+	}
+}
+
+func DataSourceFlowVersions() *schema.Resource {
+	return &schema.Resource{
+		Description: "Data source for the versions of a Genesys Cloud Flow. Versions are listed from the newest to the oldest.",
+		ReadContext: provider.ReadWithPooledClient(dataSourceFlowVersionsRead),
+		Schema: map[string]*schema.Schema{
+			"flow_id": {
+				Description: "ID of the flow.",
+				Type:        schema.TypeString,
+				Required:    true,
+			},
+			"published_version": {
+				Description: "ID of the published version of the flow.",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"latest_version": {
+				Description: "ID of the most recent version of the flow.",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"versions": {
+				Description: "Versions of the flow, from the newest to the oldest.",
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem:        flowVersionResource,
+			},
+		},
+	}
+}
+
+func ResourceFlowPublish() *schema.Resource {
+	return &schema.Resource{
+		Description: `Genesys Cloud Flow Publish. Publishes an existing version of a flow, for example to roll back to a previous version.
+If another version of the flow is published outside of this resource, the configured version is published again on the next apply. Destroying this resource does not change the published version of the flow.`,
+
+		CreateContext: provider.CreateWithPooledClient(createFlowPublish),
+		UpdateContext: provider.UpdateWithPooledClient(updateFlowPublish),
+		ReadContext:   provider.ReadWithPooledClient(readFlowPublish),
+		DeleteContext: provider.DeleteWithPooledClient(deleteFlowPublish),
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(10 * time.Minute),
+			Update: schema.DefaultTimeout(10 * time.Minute),
+		},
+		SchemaVersion: 1,
+		Schema: map[string]*schema.Schema{
+			"flow_id": {
+				Description: "ID of the flow.",
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+			},
+			"version": {
+				Description:  "ID of the existing version of the flow to publish, e.g. `3.0`.",
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+			"date_published": {
+				Description: "Date the published version of the flow was published, in RFC 3339 format.",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+		},
+	}
+}
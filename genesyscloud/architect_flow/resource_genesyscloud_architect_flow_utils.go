@@ -1,13 +1,20 @@
 package architect_flow
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"log"
 	"strconv"
 	"strings"
+	"terraform-provider-genesyscloud/genesyscloud/util/templates"
 	"terraform-provider-genesyscloud/genesyscloud/util/testrunner"
 
+	"terraform-provider-genesyscloud/genesyscloud/util"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/mypurecloud/platform-client-sdk-go/v149/platformclientv2"
 )
 
 func isForceUnlockEnabled(d *schema.ResourceData) bool {
@@ -20,6 +27,77 @@ func isForceUnlockEnabled(d *schema.ResourceData) bool {
 	return false
 }
 
+// readFlowResource returns the content of the flow file of the resource after the template is rendered and the substitutions are
+// applied. In validate only mode the content is checked by the provider and then validated by Architect. Errors fail the apply and
+// warnings are returned as warning diagnostics.
+func readFlowResource(ctx context.Context, d *schema.ResourceData, p *architectFlowProxy) (string, diag.Diagnostics) {
+	filePath := d.Get("filepath").(string)
+	templateEngine := d.Get("template_engine").(string)
+	templateVariables := d.Get("template_variables").(string)
+	substitutions := d.Get("substitutions").(map[string]interface{})
+
+	if !d.Get("validate_only").(bool) {
+		originalContent, err := readFlowFile(filePath, templateEngine, templateVariables)
+		if err != nil {
+			return "", util.BuildDiagnosticError(ResourceType, fmt.Sprintf("failed to read flow file %s", filePath), err)
+		}
+		return templates.ApplySubstitutions(originalContent, substitutions), nil
+	}
+
+	result, content, diagErr := validateFlowContent(ctx, p, filePath, templateEngine, templateVariables, substitutions)
+	if diagErr.HasError() {
+		return "", diagErr
+	}
+	_ = d.Set("name", result.flowName)
+	_ = d.Set("type", result.flowType)
+	if !result.valid() {
+		return "", append(diagErr, util.BuildDiagnosticError(ResourceType, fmt.Sprintf("flow file %s failed validation", filePath), errors.New(result.summary(validationSeverityError)))...)
+	}
+	return content, diagErr
+}
+
+// validateFlowContent checks the flow file with the provider and validates it with Architect when the check finds no errors. The warnings
+// of the validation are returned as a warning diagnostic.
+func validateFlowContent(ctx context.Context, p *architectFlowProxy, filePath, templateEngine, templateVariables string, substitutions map[string]interface{}) (*flowValidationResult, string, diag.Diagnostics) {
+	result, content, err := validateFlowFile(filePath, templateEngine, templateVariables, substitutions)
+	if err != nil {
+		return nil, "", util.BuildDiagnosticError(ResourceType, fmt.Sprintf("failed to read flow file %s", filePath), err)
+	}
+
+	var diagErr diag.Diagnostics
+	if result.valid() {
+		diagErr = validateFlowWithArchitect(ctx, p, filePath, content, result)
+		if diagErr.HasError() {
+			return nil, "", diagErr
+		}
+	}
+
+	if warnings := result.warnings(); len(warnings) > 0 {
+		diagErr = append(diagErr, diag.Diagnostic{
+			Severity: diag.Warning,
+			Summary:  fmt.Sprintf("flow file %s has %d validation warnings", filePath, len(warnings)),
+			Detail:   result.summary(validationSeverityWarning),
+		})
+	}
+	return result, content, diagErr
+}
+
+// setRenderedContentHash sets the hash of the uploaded content of templated flow files
+func setRenderedContentHash(d *schema.ResourceData, content string) {
+	if d.Get("template_engine").(string) == "" {
+		_ = d.Set("rendered_content_hash", "")
+		return
+	}
+	_ = d.Set("rendered_content_hash", templates.HashContent(content))
+}
+
+func flattenFlowVersionId(version *platformclientv2.Flowversion) string {
+	if version == nil || version.Id == nil {
+		return ""
+	}
+	return *version.Id
+}
+
 func GenerateFlowResource(resourceLabel, srcFile, fileContent string, forceUnlock bool, substitutions ...string) string {
 	fullyQualifiedPath, _ := testrunner.NormalizePath(srcFile)
 
@@ -42,4 +120,5 @@ func GenerateFlowResource(resourceLabel, srcFile, fileContent string, forceUnloc
 // in the file content hash and re-attempt an update, should the user re-run terraform apply without making changes to the file contents
 func setFileContentHashToNil(d *schema.ResourceData) {
 	_ = d.Set("file_content_hash", nil)
+	_ = d.Set("rendered_content_hash", nil)
 }
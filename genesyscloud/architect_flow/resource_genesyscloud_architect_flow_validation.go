@@ -0,0 +1,398 @@
+package architect_flow
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"terraform-provider-genesyscloud/genesyscloud/util"
+	"terraform-provider-genesyscloud/genesyscloud/util/files"
+	"terraform-provider-genesyscloud/genesyscloud/util/templates"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/mypurecloud/platform-client-sdk-go/v149/platformclientv2"
+	"gopkg.in/yaml.v3"
+)
+
+/*
+The resource_genesyscloud_architect_flow_validation.go file validates flow YAML files before they are deployed.
+The file is first checked by the provider after the substitutions are applied: it must parse and have the structure expected by
+Architect. Files passing the check are validated by Architect. The Architect jobs API publishes every file it receives, so the file is
+deployed as a temporary flow with a unique name which is deleted once the job finished. Errors and warnings reference the line and
+column of the file they apply to.
+*/
+
+const (
+	validationSeverityError   = "error"
+	validationSeverityWarning = "warning"
+)
+
+var (
+	yamlErrorLinePattern      = regexp.MustCompile(`line (\d+)`)
+	unresolvedSubstitution    = regexp.MustCompile(`{{\s*([^{}]+?)\s*}}`)
+	deploymentMessageLocation = regexp.MustCompile(`(?i)line:?\s*(\d+)`)
+)
+
+// flowValidationMessage is an error or warning found in a flow YAML file. Line and column are 0 when the message does not apply to a
+// specific location of the file.
+type flowValidationMessage struct {
+	severity string
+	message  string
+	line     int
+	column   int
+}
+
+// flowValidationResult holds the outcome of the validation of a flow YAML file
+type flowValidationResult struct {
+	flowType string
+	flowName string
+	nameNode *yaml.Node
+	messages []flowValidationMessage
+}
+
+func (r *flowValidationResult) addError(message string, line, column int) {
+	r.messages = append(r.messages, flowValidationMessage{severity: validationSeverityError, message: message, line: line, column: column})
+}
+
+func (r *flowValidationResult) addWarning(message string, line, column int) {
+	r.messages = append(r.messages, flowValidationMessage{severity: validationSeverityWarning, message: message, line: line, column: column})
+}
+
+func (r *flowValidationResult) filter(severity string) []flowValidationMessage {
+	var messages []flowValidationMessage
+	for _, message := range r.messages {
+		if message.severity == severity {
+			messages = append(messages, message)
+		}
+	}
+	return messages
+}
+
+func (r *flowValidationResult) errors() []flowValidationMessage {
+	return r.filter(validationSeverityError)
+}
+
+func (r *flowValidationResult) warnings() []flowValidationMessage {
+	return r.filter(validationSeverityWarning)
+}
+
+func (r *flowValidationResult) valid() bool {
+	return len(r.errors()) == 0
+}
+
+// summary returns the messages of the given severity as one message per line
+func (r *flowValidationResult) summary(severity string) string {
+	var lines []string
+	for _, message := range r.filter(severity) {
+		lines = append(lines, message.String())
+	}
+	return strings.Join(lines, "\n")
+}
+
+func (m flowValidationMessage) String() string {
+	if m.line > 0 {
+		return fmt.Sprintf("line %d, column %d: %s", m.line, m.column, m.message)
+	}
+	return m.message
+}
+
+// validateFlowFile reads the flow YAML file from a path or URL, renders it when a template engine is set, and validates its content after
+// the substitutions are applied the same way they are applied on upload. The content after substitutions is returned with the result.
+func validateFlowFile(filePath, templateEngine, templateVariables string, substitutions map[string]interface{}) (*flowValidationResult, string, error) {
+	originalContent, err := readFlowFile(filePath, templateEngine, templateVariables)
+	if err != nil {
+		return nil, "", err
+	}
+	content := templates.ApplySubstitutions(originalContent, substitutions)
+	return validateFlowYaml(originalContent, content, substitutions), content, nil
+}
+
+// readFlowFile returns the content of the flow YAML file before the substitutions are applied
+func readFlowFile(filePath, templateEngine, templateVariables string) (string, error) {
+	if templateEngine != "" {
+		variables, err := templates.DecodeVariables(templateVariables)
+		if err != nil {
+			return "", err
+		}
+		return templates.RenderFile(filePath, templateEngine, variables)
+	}
+
+	reader, file, err := files.DownloadOrOpenFile(filePath)
+	if err != nil {
+		return "", err
+	}
+	if file != nil {
+		defer file.Close()
+	}
+
+	content, err := io.ReadAll(reader)
+	if err != nil {
+		return "", fmt.Errorf("failed to read flow file %s: %w", filePath, err)
+	}
+	return string(content), nil
+}
+
+// validateFlowYaml checks the content of a flow YAML file after substitutions. The substitutions are passed to report the ones which
+// do not match any placeholder of the original file.
+func validateFlowYaml(originalContent, content string, substitutions map[string]interface{}) *flowValidationResult {
+	result := &flowValidationResult{}
+
+	for _, key := range sortedSubstitutionKeys(substitutions) {
+		if !strings.Contains(originalContent, fmt.Sprintf("{{%s}}", key)) {
+			result.addWarning(fmt.Sprintf("substitution '%s' does not match any placeholder of the file", key), 0, 0)
+		}
+	}
+
+	var document yaml.Node
+	if err := yaml.Unmarshal([]byte(content), &document); err != nil {
+		line := 0
+		if match := yamlErrorLinePattern.FindStringSubmatch(err.Error()); match != nil {
+			line, _ = strconv.Atoi(match[1])
+		}
+		result.addError(strings.TrimPrefix(err.Error(), "yaml: "), line, 0)
+		return result
+	}
+
+	if len(document.Content) == 0 {
+		result.addError("the flow file is empty", 0, 0)
+		return result
+	}
+	checkUnresolvedSubstitutions(content, result)
+
+	root := document.Content[0]
+	if root.Kind != yaml.MappingNode {
+		result.addError("the flow file must contain a mapping with the flow type as its only key", root.Line, root.Column)
+		return result
+	}
+	checkDuplicateKeys(root, result)
+
+	if len(root.Content) != 2 {
+		result.addError(fmt.Sprintf("the flow file must contain exactly one flow type key, found %d", len(root.Content)/2), root.Line, root.Column)
+		return result
+	}
+
+	typeNode, flowNode := root.Content[0], root.Content[1]
+	result.flowType = strings.ToLower(typeNode.Value)
+	if !isValidFlowType(result.flowType) {
+		result.addWarning(fmt.Sprintf("'%s' is not a known flow type. Known flow types: %s", typeNode.Value, strings.Join(validFlowTypes, ", ")), typeNode.Line, typeNode.Column)
+	}
+
+	if flowNode.Kind != yaml.MappingNode {
+		result.addError(fmt.Sprintf("the '%s' flow definition must be a mapping", typeNode.Value), flowNode.Line, flowNode.Column)
+		return result
+	}
+
+	nameNode := mappingValue(flowNode, "name")
+	if nameNode == nil || strings.TrimSpace(nameNode.Value) == "" {
+		result.addError("the flow name is missing", flowNode.Line, flowNode.Column)
+	} else {
+		result.flowName = nameNode.Value
+		result.nameNode = nameNode
+	}
+	return result
+}
+
+// validateFlowWithArchitect validates the content of a flow file which passed the check of the provider with Architect. The content is
+// deployed under a temporary name so the flow it defines is not changed, the messages of the Architect job are added to the result
+// and the temporary flow is deleted.
+func validateFlowWithArchitect(ctx context.Context, p *architectFlowProxy, filePath, content string, result *flowValidationResult) diag.Diagnostics {
+	validationName := fmt.Sprintf("%s (validation %s)", result.flowName, uuid.NewString()[:8])
+	validationContent, err := renameFlow(content, result.nameNode, validationName)
+	if err != nil {
+		return util.BuildDiagnosticError(ResourceType, fmt.Sprintf("failed to prepare flow file %s for validation", filePath), err)
+	}
+
+	log.Printf("Validating flow file %s with Architect as flow %s", filePath, validationName)
+	flowJob, diagErr := runFlowDeployJob(ctx, p, filePath, validationContent)
+	if diagErr != nil {
+		return diagErr
+	}
+
+	addDeploymentMessages(result, flowJob.Messages)
+	if *flowJob.Status == flowJobStatusFailure && result.valid() {
+		result.addError(fmt.Sprintf("Architect job %s failed without error messages", *flowJob.Id), 0, 0)
+	}
+
+	if flowJob.Flow != nil && flowJob.Flow.Id != nil {
+		log.Printf("Deleting validation flow %s", *flowJob.Flow.Id)
+		if diagErr := deleteFlowWithRetries(ctx, p, *flowJob.Flow.Id); diagErr != nil {
+			return diag.Diagnostics{{
+				Severity: diag.Warning,
+				Summary:  fmt.Sprintf("Failed to delete the validation flow %s (%s)", validationName, *flowJob.Flow.Id),
+				Detail:   fmt.Sprintf("The flow was published to validate %s and must be deleted manually: %v", filePath, diagErr),
+			}}
+		}
+	}
+	return nil
+}
+
+// renameFlow replaces the flow name in the content. Only the value is replaced so the lines of the content do not change and the lines
+// reported by Architect match the lines of the file.
+func renameFlow(content string, nameNode *yaml.Node, name string) (string, error) {
+	if nameNode == nil || nameNode.Kind != yaml.ScalarNode || nameNode.Style&(yaml.LiteralStyle|yaml.FoldedStyle) != 0 {
+		return "", fmt.Errorf("the flow name must be a single line value")
+	}
+	lines := strings.Split(content, "\n")
+	if nameNode.Line < 1 || nameNode.Line > len(lines) {
+		return "", fmt.Errorf("line %d of the flow name is not in the file", nameNode.Line)
+	}
+
+	line := []rune(lines[nameNode.Line-1])
+	start := nameNode.Column - 1
+	if start < 0 || start >= len(line) {
+		return "", fmt.Errorf("column %d of the flow name is not in line %d", nameNode.Column, nameNode.Line)
+	}
+	end := scalarEnd(line, start, nameNode)
+	if end < 0 {
+		return "", fmt.Errorf("the flow name at line %d must be a single line value", nameNode.Line)
+	}
+
+	lines[nameNode.Line-1] = string(line[:start]) + strconv.Quote(name) + string(line[end:])
+	return strings.Join(lines, "\n"), nil
+}
+
+// scalarEnd returns the position following the scalar starting at start, or -1 if the scalar does not end on the line
+func scalarEnd(line []rune, start int, node *yaml.Node) int {
+	switch {
+	case node.Style&yaml.DoubleQuotedStyle != 0:
+		for i := start + 1; i < len(line); i++ {
+			if line[i] == '\\' {
+				i++
+			} else if line[i] == '"' {
+				return i + 1
+			}
+		}
+	case node.Style&yaml.SingleQuotedStyle != 0:
+		for i := start + 1; i < len(line); i++ {
+			if line[i] == '\'' {
+				if i+1 < len(line) && line[i+1] == '\'' {
+					i++
+					continue
+				}
+				return i + 1
+			}
+		}
+	default:
+		if end := start + len([]rune(node.Value)); end <= len(line) && string(line[start:end]) == node.Value {
+			return end
+		}
+	}
+	return -1
+}
+
+// addDeploymentMessages adds the error and warning messages of an Architect job to the result. Other messages are informational.
+func addDeploymentMessages(result *flowValidationResult, messages *[]platformclientv2.Architectjobmessage) {
+	for _, message := range flattenDeploymentMessages(messages) {
+		messageMap := message.(map[string]interface{})
+		text, line := messageMap["text"].(string), messageMap["line"].(int)
+		switch messageType := strings.ToLower(messageMap["type"].(string)); {
+		case strings.Contains(messageType, "error"):
+			result.addError(text, line, 0)
+		case strings.Contains(messageType, "warn"):
+			result.addWarning(text, line, 0)
+		}
+	}
+}
+
+// checkDuplicateKeys reports keys defined more than once in the same mapping. Architect only keeps one of the values.
+func checkDuplicateKeys(node *yaml.Node, result *flowValidationResult) {
+	if node.Kind == yaml.MappingNode {
+		seen := make(map[string]*yaml.Node)
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			key := node.Content[i]
+			if first, exists := seen[key.Value]; exists {
+				result.addError(fmt.Sprintf("key '%s' is already defined at line %d", key.Value, first.Line), key.Line, key.Column)
+			} else {
+				seen[key.Value] = key
+			}
+		}
+	}
+	for _, child := range node.Content {
+		checkDuplicateKeys(child, result)
+	}
+}
+
+// checkUnresolvedSubstitutions reports the placeholders left in the file after the substitutions were applied
+func checkUnresolvedSubstitutions(content string, result *flowValidationResult) {
+	for i, line := range strings.Split(content, "\n") {
+		for _, match := range unresolvedSubstitution.FindAllStringSubmatchIndex(line, -1) {
+			name := line[match[2]:match[3]]
+			result.addWarning(fmt.Sprintf("placeholder '{{%s}}' has no substitution", name), i+1, match[0]+1)
+		}
+	}
+}
+
+func mappingValue(node *yaml.Node, key string) *yaml.Node {
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value == key {
+			return node.Content[i+1]
+		}
+	}
+	return nil
+}
+
+func isValidFlowType(flowType string) bool {
+	for _, validType := range validFlowTypes {
+		if validType == flowType {
+			return true
+		}
+	}
+	return false
+}
+
+func sortedSubstitutionKeys(substitutions map[string]interface{}) []string {
+	keys := make([]string, 0, len(substitutions))
+	for key := range substitutions {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func flattenFlowValidationMessages(messages []flowValidationMessage) []interface{} {
+	result := make([]interface{}, len(messages))
+	for i, message := range messages {
+		result[i] = map[string]interface{}{
+			"message": message.message,
+			"line":    message.line,
+			"column":  message.column,
+		}
+	}
+	return result
+}
+
+// flattenDeploymentMessages flattens the tracing messages of an Architect job. The line of the flow file is extracted from the
+// message text when Architect includes it.
+func flattenDeploymentMessages(messages *[]platformclientv2.Architectjobmessage) []interface{} {
+	if messages == nil {
+		return nil
+	}
+	result := make([]interface{}, 0, len(*messages))
+	for _, message := range *messages {
+		messageMap := map[string]interface{}{
+			"type": "",
+			"text": "",
+			"line": 0,
+		}
+		if message.VarType != nil {
+			messageMap["type"] = *message.VarType
+		}
+		if message.Text != nil {
+			messageMap["text"] = *message.Text
+			if match := deploymentMessageLocation.FindStringSubmatch(*message.Text); match != nil {
+				messageMap["line"], _ = strconv.Atoi(match[1])
+			}
+		}
+		if message.DateTime != nil {
+			messageMap["date_time"] = message.DateTime.Format(time.RFC3339)
+		}
+		result = append(result, messageMap)
+	}
+	return result
+}
@@ -8,9 +8,11 @@ import (
 	"os"
 	"strings"
 	"terraform-provider-genesyscloud/genesyscloud/provider"
+	"terraform-provider-genesyscloud/genesyscloud/tfexporter_state"
 	"terraform-provider-genesyscloud/genesyscloud/util"
 	"time"
 
+	"github.com/google/uuid"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/retry"
 
 	resourceExporter "terraform-provider-genesyscloud/genesyscloud/resource_exporter"
@@ -22,6 +24,13 @@ import (
 	"github.com/mypurecloud/platform-client-sdk-go/v149/platformclientv2"
 )
 
+const (
+	flowJobStatusSuccess = "Success"
+	flowJobStatusFailure = "Failure"
+)
+
+var flowJobPollInterval = 15 * time.Second
+
 func getAllFlows(ctx context.Context, clientConfig *platformclientv2.Configuration) (resourceExporter.ResourceIDMetaMap, diag.Diagnostics) {
 	resources := make(resourceExporter.ResourceIDMetaMap)
 	p := getArchitectFlowProxy(clientConfig)
@@ -50,6 +59,11 @@ func getAllFlows(ctx context.Context, clientConfig *platformclientv2.Configurati
 }
 
 func readFlow(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	if d.Get("validate_only").(bool) {
+		// No flow is published in validate only mode
+		return nil
+	}
+
 	sdkConfig := meta.(*provider.ProviderMeta).ClientConfig
 
 	proxy := getArchitectFlowProxy(sdkConfig)
@@ -65,6 +79,21 @@ func readFlow(ctx context.Context, d *schema.ResourceData, meta interface{}) dia
 
 		resourcedata.SetNillableValue(d, "name", flow.Name)
 		resourcedata.SetNillableValue(d, "type", flow.VarType)
+		_ = d.Set("published_version", flattenFlowVersionId(flow.PublishedVersion))
+		_ = d.Set("checked_in_version", flattenFlowVersionId(flow.CheckedInVersion))
+		_ = d.Set("locked", flow.LockedUser != nil || flow.LockedClient != nil)
+		_ = d.Set("validate_only", false)
+
+		// The version history is not needed to export flows. It is informational, so it is left unchanged when it cannot be read.
+		if !tfexporter_state.IsExporterActive() {
+			versions, _, err := proxy.GetLatestFlowVersions(ctx, d.Id(), maxFlowVersionHistory)
+			if err != nil {
+				log.Printf("WARNING: failed to read the version history of flow %s, keeping the previous history: %s", d.Id(), err)
+			} else {
+				sortFlowVersions(*versions)
+				_ = d.Set("version_history", flattenFlowVersions(*versions, maxFlowVersionHistory))
+			}
+		}
 
 		log.Printf("Read flow %s %s", d.Id(), *flow.Name)
 		return nil
@@ -82,6 +111,21 @@ func updateFlow(ctx context.Context, d *schema.ResourceData, meta interface{}) d
 
 	log.Printf("Updating flow")
 
+	content, diagErr := readFlowResource(ctx, d, p)
+	if diagErr.HasError() {
+		setFileContentHashToNil(d)
+		return diagErr
+	}
+
+	if d.Get("validate_only").(bool) {
+		if d.Id() == "" {
+			d.SetId(uuid.NewString())
+		}
+		setRenderedContentHash(d, content)
+		log.Printf("Validated flow file %s", d.Get("filepath").(string))
+		return diagErr
+	}
+
 	//Check to see if we need to force and unlock on an architect flow
 	if isForceUnlockEnabled(d) {
 		resp, err := p.ForceUnlockFlow(ctx, d.Id())
@@ -91,7 +135,46 @@ func updateFlow(ctx context.Context, d *schema.ResourceData, meta interface{}) d
 		}
 	}
 
-	flowJob, response, err := p.CreateFlowsDeployJob(ctx)
+	flowJob, jobErr := runFlowDeployJob(ctx, p, d.Get("filepath").(string), content)
+	if flowJob != nil {
+		_ = d.Set("last_deployment_job_id", flowJob.Id)
+		_ = d.Set("last_deployment_status", flowJob.Status)
+		_ = d.Set("last_deployment_messages", flattenDeploymentMessages(flowJob.Messages))
+	}
+	if jobErr != nil {
+		setFileContentHashToNil(d)
+		return jobErr
+	}
+
+	jobId := *flowJob.Id
+	if *flowJob.Status == flowJobStatusFailure {
+		setFileContentHashToNil(d)
+		if flowJob.Messages == nil {
+			return util.BuildDiagnosticError(ResourceType, fmt.Sprintf("flow publish failed. JobID: %s", jobId), fmt.Errorf("no tracing messages available"))
+		}
+		messages := make([]string, 0)
+		for _, m := range *flowJob.Messages {
+			messages = append(messages, *m.Text)
+		}
+		return util.BuildDiagnosticError(ResourceType, fmt.Sprintf("flow publish failed. JobID: %s", jobId), fmt.Errorf("tracing messages: %v", strings.Join(messages, "\n\n")))
+	}
+
+	if flowJob.Flow == nil || flowJob.Flow.Id == nil {
+		setFileContentHashToNil(d)
+		return util.BuildDiagnosticError(ResourceType, fmt.Sprintf("Failed to get the flowId from Architect Job (%s).", jobId), fmt.Errorf("FlowID is nil"))
+	}
+
+	d.SetId(*flowJob.Flow.Id)
+	setRenderedContentHash(d, content)
+
+	log.Printf("Updated flow %s. ", d.Id())
+	return append(diagErr, readFlow(ctx, d, meta)...)
+}
+
+// runFlowDeployJob uploads the flow content with an Architect job and waits for the job to finish. The state of the job is returned
+// when it succeeds or fails, so the caller can report its messages, and with the error when it does not finish in time.
+func runFlowDeployJob(ctx context.Context, p *architectFlowProxy, filePath, content string) (*platformclientv2.Architectjobstateresponse, diag.Diagnostics) {
+	registeredJob, response, err := p.CreateFlowsDeployJob(ctx)
 
 	if err != nil || response.Error != nil {
 		var errorString string
@@ -100,80 +183,47 @@ func updateFlow(ctx context.Context, d *schema.ResourceData, meta interface{}) d
 		} else {
 			errorString = response.ErrorMessage
 		}
-		setFileContentHashToNil(d)
-		return util.BuildAPIDiagnosticError(ResourceType, fmt.Sprintf("Failed to register job %s", errorString), response)
+		return nil, util.BuildAPIDiagnosticError(ResourceType, fmt.Sprintf("Failed to register job %s", errorString), response)
 	}
 
-	presignedUrl := *flowJob.PresignedUrl
-	jobId := *flowJob.Id
-	headers := *flowJob.Headers
-
-	filePath := d.Get("filepath").(string)
-	substitutions := d.Get("substitutions").(map[string]interface{})
+	presignedUrl := *registeredJob.PresignedUrl
+	jobId := *registeredJob.Id
+	headers := *registeredJob.Headers
 
-	reader, _, err := files.DownloadOrOpenFile(filePath)
-	if err != nil {
-		setFileContentHashToNil(d)
-		return diag.FromErr(err)
-	}
-
-	s3Uploader := files.NewS3Uploader(reader, nil, substitutions, headers, "PUT", presignedUrl)
+	s3Uploader := files.NewS3Uploader(strings.NewReader(content), nil, nil, headers, "PUT", presignedUrl)
 
 	_, uploadErr := s3Uploader.UploadWithRetries(ctx, filePath, 20*time.Second)
 	if uploadErr != nil {
-		setFileContentHashToNil(d)
-		return diag.FromErr(uploadErr)
+		return nil, diag.FromErr(uploadErr)
 	}
 
-	// Pre-define here before entering retry function, otherwise it will be overwritten
-	flowID := ""
-
+	var flowJob *platformclientv2.Architectjobstateresponse
 	retryErr := util.WithRetries(ctx, 16*time.Minute, func() *retry.RetryError {
-		flowJob, response, err := p.GetFlowsDeployJob(ctx, jobId)
+		job, response, err := p.GetFlowsDeployJob(ctx, jobId)
 		if err != nil {
 			return retry.NonRetryableError(util.BuildWithRetriesApiDiagnosticError(ResourceType, fmt.Sprintf("Error retrieving job status. JobID: %s, error: %s ", jobId, err), response))
 		}
+		flowJob = job
 
-		if *flowJob.Status == "Failure" {
-			if flowJob.Messages == nil {
-				return retry.NonRetryableError(util.BuildWithRetriesApiDiagnosticError(ResourceType, fmt.Sprintf("flow publish failed. JobID: %s, no tracing messages available", jobId), response))
-			}
-			messages := make([]string, 0)
-			for _, m := range *flowJob.Messages {
-				messages = append(messages, *m.Text)
-			}
-			return retry.NonRetryableError(util.BuildWithRetriesApiDiagnosticError(ResourceType, fmt.Sprintf("flow publish failed. JobID: %s, tracing messages: %v ", jobId, strings.Join(messages, "\n\n")), response))
-		}
-
-		if *flowJob.Status == "Success" {
-			flowID = *flowJob.Flow.Id
+		if *job.Status == flowJobStatusFailure || *job.Status == flowJobStatusSuccess {
 			return nil
 		}
 
-		time.Sleep(15 * time.Second) // Wait 15 seconds for next retry
+		time.Sleep(flowJobPollInterval)
 		return retry.RetryableError(util.BuildWithRetriesApiDiagnosticError(ResourceType, fmt.Sprintf("Job (%s) could not finish in 16 minutes and timed out ", jobId), response))
 	})
-
-	if retryErr != nil {
-		setFileContentHashToNil(d)
-		return retryErr
-	}
-
-	if flowID == "" {
-		setFileContentHashToNil(d)
-		return util.BuildDiagnosticError(ResourceType, fmt.Sprintf("Failed to get the flowId from Architect Job (%s).", jobId), fmt.Errorf("FlowID is nil"))
-	}
-
-	d.SetId(flowID)
-
-	log.Printf("Updated flow %s. ", d.Id())
-	return readFlow(ctx, d, meta)
+	return flowJob, retryErr
 }
 
 func deleteFlow(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	sdkConfig := meta.(*provider.ProviderMeta).ClientConfig
 	p := getArchitectFlowProxy(sdkConfig)
 
+	if d.Get("validate_only").(bool) {
+		log.Printf("Removing validate only flow %s from state", d.Id())
+		return nil
+	}
+
 	log.Printf("Deleting flow %s", d.Id())
 
 	//Check to see if we need to force
@@ -184,18 +234,23 @@ func deleteFlow(ctx context.Context, d *schema.ResourceData, meta interface{}) d
 		}
 	}
 
+	return deleteFlowWithRetries(ctx, p, d.Id())
+}
+
+// deleteFlowWithRetries deletes the flow, retrying while the flow is still in use by an Architect job
+func deleteFlowWithRetries(ctx context.Context, p *architectFlowProxy, flowId string) diag.Diagnostics {
 	return util.WithRetries(ctx, 30*time.Second, func() *retry.RetryError {
-		resp, err := p.DeleteFlow(ctx, d.Id())
+		resp, err := p.DeleteFlow(ctx, flowId)
 		if err != nil {
 			if util.IsStatus404(resp) {
 				// Flow deleted
-				log.Printf("Deleted Flow %s", d.Id())
+				log.Printf("Deleted Flow %s", flowId)
 				return nil
 			}
 			if resp.StatusCode == http.StatusConflict {
-				return retry.RetryableError(util.BuildWithRetriesApiDiagnosticError(ResourceType, fmt.Sprintf("error deleting flow %s | error: %s", d.Id(), err), resp))
+				return retry.RetryableError(util.BuildWithRetriesApiDiagnosticError(ResourceType, fmt.Sprintf("error deleting flow %s | error: %s", flowId, err), resp))
 			}
-			return retry.NonRetryableError(util.BuildWithRetriesApiDiagnosticError(ResourceType, fmt.Sprintf("error deleting flow %s | error: %s", d.Id(), err), resp))
+			return retry.NonRetryableError(util.BuildWithRetriesApiDiagnosticError(ResourceType, fmt.Sprintf("error deleting flow %s | error: %s", flowId, err), resp))
 		}
 		return nil
 	})
@@ -0,0 +1,125 @@
+package architect_flow
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"terraform-provider-genesyscloud/genesyscloud/provider"
+	"terraform-provider-genesyscloud/genesyscloud/util"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/retry"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// flowPublishPollInterval is the time waited between checks of the published version of a flow
+var flowPublishPollInterval = 5 * time.Second
+
+func createFlowPublish(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	sdkConfig := meta.(*provider.ProviderMeta).ClientConfig
+	p := getArchitectFlowProxy(sdkConfig)
+	flowId := d.Get("flow_id").(string)
+
+	if diagErr := publishFlowVersion(ctx, d, p, d.Timeout(schema.TimeoutCreate)); diagErr != nil {
+		return diagErr
+	}
+
+	d.SetId(flowId)
+	return readFlowPublish(ctx, d, meta)
+}
+
+func readFlowPublish(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	sdkConfig := meta.(*provider.ProviderMeta).ClientConfig
+	p := getArchitectFlowProxy(sdkConfig)
+
+	log.Printf("Reading published version of flow %s", d.Id())
+
+	return util.WithRetriesForRead(ctx, d, func() *retry.RetryError {
+		flow, resp, err := p.GetFlow(ctx, d.Id())
+		if err != nil {
+			if util.IsStatus404(resp) {
+				return retry.RetryableError(util.BuildWithRetriesApiDiagnosticError(PublishResourceType, fmt.Sprintf("failed to read flow %s: %s", d.Id(), err), resp))
+			}
+			return retry.NonRetryableError(util.BuildWithRetriesApiDiagnosticError(PublishResourceType, fmt.Sprintf("failed to read flow %s: %s", d.Id(), err), resp))
+		}
+
+		// A different published version shows as a change of version so the requested version is published again
+		_ = d.Set("flow_id", d.Id())
+		_ = d.Set("version", flattenFlowVersionId(flow.PublishedVersion))
+		_ = d.Set("date_published", "")
+		if flow.PublishedVersion != nil && flow.PublishedVersion.DatePublished != nil {
+			_ = d.Set("date_published", flow.PublishedVersion.DatePublished.UTC().Format(time.RFC3339))
+		}
+
+		log.Printf("Read published version %s of flow %s", d.Get("version").(string), d.Id())
+		return nil
+	})
+}
+
+func updateFlowPublish(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	sdkConfig := meta.(*provider.ProviderMeta).ClientConfig
+	p := getArchitectFlowProxy(sdkConfig)
+
+	if d.HasChange("version") {
+		if diagErr := publishFlowVersion(ctx, d, p, d.Timeout(schema.TimeoutUpdate)); diagErr != nil {
+			return diagErr
+		}
+	}
+	return readFlowPublish(ctx, d, meta)
+}
+
+func deleteFlowPublish(_ context.Context, d *schema.ResourceData, _ interface{}) diag.Diagnostics {
+	log.Printf("Removing flow publish %s from state. The published version of the flow is not changed.", d.Id())
+	return nil
+}
+
+// publishFlowVersion publishes an existing version of a flow and waits until it becomes the published version
+func publishFlowVersion(ctx context.Context, d *schema.ResourceData, p *architectFlowProxy, timeout time.Duration) diag.Diagnostics {
+	flowId := d.Get("flow_id").(string)
+	version := d.Get("version").(string)
+
+	if _, resp, err := p.GetFlowVersion(ctx, flowId, version); err != nil {
+		return util.BuildAPIDiagnosticError(PublishResourceType, fmt.Sprintf("failed to read version %s of flow %s: %s", version, flowId, err), resp)
+	}
+
+	flow, resp, err := p.GetFlow(ctx, flowId)
+	if err != nil {
+		return util.BuildAPIDiagnosticError(PublishResourceType, fmt.Sprintf("failed to read flow %s: %s", flowId, err), resp)
+	}
+	if flattenFlowVersionId(flow.PublishedVersion) == version {
+		log.Printf("Version %s of flow %s is already published", version, flowId)
+		return nil
+	}
+
+	operation, resp, err := p.PublishFlowVersion(ctx, flowId, version)
+	if err != nil {
+		return util.BuildAPIDiagnosticError(PublishResourceType, fmt.Sprintf("failed to publish version %s of flow %s: %s", version, flowId, err), resp)
+	}
+	operationId := ""
+	if operation != nil {
+		if operation.ErrorMessage != nil {
+			return util.BuildDiagnosticError(PublishResourceType, fmt.Sprintf("failed to publish version %s of flow %s", version, flowId), fmt.Errorf("%s", *operation.ErrorMessage))
+		}
+		operationId = util.StringOrEmpty(operation.Id)
+	}
+
+	return util.WithRetries(ctx, timeout, func() *retry.RetryError {
+		flow, resp, err := p.GetFlow(ctx, flowId)
+		if err != nil {
+			return retry.NonRetryableError(util.BuildWithRetriesApiDiagnosticError(PublishResourceType, fmt.Sprintf("failed to read flow %s: %s", flowId, err), resp))
+		}
+
+		// Only errors of the publish operation started above are relevant
+		if operation := flow.CurrentOperation; operation != nil && operation.ErrorMessage != nil && util.StringOrEmpty(operation.Id) == operationId {
+			return retry.NonRetryableError(fmt.Errorf("failed to publish version %s of flow %s: %s", version, flowId, *operation.ErrorMessage))
+		}
+		if flattenFlowVersionId(flow.PublishedVersion) == version {
+			log.Printf("Published version %s of flow %s", version, flowId)
+			return nil
+		}
+
+		time.Sleep(flowPublishPollInterval)
+		return retry.RetryableError(fmt.Errorf("version %s of flow %s was not published within %v", version, flowId, timeout))
+	})
+}
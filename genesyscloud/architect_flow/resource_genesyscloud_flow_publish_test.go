@@ -0,0 +1,95 @@
+package architect_flow
+
+import (
+	"fmt"
+	"terraform-provider-genesyscloud/genesyscloud/provider"
+	"terraform-provider-genesyscloud/genesyscloud/util"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccResourceFlowPublish(t *testing.T) {
+	var (
+		flowResourceLabel    = "test_publish_flow"
+		publishResourceLabel = "test_publish"
+		versionsLabel        = "test_versions"
+		flowName             = "Terraform Flow Publish Test-" + uuid.NewString()
+		filePath             = "../../examples/resources/genesyscloud_flow/inboundcall_flow_example.yaml"
+
+		inboundcallConfig  = "inboundCall:\n  name: %s\n  defaultLanguage: en-us\n  startUpRef: ./menus/menu[mainMenu]\n  initialGreeting:\n    tts: %s\n  menus:\n    - menu:\n        name: Main Menu\n        audio:\n          tts: You are at the Main Menu, press 9 to disconnect.\n        refId: mainMenu\n        choices:\n          - menuDisconnect:\n              name: Disconnect\n              dtmf: digit_9"
+		inboundcallConfig1 = fmt.Sprintf(inboundcallConfig, flowName, "Archy says hi!!!")
+		inboundcallConfig2 = fmt.Sprintf(inboundcallConfig, flowName, "Archy says hi again!!!")
+
+		flowFullPath     = ResourceType + "." + flowResourceLabel
+		publishFullPath  = PublishResourceType + "." + publishResourceLabel
+		versionsFullPath = "data." + VersionsResourceType + "." + versionsLabel
+	)
+
+	publishConfig := func(version string) string {
+		return fmt.Sprintf(`resource "%s" "%s" {
+	flow_id = %s.id
+	version = "%s"
+}
+`, PublishResourceType, publishResourceLabel, flowFullPath, version)
+	}
+
+	versionsConfig := fmt.Sprintf(`data "%s" "%s" {
+	flow_id    = %s.id
+	depends_on = [%s]
+}
+`, VersionsResourceType, versionsLabel, flowFullPath, publishFullPath)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { util.TestAccPreCheck(t) },
+		ProviderFactories: provider.GetProviderFactories(providerResources, providerDataSources),
+		Steps: []resource.TestStep{
+			{
+				// Create the first version of the flow
+				Config: GenerateFlowResource(flowResourceLabel, filePath, inboundcallConfig1, false),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(flowFullPath, "published_version", "1.0"),
+					resource.TestCheckResourceAttr(flowFullPath, "version_history.#", "1"),
+				),
+			},
+			{
+				// Publish a second version of the flow
+				Config: GenerateFlowResource(flowResourceLabel, filePath, inboundcallConfig2, false),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(flowFullPath, "published_version", "2.0"),
+					resource.TestCheckResourceAttr(flowFullPath, "version_history.#", "2"),
+					resource.TestCheckResourceAttr(flowFullPath, "version_history.0.version", "2.0"),
+				),
+			},
+			{
+				// Roll back to the first version
+				Config: GenerateFlowResource(flowResourceLabel, filePath, "", false) + publishConfig("1.0") + versionsConfig,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(publishFullPath, "version", "1.0"),
+					resource.TestCheckResourceAttrSet(publishFullPath, "date_published"),
+					resource.TestCheckResourceAttr(versionsFullPath, "published_version", "1.0"),
+					resource.TestCheckResourceAttr(versionsFullPath, "latest_version", "2.0"),
+					resource.TestCheckResourceAttr(versionsFullPath, "versions.#", "2"),
+					resource.TestCheckResourceAttrSet(versionsFullPath, "versions.0.date_created"),
+					resource.TestCheckResourceAttrSet(versionsFullPath, "versions.0.created_by_id"),
+				),
+			},
+			{
+				// Publish the second version again
+				Config: GenerateFlowResource(flowResourceLabel, filePath, "", false) + publishConfig("2.0") + versionsConfig,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(publishFullPath, "version", "2.0"),
+					resource.TestCheckResourceAttr(versionsFullPath, "published_version", "2.0"),
+				),
+			},
+			{
+				// Import/Read
+				ResourceName:      publishFullPath,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+		CheckDestroy: testVerifyFlowDestroyed,
+	})
+}
@@ -0,0 +1,70 @@
+package architect_flow
+
+import (
+	"fmt"
+	"path/filepath"
+	"strconv"
+	"terraform-provider-genesyscloud/genesyscloud/provider"
+	"terraform-provider-genesyscloud/genesyscloud/util"
+	"terraform-provider-genesyscloud/genesyscloud/util/templates"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccResourceArchFlowTemplate(t *testing.T) {
+	var (
+		flowResourceLabel = "test_template_flow"
+		flowName          = "Terraform Flow Template Test-" + uuid.NewString()
+		filePath          = filepath.Join("..", "..", "test", "data", "resource", ResourceType, "inboundcall_flow_template.yaml")
+		fullPath          = ResourceType + "." + flowResourceLabel
+	)
+
+	generateTemplateFlow := func(greeting string, digits string) string {
+		return fmt.Sprintf(`resource "%s" "%s" {
+	filepath           = %s
+	file_content_hash  = filesha256(%s)
+	template_engine    = "%s"
+	template_variables = jsonencode({
+		flow_name         = "%s"
+		greeting          = "%s"
+		disconnect_digits = %s
+	})
+}
+`, ResourceType, flowResourceLabel, strconv.Quote(filePath), strconv.Quote(filePath), templates.EngineGo, flowName, greeting, digits)
+	}
+
+	var renderedContentHash string
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { util.TestAccPreCheck(t) },
+		ProviderFactories: provider.GetProviderFactories(providerResources, providerDataSources),
+		Steps: []resource.TestStep{
+			{
+				Config: generateTemplateFlow("Hello", "[9]"),
+				Check: resource.ComposeTestCheckFunc(
+					validateFlow(fullPath, flowName, "", "INBOUNDCALL"),
+					resource.TestCheckResourceAttr(fullPath, "published_version", "1.0"),
+					resource.TestCheckResourceAttrWith(fullPath, "rendered_content_hash", func(value string) error {
+						renderedContentHash = value
+						return nil
+					}),
+				),
+			},
+			{
+				// Changing the template variables publishes a new version although the template file did not change
+				Config: generateTemplateFlow("Hello", "[8, 9]"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(fullPath, "published_version", "2.0"),
+					resource.TestCheckResourceAttrWith(fullPath, "rendered_content_hash", func(value string) error {
+						if value == renderedContentHash {
+							return fmt.Errorf("expected rendered_content_hash to change")
+						}
+						return nil
+					}),
+				),
+			},
+		},
+		CheckDestroy: testVerifyFlowDestroyed,
+	})
+}
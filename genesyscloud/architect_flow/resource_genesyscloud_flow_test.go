@@ -106,7 +106,7 @@ func TestAccResourceArchFlowForceUnlock(t *testing.T) {
 				ResourceName:            "genesyscloud_flow." + flowResourceLabel,
 				ImportState:             true,
 				ImportStateVerify:       true,
-				ImportStateVerifyIgnore: []string{"filepath", "force_unlock", "file_content_hash"},
+				ImportStateVerifyIgnore: []string{"filepath", "force_unlock", "file_content_hash", "last_deployment_job_id", "last_deployment_status", "last_deployment_messages"},
 			},
 		},
 		CheckDestroy: testVerifyFlowDestroyed,
@@ -189,7 +189,7 @@ func TestAccResourceArchFlowStandard(t *testing.T) {
 				ResourceName:            "genesyscloud_flow." + flowResourceLabel1,
 				ImportState:             true,
 				ImportStateVerify:       true,
-				ImportStateVerifyIgnore: []string{"filepath", "force_unlock", "file_content_hash"},
+				ImportStateVerifyIgnore: []string{"filepath", "force_unlock", "file_content_hash", "last_deployment_job_id", "last_deployment_status", "last_deployment_messages"},
 			},
 			{
 				// Create inboundemail flow
@@ -208,7 +208,7 @@ func TestAccResourceArchFlowStandard(t *testing.T) {
 				ResourceName:            "genesyscloud_flow." + flowResourceLabel2,
 				ImportState:             true,
 				ImportStateVerify:       true,
-				ImportStateVerifyIgnore: []string{"filepath", "force_unlock", "file_content_hash"},
+				ImportStateVerifyIgnore: []string{"filepath", "force_unlock", "file_content_hash", "last_deployment_job_id", "last_deployment_status", "last_deployment_messages"},
 			},
 		},
 		CheckDestroy: testVerifyFlowDestroyed,
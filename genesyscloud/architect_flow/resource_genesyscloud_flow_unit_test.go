@@ -0,0 +1,428 @@
+package architect_flow
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"terraform-provider-genesyscloud/genesyscloud/provider"
+	"terraform-provider-genesyscloud/genesyscloud/util/templates"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/mypurecloud/platform-client-sdk-go/v149/platformclientv2"
+	"github.com/stretchr/testify/assert"
+)
+
+const testFlowYaml = `inboundCall:
+  name: "{{flow_name}}"
+  defaultLanguage: en-us
+  description: "{{description}}"
+  startUpRef: ./menus/menu[mainMenu]
+`
+
+func TestUnitValidateFlowYaml(t *testing.T) {
+	substitutions := map[string]interface{}{"flow_name": "Test Flow", "unused": "value"}
+	content := templates.ApplySubstitutions(testFlowYaml, substitutions)
+
+	result := validateFlowYaml(testFlowYaml, content, substitutions)
+	assert.True(t, result.valid())
+	assert.Equal(t, "inboundcall", result.flowType)
+	assert.Equal(t, "Test Flow", result.flowName)
+	assert.Equal(t, []flowValidationMessage{
+		{severity: validationSeverityWarning, message: "substitution 'unused' does not match any placeholder of the file"},
+		{severity: validationSeverityWarning, message: "placeholder '{{description}}' has no substitution", line: 4, column: 17},
+	}, result.warnings())
+}
+
+func TestUnitValidateFlowYamlErrors(t *testing.T) {
+	testCases := []struct {
+		content  string
+		expected []flowValidationMessage
+	}{
+		{
+			content:  "inboundCall:\n\tname: a\n",
+			expected: []flowValidationMessage{{severity: validationSeverityError, message: "line 2: found character that cannot start any token", line: 2}},
+		},
+		{
+			content:  "",
+			expected: []flowValidationMessage{{severity: validationSeverityError, message: "the flow file is empty"}},
+		},
+		{
+			content:  "- inboundCall\n",
+			expected: []flowValidationMessage{{severity: validationSeverityError, message: "the flow file must contain a mapping with the flow type as its only key", line: 1, column: 1}},
+		},
+		{
+			content:  "inboundCall:\n  name: a\noutboundCall:\n  name: b\n",
+			expected: []flowValidationMessage{{severity: validationSeverityError, message: "the flow file must contain exactly one flow type key, found 2", line: 1, column: 1}},
+		},
+		{
+			content:  "inboundCall: flow\n",
+			expected: []flowValidationMessage{{severity: validationSeverityError, message: "the 'inboundCall' flow definition must be a mapping", line: 1, column: 14}},
+		},
+		{
+			content: "inboundCall:\n  defaultLanguage: en-us\n  defaultLanguage: en-gb\n",
+			expected: []flowValidationMessage{
+				{severity: validationSeverityError, message: "key 'defaultLanguage' is already defined at line 2", line: 3, column: 3},
+				{severity: validationSeverityError, message: "the flow name is missing", line: 2, column: 3},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		result := validateFlowYaml(tc.content, tc.content, nil)
+		assert.False(t, result.valid(), tc.content)
+		assert.Equal(t, tc.expected, result.errors(), tc.content)
+	}
+
+	result := validateFlowYaml("unknownFlow:\n  name: a\n", "unknownFlow:\n  name: a\n", nil)
+	assert.True(t, result.valid(), "unknown flow types should only be reported as warnings")
+	assert.Len(t, result.warnings(), 1)
+	assert.Equal(t, 1, result.warnings()[0].line)
+}
+
+func TestUnitFlattenDeploymentMessages(t *testing.T) {
+	dateTime := time.Date(2024, 5, 1, 12, 30, 0, 0, time.UTC)
+	messages := flattenDeploymentMessages(&[]platformclientv2.Architectjobmessage{
+		{VarType: platformclientv2.String("ERROR"), Text: platformclientv2.String("Invalid reference at line 12"), DateTime: &dateTime},
+		{VarType: platformclientv2.String("INFO"), Text: platformclientv2.String("Flow published")},
+	})
+	assert.Equal(t, []interface{}{
+		map[string]interface{}{"type": "ERROR", "text": "Invalid reference at line 12", "line": 12, "date_time": "2024-05-01T12:30:00Z"},
+		map[string]interface{}{"type": "INFO", "text": "Flow published", "line": 0},
+	}, messages)
+	assert.Nil(t, flattenDeploymentMessages(nil))
+}
+
+// flowValidationJob records the content uploaded to the Architect job and the flows deleted after it
+type flowValidationJob struct {
+	content        string
+	deletedFlowIds []string
+}
+
+// buildFlowValidationTestProxy returns a proxy whose Architect jobs finish with the given status and messages. Successful jobs create a
+// flow, which must be deleted by the validation.
+func buildFlowValidationTestProxy(t *testing.T, status string, messages []platformclientv2.Architectjobmessage) (*architectFlowProxy, *flowValidationJob) {
+	job := &flowValidationJob{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		assert.NoError(t, err)
+		job.content = string(body)
+	}))
+	t.Cleanup(server.Close)
+
+	jobId := "job-id"
+	presignedUrl := server.URL
+	return &architectFlowProxy{
+		createArchitectFlowJobsAttr: func(_ context.Context, _ *architectFlowProxy) (*platformclientv2.Registerarchitectjobresponse, *platformclientv2.APIResponse, error) {
+			return &platformclientv2.Registerarchitectjobresponse{Id: &jobId, PresignedUrl: &presignedUrl, Headers: &map[string]string{}}, &platformclientv2.APIResponse{StatusCode: http.StatusOK}, nil
+		},
+		getArchitectFlowJobsAttr: func(_ context.Context, _ *architectFlowProxy, id string) (*platformclientv2.Architectjobstateresponse, *platformclientv2.APIResponse, error) {
+			jobState := &platformclientv2.Architectjobstateresponse{Id: &id, Status: &status, Messages: &messages}
+			if status == flowJobStatusSuccess {
+				jobState.Flow = &platformclientv2.Addressableentityref{Id: platformclientv2.String("validation-flow-id")}
+			}
+			return jobState, &platformclientv2.APIResponse{StatusCode: http.StatusOK}, nil
+		},
+		deleteArchitectFlowAttr: func(_ context.Context, _ *architectFlowProxy, flowId string) (*platformclientv2.APIResponse, error) {
+			job.deletedFlowIds = append(job.deletedFlowIds, flowId)
+			return &platformclientv2.APIResponse{StatusCode: http.StatusOK}, nil
+		},
+	}, job
+}
+
+func TestUnitDataSourceFlowValidation(t *testing.T) {
+	filePath := filepath.Join(t.TempDir(), "flow.yaml")
+	assert.NoError(t, os.WriteFile(filePath, []byte(testFlowYaml), 0644))
+
+	var job *flowValidationJob
+	internalProxy, job = buildFlowValidationTestProxy(t, flowJobStatusSuccess, nil)
+	defer func() { internalProxy = nil }()
+	gcloud := &provider.ProviderMeta{ClientConfig: &platformclientv2.Configuration{}}
+
+	d := schema.TestResourceDataRaw(t, DataSourceFlowValidation().Schema, map[string]interface{}{
+		"filepath":      filePath,
+		"substitutions": map[string]interface{}{"flow_name": "Test Flow", "description": "A flow"},
+	})
+	diagErr := dataSourceFlowValidationRead(context.Background(), d, gcloud)
+	assert.False(t, diagErr.HasError())
+	assert.True(t, d.Get("valid").(bool))
+	assert.Equal(t, "Test Flow", d.Get("flow_name").(string))
+	assert.Equal(t, "inboundcall", d.Get("flow_type").(string))
+	assert.Empty(t, d.Get("warnings").([]interface{}))
+	assert.Equal(t, d.Id(), d.Get("content_hash").(string))
+	// The flow is validated under a temporary name on the same line, and deleted afterwards
+	assert.Regexp(t, `^inboundCall:\n  name: "Test Flow \(validation [0-9a-f]{8}\)"\n  defaultLanguage: en-us\n`, job.content)
+	assert.Equal(t, []string{"validation-flow-id"}, job.deletedFlowIds)
+
+	d = schema.TestResourceDataRaw(t, DataSourceFlowValidation().Schema, map[string]interface{}{
+		"filepath": filePath,
+	})
+	diagErr = dataSourceFlowValidationRead(context.Background(), d, gcloud)
+	assert.False(t, diagErr.HasError())
+	assert.True(t, d.Get("valid").(bool), "unresolved placeholders should only be reported as warnings")
+	assert.Equal(t, []interface{}{
+		map[string]interface{}{"message": "placeholder '{{flow_name}}' has no substitution", "line": 2, "column": 10},
+		map[string]interface{}{"message": "placeholder '{{description}}' has no substitution", "line": 4, "column": 17},
+	}, d.Get("warnings").([]interface{}))
+}
+
+func TestUnitDataSourceFlowValidationArchitectErrors(t *testing.T) {
+	filePath := filepath.Join(t.TempDir(), "flow.yaml")
+	assert.NoError(t, os.WriteFile(filePath, []byte(testFlowYaml), 0644))
+
+	var job *flowValidationJob
+	internalProxy, job = buildFlowValidationTestProxy(t, flowJobStatusFailure, []platformclientv2.Architectjobmessage{
+		{VarType: platformclientv2.String("ERROR"), Text: platformclientv2.String("Unable to find menu 'mainMenu' at line 5")},
+		{VarType: platformclientv2.String("WARNING"), Text: platformclientv2.String("The description is not used")},
+		{VarType: platformclientv2.String("INFO"), Text: platformclientv2.String("Job started")},
+	})
+	defer func() { internalProxy = nil }()
+	gcloud := &provider.ProviderMeta{ClientConfig: &platformclientv2.Configuration{}}
+
+	d := schema.TestResourceDataRaw(t, DataSourceFlowValidation().Schema, map[string]interface{}{
+		"filepath":      filePath,
+		"substitutions": map[string]interface{}{"flow_name": "Test Flow", "description": "A flow"},
+	})
+	diagErr := dataSourceFlowValidationRead(context.Background(), d, gcloud)
+	assert.True(t, diagErr.HasError())
+	assert.Equal(t, diag.Warning, diagErr[0].Severity)
+	assert.Equal(t, "The description is not used", diagErr[0].Detail)
+	assert.Contains(t, diagErr[1].Detail, "line 5, column 0: Unable to find menu 'mainMenu' at line 5")
+	assert.False(t, d.Get("valid").(bool))
+	assert.Equal(t, []interface{}{
+		map[string]interface{}{"message": "Unable to find menu 'mainMenu' at line 5", "line": 5, "column": 0},
+	}, d.Get("errors").([]interface{}))
+	assert.Empty(t, job.deletedFlowIds, "a failed job does not create a flow")
+
+	// Files failing the check of the provider are not sent to Architect
+	job.content = ""
+	assert.NoError(t, os.WriteFile(filePath, []byte("inboundCall:\n  defaultLanguage: en-us\n"), 0644))
+	diagErr = dataSourceFlowValidationRead(context.Background(), d, gcloud)
+	assert.True(t, diagErr.HasError())
+	assert.Contains(t, diagErr[len(diagErr)-1].Detail, "line 2, column 3: the flow name is missing")
+	assert.Empty(t, job.content)
+}
+
+func TestUnitRenameFlow(t *testing.T) {
+	testCases := map[string]string{
+		"inboundCall:\n  name: Test Flow # comment\n":              "inboundCall:\n  name: \"New\" # comment\n",
+		"inboundCall:\n  name: \"Test \\\"Flow\\\"\"\n  a: b\n":    "inboundCall:\n  name: \"New\"\n  a: b\n",
+		"inboundCall:\n  name: 'Test ''Flow'''\n":                  "inboundCall:\n  name: \"New\"\n",
+		"inboundCall: {name: Test Flow, defaultLanguage: en-us}\n": "inboundCall: {name: \"New\", defaultLanguage: en-us}\n",
+	}
+	for content, expected := range testCases {
+		result := validateFlowYaml(content, content, nil)
+		renamed, err := renameFlow(content, result.nameNode, "New")
+		assert.NoError(t, err, content)
+		assert.Equal(t, expected, renamed)
+	}
+
+	content := "inboundCall:\n  name: >\n    Test Flow\n"
+	result := validateFlowYaml(content, content, nil)
+	_, err := renameFlow(content, result.nameNode, "New")
+	assert.Error(t, err)
+}
+
+func TestUnitResourceFlowValidateOnly(t *testing.T) {
+	filePath := filepath.Join(t.TempDir(), "flow.yaml")
+	assert.NoError(t, os.WriteFile(filePath, []byte(testFlowYaml), 0644))
+
+	var job *flowValidationJob
+	internalProxy, job = buildFlowValidationTestProxy(t, flowJobStatusSuccess, nil)
+	defer func() { internalProxy = nil }()
+	gcloud := &provider.ProviderMeta{ClientConfig: &platformclientv2.Configuration{}}
+
+	d := schema.TestResourceDataRaw(t, ResourceArchitectFlow().Schema, map[string]interface{}{
+		"filepath":          filePath,
+		"file_content_hash": "hash",
+		"validate_only":     true,
+		"substitutions":     map[string]interface{}{"flow_name": "Test Flow", "description": "A flow"},
+	})
+	diagErr := createFlow(context.Background(), d, gcloud)
+	assert.False(t, diagErr.HasError())
+	assert.NotEmpty(t, d.Id())
+	assert.Equal(t, "Test Flow", d.Get("name").(string))
+	assert.Equal(t, "inboundcall", d.Get("type").(string))
+	assert.Equal(t, []string{"validation-flow-id"}, job.deletedFlowIds)
+
+	assert.NoError(t, os.WriteFile(filePath, []byte("inboundCall:\n  defaultLanguage: en-us\n"), 0644))
+	diagErr = updateFlow(context.Background(), d, gcloud)
+	assert.True(t, diagErr.HasError())
+	assert.Contains(t, diagErr[len(diagErr)-1].Detail, "line 2, column 3: the flow name is missing")
+	assert.Equal(t, "", d.Get("file_content_hash").(string))
+
+	assert.False(t, deleteFlow(context.Background(), d, gcloud).HasError())
+}
+
+func TestUnitFlattenFlowVersions(t *testing.T) {
+	datePublished := time.Date(2024, 5, 2, 8, 0, 0, 0, time.UTC)
+	versions := []platformclientv2.Flowversion{
+		{Id: platformclientv2.String("1.0"), DateCreated: platformclientv2.Int(1714550400000), CreatedBy: &platformclientv2.User{Id: platformclientv2.String("user-id"), Name: platformclientv2.String("User")}},
+		{Id: platformclientv2.String("3.0"), DateCreated: platformclientv2.Int(1714723200000), Debug: platformclientv2.Bool(true)},
+		{Id: platformclientv2.String("2.0"), DateCreated: platformclientv2.Int(1714636800000), DatePublished: &datePublished, CreatedByClient: &platformclientv2.Domainentityref{Id: platformclientv2.String("client-id"), Name: platformclientv2.String("Client")}},
+	}
+	sortFlowVersions(versions)
+
+	assert.Equal(t, []interface{}{
+		map[string]interface{}{"version": "3.0", "commit_version": "", "secure": false, "debug": true, "date_created": "2024-05-03T08:00:00Z", "date_published": "", "created_by_id": "", "created_by": ""},
+		map[string]interface{}{"version": "2.0", "commit_version": "", "secure": false, "debug": false, "date_created": "2024-05-02T08:00:00Z", "date_published": "2024-05-02T08:00:00Z", "created_by_id": "client-id", "created_by": "Client"},
+	}, flattenFlowVersions(versions, 2))
+	assert.Len(t, flattenFlowVersions(versions, 0), 3)
+}
+
+func TestUnitDataSourceFlowVersions(t *testing.T) {
+	internalProxy = &architectFlowProxy{
+		getArchitectFlowAttr: func(_ context.Context, _ *architectFlowProxy, id string) (*platformclientv2.Flow, *platformclientv2.APIResponse, error) {
+			return &platformclientv2.Flow{Id: &id, PublishedVersion: &platformclientv2.Flowversion{Id: platformclientv2.String("1.0")}}, &platformclientv2.APIResponse{StatusCode: http.StatusOK}, nil
+		},
+		getFlowVersionsAttr: func(_ context.Context, _ *architectFlowProxy, _ string) (*[]platformclientv2.Flowversion, *platformclientv2.APIResponse, error) {
+			return &[]platformclientv2.Flowversion{
+				{Id: platformclientv2.String("1.0"), DateCreated: platformclientv2.Int(1)},
+				{Id: platformclientv2.String("2.0"), DateCreated: platformclientv2.Int(2)},
+			}, &platformclientv2.APIResponse{StatusCode: http.StatusOK}, nil
+		},
+	}
+	defer func() { internalProxy = nil }()
+	gcloud := &provider.ProviderMeta{ClientConfig: &platformclientv2.Configuration{}}
+
+	d := schema.TestResourceDataRaw(t, DataSourceFlowVersions().Schema, map[string]interface{}{"flow_id": "flow-id"})
+	diagErr := dataSourceFlowVersionsRead(context.Background(), d, gcloud)
+	assert.False(t, diagErr.HasError())
+	assert.Equal(t, "flow-id", d.Id())
+	assert.Equal(t, "1.0", d.Get("published_version").(string))
+	assert.Equal(t, "2.0", d.Get("latest_version").(string))
+	assert.Equal(t, 2, d.Get("versions.#").(int))
+	assert.Equal(t, "2.0", d.Get("versions.0.version").(string))
+}
+
+func TestUnitResourceFlowVersionHistory(t *testing.T) {
+	var versionsErr error
+	internalProxy = &architectFlowProxy{
+		getArchitectFlowAttr: func(_ context.Context, _ *architectFlowProxy, id string) (*platformclientv2.Flow, *platformclientv2.APIResponse, error) {
+			return &platformclientv2.Flow{Id: &id, Name: platformclientv2.String("Test Flow"), VarType: platformclientv2.String("inboundcall")}, &platformclientv2.APIResponse{StatusCode: http.StatusOK}, nil
+		},
+		getLatestFlowVersionsAttr: func(_ context.Context, _ *architectFlowProxy, _ string, pageSize int) (*[]platformclientv2.Flowversion, *platformclientv2.APIResponse, error) {
+			assert.Equal(t, maxFlowVersionHistory, pageSize)
+			if versionsErr != nil {
+				return nil, &platformclientv2.APIResponse{StatusCode: http.StatusInternalServerError}, versionsErr
+			}
+			return &[]platformclientv2.Flowversion{
+				{Id: platformclientv2.String("2.0"), DateCreated: platformclientv2.Int(2)},
+				{Id: platformclientv2.String("1.0"), DateCreated: platformclientv2.Int(1)},
+			}, &platformclientv2.APIResponse{StatusCode: http.StatusOK}, nil
+		},
+	}
+	defer func() { internalProxy = nil }()
+	gcloud := &provider.ProviderMeta{ClientConfig: &platformclientv2.Configuration{}}
+
+	d := schema.TestResourceDataRaw(t, ResourceArchitectFlow().Schema, map[string]interface{}{"filepath": "flow.yaml", "file_content_hash": "hash"})
+	d.SetId("flow-id")
+	assert.False(t, readFlow(context.Background(), d, gcloud).HasError())
+	assert.Equal(t, 2, d.Get("version_history.#").(int))
+	assert.Equal(t, "2.0", d.Get("version_history.0.version").(string))
+
+	// A failure to read the history does not fail the read and keeps the previous history
+	versionsErr = fmt.Errorf("internal server error")
+	assert.False(t, readFlow(context.Background(), d, gcloud).HasError())
+	assert.Equal(t, "Test Flow", d.Get("name").(string))
+	assert.Equal(t, 2, d.Get("version_history.#").(int))
+}
+
+func TestUnitResourceFlowPublish(t *testing.T) {
+	originalInterval := flowPublishPollInterval
+	flowPublishPollInterval = time.Millisecond
+	defer func() { flowPublishPollInterval = originalInterval }()
+
+	publishedVersion := "2.0"
+	publishCalls := 0
+	getFlowCalls := 0
+	internalProxy = &architectFlowProxy{
+		getArchitectFlowAttr: func(_ context.Context, _ *architectFlowProxy, id string) (*platformclientv2.Flow, *platformclientv2.APIResponse, error) {
+			getFlowCalls++
+			// The new version is only reported as published after a few reads
+			version := publishedVersion
+			if getFlowCalls < 3 {
+				version = "2.0"
+			}
+			return &platformclientv2.Flow{Id: &id, PublishedVersion: &platformclientv2.Flowversion{Id: &version}}, &platformclientv2.APIResponse{StatusCode: http.StatusOK}, nil
+		},
+		getFlowVersionAttr: func(_ context.Context, _ *architectFlowProxy, _ string, versionId string) (*platformclientv2.Flowversion, *platformclientv2.APIResponse, error) {
+			if versionId == "9.0" {
+				return nil, &platformclientv2.APIResponse{StatusCode: http.StatusNotFound}, fmt.Errorf("version not found")
+			}
+			return &platformclientv2.Flowversion{Id: &versionId}, &platformclientv2.APIResponse{StatusCode: http.StatusOK}, nil
+		},
+		publishFlowVersionAttr: func(_ context.Context, _ *architectFlowProxy, _ string, versionId string) (*platformclientv2.Operation, *platformclientv2.APIResponse, error) {
+			publishCalls++
+			publishedVersion = versionId
+			return &platformclientv2.Operation{Id: platformclientv2.String("operation-id")}, &platformclientv2.APIResponse{StatusCode: http.StatusOK}, nil
+		},
+	}
+	defer func() { internalProxy = nil }()
+	gcloud := &provider.ProviderMeta{ClientConfig: &platformclientv2.Configuration{}}
+
+	d := schema.TestResourceDataRaw(t, ResourceFlowPublish().Schema, map[string]interface{}{"flow_id": "flow-id", "version": "1.0"})
+	diagErr := createFlowPublish(context.Background(), d, gcloud)
+	assert.False(t, diagErr.HasError())
+	assert.Equal(t, "flow-id", d.Id())
+	assert.Equal(t, "1.0", d.Get("version").(string))
+	assert.Equal(t, 1, publishCalls)
+
+	// Publishing the version which is already published does nothing
+	diagErr = createFlowPublish(context.Background(), d, gcloud)
+	assert.False(t, diagErr.HasError())
+	assert.Equal(t, 1, publishCalls)
+
+	d = schema.TestResourceDataRaw(t, ResourceFlowPublish().Schema, map[string]interface{}{"flow_id": "flow-id", "version": "9.0"})
+	diagErr = createFlowPublish(context.Background(), d, gcloud)
+	assert.True(t, diagErr.HasError())
+	assert.Equal(t, 1, publishCalls)
+	assert.Empty(t, d.Id())
+}
+
+func TestUnitResourceFlowTemplate(t *testing.T) {
+	dir := t.TempDir()
+	filePath := filepath.Join(dir, "flow.yaml")
+	assert.NoError(t, os.WriteFile(filePath, []byte("inboundCall:\n  name: {{ .flow_name }}\n  defaultLanguage: en-us\n  initialGreeting:{{ include \"greeting.yaml\" | nindent 4 }}\n"), 0644))
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "greeting.yaml"), []byte("tts: {{ .greeting }}"), 0644))
+
+	internalProxy, _ = buildFlowValidationTestProxy(t, flowJobStatusSuccess, nil)
+	defer func() { internalProxy = nil }()
+	gcloud := &provider.ProviderMeta{ClientConfig: &platformclientv2.Configuration{}}
+
+	d := schema.TestResourceDataRaw(t, ResourceArchitectFlow().Schema, map[string]interface{}{
+		"filepath":           filePath,
+		"file_content_hash":  "hash",
+		"validate_only":      true,
+		"template_engine":    templates.EngineGo,
+		"template_variables": `{"flow_name": "Test Flow", "greeting": "Hello"}`,
+	})
+	diagErr := createFlow(context.Background(), d, gcloud)
+	assert.False(t, diagErr.HasError())
+	assert.Equal(t, "Test Flow", d.Get("name").(string))
+	renderedContentHash := templates.HashContent("inboundCall:\n  name: Test Flow\n  defaultLanguage: en-us\n  initialGreeting:\n    tts: Hello\n")
+	assert.Equal(t, renderedContentHash, d.Get("rendered_content_hash").(string))
+
+	// Missing template variables fail the apply
+	assert.NoError(t, d.Set("template_variables", `{"flow_name": "Test Flow"}`))
+	diagErr = updateFlow(context.Background(), d, gcloud)
+	assert.True(t, diagErr.HasError())
+	assert.Contains(t, diagErr[0].Detail, "map has no entry for key")
+	assert.Equal(t, "", d.Get("rendered_content_hash").(string))
+
+	v := schema.TestResourceDataRaw(t, DataSourceFlowValidation().Schema, map[string]interface{}{
+		"filepath":           filePath,
+		"template_engine":    templates.EngineGo,
+		"template_variables": `{"flow_name": "Test Flow", "greeting": "Hello"}`,
+	})
+	diagErr = dataSourceFlowValidationRead(context.Background(), v, gcloud)
+	assert.False(t, diagErr.HasError())
+	assert.True(t, v.Get("valid").(bool))
+	assert.Equal(t, renderedContentHash, v.Get("content_hash").(string))
+}
@@ -0,0 +1,206 @@
+package architect_schedulegroups
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"terraform-provider-genesyscloud/genesyscloud/provider"
+	"terraform-provider-genesyscloud/genesyscloud/util"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/mypurecloud/platform-client-sdk-go/v149/platformclientv2"
+)
+
+/*
+   The data_source_genesyscloud_architect_schedulegroup_evaluation.go contains the genesyscloud_architect_schedulegroup_evaluation
+   data source. It reads the schedule group and its schedules, and expands them over the requested window using
+   genesyscloud_architect_schedulegroups_evaluation.go.
+*/
+
+// maxEvaluationWindow bounds the window a schedule group can be evaluated over
+const maxEvaluationWindow = 366 * 24 * time.Hour
+
+// evaluationTimeLayouts are the layouts of times without an offset, which are read in the evaluation time zone
+var evaluationTimeLayouts = []string{"2006-01-02T15:04:05", "2006-01-02T15:04", "2006-01-02"}
+
+// dataSourceArchitectSchedulegroupEvaluationRead evaluates the schedules of a schedule group over a window
+func dataSourceArchitectSchedulegroupEvaluationRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	sdkConfig := meta.(*provider.ProviderMeta).ClientConfig
+	proxy := getArchitectSchedulegroupsProxy(sdkConfig)
+
+	scheduleGroupId := d.Get("schedule_group_id").(string)
+	scheduleGroup, resp, err := proxy.getArchitectSchedulegroupsById(ctx, scheduleGroupId)
+	if err != nil {
+		return util.BuildAPIDiagnosticError(EvaluationResourceType, fmt.Sprintf("failed to read schedule group %s | error: %s", scheduleGroupId, err), resp)
+	}
+
+	timeZone := d.Get("time_zone").(string)
+	if timeZone == "" && scheduleGroup.TimeZone != nil {
+		timeZone = *scheduleGroup.TimeZone
+	}
+	if timeZone == "" {
+		timeZone = "UTC"
+	}
+	loc, err := time.LoadLocation(timeZone)
+	if err != nil {
+		return util.BuildDiagnosticError(EvaluationResourceType, fmt.Sprintf("failed to load time zone %s", timeZone), err)
+	}
+
+	from, err := parseEvaluationTime(d.Get("window_start").(string), loc)
+	if err != nil {
+		return util.BuildDiagnosticError(EvaluationResourceType, "invalid window_start", err)
+	}
+	to, err := parseEvaluationTime(d.Get("window_end").(string), loc)
+	if err != nil {
+		return util.BuildDiagnosticError(EvaluationResourceType, "invalid window_end", err)
+	}
+	if !to.After(from) {
+		return util.BuildDiagnosticError(EvaluationResourceType, "invalid window", fmt.Errorf("window_end %s is not after window_start %s", formatEvaluationTime(to, loc), formatEvaluationTime(from, loc)))
+	}
+	if to.Sub(from) > maxEvaluationWindow {
+		return util.BuildDiagnosticError(EvaluationResourceType, "invalid window", fmt.Errorf("the window must not be longer than %d days", int(maxEvaluationWindow.Hours()/24)))
+	}
+
+	schedules, diagErr := getEvaluatedSchedules(ctx, proxy, scheduleGroup)
+	if diagErr != nil {
+		return diagErr
+	}
+
+	evaluation, err := evaluateScheduleGroup(schedules, loc, from, to)
+	if err != nil {
+		return util.BuildDiagnosticError(EvaluationResourceType, fmt.Sprintf("failed to evaluate schedule group %s", scheduleGroupId), err)
+	}
+
+	stateAt, scheduleIdAt := "", ""
+	if atValue := d.Get("at").(string); atValue != "" {
+		at, err := parseEvaluationTime(atValue, loc)
+		if err != nil {
+			return util.BuildDiagnosticError(EvaluationResourceType, "invalid at", err)
+		}
+		schedule, err := scheduleAt(schedules, loc, at)
+		if err != nil {
+			return util.BuildDiagnosticError(EvaluationResourceType, fmt.Sprintf("failed to evaluate schedule group %s", scheduleGroupId), err)
+		}
+		stateAt = stateOf(schedule)
+		if schedule != nil {
+			scheduleIdAt = schedule.id
+		}
+	}
+
+	d.SetId(scheduleGroupId)
+	_ = d.Set("time_zone", timeZone)
+	_ = d.Set("transitions", flattenScheduleTransitions(evaluation.transitions, loc))
+	_ = d.Set("gaps", flattenScheduleGaps(evaluation.gaps, loc))
+	_ = d.Set("overlaps", flattenScheduleOverlaps(evaluation.overlaps, loc))
+	_ = d.Set("state_at", stateAt)
+	_ = d.Set("schedule_id_at", scheduleIdAt)
+	_ = d.Set("is_open", stateAt == scheduleStateOpen)
+
+	log.Printf("Evaluated schedule group %s from %s to %s: %d transitions, %d gaps, %d overlaps", scheduleGroupId, formatEvaluationTime(from, loc), formatEvaluationTime(to, loc), len(evaluation.transitions), len(evaluation.gaps), len(evaluation.overlaps))
+	return nil
+}
+
+// getEvaluatedSchedules reads the open, closed and holiday schedules of the schedule group
+func getEvaluatedSchedules(ctx context.Context, proxy *architectSchedulegroupsProxy, scheduleGroup *platformclientv2.Schedulegroup) ([]*evaluatedSchedule, diag.Diagnostics) {
+	sets := []struct {
+		state string
+		refs  *[]platformclientv2.Domainentityref
+	}{
+		{scheduleStateOpen, scheduleGroup.OpenSchedules},
+		{scheduleStateClosed, scheduleGroup.ClosedSchedules},
+		{scheduleStateHoliday, scheduleGroup.HolidaySchedules},
+	}
+
+	var schedules []*evaluatedSchedule
+	for _, set := range sets {
+		if set.refs == nil {
+			continue
+		}
+		for _, ref := range *set.refs {
+			if ref.Id == nil {
+				continue
+			}
+			schedule, resp, err := proxy.getArchitectScheduleById(ctx, *ref.Id)
+			if err != nil {
+				return nil, util.BuildAPIDiagnosticError(EvaluationResourceType, fmt.Sprintf("failed to read %s schedule %s | error: %s", set.state, *ref.Id, err), resp)
+			}
+			if schedule.Start == nil || schedule.End == nil {
+				return nil, util.BuildDiagnosticError(EvaluationResourceType, fmt.Sprintf("failed to evaluate %s schedule %s", set.state, *ref.Id), fmt.Errorf("the schedule has no start or end"))
+			}
+
+			evaluated, err := newEvaluatedSchedule(*ref.Id, util.StringOrEmpty(schedule.Name), set.state, *schedule.Start, *schedule.End, util.StringOrEmpty(schedule.Rrule))
+			if err != nil {
+				return nil, util.BuildDiagnosticError(EvaluationResourceType, fmt.Sprintf("failed to evaluate %s schedule %s", set.state, *ref.Id), err)
+			}
+			schedules = append(schedules, evaluated)
+		}
+	}
+	return schedules, nil
+}
+
+// parseEvaluationTime reads an RFC 3339 timestamp, or a date time without an offset in loc
+func parseEvaluationTime(value string, loc *time.Location) (time.Time, error) {
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t, nil
+	}
+	for _, layout := range evaluationTimeLayouts {
+		if t, err := time.ParseInLocation(layout, value, loc); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("%s is neither an RFC 3339 timestamp nor a date time in the format 2006-01-02T15:04:05", value)
+}
+
+func formatEvaluationTime(t time.Time, loc *time.Location) string {
+	return t.In(loc).Format(time.RFC3339)
+}
+
+func flattenScheduleTransitions(transitions []scheduleTransition, loc *time.Location) []interface{} {
+	result := make([]interface{}, 0, len(transitions))
+	for _, transition := range transitions {
+		scheduleId, scheduleName := "", ""
+		if transition.schedule != nil {
+			scheduleId, scheduleName = transition.schedule.id, transition.schedule.name
+		}
+		result = append(result, map[string]interface{}{
+			"time":          formatEvaluationTime(transition.time, loc),
+			"state":         transition.state,
+			"schedule_id":   scheduleId,
+			"schedule_name": scheduleName,
+		})
+	}
+	return result
+}
+
+func flattenScheduleGaps(gaps []scheduleTimeRange, loc *time.Location) []interface{} {
+	result := make([]interface{}, 0, len(gaps))
+	for _, gap := range gaps {
+		result = append(result, map[string]interface{}{
+			"start": formatEvaluationTime(gap.start, loc),
+			"end":   formatEvaluationTime(gap.end, loc),
+		})
+	}
+	return result
+}
+
+func flattenScheduleOverlaps(overlaps []scheduleOverlap, loc *time.Location) []interface{} {
+	result := make([]interface{}, 0, len(overlaps))
+	for _, overlap := range overlaps {
+		scheduleIds := make([]interface{}, 0, len(overlap.schedules))
+		states := make([]interface{}, 0, len(overlap.schedules))
+		for _, schedule := range overlap.schedules {
+			scheduleIds = append(scheduleIds, schedule.id)
+			states = append(states, schedule.state)
+		}
+		result = append(result, map[string]interface{}{
+			"start":           formatEvaluationTime(overlap.start, loc),
+			"end":             formatEvaluationTime(overlap.end, loc),
+			"schedule_ids":    scheduleIds,
+			"states":          states,
+			"effective_state": overlap.effectiveState,
+		})
+	}
+	return result
+}
@@ -0,0 +1,201 @@
+package architect_schedulegroups
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"terraform-provider-genesyscloud/genesyscloud/provider"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/mypurecloud/platform-client-sdk-go/v149/platformclientv2"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUnitExpandRrule(t *testing.T) {
+	date := func(year int, month time.Month, day, hour int) time.Time {
+		return time.Date(year, month, day, hour, 0, 0, 0, time.UTC)
+	}
+
+	testCases := []struct {
+		rrule    string
+		dtstart  time.Time
+		before   time.Time
+		expected []time.Time
+	}{
+		{
+			rrule:    "FREQ=WEEKLY;INTERVAL=1;BYDAY=MO,WE",
+			dtstart:  date(2024, time.January, 3, 9),
+			before:   date(2024, time.January, 16, 0),
+			expected: []time.Time{date(2024, time.January, 3, 9), date(2024, time.January, 8, 9), date(2024, time.January, 10, 9), date(2024, time.January, 15, 9)},
+		},
+		{
+			rrule:    "FREQ=MONTHLY;BYDAY=-1FR",
+			dtstart:  date(2024, time.January, 1, 0),
+			before:   date(2024, time.April, 1, 0),
+			expected: []time.Time{date(2024, time.January, 26, 0), date(2024, time.February, 23, 0), date(2024, time.March, 29, 0)},
+		},
+		{
+			rrule:    "FREQ=YEARLY;INTERVAL=1;BYMONTH=12;BYMONTHDAY=25",
+			dtstart:  date(2023, time.December, 25, 0),
+			before:   date(2026, time.January, 1, 0),
+			expected: []time.Time{date(2023, time.December, 25, 0), date(2024, time.December, 25, 0), date(2025, time.December, 25, 0)},
+		},
+		{
+			rrule:    "FREQ=YEARLY;BYMONTH=11;BYDAY=4TH",
+			dtstart:  date(2024, time.January, 1, 0),
+			before:   date(2026, time.January, 1, 0),
+			expected: []time.Time{date(2024, time.November, 28, 0), date(2025, time.November, 27, 0)},
+		},
+		{
+			rrule:    "FREQ=DAILY;INTERVAL=2;COUNT=3",
+			dtstart:  date(2024, time.January, 1, 8),
+			before:   date(2025, time.January, 1, 0),
+			expected: []time.Time{date(2024, time.January, 1, 8), date(2024, time.January, 3, 8), date(2024, time.January, 5, 8)},
+		},
+		{
+			rrule:    "FREQ=MONTHLY;UNTIL=20240601T000000",
+			dtstart:  date(2024, time.January, 31, 0),
+			before:   date(2025, time.January, 1, 0),
+			expected: []time.Time{date(2024, time.January, 31, 0), date(2024, time.March, 31, 0), date(2024, time.May, 31, 0)},
+		},
+	}
+
+	for _, testCase := range testCases {
+		rule, err := parseRrule(testCase.rrule)
+		if !assert.Nil(t, err, testCase.rrule) {
+			continue
+		}
+		occurrences, err := rule.expand(testCase.dtstart, testCase.before)
+		assert.Nil(t, err, testCase.rrule)
+		assert.Equal(t, testCase.expected, occurrences, testCase.rrule)
+	}
+
+	for _, rrule := range []string{"FREQ=HOURLY", "FREQ=WEEKLY;BYDAY=1MO", "FREQ=DAILY;BYSETPOS=1", "FREQ=DAILY;INTERVAL=0", "INTERVAL=1", "FREQ=DAILY;COUNT=2;UNTIL=20240101"} {
+		_, err := parseRrule(rrule)
+		assert.NotNil(t, err, rrule)
+	}
+}
+
+func TestUnitDataSourceArchitectSchedulegroupEvaluation(t *testing.T) {
+	localTime := func(month time.Month, day, hour int) *time.Time {
+		value := time.Date(2024, month, day, hour, 0, 0, 0, time.UTC)
+		return &value
+	}
+	schedules := map[string]platformclientv2.Schedule{
+		"open-id": {
+			Name:  platformclientv2.String("Weekdays"),
+			Start: localTime(time.January, 1, 9),
+			End:   localTime(time.January, 1, 17),
+			Rrule: platformclientv2.String("FREQ=WEEKLY;INTERVAL=1;BYDAY=MO,TU,WE,TH,FR"),
+		},
+		"closed-id": {
+			Name:  platformclientv2.String("Lunch"),
+			Start: localTime(time.January, 1, 12),
+			End:   localTime(time.January, 1, 13),
+			Rrule: platformclientv2.String("FREQ=DAILY;INTERVAL=1"),
+		},
+		"holiday-id": {
+			Name:  platformclientv2.String("Holiday"),
+			Start: localTime(time.March, 11, 0),
+			End:   localTime(time.March, 12, 0),
+		},
+	}
+
+	internalProxy = &architectSchedulegroupsProxy{
+		getArchitectSchedulegroupsByIdAttr: func(ctx context.Context, p *architectSchedulegroupsProxy, id string) (*platformclientv2.Schedulegroup, *platformclientv2.APIResponse, error) {
+			return &platformclientv2.Schedulegroup{
+				Id:               &id,
+				TimeZone:         platformclientv2.String("America/New_York"),
+				OpenSchedules:    &[]platformclientv2.Domainentityref{{Id: platformclientv2.String("open-id")}},
+				ClosedSchedules:  &[]platformclientv2.Domainentityref{{Id: platformclientv2.String("closed-id")}},
+				HolidaySchedules: &[]platformclientv2.Domainentityref{{Id: platformclientv2.String("holiday-id")}},
+			}, &platformclientv2.APIResponse{StatusCode: http.StatusOK}, nil
+		},
+		getArchitectScheduleByIdAttr: func(ctx context.Context, p *architectSchedulegroupsProxy, id string) (*platformclientv2.Schedule, *platformclientv2.APIResponse, error) {
+			schedule, ok := schedules[id]
+			if !ok {
+				return nil, &platformclientv2.APIResponse{StatusCode: http.StatusNotFound}, fmt.Errorf("not found")
+			}
+			return &schedule, &platformclientv2.APIResponse{StatusCode: http.StatusOK}, nil
+		},
+	}
+	defer func() { internalProxy = nil }()
+
+	dataSource := DataSourceArchitectSchedulegroupEvaluation()
+	meta := &provider.ProviderMeta{ClientConfig: &platformclientv2.Configuration{}}
+
+	// The window crosses the start of daylight saving time on 2024-03-10
+	d := schema.TestResourceDataRaw(t, dataSource.Schema, map[string]interface{}{
+		"schedule_group_id": "group-id",
+		"window_start":      "2024-03-08T00:00:00",
+		"window_end":        "2024-03-12",
+		"at":                "2024-03-11T14:00:00Z",
+	})
+	diags := dataSourceArchitectSchedulegroupEvaluationRead(context.Background(), d, meta)
+	assert.False(t, diags.HasError(), diags)
+
+	transition := func(time, state, scheduleId, scheduleName string) map[string]interface{} {
+		return map[string]interface{}{"time": time, "state": state, "schedule_id": scheduleId, "schedule_name": scheduleName}
+	}
+	assert.Equal(t, "America/New_York", d.Get("time_zone"))
+	assert.Equal(t, []interface{}{
+		transition("2024-03-08T00:00:00-05:00", "none", "", ""),
+		transition("2024-03-08T09:00:00-05:00", "open", "open-id", "Weekdays"),
+		transition("2024-03-08T12:00:00-05:00", "closed", "closed-id", "Lunch"),
+		transition("2024-03-08T13:00:00-05:00", "open", "open-id", "Weekdays"),
+		transition("2024-03-08T17:00:00-05:00", "none", "", ""),
+		transition("2024-03-09T12:00:00-05:00", "closed", "closed-id", "Lunch"),
+		transition("2024-03-09T13:00:00-05:00", "none", "", ""),
+		transition("2024-03-10T12:00:00-04:00", "closed", "closed-id", "Lunch"),
+		transition("2024-03-10T13:00:00-04:00", "none", "", ""),
+		transition("2024-03-11T00:00:00-04:00", "holiday", "holiday-id", "Holiday"),
+	}, d.Get("transitions"))
+
+	gap := func(start, end string) map[string]interface{} {
+		return map[string]interface{}{"start": start, "end": end}
+	}
+	assert.Equal(t, []interface{}{
+		gap("2024-03-08T00:00:00-05:00", "2024-03-08T09:00:00-05:00"),
+		gap("2024-03-08T17:00:00-05:00", "2024-03-09T12:00:00-05:00"),
+		gap("2024-03-09T13:00:00-05:00", "2024-03-10T12:00:00-04:00"),
+		gap("2024-03-10T13:00:00-04:00", "2024-03-11T00:00:00-04:00"),
+	}, d.Get("gaps"))
+
+	overlap := func(start, end string, scheduleIds, states []interface{}, effectiveState string) map[string]interface{} {
+		return map[string]interface{}{"start": start, "end": end, "schedule_ids": scheduleIds, "states": states, "effective_state": effectiveState}
+	}
+	assert.Equal(t, []interface{}{
+		overlap("2024-03-08T12:00:00-05:00", "2024-03-08T13:00:00-05:00", []interface{}{"closed-id", "open-id"}, []interface{}{"closed", "open"}, "closed"),
+		overlap("2024-03-11T09:00:00-04:00", "2024-03-11T12:00:00-04:00", []interface{}{"holiday-id", "open-id"}, []interface{}{"holiday", "open"}, "holiday"),
+		overlap("2024-03-11T12:00:00-04:00", "2024-03-11T13:00:00-04:00", []interface{}{"closed-id", "holiday-id", "open-id"}, []interface{}{"closed", "holiday", "open"}, "holiday"),
+		overlap("2024-03-11T13:00:00-04:00", "2024-03-11T17:00:00-04:00", []interface{}{"holiday-id", "open-id"}, []interface{}{"holiday", "open"}, "holiday"),
+	}, d.Get("overlaps"))
+
+	assert.Equal(t, "holiday", d.Get("state_at"))
+	assert.Equal(t, "holiday-id", d.Get("schedule_id_at"))
+	assert.Equal(t, false, d.Get("is_open"))
+
+	// Times without an offset are read in the time zone of the schedule group
+	d = schema.TestResourceDataRaw(t, dataSource.Schema, map[string]interface{}{
+		"schedule_group_id": "group-id",
+		"window_start":      "2024-03-08",
+		"window_end":        "2024-03-09",
+		"at":                "2024-03-08T16:59:59",
+	})
+	diags = dataSourceArchitectSchedulegroupEvaluationRead(context.Background(), d, meta)
+	assert.False(t, diags.HasError(), diags)
+	assert.Equal(t, "open", d.Get("state_at"))
+	assert.Equal(t, "open-id", d.Get("schedule_id_at"))
+	assert.Equal(t, true, d.Get("is_open"))
+
+	// The window is bounded
+	d = schema.TestResourceDataRaw(t, dataSource.Schema, map[string]interface{}{
+		"schedule_group_id": "group-id",
+		"window_start":      "2024-01-01",
+		"window_end":        "2025-06-01",
+	})
+	diags = dataSourceArchitectSchedulegroupEvaluationRead(context.Background(), d, meta)
+	assert.True(t, diags.HasError())
+}
@@ -0,0 +1,263 @@
+package architect_schedulegroups
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+/*
+   The genesyscloud_architect_schedulegroups_evaluation.go file builds the timeline of a schedule group over a window
+   for the genesyscloud_architect_schedulegroup_evaluation data source.
+
+   Architect resolves a schedule group in priority order: an active holiday schedule wins over an active closed
+   schedule, which wins over an active open schedule. When no schedule is active the group is neither open nor closed
+   by any schedule and the state is reported as "none".
+*/
+
+const (
+	scheduleStateOpen    = "open"
+	scheduleStateClosed  = "closed"
+	scheduleStateHoliday = "holiday"
+	scheduleStateNone    = "none"
+)
+
+var scheduleStatePriority = map[string]int{
+	scheduleStateOpen:    1,
+	scheduleStateClosed:  2,
+	scheduleStateHoliday: 3,
+}
+
+// evaluatedSchedule is a schedule of the group together with the set (open, closed or holiday) it belongs to.
+// start and end are the wall clock times of the first occurrence.
+type evaluatedSchedule struct {
+	id    string
+	name  string
+	state string
+	start time.Time
+	end   time.Time
+	rule  *recurrenceRule
+}
+
+type scheduleInterval struct {
+	schedule *evaluatedSchedule
+	start    time.Time
+	end      time.Time
+}
+
+type scheduleTransition struct {
+	time     time.Time
+	state    string
+	schedule *evaluatedSchedule
+}
+
+type scheduleTimeRange struct {
+	start time.Time
+	end   time.Time
+}
+
+type scheduleOverlap struct {
+	scheduleTimeRange
+	schedules      []*evaluatedSchedule
+	effectiveState string
+}
+
+type scheduleGroupEvaluation struct {
+	transitions []scheduleTransition
+	gaps        []scheduleTimeRange
+	overlaps    []scheduleOverlap
+}
+
+// newEvaluatedSchedule parses the rrule of a schedule. An empty rrule is a single occurrence from start to end.
+func newEvaluatedSchedule(id, name, state string, start, end time.Time, rrule string) (*evaluatedSchedule, error) {
+	schedule := &evaluatedSchedule{
+		id:    id,
+		name:  name,
+		state: state,
+		start: wallClock(start),
+		end:   wallClock(end),
+	}
+	if !schedule.end.After(schedule.start) {
+		return nil, fmt.Errorf("schedule %s (%s) ends before it starts", name, id)
+	}
+	if strings.TrimSpace(rrule) != "" {
+		rule, err := parseRrule(rrule)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse rrule %q of schedule %s (%s): %s", rrule, name, id, err)
+		}
+		schedule.rule = rule
+	}
+	return schedule, nil
+}
+
+// intervals returns the occurrences of the schedule in loc that overlap [from, to)
+func (s *evaluatedSchedule) intervals(loc *time.Location, from, to time.Time) ([]scheduleInterval, error) {
+	starts := []time.Time{s.start}
+	if s.rule != nil {
+		rule := *s.rule
+		rule.localize(loc)
+
+		// A day of margin covers occurrences moved across the end of the window by a daylight saving change
+		var err error
+		if starts, err = rule.expand(s.start, wallClock(to.In(loc)).AddDate(0, 0, 1)); err != nil {
+			return nil, fmt.Errorf("failed to expand rrule of schedule %s (%s): %s", s.name, s.id, err)
+		}
+	}
+
+	duration := s.end.Sub(s.start)
+	var intervals []scheduleInterval
+	for _, start := range starts {
+		interval := scheduleInterval{
+			schedule: s,
+			start:    inLocation(start, loc),
+			end:      inLocation(start.Add(duration), loc),
+		}
+		if interval.end.After(from) && interval.start.Before(to) {
+			intervals = append(intervals, interval)
+		}
+	}
+	return intervals, nil
+}
+
+// evaluateScheduleGroup returns the transitions, gaps and overlaps of the schedules over [from, to)
+func evaluateScheduleGroup(schedules []*evaluatedSchedule, loc *time.Location, from, to time.Time) (*scheduleGroupEvaluation, error) {
+	var intervals []scheduleInterval
+	points := []time.Time{from, to}
+	for _, schedule := range schedules {
+		scheduleIntervals, err := schedule.intervals(loc, from, to)
+		if err != nil {
+			return nil, err
+		}
+		for _, interval := range scheduleIntervals {
+			intervals = append(intervals, interval)
+			for _, point := range []time.Time{interval.start, interval.end} {
+				if point.After(from) && point.Before(to) {
+					points = append(points, point)
+				}
+			}
+		}
+	}
+
+	sort.Slice(points, func(i, j int) bool { return points[i].Before(points[j]) })
+
+	evaluation := &scheduleGroupEvaluation{}
+	for i := 0; i < len(points)-1; i++ {
+		start, end := points[i], points[i+1]
+		if !end.After(start) {
+			continue
+		}
+
+		active := activeIntervals(intervals, start)
+		effective := effectiveSchedule(active)
+		if n := len(evaluation.transitions); n == 0 || evaluation.transitions[n-1].schedule != effective {
+			evaluation.transitions = append(evaluation.transitions, scheduleTransition{time: start, state: stateOf(effective), schedule: effective})
+		}
+
+		if effective == nil {
+			if n := len(evaluation.gaps); n > 0 && evaluation.gaps[n-1].end.Equal(start) {
+				evaluation.gaps[n-1].end = end
+			} else {
+				evaluation.gaps = append(evaluation.gaps, scheduleTimeRange{start: start, end: end})
+			}
+		}
+
+		if overlapping := overlappingSchedules(active); overlapping != nil {
+			n := len(evaluation.overlaps)
+			if n > 0 && evaluation.overlaps[n-1].end.Equal(start) && sameSchedules(evaluation.overlaps[n-1].schedules, overlapping) {
+				evaluation.overlaps[n-1].end = end
+			} else {
+				evaluation.overlaps = append(evaluation.overlaps, scheduleOverlap{
+					scheduleTimeRange: scheduleTimeRange{start: start, end: end},
+					schedules:         overlapping,
+					effectiveState:    stateOf(effective),
+				})
+			}
+		}
+	}
+	return evaluation, nil
+}
+
+// scheduleAt returns the schedule in effect at the given time, or nil when no schedule is active
+func scheduleAt(schedules []*evaluatedSchedule, loc *time.Location, at time.Time) (*evaluatedSchedule, error) {
+	var intervals []scheduleInterval
+	for _, schedule := range schedules {
+		scheduleIntervals, err := schedule.intervals(loc, at, at.Add(time.Second))
+		if err != nil {
+			return nil, err
+		}
+		intervals = append(intervals, scheduleIntervals...)
+	}
+	return effectiveSchedule(activeIntervals(intervals, at)), nil
+}
+
+func activeIntervals(intervals []scheduleInterval, at time.Time) []scheduleInterval {
+	var active []scheduleInterval
+	for _, interval := range intervals {
+		if !at.Before(interval.start) && at.Before(interval.end) {
+			active = append(active, interval)
+		}
+	}
+	return active
+}
+
+// effectiveSchedule picks the active schedule with the highest priority. Ties go to the occurrence that started
+// first, then to the lowest schedule id so that the result is stable.
+func effectiveSchedule(active []scheduleInterval) *evaluatedSchedule {
+	var effective *scheduleInterval
+	for i := range active {
+		candidate := &active[i]
+		if effective == nil {
+			effective = candidate
+			continue
+		}
+		candidatePriority, effectivePriority := scheduleStatePriority[candidate.schedule.state], scheduleStatePriority[effective.schedule.state]
+		if candidatePriority > effectivePriority ||
+			(candidatePriority == effectivePriority && candidate.start.Before(effective.start)) ||
+			(candidatePriority == effectivePriority && candidate.start.Equal(effective.start) && candidate.schedule.id < effective.schedule.id) {
+			effective = candidate
+		}
+	}
+	if effective == nil {
+		return nil
+	}
+	return effective.schedule
+}
+
+// overlappingSchedules returns the active schedules sorted by id when they belong to more than one set
+func overlappingSchedules(active []scheduleInterval) []*evaluatedSchedule {
+	states := make(map[string]bool)
+	seen := make(map[*evaluatedSchedule]bool)
+	var schedules []*evaluatedSchedule
+	for _, interval := range active {
+		states[interval.schedule.state] = true
+		if !seen[interval.schedule] {
+			seen[interval.schedule] = true
+			schedules = append(schedules, interval.schedule)
+		}
+	}
+	if len(states) < 2 {
+		return nil
+	}
+	sort.Slice(schedules, func(i, j int) bool { return schedules[i].id < schedules[j].id })
+	return schedules
+}
+
+func sameSchedules(a, b []*evaluatedSchedule) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func stateOf(schedule *evaluatedSchedule) string {
+	if schedule == nil {
+		return scheduleStateNone
+	}
+	return schedule.state
+}
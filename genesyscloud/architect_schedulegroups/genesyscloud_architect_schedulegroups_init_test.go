@@ -41,6 +41,7 @@ func (r *registerTestInstance) registerTestDataSources() {
 	defer r.datasourceMapMutex.Unlock()
 
 	providerDataSources[ResourceType] = DataSourceArchitectSchedulegroups()
+	providerDataSources[EvaluationResourceType] = DataSourceArchitectSchedulegroupEvaluation()
 }
 
 // initTestResources initializes all test resources and data sources.
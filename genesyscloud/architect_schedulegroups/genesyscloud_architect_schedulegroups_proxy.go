@@ -24,6 +24,7 @@ type getArchitectSchedulegroupsIdByNameFunc func(ctx context.Context, p *archite
 type getArchitectSchedulegroupsByIdFunc func(ctx context.Context, p *architectSchedulegroupsProxy, id string) (scheduleGroup *platformclientv2.Schedulegroup, response *platformclientv2.APIResponse, err error)
 type updateArchitectSchedulegroupsFunc func(ctx context.Context, p *architectSchedulegroupsProxy, id string, scheduleGroup *platformclientv2.Schedulegroup) (*platformclientv2.Schedulegroup, *platformclientv2.APIResponse, error)
 type deleteArchitectSchedulegroupsFunc func(ctx context.Context, p *architectSchedulegroupsProxy, id string) (*platformclientv2.APIResponse, error)
+type getArchitectScheduleByIdFunc func(ctx context.Context, p *architectSchedulegroupsProxy, id string) (schedule *platformclientv2.Schedule, response *platformclientv2.APIResponse, err error)
 
 // architectSchedulegroupsProxy contains all of the methods that call genesys cloud APIs.
 type architectSchedulegroupsProxy struct {
@@ -35,6 +36,7 @@ type architectSchedulegroupsProxy struct {
 	getArchitectSchedulegroupsByIdAttr     getArchitectSchedulegroupsByIdFunc
 	updateArchitectSchedulegroupsAttr      updateArchitectSchedulegroupsFunc
 	deleteArchitectSchedulegroupsAttr      deleteArchitectSchedulegroupsFunc
+	getArchitectScheduleByIdAttr           getArchitectScheduleByIdFunc
 }
 
 // newArchitectSchedulegroupsProxy initializes the architect schedulegroups proxy with all of the data needed to communicate with Genesys Cloud
@@ -49,6 +51,7 @@ func newArchitectSchedulegroupsProxy(clientConfig *platformclientv2.Configuratio
 		getArchitectSchedulegroupsByIdAttr:     getArchitectSchedulegroupsByIdFn,
 		updateArchitectSchedulegroupsAttr:      updateArchitectSchedulegroupsFn,
 		deleteArchitectSchedulegroupsAttr:      deleteArchitectSchedulegroupsFn,
+		getArchitectScheduleByIdAttr:           getArchitectScheduleByIdFn,
 	}
 }
 
@@ -91,6 +94,11 @@ func (p *architectSchedulegroupsProxy) deleteArchitectSchedulegroups(ctx context
 	return p.deleteArchitectSchedulegroupsAttr(ctx, p, id)
 }
 
+// getArchitectScheduleById returns a single Genesys Cloud architect schedule referenced by a schedule group
+func (p *architectSchedulegroupsProxy) getArchitectScheduleById(ctx context.Context, id string) (schedule *platformclientv2.Schedule, response *platformclientv2.APIResponse, err error) {
+	return p.getArchitectScheduleByIdAttr(ctx, p, id)
+}
+
 // createArchitectSchedulegroupsFn is an implementation function for creating a Genesys Cloud architect schedulegroups
 func createArchitectSchedulegroupsFn(ctx context.Context, p *architectSchedulegroupsProxy, architectSchedulegroups *platformclientv2.Schedulegroup) (*platformclientv2.Schedulegroup, *platformclientv2.APIResponse, error) {
 	scheduleGroup, apiResponse, err := p.architectApi.PostArchitectSchedulegroups(*architectSchedulegroups)
@@ -184,3 +192,12 @@ func deleteArchitectSchedulegroupsFn(ctx context.Context, p *architectSchedulegr
 	}
 	return resp, nil
 }
+
+// getArchitectScheduleByIdFn is an implementation of the function to get a Genesys Cloud architect schedule by Id
+func getArchitectScheduleByIdFn(ctx context.Context, p *architectSchedulegroupsProxy, id string) (schedule *platformclientv2.Schedule, response *platformclientv2.APIResponse, err error) {
+	schedule, apiResponse, err := p.architectApi.GetArchitectSchedule(id)
+	if err != nil {
+		return nil, apiResponse, fmt.Errorf("Failed to retrieve architect schedule by id %s: %s", id, err)
+	}
+	return schedule, apiResponse, nil
+}
@@ -0,0 +1,351 @@
+package architect_schedulegroups
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"terraform-provider-genesyscloud/genesyscloud/validators"
+	"time"
+)
+
+/*
+   The genesyscloud_architect_schedulegroups_rrule.go file expands the iCal recurrence rules (RRULE) of architect
+   schedules into occurrences. Only the rule parts used by Architect schedules are supported: FREQ, INTERVAL, COUNT,
+   UNTIL, BYDAY, BYMONTH, BYMONTHDAY and WKST.
+
+   All times handled here are wall clock times without a time zone. They are stored in time.UTC so that adding days
+   is never affected by daylight saving changes, and are placed in the time zone of the schedule group by the caller.
+*/
+
+// maxRrulePeriods bounds the number of days, weeks, months or years a rule is expanded over
+const maxRrulePeriods = 100000
+
+var rruleWeekdays = map[string]time.Weekday{
+	"SU": time.Sunday,
+	"MO": time.Monday,
+	"TU": time.Tuesday,
+	"WE": time.Wednesday,
+	"TH": time.Thursday,
+	"FR": time.Friday,
+	"SA": time.Saturday,
+}
+
+// rruleWeekday is an entry of BYDAY. ordinal is 0 for every matching weekday of the period, n for the nth and -n for
+// the nth last matching weekday of the month or year.
+type rruleWeekday struct {
+	weekday time.Weekday
+	ordinal int
+}
+
+type recurrenceRule struct {
+	freq       string
+	interval   int
+	count      int
+	until      *time.Time
+	untilUtc   bool
+	byDay      []rruleWeekday
+	byMonth    []int
+	byMonthDay []int
+	weekStart  time.Weekday
+}
+
+// parseRrule validates the rule with validators.ValidateRrule and parses it into a recurrenceRule
+func parseRrule(rrule string) (*recurrenceRule, error) {
+	rrule = strings.TrimPrefix(strings.TrimSpace(rrule), "RRULE:")
+	if diagErr := validators.ValidateRrule(rrule, nil); diagErr.HasError() {
+		return nil, fmt.Errorf("%s", diagErr[0].Summary)
+	}
+
+	rule := &recurrenceRule{interval: 1, weekStart: time.Monday}
+	for _, part := range strings.Split(rrule, ";") {
+		if part == "" {
+			continue
+		}
+		name, value, found := strings.Cut(part, "=")
+		if !found || value == "" {
+			return nil, fmt.Errorf("invalid rrule part %q", part)
+		}
+
+		switch name {
+		case "FREQ":
+			switch value {
+			case "DAILY", "WEEKLY", "MONTHLY", "YEARLY":
+				rule.freq = value
+			default:
+				return nil, fmt.Errorf("unsupported FREQ %s. Supported values are DAILY, WEEKLY, MONTHLY and YEARLY", value)
+			}
+		case "INTERVAL":
+			interval, err := strconv.Atoi(value)
+			if err != nil || interval < 1 {
+				return nil, fmt.Errorf("invalid INTERVAL %s", value)
+			}
+			rule.interval = interval
+		case "COUNT":
+			count, err := strconv.Atoi(value)
+			if err != nil || count < 1 {
+				return nil, fmt.Errorf("invalid COUNT %s", value)
+			}
+			rule.count = count
+		case "UNTIL":
+			until, isUtc, err := parseRruleUntil(value)
+			if err != nil {
+				return nil, err
+			}
+			rule.until = &until
+			rule.untilUtc = isUtc
+		case "BYDAY":
+			for _, day := range strings.Split(value, ",") {
+				weekday, err := parseRruleWeekday(day)
+				if err != nil {
+					return nil, err
+				}
+				rule.byDay = append(rule.byDay, weekday)
+			}
+		case "BYMONTH":
+			months, err := parseRruleInts(value)
+			if err != nil {
+				return nil, fmt.Errorf("invalid BYMONTH %s: %s", value, err)
+			}
+			rule.byMonth = months
+		case "BYMONTHDAY":
+			days, err := parseRruleInts(value)
+			if err != nil {
+				return nil, fmt.Errorf("invalid BYMONTHDAY %s: %s", value, err)
+			}
+			rule.byMonthDay = days
+		case "WKST":
+			weekday, ok := rruleWeekdays[value]
+			if !ok {
+				return nil, fmt.Errorf("invalid WKST %s", value)
+			}
+			rule.weekStart = weekday
+		default:
+			return nil, fmt.Errorf("unsupported rrule part %s", name)
+		}
+	}
+
+	if rule.freq == "" {
+		return nil, fmt.Errorf("rrule %q has no FREQ", rrule)
+	}
+	if rule.count > 0 && rule.until != nil {
+		return nil, fmt.Errorf("rrule %q must not set both COUNT and UNTIL", rrule)
+	}
+	if rule.freq == "DAILY" || rule.freq == "WEEKLY" {
+		for _, day := range rule.byDay {
+			if day.ordinal != 0 {
+				return nil, fmt.Errorf("BYDAY ordinals are only supported with FREQ=MONTHLY or FREQ=YEARLY")
+			}
+		}
+	}
+	return rule, nil
+}
+
+func parseRruleUntil(value string) (time.Time, bool, error) {
+	if until, err := time.Parse("20060102T150405Z", value); err == nil {
+		return until, true, nil
+	}
+	for _, layout := range []string{"20060102T150405", "20060102"} {
+		if until, err := time.Parse(layout, value); err == nil {
+			return until, false, nil
+		}
+	}
+	return time.Time{}, false, fmt.Errorf("invalid UNTIL %s", value)
+}
+
+func parseRruleWeekday(value string) (rruleWeekday, error) {
+	if len(value) < 2 {
+		return rruleWeekday{}, fmt.Errorf("invalid BYDAY %s", value)
+	}
+	weekday, ok := rruleWeekdays[value[len(value)-2:]]
+	if !ok {
+		return rruleWeekday{}, fmt.Errorf("invalid BYDAY %s", value)
+	}
+	ordinal := 0
+	if prefix := value[:len(value)-2]; prefix != "" {
+		var err error
+		if ordinal, err = strconv.Atoi(prefix); err != nil || ordinal == 0 || ordinal > 53 || ordinal < -53 {
+			return rruleWeekday{}, fmt.Errorf("invalid BYDAY %s", value)
+		}
+	}
+	return rruleWeekday{weekday: weekday, ordinal: ordinal}, nil
+}
+
+func parseRruleInts(value string) ([]int, error) {
+	var result []int
+	for _, item := range strings.Split(value, ",") {
+		number, err := strconv.Atoi(item)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, number)
+	}
+	return result, nil
+}
+
+// localize converts a UTC UNTIL into the wall clock of the time zone the rule is expanded in
+func (r *recurrenceRule) localize(loc *time.Location) {
+	if r.until != nil && r.untilUtc {
+		until := wallClock(r.until.In(loc))
+		r.until = &until
+		r.untilUtc = false
+	}
+}
+
+// expand returns the start of every occurrence of the rule from dtstart that starts before the given time
+func (r *recurrenceRule) expand(dtstart, before time.Time) ([]time.Time, error) {
+	var occurrences []time.Time
+	emitted := 0
+	for period := 0; period < maxRrulePeriods; period++ {
+		periodStart, candidates := r.period(dtstart, period)
+		if !periodStart.Before(before) {
+			return occurrences, nil
+		}
+		for _, candidate := range candidates {
+			if candidate.Before(dtstart) {
+				continue
+			}
+			if (r.until != nil && candidate.After(*r.until)) || (r.count > 0 && emitted >= r.count) || !candidate.Before(before) {
+				return occurrences, nil
+			}
+			emitted++
+			occurrences = append(occurrences, candidate)
+		}
+	}
+	return nil, fmt.Errorf("rrule expands over more than %d periods", maxRrulePeriods)
+}
+
+// period returns the start of the nth period of the rule and the sorted occurrences within it
+func (r *recurrenceRule) period(dtstart time.Time, n int) (time.Time, []time.Time) {
+	at := func(year int, month time.Month, day int) time.Time {
+		return time.Date(year, month, day, dtstart.Hour(), dtstart.Minute(), dtstart.Second(), dtstart.Nanosecond(), time.UTC)
+	}
+
+	var start time.Time
+	var days []time.Time
+	switch r.freq {
+	case "DAILY":
+		start = at(dtstart.Year(), dtstart.Month(), dtstart.Day()+n*r.interval)
+		if (len(r.byMonthDay) == 0 || containsInt(r.byMonthDay, start.Day())) && (len(r.byDay) == 0 || r.matchesWeekday(start, 0, 0)) {
+			days = append(days, start)
+		}
+	case "WEEKLY":
+		offset := (int(dtstart.Weekday()) - int(r.weekStart) + 7) % 7
+		start = at(dtstart.Year(), dtstart.Month(), dtstart.Day()-offset+7*n*r.interval)
+		if len(r.byDay) == 0 {
+			days = append(days, start.AddDate(0, 0, offset))
+		}
+		for i := 0; i < 7 && len(r.byDay) > 0; i++ {
+			day := start.AddDate(0, 0, i)
+			if r.matchesWeekday(day, 0, 0) {
+				days = append(days, day)
+			}
+		}
+	case "MONTHLY":
+		start = at(dtstart.Year(), dtstart.Month()+time.Month(n*r.interval), 1)
+		days = r.monthDays(start.Year(), start.Month(), dtstart, at)
+	case "YEARLY":
+		start = at(dtstart.Year()+n*r.interval, time.January, 1)
+		days = r.yearDays(start.Year(), dtstart, at)
+	}
+
+	var occurrences []time.Time
+	for _, day := range days {
+		if len(r.byMonth) == 0 || containsInt(r.byMonth, int(day.Month())) {
+			occurrences = append(occurrences, day)
+		}
+	}
+	sort.Slice(occurrences, func(i, j int) bool { return occurrences[i].Before(occurrences[j]) })
+	return start, occurrences
+}
+
+// monthDays returns the days of a month matched by BYMONTHDAY and BYDAY, or the day of the month of dtstart
+func (r *recurrenceRule) monthDays(year int, month time.Month, dtstart time.Time, at func(int, time.Month, int) time.Time) []time.Time {
+	length := daysIn(year, month)
+	var days []time.Time
+	for day := 1; day <= length; day++ {
+		date := at(year, month, day)
+		switch {
+		case len(r.byMonthDay) > 0:
+			if containsInt(r.byMonthDay, day) && (len(r.byDay) == 0 || r.matchesWeekday(date, day, length)) {
+				days = append(days, date)
+			}
+		case len(r.byDay) > 0:
+			if r.matchesWeekday(date, day, length) {
+				days = append(days, date)
+			}
+		case day == dtstart.Day():
+			days = append(days, date)
+		}
+	}
+	return days
+}
+
+// yearDays returns the days of a year matched by the BY* parts of the rule, or the anniversary of dtstart
+func (r *recurrenceRule) yearDays(year int, dtstart time.Time, at func(int, time.Month, int) time.Time) []time.Time {
+	months := r.byMonth
+	if len(months) == 0 {
+		if len(r.byMonthDay) == 0 && len(r.byDay) > 0 {
+			// BYDAY without BYMONTH matches weekdays of the whole year, ordinals count from the start or end of the year
+			length := time.Date(year, time.December, 31, 0, 0, 0, 0, time.UTC).YearDay()
+			var days []time.Time
+			for day := 1; day <= length; day++ {
+				date := at(year, time.January, day)
+				if r.matchesWeekday(date, day, length) {
+					days = append(days, date)
+				}
+			}
+			return days
+		}
+		if len(r.byMonthDay) > 0 {
+			months = []int{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12}
+		} else {
+			months = []int{int(dtstart.Month())}
+		}
+	}
+
+	var days []time.Time
+	for _, month := range months {
+		days = append(days, r.monthDays(year, time.Month(month), dtstart, at)...)
+	}
+	return days
+}
+
+// matchesWeekday reports whether date matches BYDAY. index is the 1-based position of date within a period of length
+// days, and is used to resolve ordinals
+func (r *recurrenceRule) matchesWeekday(date time.Time, index, length int) bool {
+	for _, day := range r.byDay {
+		if day.weekday != date.Weekday() {
+			continue
+		}
+		if day.ordinal == 0 ||
+			(day.ordinal > 0 && (index-1)/7+1 == day.ordinal) ||
+			(day.ordinal < 0 && (length-index)/7+1 == -day.ordinal) {
+			return true
+		}
+	}
+	return false
+}
+
+func daysIn(year int, month time.Month) int {
+	return time.Date(year, month+1, 0, 0, 0, 0, 0, time.UTC).Day()
+}
+
+func containsInt(values []int, value int) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+// wallClock drops the time zone of t, keeping its year, month, day and time of day
+func wallClock(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute(), t.Second(), t.Nanosecond(), time.UTC)
+}
+
+// inLocation places a wall clock time in loc
+func inLocation(wall time.Time, loc *time.Location) time.Time {
+	return time.Date(wall.Year(), wall.Month(), wall.Day(), wall.Hour(), wall.Minute(), wall.Second(), wall.Nanosecond(), loc)
+}
@@ -1,6 +1,7 @@
 package architect_schedulegroups
 
 import (
+	"fmt"
 	"terraform-provider-genesyscloud/genesyscloud/provider"
 	resourceExporter "terraform-provider-genesyscloud/genesyscloud/resource_exporter"
 	registrar "terraform-provider-genesyscloud/genesyscloud/resource_register"
@@ -16,12 +17,16 @@ resource_genesycloud_architect_schedulegroups_schema.go holds four functions wit
 3.  The datasource schema definitions for the architect_schedulegroups datasource.
 4.  The resource exporter configuration for the architect_schedulegroups exporter.
 */
-const ResourceType = "genesyscloud_architect_schedulegroups"
+const (
+	ResourceType           = "genesyscloud_architect_schedulegroups"
+	EvaluationResourceType = "genesyscloud_architect_schedulegroup_evaluation"
+)
 
 // SetRegistrar registers all of the resources, datasources and exporters in the package
 func SetRegistrar(regInstance registrar.Registrar) {
 	regInstance.RegisterResource(ResourceType, ResourceArchitectSchedulegroups())
 	regInstance.RegisterDataSource(ResourceType, DataSourceArchitectSchedulegroups())
+	regInstance.RegisterDataSource(EvaluationResourceType, DataSourceArchitectSchedulegroupEvaluation())
 	regInstance.RegisterExporter(ResourceType, ArchitectSchedulegroupsExporter())
 }
 
@@ -112,3 +117,142 @@ func DataSourceArchitectSchedulegroups() *schema.Resource {
 		},
 	}
 }
+
+// DataSourceArchitectSchedulegroupEvaluation registers the genesyscloud_architect_schedulegroup_evaluation data source
+func DataSourceArchitectSchedulegroupEvaluation() *schema.Resource {
+	timeDescription := "Either an RFC 3339 timestamp (2006-01-02T15:04:05Z07:00) or a date time without an offset (2006-01-02T15:04:05) in the time zone of the evaluation."
+
+	return &schema.Resource{
+		Description: "Evaluates the open, closed and holiday schedules of a Genesys Cloud Schedule Group over a window. " +
+			"Holiday schedules take precedence over closed schedules, which take precedence over open schedules. " +
+			"Reports the effective schedule at each transition, the gaps where no schedule is active and the overlaps between the open, closed and holiday sets.",
+		ReadContext: provider.ReadWithPooledClient(dataSourceArchitectSchedulegroupEvaluationRead),
+		Schema: map[string]*schema.Schema{
+			"schedule_group_id": {
+				Description: "ID of the schedule group to evaluate.",
+				Type:        schema.TypeString,
+				Required:    true,
+			},
+			"window_start": {
+				Description: "Start of the evaluation window. " + timeDescription,
+				Type:        schema.TypeString,
+				Required:    true,
+			},
+			"window_end": {
+				Description: fmt.Sprintf("End of the evaluation window, at most %d days after window_start. %s", int(maxEvaluationWindow.Hours()/24), timeDescription),
+				Type:        schema.TypeString,
+				Required:    true,
+			},
+			"at": {
+				Description: "Time to evaluate the state of the schedule group at, for example to assert that it is open. " + timeDescription,
+				Type:        schema.TypeString,
+				Optional:    true,
+			},
+			"time_zone": {
+				Description: "Time zone the schedules are evaluated in. Defaults to the time_zone of the schedule group, or UTC when the schedule group has none.",
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+			},
+			"transitions": {
+				Description: "Changes of the effective schedule over the window. The first transition is at window_start.",
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"time": {
+							Description: "RFC 3339 time of the transition in the evaluation time zone.",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"state": {
+							Description: "State of the schedule group from this transition (open | closed | holiday | none).",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"schedule_id": {
+							Description: "ID of the effective schedule. Empty when the state is none.",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"schedule_name": {
+							Description: "Name of the effective schedule. Empty when the state is none.",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+					},
+				},
+			},
+			"gaps": {
+				Description: "Periods of the window where no open, closed or holiday schedule is active.",
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"start": {
+							Description: "RFC 3339 start of the gap.",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"end": {
+							Description: "RFC 3339 end of the gap.",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+					},
+				},
+			},
+			"overlaps": {
+				Description: "Periods of the window where schedules of more than one set (open, closed or holiday) are active.",
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"start": {
+							Description: "RFC 3339 start of the overlap.",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"end": {
+							Description: "RFC 3339 end of the overlap.",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"schedule_ids": {
+							Description: "IDs of the active schedules.",
+							Type:        schema.TypeList,
+							Computed:    true,
+							Elem:        &schema.Schema{Type: schema.TypeString},
+						},
+						"states": {
+							Description: "Sets of the active schedules, in the same order as schedule_ids.",
+							Type:        schema.TypeList,
+							Computed:    true,
+							Elem:        &schema.Schema{Type: schema.TypeString},
+						},
+						"effective_state": {
+							Description: "State the schedule group resolves to during the overlap.",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+					},
+				},
+			},
+			"state_at": {
+				Description: "State of the schedule group at the time set in at (open | closed | holiday | none). Empty when at is not set.",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"schedule_id_at": {
+				Description: "ID of the schedule in effect at the time set in at. Empty when at is not set or no schedule is active.",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"is_open": {
+				Description: "Whether the schedule group is open at the time set in at. False when at is not set.",
+				Type:        schema.TypeBool,
+				Computed:    true,
+			},
+		},
+	}
+}
@@ -0,0 +1,531 @@
+package architect_schedules
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	files "terraform-provider-genesyscloud/genesyscloud/util/files"
+	"terraform-provider-genesyscloud/genesyscloud/validators"
+	"time"
+)
+
+/*
+   The genesyscloud_architect_holiday_schedules_sources.go file reads the holidays of the
+   genesyscloud_architect_holiday_schedules resource from an iCalendar (.ics) file or from a built-in
+   country rule set.
+
+   Holidays are keyed by the date of their first day (yyyy-MM-dd). Their start and end are wall clock
+   times without a time zone, like the start and end of an architect schedule.
+*/
+
+const holidayKeyFormat = "2006-01-02"
+
+// holiday is a single holiday to materialize as an architect schedule
+type holiday struct {
+	key   string
+	name  string
+	start time.Time
+	end   time.Time
+	rrule string
+}
+
+// loadHolidays reads the holidays of the ics_file or country_code of the resource, restricted to years when set
+func loadHolidays(icsFile, countryCode string, years []int) ([]holiday, error) {
+	var holidays []holiday
+	switch {
+	case icsFile != "":
+		reader, file, err := files.DownloadOrOpenFile(icsFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to open iCalendar file %s: %w", icsFile, err)
+		}
+		if file != nil {
+			defer file.Close()
+		}
+		if holidays, err = parseIcsHolidays(reader); err != nil {
+			return nil, fmt.Errorf("invalid iCalendar file %s: %w", icsFile, err)
+		}
+		holidays = filterHolidayYears(holidays, years)
+	case countryCode != "":
+		if len(years) == 0 {
+			return nil, fmt.Errorf("years must be set to generate the holidays of country %s", countryCode)
+		}
+		var err error
+		if holidays, err = builtInHolidays(countryCode, years); err != nil {
+			return nil, err
+		}
+	default:
+		return nil, fmt.Errorf("either ics_file or country_code must be set")
+	}
+
+	sort.Slice(holidays, func(i, j int) bool { return holidays[i].key < holidays[j].key })
+	return holidays, nil
+}
+
+// filterHolidayYears keeps the holidays starting in one of the years. Recurring holidays are always kept.
+func filterHolidayYears(holidays []holiday, years []int) []holiday {
+	if len(years) == 0 {
+		return holidays
+	}
+	var filtered []holiday
+	for _, h := range holidays {
+		for _, year := range years {
+			if h.rrule != "" || h.start.Year() == year {
+				filtered = append(filtered, h)
+				break
+			}
+		}
+	}
+	return filtered
+}
+
+// icsProperty is a content line of an iCalendar file
+type icsProperty struct {
+	name   string
+	params map[string]string
+	value  string
+}
+
+// parseIcsHolidays reads the VEVENT components of an iCalendar file. Cancelled events are skipped, and an event's
+// RRULE is kept as the rrule of its schedule.
+func parseIcsHolidays(reader io.Reader) ([]holiday, error) {
+	lines, err := unfoldIcsLines(reader)
+	if err != nil {
+		return nil, err
+	}
+
+	var holidays []holiday
+	var event []icsProperty
+	inEvent := false
+	eventLine, nested := 0, 0
+	seen := make(map[string]int)
+	for _, line := range lines {
+		property, err := parseIcsProperty(line.text)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", line.number, err)
+		}
+
+		switch {
+		case property.name == "BEGIN" && strings.EqualFold(property.value, "VEVENT"):
+			inEvent, event, eventLine = true, nil, line.number
+		case property.name == "END" && strings.EqualFold(property.value, "VEVENT"):
+			if !inEvent {
+				return nil, fmt.Errorf("line %d: END:VEVENT without BEGIN:VEVENT", line.number)
+			}
+			inEvent = false
+			h, ok, err := icsEventHoliday(event)
+			if err != nil {
+				return nil, fmt.Errorf("event at line %d: %w", eventLine, err)
+			}
+			if !ok {
+				continue
+			}
+			if previousLine, found := seen[h.key]; found {
+				return nil, fmt.Errorf("events at lines %d and %d both start on %s", previousLine, eventLine, h.key)
+			}
+			seen[h.key] = eventLine
+			holidays = append(holidays, h)
+		case inEvent && property.name == "BEGIN":
+			// Properties of components within the event, such as VALARM, are not properties of the event
+			nested++
+		case inEvent && property.name == "END":
+			nested--
+		case inEvent && nested == 0:
+			event = append(event, property)
+		}
+	}
+	if inEvent {
+		return nil, fmt.Errorf("event at line %d has no END:VEVENT", eventLine)
+	}
+	return holidays, nil
+}
+
+type icsLine struct {
+	number int
+	text   string
+}
+
+// unfoldIcsLines joins the continuation lines of an iCalendar file, which start with a space or a tab
+func unfoldIcsLines(reader io.Reader) ([]icsLine, error) {
+	var lines []icsLine
+	scanner := bufio.NewScanner(reader)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	number := 0
+	for scanner.Scan() {
+		number++
+		text := strings.TrimRight(scanner.Text(), "\r")
+		if number == 1 {
+			text = strings.TrimPrefix(text, "\ufeff")
+		}
+		if (strings.HasPrefix(text, " ") || strings.HasPrefix(text, "\t")) && len(lines) > 0 {
+			lines[len(lines)-1].text += text[1:]
+			continue
+		}
+		if strings.TrimSpace(text) == "" {
+			continue
+		}
+		lines = append(lines, icsLine{number: number, text: text})
+	}
+	return lines, scanner.Err()
+}
+
+func parseIcsProperty(line string) (icsProperty, error) {
+	// The value starts after the first colon that is not in a quoted parameter value
+	quoted := false
+	separator := -1
+	for i, r := range line {
+		if r == '"' {
+			quoted = !quoted
+		} else if r == ':' && !quoted {
+			separator = i
+			break
+		}
+	}
+	if separator < 0 {
+		return icsProperty{}, fmt.Errorf("invalid content line %q", line)
+	}
+
+	parts := strings.Split(line[:separator], ";")
+	property := icsProperty{
+		name:   strings.ToUpper(parts[0]),
+		params: make(map[string]string),
+		value:  line[separator+1:],
+	}
+	for _, param := range parts[1:] {
+		name, value, _ := strings.Cut(param, "=")
+		property.params[strings.ToUpper(name)] = strings.Trim(value, `"`)
+	}
+	return property, nil
+}
+
+// icsEventHoliday converts an event to a holiday. It returns false for cancelled events.
+func icsEventHoliday(event []icsProperty) (holiday, bool, error) {
+	var h holiday
+	var dtstart, dtend, duration *icsProperty
+	for i, property := range event {
+		switch property.name {
+		case "SUMMARY":
+			h.name = unescapeIcsText(property.value)
+		case "DTSTART":
+			dtstart = &event[i]
+		case "DTEND":
+			dtend = &event[i]
+		case "DURATION":
+			duration = &event[i]
+		case "RRULE":
+			h.rrule = property.value
+		case "STATUS":
+			if strings.EqualFold(property.value, "CANCELLED") {
+				return holiday{}, false, nil
+			}
+		}
+	}
+
+	if dtstart == nil {
+		return holiday{}, false, fmt.Errorf("missing DTSTART")
+	}
+	if strings.TrimSpace(h.name) == "" {
+		return holiday{}, false, fmt.Errorf("missing SUMMARY")
+	}
+
+	start, allDay, err := parseIcsTime(*dtstart)
+	if err != nil {
+		return holiday{}, false, err
+	}
+	switch {
+	case dtend != nil:
+		if h.end, _, err = parseIcsTime(*dtend); err != nil {
+			return holiday{}, false, err
+		}
+	case duration != nil:
+		length, err := parseIcsDuration(duration.value)
+		if err != nil {
+			return holiday{}, false, err
+		}
+		h.end = start.Add(length)
+	case allDay:
+		h.end = start.AddDate(0, 0, 1)
+	default:
+		return holiday{}, false, fmt.Errorf("missing DTEND or DURATION")
+	}
+	if !h.end.After(start) {
+		return holiday{}, false, fmt.Errorf("ends before it starts")
+	}
+	if h.rrule != "" {
+		if diagErr := validators.ValidateRrule(h.rrule, nil); diagErr.HasError() {
+			return holiday{}, false, fmt.Errorf("invalid RRULE %s: %s", h.rrule, diagErr[0].Summary)
+		}
+	}
+
+	h.start = start
+	h.key = start.Format(holidayKeyFormat)
+	return h, true, nil
+}
+
+// parseIcsTime reads a DATE or DATE-TIME value. Times are taken as written, as schedules have no time zone, so UTC
+// times are rejected.
+func parseIcsTime(property icsProperty) (time.Time, bool, error) {
+	value := property.value
+	if strings.EqualFold(property.params["VALUE"], "DATE") || len(value) == len("20060102") {
+		t, err := time.Parse("20060102", value)
+		if err != nil {
+			return time.Time{}, false, fmt.Errorf("invalid %s date %s", property.name, value)
+		}
+		return t, true, nil
+	}
+	if strings.HasSuffix(value, "Z") {
+		return time.Time{}, false, fmt.Errorf("%s %s is in UTC. Use a DATE or a local DATE-TIME value", property.name, value)
+	}
+	t, err := time.Parse("20060102T150405", value)
+	if err != nil {
+		return time.Time{}, false, fmt.Errorf("invalid %s date time %s", property.name, value)
+	}
+	return t, false, nil
+}
+
+var icsDurationPattern = regexp.MustCompile(`^\+?P(?:(\d+)W)?(?:(\d+)D)?(?:T(?:(\d+)H)?(?:(\d+)M)?(?:(\d+)S)?)?$`)
+
+// parseIcsDuration reads a positive DURATION value such as P1D or PT8H30M
+func parseIcsDuration(value string) (time.Duration, error) {
+	match := icsDurationPattern.FindStringSubmatch(value)
+	if match == nil || value == "P" || strings.HasSuffix(value, "T") {
+		return 0, fmt.Errorf("invalid DURATION %s", value)
+	}
+	units := []time.Duration{7 * 24 * time.Hour, 24 * time.Hour, time.Hour, time.Minute, time.Second}
+	var duration time.Duration
+	for i, unit := range units {
+		if match[i+1] != "" {
+			n, _ := strconv.Atoi(match[i+1])
+			duration += time.Duration(n) * unit
+		}
+	}
+	return duration, nil
+}
+
+func unescapeIcsText(value string) string {
+	replacer := strings.NewReplacer(`\n`, " ", `\N`, " ", `\,`, ",", `\;`, ";", `\\`, `\`)
+	return strings.TrimSpace(replacer.Replace(value))
+}
+
+// builtInHolidayCountries are the country codes with a built-in holiday rule set
+var builtInHolidayCountries = map[string]func(year int) []holidayDate{
+	"CA": canadaHolidays,
+	"DE": germanyHolidays,
+	"FR": franceHolidays,
+	"GB": unitedKingdomHolidays,
+	"IE": irelandHolidays,
+	"US": unitedStatesHolidays,
+}
+
+// holidayDate is an all day holiday of a built-in rule set
+type holidayDate struct {
+	date time.Time
+	name string
+}
+
+// builtInHolidays generates the all day holidays of a country for the given years
+func builtInHolidays(countryCode string, years []int) ([]holiday, error) {
+	rules, ok := builtInHolidayCountries[countryCode]
+	if !ok {
+		return nil, fmt.Errorf("no built-in holidays for country %s", countryCode)
+	}
+
+	var holidays []holiday
+	seen := make(map[string]bool)
+	for _, year := range years {
+		for _, date := range rules(year) {
+			key := date.date.Format(holidayKeyFormat)
+			if seen[key] {
+				continue
+			}
+			seen[key] = true
+			holidays = append(holidays, holiday{key: key, name: date.name, start: date.date, end: date.date.AddDate(0, 0, 1)})
+		}
+	}
+	return holidays, nil
+}
+
+func unitedStatesHolidays(year int) []holidayDate {
+	dates := []holidayDate{
+		{nthWeekday(year, time.January, time.Monday, 3), "Martin Luther King Jr. Day"},
+		{nthWeekday(year, time.February, time.Monday, 3), "Washington's Birthday"},
+		{nthWeekday(year, time.May, time.Monday, -1), "Memorial Day"},
+		{nthWeekday(year, time.September, time.Monday, 1), "Labor Day"},
+		{nthWeekday(year, time.October, time.Monday, 2), "Columbus Day"},
+		{nthWeekday(year, time.November, time.Thursday, 4), "Thanksgiving Day"},
+	}
+	fixed := []holidayDate{
+		{date(year, time.January, 1), "New Year's Day"},
+		{date(year, time.July, 4), "Independence Day"},
+		{date(year, time.November, 11), "Veterans Day"},
+		{date(year, time.December, 25), "Christmas Day"},
+	}
+	if year >= 2021 {
+		fixed = append(fixed, holidayDate{date(year, time.June, 19), "Juneteenth National Independence Day"})
+	}
+
+	// Holidays on a Saturday are observed on the Friday before, and holidays on a Sunday on the Monday after
+	for _, h := range fixed {
+		dates = append(dates, h)
+		switch h.date.Weekday() {
+		case time.Saturday:
+			dates = append(dates, holidayDate{h.date.AddDate(0, 0, -1), h.name + " (observed)"})
+		case time.Sunday:
+			dates = append(dates, holidayDate{h.date.AddDate(0, 0, 1), h.name + " (observed)"})
+		}
+	}
+	return dates
+}
+
+func unitedKingdomHolidays(year int) []holidayDate {
+	easter := easterSunday(year)
+	dates := []holidayDate{
+		{easter.AddDate(0, 0, -2), "Good Friday"},
+		{easter.AddDate(0, 0, 1), "Easter Monday"},
+		{nthWeekday(year, time.May, time.Monday, 1), "Early May bank holiday"},
+		{nthWeekday(year, time.May, time.Monday, -1), "Spring bank holiday"},
+		{nthWeekday(year, time.August, time.Monday, -1), "Summer bank holiday"},
+	}
+	return withSubstituteDays(dates, []holidayDate{
+		{date(year, time.January, 1), "New Year's Day"},
+		{date(year, time.December, 25), "Christmas Day"},
+		{date(year, time.December, 26), "Boxing Day"},
+	})
+}
+
+func irelandHolidays(year int) []holidayDate {
+	dates := []holidayDate{
+		{easterSunday(year).AddDate(0, 0, 1), "Easter Monday"},
+		{nthWeekday(year, time.May, time.Monday, 1), "May Bank Holiday"},
+		{nthWeekday(year, time.June, time.Monday, 1), "June Bank Holiday"},
+		{nthWeekday(year, time.August, time.Monday, 1), "August Bank Holiday"},
+		{nthWeekday(year, time.October, time.Monday, -1), "October Bank Holiday"},
+	}
+	if year >= 2023 {
+		// St Brigid's Day is the first Monday of February, or the 1st of February when it is a Friday
+		stBrigid := nthWeekday(year, time.February, time.Monday, 1)
+		if february1 := date(year, time.February, 1); february1.Weekday() == time.Friday {
+			stBrigid = february1
+		}
+		dates = append(dates, holidayDate{stBrigid, "St Brigid's Day"})
+	}
+	return withSubstituteDays(dates, []holidayDate{
+		{date(year, time.January, 1), "New Year's Day"},
+		{date(year, time.March, 17), "St Patrick's Day"},
+		{date(year, time.December, 25), "Christmas Day"},
+		{date(year, time.December, 26), "St Stephen's Day"},
+	})
+}
+
+func canadaHolidays(year int) []holidayDate {
+	// Victoria Day is the last Monday before the 25th of May
+	victoriaDay := date(year, time.May, 24)
+	for victoriaDay.Weekday() != time.Monday {
+		victoriaDay = victoriaDay.AddDate(0, 0, -1)
+	}
+
+	dates := []holidayDate{
+		{easterSunday(year).AddDate(0, 0, -2), "Good Friday"},
+		{victoriaDay, "Victoria Day"},
+		{nthWeekday(year, time.September, time.Monday, 1), "Labour Day"},
+		{nthWeekday(year, time.October, time.Monday, 2), "Thanksgiving Day"},
+		{date(year, time.November, 11), "Remembrance Day"},
+	}
+	if year >= 2021 {
+		dates = append(dates, holidayDate{date(year, time.September, 30), "National Day for Truth and Reconciliation"})
+	}
+	return withSubstituteDays(dates, []holidayDate{
+		{date(year, time.January, 1), "New Year's Day"},
+		{date(year, time.July, 1), "Canada Day"},
+		{date(year, time.December, 25), "Christmas Day"},
+		{date(year, time.December, 26), "Boxing Day"},
+	})
+}
+
+func germanyHolidays(year int) []holidayDate {
+	easter := easterSunday(year)
+	return []holidayDate{
+		{date(year, time.January, 1), "Neujahr"},
+		{easter.AddDate(0, 0, -2), "Karfreitag"},
+		{easter.AddDate(0, 0, 1), "Ostermontag"},
+		{date(year, time.May, 1), "Tag der Arbeit"},
+		{easter.AddDate(0, 0, 39), "Christi Himmelfahrt"},
+		{easter.AddDate(0, 0, 50), "Pfingstmontag"},
+		{date(year, time.October, 3), "Tag der Deutschen Einheit"},
+		{date(year, time.December, 25), "1. Weihnachtstag"},
+		{date(year, time.December, 26), "2. Weihnachtstag"},
+	}
+}
+
+func franceHolidays(year int) []holidayDate {
+	easter := easterSunday(year)
+	return []holidayDate{
+		{date(year, time.January, 1), "Jour de l'an"},
+		{easter.AddDate(0, 0, 1), "Lundi de Pâques"},
+		{date(year, time.May, 1), "Fête du Travail"},
+		{date(year, time.May, 8), "Victoire 1945"},
+		{easter.AddDate(0, 0, 39), "Ascension"},
+		{easter.AddDate(0, 0, 50), "Lundi de Pentecôte"},
+		{date(year, time.July, 14), "Fête nationale"},
+		{date(year, time.August, 15), "Assomption"},
+		{date(year, time.November, 1), "Toussaint"},
+		{date(year, time.November, 11), "Armistice 1918"},
+		{date(year, time.December, 25), "Noël"},
+	}
+}
+
+// withSubstituteDays adds the fixed holidays to dates, together with a substitute day for each fixed holiday on a
+// weekend. The substitute day is the next weekday that is not already a holiday.
+func withSubstituteDays(dates []holidayDate, fixed []holidayDate) []holidayDate {
+	taken := make(map[time.Time]bool)
+	for _, h := range append(dates, fixed...) {
+		taken[h.date] = true
+	}
+
+	dates = append(dates, fixed...)
+	for _, h := range fixed {
+		if h.date.Weekday() != time.Saturday && h.date.Weekday() != time.Sunday {
+			continue
+		}
+		substitute := h.date.AddDate(0, 0, 1)
+		for substitute.Weekday() == time.Saturday || substitute.Weekday() == time.Sunday || taken[substitute] {
+			substitute = substitute.AddDate(0, 0, 1)
+		}
+		taken[substitute] = true
+		dates = append(dates, holidayDate{substitute, h.name + " (substitute day)"})
+	}
+	return dates
+}
+
+func date(year int, month time.Month, day int) time.Time {
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
+
+// nthWeekday returns the nth weekday of a month, or the nth last weekday when n is negative
+func nthWeekday(year int, month time.Month, weekday time.Weekday, n int) time.Time {
+	if n > 0 {
+		first := date(year, month, 1)
+		return first.AddDate(0, 0, (int(weekday)-int(first.Weekday())+7)%7+7*(n-1))
+	}
+	last := date(year, month+1, 0)
+	return last.AddDate(0, 0, -((int(last.Weekday())-int(weekday)+7)%7)+7*(n+1))
+}
+
+// easterSunday computes the date of Easter Sunday in the Gregorian calendar
+func easterSunday(year int) time.Time {
+	a := year % 19
+	b, c := year/100, year%100
+	d, e := b/4, b%4
+	f := (b + 8) / 25
+	g := (b - f + 1) / 3
+	h := (19*a + b - d - g + 15) % 30
+	i, k := c/4, c%4
+	l := (32 + 2*e + 2*i - h - k) % 7
+	m := (a + 11*h + 22*l) / 451
+	month := (h + l - 7*m + 114) / 31
+	day := (h+l-7*m+114)%31 + 1
+	return date(year, time.Month(month), day)
+}
@@ -30,6 +30,7 @@ func (r *registerTestInstance) registerTestResources() {
 	defer r.resourceMapMutex.Unlock()
 
 	providerResources[ResourceType] = ResourceArchitectSchedules()
+	providerResources[HolidaySchedulesResourceType] = ResourceArchitectHolidaySchedules()
 	providerResources[authDivision.ResourceType] = authDivision.ResourceAuthDivision()
 }
 
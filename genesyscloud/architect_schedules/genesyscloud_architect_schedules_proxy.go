@@ -28,6 +28,8 @@ type getArchitectSchedulesIdByNameFunc func(ctx context.Context, p *architectSch
 type getArchitectSchedulesByIdFunc func(ctx context.Context, p *architectSchedulesProxy, id string) (schedules *platformclientv2.Schedule, response *platformclientv2.APIResponse, err error)
 type updateArchitectSchedulesFunc func(ctx context.Context, p *architectSchedulesProxy, id string, schedules *platformclientv2.Schedule) (*platformclientv2.Schedule, *platformclientv2.APIResponse, error)
 type deleteArchitectSchedulesFunc func(ctx context.Context, p *architectSchedulesProxy, id string) (*platformclientv2.APIResponse, error)
+type getArchitectSchedulegroupByIdFunc func(ctx context.Context, p *architectSchedulesProxy, id string) (scheduleGroup *platformclientv2.Schedulegroup, response *platformclientv2.APIResponse, err error)
+type updateArchitectSchedulegroupFunc func(ctx context.Context, p *architectSchedulesProxy, id string, scheduleGroup *platformclientv2.Schedulegroup) (*platformclientv2.Schedulegroup, *platformclientv2.APIResponse, error)
 
 /*
 The architectSchedulesProxy struct holds all the methods responsible for making calls to
@@ -45,6 +47,8 @@ type architectSchedulesProxy struct {
 	getArchitectSchedulesByIdAttr     getArchitectSchedulesByIdFunc
 	updateArchitectSchedulesAttr      updateArchitectSchedulesFunc
 	deleteArchitectSchedulesAttr      deleteArchitectSchedulesFunc
+	getArchitectSchedulegroupByIdAttr getArchitectSchedulegroupByIdFunc
+	updateArchitectSchedulegroupAttr  updateArchitectSchedulegroupFunc
 	schedulesCache                    rc.CacheInterface[platformclientv2.Schedule] //Define the cache for architect schedules resource
 }
 
@@ -67,6 +71,8 @@ func newArchitectSchedulesProxy(clientConfig *platformclientv2.Configuration) *a
 		getArchitectSchedulesByIdAttr:     getArchitectSchedulesByIdFn,
 		updateArchitectSchedulesAttr:      updateArchitectSchedulesFn,
 		deleteArchitectSchedulesAttr:      deleteArchitectSchedulesFn,
+		getArchitectSchedulegroupByIdAttr: getArchitectSchedulegroupByIdFn,
+		updateArchitectSchedulegroupAttr:  updateArchitectSchedulegroupFn,
 	}
 }
 
@@ -117,6 +123,16 @@ func (p *architectSchedulesProxy) deleteArchitectSchedules(ctx context.Context,
 	return p.deleteArchitectSchedulesAttr(ctx, p, id)
 }
 
+// getArchitectSchedulegroupById returns the Genesys Cloud architect schedule group holiday schedules are attached to
+func (p *architectSchedulesProxy) getArchitectSchedulegroupById(ctx context.Context, id string) (scheduleGroup *platformclientv2.Schedulegroup, response *platformclientv2.APIResponse, err error) {
+	return p.getArchitectSchedulegroupByIdAttr(ctx, p, id)
+}
+
+// updateArchitectSchedulegroup updates the Genesys Cloud architect schedule group holiday schedules are attached to
+func (p *architectSchedulesProxy) updateArchitectSchedulegroup(ctx context.Context, id string, scheduleGroup *platformclientv2.Schedulegroup) (*platformclientv2.Schedulegroup, *platformclientv2.APIResponse, error) {
+	return p.updateArchitectSchedulegroupAttr(ctx, p, id, scheduleGroup)
+}
+
 // createArchitectSchedulesFn is an implementation function for creating a Genesys Cloud architect schedules
 func createArchitectSchedulesFn(ctx context.Context, p *architectSchedulesProxy, architectSchedules *platformclientv2.Schedule) (*platformclientv2.Schedule, *platformclientv2.APIResponse, error) {
 	schedules, apiResponse, err := p.architectApi.PostArchitectSchedules(*architectSchedules)
@@ -215,3 +231,21 @@ func deleteArchitectSchedulesFn(ctx context.Context, p *architectSchedulesProxy,
 	}
 	return resp, nil
 }
+
+// getArchitectSchedulegroupByIdFn is an implementation of the function to get a Genesys Cloud architect schedule group by Id
+func getArchitectSchedulegroupByIdFn(ctx context.Context, p *architectSchedulesProxy, id string) (scheduleGroup *platformclientv2.Schedulegroup, response *platformclientv2.APIResponse, err error) {
+	scheduleGroup, apiResponse, err := p.architectApi.GetArchitectSchedulegroup(id)
+	if err != nil {
+		return nil, apiResponse, fmt.Errorf("Failed to retrieve architect schedule group by id %s: %s", id, err)
+	}
+	return scheduleGroup, apiResponse, nil
+}
+
+// updateArchitectSchedulegroupFn is an implementation of the function to update a Genesys Cloud architect schedule group
+func updateArchitectSchedulegroupFn(ctx context.Context, p *architectSchedulesProxy, id string, scheduleGroup *platformclientv2.Schedulegroup) (*platformclientv2.Schedulegroup, *platformclientv2.APIResponse, error) {
+	updatedGroup, apiResponse, err := p.architectApi.PutArchitectSchedulegroup(id, *scheduleGroup)
+	if err != nil {
+		return nil, apiResponse, fmt.Errorf("Failed to update architect schedule group %s: %s", id, err)
+	}
+	return updatedGroup, apiResponse, nil
+}
@@ -0,0 +1,458 @@
+package architect_schedules
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"log"
+	"sort"
+	"terraform-provider-genesyscloud/genesyscloud/provider"
+	"terraform-provider-genesyscloud/genesyscloud/util"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/leekchan/timeutil"
+	"github.com/mypurecloud/platform-client-sdk-go/v149/platformclientv2"
+)
+
+/*
+   The resource_genesyscloud_architect_holiday_schedules.go file contains the genesyscloud_architect_holiday_schedules
+   resource. It materializes one architect schedule per holiday of its source, and keeps the schedules in sync with the
+   source by the date key of each holiday. The holidays are read by genesyscloud_architect_holiday_schedules_sources.go.
+*/
+
+// holidaySchedule is a holiday schedule managed by the resource, as kept in the holidays attribute
+type holidaySchedule struct {
+	key        string
+	name       string
+	scheduleId string
+	start      string
+	end        string
+	rrule      string
+}
+
+// newHolidaySchedule returns the schedule of a holiday, named after the name_prefix of the resource and the holiday date key
+func newHolidaySchedule(namePrefix string, h holiday) holidaySchedule {
+	return holidaySchedule{
+		key:   h.key,
+		name:  fmt.Sprintf("%s %s %s", namePrefix, h.key, h.name),
+		start: timeutil.Strftime(&h.start, "%Y-%m-%dT%H:%M:%S.%f"),
+		end:   timeutil.Strftime(&h.end, "%Y-%m-%dT%H:%M:%S.%f"),
+		rrule: h.rrule,
+	}
+}
+
+func (s holidaySchedule) sameContent(other holidaySchedule) bool {
+	return s.name == other.name && s.start == other.start && s.end == other.end && s.rrule == other.rrule
+}
+
+// hashHolidaySchedules returns the hash of the content of the schedules, sorted by date key
+func hashHolidaySchedules(schedules []holidaySchedule) string {
+	sorted := append([]holidaySchedule(nil), schedules...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].key < sorted[j].key })
+
+	hash := sha256.New()
+	for _, s := range sorted {
+		_, _ = fmt.Fprintf(hash, "%s\n%s\n%s\n%s\n%s\n", s.key, s.name, s.start, s.end, s.rrule)
+	}
+	return hex.EncodeToString(hash.Sum(nil))
+}
+
+// desiredHolidaySchedules reads the source of the resource and returns the schedules it describes
+func desiredHolidaySchedules(get func(string) interface{}) ([]holidaySchedule, error) {
+	var years []int
+	for _, year := range get("years").([]interface{}) {
+		years = append(years, year.(int))
+	}
+
+	holidays, err := loadHolidays(get("ics_file").(string), get("country_code").(string), years)
+	if err != nil {
+		return nil, err
+	}
+
+	namePrefix := get("name_prefix").(string)
+	schedules := make([]holidaySchedule, 0, len(holidays))
+	for _, h := range holidays {
+		schedules = append(schedules, newHolidaySchedule(namePrefix, h))
+	}
+	return schedules, nil
+}
+
+// customizeHolidaySchedulesDiff plans holidays_hash from the source, so that changes to the ics file or to the built-in
+// holidays are planned as updates
+func customizeHolidaySchedulesDiff(_ context.Context, d *schema.ResourceDiff, _ interface{}) error {
+	for _, key := range []string{"name_prefix", "ics_file", "country_code", "years"} {
+		if !d.NewValueKnown(key) {
+			return d.SetNewComputed("holidays_hash")
+		}
+	}
+
+	desired, err := desiredHolidaySchedules(d.Get)
+	if err != nil {
+		return err
+	}
+	if hash := hashHolidaySchedules(desired); hash != d.Get("holidays_hash").(string) {
+		if err := d.SetNew("holidays_hash", hash); err != nil {
+			return err
+		}
+		return d.SetNewComputed("holidays")
+	}
+	return nil
+}
+
+func createArchitectHolidaySchedules(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	sdkConfig := meta.(*provider.ProviderMeta).ClientConfig
+	proxy := getArchitectSchedulesProxy(sdkConfig)
+
+	desired, err := desiredHolidaySchedules(d.Get)
+	if err != nil {
+		return util.BuildDiagnosticError(HolidaySchedulesResourceType, "failed to read the holidays", err)
+	}
+
+	d.SetId(uuid.NewString())
+	log.Printf("Creating %d holiday schedules %s", len(desired), d.Get("name_prefix").(string))
+	if diagErr := syncHolidaySchedules(ctx, d, proxy, desired); diagErr != nil {
+		return diagErr
+	}
+
+	log.Printf("Created holiday schedules %s", d.Id())
+	return readArchitectHolidaySchedules(ctx, d, meta)
+}
+
+func readArchitectHolidaySchedules(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	sdkConfig := meta.(*provider.ProviderMeta).ClientConfig
+	proxy := getArchitectSchedulesProxy(sdkConfig)
+
+	log.Printf("Reading holiday schedules %s", d.Id())
+	var current []holidaySchedule
+	for _, s := range holidaySchedulesFromState(d) {
+		schedule, resp, err := proxy.getArchitectSchedulesById(ctx, s.scheduleId)
+		if err != nil {
+			if util.IsStatus404(resp) {
+				log.Printf("Holiday schedule %s %s no longer exists", s.key, s.scheduleId)
+				continue
+			}
+			return util.BuildAPIDiagnosticError(HolidaySchedulesResourceType, fmt.Sprintf("failed to read holiday schedule %s | error: %s", s.scheduleId, err), resp)
+		}
+		if schedule.State != nil && *schedule.State == "deleted" {
+			log.Printf("Holiday schedule %s %s has been deleted", s.key, s.scheduleId)
+			continue
+		}
+
+		current = append(current, flattenHolidaySchedule(s.key, schedule))
+		if d.Get("division_id").(string) == "" && schedule.Division != nil && schedule.Division.Id != nil {
+			_ = d.Set("division_id", *schedule.Division.Id)
+		}
+	}
+
+	setHolidaySchedules(d, current)
+	_ = d.Set("holidays_hash", hashHolidaySchedules(current))
+
+	log.Printf("Read %d holiday schedules %s", len(current), d.Id())
+	return nil
+}
+
+func updateArchitectHolidaySchedules(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	sdkConfig := meta.(*provider.ProviderMeta).ClientConfig
+	proxy := getArchitectSchedulesProxy(sdkConfig)
+
+	desired, err := desiredHolidaySchedules(d.Get)
+	if err != nil {
+		return util.BuildDiagnosticError(HolidaySchedulesResourceType, "failed to read the holidays", err)
+	}
+
+	log.Printf("Updating holiday schedules %s", d.Id())
+	if diagErr := syncHolidaySchedules(ctx, d, proxy, desired); diagErr != nil {
+		return diagErr
+	}
+
+	log.Printf("Updated holiday schedules %s", d.Id())
+	return readArchitectHolidaySchedules(ctx, d, meta)
+}
+
+func deleteArchitectHolidaySchedules(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	sdkConfig := meta.(*provider.ProviderMeta).ClientConfig
+	proxy := getArchitectSchedulesProxy(sdkConfig)
+
+	current := holidaySchedulesFromState(d)
+	var scheduleIds []string
+	for _, s := range current {
+		scheduleIds = append(scheduleIds, s.scheduleId)
+	}
+
+	if groupId := d.Get("schedule_group_id").(string); groupId != "" {
+		if diagErr := updateScheduleGroupHolidays(ctx, proxy, groupId, nil, scheduleIds); diagErr != nil {
+			return diagErr
+		}
+	}
+
+	for _, s := range current {
+		if diagErr := deleteHolidaySchedule(ctx, proxy, s); diagErr != nil {
+			return diagErr
+		}
+	}
+
+	log.Printf("Deleted holiday schedules %s", d.Id())
+	return nil
+}
+
+// syncHolidaySchedules creates the schedules of new holidays, updates the schedules of changed holidays and deletes the
+// schedules of removed holidays. The schedules are attached to the schedule group before the removed ones are deleted.
+// The holidays attribute is set to the schedules that exist, also when the sync fails.
+func syncHolidaySchedules(ctx context.Context, d *schema.ResourceData, proxy *architectSchedulesProxy, desired []holidaySchedule) diag.Diagnostics {
+	// The plan marks holidays as unknown when the source changes, so the current schedules are read from the state
+	oldHolidays, _ := d.GetChange("holidays")
+	current := make(map[string]holidaySchedule)
+	for _, s := range buildHolidaySchedules(oldHolidays.([]interface{})) {
+		current[s.key] = s
+	}
+
+	synced := make(map[string]holidaySchedule)
+	for key, s := range current {
+		synced[key] = s
+	}
+	defer func() {
+		schedules := make([]holidaySchedule, 0, len(synced))
+		for _, s := range synced {
+			schedules = append(schedules, s)
+		}
+		setHolidaySchedules(d, schedules)
+	}()
+
+	settingsChanged := d.HasChanges("division_id", "description")
+	desiredKeys := make(map[string]bool)
+	var desiredIds []string
+	for _, s := range desired {
+		desiredKeys[s.key] = true
+		existing, exists := current[s.key]
+		switch {
+		case !exists:
+			scheduleId, diagErr := createHolidaySchedule(ctx, d, proxy, s)
+			if diagErr != nil {
+				return diagErr
+			}
+			s.scheduleId = scheduleId
+		case !existing.sameContent(s) || settingsChanged:
+			s.scheduleId = existing.scheduleId
+			scheduleId, diagErr := updateHolidaySchedule(ctx, d, proxy, s)
+			if diagErr != nil {
+				return diagErr
+			}
+			s.scheduleId = scheduleId
+		default:
+			s = existing
+		}
+		synced[s.key] = s
+		desiredIds = append(desiredIds, s.scheduleId)
+	}
+
+	var removed []holidaySchedule
+	var removedIds []string
+	for key, s := range current {
+		if !desiredKeys[key] {
+			removed = append(removed, s)
+			removedIds = append(removedIds, s.scheduleId)
+		}
+	}
+
+	oldGroupId, newGroupId := d.GetChange("schedule_group_id")
+	if oldGroupId.(string) != "" && oldGroupId.(string) != newGroupId.(string) {
+		var currentIds []string
+		for _, s := range current {
+			currentIds = append(currentIds, s.scheduleId)
+		}
+		if diagErr := updateScheduleGroupHolidays(ctx, proxy, oldGroupId.(string), nil, currentIds); diagErr != nil {
+			return diagErr
+		}
+	}
+	if newGroupId.(string) != "" {
+		if diagErr := updateScheduleGroupHolidays(ctx, proxy, newGroupId.(string), desiredIds, removedIds); diagErr != nil {
+			return diagErr
+		}
+	}
+
+	for _, s := range removed {
+		if diagErr := deleteHolidaySchedule(ctx, proxy, s); diagErr != nil {
+			return diagErr
+		}
+		delete(synced, s.key)
+	}
+	return nil
+}
+
+func buildHolidaySchedule(d *schema.ResourceData, s holidaySchedule) (*platformclientv2.Schedule, diag.Diagnostics) {
+	start, err := time.Parse(timeFormat, s.start)
+	if err != nil {
+		return nil, util.BuildDiagnosticError(HolidaySchedulesResourceType, fmt.Sprintf("Failed to parse date %s", s.start), err)
+	}
+	end, err := time.Parse(timeFormat, s.end)
+	if err != nil {
+		return nil, util.BuildDiagnosticError(HolidaySchedulesResourceType, fmt.Sprintf("Failed to parse date %s", s.end), err)
+	}
+
+	schedule := &platformclientv2.Schedule{
+		Name:  platformclientv2.String(s.name),
+		Start: &start,
+		End:   &end,
+		Rrule: platformclientv2.String(s.rrule),
+	}
+	if description := d.Get("description").(string); description != "" {
+		schedule.Description = &description
+	}
+	if divisionId := d.Get("division_id").(string); divisionId != "" {
+		schedule.Division = &platformclientv2.Writabledivision{Id: &divisionId}
+	}
+	return schedule, nil
+}
+
+func createHolidaySchedule(ctx context.Context, d *schema.ResourceData, proxy *architectSchedulesProxy, s holidaySchedule) (string, diag.Diagnostics) {
+	schedule, diagErr := buildHolidaySchedule(d, s)
+	if diagErr != nil {
+		return "", diagErr
+	}
+
+	log.Printf("Creating holiday schedule %s", s.name)
+	created, resp, err := proxy.createArchitectSchedules(ctx, schedule)
+	if err != nil {
+		return "", util.BuildAPIDiagnosticError(HolidaySchedulesResourceType, fmt.Sprintf("failed to create holiday schedule %s | error: %s", s.name, err), resp)
+	}
+	return *created.Id, nil
+}
+
+// updateHolidaySchedule updates the schedule of a holiday, and creates it again when it has been deleted outside of Terraform
+func updateHolidaySchedule(ctx context.Context, d *schema.ResourceData, proxy *architectSchedulesProxy, s holidaySchedule) (string, diag.Diagnostics) {
+	schedule, diagErr := buildHolidaySchedule(d, s)
+	if diagErr != nil {
+		return "", diagErr
+	}
+
+	log.Printf("Updating holiday schedule %s %s", s.name, s.scheduleId)
+	_, resp, err := proxy.updateArchitectSchedules(ctx, s.scheduleId, schedule)
+	if err != nil {
+		if util.IsStatus404(resp) {
+			return createHolidaySchedule(ctx, d, proxy, s)
+		}
+		return "", util.BuildAPIDiagnosticError(HolidaySchedulesResourceType, fmt.Sprintf("failed to update holiday schedule %s | error: %s", s.name, err), resp)
+	}
+	return s.scheduleId, nil
+}
+
+func deleteHolidaySchedule(ctx context.Context, proxy *architectSchedulesProxy, s holidaySchedule) diag.Diagnostics {
+	// A schedule still linked to a schedule group cannot be deleted until the schedule group is updated
+	return util.RetryWhen(util.IsStatus409, func() (*platformclientv2.APIResponse, diag.Diagnostics) {
+		log.Printf("Deleting holiday schedule %s %s", s.name, s.scheduleId)
+		resp, err := proxy.deleteArchitectSchedules(ctx, s.scheduleId)
+		if err != nil && !util.IsStatus404(resp) {
+			return resp, util.BuildAPIDiagnosticError(HolidaySchedulesResourceType, fmt.Sprintf("failed to delete holiday schedule %s | error: %s", s.scheduleId, err), resp)
+		}
+		return resp, nil
+	})
+}
+
+// updateScheduleGroupHolidays adds and removes holiday schedules of a schedule group, keeping its other holiday schedules
+func updateScheduleGroupHolidays(ctx context.Context, proxy *architectSchedulesProxy, groupId string, add, remove []string) diag.Diagnostics {
+	group, resp, err := proxy.getArchitectSchedulegroupById(ctx, groupId)
+	if err != nil {
+		if util.IsStatus404(resp) && len(add) == 0 {
+			return nil
+		}
+		return util.BuildAPIDiagnosticError(HolidaySchedulesResourceType, fmt.Sprintf("failed to read schedule group %s | error: %s", groupId, err), resp)
+	}
+
+	removeIds := make(map[string]bool)
+	for _, id := range remove {
+		removeIds[id] = true
+	}
+
+	var holidays []platformclientv2.Domainentityref
+	present := make(map[string]bool)
+	if group.HolidaySchedules != nil {
+		for _, ref := range *group.HolidaySchedules {
+			if ref.Id == nil || removeIds[*ref.Id] {
+				continue
+			}
+			present[*ref.Id] = true
+			holidays = append(holidays, platformclientv2.Domainentityref{Id: ref.Id})
+		}
+	}
+	changed := group.HolidaySchedules != nil && len(holidays) != len(*group.HolidaySchedules)
+	for _, id := range add {
+		if !present[id] {
+			present[id] = true
+			holidays = append(holidays, platformclientv2.Domainentityref{Id: platformclientv2.String(id)})
+			changed = true
+		}
+	}
+	if !changed {
+		return nil
+	}
+
+	group.HolidaySchedules = &holidays
+	log.Printf("Updating the holiday schedules of schedule group %s", groupId)
+	if _, resp, err := proxy.updateArchitectSchedulegroup(ctx, groupId, group); err != nil {
+		return util.BuildAPIDiagnosticError(HolidaySchedulesResourceType, fmt.Sprintf("failed to update the holiday schedules of schedule group %s | error: %s", groupId, err), resp)
+	}
+	return nil
+}
+
+func flattenHolidaySchedule(key string, schedule *platformclientv2.Schedule) holidaySchedule {
+	s := holidaySchedule{key: key}
+	if schedule.Id != nil {
+		s.scheduleId = *schedule.Id
+	}
+	if schedule.Name != nil {
+		s.name = *schedule.Name
+	}
+	if schedule.Start != nil {
+		s.start = timeutil.Strftime(schedule.Start, "%Y-%m-%dT%H:%M:%S.%f")
+	}
+	if schedule.End != nil {
+		s.end = timeutil.Strftime(schedule.End, "%Y-%m-%dT%H:%M:%S.%f")
+	}
+	if schedule.Rrule != nil {
+		s.rrule = *schedule.Rrule
+	}
+	return s
+}
+
+func holidaySchedulesFromState(d *schema.ResourceData) []holidaySchedule {
+	return buildHolidaySchedules(d.Get("holidays").([]interface{}))
+}
+
+func buildHolidaySchedules(holidays []interface{}) []holidaySchedule {
+	var schedules []holidaySchedule
+	for _, item := range holidays {
+		m, ok := item.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		schedules = append(schedules, holidaySchedule{
+			key:        m["key"].(string),
+			name:       m["name"].(string),
+			scheduleId: m["schedule_id"].(string),
+			start:      m["start"].(string),
+			end:        m["end"].(string),
+			rrule:      m["rrule"].(string),
+		})
+	}
+	return schedules
+}
+
+func setHolidaySchedules(d *schema.ResourceData, schedules []holidaySchedule) {
+	sort.Slice(schedules, func(i, j int) bool { return schedules[i].key < schedules[j].key })
+	holidays := make([]interface{}, 0, len(schedules))
+	for _, s := range schedules {
+		holidays = append(holidays, map[string]interface{}{
+			"key":         s.key,
+			"name":        s.name,
+			"schedule_id": s.scheduleId,
+			"start":       s.start,
+			"end":         s.end,
+			"rrule":       s.rrule,
+		})
+	}
+	_ = d.Set("holidays", holidays)
+}
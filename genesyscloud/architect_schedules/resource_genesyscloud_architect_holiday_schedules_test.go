@@ -0,0 +1,89 @@
+package architect_schedules
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+	"terraform-provider-genesyscloud/genesyscloud/provider"
+	"terraform-provider-genesyscloud/genesyscloud/util"
+	"terraform-provider-genesyscloud/genesyscloud/util/testrunner"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/mypurecloud/platform-client-sdk-go/v149/platformclientv2"
+)
+
+func TestAccResourceArchitectHolidaySchedules(t *testing.T) {
+	var (
+		resourceLabel = "holidays"
+		namePrefix    = "CX as Code Holidays " + uuid.NewString()
+		description   = "Holidays by CX as Code"
+		icsFile       = filepath.Join(testrunner.GetTestDataPath("resource", HolidaySchedulesResourceType), "holidays.ics")
+	)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { util.TestAccPreCheck(t) },
+		ProviderFactories: provider.GetProviderFactories(providerResources, providerDataSources),
+		Steps: []resource.TestStep{
+			{
+				// Create from the built-in US holidays
+				Config: fmt.Sprintf(`resource "%s" "%s" {
+	name_prefix  = "%s"
+	description  = "%s"
+	country_code = "US"
+	years        = [2031]
+}
+`, HolidaySchedulesResourceType, resourceLabel, namePrefix, description),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(HolidaySchedulesResourceType+"."+resourceLabel, "holidays.#", "11"),
+					resource.TestCheckResourceAttr(HolidaySchedulesResourceType+"."+resourceLabel, "holidays.0.key", "2031-01-01"),
+					resource.TestCheckResourceAttr(HolidaySchedulesResourceType+"."+resourceLabel, "holidays.0.name", namePrefix+" 2031-01-01 New Year's Day"),
+					resource.TestCheckResourceAttr(HolidaySchedulesResourceType+"."+resourceLabel, "holidays.0.start", "2031-01-01T00:00:00.000000"),
+					resource.TestCheckResourceAttr(HolidaySchedulesResourceType+"."+resourceLabel, "holidays.0.end", "2031-01-02T00:00:00.000000"),
+					provider.TestDefaultHomeDivision(HolidaySchedulesResourceType+"."+resourceLabel),
+				),
+			},
+			{
+				// Replace the holidays with the events of an iCalendar file
+				Config: fmt.Sprintf(`resource "%s" "%s" {
+	name_prefix = "%s"
+	description = "%s"
+	ics_file    = "%s"
+}
+`, HolidaySchedulesResourceType, resourceLabel, namePrefix, description, filepath.ToSlash(icsFile)),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(HolidaySchedulesResourceType+"."+resourceLabel, "holidays.#", "2"),
+					resource.TestCheckResourceAttr(HolidaySchedulesResourceType+"."+resourceLabel, "holidays.0.name", namePrefix+" 2031-06-12 Company Day"),
+					resource.TestCheckResourceAttr(HolidaySchedulesResourceType+"."+resourceLabel, "holidays.1.key", "2031-12-29"),
+					resource.TestCheckResourceAttr(HolidaySchedulesResourceType+"."+resourceLabel, "holidays.1.end", "2032-01-01T00:00:00.000000"),
+				),
+			},
+		},
+		CheckDestroy: testVerifyHolidaySchedulesDestroyed,
+	})
+}
+
+func testVerifyHolidaySchedulesDestroyed(state *terraform.State) error {
+	archAPI := platformclientv2.NewArchitectApi()
+	for _, rs := range state.RootModule().Resources {
+		if rs.Type != HolidaySchedulesResourceType {
+			continue
+		}
+
+		for key, scheduleId := range rs.Primary.Attributes {
+			if !strings.HasPrefix(key, "holidays.") || !strings.HasSuffix(key, ".schedule_id") {
+				continue
+			}
+			sched, resp, err := archAPI.GetArchitectSchedule(scheduleId)
+			if sched != nil && (sched.State == nil || *sched.State != "deleted") {
+				return fmt.Errorf("Holiday schedule (%s) still exists", scheduleId)
+			} else if sched == nil && !util.IsStatus404(resp) {
+				return fmt.Errorf("Unexpected error: %s", err)
+			}
+		}
+	}
+	// Success. All holiday schedules destroyed
+	return nil
+}
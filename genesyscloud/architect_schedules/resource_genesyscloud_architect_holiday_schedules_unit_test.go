@@ -0,0 +1,322 @@
+package architect_schedules
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"terraform-provider-genesyscloud/genesyscloud/provider"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/mypurecloud/platform-client-sdk-go/v149/platformclientv2"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUnitParseIcsHolidays(t *testing.T) {
+	holidays, err := parseIcsHolidays(strings.NewReader(strings.Join([]string{
+		"BEGIN:VCALENDAR",
+		"VERSION:2.0",
+		"BEGIN:VEVENT",
+		"DTSTART;VALUE=DATE:20301225",
+		"SUMMARY:Christmas\\, ",
+		" Day",
+		"END:VEVENT",
+		"BEGIN:VEVENT",
+		"DTSTART;VALUE=DATE:20301231",
+		"DTEND;VALUE=DATE:20310102",
+		"SUMMARY:Year end shutdown",
+		"BEGIN:VALARM",
+		"TRIGGER:-PT15M",
+		"SUMMARY:Reminder",
+		"END:VALARM",
+		"END:VEVENT",
+		"BEGIN:VEVENT",
+		"DTSTART;TZID=America/New_York:20301124T120000",
+		"DURATION:PT5H",
+		"SUMMARY:Early close",
+		"END:VEVENT",
+		"BEGIN:VEVENT",
+		"DTSTART;VALUE=DATE:20300704",
+		"RRULE:FREQ=YEARLY;INTERVAL=1",
+		"SUMMARY:Independence Day",
+		"END:VEVENT",
+		"BEGIN:VEVENT",
+		"DTSTART;VALUE=DATE:20300101",
+		"STATUS:CANCELLED",
+		"SUMMARY:Cancelled",
+		"END:VEVENT",
+		"END:VCALENDAR",
+	}, "\r\n")))
+	assert.Nil(t, err)
+
+	day := func(month time.Month, day, hour int) time.Time {
+		return time.Date(2030, month, day, hour, 0, 0, 0, time.UTC)
+	}
+	assert.Equal(t, []holiday{
+		{key: "2030-12-25", name: "Christmas, Day", start: day(time.December, 25, 0), end: day(time.December, 26, 0)},
+		{key: "2030-12-31", name: "Year end shutdown", start: day(time.December, 31, 0), end: day(time.December, 33, 0)},
+		{key: "2030-11-24", name: "Early close", start: day(time.November, 24, 12), end: day(time.November, 24, 17)},
+		{key: "2030-07-04", name: "Independence Day", start: day(time.July, 4, 0), end: day(time.July, 5, 0), rrule: "FREQ=YEARLY;INTERVAL=1"},
+	}, holidays)
+
+	assert.Equal(t, []string{"2030-07-04", "2030-12-25"}, holidayKeys(filterHolidayYears([]holiday{
+		{key: "2029-12-25", start: time.Date(2029, time.December, 25, 0, 0, 0, 0, time.UTC)},
+		{key: "2030-07-04", start: day(time.July, 4, 0), rrule: "FREQ=YEARLY"},
+		{key: "2030-12-25", start: day(time.December, 25, 0)},
+	}, []int{2028, 2030})))
+
+	for content, expected := range map[string]string{
+		"BEGIN:VEVENT\nDTSTART:20300101\nSUMMARY:A\nEND:VEVENT\nBEGIN:VEVENT\nDTSTART:20300101\nSUMMARY:B\nEND:VEVENT": "events at lines 1 and 5 both start on 2030-01-01",
+		"BEGIN:VEVENT\nDTSTART:20300101T000000Z\nDTEND:20300102T000000Z\nSUMMARY:A\nEND:VEVENT":                        "event at line 1: DTSTART 20300101T000000Z is in UTC. Use a DATE or a local DATE-TIME value",
+		"BEGIN:VEVENT\nDTSTART:20300101T090000\nSUMMARY:A\nEND:VEVENT":                                                 "event at line 1: missing DTEND or DURATION",
+		"BEGIN:VEVENT\nDTSTART:20300101\nEND:VEVENT":                                                                   "event at line 1: missing SUMMARY",
+		"BEGIN:VEVENT\nDTSTART:20300101\nSUMMARY:A":                                                                    "event at line 1 has no END:VEVENT",
+	} {
+		_, err := parseIcsHolidays(strings.NewReader(content))
+		assert.EqualError(t, err, expected)
+	}
+}
+
+func TestUnitBuiltInHolidays(t *testing.T) {
+	assert.Equal(t, "2019-04-21", easterSunday(2019).Format(holidayKeyFormat))
+	assert.Equal(t, "2024-03-31", easterSunday(2024).Format(holidayKeyFormat))
+	assert.Equal(t, "2025-04-20", easterSunday(2025).Format(holidayKeyFormat))
+
+	holidays, err := loadHolidays("", "US", []int{2022})
+	assert.Nil(t, err)
+	assert.Equal(t, []string{
+		"2021-12-31 New Year's Day (observed)",
+		"2022-01-01 New Year's Day",
+		"2022-01-17 Martin Luther King Jr. Day",
+		"2022-02-21 Washington's Birthday",
+		"2022-05-30 Memorial Day",
+		"2022-06-19 Juneteenth National Independence Day",
+		"2022-06-20 Juneteenth National Independence Day (observed)",
+		"2022-07-04 Independence Day",
+		"2022-09-05 Labor Day",
+		"2022-10-10 Columbus Day",
+		"2022-11-11 Veterans Day",
+		"2022-11-24 Thanksgiving Day",
+		"2022-12-25 Christmas Day",
+		"2022-12-26 Christmas Day (observed)",
+	}, holidayNames(holidays))
+
+	holidays, err = loadHolidays("", "GB", []int{2022})
+	assert.Nil(t, err)
+	assert.Equal(t, []string{
+		"2022-01-01 New Year's Day",
+		"2022-01-03 New Year's Day (substitute day)",
+		"2022-04-15 Good Friday",
+		"2022-04-18 Easter Monday",
+		"2022-05-02 Early May bank holiday",
+		"2022-05-30 Spring bank holiday",
+		"2022-08-29 Summer bank holiday",
+		"2022-12-25 Christmas Day",
+		"2022-12-26 Boxing Day",
+		"2022-12-27 Christmas Day (substitute day)",
+	}, holidayNames(holidays))
+
+	holidays, err = loadHolidays("", "DE", []int{2024})
+	assert.Nil(t, err)
+	assert.Contains(t, holidayNames(holidays), "2024-05-09 Christi Himmelfahrt")
+	assert.Contains(t, holidayNames(holidays), "2024-05-20 Pfingstmontag")
+	assert.Equal(t, time.Date(2024, time.May, 10, 0, 0, 0, 0, time.UTC), holidays[4].end)
+
+	_, err = loadHolidays("", "US", nil)
+	assert.EqualError(t, err, "years must be set to generate the holidays of country US")
+}
+
+func TestUnitSyncHolidaySchedules(t *testing.T) {
+	var calls []string
+	group := &platformclientv2.Schedulegroup{
+		Id:               platformclientv2.String("group-id"),
+		HolidaySchedules: &[]platformclientv2.Domainentityref{{Id: platformclientv2.String("other-id")}, {Id: platformclientv2.String("id-1")}, {Id: platformclientv2.String("id-2")}, {Id: platformclientv2.String("id-3")}},
+	}
+
+	internalProxy = &architectSchedulesProxy{
+		createArchitectSchedulesAttr: func(ctx context.Context, p *architectSchedulesProxy, schedule *platformclientv2.Schedule) (*platformclientv2.Schedule, *platformclientv2.APIResponse, error) {
+			calls = append(calls, fmt.Sprintf("create %s %s", *schedule.Name, *schedule.Division.Id))
+			return &platformclientv2.Schedule{Id: platformclientv2.String("new-1")}, &platformclientv2.APIResponse{StatusCode: http.StatusOK}, nil
+		},
+		updateArchitectSchedulesAttr: func(ctx context.Context, p *architectSchedulesProxy, id string, schedule *platformclientv2.Schedule) (*platformclientv2.Schedule, *platformclientv2.APIResponse, error) {
+			calls = append(calls, fmt.Sprintf("update %s %s", id, *schedule.Name))
+			return schedule, &platformclientv2.APIResponse{StatusCode: http.StatusOK}, nil
+		},
+		deleteArchitectSchedulesAttr: func(ctx context.Context, p *architectSchedulesProxy, id string) (*platformclientv2.APIResponse, error) {
+			calls = append(calls, "delete "+id)
+			return &platformclientv2.APIResponse{StatusCode: http.StatusOK}, nil
+		},
+		getArchitectSchedulegroupByIdAttr: func(ctx context.Context, p *architectSchedulesProxy, id string) (*platformclientv2.Schedulegroup, *platformclientv2.APIResponse, error) {
+			return group, &platformclientv2.APIResponse{StatusCode: http.StatusOK}, nil
+		},
+		updateArchitectSchedulegroupAttr: func(ctx context.Context, p *architectSchedulesProxy, id string, scheduleGroup *platformclientv2.Schedulegroup) (*platformclientv2.Schedulegroup, *platformclientv2.APIResponse, error) {
+			var ids []string
+			for _, ref := range *scheduleGroup.HolidaySchedules {
+				ids = append(ids, *ref.Id)
+			}
+			calls = append(calls, fmt.Sprintf("update group %s %s", id, strings.Join(ids, ",")))
+			return scheduleGroup, &platformclientv2.APIResponse{StatusCode: http.StatusOK}, nil
+		},
+	}
+	defer func() { internalProxy = nil }()
+
+	newYear := newHolidaySchedule("US", holiday{key: "2030-01-01", name: "New Year's Day", start: time.Date(2030, time.January, 1, 0, 0, 0, 0, time.UTC), end: time.Date(2030, time.January, 2, 0, 0, 0, 0, time.UTC)})
+	memorialDay := newHolidaySchedule("US", holiday{key: "2030-05-27", name: "Memorial Day", start: time.Date(2030, time.May, 27, 0, 0, 0, 0, time.UTC), end: time.Date(2030, time.May, 28, 0, 0, 0, 0, time.UTC)})
+	christmas := newHolidaySchedule("US", holiday{key: "2030-12-25", name: "Christmas Day", start: time.Date(2030, time.December, 25, 0, 0, 0, 0, time.UTC), end: time.Date(2030, time.December, 26, 0, 0, 0, 0, time.UTC)})
+
+	currentNewYear, currentMemorialDay := newYear, memorialDay
+	currentNewYear.scheduleId = "id-1"
+	currentMemorialDay.scheduleId, currentMemorialDay.name = "id-2", "US 2030-05-27 Decoration Day"
+	independenceDay := holidaySchedule{key: "2030-07-04", name: "US 2030-07-04 Independence Day", scheduleId: "id-3"}
+
+	// The resource is read from its state, so that only the holidays differ
+	d := ResourceArchitectHolidaySchedules().Data(holidaySchedulesState(map[string]string{
+		"name_prefix":       "US",
+		"division_id":       "division-id",
+		"country_code":      "US",
+		"years.#":           "1",
+		"years.0":           "2030",
+		"schedule_group_id": "group-id",
+	}, []holidaySchedule{currentNewYear, currentMemorialDay, independenceDay}))
+
+	diags := syncHolidaySchedules(context.Background(), d, internalProxy, []holidaySchedule{newYear, memorialDay, christmas})
+	assert.False(t, diags.HasError(), diags)
+	assert.Equal(t, []string{
+		"update id-2 US 2030-05-27 Memorial Day",
+		"create US 2030-12-25 Christmas Day division-id",
+		"update group group-id other-id,id-1,id-2,new-1",
+		"delete id-3",
+	}, calls)
+
+	var ids []string
+	for _, s := range holidaySchedulesFromState(d) {
+		ids = append(ids, s.key+" "+s.scheduleId)
+	}
+	assert.Equal(t, []string{"2030-01-01 id-1", "2030-05-27 id-2", "2030-12-25 new-1"}, ids)
+
+	// The schedules created before a failure are kept in the state
+	calls = nil
+	internalProxy.createArchitectSchedulesAttr = func(ctx context.Context, p *architectSchedulesProxy, schedule *platformclientv2.Schedule) (*platformclientv2.Schedule, *platformclientv2.APIResponse, error) {
+		if strings.Contains(*schedule.Name, "Thanksgiving") {
+			return nil, &platformclientv2.APIResponse{StatusCode: http.StatusBadRequest}, fmt.Errorf("bad request")
+		}
+		return &platformclientv2.Schedule{Id: platformclientv2.String("new-2")}, &platformclientv2.APIResponse{StatusCode: http.StatusOK}, nil
+	}
+	laborDay := newHolidaySchedule("US", holiday{key: "2030-09-02", name: "Labor Day", start: time.Date(2030, time.September, 2, 0, 0, 0, 0, time.UTC), end: time.Date(2030, time.September, 3, 0, 0, 0, 0, time.UTC)})
+	thanksgiving := newHolidaySchedule("US", holiday{key: "2030-11-28", name: "Thanksgiving Day", start: time.Date(2030, time.November, 28, 0, 0, 0, 0, time.UTC), end: time.Date(2030, time.November, 29, 0, 0, 0, 0, time.UTC)})
+	d = ResourceArchitectHolidaySchedules().Data(d.State())
+	diags = syncHolidaySchedules(context.Background(), d, internalProxy, []holidaySchedule{newYear, memorialDay, laborDay, thanksgiving, christmas})
+	assert.True(t, diags.HasError())
+	assert.Equal(t, 4, len(holidaySchedulesFromState(d)))
+}
+
+func TestUnitUpdateHolidaySchedulesComputedHolidays(t *testing.T) {
+	source := map[string]interface{}{
+		"name_prefix":  "US",
+		"ics_file":     "",
+		"country_code": "US",
+		"years":        []interface{}{2030},
+	}
+	desired, err := desiredHolidaySchedules(func(key string) interface{} { return source[key] })
+	assert.Nil(t, err)
+
+	// Christmas Day is added to the holidays of the state
+	schedules := make(map[string]*platformclientv2.Schedule)
+	var current []holidaySchedule
+	for i, s := range desired {
+		if s.key != "2030-12-25" {
+			s.scheduleId = fmt.Sprintf("id-%d", i)
+			current = append(current, s)
+		}
+		start, _ := time.Parse(timeFormat, s.start)
+		end, _ := time.Parse(timeFormat, s.end)
+		schedules[s.key] = &platformclientv2.Schedule{Id: platformclientv2.String(s.scheduleId), Name: platformclientv2.String(s.name), Start: &start, End: &end, Rrule: platformclientv2.String(s.rrule)}
+	}
+
+	var calls []string
+	internalProxy = &architectSchedulesProxy{
+		createArchitectSchedulesAttr: func(ctx context.Context, p *architectSchedulesProxy, schedule *platformclientv2.Schedule) (*platformclientv2.Schedule, *platformclientv2.APIResponse, error) {
+			calls = append(calls, "create "+*schedule.Name)
+			schedules["2030-12-25"].Id = platformclientv2.String("new-1")
+			return schedules["2030-12-25"], &platformclientv2.APIResponse{StatusCode: http.StatusOK}, nil
+		},
+		getArchitectSchedulesByIdAttr: func(ctx context.Context, p *architectSchedulesProxy, id string) (*platformclientv2.Schedule, *platformclientv2.APIResponse, error) {
+			for _, schedule := range schedules {
+				if *schedule.Id == id {
+					return schedule, &platformclientv2.APIResponse{StatusCode: http.StatusOK}, nil
+				}
+			}
+			return nil, &platformclientv2.APIResponse{StatusCode: http.StatusNotFound}, fmt.Errorf("not found")
+		},
+		updateArchitectSchedulesAttr: func(ctx context.Context, p *architectSchedulesProxy, id string, schedule *platformclientv2.Schedule) (*platformclientv2.Schedule, *platformclientv2.APIResponse, error) {
+			calls = append(calls, "update "+id)
+			return schedule, &platformclientv2.APIResponse{StatusCode: http.StatusOK}, nil
+		},
+		deleteArchitectSchedulesAttr: func(ctx context.Context, p *architectSchedulesProxy, id string) (*platformclientv2.APIResponse, error) {
+			calls = append(calls, "delete "+id)
+			return &platformclientv2.APIResponse{StatusCode: http.StatusOK}, nil
+		},
+	}
+	defer func() { internalProxy = nil }()
+
+	state := holidaySchedulesState(map[string]string{
+		"name_prefix":   "US",
+		"division_id":   "division-id",
+		"country_code":  "US",
+		"years.#":       "1",
+		"years.0":       "2030",
+		"holidays_hash": hashHolidaySchedules(current),
+	}, current)
+	resourceSchema := ResourceArchitectHolidaySchedules().Schema
+	config := map[string]interface{}{
+		"name_prefix":  "US",
+		"division_id":  "division-id",
+		"country_code": "US",
+		"years":        []interface{}{2030},
+	}
+	diff, err := schema.InternalMap(resourceSchema).Diff(context.Background(), state, terraform.NewResourceConfigRaw(config), customizeHolidaySchedulesDiff, nil, true)
+	assert.Nil(t, err)
+	assert.True(t, diff.Attributes["holidays.#"].NewComputed)
+	assert.Equal(t, hashHolidaySchedules(desired), diff.Attributes["holidays_hash"].New)
+
+	d, err := schema.InternalMap(resourceSchema).Data(state, diff)
+	assert.Nil(t, err)
+	gcloud := &provider.ProviderMeta{ClientConfig: &platformclientv2.Configuration{}}
+	diags := updateArchitectHolidaySchedules(context.Background(), d, gcloud)
+	assert.False(t, diags.HasError(), diags)
+
+	// Only the new holiday is created, the other schedules keep their IDs
+	assert.Equal(t, []string{"create US 2030-12-25 Christmas Day"}, calls)
+	holidays := holidaySchedulesFromState(d)
+	assert.Equal(t, len(desired), len(holidays))
+	for _, s := range holidays {
+		assert.Equal(t, *schedules[s.key].Id, s.scheduleId, s.key)
+	}
+	assert.Equal(t, hashHolidaySchedules(desired), d.Get("holidays_hash"))
+}
+
+// holidaySchedulesState returns the state of a holiday schedules resource with the attributes and the schedules
+func holidaySchedulesState(attributes map[string]string, schedules []holidaySchedule) *terraform.InstanceState {
+	d := ResourceArchitectHolidaySchedules().Data(&terraform.InstanceState{ID: "holiday-schedules-id", Attributes: attributes})
+	setHolidaySchedules(d, schedules)
+	return d.State()
+}
+
+func holidayKeys(holidays []holiday) []string {
+	var keys []string
+	for _, h := range holidays {
+		keys = append(keys, h.key)
+	}
+	return keys
+}
+
+func holidayNames(holidays []holiday) []string {
+	var names []string
+	for _, h := range holidays {
+		names = append(names, h.key+" "+h.name)
+	}
+	return names
+}
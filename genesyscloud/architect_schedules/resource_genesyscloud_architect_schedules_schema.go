@@ -1,21 +1,28 @@
 package architect_schedules
 
 import (
+	"sort"
+	"strings"
 	"terraform-provider-genesyscloud/genesyscloud/provider"
 	resourceExporter "terraform-provider-genesyscloud/genesyscloud/resource_exporter"
 	registrar "terraform-provider-genesyscloud/genesyscloud/resource_register"
 	"terraform-provider-genesyscloud/genesyscloud/validators"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
-const ResourceType = "genesyscloud_architect_schedules"
+const (
+	ResourceType                 = "genesyscloud_architect_schedules"
+	HolidaySchedulesResourceType = "genesyscloud_architect_holiday_schedules"
+)
 
 // SetRegistrar registers all of the resources, datasources and exporters in the pakage
 func SetRegistrar(regInstance registrar.Registrar) {
 	regInstance.RegisterResource(ResourceType, ResourceArchitectSchedules())
 	regInstance.RegisterDataSource(ResourceType, DataSourceArchitectSchedules())
 	regInstance.RegisterExporter(ResourceType, ArchitectSchedulesExporter())
+	regInstance.RegisterResource(HolidaySchedulesResourceType, ResourceArchitectHolidaySchedules())
 }
 
 // ResourceArchitectSchedules registers the genesyscloud_architect_schedules resource with Terraform
@@ -100,3 +107,116 @@ func DataSourceArchitectSchedules() *schema.Resource {
 		},
 	}
 }
+
+// ResourceArchitectHolidaySchedules registers the genesyscloud_architect_holiday_schedules resource with Terraform
+func ResourceArchitectHolidaySchedules() *schema.Resource {
+	var countryCodes []string
+	for countryCode := range builtInHolidayCountries {
+		countryCodes = append(countryCodes, countryCode)
+	}
+	sort.Strings(countryCodes)
+
+	return &schema.Resource{
+		Description: "Genesys Cloud Architect holiday schedules. Creates one all day schedule per holiday of an iCalendar (.ics) file or of a built-in country holiday rule set, " +
+			"and keeps the schedules in sync with the source by the date of each holiday. The schedules can be attached to the holiday schedules of a schedule group.",
+
+		CreateContext: provider.CreateWithPooledClient(createArchitectHolidaySchedules),
+		ReadContext:   provider.ReadWithPooledClient(readArchitectHolidaySchedules),
+		UpdateContext: provider.UpdateWithPooledClient(updateArchitectHolidaySchedules),
+		DeleteContext: provider.DeleteWithPooledClient(deleteArchitectHolidaySchedules),
+		CustomizeDiff: customizeHolidaySchedulesDiff,
+		Schema: map[string]*schema.Schema{
+			"name_prefix": {
+				Description: "Prefix of the names of the schedules. Each schedule is named `<name_prefix> <yyyy-MM-dd> <holiday name>` after the date of the first day of its holiday.",
+				Type:        schema.TypeString,
+				Required:    true,
+			},
+			"division_id": {
+				Description: "The division to which the schedules will belong. If not set, the home division will be used. If set, you must have all divisions and future divisions selected in your OAuth client role",
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+			},
+			"description": {
+				Description: "Description of the schedules.",
+				Type:        schema.TypeString,
+				Optional:    true,
+			},
+			"ics_file": {
+				Description: "Path or URL of an iCalendar (.ics) file. Each VEVENT is a holiday named after its SUMMARY, from DTSTART to DTEND. " +
+					"DATE values are all day holidays; DATE-TIME values are taken as written, in the time zone of the schedule group, and must not be in UTC. " +
+					"The RRULE of an event becomes the rrule of its schedule. Cancelled events are skipped, and no two events may start on the same date.",
+				Type:         schema.TypeString,
+				Optional:     true,
+				ExactlyOneOf: []string{"ics_file", "country_code"},
+				ValidateFunc: validators.ValidatePath,
+			},
+			"country_code": {
+				Description: "Country of the built-in holiday rule set (" + strings.Join(countryCodes, " | ") + "). Holidays are generated for each of the years. " +
+					"Fixed date holidays on a weekend get an extra observed day on a weekday in US, and a substitute day on the next free weekday in CA, GB and IE.",
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.StringInSlice(countryCodes, false),
+			},
+			"years": {
+				Description: "Years to create holidays for. Required with country_code. With ics_file, only the events starting in these years are imported, and recurring events are always imported.",
+				Type:        schema.TypeList,
+				Optional:    true,
+				Elem: &schema.Schema{
+					Type:         schema.TypeInt,
+					ValidateFunc: validation.IntBetween(1900, 2200),
+				},
+			},
+			"schedule_group_id": {
+				Description: "ID of a schedule group to add the schedules to as holiday schedules. Other holiday schedules of the group are kept. " +
+					"Add `holiday_schedules_id` to the `ignore_changes` of a genesyscloud_architect_schedulegroups resource managing the same group.",
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"holidays": {
+				Description: "The schedules of the holidays, sorted by date key.",
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"key": {
+							Description: "Date of the first day of the holiday (yyyy-MM-dd).",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"name": {
+							Description: "Name of the schedule.",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"schedule_id": {
+							Description: "ID of the schedule.",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"start": {
+							Description: "Start of the schedule.",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"end": {
+							Description: "End of the schedule.",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"rrule": {
+							Description: "Recurrence rule of the schedule.",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+					},
+				},
+			},
+			"holidays_hash": {
+				Description: "Hash of the names, dates and recurrence rules of the schedules.",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+		},
+	}
+}
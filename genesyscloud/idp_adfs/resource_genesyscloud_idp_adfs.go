@@ -7,6 +7,7 @@ import (
 	"terraform-provider-genesyscloud/genesyscloud/provider"
 	resourceExporter "terraform-provider-genesyscloud/genesyscloud/resource_exporter"
 	"terraform-provider-genesyscloud/genesyscloud/util"
+	"terraform-provider-genesyscloud/genesyscloud/util/saml"
 	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
@@ -63,7 +64,7 @@ func readIdpAdfs(ctx context.Context, d *schema.ResourceData, meta interface{})
 
 	cc := consistency_checker.NewConsistencyCheck(ctx, d, meta, ResourceIdpAdfs(), constants.ConsistencyChecks(), ResourceType)
 
-	return util.WithRetriesForReadCustomTimeout(ctx, d.Timeout(schema.TimeoutRead), d, func() *retry.RetryError {
+	diagErr := util.WithRetriesForReadCustomTimeout(ctx, d.Timeout(schema.TimeoutRead), d, func() *retry.RetryError {
 		aDFS, resp, getErr := proxy.getIdpAdfs(ctx)
 		if getErr != nil {
 			if util.IsStatus404(resp) {
@@ -92,6 +93,10 @@ func readIdpAdfs(ctx context.Context, d *schema.ResourceData, meta interface{})
 		log.Printf("Read idp adfs")
 		return cc.CheckState(d)
 	})
+	if diagErr != nil {
+		return diagErr
+	}
+	return saml.CertificateExpiryWarnings(d)
 }
 
 // updateIdpAdfs is used by the idp_adfs resource to update an idp adfs in Genesys Cloud
@@ -114,7 +119,8 @@ func updateIdpAdfs(ctx context.Context, d *schema.ResourceData, meta interface{}
 	}
 
 	log.Printf("Updated idp adfs")
-	return readIdpAdfs(ctx, d, meta)
+	rotationWarnings := saml.MetadataRotationWarnings(d)
+	return append(rotationWarnings, readIdpAdfs(ctx, d, meta)...)
 }
 
 // deleteIdpAdfs is used by the idp_adfs resource to delete an idp adfs from Genesys cloud
@@ -8,6 +8,7 @@ import (
 	"terraform-provider-genesyscloud/genesyscloud/provider"
 	resourceExporter "terraform-provider-genesyscloud/genesyscloud/resource_exporter"
 	registrar "terraform-provider-genesyscloud/genesyscloud/resource_register"
+	"terraform-provider-genesyscloud/genesyscloud/util/saml"
 )
 
 /*
@@ -56,23 +57,28 @@ func ResourceIdpAdfs() *schema.Resource {
 				Default:     false,
 			},
 			`issuer_uri`: {
-				Description: `Issuer URI provided by ADFS.`,
-				Required:    true,
-				Type:        schema.TypeString,
+				Description:  `Issuer URI provided by ADFS.`,
+				Optional:     true,
+				Computed:     true,
+				Type:         schema.TypeString,
+				AtLeastOneOf: saml.MetadataAlternatives(`issuer_uri`),
 			},
 			`target_uri`: {
 				Description: `Target URI provided by ADFS.`,
 				Optional:    true,
 				Type:        schema.TypeString,
+				Computed:    true,
 			},
 			`slo_uri`: {
 				Description: `Provided by ADFS on app creation`,
 				Optional:    true,
 				Type:        schema.TypeString,
+				Computed:    true,
 			},
 			`slo_binding`: {
 				Optional: true,
 				Type:     schema.TypeString,
+				Computed: true,
 			},
 			`relying_party_identifier`: {
 				Description: `String used to identify Genesys Cloud to ADFS.`,
@@ -81,12 +87,18 @@ func ResourceIdpAdfs() *schema.Resource {
 				Type:        schema.TypeString,
 			},
 			`certificates`: {
-				Description: `PEM or DER encoded public X.509 certificates for SAML signature validation.`,
-				Required:    true,
-				Type:        schema.TypeList,
-				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description:  `PEM or DER encoded public X.509 certificates for SAML signature validation.`,
+				Optional:     true,
+				Computed:     true,
+				Type:         schema.TypeList,
+				Elem:         &schema.Schema{Type: schema.TypeString},
+				AtLeastOneOf: saml.MetadataAlternatives(`certificates`),
 			},
+			`metadata_xml`:  saml.MetadataXmlSchema(),
+			`metadata_file`: saml.MetadataFileSchema(),
+			`metadata_hash`: saml.MetadataHashSchema(),
 		},
+		CustomizeDiff: saml.CustomizeMetadataDiff,
 	}
 }
 
@@ -97,5 +109,6 @@ func IdpAdfsExporter() *resourceExporter.ResourceExporter {
 		RefAttrs:         map[string]*resourceExporter.RefAttrSettings{
 			// TODO: Add any reference attributes here
 		},
+		ExcludedAttributes: []string{"metadata_hash"},
 	}
 }
@@ -7,6 +7,7 @@ import (
 	"terraform-provider-genesyscloud/genesyscloud/provider"
 	resourceExporter "terraform-provider-genesyscloud/genesyscloud/resource_exporter"
 	"terraform-provider-genesyscloud/genesyscloud/util"
+	"terraform-provider-genesyscloud/genesyscloud/util/saml"
 	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
@@ -64,7 +65,7 @@ func readIdpGeneric(ctx context.Context, d *schema.ResourceData, meta interface{
 
 	cc := consistency_checker.NewConsistencyCheck(ctx, d, meta, ResourceIdpGeneric(), constants.ConsistencyChecks(), ResourceType)
 
-	return util.WithRetriesForReadCustomTimeout(ctx, d.Timeout(schema.TimeoutRead), d, func() *retry.RetryError {
+	diagErr := util.WithRetriesForReadCustomTimeout(ctx, d.Timeout(schema.TimeoutRead), d, func() *retry.RetryError {
 		genericSAML, resp, getErr := proxy.getIdpGeneric(ctx)
 		if getErr != nil {
 			if util.IsStatus404(resp) {
@@ -96,6 +97,10 @@ func readIdpGeneric(ctx context.Context, d *schema.ResourceData, meta interface{
 		log.Printf("Read idp generic %s %s", d.Id(), *genericSAML.Name)
 		return cc.CheckState(d)
 	})
+	if diagErr != nil {
+		return diagErr
+	}
+	return saml.CertificateExpiryWarnings(d)
 }
 
 // updateIdpGeneric is used by the idp_generic resource to update an idp generic in Genesys Cloud
@@ -121,7 +126,8 @@ func updateIdpGeneric(ctx context.Context, d *schema.ResourceData, meta interfac
 	}
 
 	log.Printf("Updated idp generic")
-	return readIdpGeneric(ctx, d, meta)
+	rotationWarnings := saml.MetadataRotationWarnings(d)
+	return append(rotationWarnings, readIdpGeneric(ctx, d, meta)...)
 }
 
 // deleteIdpGeneric is used by the idp_generic resource to delete an idp generic from Genesys cloud
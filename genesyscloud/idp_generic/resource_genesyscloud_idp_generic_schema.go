@@ -9,6 +9,7 @@ import (
 	"terraform-provider-genesyscloud/genesyscloud/provider"
 	resourceExporter "terraform-provider-genesyscloud/genesyscloud/resource_exporter"
 	registrar "terraform-provider-genesyscloud/genesyscloud/resource_register"
+	"terraform-provider-genesyscloud/genesyscloud/util/saml"
 )
 
 /*
@@ -57,25 +58,30 @@ func ResourceIdpGeneric() *schema.Resource {
 				Type:        schema.TypeBool,
 			},
 			`issuer_uri`: {
-				Description: `Issuer URI provided by the provider.`,
-				Required:    true,
-				Type:        schema.TypeString,
+				Description:  `Issuer URI provided by the provider.`,
+				Optional:     true,
+				Computed:     true,
+				Type:         schema.TypeString,
+				AtLeastOneOf: saml.MetadataAlternatives(`issuer_uri`),
 			},
 			`target_uri`: {
 				Description: `Target URI provided by the provider.`,
 				Optional:    true,
 				Type:        schema.TypeString,
+				Computed:    true,
 			},
 			`slo_uri`: {
 				Description: `Provided on app creation.`,
 				Optional:    true,
 				Type:        schema.TypeString,
+				Computed:    true,
 			},
 			`slo_binding`: {
 				Description:  `Valid values: HTTP Redirect, HTTP Post`,
 				Optional:     true,
 				Type:         schema.TypeString,
 				ValidateFunc: validation.StringInSlice([]string{`HTTP Redirect`, `HTTP Post`}, false),
+				Computed:     true,
 			},
 			`relying_party_identifier`: {
 				Description: `String used to identify Genesys Cloud to the identity provider.`,
@@ -84,10 +90,12 @@ func ResourceIdpGeneric() *schema.Resource {
 				Type:        schema.TypeString,
 			},
 			`certificates`: {
-				Description: `PEM or DER encoded public X.509 certificates for SAML signature validation.`,
-				Required:    true,
-				Type:        schema.TypeList,
-				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description:  `PEM or DER encoded public X.509 certificates for SAML signature validation.`,
+				Optional:     true,
+				Computed:     true,
+				Type:         schema.TypeList,
+				Elem:         &schema.Schema{Type: schema.TypeString},
+				AtLeastOneOf: saml.MetadataAlternatives(`certificates`),
 			},
 			`logo_image_data`: {
 				Description: `Base64 encoded SVG image.`,
@@ -116,7 +124,11 @@ func ResourceIdpGeneric() *schema.Resource {
 					`urn:oasis:names:tc:SAML:2.0:nameid-format:transient`,
 				}, false),
 			},
+			`metadata_xml`:  saml.MetadataXmlSchema(),
+			`metadata_file`: saml.MetadataFileSchema(),
+			`metadata_hash`: saml.MetadataHashSchema(),
 		},
+		CustomizeDiff: saml.CustomizeMetadataDiff,
 	}
 }
 
@@ -127,5 +139,6 @@ func IdpGenericExporter() *resourceExporter.ResourceExporter {
 		RefAttrs:         map[string]*resourceExporter.RefAttrSettings{
 			// TODO: Add any reference attributes here
 		},
+		ExcludedAttributes: []string{"metadata_hash"},
 	}
 }
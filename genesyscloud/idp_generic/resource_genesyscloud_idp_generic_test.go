@@ -182,6 +182,46 @@ func TestAccResourceIdpGeneric(t *testing.T) {
 	})
 }
 
+func TestAccResourceIdpGenericMetadata(t *testing.T) {
+	var (
+		name      = "generic-metadata"
+		issuerURI = "https://idp.example.com/entity"
+		ssoURI    = "https://idp.example.com/sso"
+		sloURI    = "https://idp.example.com/slo"
+	)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { util.TestAccPreCheck(t) },
+		ProviderFactories: provider.GetProviderFactories(providerResources, nil),
+		Steps: []resource.TestStep{
+			{
+				// Create from metadata
+				Config: generateIdpGenericMetadataResource(name, generateSamlMetadata(issuerURI, ssoURI, sloURI, util.TestCert1)),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("genesyscloud_idp_generic.generic", "name", name),
+					util.ValidateStringInArray("genesyscloud_idp_generic.generic", "certificates", util.TestCert1),
+					resource.TestCheckResourceAttr("genesyscloud_idp_generic.generic", "certificates.#", "1"),
+					resource.TestCheckResourceAttr("genesyscloud_idp_generic.generic", "issuer_uri", issuerURI),
+					resource.TestCheckResourceAttr("genesyscloud_idp_generic.generic", "target_uri", ssoURI),
+					resource.TestCheckResourceAttr("genesyscloud_idp_generic.generic", "slo_uri", sloURI),
+					resource.TestCheckResourceAttr("genesyscloud_idp_generic.generic", "slo_binding", "HTTP Redirect"),
+					resource.TestCheckResourceAttrSet("genesyscloud_idp_generic.generic", "metadata_hash"),
+				),
+			},
+			{
+				// Rotate the certificate of the metadata
+				Config: generateIdpGenericMetadataResource(name, generateSamlMetadata(issuerURI, ssoURI, sloURI, util.TestCert2)),
+				Check: resource.ComposeTestCheckFunc(
+					util.ValidateStringInArray("genesyscloud_idp_generic.generic", "certificates", util.TestCert2),
+					resource.TestCheckResourceAttr("genesyscloud_idp_generic.generic", "certificates.#", "1"),
+					resource.TestCheckResourceAttr("genesyscloud_idp_generic.generic", "issuer_uri", issuerURI),
+				),
+			},
+		},
+		CheckDestroy: testVerifyIdpGenericDestroyed,
+	})
+}
+
 func generateIdpGenericResource(
 	name string,
 	certs string,
@@ -210,6 +250,26 @@ func generateIdpGenericResource(
 	`, name, certs, issuerURI, targetURI, partyID, disabled, logoImageData, endpointCompression, nameIDFormat, sloURI, sloBinding)
 }
 
+func generateIdpGenericMetadataResource(name string, metadataXml string) string {
+	return fmt.Sprintf(`resource "genesyscloud_idp_generic" "generic" {
+		name = "%s"
+		metadata_xml = %s
+	}
+	`, name, strconv.Quote(metadataXml))
+}
+
+func generateSamlMetadata(issuerURI string, ssoURI string, sloURI string, cert string) string {
+	return fmt.Sprintf(`<md:EntityDescriptor xmlns:md="urn:oasis:names:tc:SAML:2.0:metadata" xmlns:ds="http://www.w3.org/2000/09/xmldsig#" entityID="%s">
+  <md:IDPSSODescriptor protocolSupportEnumeration="urn:oasis:names:tc:SAML:2.0:protocol">
+    <md:KeyDescriptor use="signing">
+      <ds:KeyInfo><ds:X509Data><ds:X509Certificate>%s</ds:X509Certificate></ds:X509Data></ds:KeyInfo>
+    </md:KeyDescriptor>
+    <md:SingleLogoutService Binding="urn:oasis:names:tc:SAML:2.0:bindings:HTTP-Redirect" Location="%s"/>
+    <md:SingleSignOnService Binding="urn:oasis:names:tc:SAML:2.0:bindings:HTTP-Redirect" Location="%s"/>
+  </md:IDPSSODescriptor>
+</md:EntityDescriptor>`, issuerURI, cert, sloURI, ssoURI)
+}
+
 func testVerifyIdpGenericDestroyed(state *terraform.State) error {
 	idpAPI := platformclientv2.NewIdentityProviderApi()
 	for _, rs := range state.RootModule().Resources {
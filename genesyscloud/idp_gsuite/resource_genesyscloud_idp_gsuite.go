@@ -7,6 +7,7 @@ import (
 	"terraform-provider-genesyscloud/genesyscloud/provider"
 	resourceExporter "terraform-provider-genesyscloud/genesyscloud/resource_exporter"
 	"terraform-provider-genesyscloud/genesyscloud/util"
+	"terraform-provider-genesyscloud/genesyscloud/util/saml"
 	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
@@ -64,7 +65,7 @@ func readIdpGsuite(ctx context.Context, d *schema.ResourceData, meta interface{}
 
 	cc := consistency_checker.NewConsistencyCheck(ctx, d, meta, ResourceIdpGsuite(), constants.ConsistencyChecks(), ResourceType)
 
-	return util.WithRetriesForReadCustomTimeout(ctx, d.Timeout(schema.TimeoutRead), d, func() *retry.RetryError {
+	diagErr := util.WithRetriesForReadCustomTimeout(ctx, d.Timeout(schema.TimeoutRead), d, func() *retry.RetryError {
 		gSuite, resp, getErr := proxy.getIdpGsuite(ctx)
 		if getErr != nil {
 			if util.IsStatus404(resp) {
@@ -93,6 +94,10 @@ func readIdpGsuite(ctx context.Context, d *schema.ResourceData, meta interface{}
 		log.Printf("Read idp gsuite")
 		return cc.CheckState(d)
 	})
+	if diagErr != nil {
+		return diagErr
+	}
+	return saml.CertificateExpiryWarnings(d)
 }
 
 // updateIdpGsuite is used by the idp_gsuite resource to update an idp gsuite in Genesys Cloud
@@ -118,7 +123,8 @@ func updateIdpGsuite(ctx context.Context, d *schema.ResourceData, meta interface
 	}
 
 	log.Printf("Updated idp gsuite")
-	return readIdpGsuite(ctx, d, meta)
+	rotationWarnings := saml.MetadataRotationWarnings(d)
+	return append(rotationWarnings, readIdpGsuite(ctx, d, meta)...)
 }
 
 // deleteIdpGsuite is used by the idp_gsuite resource to delete an idp gsuite from Genesys cloud
@@ -9,6 +9,7 @@ import (
 	"terraform-provider-genesyscloud/genesyscloud/provider"
 	resourceExporter "terraform-provider-genesyscloud/genesyscloud/resource_exporter"
 	registrar "terraform-provider-genesyscloud/genesyscloud/resource_register"
+	"terraform-provider-genesyscloud/genesyscloud/util/saml"
 )
 
 /*
@@ -57,25 +58,30 @@ func ResourceIdpGsuite() *schema.Resource {
 				Default:     false,
 			},
 			`issuer_uri`: {
-				Description: `Issuer URI provided by GSuite.`,
-				Required:    true,
-				Type:        schema.TypeString,
+				Description:  `Issuer URI provided by GSuite.`,
+				Optional:     true,
+				Computed:     true,
+				Type:         schema.TypeString,
+				AtLeastOneOf: saml.MetadataAlternatives(`issuer_uri`),
 			},
 			`target_uri`: {
 				Description: `Target URI provided by GSuite.`,
 				Optional:    true,
 				Type:        schema.TypeString,
+				Computed:    true,
 			},
 			`slo_uri`: {
 				Description: `Provided on app creation.`,
 				Optional:    true,
 				Type:        schema.TypeString,
+				Computed:    true,
 			},
 			`slo_binding`: {
 				Description:  `Valid values: HTTP Redirect, HTTP Post`,
 				Optional:     true,
 				Type:         schema.TypeString,
 				ValidateFunc: validation.StringInSlice([]string{`HTTP Redirect`, `HTTP Post`}, false),
+				Computed:     true,
 			},
 			`relying_party_identifier`: {
 				Description: `String used to identify Genesys Cloud to GSuite.`,
@@ -84,12 +90,18 @@ func ResourceIdpGsuite() *schema.Resource {
 				Type:        schema.TypeString,
 			},
 			`certificates`: {
-				Description: `PEM or DER encoded public X.509 certificates for SAML signature validation.`,
-				Required:    true,
-				Type:        schema.TypeList,
-				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description:  `PEM or DER encoded public X.509 certificates for SAML signature validation.`,
+				Optional:     true,
+				Computed:     true,
+				Type:         schema.TypeList,
+				Elem:         &schema.Schema{Type: schema.TypeString},
+				AtLeastOneOf: saml.MetadataAlternatives(`certificates`),
 			},
+			`metadata_xml`:  saml.MetadataXmlSchema(),
+			`metadata_file`: saml.MetadataFileSchema(),
+			`metadata_hash`: saml.MetadataHashSchema(),
 		},
+		CustomizeDiff: saml.CustomizeMetadataDiff,
 	}
 }
 
@@ -100,5 +112,6 @@ func IdpGsuiteExporter() *resourceExporter.ResourceExporter {
 		RefAttrs:         map[string]*resourceExporter.RefAttrSettings{
 			// TODO: Add any reference attributes here
 		},
+		ExcludedAttributes: []string{"metadata_hash"},
 	}
 }
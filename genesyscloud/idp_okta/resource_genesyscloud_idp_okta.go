@@ -7,6 +7,7 @@ import (
 	"terraform-provider-genesyscloud/genesyscloud/provider"
 	resourceExporter "terraform-provider-genesyscloud/genesyscloud/resource_exporter"
 	"terraform-provider-genesyscloud/genesyscloud/util"
+	"terraform-provider-genesyscloud/genesyscloud/util/saml"
 	"time"
 
 	"terraform-provider-genesyscloud/genesyscloud/consistency_checker"
@@ -62,7 +63,7 @@ func readIdpOkta(ctx context.Context, d *schema.ResourceData, meta interface{})
 
 	log.Printf("Reading idp okta %s", d.Id())
 
-	return util.WithRetriesForReadCustomTimeout(ctx, d.Timeout(schema.TimeoutRead), d, func() *retry.RetryError {
+	diagErr := util.WithRetriesForReadCustomTimeout(ctx, d.Timeout(schema.TimeoutRead), d, func() *retry.RetryError {
 		okta, resp, getErr := proxy.getIdpOkta(ctx)
 		if getErr != nil {
 			if util.IsStatus404(resp) {
@@ -93,6 +94,10 @@ func readIdpOkta(ctx context.Context, d *schema.ResourceData, meta interface{})
 		log.Printf("Read idp okta")
 		return cc.CheckState(d)
 	})
+	if diagErr != nil {
+		return diagErr
+	}
+	return saml.CertificateExpiryWarnings(d)
 }
 
 // updateIdpOkta is used by the idp_okta resource to update an idp okta in Genesys Cloud
@@ -117,7 +122,8 @@ func updateIdpOkta(ctx context.Context, d *schema.ResourceData, meta interface{}
 	}
 
 	log.Printf("Updated idp okta")
-	return readIdpOkta(ctx, d, meta)
+	rotationWarnings := saml.MetadataRotationWarnings(d)
+	return append(rotationWarnings, readIdpOkta(ctx, d, meta)...)
 }
 
 // deleteIdpOkta is used by the idp_okta resource to delete an idp okta from Genesys cloud
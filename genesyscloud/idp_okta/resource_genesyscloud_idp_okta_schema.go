@@ -9,6 +9,7 @@ import (
 	"terraform-provider-genesyscloud/genesyscloud/provider"
 	resourceExporter "terraform-provider-genesyscloud/genesyscloud/resource_exporter"
 	registrar "terraform-provider-genesyscloud/genesyscloud/resource_register"
+	"terraform-provider-genesyscloud/genesyscloud/util/saml"
 )
 
 /*
@@ -56,25 +57,30 @@ func ResourceIdpOkta() *schema.Resource {
 				Type:        schema.TypeBool,
 			},
 			`issuer_uri`: {
-				Description: `Issuer URI provided by Okta.`,
-				Required:    true,
-				Type:        schema.TypeString,
+				Description:  `Issuer URI provided by Okta.`,
+				Optional:     true,
+				Computed:     true,
+				Type:         schema.TypeString,
+				AtLeastOneOf: saml.MetadataAlternatives(`issuer_uri`),
 			},
 			`target_uri`: {
 				Description: `Target URI provided by Okta.`,
 				Optional:    true,
 				Type:        schema.TypeString,
+				Computed:    true,
 			},
 			`slo_uri`: {
 				Description: `Provided by Okta on app creation.`,
 				Optional:    true,
 				Type:        schema.TypeString,
+				Computed:    true,
 			},
 			`slo_binding`: {
 				Description:  `Valid values: HTTP Redirect, HTTP Post`,
 				Optional:     true,
 				Type:         schema.TypeString,
 				ValidateFunc: validation.StringInSlice([]string{`HTTP Redirect`, `HTTP Post`}, false),
+				Computed:     true,
 			},
 			`relying_party_identifier`: {
 				Description: `String used to identify Genesys Cloud to Okta.`,
@@ -83,13 +89,19 @@ func ResourceIdpOkta() *schema.Resource {
 				Computed:    true,
 			},
 			`certificates`: {
-				Description: `PEM or DER encoded public X.509 certificates for SAML signature validation.`,
-				Required:    true,
-				Type:        schema.TypeList,
-				Elem:        &schema.Schema{Type: schema.TypeString},
-				MinItems:    1,
+				Description:  `PEM or DER encoded public X.509 certificates for SAML signature validation.`,
+				Optional:     true,
+				Computed:     true,
+				Type:         schema.TypeList,
+				Elem:         &schema.Schema{Type: schema.TypeString},
+				MinItems:     1,
+				AtLeastOneOf: saml.MetadataAlternatives(`certificates`),
 			},
+			`metadata_xml`:  saml.MetadataXmlSchema(),
+			`metadata_file`: saml.MetadataFileSchema(),
+			`metadata_hash`: saml.MetadataHashSchema(),
 		},
+		CustomizeDiff: saml.CustomizeMetadataDiff,
 	}
 }
 
@@ -100,5 +112,6 @@ func IdpOktaExporter() *resourceExporter.ResourceExporter {
 		RefAttrs:         map[string]*resourceExporter.RefAttrSettings{
 			// TODO: Add any reference attributes here
 		},
+		ExcludedAttributes: []string{"metadata_hash"},
 	}
 }
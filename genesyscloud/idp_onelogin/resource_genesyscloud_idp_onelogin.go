@@ -7,6 +7,7 @@ import (
 	"terraform-provider-genesyscloud/genesyscloud/provider"
 	resourceExporter "terraform-provider-genesyscloud/genesyscloud/resource_exporter"
 	"terraform-provider-genesyscloud/genesyscloud/util"
+	"terraform-provider-genesyscloud/genesyscloud/util/saml"
 	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
@@ -62,7 +63,7 @@ func readIdpOnelogin(ctx context.Context, d *schema.ResourceData, meta interface
 
 	log.Printf("Reading idp onelogin")
 
-	return util.WithRetriesForReadCustomTimeout(ctx, d.Timeout(schema.TimeoutRead), d, func() *retry.RetryError {
+	diagErr := util.WithRetriesForReadCustomTimeout(ctx, d.Timeout(schema.TimeoutRead), d, func() *retry.RetryError {
 		oneLogin, resp, getErr := proxy.getIdpOnelogin(ctx)
 		if getErr != nil {
 			if util.IsStatus404(resp) {
@@ -93,6 +94,10 @@ func readIdpOnelogin(ctx context.Context, d *schema.ResourceData, meta interface
 		log.Printf("Read idp onelogin")
 		return cc.CheckState(d)
 	})
+	if diagErr != nil {
+		return diagErr
+	}
+	return saml.CertificateExpiryWarnings(d)
 }
 
 // updateIdpOnelogin is used by the idp_onelogin resource to update an idp onelogin in Genesys Cloud
@@ -117,7 +122,8 @@ func updateIdpOnelogin(ctx context.Context, d *schema.ResourceData, meta interfa
 	}
 
 	log.Printf("Updated idp onelogin")
-	return readIdpOnelogin(ctx, d, meta)
+	rotationWarnings := saml.MetadataRotationWarnings(d)
+	return append(rotationWarnings, readIdpOnelogin(ctx, d, meta)...)
 }
 
 // deleteIdpOnelogin is used by the idp_onelogin resource to delete an idp onelogin from Genesys cloud
@@ -9,6 +9,7 @@ import (
 	"terraform-provider-genesyscloud/genesyscloud/provider"
 	resourceExporter "terraform-provider-genesyscloud/genesyscloud/resource_exporter"
 	registrar "terraform-provider-genesyscloud/genesyscloud/resource_register"
+	"terraform-provider-genesyscloud/genesyscloud/util/saml"
 )
 
 /*
@@ -57,25 +58,30 @@ func ResourceIdpOnelogin() *schema.Resource {
 				Default:     false,
 			},
 			`issuer_uri`: {
-				Description: `Issuer URI provided by OneLogin.`,
-				Required:    true,
-				Type:        schema.TypeString,
+				Description:  `Issuer URI provided by OneLogin.`,
+				Optional:     true,
+				Computed:     true,
+				Type:         schema.TypeString,
+				AtLeastOneOf: saml.MetadataAlternatives(`issuer_uri`),
 			},
 			`target_uri`: {
 				Description: `Target URI provided by OneLogin.`,
 				Optional:    true,
 				Type:        schema.TypeString,
+				Computed:    true,
 			},
 			`slo_uri`: {
 				Description: `Provided by OneLogin on app creation`,
 				Optional:    true,
 				Type:        schema.TypeString,
+				Computed:    true,
 			},
 			`slo_binding`: {
 				Description:  `Valid values: HTTP Redirect, HTTP Post`,
 				Optional:     true,
 				Type:         schema.TypeString,
 				ValidateFunc: validation.StringInSlice([]string{`HTTP Redirect`, `HTTP Post`}, false),
+				Computed:     true,
 			},
 			`relying_party_identifier`: {
 				Description: `String used to identify Genesys Cloud to OneLogin.`,
@@ -84,12 +90,17 @@ func ResourceIdpOnelogin() *schema.Resource {
 				Type:        schema.TypeString,
 			},
 			`certificates`: {
-				Description: `PEM or DER encoded public X.509 certificates for SAML signature validation.`,
-				Optional:    true,
-				Type:        schema.TypeList,
-				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description:  `PEM or DER encoded public X.509 certificates for SAML signature validation.`,
+				Optional:     true,
+				Type:         schema.TypeList,
+				Elem:         &schema.Schema{Type: schema.TypeString},
+				AtLeastOneOf: saml.MetadataAlternatives(`certificates`),
 			},
+			`metadata_xml`:  saml.MetadataXmlSchema(),
+			`metadata_file`: saml.MetadataFileSchema(),
+			`metadata_hash`: saml.MetadataHashSchema(),
 		},
+		CustomizeDiff: saml.CustomizeMetadataDiff,
 	}
 }
 
@@ -100,5 +111,6 @@ func IdpOneloginExporter() *resourceExporter.ResourceExporter {
 		RefAttrs:         map[string]*resourceExporter.RefAttrSettings{
 			// TODO: Add any reference attributes here
 		},
+		ExcludedAttributes: []string{"metadata_hash"},
 	}
 }
@@ -7,6 +7,7 @@ import (
 	"terraform-provider-genesyscloud/genesyscloud/provider"
 	resourceExporter "terraform-provider-genesyscloud/genesyscloud/resource_exporter"
 	"terraform-provider-genesyscloud/genesyscloud/util"
+	"terraform-provider-genesyscloud/genesyscloud/util/saml"
 	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
@@ -64,7 +65,7 @@ func readIdpPing(ctx context.Context, d *schema.ResourceData, meta interface{})
 
 	cc := consistency_checker.NewConsistencyCheck(ctx, d, meta, ResourceIdpPing(), constants.ConsistencyChecks(), ResourceType)
 
-	return util.WithRetriesForReadCustomTimeout(ctx, d.Timeout(schema.TimeoutRead), d, func() *retry.RetryError {
+	diagErr := util.WithRetriesForReadCustomTimeout(ctx, d.Timeout(schema.TimeoutRead), d, func() *retry.RetryError {
 		pingIdentity, resp, getErr := proxy.getIdpPing(ctx)
 		if getErr != nil {
 			if util.IsStatus404(resp) {
@@ -93,6 +94,10 @@ func readIdpPing(ctx context.Context, d *schema.ResourceData, meta interface{})
 		log.Printf("Read idp ping")
 		return cc.CheckState(d)
 	})
+	if diagErr != nil {
+		return diagErr
+	}
+	return saml.CertificateExpiryWarnings(d)
 }
 
 // updateIdpPing is used by the idp_ping resource to update an idp ping in Genesys Cloud
@@ -117,7 +122,8 @@ func updateIdpPing(ctx context.Context, d *schema.ResourceData, meta interface{}
 	}
 
 	log.Printf("Updated idp ping")
-	return readIdpPing(ctx, d, meta)
+	rotationWarnings := saml.MetadataRotationWarnings(d)
+	return append(rotationWarnings, readIdpPing(ctx, d, meta)...)
 }
 
 // deleteIdpPing is used by the idp_ping resource to delete an idp ping from Genesys cloud
@@ -11,6 +11,7 @@ import (
 	"terraform-provider-genesyscloud/genesyscloud/util/constants"
 	"terraform-provider-genesyscloud/genesyscloud/util/lists"
 	"terraform-provider-genesyscloud/genesyscloud/util/resourcedata"
+	"terraform-provider-genesyscloud/genesyscloud/util/saml"
 	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
@@ -57,7 +58,7 @@ func readIdpSalesforce(ctx context.Context, d *schema.ResourceData, meta interfa
 
 	log.Printf("Reading IDP Salesforce")
 
-	return util.WithRetriesForReadCustomTimeout(ctx, d.Timeout(schema.TimeoutRead), d, func() *retry.RetryError {
+	diagErr := util.WithRetriesForReadCustomTimeout(ctx, d.Timeout(schema.TimeoutRead), d, func() *retry.RetryError {
 		salesforce, resp, getErr := proxy.getIdpSalesforce(ctx)
 		if getErr != nil {
 			if util.IsStatus404(resp) {
@@ -86,6 +87,10 @@ func readIdpSalesforce(ctx context.Context, d *schema.ResourceData, meta interfa
 		log.Printf("Read IDP Salesforce")
 		return cc.CheckState(d)
 	})
+	if diagErr != nil {
+		return diagErr
+	}
+	return saml.CertificateExpiryWarnings(d)
 }
 
 func updateIdpSalesforce(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
@@ -132,7 +137,8 @@ func updateIdpSalesforce(ctx context.Context, d *schema.ResourceData, meta inter
 	}
 
 	log.Printf("Updated IDP Salesforce")
-	return readIdpSalesforce(ctx, d, meta)
+	rotationWarnings := saml.MetadataRotationWarnings(d)
+	return append(rotationWarnings, readIdpSalesforce(ctx, d, meta)...)
 }
 
 func deleteIdpSalesforce(ctx context.Context, _ *schema.ResourceData, meta interface{}) diag.Diagnostics {
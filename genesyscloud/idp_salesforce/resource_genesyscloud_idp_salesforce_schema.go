@@ -8,6 +8,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 
 	registrar "terraform-provider-genesyscloud/genesyscloud/resource_register"
+	"terraform-provider-genesyscloud/genesyscloud/util/saml"
 )
 
 /*
@@ -50,29 +51,36 @@ func ResourceIdpSalesforce() *schema.Resource {
 				Type:        schema.TypeString,
 			},
 			"certificates": {
-				Description: "PEM or DER encoded public X.509 certificates for SAML signature validation.",
-				Type:        schema.TypeList,
-				Required:    true,
-				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description:  "PEM or DER encoded public X.509 certificates for SAML signature validation.",
+				Type:         schema.TypeList,
+				Optional:     true,
+				Computed:     true,
+				Elem:         &schema.Schema{Type: schema.TypeString},
+				AtLeastOneOf: saml.MetadataAlternatives("certificates"),
 			},
 			"issuer_uri": {
-				Description: "Issuer URI provided by Salesforce.",
-				Type:        schema.TypeString,
-				Required:    true,
+				Description:  "Issuer URI provided by Salesforce.",
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				AtLeastOneOf: saml.MetadataAlternatives("issuer_uri"),
 			},
 			"target_uri": {
 				Description: "Target URI provided by Salesforce.",
 				Type:        schema.TypeString,
 				Optional:    true,
+				Computed:    true,
 			},
 			`slo_uri`: {
 				Description: `Provided on app creation.`,
 				Optional:    true,
 				Type:        schema.TypeString,
+				Computed:    true,
 			},
 			`slo_binding`: {
 				Optional: true,
 				Type:     schema.TypeString,
+				Computed: true,
 			},
 			`relying_party_identifier`: {
 				Description: `String used to identify Genesys Cloud to Ping.`,
@@ -86,13 +94,18 @@ func ResourceIdpSalesforce() *schema.Resource {
 				Optional:    true,
 				Default:     false,
 			},
+			`metadata_xml`:  saml.MetadataXmlSchema(),
+			`metadata_file`: saml.MetadataFileSchema(),
+			`metadata_hash`: saml.MetadataHashSchema(),
 		},
+		CustomizeDiff: saml.CustomizeMetadataDiff,
 	}
 }
 
 func IdpSalesforceExporter() *resourceExporter.ResourceExporter {
 	return &resourceExporter.ResourceExporter{
-		GetResourcesFunc: provider.GetAllWithPooledClient(getAllIdpSalesforce),
-		RefAttrs:         map[string]*resourceExporter.RefAttrSettings{}, // No references
+		GetResourcesFunc:   provider.GetAllWithPooledClient(getAllIdpSalesforce),
+		RefAttrs:           map[string]*resourceExporter.RefAttrSettings{}, // No references
+		ExcludedAttributes: []string{"metadata_hash"},
 	}
 }
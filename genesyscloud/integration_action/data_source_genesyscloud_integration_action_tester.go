@@ -0,0 +1,90 @@
+package integration_action
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"terraform-provider-genesyscloud/genesyscloud/util"
+	"terraform-provider-genesyscloud/genesyscloud/util/templates"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+/*
+   The data_source_genesyscloud_integration_action_tester.go contains the genesyscloud_integration_action_test data source.
+   The data source does not call Genesys Cloud: the stages of the action are run by the provider in
+   genesyscloud_integration_action_tester.go. The request is only sent to an endpoint_url, or to the service when send_request is set.
+*/
+
+// dataSourceIntegrationActionTestRead runs the action configuration against the sample input and sets the output of each stage
+func dataSourceIntegrationActionTestRead(ctx context.Context, d *schema.ResourceData, _ interface{}) diag.Diagnostics {
+	test := buildActionTest(d)
+	result := test.run(ctx)
+
+	d.SetId(templates.HashContent(strings.Join([]string{test.contractInput, test.contractOutput, test.input, result.requestUrl, result.requestBody, result.responseBody}, "\n")))
+	_ = d.Set("input_errors", emptyIfNil(result.inputErrors))
+	_ = d.Set("request_url", result.requestUrl)
+	_ = d.Set("request_headers", result.requestHeaders)
+	_ = d.Set("request_body", result.requestBody)
+	_ = d.Set("response_status_code", result.responseStatusCode)
+	_ = d.Set("response_body", result.responseBody)
+	_ = d.Set("translation_map_values", result.translationMapValues)
+	_ = d.Set("success_output", result.successOutput)
+	_ = d.Set("result", result.result)
+	_ = d.Set("output_errors", emptyIfNil(result.outputErrors))
+	_ = d.Set("errors", emptyIfNil(result.errors))
+	_ = d.Set("success", len(result.errors) == 0)
+
+	log.Printf("Tested integration action with request %s %s: %d errors", test.requestType, result.requestUrl, len(result.errors))
+	if len(result.errors) > 0 && d.Get("fail_on_error").(bool) {
+		return util.BuildDiagnosticError(TestResourceType, "integration action test failed", errors.New(strings.Join(result.errors, "\n")))
+	}
+	return nil
+}
+
+func buildActionTest(d *schema.ResourceData) *actionTest {
+	test := &actionTest{
+		contractInput:  d.Get("contract_input").(string),
+		contractOutput: d.Get("contract_output").(string),
+		input:          d.Get("input").(string),
+		endpointUrl:    d.Get("endpoint_url").(string),
+		liveRequest:    d.Get("send_request").(bool),
+		timeout:        time.Duration(d.Get("timeout_seconds").(int)) * time.Second,
+	}
+
+	if configRequest, ok := d.Get("config_request").([]interface{}); ok && len(configRequest) > 0 && configRequest[0] != nil {
+		request := configRequest[0].(map[string]interface{})
+		test.requestUrlTemplate = request["request_url_template"].(string)
+		test.requestType = request["request_type"].(string)
+		test.requestTemplate = request["request_template"].(string)
+		test.headers = stringMap(request["headers"])
+	}
+	if configResponse, ok := d.Get("config_response").([]interface{}); ok && len(configResponse) > 0 && configResponse[0] != nil {
+		response := configResponse[0].(map[string]interface{})
+		test.translationMap = stringMap(response["translation_map"])
+		test.translationMapDefaults = stringMap(response["translation_map_defaults"])
+		test.successTemplate = response["success_template"].(string)
+	}
+	return test
+}
+
+func stringMap(value interface{}) map[string]string {
+	result := make(map[string]string)
+	if m, ok := value.(map[string]interface{}); ok {
+		for k, v := range m {
+			result[k] = fmt.Sprint(v)
+		}
+	}
+	return result
+}
+
+func emptyIfNil(values []string) []string {
+	if values == nil {
+		return []string{}
+	}
+	return values
+}
@@ -0,0 +1,152 @@
+package integration_action
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"terraform-provider-genesyscloud/genesyscloud/provider"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/mypurecloud/platform-client-sdk-go/v149/platformclientv2"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUnitDataSourceIntegrationActionTest(t *testing.T) {
+	var (
+		receivedMethod  string
+		receivedPath    string
+		receivedQuery   string
+		receivedHeaders http.Header
+		receivedBody    string
+	)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		receivedMethod, receivedPath, receivedQuery, receivedHeaders, receivedBody = r.Method, r.URL.Path, r.URL.RawQuery, r.Header, string(body)
+		if strings.HasSuffix(r.URL.Path, "/missing") {
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"message": "not found"}`))
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"user": {"id": "u-1", "profile": {"displayName": "Ann"}}, "groups": [{"name": "a"}, {"name": "b"}]}`))
+	}))
+	defer server.Close()
+
+	config := map[string]interface{}{
+		"contract_input":  `{"type": "object", "required": ["email"], "properties": {"email": {"type": "string"}, "limit": {"type": "integer"}}}`,
+		"contract_output": `{"type": "object", "required": ["id", "name"], "properties": {"id": {"type": "string"}, "name": {"type": "string"}, "groups": {"type": "array"}, "status": {"type": "string"}}}`,
+		"input":           `{"email": "ann@example.com", "limit": 5}`,
+		"endpoint_url":    server.URL + "/stub",
+		"config_request": []interface{}{map[string]interface{}{
+			"request_url_template": "https://api.example.com/users/search?email=$esc.url(${input.email})",
+			"request_type":         "POST",
+			"request_template":     `{"email": "${input.email}", "limit": ${input.limit}}`,
+			"headers":              map[string]interface{}{"X-Limit": "${input.limit}"},
+		}},
+		"config_response": []interface{}{map[string]interface{}{
+			"translation_map": map[string]interface{}{
+				"id":     "$.user.id",
+				"name":   "$.user.profile.displayName",
+				"groups": "$.groups[*].name",
+				"status": "$.user.status",
+			},
+			"translation_map_defaults": map[string]interface{}{"status": `"active"`},
+			"success_template":         `{"id": ${id}, "name": ${name}, "groups": ${groups}, "status": ${status}}`,
+		}},
+	}
+
+	d := schema.TestResourceDataRaw(t, DataSourceIntegrationActionTest().Schema, config)
+	diags := dataSourceIntegrationActionTestRead(context.Background(), d, &provider.ProviderMeta{ClientConfig: &platformclientv2.Configuration{}})
+	assert.False(t, diags.HasError(), diags)
+
+	assert.Equal(t, "POST", receivedMethod)
+	assert.Equal(t, "/stub/users/search", receivedPath)
+	assert.Equal(t, "email=ann%40example.com", receivedQuery)
+	assert.Equal(t, "5", receivedHeaders.Get("X-Limit"))
+	assert.Equal(t, "application/json", receivedHeaders.Get("Content-Type"))
+	assert.Equal(t, `{"email": "ann@example.com", "limit": 5}`, receivedBody)
+
+	assert.Equal(t, "https://api.example.com/users/search?email=ann%40example.com", d.Get("request_url"))
+	assert.Equal(t, 200, d.Get("response_status_code"))
+	assert.Equal(t, map[string]interface{}{"id": `"u-1"`, "name": `"Ann"`, "groups": `["a","b"]`, "status": `"active"`}, d.Get("translation_map_values"))
+	assert.Equal(t, `{"groups":["a","b"],"id":"u-1","name":"Ann","status":"active"}`, d.Get("result"))
+	assert.Equal(t, true, d.Get("success"))
+	assert.Empty(t, d.Get("errors"))
+	assert.NotEmpty(t, d.Id())
+
+	// Invalid input stops the run before the request is sent
+	receivedMethod = ""
+	config["input"] = `{"limit": "5"}`
+	config["fail_on_error"] = false
+	d = schema.TestResourceDataRaw(t, DataSourceIntegrationActionTest().Schema, config)
+	diags = dataSourceIntegrationActionTestRead(context.Background(), d, nil)
+	assert.False(t, diags.HasError(), diags)
+	assert.Equal(t, "", receivedMethod)
+	assert.Equal(t, false, d.Get("success"))
+	assert.Equal(t, []interface{}{"$.limit: got string, want integer", "$: missing property 'email'"}, d.Get("input_errors"))
+
+	// Missing translation values without defaults and results not matching contract_output are errors
+	config["input"] = `{"email": "ann@example.com", "limit": 5}`
+	config["contract_output"] = `{"type": "object", "properties": {"id": {"type": "integer"}}}`
+	config["config_response"] = []interface{}{map[string]interface{}{
+		"translation_map":  map[string]interface{}{"id": "$.user.id", "email": "$.user.email"},
+		"success_template": `{"id": ${id}}`,
+	}}
+	d = schema.TestResourceDataRaw(t, DataSourceIntegrationActionTest().Schema, config)
+	_ = dataSourceIntegrationActionTestRead(context.Background(), d, nil)
+	assert.Equal(t, []interface{}{"translation_map: email: no value found at $.user.email and no default is set"}, d.Get("errors"))
+
+	delete(config["config_response"].([]interface{})[0].(map[string]interface{})["translation_map"].(map[string]interface{}), "email")
+	d = schema.TestResourceDataRaw(t, DataSourceIntegrationActionTest().Schema, config)
+	_ = dataSourceIntegrationActionTestRead(context.Background(), d, nil)
+	assert.Equal(t, `{"id": "u-1"}`, d.Get("success_output"))
+	assert.Equal(t, []interface{}{"$.id: got string, want integer"}, d.Get("output_errors"))
+
+	// Error responses fail the data source unless fail_on_error is false
+	config["config_request"].([]interface{})[0].(map[string]interface{})["request_url_template"] = "/users/missing"
+	config["fail_on_error"] = true
+	d = schema.TestResourceDataRaw(t, DataSourceIntegrationActionTest().Schema, config)
+	diags = dataSourceIntegrationActionTestRead(context.Background(), d, nil)
+	assert.True(t, diags.HasError())
+	assert.Equal(t, 404, d.Get("response_status_code"))
+	assert.Equal(t, []interface{}{"response: POST " + server.URL + "/stub/users/missing returned status 404"}, d.Get("errors"))
+
+	var responseBody map[string]interface{}
+	assert.Nil(t, json.Unmarshal([]byte(d.Get("response_body").(string)), &responseBody))
+	assert.Equal(t, "not found", responseBody["message"])
+
+	// Without an endpoint_url, the request is rendered but not sent unless send_request is set
+	receivedMethod = ""
+	delete(config, "endpoint_url")
+	config["config_request"].([]interface{})[0].(map[string]interface{})["request_url_template"] = server.URL + "/users"
+	d = schema.TestResourceDataRaw(t, DataSourceIntegrationActionTest().Schema, config)
+	diags = dataSourceIntegrationActionTestRead(context.Background(), d, nil)
+	assert.False(t, diags.HasError(), diags)
+	assert.Equal(t, "", receivedMethod)
+	assert.Equal(t, server.URL+"/users", d.Get("request_url"))
+	assert.Equal(t, 0, d.Get("response_status_code"))
+	assert.Equal(t, "", d.Get("result"))
+	assert.Empty(t, d.Get("errors"))
+
+	// With send_request, only requests which do not change data are sent to the rendered URL
+	config["send_request"] = true
+	d = schema.TestResourceDataRaw(t, DataSourceIntegrationActionTest().Schema, config)
+	diags = dataSourceIntegrationActionTestRead(context.Background(), d, nil)
+	assert.True(t, diags.HasError())
+	assert.Equal(t, "", receivedMethod)
+	assert.Equal(t, []interface{}{"request: POST requests are only sent to an endpoint_url, set it to the URL of a stub of the service"}, d.Get("errors"))
+
+	config["config_request"].([]interface{})[0].(map[string]interface{})["request_type"] = "GET"
+	config["fail_on_error"] = false
+	d = schema.TestResourceDataRaw(t, DataSourceIntegrationActionTest().Schema, config)
+	diags = dataSourceIntegrationActionTestRead(context.Background(), d, nil)
+	assert.False(t, diags.HasError(), diags)
+	assert.Equal(t, 200, d.Get("response_status_code"))
+	assert.Equal(t, "GET", receivedMethod)
+	assert.Equal(t, "/users", receivedPath)
+	assert.Equal(t, "", receivedBody)
+}
@@ -42,6 +42,7 @@ func (r *registerTestInstance) registerTestDataSources() {
 	defer r.datasourceMapMutex.Unlock()
 
 	providerDataSources[ResourceType] = DataSourceIntegrationAction()
+	providerDataSources[TestResourceType] = DataSourceIntegrationActionTest()
 	providerDataSources[integration.ResourceType] = integration.DataSourceIntegration()
 }
 
@@ -0,0 +1,114 @@
+package integration_action
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/ohler55/ojg/jp"
+)
+
+/*
+The genesyscloud_integration_action_json_path.go file evaluates the JSONPath expressions of action translation maps for the
+genesyscloud_integration_action_test data source with the github.com/ohler55/ojg library. Paths must start with the root $.
+*/
+
+// jsonPathObject exposes an object to the JSONPath library with sorted keys, so wildcards and deep scans return the values of
+// objects in a stable order
+type jsonPathObject map[string]interface{}
+
+func (o jsonPathObject) ValueForKey(key string) (interface{}, bool) {
+	value, ok := o[key]
+	return value, ok
+}
+
+func (o jsonPathObject) SetValueForKey(key string, value interface{}) {
+	o[key] = value
+}
+
+func (o jsonPathObject) RemoveValueForKey(key string) {
+	delete(o, key)
+}
+
+func (o jsonPathObject) Keys() []string {
+	return sortedKeys(o)
+}
+
+// evaluateJsonPath returns the values matched by the path in a document decoded with decodeJson, and whether the path is definite.
+// A definite path matches at most one value, while wildcards, deep scans, slices, unions and filters can match several.
+func evaluateJsonPath(document interface{}, path string) ([]interface{}, bool, error) {
+	path = strings.TrimSpace(path)
+	if !strings.HasPrefix(path, "$") {
+		return nil, false, fmt.Errorf("JSON path %q must start with $", path)
+	}
+	expr, err := jp.ParseString(path)
+	if err != nil {
+		return nil, false, fmt.Errorf("JSON path %q: %s", path, err)
+	}
+
+	definite := true
+	for _, fragment := range expr {
+		switch fragment.(type) {
+		case jp.Root, jp.At, jp.Bracket, jp.Child, jp.Nth:
+		default:
+			definite = false
+		}
+	}
+
+	values := expr.Get(toJsonPathDocument(document))
+	for i, value := range values {
+		values[i] = fromJsonPathDocument(value)
+	}
+	return values, definite, nil
+}
+
+// toJsonPathDocument converts a document decoded with decodeJson for the JSONPath library: objects are sorted and numbers are
+// converted to the types the filters of the library compare
+func toJsonPathDocument(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		object := make(jsonPathObject, len(v))
+		for key, item := range v {
+			object[key] = toJsonPathDocument(item)
+		}
+		return object
+	case []interface{}:
+		array := make([]interface{}, len(v))
+		for i, item := range v {
+			array[i] = toJsonPathDocument(item)
+		}
+		return array
+	case json.Number:
+		if i, err := v.Int64(); err == nil {
+			return i
+		}
+		// Integers larger than int64 are kept as json.Number so they are not rounded
+		if f, err := v.Float64(); err == nil && strings.ContainsAny(v.String(), ".eE") {
+			return f
+		}
+	}
+	return value
+}
+
+// fromJsonPathDocument converts a value returned by the JSONPath library back to the types of decodeJson
+func fromJsonPathDocument(value interface{}) interface{} {
+	switch v := value.(type) {
+	case jsonPathObject:
+		object := make(map[string]interface{}, len(v))
+		for key, item := range v {
+			object[key] = fromJsonPathDocument(item)
+		}
+		return object
+	case []interface{}:
+		array := make([]interface{}, len(v))
+		for i, item := range v {
+			array[i] = fromJsonPathDocument(item)
+		}
+		return array
+	case int64:
+		return json.Number(velocityString(v))
+	case float64:
+		return json.Number(velocityString(v))
+	}
+	return value
+}
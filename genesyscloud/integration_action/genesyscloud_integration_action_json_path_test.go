@@ -0,0 +1,82 @@
+package integration_action
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUnitEvaluateJsonPath(t *testing.T) {
+	document, err := decodeJson(`{"store": {"books": [{"title": "A", "price": 8}, {"title": "B", "price": 12.5}, {"title": "C"}], "owner": {"name": "Ann"}}, "big": 12345678901234567890}`)
+	assert.Nil(t, err)
+
+	testCases := []struct {
+		path     string
+		expected string
+		definite bool
+	}{
+		{"$.store.owner.name", `["Ann"]`, true},
+		{"$['store']['books'][1].title", `["B"]`, true},
+		{"$.store.books[-1].title", `["C"]`, true},
+		{"$.store.books[*].title", `["A","B","C"]`, false},
+		{"$.store.books[0:2].price", `[8,12.5]`, false},
+		{"$..price", `[8,12.5]`, false},
+		{"$.store.books[?(@.price > 10)].title", `["B"]`, false},
+		{"$.store.owner.*", `["Ann"]`, false},
+		{"$.store.missing", `null`, true},
+		{" $.big ", `[12345678901234567890]`, true},
+	}
+	for _, testCase := range testCases {
+		values, definite, err := evaluateJsonPath(document, testCase.path)
+		if assert.Nil(t, err, testCase.path) {
+			assert.Equal(t, testCase.expected, encodeJson(values), testCase.path)
+			assert.Equal(t, testCase.definite, definite, testCase.path)
+		}
+	}
+}
+
+func TestUnitEvaluateJsonPathSelectors(t *testing.T) {
+	document, err := decodeJson(`{"items": [0, 1, 2, 3, 4, 5], "users": [{"name": "a", "tags": ["x"]}, {"name": "b", "tags": ["y", "z"]}], "first name": "Ann", "meta": {"b": 2, "a": 1}}`)
+	assert.Nil(t, err)
+
+	testCases := []struct {
+		path     string
+		expected string
+		definite bool
+	}{
+		{`$["first name"]`, `["Ann"]`, true},
+		{`$['meta']['a', "b"]`, `[1,2]`, false},
+		{"$.items[0, 2, -1]", `[0,2,5]`, false},
+		{"$.items[-2:]", `[4,5]`, false},
+		{"$.items[1:6:3]", `[1,4]`, false},
+		{"$.items[10:20]", `null`, false},
+		{"$.items[6]", `null`, true},
+		{"$.meta.*", `[1,2]`, false},
+		{"$.users[*].tags[0]", `["x","y"]`, false},
+		{"$.users[?(@.name == 'b')].tags", `[["y","z"]]`, false},
+		{"$.users.name", `null`, true},
+		{"$..tags[1]", `["z"]`, false},
+	}
+	for _, testCase := range testCases {
+		values, definite, err := evaluateJsonPath(document, testCase.path)
+		if assert.Nil(t, err, testCase.path) {
+			assert.Equal(t, testCase.expected, encodeJson(values), testCase.path)
+			assert.Equal(t, testCase.definite, definite, testCase.path)
+		}
+	}
+}
+
+func TestUnitEvaluateJsonPathErrors(t *testing.T) {
+	testCases := map[string]string{
+		"":            "must start with $",
+		"store.owner": "must start with $",
+		"$x":          `JSON path "$x": parse error`,
+		"$[":          `JSON path "$[": not terminated`,
+		"$['a', b]":   "invalid union syntax",
+		"$[1:2:3:4]":  "invalid slice syntax",
+	}
+	for path, expected := range testCases {
+		_, _, err := evaluateJsonPath(map[string]interface{}{}, path)
+		assert.ErrorContains(t, err, expected, path)
+	}
+}
@@ -0,0 +1,133 @@
+package integration_action
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/santhosh-tekuri/jsonschema/v6"
+	"github.com/santhosh-tekuri/jsonschema/v6/kind"
+	"golang.org/x/text/language"
+	"golang.org/x/text/message"
+)
+
+/*
+The genesyscloud_integration_action_json_schema.go file validates values against the JSON schemas of action contracts for the
+genesyscloud_integration_action_test data source with the github.com/santhosh-tekuri/jsonschema library. Contracts without $schema
+are validated as draft 4 schemas, like Genesys Cloud does. References are resolved within the schema only, remote schemas are not
+loaded.
+*/
+
+// contractSchemaUrl is the URL the contract is registered under. References like #/definitions/address are resolved against it.
+const contractSchemaUrl = "urn:genesyscloud:integration-action:contract"
+
+var jsonSchemaPrinter = message.NewPrinter(language.English)
+
+// localSchemaLoader refuses to load the schemas referenced by contracts, so validating a contract never reads files or calls URLs
+type localSchemaLoader struct{}
+
+func (localSchemaLoader) Load(url string) (any, error) {
+	return nil, fmt.Errorf("only references local to the schema are supported")
+}
+
+// compileJsonSchema compiles a contract decoded with decodeJson. An error is returned if the contract is not a valid schema.
+func compileJsonSchema(schema interface{}) (*jsonschema.Schema, error) {
+	compiler := jsonschema.NewCompiler()
+	compiler.DefaultDraft(jsonschema.Draft4)
+	compiler.UseLoader(localSchemaLoader{})
+	if err := compiler.AddResource(contractSchemaUrl, schema); err != nil {
+		return nil, err
+	}
+	return compiler.Compile(contractSchemaUrl)
+}
+
+// validateJsonSchema returns the errors of the value against the schema, sorted by the path they apply to. Values must be decoded
+// with decodeJson.
+func validateJsonSchema(schema *jsonschema.Schema, value interface{}) []string {
+	err := schema.Validate(value)
+	if err == nil {
+		return nil
+	}
+	validationErr, ok := err.(*jsonschema.ValidationError)
+	if !ok {
+		return []string{fmt.Sprintf("$: %s", err)}
+	}
+
+	seen := make(map[string]bool)
+	var errors []string
+	collectJsonSchemaErrors(validationErr, func(e *jsonschema.ValidationError) {
+		message := fmt.Sprintf("%s: %s", jsonInstancePath(e.InstanceLocation), e.ErrorKind.LocalizedString(jsonSchemaPrinter))
+		// The same error is reported once per schema it is found through
+		if !seen[message] {
+			seen[message] = true
+			errors = append(errors, message)
+		}
+	})
+	sort.Strings(errors)
+	return errors
+}
+
+// collectJsonSchemaErrors calls add with the errors of the validation which are reported to the user. The errors wrapping others, like
+// the ones of a schema or a reference, are replaced by the errors they wrap. The errors of the branches of anyOf and oneOf are
+// alternatives, so only the error of the keyword is reported.
+func collectJsonSchemaErrors(e *jsonschema.ValidationError, add func(*jsonschema.ValidationError)) {
+	switch e.ErrorKind.(type) {
+	case *kind.AnyOf, *kind.OneOf:
+		add(e)
+		return
+	}
+	if len(e.Causes) == 0 {
+		add(e)
+		return
+	}
+	for _, cause := range e.Causes {
+		collectJsonSchemaErrors(cause, add)
+	}
+}
+
+// decodeJson decodes JSON keeping numbers as json.Number
+func decodeJson(data string) (interface{}, error) {
+	decoder := json.NewDecoder(strings.NewReader(data))
+	decoder.UseNumber()
+	var value interface{}
+	if err := decoder.Decode(&value); err != nil {
+		return nil, err
+	}
+	if decoder.More() {
+		return nil, fmt.Errorf("unexpected data after JSON value")
+	}
+	return value, nil
+}
+
+// jsonInstancePath converts the location of an instance, like [tags 0], to the path used in error messages, like $.tags[0]
+func jsonInstancePath(location []string) string {
+	path := "$"
+	for _, token := range location {
+		if isJsonIndex(token) {
+			path += "[" + token + "]"
+		} else {
+			path = jsonPropertyPath(path, token)
+		}
+	}
+	return path
+}
+
+func isJsonIndex(token string) bool {
+	if token == "" || len(token) > 1 && token[0] == '0' {
+		return false
+	}
+	for i := 0; i < len(token); i++ {
+		if !isDigit(token[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+func jsonPropertyPath(path, name string) string {
+	if name != "" && isVelocityIdentifierStart(name[0]) && readVelocityIdentifier(name) == name {
+		return path + "." + name
+	}
+	return fmt.Sprintf("%s[%s]", path, encodeJson(name))
+}
@@ -0,0 +1,100 @@
+package integration_action
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func validateJsonSchemaString(t *testing.T, schema, value string) []string {
+	decodedSchema, err := decodeJson(schema)
+	assert.Nil(t, err)
+	compiled, err := compileJsonSchema(decodedSchema)
+	if !assert.Nil(t, err, schema) {
+		return nil
+	}
+	decodedValue, err := decodeJson(value)
+	assert.Nil(t, err)
+	return validateJsonSchema(compiled, decodedValue)
+}
+
+func TestUnitValidateJsonSchema(t *testing.T) {
+	schema := `{
+		"type": "object",
+		"required": ["id", "tags"],
+		"additionalProperties": false,
+		"properties": {
+			"id": {"type": "string", "pattern": "^[0-9]+$"},
+			"count": {"type": "integer", "minimum": 1, "maximum": 10},
+			"status": {"enum": ["open", "closed"]},
+			"tags": {"type": "array", "maxItems": 2, "items": {"$ref": "#/definitions/tag"}}
+		},
+		"definitions": {"tag": {"type": "string", "minLength": 2}}
+	}`
+
+	assert.Empty(t, validateJsonSchemaString(t, schema, `{"id": "12", "count": 3, "status": "open", "tags": ["ab"]}`))
+
+	assert.Equal(t, []string{
+		`$.count: got number, want integer`,
+		`$.id: 'x1' does not match pattern '^[0-9]+$'`,
+		`$.status: value must be one of 'open', 'closed'`,
+		`$.tags: maxItems: got 3, want 2`,
+		`$.tags[0]: minLength: got 1, want 2`,
+		`$: additional properties 'extra' not allowed`,
+	}, validateJsonSchemaString(t, schema, `{"id": "x1", "count": 1.5, "status": "new", "tags": ["a", "bc", "de"], "extra": true}`))
+
+	assert.Equal(t, []string{
+		`$.count: minimum: got 0, want 1`,
+		`$: missing properties 'id', 'tags'`,
+	}, validateJsonSchemaString(t, schema, `{"count": 0}`))
+}
+
+func TestUnitValidateJsonSchemaKeywords(t *testing.T) {
+	testCases := []struct {
+		name   string
+		schema string
+		value  string
+		errors []string
+	}{
+		{name: "type list", schema: `{"type": ["string", "null"]}`, value: `1`, errors: []string{"$: got number, want null or string"}},
+		{name: "integral number as integer", schema: `{"type": "integer"}`, value: `2.0`},
+		{name: "property name with spaces", schema: `{"properties": {"first name": {"type": "string"}}}`, value: `{"first name": 1}`, errors: []string{`$["first name"]: got number, want string`}},
+		{name: "draft 4 exclusive minimum", schema: `{"minimum": 1, "exclusiveMinimum": true}`, value: `1`, errors: []string{"$: exclusiveMinimum: got 1, want 1"}},
+		{name: "declared draft", schema: `{"$schema": "https://json-schema.org/draft/2020-12/schema", "const": "a"}`, value: `"b"`, errors: []string{"$: value must be 'a'"}},
+		{name: "any of", schema: `{"anyOf": [{"type": "string"}, {"type": "integer"}]}`, value: `true`, errors: []string{"$: 'anyOf' failed"}},
+		{name: "one of", schema: `{"oneOf": [{"type": "number"}, {"type": "integer"}]}`, value: `1`, errors: []string{"$: 'oneOf' failed, subschemas 0, 1 matched"}},
+		{name: "recursive ref", schema: `{"type": "object", "properties": {"child": {"$ref": "#"}}, "additionalProperties": false}`, value: `{"child": {"child": {"other": 1}}}`, errors: []string{"$.child.child: additional properties 'other' not allowed"}},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			assert.Equal(t, testCase.errors, validateJsonSchemaString(t, testCase.schema, testCase.value))
+		})
+	}
+}
+
+func TestUnitCompileJsonSchemaErrors(t *testing.T) {
+	testCases := map[string]string{
+		`{"pattern": "a(b"}`:                          "'a(b' is not valid regex",
+		`{"type": 1}`:                                 "is not valid against metaschema",
+		`{"$ref": "#/definitions/missing"}`:           "not found",
+		`{"$ref": "https://example.com/schema.json"}`: "only references local to the schema are supported",
+		`{"$ref": "file:///etc/hosts"}`:               "only references local to the schema are supported",
+	}
+	for schema, expected := range testCases {
+		decoded, err := decodeJson(schema)
+		assert.Nil(t, err)
+		_, err = compileJsonSchema(decoded)
+		assert.ErrorContains(t, err, expected, schema)
+	}
+}
+
+func TestUnitDecodeJson(t *testing.T) {
+	value, err := decodeJson(`{"big": 12345678901234567890, "small": 1.5}`)
+	assert.Nil(t, err)
+	assert.Equal(t, `{"big":12345678901234567890,"small":1.5}`, encodeJson(value))
+
+	_, err = decodeJson(`{"a": 1} {"b": 2}`)
+	assert.ErrorContains(t, err, "unexpected data after JSON value")
+	_, err = decodeJson(`{"a": }`)
+	assert.NotNil(t, err)
+}
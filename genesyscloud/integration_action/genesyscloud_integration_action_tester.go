@@ -0,0 +1,327 @@
+package integration_action
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"terraform-provider-genesyscloud/genesyscloud/util/lists"
+	"time"
+)
+
+/*
+The genesyscloud_integration_action_tester.go file runs the stages of an integration action for the
+genesyscloud_integration_action_test data source, the way Genesys Cloud executes them:
+
+ 1. The input is validated against contract_input.
+ 2. The request URL, headers and body templates are rendered with the input.
+ 3. The request is sent. The scheme and host of the URL can be replaced to send it to a local stub. Without a stub, the request is
+    only sent to the service when send_request is set, and only if it does not change data, since the data source is read on every
+    plan and refresh. Otherwise the run stops after the request is rendered.
+ 4. The translation_map JSON paths are applied to the response, falling back to translation_map_defaults.
+ 5. The success template is rendered with the translated values and validated against contract_output.
+
+A failing stage stops the run. The output of every completed stage is kept in the result.
+*/
+
+const (
+	defaultRequestTemplate = "${input.rawRequest}"
+	defaultSuccessTemplate = "${rawResult}"
+
+	// maxActionResponseBytes is the largest response read from the service
+	maxActionResponseBytes = 5 * 1024 * 1024
+)
+
+// safeRequestTypes lists the request types sent to the rendered URL when send_request is set and no endpoint_url is set
+var safeRequestTypes = []string{http.MethodGet, http.MethodHead, http.MethodOptions}
+
+type actionTest struct {
+	contractInput          string
+	contractOutput         string
+	input                  string
+	requestUrlTemplate     string
+	requestType            string
+	requestTemplate        string
+	headers                map[string]string
+	translationMap         map[string]string
+	translationMapDefaults map[string]string
+	successTemplate        string
+	endpointUrl            string
+	liveRequest            bool
+	timeout                time.Duration
+}
+
+type actionTestResult struct {
+	inputErrors          []string
+	requestUrl           string
+	requestHeaders       map[string]string
+	requestBody          string
+	responseStatusCode   int
+	responseBody         string
+	translationMapValues map[string]string
+	successOutput        string
+	result               string
+	outputErrors         []string
+	errors               []string
+}
+
+func (r *actionTestResult) addError(stage string, format string, args ...interface{}) {
+	r.errors = append(r.errors, fmt.Sprintf("%s: %s", stage, fmt.Sprintf(format, args...)))
+}
+
+// run runs the stages of the action. Errors are collected in the result.
+func (t *actionTest) run(ctx context.Context) *actionTestResult {
+	r := &actionTestResult{}
+
+	contractInput, err := decodeJson(t.contractInput)
+	if err != nil {
+		r.addError("contract_input", "invalid JSON: %s", err)
+	}
+	contractOutput, err := decodeJson(t.contractOutput)
+	if err != nil {
+		r.addError("contract_output", "invalid JSON: %s", err)
+	}
+	input, err := decodeJson(t.input)
+	if err != nil {
+		r.addError("input", "invalid JSON: %s", err)
+	}
+	if len(r.errors) > 0 {
+		return r
+	}
+
+	inputSchema, err := compileJsonSchema(contractInput)
+	if err != nil {
+		r.addError("contract_input", "invalid JSON schema: %s", err)
+	}
+	outputSchema, err := compileJsonSchema(contractOutput)
+	if err != nil {
+		r.addError("contract_output", "invalid JSON schema: %s", err)
+	}
+	if len(r.errors) > 0 {
+		return r
+	}
+
+	r.inputErrors = validateJsonSchema(inputSchema, input)
+	for _, inputError := range r.inputErrors {
+		r.addError("input", "%s", inputError)
+	}
+	if len(r.errors) > 0 {
+		return r
+	}
+
+	inputVariable := map[string]interface{}{}
+	if object, ok := input.(map[string]interface{}); ok {
+		inputVariable = object
+	}
+	inputVariable["rawRequest"] = encodeJson(input)
+	variables := map[string]interface{}{"input": inputVariable}
+
+	if !t.renderRequest(r, variables) {
+		return r
+	}
+	// Without a stub, the service is only called when send_request is set
+	if t.endpointUrl == "" && !t.liveRequest {
+		return r
+	}
+	if !t.sendRequest(ctx, r) {
+		return r
+	}
+
+	successVariables, ok := t.translateResponse(r)
+	if !ok {
+		return r
+	}
+	successVariables["input"] = inputVariable
+
+	successTemplate := t.successTemplate
+	if successTemplate == "" {
+		successTemplate = defaultSuccessTemplate
+	}
+	r.successOutput, err = renderVelocityTemplate("success_template", successTemplate, successVariables)
+	if err != nil {
+		r.addError("success_template", "%s", err)
+		return r
+	}
+
+	output, err := decodeJson(r.successOutput)
+	if err != nil {
+		r.addError("success_template", "output is not valid JSON: %s", err)
+		return r
+	}
+	r.result = encodeJson(output)
+	r.outputErrors = validateJsonSchema(outputSchema, output)
+	for _, outputError := range r.outputErrors {
+		r.addError("output", "%s", outputError)
+	}
+	return r
+}
+
+func (t *actionTest) renderRequest(r *actionTestResult, variables map[string]interface{}) bool {
+	var err error
+	r.requestUrl, err = renderVelocityTemplate("request_url_template", t.requestUrlTemplate, variables)
+	if err != nil {
+		r.addError("request_url_template", "%s", err)
+		return false
+	}
+
+	r.requestHeaders = make(map[string]string, len(t.headers))
+	for name, value := range t.headers {
+		r.requestHeaders[name], err = renderVelocityTemplate("headers."+name, value, variables)
+		if err != nil {
+			r.addError("headers", "%s", err)
+			return false
+		}
+	}
+
+	requestTemplate := t.requestTemplate
+	if requestTemplate == "" {
+		requestTemplate = defaultRequestTemplate
+	}
+	r.requestBody, err = renderVelocityTemplate("request_template", requestTemplate, variables)
+	if err != nil {
+		r.addError("request_template", "%s", err)
+		return false
+	}
+	return true
+}
+
+func (t *actionTest) sendRequest(ctx context.Context, r *actionTestResult) bool {
+	if t.endpointUrl == "" && !lists.ItemInSlice(t.requestType, safeRequestTypes) {
+		r.addError("request", "%s requests are only sent to an endpoint_url, set it to the URL of a stub of the service", t.requestType)
+		return false
+	}
+	requestUrl, err := replaceEndpoint(r.requestUrl, t.endpointUrl)
+	if err != nil {
+		r.addError("request", "%s", err)
+		return false
+	}
+
+	var body io.Reader
+	hasBody := t.requestType != http.MethodGet && t.requestType != http.MethodDelete && r.requestBody != ""
+	if hasBody {
+		body = strings.NewReader(r.requestBody)
+	}
+	request, err := http.NewRequestWithContext(ctx, t.requestType, requestUrl, body)
+	if err != nil {
+		r.addError("request", "%s", err)
+		return false
+	}
+	for name, value := range r.requestHeaders {
+		request.Header.Set(name, value)
+	}
+	if hasBody && request.Header.Get("Content-Type") == "" {
+		request.Header.Set("Content-Type", "application/json")
+	}
+
+	client := &http.Client{Timeout: t.timeout}
+	response, err := client.Do(request)
+	if err != nil {
+		r.addError("request", "%s", err)
+		return false
+	}
+	defer response.Body.Close()
+
+	content, err := io.ReadAll(io.LimitReader(response.Body, maxActionResponseBytes+1))
+	if err != nil {
+		r.addError("response", "failed to read response: %s", err)
+		return false
+	}
+	if len(content) > maxActionResponseBytes {
+		r.addError("response", "response is larger than %d bytes", maxActionResponseBytes)
+		return false
+	}
+	r.responseStatusCode = response.StatusCode
+	r.responseBody = string(content)
+
+	if response.StatusCode < 200 || response.StatusCode > 299 {
+		r.addError("response", "%s %s returned status %d", t.requestType, requestUrl, response.StatusCode)
+		return false
+	}
+	return true
+}
+
+// translateResponse applies the translation map to the response and returns the variables of the success template
+func (t *actionTest) translateResponse(r *actionTestResult) (map[string]interface{}, bool) {
+	variables := map[string]interface{}{"rawResult": r.responseBody}
+	r.translationMapValues = make(map[string]string, len(t.translationMap))
+	if len(t.translationMap) == 0 {
+		return variables, true
+	}
+
+	document, err := decodeJson(r.responseBody)
+	if err != nil {
+		r.addError("translation_map", "response is not valid JSON: %s", err)
+		return nil, false
+	}
+
+	names := make([]string, 0, len(t.translationMap))
+	for name := range t.translationMap {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	ok := true
+	for _, name := range names {
+		values, definite, err := evaluateJsonPath(document, t.translationMap[name])
+		if err != nil {
+			r.addError("translation_map", "%s: %s", name, err)
+			ok = false
+			continue
+		}
+
+		var value string
+		switch {
+		case len(values) > 0 && definite:
+			value = encodeJson(values[0])
+		case len(values) > 0:
+			value = encodeJson(values)
+		default:
+			defaultValue, hasDefault := t.translationMapDefaults[name]
+			if !hasDefault {
+				r.addError("translation_map", "%s: no value found at %s and no default is set", name, t.translationMap[name])
+				ok = false
+				continue
+			}
+			if _, err := decodeJson(defaultValue); err != nil {
+				r.addError("translation_map_defaults", "%s: default is not valid JSON: %s", name, err)
+				ok = false
+				continue
+			}
+			value = defaultValue
+		}
+		r.translationMapValues[name] = value
+		variables[name] = value
+	}
+	return variables, ok
+}
+
+// replaceEndpoint replaces the scheme and host of the request URL by the ones of the endpoint, and prefixes its path by the
+// path of the endpoint
+func replaceEndpoint(requestUrl, endpointUrl string) (string, error) {
+	if endpointUrl == "" {
+		return requestUrl, nil
+	}
+	endpoint, err := url.Parse(endpointUrl)
+	if err != nil {
+		return "", fmt.Errorf("invalid endpoint_url %s: %s", endpointUrl, err)
+	}
+	request, err := url.Parse(requestUrl)
+	if err != nil {
+		return "", fmt.Errorf("invalid request URL %s: %s", requestUrl, err)
+	}
+
+	endpoint.RawQuery = ""
+	endpoint.Fragment = ""
+	path := request.EscapedPath()
+	if !strings.HasPrefix(path, "/") {
+		path = "/" + path
+	}
+	result := strings.TrimSuffix(endpoint.String(), "/") + path
+	if request.RawQuery != "" {
+		result += "?" + request.RawQuery
+	}
+	return result, nil
+}
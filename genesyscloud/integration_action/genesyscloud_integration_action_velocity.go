@@ -0,0 +1,1241 @@
+package integration_action
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"html"
+	"math"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+/*
+The genesyscloud_integration_action_velocity.go file renders the Velocity templates of integration actions for the
+genesyscloud_integration_action_test data source. It implements the following subset of the Velocity Template Language, which
+covers the templates generated by Genesys Cloud and the examples of its documentation:
+
+  - references with properties and indexes: $input.name, ${input.items[0]}, $input.scores['a']
+  - quiet references: $!input.name renders nothing when the reference is undefined
+  - the length() and isEmpty() methods of strings, and the size() and isEmpty() methods of lists and objects
+  - the #if, #elseif, #else, #foreach and #set directives, and ## and #* *# comments
+  - expressions with string, number, boolean and null literals, the comparison operators ==, !=, <, <=, >, >= and the logical
+    operators &&, || and !, including their word forms like eq and and
+  - the $esc, $math and $successTemplateUtils tools. Arithmetic is done with $math, like $math.add($input.count, 1).
+
+Other constructs, like the arithmetic operators, list, map and range literals, other methods and macros, are not supported. They are
+reported as errors, or rendered as written like Velocity renders invalid references. Undefined references without ! are rendered as
+written, like Velocity does. Objects and lists are rendered as JSON. Lines which only hold a directive do not produce output.
+*/
+
+// maxVelocityIterations limits the iterations of #foreach loops
+const maxVelocityIterations = 10000
+
+var velocityDirectives = map[string]bool{"if": true, "elseif": true, "else": true, "end": true, "foreach": true, "set": true}
+
+type velocityNode interface{}
+
+type velocityText struct {
+	text string
+}
+
+type velocityIf struct {
+	conditions []velocityExpr
+	bodies     [][]velocityNode
+	elseBody   []velocityNode
+}
+
+type velocityForeach struct {
+	variable   string
+	collection velocityExpr
+	body       []velocityNode
+}
+
+type velocitySet struct {
+	target *velocityReference
+	value  velocityExpr
+}
+
+// velocityDirective is the parsed header of a directive
+type velocityDirective struct {
+	name     string
+	expr     velocityExpr
+	variable string
+	target   *velocityReference
+}
+
+type velocityExpr interface{}
+
+// velocityReference is a reference like $!{input.items[0].name}. It is both a node and an expression.
+type velocityReference struct {
+	raw      string
+	quiet    bool
+	name     string
+	segments []velocitySegment
+}
+
+type velocitySegment struct {
+	name     string
+	isMethod bool
+	args     []velocityExpr
+	index    velocityExpr
+}
+
+type velocityLiteral struct {
+	value interface{}
+}
+
+type velocityInterpolated struct {
+	nodes []velocityNode
+}
+
+type velocityNot struct {
+	operand velocityExpr
+}
+
+type velocityBinary struct {
+	op    string
+	left  velocityExpr
+	right velocityExpr
+}
+
+// velocityTemplate is a parsed template
+type velocityTemplate struct {
+	name  string
+	nodes []velocityNode
+}
+
+type velocityParser struct {
+	name string
+	src  string
+	pos  int
+}
+
+// parseVelocityTemplate parses a template. The name is used in error messages.
+func parseVelocityTemplate(name, src string) (*velocityTemplate, error) {
+	p := &velocityParser{name: name, src: src}
+	nodes, end, err := p.parseNodes()
+	if err != nil {
+		return nil, err
+	}
+	if end != nil {
+		return nil, p.errorf("unexpected #%s", end.name)
+	}
+	return &velocityTemplate{name: name, nodes: nodes}, nil
+}
+
+// renderVelocityTemplate parses and renders a template with the variables
+func renderVelocityTemplate(name, src string, variables map[string]interface{}) (string, error) {
+	tmpl, err := parseVelocityTemplate(name, src)
+	if err != nil {
+		return "", err
+	}
+	return tmpl.render(variables)
+}
+
+func (p *velocityParser) errorf(format string, args ...interface{}) error {
+	line := strings.Count(p.src[:min(p.pos, len(p.src))], "\n") + 1
+	return fmt.Errorf("%s: line %d: %s", p.name, line, fmt.Sprintf(format, args...))
+}
+
+// parseNodes parses nodes until the end of the template or an #elseif, #else or #end directive, which is returned
+func (p *velocityParser) parseNodes() ([]velocityNode, *velocityDirective, error) {
+	var (
+		nodes []velocityNode
+		text  strings.Builder
+	)
+	flushText := func() {
+		if text.Len() > 0 {
+			nodes = append(nodes, &velocityText{text: text.String()})
+			text.Reset()
+		}
+	}
+
+	for p.pos < len(p.src) {
+		rest := p.src[p.pos:]
+		switch {
+		case strings.HasPrefix(rest, `\$`) || strings.HasPrefix(rest, `\#`):
+			text.WriteByte(rest[1])
+			p.pos += 2
+			continue
+		case strings.HasPrefix(rest, "##"):
+			end := strings.IndexByte(rest, '\n')
+			if end < 0 {
+				end = len(rest) - 1
+			}
+			p.pos += end + 1
+			continue
+		case strings.HasPrefix(rest, "#*"):
+			end := strings.Index(rest[2:], "*#")
+			if end < 0 {
+				return nil, nil, p.errorf("unterminated #* comment")
+			}
+			p.pos += end + 4
+			continue
+		case rest[0] == '#':
+			name, length := p.peekDirective()
+			if name == "" {
+				break
+			}
+			lineStart := strings.LastIndexByte(p.src[:p.pos], '\n') + 1
+			leading := p.src[lineStart:p.pos]
+
+			p.pos += length
+			directive, err := p.parseDirectiveHeader(name)
+			if err != nil {
+				return nil, nil, err
+			}
+			// A line holding only a directive does not produce output
+			if strings.TrimSpace(leading) == "" && p.restOfLineBlank() {
+				content := text.String()
+				text.Reset()
+				text.WriteString(content[:len(content)-len(leading)])
+				p.skipRestOfLine()
+			}
+			flushText()
+
+			switch name {
+			case "elseif", "else", "end":
+				return nodes, directive, nil
+			case "set":
+				nodes = append(nodes, &velocitySet{target: directive.target, value: directive.expr})
+			case "if":
+				node, err := p.parseIfBody(directive.expr)
+				if err != nil {
+					return nil, nil, err
+				}
+				nodes = append(nodes, node)
+			case "foreach":
+				body, end, err := p.parseNodes()
+				if err != nil {
+					return nil, nil, err
+				}
+				if end == nil || end.name != "end" {
+					return nil, nil, p.errorf("#foreach without #end")
+				}
+				nodes = append(nodes, &velocityForeach{variable: directive.variable, collection: directive.expr, body: body})
+			}
+			continue
+		case rest[0] == '$':
+			ref, err := p.parseReference()
+			if err != nil {
+				return nil, nil, err
+			}
+			if ref != nil {
+				flushText()
+				nodes = append(nodes, ref)
+				continue
+			}
+		}
+		text.WriteByte(p.src[p.pos])
+		p.pos++
+	}
+	flushText()
+	return nodes, nil, nil
+}
+
+func (p *velocityParser) parseIfBody(condition velocityExpr) (*velocityIf, error) {
+	node := &velocityIf{}
+	for {
+		body, end, err := p.parseNodes()
+		if err != nil {
+			return nil, err
+		}
+		if end == nil {
+			return nil, p.errorf("#if without #end")
+		}
+		node.conditions = append(node.conditions, condition)
+		node.bodies = append(node.bodies, body)
+
+		switch end.name {
+		case "elseif":
+			condition = end.expr
+		case "else":
+			elseBody, elseEnd, err := p.parseNodes()
+			if err != nil {
+				return nil, err
+			}
+			if elseEnd == nil || elseEnd.name != "end" {
+				return nil, p.errorf("#else without #end")
+			}
+			node.elseBody = elseBody
+			return node, nil
+		default:
+			return node, nil
+		}
+	}
+}
+
+// peekDirective returns the name and length of the directive at the current position, like #if or #{else}
+func (p *velocityParser) peekDirective() (string, int) {
+	i := p.pos + 1
+	braced := i < len(p.src) && p.src[i] == '{'
+	if braced {
+		i++
+	}
+	name := readVelocityIdentifier(p.src[i:])
+	if !velocityDirectives[name] {
+		return "", 0
+	}
+	i += len(name)
+	if braced {
+		if i >= len(p.src) || p.src[i] != '}' {
+			return "", 0
+		}
+		i++
+	}
+	return name, i - p.pos
+}
+
+func (p *velocityParser) parseDirectiveHeader(name string) (*velocityDirective, error) {
+	directive := &velocityDirective{name: name}
+	if name == "else" || name == "end" {
+		return directive, nil
+	}
+
+	p.skipSpaces()
+	if !p.consume("(") {
+		return nil, p.errorf("expected ( after #%s", name)
+	}
+	p.skipSpaces()
+
+	var err error
+	switch name {
+	case "if", "elseif":
+		directive.expr, err = p.parseExpr()
+	case "foreach":
+		var variable *velocityReference
+		variable, err = p.parseReference()
+		if err == nil && (variable == nil || len(variable.segments) > 0) {
+			return nil, p.errorf("expected a variable in #foreach")
+		}
+		if err != nil {
+			return nil, err
+		}
+		directive.variable = variable.name
+		p.skipSpaces()
+		if !p.consumeWord("in") {
+			return nil, p.errorf("expected 'in' in #foreach")
+		}
+		p.skipSpaces()
+		directive.expr, err = p.parseExpr()
+	case "set":
+		directive.target, err = p.parseReference()
+		if err == nil && directive.target == nil {
+			return nil, p.errorf("expected a reference in #set")
+		}
+		if err != nil {
+			return nil, err
+		}
+		p.skipSpaces()
+		if !p.consume("=") {
+			return nil, p.errorf("expected = in #set")
+		}
+		p.skipSpaces()
+		directive.expr, err = p.parseExpr()
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	p.skipSpaces()
+	if !p.consume(")") {
+		return nil, p.errorf("expected ) to close #%s", name)
+	}
+	return directive, nil
+}
+
+func (p *velocityParser) restOfLineBlank() bool {
+	for i := p.pos; i < len(p.src) && p.src[i] != '\n'; i++ {
+		if p.src[i] != ' ' && p.src[i] != '\t' && p.src[i] != '\r' {
+			return false
+		}
+	}
+	return true
+}
+
+func (p *velocityParser) skipRestOfLine() {
+	for p.pos < len(p.src) && p.src[p.pos] != '\n' {
+		p.pos++
+	}
+	if p.pos < len(p.src) {
+		p.pos++
+	}
+}
+
+func (p *velocityParser) skipSpaces() {
+	for p.pos < len(p.src) && strings.IndexByte(" \t\r\n", p.src[p.pos]) >= 0 {
+		p.pos++
+	}
+}
+
+func (p *velocityParser) consume(token string) bool {
+	if strings.HasPrefix(p.src[p.pos:], token) {
+		p.pos += len(token)
+		return true
+	}
+	return false
+}
+
+// consumeWord consumes a keyword which is not followed by an identifier character
+func (p *velocityParser) consumeWord(word string) bool {
+	end := p.pos + len(word)
+	if !strings.HasPrefix(p.src[p.pos:], word) || (end < len(p.src) && isVelocityIdentifierChar(p.src[end])) {
+		return false
+	}
+	p.pos = end
+	return true
+}
+
+// parseReference parses the reference at the current position. It returns nil if the $ does not start a reference.
+func (p *velocityParser) parseReference() (*velocityReference, error) {
+	start := p.pos
+	i := p.pos + 1
+	quiet := i < len(p.src) && p.src[i] == '!'
+	if quiet {
+		i++
+	}
+	braced := i < len(p.src) && p.src[i] == '{'
+	if braced {
+		i++
+	}
+	name := readVelocityIdentifier(p.src[i:])
+	if name == "" || !isVelocityIdentifierStart(name[0]) {
+		return nil, nil
+	}
+
+	ref := &velocityReference{quiet: quiet, name: name}
+	p.pos = i + len(name)
+	for p.pos < len(p.src) {
+		if p.src[p.pos] == '.' && p.pos+1 < len(p.src) && isVelocityIdentifierStart(p.src[p.pos+1]) {
+			p.pos++
+			segment := velocitySegment{name: readVelocityIdentifier(p.src[p.pos:])}
+			p.pos += len(segment.name)
+			if p.pos < len(p.src) && p.src[p.pos] == '(' {
+				p.pos++
+				args, err := p.parseExprList(")")
+				if err != nil {
+					return nil, err
+				}
+				segment.isMethod = true
+				segment.args = args
+			}
+			ref.segments = append(ref.segments, segment)
+			continue
+		}
+		if p.src[p.pos] == '[' {
+			p.pos++
+			p.skipSpaces()
+			index, err := p.parseExpr()
+			if err != nil {
+				return nil, err
+			}
+			p.skipSpaces()
+			if !p.consume("]") {
+				return nil, p.errorf("expected ] in reference %s", p.src[start:p.pos])
+			}
+			ref.segments = append(ref.segments, velocitySegment{index: index})
+			continue
+		}
+		break
+	}
+
+	if braced && !p.consume("}") {
+		// Like Velocity, an unterminated ${ is rendered as text
+		p.pos = start
+		return nil, nil
+	}
+	ref.raw = p.src[start:p.pos]
+	return ref, nil
+}
+
+// parseExprList parses expressions separated by commas up to the closing token
+func (p *velocityParser) parseExprList(closing string) ([]velocityExpr, error) {
+	var exprs []velocityExpr
+	p.skipSpaces()
+	if p.consume(closing) {
+		return exprs, nil
+	}
+	for {
+		p.skipSpaces()
+		expr, err := p.parseExpr()
+		if err != nil {
+			return nil, err
+		}
+		exprs = append(exprs, expr)
+		p.skipSpaces()
+		if p.consume(closing) {
+			return exprs, nil
+		}
+		if !p.consume(",") {
+			return nil, p.errorf("expected , or %s", closing)
+		}
+	}
+}
+
+func (p *velocityParser) parseExpr() (velocityExpr, error) {
+	return p.parseBinary(0)
+}
+
+// velocityOperators lists the binary operators by increasing precedence
+var velocityOperators = [][]string{
+	{"||", "or"},
+	{"&&", "and"},
+	{"==", "!=", "eq", "ne"},
+	{"<=", ">=", "<", ">", "le", "ge", "lt", "gt"},
+}
+
+var velocityWordOperators = map[string]string{"or": "||", "and": "&&", "eq": "==", "ne": "!=", "le": "<=", "ge": ">=", "lt": "<", "gt": ">"}
+
+func (p *velocityParser) parseBinary(level int) (velocityExpr, error) {
+	if level == len(velocityOperators) {
+		return p.parseUnary()
+	}
+	left, err := p.parseBinary(level + 1)
+	if err != nil {
+		return nil, err
+	}
+	for {
+		p.skipSpaces()
+		op := ""
+		for _, candidate := range velocityOperators[level] {
+			if _, isWord := velocityWordOperators[candidate]; isWord {
+				if p.consumeWord(candidate) {
+					op = velocityWordOperators[candidate]
+					break
+				}
+			} else if p.consume(candidate) {
+				op = candidate
+				break
+			}
+		}
+		if op == "" {
+			if p.pos < len(p.src) && strings.IndexByte("+-*/%", p.src[p.pos]) >= 0 {
+				return nil, p.errorf("arithmetic operator %s is not supported, use $math", string(p.src[p.pos]))
+			}
+			return left, nil
+		}
+		p.skipSpaces()
+		right, err := p.parseBinary(level + 1)
+		if err != nil {
+			return nil, err
+		}
+		left = &velocityBinary{op: op, left: left, right: right}
+	}
+}
+
+func (p *velocityParser) parseUnary() (velocityExpr, error) {
+	p.skipSpaces()
+	if p.consume("!") || p.consumeWord("not") {
+		operand, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		return &velocityNot{operand: operand}, nil
+	}
+	if p.pos < len(p.src) && p.src[p.pos] == '-' && (p.pos+1 == len(p.src) || !isDigit(p.src[p.pos+1])) {
+		return nil, p.errorf("arithmetic operator - is not supported, use $math")
+	}
+	return p.parsePrimary()
+}
+
+func (p *velocityParser) parsePrimary() (velocityExpr, error) {
+	if p.pos >= len(p.src) {
+		return nil, p.errorf("unexpected end of template")
+	}
+	c := p.src[p.pos]
+	switch {
+	case c == '(':
+		p.pos++
+		p.skipSpaces()
+		expr, err := p.parseExpr()
+		if err != nil {
+			return nil, err
+		}
+		p.skipSpaces()
+		if !p.consume(")") {
+			return nil, p.errorf("expected )")
+		}
+		return expr, nil
+	case c == '"':
+		return p.parseDoubleQuoted()
+	case c == '\'':
+		end := strings.IndexByte(p.src[p.pos+1:], '\'')
+		if end < 0 {
+			return nil, p.errorf("unterminated string")
+		}
+		value := p.src[p.pos+1 : p.pos+1+end]
+		p.pos += end + 2
+		return &velocityLiteral{value: value}, nil
+	case isDigit(c) || c == '-':
+		start := p.pos
+		p.pos++
+		for p.pos < len(p.src) && (isDigit(p.src[p.pos]) || p.src[p.pos] == '.' && p.pos+1 < len(p.src) && isDigit(p.src[p.pos+1])) {
+			p.pos++
+		}
+		number := p.src[start:p.pos]
+		if i, err := strconv.ParseInt(number, 10, 64); err == nil {
+			return &velocityLiteral{value: i}, nil
+		}
+		f, err := strconv.ParseFloat(number, 64)
+		if err != nil {
+			return nil, p.errorf("invalid number %s", number)
+		}
+		return &velocityLiteral{value: f}, nil
+	case c == '[' || c == '{':
+		return nil, p.errorf("list, map and range literals are not supported")
+	case c == '$':
+		ref, err := p.parseReference()
+		if err != nil {
+			return nil, err
+		}
+		if ref == nil {
+			return nil, p.errorf("invalid reference")
+		}
+		return ref, nil
+	case p.consumeWord("true"):
+		return &velocityLiteral{value: true}, nil
+	case p.consumeWord("false"):
+		return &velocityLiteral{value: false}, nil
+	case p.consumeWord("null"):
+		return &velocityLiteral{value: nil}, nil
+	}
+	return nil, p.errorf("unexpected %q in expression", string(c))
+}
+
+// parseDoubleQuoted parses a double quoted string. References and directives inside it are rendered when it is evaluated.
+func (p *velocityParser) parseDoubleQuoted() (velocityExpr, error) {
+	var content strings.Builder
+	i := p.pos + 1
+	for {
+		if i >= len(p.src) {
+			return nil, p.errorf("unterminated string")
+		}
+		c := p.src[i]
+		if c == '\\' && i+1 < len(p.src) && p.src[i+1] == '"' {
+			content.WriteByte('"')
+			i += 2
+			continue
+		}
+		if c == '"' {
+			// Velocity escapes double quotes by doubling them
+			if i+1 < len(p.src) && p.src[i+1] == '"' {
+				content.WriteByte('"')
+				i += 2
+				continue
+			}
+			break
+		}
+		content.WriteByte(c)
+		i++
+	}
+	p.pos = i + 1
+
+	value := content.String()
+	if !strings.ContainsAny(value, "$#") {
+		return &velocityLiteral{value: value}, nil
+	}
+	inner := &velocityParser{name: p.name, src: value}
+	nodes, end, err := inner.parseNodes()
+	if err != nil {
+		return nil, err
+	}
+	if end != nil {
+		return nil, p.errorf("unexpected #%s in string", end.name)
+	}
+	return &velocityInterpolated{nodes: nodes}, nil
+}
+
+func readVelocityIdentifier(s string) string {
+	i := 0
+	for i < len(s) && isVelocityIdentifierChar(s[i]) {
+		i++
+	}
+	return s[:i]
+}
+
+func isVelocityIdentifierStart(c byte) bool {
+	return c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c == '_'
+}
+
+func isVelocityIdentifierChar(c byte) bool {
+	return isVelocityIdentifierStart(c) || isDigit(c)
+}
+
+func isDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
+
+// velocityContext holds the variables of a rendering
+type velocityContext struct {
+	name string
+	vars map[string]interface{}
+}
+
+type velocityEscTool struct{}
+type velocityMathTool struct{}
+type velocitySuccessTemplateUtils struct{}
+
+func (t *velocityTemplate) render(variables map[string]interface{}) (string, error) {
+	ctx := &velocityContext{name: t.name, vars: map[string]interface{}{
+		"esc":                  velocityEscTool{},
+		"math":                 velocityMathTool{},
+		"successTemplateUtils": velocitySuccessTemplateUtils{},
+	}}
+	for k, v := range variables {
+		ctx.vars[k] = v
+	}
+
+	var out strings.Builder
+	if err := ctx.renderNodes(t.nodes, &out); err != nil {
+		return "", err
+	}
+	return out.String(), nil
+}
+
+func (ctx *velocityContext) renderNodes(nodes []velocityNode, out *strings.Builder) error {
+	for _, node := range nodes {
+		switch n := node.(type) {
+		case *velocityText:
+			out.WriteString(n.text)
+		case *velocityReference:
+			value, err := ctx.evalReference(n)
+			if err != nil {
+				return err
+			}
+			if value == nil {
+				if !n.quiet {
+					out.WriteString(n.raw)
+				}
+				continue
+			}
+			out.WriteString(velocityString(value))
+		case *velocitySet:
+			value, err := ctx.eval(n.value)
+			if err != nil {
+				return err
+			}
+			if err := ctx.assign(n.target, value); err != nil {
+				return err
+			}
+		case *velocityIf:
+			rendered := false
+			for i, condition := range n.conditions {
+				value, err := ctx.eval(condition)
+				if err != nil {
+					return err
+				}
+				if velocityTruthy(value) {
+					if err := ctx.renderNodes(n.bodies[i], out); err != nil {
+						return err
+					}
+					rendered = true
+					break
+				}
+			}
+			if !rendered {
+				if err := ctx.renderNodes(n.elseBody, out); err != nil {
+					return err
+				}
+			}
+		case *velocityForeach:
+			if err := ctx.renderForeach(n, out); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (ctx *velocityContext) renderForeach(n *velocityForeach, out *strings.Builder) error {
+	collection, err := ctx.eval(n.collection)
+	if err != nil {
+		return err
+	}
+
+	var items []interface{}
+	switch c := collection.(type) {
+	case nil:
+	case []interface{}:
+		items = c
+	case map[string]interface{}:
+		// Like Velocity, iterating a map iterates its values
+		for _, key := range sortedKeys(c) {
+			items = append(items, c[key])
+		}
+	default:
+		items = []interface{}{c}
+	}
+	if len(items) > maxVelocityIterations {
+		return fmt.Errorf("%s: #foreach over %d items exceeds the limit of %d", ctx.name, len(items), maxVelocityIterations)
+	}
+
+	saved := make(map[string]interface{})
+	for _, name := range []string{n.variable, "foreach", "velocityCount"} {
+		if value, ok := ctx.vars[name]; ok {
+			saved[name] = value
+		}
+	}
+	defer func() {
+		for _, name := range []string{n.variable, "foreach", "velocityCount"} {
+			if value, ok := saved[name]; ok {
+				ctx.vars[name] = value
+			} else {
+				delete(ctx.vars, name)
+			}
+		}
+	}()
+
+	for i, item := range items {
+		ctx.vars[n.variable] = item
+		ctx.vars["velocityCount"] = int64(i + 1)
+		ctx.vars["foreach"] = map[string]interface{}{
+			"index":   int64(i),
+			"count":   int64(i + 1),
+			"hasNext": i < len(items)-1,
+			"first":   i == 0,
+			"last":    i == len(items)-1,
+		}
+		if err := ctx.renderNodes(n.body, out); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (ctx *velocityContext) assign(target *velocityReference, value interface{}) error {
+	if len(target.segments) == 0 {
+		ctx.vars[target.name] = value
+		return nil
+	}
+
+	parentRef := &velocityReference{name: target.name, segments: target.segments[:len(target.segments)-1]}
+	parent, err := ctx.evalReference(parentRef)
+	if err != nil {
+		return err
+	}
+	last := target.segments[len(target.segments)-1]
+	object, ok := parent.(map[string]interface{})
+	if !ok || last.isMethod || last.index != nil {
+		return fmt.Errorf("%s: cannot set %s", ctx.name, target.raw)
+	}
+	object[last.name] = value
+	return nil
+}
+
+func (ctx *velocityContext) eval(expr velocityExpr) (interface{}, error) {
+	switch e := expr.(type) {
+	case *velocityLiteral:
+		return e.value, nil
+	case *velocityReference:
+		return ctx.evalReference(e)
+	case *velocityInterpolated:
+		var out strings.Builder
+		if err := ctx.renderNodes(e.nodes, &out); err != nil {
+			return nil, err
+		}
+		return out.String(), nil
+	case *velocityNot:
+		operand, err := ctx.eval(e.operand)
+		if err != nil {
+			return nil, err
+		}
+		return !velocityTruthy(operand), nil
+	case *velocityBinary:
+		left, err := ctx.eval(e.left)
+		if err != nil {
+			return nil, err
+		}
+		// && and || short-circuit
+		switch e.op {
+		case "&&":
+			if !velocityTruthy(left) {
+				return false, nil
+			}
+		case "||":
+			if velocityTruthy(left) {
+				return true, nil
+			}
+		}
+		right, err := ctx.eval(e.right)
+		if err != nil {
+			return nil, err
+		}
+		switch e.op {
+		case "&&", "||":
+			return velocityTruthy(right), nil
+		case "==":
+			return velocityEqual(left, right), nil
+		case "!=":
+			return !velocityEqual(left, right), nil
+		default:
+			comparison, err := velocityCompare(left, right)
+			if err != nil {
+				return nil, fmt.Errorf("%s: %w", ctx.name, err)
+			}
+			switch e.op {
+			case "<":
+				return comparison < 0, nil
+			case "<=":
+				return comparison <= 0, nil
+			case ">":
+				return comparison > 0, nil
+			default:
+				return comparison >= 0, nil
+			}
+		}
+	}
+	return nil, fmt.Errorf("%s: unsupported expression %T", ctx.name, expr)
+}
+
+func (ctx *velocityContext) evalReference(ref *velocityReference) (interface{}, error) {
+	value := ctx.vars[ref.name]
+	for _, segment := range ref.segments {
+		if value == nil {
+			return nil, nil
+		}
+		switch {
+		case segment.isMethod:
+			args := make([]interface{}, len(segment.args))
+			for i, arg := range segment.args {
+				argValue, err := ctx.eval(arg)
+				if err != nil {
+					return nil, err
+				}
+				args[i] = argValue
+			}
+			result, err := callVelocityMethod(value, segment.name, args)
+			if err != nil {
+				return nil, fmt.Errorf("%s: %s: %w", ctx.name, ref.raw, err)
+			}
+			value = result
+		case segment.index != nil:
+			index, err := ctx.eval(segment.index)
+			if err != nil {
+				return nil, err
+			}
+			value = velocityIndex(value, index)
+		default:
+			value = velocityProperty(value, segment.name)
+		}
+	}
+	return value, nil
+}
+
+func velocityProperty(value interface{}, name string) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		return v[name]
+	}
+	return nil
+}
+
+func velocityIndex(value interface{}, index interface{}) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		return v[velocityString(index)]
+	case []interface{}:
+		number, isInteger, ok := velocityNumber(index)
+		if !ok || !isInteger {
+			return nil
+		}
+		i := int(number)
+		if i < 0 {
+			i += len(v)
+		}
+		if i < 0 || i >= len(v) {
+			return nil
+		}
+		return v[i]
+	}
+	return nil
+}
+
+func callVelocityMethod(value interface{}, name string, args []interface{}) (interface{}, error) {
+	expectArgs := func(count ...int) error {
+		for _, c := range count {
+			if len(args) == c {
+				return nil
+			}
+		}
+		return fmt.Errorf("wrong number of arguments for %s: %d", name, len(args))
+	}
+
+	switch v := value.(type) {
+	case velocityEscTool:
+		if err := expectArgs(1); err != nil {
+			return nil, err
+		}
+		if args[0] == nil {
+			return nil, nil
+		}
+		s := velocityString(args[0])
+		switch name {
+		case "jsonString", "jsonEncode", "java", "javascript":
+			return jsonEscape(s), nil
+		case "url":
+			return url.QueryEscape(s), nil
+		case "xml", "html":
+			return html.EscapeString(s), nil
+		}
+	case velocityMathTool:
+		return callVelocityMathMethod(name, args)
+	case velocitySuccessTemplateUtils:
+		if name == "firstFromArray" {
+			if err := expectArgs(1, 2); err != nil {
+				return nil, err
+			}
+			defaultValue := ""
+			if len(args) == 2 {
+				defaultValue = velocityString(args[1])
+			}
+			var items []json.RawMessage
+			if err := json.Unmarshal([]byte(velocityString(args[0])), &items); err != nil || len(items) == 0 {
+				return defaultValue, nil
+			}
+			return string(items[0]), nil
+		}
+	case string:
+		switch name {
+		case "length":
+			return int64(len([]rune(v))), expectArgs(0)
+		case "isEmpty":
+			return v == "", expectArgs(0)
+		}
+	case []interface{}:
+		switch name {
+		case "size":
+			return int64(len(v)), expectArgs(0)
+		case "isEmpty":
+			return len(v) == 0, expectArgs(0)
+		}
+	case map[string]interface{}:
+		switch name {
+		case "size":
+			return int64(len(v)), expectArgs(0)
+		case "isEmpty":
+			return len(v) == 0, expectArgs(0)
+		}
+	}
+	return nil, fmt.Errorf("unsupported method %s", name)
+}
+
+func callVelocityMathMethod(name string, args []interface{}) (interface{}, error) {
+	numbers := make([]float64, len(args))
+	allIntegers := true
+	for i, arg := range args {
+		number, isInteger, ok := velocityNumber(arg)
+		if !ok {
+			if s, isString := arg.(string); isString {
+				if parsed, err := strconv.ParseFloat(strings.TrimSpace(s), 64); err == nil {
+					number, isInteger, ok = parsed, parsed == math.Trunc(parsed) && !strings.Contains(s, "."), true
+				}
+			}
+		}
+		if !ok {
+			return nil, nil
+		}
+		numbers[i] = number
+		allIntegers = allIntegers && isInteger
+	}
+
+	switch name {
+	case "add", "sub", "mul", "div", "mod", "max", "min":
+		if len(numbers) != 2 {
+			return nil, fmt.Errorf("wrong number of arguments for %s: %d", name, len(numbers))
+		}
+		ops := map[string]string{"add": "+", "sub": "-", "mul": "*", "div": "/", "mod": "%"}
+		if op, ok := ops[name]; ok {
+			return velocityArithmetic(op, velocityNumberValue(numbers[0], allIntegers), velocityNumberValue(numbers[1], allIntegers))
+		}
+		if (name == "max") == (numbers[0] > numbers[1]) {
+			return velocityNumberValue(numbers[0], allIntegers), nil
+		}
+		return velocityNumberValue(numbers[1], allIntegers), nil
+	case "abs", "round", "floor", "ceil", "toNumber", "toInteger":
+		if len(numbers) != 1 {
+			return nil, fmt.Errorf("wrong number of arguments for %s: %d", name, len(numbers))
+		}
+		switch name {
+		case "abs":
+			return velocityNumberValue(math.Abs(numbers[0]), allIntegers), nil
+		case "round":
+			return int64(math.Round(numbers[0])), nil
+		case "floor":
+			return int64(math.Floor(numbers[0])), nil
+		case "ceil":
+			return int64(math.Ceil(numbers[0])), nil
+		case "toInteger":
+			return int64(numbers[0]), nil
+		default:
+			return velocityNumberValue(numbers[0], allIntegers), nil
+		}
+	}
+	return nil, fmt.Errorf("unsupported method %s", name)
+}
+
+func velocityNumberValue(number float64, isInteger bool) interface{} {
+	if isInteger {
+		return int64(number)
+	}
+	return number
+}
+
+// velocityNumber returns the value of a number and whether it is an integer
+func velocityNumber(value interface{}) (float64, bool, bool) {
+	switch v := value.(type) {
+	case int64:
+		return float64(v), true, true
+	case int:
+		return float64(v), true, true
+	case float64:
+		return v, false, true
+	case json.Number:
+		if i, err := v.Int64(); err == nil {
+			return float64(i), true, true
+		}
+		f, err := v.Float64()
+		return f, false, err == nil
+	}
+	return 0, false, false
+}
+
+func velocityArithmetic(op string, left, right interface{}) (interface{}, error) {
+	leftNumber, leftInteger, leftOk := velocityNumber(left)
+	rightNumber, rightInteger, rightOk := velocityNumber(right)
+	if !leftOk || !rightOk {
+		return nil, fmt.Errorf("cannot apply %s to %s and %s", op, velocityString(left), velocityString(right))
+	}
+
+	if leftInteger && rightInteger {
+		l, r := int64(leftNumber), int64(rightNumber)
+		switch op {
+		case "+":
+			return l + r, nil
+		case "-":
+			return l - r, nil
+		case "*":
+			return l * r, nil
+		}
+		if r == 0 {
+			return nil, fmt.Errorf("division by zero")
+		}
+		if op == "/" {
+			return l / r, nil
+		}
+		return l % r, nil
+	}
+
+	switch op {
+	case "+":
+		return leftNumber + rightNumber, nil
+	case "-":
+		return leftNumber - rightNumber, nil
+	case "*":
+		return leftNumber * rightNumber, nil
+	case "/":
+		return leftNumber / rightNumber, nil
+	}
+	return math.Mod(leftNumber, rightNumber), nil
+}
+
+func velocityEqual(left, right interface{}) bool {
+	if left == nil || right == nil {
+		return left == nil && right == nil
+	}
+	leftNumber, _, leftOk := velocityNumber(left)
+	rightNumber, _, rightOk := velocityNumber(right)
+	if leftOk && rightOk {
+		return leftNumber == rightNumber
+	}
+	return velocityString(left) == velocityString(right)
+}
+
+func velocityCompare(left, right interface{}) (int, error) {
+	leftNumber, _, leftOk := velocityNumber(left)
+	rightNumber, _, rightOk := velocityNumber(right)
+	if leftOk && rightOk {
+		switch {
+		case leftNumber < rightNumber:
+			return -1, nil
+		case leftNumber > rightNumber:
+			return 1, nil
+		}
+		return 0, nil
+	}
+	leftString, leftIsString := left.(string)
+	rightString, rightIsString := right.(string)
+	if leftIsString && rightIsString {
+		return strings.Compare(leftString, rightString), nil
+	}
+	return 0, fmt.Errorf("cannot compare %s and %s", velocityString(left), velocityString(right))
+}
+
+// velocityTruthy follows the rules of Velocity 2: null, false, empty strings, empty collections and zero are false
+func velocityTruthy(value interface{}) bool {
+	switch v := value.(type) {
+	case nil:
+		return false
+	case bool:
+		return v
+	case string:
+		return v != ""
+	case []interface{}:
+		return len(v) > 0
+	case map[string]interface{}:
+		return len(v) > 0
+	}
+	if number, _, ok := velocityNumber(value); ok {
+		return number != 0
+	}
+	return true
+}
+
+// velocityString renders a value. Objects and lists are rendered as JSON.
+func velocityString(value interface{}) string {
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case bool:
+		return strconv.FormatBool(v)
+	case json.Number:
+		return v.String()
+	case int64:
+		return strconv.FormatInt(v, 10)
+	case int:
+		return strconv.Itoa(v)
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	}
+	return encodeJson(value)
+}
+
+// encodeJson encodes a value as JSON without escaping HTML characters
+func encodeJson(value interface{}) string {
+	var buffer bytes.Buffer
+	encoder := json.NewEncoder(&buffer)
+	encoder.SetEscapeHTML(false)
+	if err := encoder.Encode(value); err != nil {
+		return fmt.Sprint(value)
+	}
+	return strings.TrimSuffix(buffer.String(), "\n")
+}
+
+// jsonEscape escapes a string for a JSON string literal, without the surrounding quotes
+func jsonEscape(s string) string {
+	encoded := encodeJson(s)
+	return encoded[1 : len(encoded)-1]
+}
+
+func sortedKeys(m map[string]interface{}) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,181 @@
+package integration_action
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUnitRenderVelocityTemplate(t *testing.T) {
+	input, err := decodeJson(`{"name": "Ann \"A\" Lee", "age": 42, "tags": ["a", "b", "c"], "address": {"city": "Paris"}, "empty": ""}`)
+	assert.Nil(t, err)
+	variables := map[string]interface{}{"input": input}
+
+	testCases := []struct {
+		name     string
+		template string
+		expected string
+	}{
+		{"reference", "Hello ${input.name}", `Hello Ann "A" Lee`},
+		{"json string", `{"name": "$esc.jsonString(${input.name})"}`, `{"name": "Ann \"A\" Lee"}`},
+		{"url", "/users?city=$esc.url($input.address.city)&q=$esc.url('a b')", "/users?city=Paris&q=a+b"},
+		{"object as json", "${input.address}", `{"city":"Paris"}`},
+		{"index and methods", "${input.tags[1]} ${input.tags.size()} ${input.name.length()} $input.empty.isEmpty()", `b 3 11 true`},
+		{"undefined", "$input.missing|$!input.missing|${input.missing}|$!{input.missing}", "$input.missing||${input.missing}|"},
+		{"escapes and literal dollars", `\$input.name costs $5 #notadirective`, `$input.name costs $5 #notadirective`},
+		{"comments", "a## line comment\nb#* block\ncomment *#c", "abc"},
+		{"if", "#if($input.age >= 18 && $input.empty == '')adult#{else}minor#end", "adult"},
+		{"elseif", "#if($input.age < 18)minor#elseif($input.age < 65)adult#else senior#end", "adult"},
+		{"truthiness", "#if($input.empty)x#end#if(!$input.missing)y#end#if($input.tags)z#end", "yz"},
+		{"foreach", "[#foreach($tag in $input.tags)\"$tag\"#if($foreach.hasNext),#end#end]", `["a","b","c"]`},
+		{"foreach count", "#foreach($tag in $input.tags)$foreach.count:$velocityCount #end", "1:1 2:2 3:3 "},
+		{"set and math", "#set($next = $math.add($input.age, 1))#set($half = $math.div($input.age, 4))#set($ratio = $math.div($input.age, 4.0))$next $half $ratio", "43 10 10.5"},
+		{"string interpolation", `#set($greeting = "Hi ${input.address.city}!")$greeting`, "Hi Paris!"},
+		{"math", "$math.add(1, 2) $math.max($input.age, 50) $math.round(2.6) $math.toInteger('7')", "3 50 3 7"},
+		{"directive lines", "[\n#foreach($tag in $input.tags)\n  $tag\n#end\n]", "[\n  a\n  b\n  c\n]"},
+		{"first from array", `$successTemplateUtils.firstFromArray('[{"id":1},{"id":2}]') $successTemplateUtils.firstFromArray('[]', 'none')`, `{"id":1} none`},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			output, err := renderVelocityTemplate(testCase.name, testCase.template, variables)
+			if assert.Nil(t, err) {
+				assert.Equal(t, testCase.expected, output)
+			}
+		})
+	}
+
+	for _, template := range []string{"#if($input.age)", "#foreach($x $input.tags)#end", "#end", "$input.name.unknownMethod()", "#set($x = $math.div(1, 0))", "#set($x = $input.age + 1)", "#set($x = [1, 2])"} {
+		_, err := renderVelocityTemplate("invalid", template, variables)
+		assert.NotNil(t, err, template)
+	}
+}
+
+func TestUnitRenderVelocityTemplateSyntax(t *testing.T) {
+	input, err := decodeJson(`{"name": "Ann", "items": [{"id": 1}, {"id": 2}], "scores": {"b": 2, "a": 1}, "zero": 0, "settable": {}}`)
+	assert.Nil(t, err)
+	variables := map[string]interface{}{"input": input}
+
+	testCases := []struct {
+		name     string
+		template string
+		expected string
+	}{
+		{"braced directives", "#{if}($input.name)yes#{else}no#{end}", "yes"},
+		{"braced reference before text", "${input.name}s $input.names", "Anns $input.names"},
+		{"quiet braced", "[$!{input.missing.id}]", "[]"},
+		{"unterminated braced reference", "${input.name", "${input.name"},
+		{"not a reference", "$1 $ $.name #1 #unknown", "$1 $ $.name #1 #unknown"},
+		{"trailing dot", "$input.name.", "Ann."},
+		{"escaped directive", `\#if(true)x\#end`, "#if(true)x#end"},
+		{"line comment at end", "a## comment", "a"},
+		{"double quoted escapes", `#set($s = "say ""hi"" and \"bye\"")$s`, `say "hi" and "bye"`},
+		{"single quoted literal", `#set($s = '$input.name')$s`, "$input.name"},
+		{"interpolated directive", `#set($s = "#if($input.zero)non zero#{else}zero#end")$s`, "zero"},
+		{"word operators", "#if($input.zero eq 0 and not ($input.name ne 'Ann') or false)ok#end", "ok"},
+		{"word comparisons", "#if(1 lt 2 && 2 le 2 && 3 gt 2 && 3 ge 3)ok#end", "ok"},
+		{"parentheses", "#if(($input.zero == 1 || true) && !(false))ok#end", "ok"},
+		{"negative numbers", "#set($x = -5)#if($x < -1.5)$x#end", "-5"},
+		{"string comparison", "#if('abc' < 'abd' && 'b' > 'a')ok#end", "ok"},
+		{"number equality", "#if($input.zero == 0.0 && 1 != 2 && null == $input.missing)ok#end", "ok"},
+		{"string equality", "#if($input.name == 'Ann' && 1 != '1x')ok#end", "ok"},
+		{"short circuit", "#if(false && $input.name.unknownMethod())x#{else}ok#end", "ok"},
+		{"null literal", "#set($x = null)[$!x]", "[]"},
+		{"map values", "#foreach($score in $input.scores)$score#end", "12"},
+		{"scalar and null collections", "#foreach($x in $input.name)[$x]#end#foreach($x in $input.missing)x#end", "[Ann]"},
+		{"nested foreach", "#foreach($a in $input.items)#foreach($b in $input.scores)$a.id$b$foreach.index #end$foreach.count;#end", "110 121 1;210 221 2;"},
+		{"foreach restores variables", "#set($item = 'kept')#foreach($item in $input.items)#end$item [$!foreach]", "kept []"},
+		{"foreach first and last", "#foreach($item in $input.items)#if($foreach.first)<#end$item.id#if($foreach.last)>#end#end", "<12>"},
+		{"set property", "#set($input.settable.key = 'value')#set($input.settable.scores = $input.scores)$input.settable", `{"key":"value","scores":{"a":1,"b":2}}`},
+		{"negative index", "$input.items[-1].id $!input.items[5].id $!input.items['x']", "2  "},
+		{"map index", "#set($key = 'a')$input.scores[$key] $input.scores['b']", "1 2"},
+		{"no bean properties", "[$!input.items.empty] [$!input.name.empty]", "[] []"},
+		{"list methods", "$input.items.size() $input.items.isEmpty()", "2 false"},
+		{"map methods", "$input.scores.size() $input.scores.isEmpty()", "2 false"},
+		{"string methods", "$input.name.length() $input.name.isEmpty() ' x '.trim()", "3 false ' x '.trim()"},
+		{"unicode length", "#set($s = 'été')$s.length()", "3"},
+		{"escape tools", "$esc.html('<a href=\"x\">') $esc.xml(\"'\") $esc.java('a\nb') $esc.javascript('\"') [$!esc.url($input.missing)]", `&lt;a href=&#34;x&#34;&gt; &#39; a\nb \" []`},
+		{"math tools", "$math.sub(5, 7) $math.mul(2, 2.5) $math.div(7, 2) $math.div(7.0, 2) $math.mod(7, 4) $math.min(1, 2)", "-2 5 3 3.5 3 1"},
+		{"math rounding", "$math.abs(-2) $math.abs(-2.5) $math.floor(2.7) $math.ceil(2.1) $math.toNumber('2.5') $math.toInteger(2.9)", "2 2.5 2 3 2.5 2"},
+		{"math invalid numbers", "[$!math.add('x', 1)] [$!math.toNumber($input.missing)]", "[] []"},
+		{"first from array", "$successTemplateUtils.firstFromArray('not json', 'default') [$successTemplateUtils.firstFromArray('{}')]", "default []"},
+		{"directive lines keep other lines", "  #set($x = 1)\n  text #if(true)inline#end\n#if(true)\n$x\n#end\n", "  text inline\n1\n"},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			output, err := renderVelocityTemplate(testCase.name, testCase.template, variables)
+			if assert.Nil(t, err) {
+				assert.Equal(t, testCase.expected, output)
+			}
+		})
+	}
+}
+
+func TestUnitRenderVelocityTemplateErrors(t *testing.T) {
+	input, err := decodeJson(`{"name": "Ann", "items": [1, 2]}`)
+	assert.Nil(t, err)
+	variables := map[string]interface{}{"input": input}
+
+	testCases := map[string]string{
+		"a #* comment":                           "test: line 1: unterminated #* comment",
+		"#if(true)\nx\n#else\ny":                 "test: line 4: #else without #end",
+		"#foreach($x in $input.items)\nx":        "test: line 2: #foreach without #end",
+		"x\n#else":                               "test: line 2: unexpected #else",
+		"#if true)#end":                          "expected ( after #if",
+		"#if(true#end":                           "expected ) to close #if",
+		"#foreach($x.y in $input.items)#end":     "expected a variable in #foreach",
+		"#foreach($x of $input.items)#end":       "expected 'in' in #foreach",
+		"#set(x = 1)":                            "expected a reference in #set",
+		"#set($x 1)":                             "expected = in #set",
+		"#set($x = 'abc)":                        "unterminated string",
+		`#set($x = "abc)`:                        "unterminated string",
+		"#set($x = [1, 2])":                      "test: line 1: list, map and range literals are not supported",
+		"#foreach($i in [1..3])#end":             "list, map and range literals are not supported",
+		"#set($x = {'a': 1})":                    "list, map and range literals are not supported",
+		"#set($x = $input.items[0)":              "expected ] in reference",
+		"$math.add(1":                            "expected , or )",
+		"#set($x = 1 +)":                         "test: line 1: arithmetic operator + is not supported, use $math",
+		"#set($x = $input.items.size() * 2)":     "arithmetic operator * is not supported, use $math",
+		"#if($input.name == 'a' + 'b')#end":      "arithmetic operator + is not supported, use $math",
+		"#set($x = -$input.items.size())":        "arithmetic operator - is not supported, use $math",
+		"#set($x = ())":                          `unexpected ")" in expression`,
+		"#set($x = ":                             "unexpected end of template",
+		"#set($x = $)":                           "invalid reference",
+		`#set($x = "#end")`:                      "unexpected #end in string",
+		"#if($input.items > 1)#end":              "test: cannot compare [1,2] and 1",
+		"$math.mod(1, 0)":                        "division by zero",
+		"#set($input.items[0] = 1)":              "test: cannot set $input.items[0]",
+		"$input.name.toUpperCase()":              "test: $input.name.toUpperCase(): unsupported method toUpperCase",
+		"$input.name.substring(1)":               "unsupported method substring",
+		"$input.name.length(1)":                  "wrong number of arguments for length: 1",
+		"$input.items.get(0)":                    "unsupported method get",
+		"$input.items.size(1)":                   "wrong number of arguments for size: 1",
+		"$input.items.unknown()":                 "unsupported method unknown",
+		"$esc.url('a', 'b')":                     "wrong number of arguments for url: 2",
+		"$esc.unknown('a')":                      "unsupported method unknown",
+		"$math.add(1)":                           "wrong number of arguments for add: 1",
+		"$math.round(1, 2)":                      "wrong number of arguments for round: 2",
+		"$math.pow(1, 2)":                        "unsupported method pow",
+		"$successTemplateUtils.other()":          "unsupported method other",
+		"$successTemplateUtils.firstFromArray()": "wrong number of arguments for firstFromArray: 0",
+	}
+	for template, expected := range testCases {
+		_, err := renderVelocityTemplate("test", template, variables)
+		assert.ErrorContains(t, err, expected, template)
+	}
+}
+
+func TestUnitVelocityHelpers(t *testing.T) {
+	assert.False(t, velocityTruthy(nil))
+	assert.False(t, velocityTruthy(""))
+	assert.False(t, velocityTruthy([]interface{}{}))
+	assert.False(t, velocityTruthy(map[string]interface{}{}))
+	assert.False(t, velocityTruthy(0.0))
+	assert.True(t, velocityTruthy(int64(-1)))
+	assert.True(t, velocityTruthy(velocityEscTool{}))
+
+	assert.Equal(t, "", velocityString(nil))
+	assert.Equal(t, "1.25", velocityString(1.25))
+	assert.Equal(t, "100000000", velocityString(1e8))
+	assert.Equal(t, `["<a>",{"k":null}]`, velocityString([]interface{}{"<a>", map[string]interface{}{"k": nil}}))
+	assert.Equal(t, `line\nbreak \"quoted\" <tag>`, jsonEscape("line\nbreak \"quoted\" <tag>"))
+}
@@ -18,11 +18,15 @@ resource_genesyscloud_integration_action_schema.go should hold four types of fun
 3.  The datasource schema definitions for the integration_action datasource.
 4.  The resource exporter configuration for the integration_action exporter.
 */
-const ResourceType = "genesyscloud_integration_action"
+const (
+	ResourceType     = "genesyscloud_integration_action"
+	TestResourceType = "genesyscloud_integration_action_test"
+)
 
 // SetRegistrar registers all of the resources, datasources and exporters in the package
 func SetRegistrar(l registrar.Registrar) {
 	l.RegisterDataSource(ResourceType, DataSourceIntegrationAction())
+	l.RegisterDataSource(TestResourceType, DataSourceIntegrationActionTest())
 	l.RegisterResource(ResourceType, ResourceIntegrationAction())
 	l.RegisterExporter(ResourceType, IntegrationActionExporter())
 }
@@ -181,3 +185,189 @@ func DataSourceIntegrationAction() *schema.Resource {
 		},
 	}
 }
+
+// DataSourceIntegrationActionTest registers the genesyscloud_integration_action_test data source
+func DataSourceIntegrationActionTest() *schema.Resource {
+	testConfigRequest := &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"request_url_template": {
+				Description: "Velocity template of the URL of the request.",
+				Type:        schema.TypeString,
+				Required:    true,
+			},
+			"request_type": {
+				Description:  "HTTP method to use for request (GET | PUT | POST | PATCH | DELETE).",
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validation.StringInSlice([]string{"GET", "PUT", "POST", "PATCH", "DELETE"}, false),
+			},
+			"request_template": {
+				Description: "Velocity template of the request body. Defaults to `${input.rawRequest}`. Any instances of '${' must be properly escaped as '$${'.",
+				Type:        schema.TypeString,
+				Optional:    true,
+			},
+			"headers": {
+				Description: "Map of headers in name, value pairs to include in request. Values are Velocity templates.",
+				Type:        schema.TypeMap,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+		},
+	}
+
+	testConfigResponse := &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"translation_map": {
+				Description: "Map 'attribute name' and 'JSON path' pairs used to extract data from REST response.",
+				Type:        schema.TypeMap,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			"translation_map_defaults": {
+				Description: "Map 'attribute name' and 'default value' pairs used as fallback values if JSON path extraction fails for specified key. Default values are JSON, so string defaults must be quoted.",
+				Type:        schema.TypeMap,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			"success_template": {
+				Description: "Velocity template of the result. Defaults to `${rawResult}`. Any instances of '${' must be properly escaped as '$${'.",
+				Type:        schema.TypeString,
+				Optional:    true,
+			},
+		},
+	}
+
+	stringList := &schema.Schema{Type: schema.TypeString}
+
+	return &schema.Resource{
+		Description: `Data source for testing the configuration of a Genesys Cloud integration action against a sample input, without creating the action.
+The input is validated against contract_input, the request templates are rendered and the request is sent, the translation map and success template are applied to the response, and the result is validated against contract_output.
+The request is only sent to endpoint_url, or to the service when send_request is true. Otherwise the test stops after the request is rendered.
+The templates are rendered by the provider, which supports a subset of the Velocity Template Language: references, the #if, #elseif, #else, #foreach and #set directives, comparison and logical operators, and the $esc, $math and $successTemplateUtils tools. The output of each stage is returned.`,
+		ReadContext: provider.ReadWithPooledClient(dataSourceIntegrationActionTestRead),
+		Schema: map[string]*schema.Schema{
+			"contract_input": {
+				Description:  "JSON Schema of the input of the action.",
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validation.StringIsJSON,
+			},
+			"contract_output": {
+				Description:  "JSON Schema of the result of the action.",
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validation.StringIsJSON,
+			},
+			"config_request": {
+				Description: "Configuration of outbound request.",
+				Type:        schema.TypeList,
+				Required:    true,
+				MaxItems:    1,
+				Elem:        testConfigRequest,
+			},
+			"config_response": {
+				Description: "Configuration of response processing.",
+				Type:        schema.TypeList,
+				Optional:    true,
+				MaxItems:    1,
+				Elem:        testConfigResponse,
+			},
+			"input": {
+				Description:  "JSON sample input of the action.",
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validation.StringIsJSON,
+			},
+			"endpoint_url": {
+				Description:  "URL the request is sent to instead of the rendered URL, like the URL of a local stub. Its scheme and host replace the ones of the rendered URL, and its path prefixes the rendered path. Without an endpoint URL, the request is only sent when send_request is true.",
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.IsURLWithHTTPorHTTPS,
+			},
+			"send_request": {
+				Description: "If true and no endpoint_url is set, the request is sent to the rendered URL of the service. Only GET, HEAD and OPTIONS requests are sent, since the data source is read on every plan and refresh. If false and no endpoint_url is set, the request is not sent and the response attributes are empty.",
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+			},
+			"timeout_seconds": {
+				Description:  "Timeout of the request in seconds.",
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      15,
+				ValidateFunc: validation.IntBetween(1, 60),
+			},
+			"fail_on_error": {
+				Description: "If true, reading the data source fails when a stage fails. If false, the errors are only returned in the errors attribute.",
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     true,
+			},
+			"input_errors": {
+				Description: "Errors of the validation of the input against contract_input.",
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem:        stringList,
+			},
+			"request_url": {
+				Description: "Rendered URL of the request.",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"request_headers": {
+				Description: "Rendered headers of the request.",
+				Type:        schema.TypeMap,
+				Computed:    true,
+				Elem:        stringList,
+			},
+			"request_body": {
+				Description: "Rendered body of the request. The body is not sent for GET and DELETE requests.",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"response_status_code": {
+				Description: "HTTP status code of the response.",
+				Type:        schema.TypeInt,
+				Computed:    true,
+			},
+			"response_body": {
+				Description: "Body of the response.",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"translation_map_values": {
+				Description: "JSON values extracted from the response by the translation map, or their defaults.",
+				Type:        schema.TypeMap,
+				Computed:    true,
+				Elem:        stringList,
+			},
+			"success_output": {
+				Description: "Rendered success template.",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"result": {
+				Description: "Result of the action, as normalized JSON.",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"output_errors": {
+				Description: "Errors of the validation of the result against contract_output.",
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem:        stringList,
+			},
+			"errors": {
+				Description: "Errors of all stages, prefixed by the stage or attribute they apply to.",
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem:        stringList,
+			},
+			"success": {
+				Description: "True if all stages succeeded.",
+				Type:        schema.TypeBool,
+				Computed:    true,
+			},
+		},
+	}
+}
@@ -0,0 +1,323 @@
+package knowledge_import
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/mypurecloud/platform-client-sdk-go/v149/platformclientv2"
+	"gopkg.in/yaml.v3"
+)
+
+/*
+The genesyscloud_knowledge_import_documents.go file reads the Markdown and HTML files of the import directory and converts them to the
+JSON format of the knowledge import job API. A file can start with a YAML front matter block:
+
+	---
+	external_id: returns-policy
+	title: Returns policy
+	category: Policies
+	labels: [Returns, Orders]
+	alternatives:
+	  - How do I return an item?
+	  - phrase: Refund
+	    autocomplete: true
+	visible: true
+	---
+
+The external ID is the stable key used to sync the documents. It defaults to the path of the file relative to the directory without
+its extension. The title defaults to the first level 1 heading of the file, then to the name of the file.
+*/
+
+const (
+	documentFormatMarkdown = "markdown"
+	documentFormatHtml     = "html"
+
+	// maxDocumentErrors limits the number of file errors reported at once
+	maxDocumentErrors = 20
+)
+
+var (
+	markdownExtensions = []string{".md", ".markdown"}
+	htmlExtensions     = []string{".html", ".htm"}
+)
+
+// importDocument is a file of the import directory converted to a knowledge document
+type importDocument struct {
+	externalId   string
+	path         string
+	title        string
+	category     string
+	labels       []string
+	alternatives []documentAlternative
+	visible      bool
+	blocks       []platformclientv2.Documentbodyblock
+	hash         string
+}
+
+type documentFrontMatter struct {
+	ExternalId   string                `yaml:"external_id"`
+	Title        string                `yaml:"title"`
+	Category     string                `yaml:"category"`
+	Labels       []string              `yaml:"labels"`
+	Alternatives []documentAlternative `yaml:"alternatives"`
+	Visible      *bool                 `yaml:"visible"`
+}
+
+// documentAlternative is an alternative phrase of a document. It can be written as a string or as a mapping with phrase and autocomplete.
+type documentAlternative struct {
+	Phrase       string `yaml:"phrase"`
+	Autocomplete bool   `yaml:"autocomplete"`
+}
+
+func (a *documentAlternative) UnmarshalYAML(node *yaml.Node) error {
+	if node.Kind == yaml.ScalarNode {
+		a.Phrase = node.Value
+		return nil
+	}
+	type alternative documentAlternative
+	return node.Decode((*alternative)(a))
+}
+
+// importFile is the JSON document uploaded to the knowledge import job API
+type importFile struct {
+	Documents []importFileDocument `json:"documents"`
+}
+
+type importFileDocument struct {
+	ExternalId   string                                          `json:"externalId"`
+	Title        string                                          `json:"title"`
+	Visible      bool                                            `json:"visible"`
+	Alternatives []platformclientv2.Knowledgedocumentalternative `json:"alternatives,omitempty"`
+	Category     *importFileEntity                               `json:"category,omitempty"`
+	Labels       []importFileEntity                              `json:"labels,omitempty"`
+	Variations   []importFileVariation                           `json:"variations"`
+}
+
+type importFileEntity struct {
+	Name string `json:"name"`
+}
+
+type importFileVariation struct {
+	Body platformclientv2.Documentbodyrequest `json:"body"`
+}
+
+// readImportDocuments reads the Markdown and HTML files of the directory and its subdirectories. Hidden files and directories are
+// ignored. The documents are sorted by external ID.
+func readImportDocuments(directory string, defaultVisible bool) ([]importDocument, error) {
+	info, err := os.Stat(directory)
+	if err != nil {
+		return nil, err
+	}
+	if !info.IsDir() {
+		return nil, fmt.Errorf("%s is not a directory", directory)
+	}
+
+	var (
+		documents []importDocument
+		errs      []error
+	)
+	err = filepath.WalkDir(directory, func(filePath string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if strings.HasPrefix(entry.Name(), ".") && filePath != directory {
+			if entry.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if entry.IsDir() || documentFormat(entry.Name()) == "" {
+			return nil
+		}
+
+		relativePath, err := filepath.Rel(directory, filePath)
+		if err != nil {
+			return err
+		}
+		content, err := os.ReadFile(filePath)
+		if err != nil {
+			return err
+		}
+		document, err := parseImportDocument(filepath.ToSlash(relativePath), content, defaultVisible)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", filepath.ToSlash(relativePath), err))
+			return nil
+		}
+		documents = append(documents, *document)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	sort.Slice(documents, func(i, j int) bool {
+		return documents[i].externalId < documents[j].externalId
+	})
+	for i := 1; i < len(documents); i++ {
+		if documents[i].externalId == documents[i-1].externalId {
+			errs = append(errs, fmt.Errorf("%s: external ID '%s' is already used by %s", documents[i].path, documents[i].externalId, documents[i-1].path))
+		}
+	}
+
+	if len(errs) > maxDocumentErrors {
+		errs = append(errs[:maxDocumentErrors], fmt.Errorf("%d more errors", len(errs)-maxDocumentErrors))
+	}
+	if len(errs) > 0 {
+		return nil, errors.Join(errs...)
+	}
+	return documents, nil
+}
+
+// parseImportDocument converts the content of a Markdown or HTML file to a document. relativePath is slash separated.
+func parseImportDocument(relativePath string, content []byte, defaultVisible bool) (*importDocument, error) {
+	frontMatterContent, body, err := splitFrontMatter(string(content))
+	if err != nil {
+		return nil, err
+	}
+
+	var frontMatter documentFrontMatter
+	if strings.TrimSpace(frontMatterContent) != "" {
+		decoder := yaml.NewDecoder(strings.NewReader(frontMatterContent))
+		decoder.KnownFields(true)
+		if err := decoder.Decode(&frontMatter); err != nil {
+			return nil, fmt.Errorf("invalid front matter: %w", err)
+		}
+	}
+
+	var (
+		blocks  []platformclientv2.Documentbodyblock
+		heading string
+	)
+	switch documentFormat(relativePath) {
+	case documentFormatMarkdown:
+		blocks, heading = convertMarkdown(body)
+	case documentFormatHtml:
+		blocks, heading, err = convertHtml(body)
+		if err != nil {
+			return nil, err
+		}
+	default:
+		return nil, fmt.Errorf("unsupported file extension %s", path.Ext(relativePath))
+	}
+	if len(blocks) == 0 {
+		return nil, fmt.Errorf("the document has no content")
+	}
+
+	document := &importDocument{
+		externalId:   frontMatter.ExternalId,
+		path:         relativePath,
+		title:        strings.TrimSpace(frontMatter.Title),
+		category:     strings.TrimSpace(frontMatter.Category),
+		labels:       frontMatter.Labels,
+		alternatives: frontMatter.Alternatives,
+		visible:      defaultVisible,
+		blocks:       blocks,
+	}
+	if document.externalId == "" {
+		document.externalId = strings.TrimSuffix(relativePath, path.Ext(relativePath))
+	}
+	if document.title == "" {
+		document.title = heading
+	}
+	if document.title == "" {
+		document.title = strings.TrimSuffix(path.Base(relativePath), path.Ext(relativePath))
+	}
+	if frontMatter.Visible != nil {
+		document.visible = *frontMatter.Visible
+	}
+
+	encoded, err := json.Marshal(document.toImportFileDocument())
+	if err != nil {
+		return nil, err
+	}
+	hash := sha256.Sum256(encoded)
+	document.hash = hex.EncodeToString(hash[:])
+	return document, nil
+}
+
+// splitFrontMatter returns the YAML front matter of the content, if any, and the content after it
+func splitFrontMatter(content string) (string, string, error) {
+	content = strings.TrimPrefix(strings.ReplaceAll(content, "\r\n", "\n"), "\ufeff")
+	lines := strings.Split(content, "\n")
+	if len(lines) == 0 || strings.TrimSpace(lines[0]) != "---" {
+		return "", content, nil
+	}
+	for i := 1; i < len(lines); i++ {
+		if line := strings.TrimSpace(lines[i]); line == "---" || line == "..." {
+			return strings.Join(lines[1:i], "\n"), strings.Join(lines[i+1:], "\n"), nil
+		}
+	}
+	return "", "", fmt.Errorf("the front matter is not terminated by a --- line")
+}
+
+func documentFormat(fileName string) string {
+	extension := strings.ToLower(path.Ext(fileName))
+	for _, markdownExtension := range markdownExtensions {
+		if extension == markdownExtension {
+			return documentFormatMarkdown
+		}
+	}
+	for _, htmlExtension := range htmlExtensions {
+		if extension == htmlExtension {
+			return documentFormatHtml
+		}
+	}
+	return ""
+}
+
+func (d *importDocument) toImportFileDocument() importFileDocument {
+	document := importFileDocument{
+		ExternalId: d.externalId,
+		Title:      d.title,
+		Visible:    d.visible,
+		Variations: []importFileVariation{{Body: platformclientv2.Documentbodyrequest{Blocks: &d.blocks}}},
+	}
+	for _, alternative := range d.alternatives {
+		document.Alternatives = append(document.Alternatives, platformclientv2.Knowledgedocumentalternative{
+			Phrase:       platformclientv2.String(alternative.Phrase),
+			Autocomplete: platformclientv2.Bool(alternative.Autocomplete),
+		})
+	}
+	if d.category != "" {
+		document.Category = &importFileEntity{Name: d.category}
+	}
+	for _, label := range d.labels {
+		document.Labels = append(document.Labels, importFileEntity{Name: label})
+	}
+	return document
+}
+
+// buildImportFile encodes the documents in the JSON format of the knowledge import job API
+func buildImportFile(documents []importDocument) ([]byte, error) {
+	file := importFile{Documents: make([]importFileDocument, len(documents))}
+	for i := range documents {
+		file.Documents[i] = documents[i].toImportFileDocument()
+	}
+
+	var buffer bytes.Buffer
+	encoder := json.NewEncoder(&buffer)
+	encoder.SetEscapeHTML(false)
+	if err := encoder.Encode(file); err != nil {
+		return nil, fmt.Errorf("failed to encode the knowledge import file: %w", err)
+	}
+	return buffer.Bytes(), nil
+}
+
+// hashImportDocuments returns a hash of the external IDs and content hashes of the documents
+func hashImportDocuments(documents []importDocument) string {
+	hash := sha256.New()
+	for _, document := range documents {
+		hash.Write([]byte(document.externalId + "\n" + document.hash + "\n"))
+	}
+	return hex.EncodeToString(hash.Sum(nil))
+}
@@ -0,0 +1,272 @@
+package knowledge_import
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/mypurecloud/platform-client-sdk-go/v149/platformclientv2"
+	"golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
+)
+
+/*
+The genesyscloud_knowledge_import_html.go file converts HTML to the body blocks of a knowledge document variation. Headings, paragraphs,
+preformatted text, lists and images become document blocks. The rows of tables become paragraphs with the cells separated by " | ".
+Container elements like div, section and blockquote are flattened. Scripts, styles and forms are ignored.
+*/
+
+type htmlConverter struct {
+	blocks []platformclientv2.Documentbodyblock
+	// inline holds the contents of the paragraph being built from the text and inline elements between blocks
+	inline  []platformclientv2.Documentcontentblock
+	title   string
+	heading string
+}
+
+var htmlHeadingLevels = map[atom.Atom]int{atom.H1: 1, atom.H2: 2, atom.H3: 3, atom.H4: 4, atom.H5: 5, atom.H6: 6}
+
+// convertHtml converts HTML to document body blocks. It also returns the title of the page, or the text of its first h1 heading.
+func convertHtml(content string) ([]platformclientv2.Documentbodyblock, string, error) {
+	doc, err := html.Parse(strings.NewReader(content))
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to parse HTML: %w", err)
+	}
+
+	c := &htmlConverter{}
+	c.convertChildren(doc)
+	c.flushInline()
+
+	title := c.title
+	if title == "" {
+		title = c.heading
+	}
+	return c.blocks, title, nil
+}
+
+func (c *htmlConverter) convertChildren(n *html.Node) {
+	for child := n.FirstChild; child != nil; child = child.NextSibling {
+		c.convert(child)
+	}
+}
+
+func (c *htmlConverter) convert(n *html.Node) {
+	switch n.Type {
+	case html.DocumentNode:
+		c.convertChildren(n)
+		return
+	case html.TextNode:
+		c.inline = append(c.inline, inlineHtmlContents(n, nil, "")...)
+		return
+	case html.ElementNode:
+	default:
+		return
+	}
+
+	if level, ok := htmlHeadingLevels[n.DataAtom]; ok {
+		c.flushInline()
+		contents := normalizeContents(inlineHtmlContents(n, nil, ""))
+		if len(contents) == 0 {
+			return
+		}
+		c.blocks = append(c.blocks, paragraphBlock(fmt.Sprintf("Heading%d", level), contents))
+		if level == 1 && c.heading == "" {
+			c.heading = plainText(contents)
+		}
+		return
+	}
+
+	switch n.DataAtom {
+	case atom.Head:
+		if title := findHtmlElement(n, atom.Title); title != nil {
+			c.title = strings.TrimSpace(collapseWhitespace(htmlText(title)))
+		}
+	case atom.Script, atom.Style, atom.Noscript, atom.Template, atom.Form, atom.Button, atom.Select, atom.Iframe:
+	case atom.P:
+		c.flushInline()
+		c.inline = inlineHtmlContents(n, nil, "")
+		c.flushInline()
+	case atom.Pre:
+		c.flushInline()
+		if text := strings.Trim(htmlText(n), "\n"); text != "" {
+			c.blocks = append(c.blocks, preformattedBlock(text))
+		}
+	case atom.Ul, atom.Ol:
+		c.flushInline()
+		var items [][]platformclientv2.Documentcontentblock
+		for child := n.FirstChild; child != nil; child = child.NextSibling {
+			if child.Type == html.ElementNode && child.DataAtom == atom.Li {
+				if contents := normalizeContents(inlineHtmlContents(child, nil, "")); len(contents) > 0 {
+					items = append(items, contents)
+				}
+			}
+		}
+		if len(items) > 0 {
+			c.blocks = append(c.blocks, listBlock(n.DataAtom == atom.Ol, items))
+		}
+	case atom.Img:
+		if src := htmlAttribute(n, "src"); src != "" {
+			c.flushInline()
+			c.blocks = append(c.blocks, imageBlock(src))
+		}
+	case atom.Table:
+		c.flushInline()
+		c.convertTable(n)
+	case atom.Br:
+		c.inline = append(c.inline, textContent("\n", nil, ""))
+	case atom.Hr:
+		c.flushInline()
+	case atom.Html, atom.Body, atom.Div, atom.Section, atom.Article, atom.Main, atom.Header, atom.Footer, atom.Nav, atom.Aside,
+		atom.Blockquote, atom.Figure, atom.Figcaption, atom.Details, atom.Summary, atom.Dl, atom.Dt, atom.Dd, atom.Center:
+		c.flushInline()
+		c.convertChildren(n)
+		c.flushInline()
+	default:
+		c.inline = append(c.inline, inlineHtmlContents(n, nil, "")...)
+	}
+}
+
+// convertTable adds a paragraph for each row of the table
+func (c *htmlConverter) convertTable(table *html.Node) {
+	var walk func(n *html.Node)
+	walk = func(n *html.Node) {
+		for child := n.FirstChild; child != nil; child = child.NextSibling {
+			if child.Type != html.ElementNode {
+				continue
+			}
+			if child.DataAtom != atom.Tr {
+				walk(child)
+				continue
+			}
+			var row []platformclientv2.Documentcontentblock
+			for cell := child.FirstChild; cell != nil; cell = cell.NextSibling {
+				if cell.Type != html.ElementNode || (cell.DataAtom != atom.Td && cell.DataAtom != atom.Th) {
+					continue
+				}
+				var marks []string
+				if cell.DataAtom == atom.Th {
+					marks = []string{markBold}
+				}
+				if len(row) > 0 {
+					row = append(row, textContent(" | ", nil, ""))
+				}
+				row = append(row, normalizeContents(inlineHtmlContents(cell, marks, ""))...)
+			}
+			c.inline = row
+			c.flushInline()
+		}
+	}
+	walk(table)
+}
+
+func (c *htmlConverter) flushInline() {
+	if contents := normalizeContents(c.inline); len(contents) > 0 {
+		c.blocks = append(c.blocks, paragraphBlock(fontTypeParagraph, contents))
+	}
+	c.inline = nil
+}
+
+// inlineHtmlContents converts the text and inline elements of the node to content blocks
+func inlineHtmlContents(n *html.Node, marks []string, hyperlink string) []platformclientv2.Documentcontentblock {
+	switch n.Type {
+	case html.TextNode:
+		return []platformclientv2.Documentcontentblock{textContent(collapseWhitespace(n.Data), marks, hyperlink)}
+	case html.ElementNode:
+	default:
+		return nil
+	}
+
+	switch n.DataAtom {
+	case atom.Script, atom.Style, atom.Noscript, atom.Template:
+		return nil
+	case atom.Br:
+		return []platformclientv2.Documentcontentblock{textContent("\n", marks, hyperlink)}
+	case atom.Img:
+		if src := htmlAttribute(n, "src"); src != "" {
+			return []platformclientv2.Documentcontentblock{imageContent(src, hyperlink)}
+		}
+		return nil
+	case atom.Strong, atom.B:
+		marks = appendMark(marks, markBold)
+	case atom.Em, atom.I:
+		marks = appendMark(marks, markItalic)
+	case atom.U, atom.Ins:
+		marks = appendMark(marks, markUnderline)
+	case atom.S, atom.Strike, atom.Del:
+		marks = appendMark(marks, markStrikethrough)
+	case atom.A:
+		if href := htmlAttribute(n, "href"); href != "" {
+			hyperlink = href
+		}
+	}
+
+	var contents []platformclientv2.Documentcontentblock
+	for child := n.FirstChild; child != nil; child = child.NextSibling {
+		contents = append(contents, inlineHtmlContents(child, marks, hyperlink)...)
+	}
+	// Nested lists and paragraphs inside list items are separated by spaces
+	if n.DataAtom == atom.Li || n.DataAtom == atom.P || n.DataAtom == atom.Ul || n.DataAtom == atom.Ol || n.DataAtom == atom.Div {
+		contents = append(contents, textContent(" ", nil, ""))
+	}
+	return contents
+}
+
+func appendMark(marks []string, mark string) []string {
+	for _, existing := range marks {
+		if existing == mark {
+			return marks
+		}
+	}
+	return append(append([]string{}, marks...), mark)
+}
+
+// collapseWhitespace replaces the runs of whitespace of HTML text by a single space
+func collapseWhitespace(text string) string {
+	fields := strings.Fields(text)
+	if len(fields) == 0 {
+		if text == "" {
+			return ""
+		}
+		return " "
+	}
+	collapsed := strings.Join(fields, " ")
+	if strings.TrimLeft(text[:1], " \t\r\n\f") == "" {
+		collapsed = " " + collapsed
+	}
+	if strings.TrimRight(text[len(text)-1:], " \t\r\n\f") == "" {
+		collapsed += " "
+	}
+	return collapsed
+}
+
+// htmlText returns the text of the node and its descendants
+func htmlText(n *html.Node) string {
+	if n.Type == html.TextNode {
+		return n.Data
+	}
+	var text strings.Builder
+	for child := n.FirstChild; child != nil; child = child.NextSibling {
+		text.WriteString(htmlText(child))
+	}
+	return text.String()
+}
+
+func findHtmlElement(n *html.Node, a atom.Atom) *html.Node {
+	if n.Type == html.ElementNode && n.DataAtom == a {
+		return n
+	}
+	for child := n.FirstChild; child != nil; child = child.NextSibling {
+		if found := findHtmlElement(child, a); found != nil {
+			return found
+		}
+	}
+	return nil
+}
+
+func htmlAttribute(n *html.Node, key string) string {
+	for _, attr := range n.Attr {
+		if attr.Key == key {
+			return strings.TrimSpace(attr.Val)
+		}
+	}
+	return ""
+}
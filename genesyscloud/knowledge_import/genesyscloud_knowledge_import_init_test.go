@@ -0,0 +1,51 @@
+package knowledge_import
+
+import (
+	"sync"
+	gcloud "terraform-provider-genesyscloud/genesyscloud"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+/*
+The genesyscloud_knowledge_import_init_test.go file is used to initialize the data sources and resources
+used in testing the knowledge import resource.
+*/
+
+// providerResources holds a map of all registered resources
+var providerResources map[string]*schema.Resource
+
+// providerDataSources holds a map of all registered datasources
+var providerDataSources map[string]*schema.Resource
+
+type registerTestInstance struct {
+	resourceMapMutex sync.RWMutex
+}
+
+// registerTestResources registers all resources used in the tests
+func (r *registerTestInstance) registerTestResources() {
+	r.resourceMapMutex.Lock()
+	defer r.resourceMapMutex.Unlock()
+
+	providerResources["genesyscloud_knowledge_knowledgebase"] = gcloud.ResourceKnowledgeKnowledgebase()
+	providerResources[ResourceType] = ResourceKnowledgeImport()
+}
+
+// initTestResources initializes all test resources and data sources.
+func initTestResources() {
+	providerResources = make(map[string]*schema.Resource)
+	providerDataSources = make(map[string]*schema.Resource)
+	regInstance := &registerTestInstance{}
+
+	regInstance.registerTestResources()
+}
+
+// TestMain is a "setup" function called by the testing framework when run the test
+func TestMain(m *testing.M) {
+	// Run setup function before starting the test suite for knowledge import package
+	initTestResources()
+
+	// Run the test suite for the knowledge import package
+	m.Run()
+}
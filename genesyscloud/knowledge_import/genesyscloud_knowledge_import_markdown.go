@@ -0,0 +1,458 @@
+package knowledge_import
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/mypurecloud/platform-client-sdk-go/v149/platformclientv2"
+)
+
+/*
+The genesyscloud_knowledge_import_markdown.go file converts Markdown to the body blocks of a knowledge document variation. It supports
+the subset of Markdown that maps to document blocks: ATX headings, paragraphs, fenced and indented code, block quotes, flat ordered and
+unordered lists and images, with bold, italic, strikethrough, code spans and links inside the text.
+*/
+
+const (
+	blockTypeParagraph     = "Paragraph"
+	blockTypeImage         = "Image"
+	blockTypeOrderedList   = "OrderedList"
+	blockTypeUnorderedList = "UnorderedList"
+
+	contentTypeText  = "Text"
+	contentTypeImage = "Image"
+	listItemType     = "ListItem"
+
+	fontTypeParagraph    = "Paragraph"
+	fontTypePreformatted = "Preformatted"
+
+	markBold          = "Bold"
+	markItalic        = "Italic"
+	markUnderline     = "Underline"
+	markStrikethrough = "Strikethrough"
+)
+
+var (
+	markdownHeadingRegex       = regexp.MustCompile(`^(#{1,6})(\s+(.*?))?(\s+#+)?\s*$`)
+	markdownListItemRegex      = regexp.MustCompile(`^\s{0,3}([-*+]|\d{1,9}[.)])\s+(.*)$`)
+	markdownThematicBreakRegex = regexp.MustCompile(`^\s{0,3}(([*]\s*){3,}|(-\s*){3,}|(_\s*){3,})$`)
+	markdownImageRegex         = regexp.MustCompile(`^!\[[^\]]*\]\(([^)\s]+)(\s+"[^"]*")?\)$`)
+)
+
+// convertMarkdown converts Markdown to document body blocks. It also returns the text of the first level 1 heading.
+func convertMarkdown(content string) ([]platformclientv2.Documentbodyblock, string) {
+	var (
+		blocks      []platformclientv2.Documentbodyblock
+		title       string
+		paragraph   []string
+		listItems   []string
+		listOrdered bool
+	)
+
+	flushParagraph := func() {
+		if len(paragraph) > 0 {
+			if contents := parseMarkdownInline(strings.Join(paragraph, " ")); len(contents) > 0 {
+				blocks = append(blocks, paragraphBlock(fontTypeParagraph, contents))
+			}
+			paragraph = nil
+		}
+	}
+	flushList := func() {
+		if len(listItems) > 0 {
+			items := make([][]platformclientv2.Documentcontentblock, 0, len(listItems))
+			for _, item := range listItems {
+				items = append(items, parseMarkdownInline(item))
+			}
+			blocks = append(blocks, listBlock(listOrdered, items))
+			listItems = nil
+		}
+	}
+
+	lines := strings.Split(strings.ReplaceAll(content, "\r\n", "\n"), "\n")
+	for i := 0; i < len(lines); i++ {
+		line := strings.ReplaceAll(lines[i], "\t", "    ")
+		trimmed := strings.TrimSpace(line)
+
+		if fence := markdownFence(trimmed); fence != "" {
+			flushParagraph()
+			flushList()
+			var code []string
+			for i++; i < len(lines) && !strings.HasPrefix(strings.TrimSpace(lines[i]), fence); i++ {
+				code = append(code, lines[i])
+			}
+			blocks = append(blocks, preformattedBlock(strings.Join(code, "\n")))
+			continue
+		}
+
+		switch {
+		case trimmed == "":
+			// Blank lines end paragraphs. Lists continue when the next item follows the blank line.
+			flushParagraph()
+		case markdownHeadingRegex.MatchString(trimmed):
+			flushParagraph()
+			flushList()
+			match := markdownHeadingRegex.FindStringSubmatch(trimmed)
+			// A heading with only a closing sequence is empty
+			text := match[3]
+			if strings.Trim(text, "#") == "" {
+				text = ""
+			}
+			contents := parseMarkdownInline(text)
+			if len(contents) == 0 {
+				continue
+			}
+			blocks = append(blocks, paragraphBlock(fmt.Sprintf("Heading%d", len(match[1])), contents))
+			if len(match[1]) == 1 && title == "" {
+				title = plainText(contents)
+			}
+		case markdownThematicBreakRegex.MatchString(line):
+			flushParagraph()
+			flushList()
+		case markdownListItemRegex.MatchString(line) && (len(paragraph) == 0 || len(listItems) > 0):
+			flushParagraph()
+			match := markdownListItemRegex.FindStringSubmatch(line)
+			ordered := !strings.ContainsAny(match[1], "-*+")
+			if len(listItems) > 0 && ordered != listOrdered {
+				flushList()
+			}
+			listOrdered = ordered
+			listItems = append(listItems, match[2])
+		case len(listItems) > 0 && len(paragraph) == 0 && strings.HasPrefix(line, "  "):
+			// Indented lines continue the last list item
+			listItems[len(listItems)-1] += " " + trimmed
+		case len(paragraph) == 0 && strings.HasPrefix(line, "    "):
+			flushList()
+			code := []string{strings.TrimPrefix(line, "    ")}
+			for i+1 < len(lines) && (strings.HasPrefix(lines[i+1], "    ") || strings.HasPrefix(lines[i+1], "\t")) {
+				i++
+				code = append(code, strings.TrimPrefix(strings.TrimPrefix(lines[i], "\t"), "    "))
+			}
+			blocks = append(blocks, preformattedBlock(strings.Join(code, "\n")))
+		case markdownImageRegex.MatchString(trimmed) && len(paragraph) == 0:
+			flushList()
+			blocks = append(blocks, imageBlock(markdownImageRegex.FindStringSubmatch(trimmed)[1]))
+		case strings.HasPrefix(trimmed, ">"):
+			flushList()
+			quoted := strings.TrimSpace(strings.TrimLeft(trimmed, "> "))
+			if quoted == "" {
+				flushParagraph()
+				continue
+			}
+			paragraph = append(paragraph, quoted)
+		default:
+			flushList()
+			paragraph = append(paragraph, trimmed)
+		}
+	}
+	flushParagraph()
+	flushList()
+
+	return blocks, title
+}
+
+// markdownFence returns the delimiter of a code fence starting on the line, if any
+func markdownFence(line string) string {
+	for _, fence := range []string{"```", "~~~"} {
+		if strings.HasPrefix(line, fence) {
+			return fence
+		}
+	}
+	return ""
+}
+
+// parseMarkdownInline converts the inline Markdown of a paragraph to content blocks
+func parseMarkdownInline(text string) []platformclientv2.Documentcontentblock {
+	var contents []platformclientv2.Documentcontentblock
+	parseMarkdownSpan(text, nil, "", &contents)
+	return normalizeContents(contents)
+}
+
+func parseMarkdownSpan(text string, marks []string, hyperlink string, contents *[]platformclientv2.Documentcontentblock) {
+	var plain strings.Builder
+	flush := func() {
+		if plain.Len() > 0 {
+			*contents = append(*contents, textContent(plain.String(), marks, hyperlink))
+			plain.Reset()
+		}
+	}
+
+	for i := 0; i < len(text); {
+		c := text[i]
+		switch c {
+		case '\\':
+			if i+1 < len(text) && strings.IndexByte("\\`*_{}[]()#+-.!~>|", text[i+1]) >= 0 {
+				plain.WriteByte(text[i+1])
+				i += 2
+				continue
+			}
+		case '`':
+			if end := strings.IndexByte(text[i+1:], '`'); end >= 0 {
+				plain.WriteString(text[i+1 : i+1+end])
+				i += end + 2
+				continue
+			}
+		case '!':
+			if _, url, length, ok := parseMarkdownLink(text[i+1:]); ok {
+				flush()
+				*contents = append(*contents, imageContent(url, hyperlink))
+				i += length + 1
+				continue
+			}
+		case '[':
+			if label, url, length, ok := parseMarkdownLink(text[i:]); ok {
+				flush()
+				parseMarkdownSpan(label, marks, url, contents)
+				i += length
+				continue
+			}
+		case '*', '_', '~':
+			if delimiter, mark := markdownEmphasis(text, i); delimiter != "" {
+				start := i + len(delimiter)
+				if end := findClosingDelimiter(text[start:], delimiter); end > 0 {
+					flush()
+					parseMarkdownSpan(text[start:start+end], appendMark(marks, mark), hyperlink, contents)
+					i = start + end + len(delimiter)
+					continue
+				}
+			}
+		}
+		plain.WriteByte(c)
+		i++
+	}
+	flush()
+}
+
+// markdownEmphasis returns the emphasis delimiter starting at position i of the text and its mark
+func markdownEmphasis(text string, i int) (string, string) {
+	c := text[i]
+	// Underscores inside words, like in snake_case, are not emphasis
+	if c == '_' && i > 0 && isWordCharacter(text[i-1]) {
+		return "", ""
+	}
+
+	delimiter := string(c)
+	if i+1 < len(text) && text[i+1] == c {
+		delimiter += string(c)
+	}
+	// The emphasized text cannot start with a space
+	if next := i + len(delimiter); next >= len(text) || text[next] == ' ' {
+		return "", ""
+	}
+
+	switch delimiter {
+	case "**", "__":
+		return delimiter, markBold
+	case "*", "_":
+		return delimiter, markItalic
+	case "~~":
+		return delimiter, markStrikethrough
+	}
+	return "", ""
+}
+
+// findClosingDelimiter returns the position of the delimiter closing an emphasis. Single delimiters skip the double delimiters of
+// nested emphasis.
+func findClosingDelimiter(text, delimiter string) int {
+	for i := 0; i < len(text); i++ {
+		if text[i] == '\\' {
+			i++
+			continue
+		}
+		if text[i] == '`' {
+			if end := strings.IndexByte(text[i+1:], '`'); end >= 0 {
+				i += end + 1
+			}
+			continue
+		}
+		if !strings.HasPrefix(text[i:], delimiter) {
+			continue
+		}
+		if len(delimiter) == 1 && i+1 < len(text) && text[i+1] == delimiter[0] {
+			i++
+			continue
+		}
+		if i == 0 || text[i-1] == ' ' {
+			continue
+		}
+		// Double delimiters close at the end of a longer run, like in **bold *italic***, so the single delimiter closes first
+		end := i
+		for end < len(text) && text[end] == delimiter[0] {
+			end++
+		}
+		if end-i > len(delimiter) {
+			return end - len(delimiter)
+		}
+		if delimiter[0] == '_' && i+len(delimiter) < len(text) && isWordCharacter(text[i+len(delimiter)]) {
+			continue
+		}
+		return i
+	}
+	return -1
+}
+
+// parseMarkdownLink parses a [label](url "title") link at the start of the text. It returns the label, the URL and the length of the link.
+func parseMarkdownLink(text string) (string, string, int, bool) {
+	if !strings.HasPrefix(text, "[") {
+		return "", "", 0, false
+	}
+	depth := 0
+	labelEnd := -1
+	for i := 0; i < len(text) && labelEnd < 0; i++ {
+		switch text[i] {
+		case '\\':
+			i++
+		case '[':
+			depth++
+		case ']':
+			depth--
+			if depth == 0 {
+				labelEnd = i
+			}
+		}
+	}
+	if labelEnd < 0 || labelEnd+1 >= len(text) || text[labelEnd+1] != '(' {
+		return "", "", 0, false
+	}
+	urlEnd := strings.IndexByte(text[labelEnd+2:], ')')
+	if urlEnd < 0 {
+		return "", "", 0, false
+	}
+	destination := strings.TrimSpace(text[labelEnd+2 : labelEnd+2+urlEnd])
+	if space := strings.IndexAny(destination, " \t"); space >= 0 {
+		destination = destination[:space]
+	}
+	destination = strings.TrimSuffix(strings.TrimPrefix(destination, "<"), ">")
+	if destination == "" {
+		return "", "", 0, false
+	}
+	return text[1:labelEnd], destination, labelEnd + urlEnd + 3, true
+}
+
+func isWordCharacter(c byte) bool {
+	return c == '_' || c >= '0' && c <= '9' || c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z'
+}
+
+func paragraphBlock(fontType string, contents []platformclientv2.Documentcontentblock) platformclientv2.Documentbodyblock {
+	return platformclientv2.Documentbodyblock{
+		VarType: platformclientv2.String(blockTypeParagraph),
+		Paragraph: &platformclientv2.Documentbodyparagraph{
+			Blocks:     &contents,
+			Properties: &platformclientv2.Documentbodyparagraphproperties{FontType: platformclientv2.String(fontType)},
+		},
+	}
+}
+
+func preformattedBlock(text string) platformclientv2.Documentbodyblock {
+	return paragraphBlock(fontTypePreformatted, []platformclientv2.Documentcontentblock{textContent(text, nil, "")})
+}
+
+func imageBlock(url string) platformclientv2.Documentbodyblock {
+	return platformclientv2.Documentbodyblock{
+		VarType: platformclientv2.String(blockTypeImage),
+		Image:   &platformclientv2.Documentbodyimage{Url: platformclientv2.String(url)},
+	}
+}
+
+func listBlock(ordered bool, items [][]platformclientv2.Documentcontentblock) platformclientv2.Documentbodyblock {
+	listBlocks := make([]platformclientv2.Documentbodylistblock, 0, len(items))
+	for _, item := range items {
+		itemContents := make([]platformclientv2.Documentlistcontentblock, 0, len(item))
+		for _, content := range item {
+			itemContents = append(itemContents, platformclientv2.Documentlistcontentblock{
+				VarType: content.VarType,
+				Text:    content.Text,
+				Image:   content.Image,
+			})
+		}
+		listBlocks = append(listBlocks, platformclientv2.Documentbodylistblock{
+			VarType: platformclientv2.String(listItemType),
+			Blocks:  &itemContents,
+		})
+	}
+
+	blockType := blockTypeUnorderedList
+	if ordered {
+		blockType = blockTypeOrderedList
+	}
+	return platformclientv2.Documentbodyblock{
+		VarType: platformclientv2.String(blockType),
+		List:    &platformclientv2.Documentbodylist{Blocks: &listBlocks},
+	}
+}
+
+func textContent(text string, marks []string, hyperlink string) platformclientv2.Documentcontentblock {
+	documentText := &platformclientv2.Documenttext{Text: platformclientv2.String(text)}
+	if len(marks) > 0 {
+		documentText.Marks = &marks
+	}
+	if hyperlink != "" {
+		documentText.Hyperlink = platformclientv2.String(hyperlink)
+	}
+	return platformclientv2.Documentcontentblock{VarType: platformclientv2.String(contentTypeText), Text: documentText}
+}
+
+func imageContent(url, hyperlink string) platformclientv2.Documentcontentblock {
+	image := &platformclientv2.Documentbodyimage{Url: platformclientv2.String(url)}
+	if hyperlink != "" {
+		image.Hyperlink = platformclientv2.String(hyperlink)
+	}
+	return platformclientv2.Documentcontentblock{VarType: platformclientv2.String(contentTypeImage), Image: image}
+}
+
+// normalizeContents merges adjacent texts with the same marks and hyperlink, and trims the spaces at the start and end of the contents
+func normalizeContents(contents []platformclientv2.Documentcontentblock) []platformclientv2.Documentcontentblock {
+	var merged []platformclientv2.Documentcontentblock
+	for _, content := range contents {
+		if last := len(merged) - 1; last >= 0 && content.Text != nil && merged[last].Text != nil && sameTextFormat(merged[last].Text, content.Text) {
+			text := *merged[last].Text
+			text.Text = platformclientv2.String(*text.Text + *content.Text.Text)
+			merged[last].Text = &text
+			continue
+		}
+		merged = append(merged, content)
+	}
+
+	trim := func(i int, trimFunc func(string) string) {
+		if i >= 0 && i < len(merged) && merged[i].Text != nil {
+			text := *merged[i].Text
+			text.Text = platformclientv2.String(trimFunc(*text.Text))
+			merged[i].Text = &text
+		}
+	}
+	trim(0, func(s string) string { return strings.TrimLeft(s, " \n") })
+	trim(len(merged)-1, func(s string) string { return strings.TrimRight(s, " \n") })
+
+	result := merged[:0]
+	for _, content := range merged {
+		if content.Text == nil || *content.Text.Text != "" {
+			result = append(result, content)
+		}
+	}
+	return result
+}
+
+func sameTextFormat(a, b *platformclientv2.Documenttext) bool {
+	if (a.Hyperlink == nil) != (b.Hyperlink == nil) || a.Hyperlink != nil && *a.Hyperlink != *b.Hyperlink {
+		return false
+	}
+	var aMarks, bMarks []string
+	if a.Marks != nil {
+		aMarks = *a.Marks
+	}
+	if b.Marks != nil {
+		bMarks = *b.Marks
+	}
+	return strings.Join(aMarks, ",") == strings.Join(bMarks, ",")
+}
+
+// plainText returns the text of the contents without formatting
+func plainText(contents []platformclientv2.Documentcontentblock) string {
+	var text strings.Builder
+	for _, content := range contents {
+		if content.Text != nil && content.Text.Text != nil {
+			text.WriteString(*content.Text.Text)
+		}
+	}
+	return strings.TrimSpace(text.String())
+}
@@ -0,0 +1,142 @@
+package knowledge_import
+
+import (
+	"testing"
+
+	"github.com/mypurecloud/platform-client-sdk-go/v149/platformclientv2"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUnitConvertMarkdownBlocks(t *testing.T) {
+	testCases := []struct {
+		name     string
+		markdown string
+		title    string
+		blocks   []string
+	}{
+		{
+			name:     "headings",
+			markdown: "## Overview ##\n# First\n###### Sixth\n#NoSpace\n# First again",
+			title:    "First",
+			blocks:   []string{"Heading2: Overview", "Heading1: First", "Heading6: Sixth", "Paragraph: #NoSpace", "Heading1: First again"},
+		},
+		{
+			name:     "empty heading",
+			markdown: "#\n#   ##\nText",
+			blocks:   []string{"Paragraph: Text"},
+		},
+		{
+			name:     "paragraphs",
+			markdown: "First line\nsecond line\n\n\nNext paragraph  \r\nwith CRLF",
+			blocks:   []string{"Paragraph: First line second line", "Paragraph: Next paragraph with CRLF"},
+		},
+		{
+			name:     "thematic breaks",
+			markdown: "Before\n***\nMiddle\n- - -\n___\nAfter",
+			blocks:   []string{"Paragraph: Before", "Paragraph: Middle", "Paragraph: After"},
+		},
+		{
+			name:     "list types",
+			markdown: "- one\n+ two\n1. three\n2) four\n- five",
+			blocks:   []string{"UnorderedList: one / two", "OrderedList: three / four", "UnorderedList: five"},
+		},
+		{
+			name:     "list with blank lines and continuation",
+			markdown: "1. one\n\n2. two\n   continued\n\nAfter the list",
+			blocks:   []string{"OrderedList: one / two continued", "Paragraph: After the list"},
+		},
+		{
+			name:     "list marker inside paragraph",
+			markdown: "The total is\n- 5 items\n\n- item",
+			blocks:   []string{"Paragraph: The total is - 5 items", "UnorderedList: item"},
+		},
+		{
+			name:     "fenced code",
+			markdown: "~~~yaml\nkey: *value*\n\n  indented\n~~~\nText",
+			blocks:   []string{"Preformatted: key: *value*\n\n  indented", "Paragraph: Text"},
+		},
+		{
+			name:     "unterminated fence",
+			markdown: "```\ncode\n# not a heading",
+			blocks:   []string{"Preformatted: code\n# not a heading"},
+		},
+		{
+			name:     "indented code",
+			markdown: "    line 1\n\tline 2\n\nText\n    continues the paragraph",
+			blocks:   []string{"Preformatted: line 1\nline 2", "Paragraph: Text continues the paragraph"},
+		},
+		{
+			name:     "block quotes",
+			markdown: "> First\n> second\n>\n> > Nested",
+			blocks:   []string{"Paragraph: First second", "Paragraph: Nested"},
+		},
+		{
+			name:     "images",
+			markdown: "![Logo](https://example.com/logo.png \"Logo\")\nText ![icon](https://example.com/icon.png) inline",
+			blocks:   []string{"Image: https://example.com/logo.png", "Paragraph: Text <https://example.com/icon.png> inline"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			blocks, title := convertMarkdown(tc.markdown)
+			assert.Equal(t, tc.title, title)
+			assert.Equal(t, tc.blocks, describeBlocks(blocks))
+		})
+	}
+}
+
+func TestUnitParseMarkdownInline(t *testing.T) {
+	testCases := []struct {
+		name   string
+		text   string
+		result string
+	}{
+		{name: "bold and italic", text: "**bold** __bold__ *italic* _italic_", result: "[bold|Bold|] [bold|Bold|] [italic|Italic|] [italic|Italic|]"},
+		{name: "strikethrough", text: "~~gone~~ and ~single~", result: "[gone|Strikethrough|] and ~single~"},
+		{name: "nested marks", text: "**bold *and italic***", result: "[bold |Bold|][and italic|Bold,Italic|]"},
+		{name: "unclosed emphasis", text: "**not closed and *also not", result: "**not closed and *also not"},
+		{name: "spaces around delimiters", text: "a * b * c and 2 ** 3", result: "a * b * c and 2 ** 3"},
+		{name: "underscores in words", text: "snake_case_name and _italic_", result: "snake_case_name and [italic|Italic|]"},
+		{name: "escapes", text: `\*not italic\* \[not a link\] \a`, result: `*not italic* [not a link] \a`},
+		{name: "code spans", text: "`*raw* [text](url)` and `unclosed", result: "*raw* [text](url) and `unclosed"},
+		{name: "code span inside emphasis", text: "*use `a*b`*", result: "[use a*b|Italic|]"},
+		{name: "link", text: "see [the **docs**](https://example.com/docs \"Docs\")", result: "see [the ||https://example.com/docs][docs|Bold|https://example.com/docs]"},
+		{name: "link in angle brackets", text: "[docs](<https://example.com>)", result: "[docs||https://example.com]"},
+		{name: "link with brackets in label", text: "[a [b] c](https://example.com)", result: "[a [b] c||https://example.com]"},
+		{name: "not a link", text: "[label] (https://example.com) and [empty]() and [open](https://example.com", result: "[label] (https://example.com) and [empty]() and [open](https://example.com"},
+		{name: "merged text", text: "plain `code` plain", result: "plain code plain"},
+		{name: "trimmed", text: "  **padded**  ", result: "[padded|Bold|]"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			contents := parseMarkdownInline(tc.text)
+			assert.Equal(t, []string{"Paragraph: " + tc.result}, describeBlocks([]platformclientv2.Documentbodyblock{paragraphBlock(fontTypeParagraph, contents)}))
+		})
+	}
+}
+
+func TestUnitParseMarkdownLink(t *testing.T) {
+	label, url, length, ok := parseMarkdownLink(`[label](https://example.com "Title") rest`)
+	assert.True(t, ok)
+	assert.Equal(t, "label", label)
+	assert.Equal(t, "https://example.com", url)
+	assert.Equal(t, len(`[label](https://example.com "Title")`), length)
+
+	for _, text := range []string{"label](url)", "[label]", "[label] (url)", "[label](", "[label]( )", "[unclosed(url)"} {
+		_, _, _, ok := parseMarkdownLink(text)
+		assert.False(t, ok, text)
+	}
+}
+
+func TestUnitFindClosingDelimiter(t *testing.T) {
+	assert.Equal(t, 4, findClosingDelimiter("text* rest", "*"))
+	assert.Equal(t, 4, findClosingDelimiter("text** rest", "**"))
+	assert.Equal(t, 9, findClosingDelimiter("a **b** c*", "*"))
+	assert.Equal(t, 8, findClosingDelimiter(`a \* b c*`, "*"))
+	assert.Equal(t, 8, findClosingDelimiter("a *b* c***", "**"))
+	assert.Equal(t, 9, findClosingDelimiter("a `*` b c*", "*"))
+	assert.Equal(t, -1, findClosingDelimiter("a * b", "*"))
+	assert.Equal(t, -1, findClosingDelimiter("snake_case", "_"))
+}
@@ -0,0 +1,180 @@
+package knowledge_import
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"terraform-provider-genesyscloud/genesyscloud/util"
+	"terraform-provider-genesyscloud/genesyscloud/util/files"
+
+	"github.com/mypurecloud/platform-client-sdk-go/v149/platformclientv2"
+)
+
+/*
+The genesyscloud_knowledge_import_proxy.go file contains the proxy structures and methods that interact with the Genesys Cloud SDK.
+The proxy uploads the import files, manages the knowledge import jobs and looks up the documents of a knowledge base by external ID.
+*/
+
+// externalIdsPageSize is the number of external IDs looked up per request
+const externalIdsPageSize = 50
+
+var internalProxy *knowledgeImportProxy
+
+type uploadImportFileFunc func(ctx context.Context, p *knowledgeImportProxy, fileName string, content []byte) (string, *platformclientv2.APIResponse, error)
+type createImportJobFunc func(ctx context.Context, p *knowledgeImportProxy, knowledgeBaseId string, body *platformclientv2.Knowledgeimportjobrequest) (*platformclientv2.Knowledgeimportjobresponse, *platformclientv2.APIResponse, error)
+type getImportJobFunc func(ctx context.Context, p *knowledgeImportProxy, knowledgeBaseId string, importJobId string) (*platformclientv2.Knowledgeimportjobresponse, *platformclientv2.APIResponse, error)
+type updateImportJobStatusFunc func(ctx context.Context, p *knowledgeImportProxy, knowledgeBaseId string, importJobId string, status string) (*platformclientv2.Knowledgeimportjobresponse, *platformclientv2.APIResponse, error)
+type getDocumentsByExternalIdsFunc func(ctx context.Context, p *knowledgeImportProxy, knowledgeBaseId string, externalIds []string) (*[]platformclientv2.Knowledgedocumentresponse, *platformclientv2.APIResponse, error)
+type deleteDocumentFunc func(ctx context.Context, p *knowledgeImportProxy, knowledgeBaseId string, documentId string) (*platformclientv2.APIResponse, error)
+
+type knowledgeImportProxy struct {
+	clientConfig                  *platformclientv2.Configuration
+	knowledgeApi                  *platformclientv2.KnowledgeApi
+	uploadsApi                    *platformclientv2.UploadsApi
+	uploadImportFileAttr          uploadImportFileFunc
+	createImportJobAttr           createImportJobFunc
+	getImportJobAttr              getImportJobFunc
+	updateImportJobStatusAttr     updateImportJobStatusFunc
+	getDocumentsByExternalIdsAttr getDocumentsByExternalIdsFunc
+	deleteDocumentAttr            deleteDocumentFunc
+}
+
+func newKnowledgeImportProxy(clientConfig *platformclientv2.Configuration) *knowledgeImportProxy {
+	return &knowledgeImportProxy{
+		clientConfig:                  clientConfig,
+		knowledgeApi:                  platformclientv2.NewKnowledgeApiWithConfig(clientConfig),
+		uploadsApi:                    platformclientv2.NewUploadsApiWithConfig(clientConfig),
+		uploadImportFileAttr:          uploadImportFileFn,
+		createImportJobAttr:           createImportJobFn,
+		getImportJobAttr:              getImportJobFn,
+		updateImportJobStatusAttr:     updateImportJobStatusFn,
+		getDocumentsByExternalIdsAttr: getDocumentsByExternalIdsFn,
+		deleteDocumentAttr:            deleteDocumentFn,
+	}
+}
+
+func getKnowledgeImportProxy(clientConfig *platformclientv2.Configuration) *knowledgeImportProxy {
+	if internalProxy == nil {
+		internalProxy = newKnowledgeImportProxy(clientConfig)
+	}
+	return internalProxy
+}
+
+// uploadImportFile uploads the content of an import file and returns its upload key
+func (p *knowledgeImportProxy) uploadImportFile(ctx context.Context, fileName string, content []byte) (string, *platformclientv2.APIResponse, error) {
+	return p.uploadImportFileAttr(ctx, p, fileName, content)
+}
+
+func (p *knowledgeImportProxy) createImportJob(ctx context.Context, knowledgeBaseId string, body *platformclientv2.Knowledgeimportjobrequest) (*platformclientv2.Knowledgeimportjobresponse, *platformclientv2.APIResponse, error) {
+	return p.createImportJobAttr(ctx, p, knowledgeBaseId, body)
+}
+
+func (p *knowledgeImportProxy) getImportJob(ctx context.Context, knowledgeBaseId string, importJobId string) (*platformclientv2.Knowledgeimportjobresponse, *platformclientv2.APIResponse, error) {
+	return p.getImportJobAttr(ctx, p, knowledgeBaseId, importJobId)
+}
+
+func (p *knowledgeImportProxy) updateImportJobStatus(ctx context.Context, knowledgeBaseId string, importJobId string, status string) (*platformclientv2.Knowledgeimportjobresponse, *platformclientv2.APIResponse, error) {
+	return p.updateImportJobStatusAttr(ctx, p, knowledgeBaseId, importJobId, status)
+}
+
+// getDocumentsByExternalIds returns the documents, including drafts, of the knowledge base with one of the external IDs
+func (p *knowledgeImportProxy) getDocumentsByExternalIds(ctx context.Context, knowledgeBaseId string, externalIds []string) (*[]platformclientv2.Knowledgedocumentresponse, *platformclientv2.APIResponse, error) {
+	return p.getDocumentsByExternalIdsAttr(ctx, p, knowledgeBaseId, externalIds)
+}
+
+func (p *knowledgeImportProxy) deleteDocument(ctx context.Context, knowledgeBaseId string, documentId string) (*platformclientv2.APIResponse, error) {
+	return p.deleteDocumentAttr(ctx, p, knowledgeBaseId, documentId)
+}
+
+func uploadImportFileFn(_ context.Context, p *knowledgeImportProxy, fileName string, content []byte) (string, *platformclientv2.APIResponse, error) {
+	uploadRequest := platformclientv2.Uploadurlrequest{
+		FileName:                platformclientv2.String(fileName),
+		ContentType:             platformclientv2.String("application/json"),
+		SignedUrlTimeoutSeconds: platformclientv2.Int(3600),
+	}
+	upload, resp, err := p.uploadsApi.PostKnowledgeDocumentuploads(uploadRequest)
+	if err != nil {
+		return "", resp, fmt.Errorf("failed to get upload URL for knowledge import file %s: %s", fileName, err)
+	}
+	if upload.Url == nil || upload.UploadKey == nil {
+		return "", resp, fmt.Errorf("no upload URL returned for knowledge import file %s", fileName)
+	}
+
+	headers := map[string]string{"Content-Type": "application/json"}
+	if upload.Headers != nil {
+		for k, v := range *upload.Headers {
+			headers[k] = v
+		}
+	}
+	if _, err := files.NewS3Uploader(bytes.NewReader(content), nil, nil, headers, "PUT", *upload.Url).Upload(); err != nil {
+		return "", resp, fmt.Errorf("failed to upload knowledge import file %s: %s", fileName, err)
+	}
+	return *upload.UploadKey, resp, nil
+}
+
+func createImportJobFn(_ context.Context, p *knowledgeImportProxy, knowledgeBaseId string, body *platformclientv2.Knowledgeimportjobrequest) (*platformclientv2.Knowledgeimportjobresponse, *platformclientv2.APIResponse, error) {
+	job, resp, err := p.knowledgeApi.PostKnowledgeKnowledgebaseImportJobs(knowledgeBaseId, *body)
+	if err != nil {
+		return nil, resp, fmt.Errorf("failed to create knowledge import job: %s", err)
+	}
+	return job, resp, nil
+}
+
+func getImportJobFn(_ context.Context, p *knowledgeImportProxy, knowledgeBaseId string, importJobId string) (*platformclientv2.Knowledgeimportjobresponse, *platformclientv2.APIResponse, error) {
+	job, resp, err := p.knowledgeApi.GetKnowledgeKnowledgebaseImportJob(knowledgeBaseId, importJobId, nil)
+	if err != nil {
+		return nil, resp, fmt.Errorf("failed to get knowledge import job %s: %s", importJobId, err)
+	}
+	return job, resp, nil
+}
+
+func updateImportJobStatusFn(_ context.Context, p *knowledgeImportProxy, knowledgeBaseId string, importJobId string, status string) (*platformclientv2.Knowledgeimportjobresponse, *platformclientv2.APIResponse, error) {
+	job, resp, err := p.knowledgeApi.PatchKnowledgeKnowledgebaseImportJob(knowledgeBaseId, importJobId, platformclientv2.Importstatusrequest{Status: &status})
+	if err != nil {
+		return nil, resp, fmt.Errorf("failed to set status of knowledge import job %s to %s: %s", importJobId, status, err)
+	}
+	return job, resp, nil
+}
+
+func getDocumentsByExternalIdsFn(_ context.Context, p *knowledgeImportProxy, knowledgeBaseId string, externalIds []string) (*[]platformclientv2.Knowledgedocumentresponse, *platformclientv2.APIResponse, error) {
+	var (
+		documents []platformclientv2.Knowledgedocumentresponse
+		resp      *platformclientv2.APIResponse
+	)
+
+	for start := 0; start < len(externalIds); start += externalIdsPageSize {
+		end := min(start+externalIdsPageSize, len(externalIds))
+		after := ""
+		for {
+			listing, getResp, err := p.knowledgeApi.GetKnowledgeKnowledgebaseDocuments(knowledgeBaseId, "", after, fmt.Sprintf("%d", externalIdsPageSize), "", nil, nil, false, true, nil, nil, externalIds[start:end])
+			resp = getResp
+			if err != nil {
+				return nil, resp, fmt.Errorf("failed to get documents of knowledge base %s: %s", knowledgeBaseId, err)
+			}
+			if listing.Entities == nil || len(*listing.Entities) == 0 {
+				break
+			}
+			documents = append(documents, *listing.Entities...)
+
+			if listing.NextUri == nil || *listing.NextUri == "" {
+				break
+			}
+			after, err = util.GetQueryParamValueFromUri(*listing.NextUri, "after")
+			if err != nil {
+				return nil, resp, fmt.Errorf("failed to parse after cursor from documents nextUri: %s", err)
+			}
+			if after == "" {
+				break
+			}
+		}
+	}
+	return &documents, resp, nil
+}
+
+func deleteDocumentFn(_ context.Context, p *knowledgeImportProxy, knowledgeBaseId string, documentId string) (*platformclientv2.APIResponse, error) {
+	resp, err := p.knowledgeApi.DeleteKnowledgeKnowledgebaseDocument(knowledgeBaseId, documentId)
+	if err != nil {
+		return resp, fmt.Errorf("failed to delete knowledge document %s: %s", documentId, err)
+	}
+	return resp, nil
+}
@@ -0,0 +1,448 @@
+package knowledge_import
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"terraform-provider-genesyscloud/genesyscloud/provider"
+	"terraform-provider-genesyscloud/genesyscloud/util"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/retry"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/mypurecloud/platform-client-sdk-go/v149/platformclientv2"
+)
+
+const (
+	importJobStatusValidationCompleted = "ValidationCompleted"
+	importJobStatusValidationFailed    = "ValidationFailed"
+	importJobStatusStarted             = "Started"
+	importJobStatusCompleted           = "Completed"
+	importJobStatusPartialCompleted    = "PartialCompleted"
+	importJobStatusFailed              = "Failed"
+)
+
+// knowledgeImportPollInterval is the time waited between checks of the status of an import job
+var knowledgeImportPollInterval = 5 * time.Second
+
+// importedDocument is a document of the state of the resource
+type importedDocument struct {
+	externalId  string
+	documentId  string
+	path        string
+	title       string
+	contentHash string
+}
+
+func createKnowledgeImport(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	sdkConfig := meta.(*provider.ProviderMeta).ClientConfig
+	p := getKnowledgeImportProxy(sdkConfig)
+
+	// The ID is set before the import so the documents saved in the state by a failed sync are deleted with the tainted resource
+	d.SetId(uuid.NewString())
+
+	log.Printf("Importing knowledge documents from %s", d.Get("directory").(string))
+	if diagErr := syncKnowledgeDocuments(ctx, d, p, nil, d.Timeout(schema.TimeoutCreate)); diagErr != nil {
+		return diagErr
+	}
+
+	log.Printf("Imported knowledge documents from %s", d.Get("directory").(string))
+	return readKnowledgeImport(ctx, d, meta)
+}
+
+func readKnowledgeImport(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	sdkConfig := meta.(*provider.ProviderMeta).ClientConfig
+	p := getKnowledgeImportProxy(sdkConfig)
+	knowledgeBaseId := d.Get("knowledge_base_id").(string)
+
+	documents := getImportedDocuments(d.Get("documents").([]interface{}))
+	if len(documents) == 0 {
+		return nil
+	}
+
+	log.Printf("Reading documents of knowledge import %s", d.Id())
+
+	return util.WithRetriesForRead(ctx, d, func() *retry.RetryError {
+		documentIds, resp, err := getDocumentIdsByExternalId(ctx, p, knowledgeBaseId, documents)
+		if err != nil {
+			if util.IsStatus404(resp) {
+				return retry.RetryableError(util.BuildWithRetriesApiDiagnosticError(ResourceType, fmt.Sprintf("Failed to read documents of knowledge base %s | error: %s", knowledgeBaseId, err), resp))
+			}
+			return retry.NonRetryableError(util.BuildWithRetriesApiDiagnosticError(ResourceType, fmt.Sprintf("Failed to read documents of knowledge base %s | error: %s", knowledgeBaseId, err), resp))
+		}
+
+		// Documents deleted outside of Terraform are imported again on the next apply
+		var existing []importedDocument
+		for _, document := range documents {
+			documentId, ok := documentIds[document.externalId]
+			if !ok {
+				log.Printf("Document %s of knowledge import %s no longer exists", document.externalId, d.Id())
+				continue
+			}
+			document.documentId = documentId
+			existing = append(existing, document)
+		}
+		if len(existing) != len(documents) {
+			_ = d.Set("content_hash", "")
+		}
+		_ = d.Set("documents", flattenImportedDocuments(existing))
+
+		log.Printf("Read %d documents of knowledge import %s", len(existing), d.Id())
+		return nil
+	})
+}
+
+func updateKnowledgeImport(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	sdkConfig := meta.(*provider.ProviderMeta).ClientConfig
+	p := getKnowledgeImportProxy(sdkConfig)
+
+	if !d.HasChange("content_hash") {
+		return readKnowledgeImport(ctx, d, meta)
+	}
+
+	oldDocuments, _ := d.GetChange("documents")
+	synced := getImportedDocuments(oldDocuments.([]interface{}))
+
+	log.Printf("Syncing knowledge documents from %s", d.Get("directory").(string))
+	if diagErr := syncKnowledgeDocuments(ctx, d, p, synced, d.Timeout(schema.TimeoutUpdate)); diagErr != nil {
+		return diagErr
+	}
+
+	log.Printf("Synced knowledge documents from %s", d.Get("directory").(string))
+	return readKnowledgeImport(ctx, d, meta)
+}
+
+func deleteKnowledgeImport(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	sdkConfig := meta.(*provider.ProviderMeta).ClientConfig
+	p := getKnowledgeImportProxy(sdkConfig)
+	knowledgeBaseId := d.Get("knowledge_base_id").(string)
+
+	documents := getImportedDocuments(d.Get("documents").([]interface{}))
+	log.Printf("Deleting %d documents of knowledge import %s", len(documents), d.Id())
+	if diagErr := deleteKnowledgeDocuments(ctx, p, knowledgeBaseId, documents); diagErr != nil {
+		return diagErr
+	}
+
+	log.Printf("Deleted %d documents of knowledge import %s", len(documents), d.Id())
+	return nil
+}
+
+// customizeKnowledgeImportDiff converts the files of the directory during the plan so that added, changed and removed files update the resource
+func customizeKnowledgeImportDiff(_ context.Context, diff *schema.ResourceDiff, _ interface{}) error {
+	if !diff.NewValueKnown("directory") || !diff.NewValueKnown("visible") {
+		return setKnowledgeImportComputed(diff)
+	}
+
+	directory := diff.Get("directory").(string)
+	documents, err := readImportDocuments(directory, diff.Get("visible").(bool))
+	if err != nil {
+		// The files may not exist until other resources are applied. Conversion errors are reported when the resource is applied.
+		log.Printf("Failed to read knowledge documents from %s during plan: %v", directory, err)
+		return setKnowledgeImportComputed(diff)
+	}
+
+	if hash := hashImportDocuments(documents); hash != diff.Get("content_hash").(string) {
+		if err := diff.SetNew("content_hash", hash); err != nil {
+			return err
+		}
+		return setKnowledgeImportSyncComputed(diff)
+	}
+	return nil
+}
+
+func setKnowledgeImportComputed(diff *schema.ResourceDiff) error {
+	if err := diff.SetNewComputed("content_hash"); err != nil {
+		return err
+	}
+	return setKnowledgeImportSyncComputed(diff)
+}
+
+func setKnowledgeImportSyncComputed(diff *schema.ResourceDiff) error {
+	for _, key := range []string{"documents", "last_import_job_id", "last_import_status"} {
+		if err := diff.SetNewComputed(key); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// syncKnowledgeDocuments imports the documents of the directory which are new or changed since the synced documents, and deletes the
+// documents of removed files
+func syncKnowledgeDocuments(ctx context.Context, d *schema.ResourceData, p *knowledgeImportProxy, synced []importedDocument, timeout time.Duration) diag.Diagnostics {
+	knowledgeBaseId := d.Get("knowledge_base_id").(string)
+	directory := d.Get("directory").(string)
+
+	// Keep the synced documents and the documents created by the failed sync so that the changes are attempted again on the next
+	// apply, and the created documents are not left in the knowledge base if the resource is destroyed
+	failSync := func(attempted []importDocument, diagErr diag.Diagnostics) diag.Diagnostics {
+		_ = d.Set("content_hash", "")
+		documents := append([]importedDocument{}, synced...)
+		documents = append(documents, findCreatedDocuments(ctx, p, knowledgeBaseId, attempted, synced)...)
+		_ = d.Set("documents", flattenImportedDocuments(documents))
+		return diagErr
+	}
+
+	documents, err := readImportDocuments(directory, d.Get("visible").(bool))
+	if err != nil {
+		return failSync(nil, util.BuildDiagnosticError(ResourceType, fmt.Sprintf("Failed to read knowledge documents from %s", directory), err))
+	}
+
+	changed := diffImportDocuments(documents, synced)
+	if len(changed) > 0 {
+		log.Printf("Importing %d new or changed documents to knowledge base %s", len(changed), knowledgeBaseId)
+		if diagErr := importKnowledgeDocuments(ctx, d, p, knowledgeBaseId, changed, timeout); diagErr != nil {
+			return failSync(changed, diagErr)
+		}
+	}
+
+	desired := make([]importedDocument, len(documents))
+	for i, document := range documents {
+		desired[i] = importedDocument{externalId: document.externalId, path: document.path, title: document.title, contentHash: document.hash}
+	}
+	documentIds, resp, err := getDocumentIdsByExternalId(ctx, p, knowledgeBaseId, desired)
+	if err != nil {
+		return failSync(changed, util.BuildAPIDiagnosticError(ResourceType, fmt.Sprintf("Failed to read documents of knowledge base %s error: %s", knowledgeBaseId, err), resp))
+	}
+	var missing []string
+	for i := range desired {
+		desired[i].documentId = documentIds[desired[i].externalId]
+		if desired[i].documentId == "" {
+			missing = append(missing, fmt.Sprintf("%s (%s)", desired[i].externalId, desired[i].path))
+		}
+	}
+	if len(missing) > 0 {
+		return failSync(changed, util.BuildDiagnosticError(ResourceType, fmt.Sprintf("Documents were not found in knowledge base %s after the import", knowledgeBaseId), fmt.Errorf("%s", strings.Join(missing, ", "))))
+	}
+
+	removed := removedImportDocuments(desired, synced)
+	if len(removed) > 0 && d.Get("delete_removed").(bool) {
+		log.Printf("Deleting %d documents of removed files from knowledge base %s", len(removed), knowledgeBaseId)
+		if diagErr := deleteKnowledgeDocuments(ctx, p, knowledgeBaseId, removed); diagErr != nil {
+			return failSync(changed, diagErr)
+		}
+	}
+
+	_ = d.Set("documents", flattenImportedDocuments(desired))
+	_ = d.Set("content_hash", hashImportDocuments(documents))
+	return nil
+}
+
+// findCreatedDocuments returns the documents of the knowledge base created by the import of the attempted documents which are not
+// synced yet. Their content hash is empty so that they are imported again on the next apply.
+func findCreatedDocuments(ctx context.Context, p *knowledgeImportProxy, knowledgeBaseId string, attempted []importDocument, synced []importedDocument) []importedDocument {
+	syncedIds := make(map[string]bool, len(synced))
+	for _, document := range synced {
+		syncedIds[document.externalId] = true
+	}
+	var created []importedDocument
+	for _, document := range attempted {
+		if !syncedIds[document.externalId] {
+			created = append(created, importedDocument{externalId: document.externalId, path: document.path, title: document.title})
+		}
+	}
+	if len(created) == 0 {
+		return nil
+	}
+
+	documentIds, _, err := getDocumentIdsByExternalId(ctx, p, knowledgeBaseId, created)
+	if err != nil {
+		log.Printf("Failed to read the documents created in knowledge base %s by the failed import: %v", knowledgeBaseId, err)
+		return nil
+	}
+	var result []importedDocument
+	for _, document := range created {
+		if documentId, ok := documentIds[document.externalId]; ok {
+			document.documentId = documentId
+			result = append(result, document)
+		}
+	}
+	return result
+}
+
+// diffImportDocuments returns the documents which are new or changed since the last sync
+func diffImportDocuments(documents []importDocument, synced []importedDocument) []importDocument {
+	syncedHashes := make(map[string]string, len(synced))
+	for _, document := range synced {
+		syncedHashes[document.externalId] = document.contentHash
+	}
+
+	var changed []importDocument
+	for _, document := range documents {
+		if hash, ok := syncedHashes[document.externalId]; !ok || hash != document.hash {
+			changed = append(changed, document)
+		}
+	}
+	return changed
+}
+
+// removedImportDocuments returns the synced documents which are no longer in the directory
+func removedImportDocuments(desired []importedDocument, synced []importedDocument) []importedDocument {
+	desiredIds := make(map[string]bool, len(desired))
+	for _, document := range desired {
+		desiredIds[document.externalId] = true
+	}
+
+	var removed []importedDocument
+	for _, document := range synced {
+		if !desiredIds[document.externalId] {
+			removed = append(removed, document)
+		}
+	}
+	return removed
+}
+
+// importKnowledgeDocuments uploads the documents in an import file and waits for the import job to complete
+func importKnowledgeDocuments(ctx context.Context, d *schema.ResourceData, p *knowledgeImportProxy, knowledgeBaseId string, documents []importDocument, timeout time.Duration) diag.Diagnostics {
+	content, err := buildImportFile(documents)
+	if err != nil {
+		return util.BuildDiagnosticError(ResourceType, "Failed to build knowledge import file", err)
+	}
+
+	uploadKey, resp, err := p.uploadImportFile(ctx, fmt.Sprintf("knowledge-import-%s.json", d.Id()), content)
+	if err != nil {
+		return util.BuildAPIDiagnosticError(ResourceType, fmt.Sprintf("Failed to upload knowledge import file error: %s", err), resp)
+	}
+
+	job, resp, err := p.createImportJob(ctx, knowledgeBaseId, &platformclientv2.Knowledgeimportjobrequest{
+		UploadKey:            &uploadKey,
+		FileType:             platformclientv2.String("Json"),
+		Settings:             &platformclientv2.Knowledgeimportjobsettings{ImportAsNew: platformclientv2.Bool(false)},
+		SkipConfirmationStep: platformclientv2.Bool(true),
+	})
+	if err != nil {
+		return util.BuildAPIDiagnosticError(ResourceType, fmt.Sprintf("Failed to create import job for knowledge base %s error: %s", knowledgeBaseId, err), resp)
+	}
+	jobId := *job.Id
+	_ = d.Set("last_import_job_id", jobId)
+	log.Printf("Created knowledge import job %s", jobId)
+
+	return util.WithRetries(ctx, timeout, func() *retry.RetryError {
+		job, resp, err := p.getImportJob(ctx, knowledgeBaseId, jobId)
+		if err != nil {
+			return retry.NonRetryableError(util.BuildWithRetriesApiDiagnosticError(ResourceType, fmt.Sprintf("Failed to read knowledge import job %s error: %s", jobId, err), resp))
+		}
+
+		status := ""
+		if job.Status != nil {
+			status = *job.Status
+		}
+		_ = d.Set("last_import_status", status)
+
+		switch status {
+		case importJobStatusCompleted:
+			log.Printf("Knowledge import job %s completed", jobId)
+			return nil
+		case importJobStatusPartialCompleted, importJobStatusFailed, importJobStatusValidationFailed:
+			return retry.NonRetryableError(fmt.Errorf("knowledge import job %s finished with status %s: %w", jobId, status, importJobErrors(job, documents)))
+		case importJobStatusValidationCompleted:
+			// Jobs normally skip the confirmation step, but a job waiting for confirmation is started
+			if _, resp, err := p.updateImportJobStatus(ctx, knowledgeBaseId, jobId, importJobStatusStarted); err != nil {
+				return retry.NonRetryableError(util.BuildWithRetriesApiDiagnosticError(ResourceType, fmt.Sprintf("Failed to start knowledge import job %s error: %s", jobId, err), resp))
+			}
+		}
+
+		time.Sleep(knowledgeImportPollInterval)
+		return retry.RetryableError(fmt.Errorf("knowledge import job %s did not complete within %v. Status: %s", jobId, timeout, status))
+	})
+}
+
+// importJobErrors returns the errors of the import job report with the paths of the files of the documents
+func importJobErrors(job *platformclientv2.Knowledgeimportjobresponse, documents []importDocument) error {
+	if job.Report == nil || job.Report.Errors == nil || len(*job.Report.Errors) == 0 {
+		return fmt.Errorf("no errors reported")
+	}
+
+	var errs []error
+	for _, jobError := range *job.Report.Errors {
+		message := ""
+		if jobError.Message != nil {
+			message = *jobError.Message
+		}
+		if jobError.DocumentIndex != nil && *jobError.DocumentIndex >= 0 && *jobError.DocumentIndex < len(documents) {
+			document := documents[*jobError.DocumentIndex]
+			message = fmt.Sprintf("%s (%s): %s", document.path, document.externalId, message)
+		}
+		errs = append(errs, errors.New(message))
+		if len(errs) == maxDocumentErrors {
+			break
+		}
+	}
+	if remaining := len(*job.Report.Errors) - len(errs); remaining > 0 {
+		errs = append(errs, fmt.Errorf("%d more errors", remaining))
+	}
+	return errors.Join(errs...)
+}
+
+// getDocumentIdsByExternalId returns the IDs of the documents of the knowledge base keyed by external ID
+func getDocumentIdsByExternalId(ctx context.Context, p *knowledgeImportProxy, knowledgeBaseId string, documents []importedDocument) (map[string]string, *platformclientv2.APIResponse, error) {
+	externalIds := make([]string, len(documents))
+	for i, document := range documents {
+		externalIds[i] = document.externalId
+	}
+
+	knowledgeDocuments, resp, err := p.getDocumentsByExternalIds(ctx, knowledgeBaseId, externalIds)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	documentIds := make(map[string]string, len(*knowledgeDocuments))
+	for _, document := range *knowledgeDocuments {
+		if document.ExternalId != nil && document.Id != nil {
+			documentIds[*document.ExternalId] = *document.Id
+		}
+	}
+	return documentIds, resp, nil
+}
+
+func deleteKnowledgeDocuments(ctx context.Context, p *knowledgeImportProxy, knowledgeBaseId string, documents []importedDocument) diag.Diagnostics {
+	for _, document := range documents {
+		if document.documentId == "" {
+			continue
+		}
+		resp, err := p.deleteDocument(ctx, knowledgeBaseId, document.documentId)
+		if err != nil {
+			if util.IsStatus404(resp) {
+				log.Printf("Document %s of knowledge base %s already deleted", document.documentId, knowledgeBaseId)
+				continue
+			}
+			return util.BuildAPIDiagnosticError(ResourceType, fmt.Sprintf("Failed to delete document %s (%s) of knowledge base %s error: %s", document.documentId, document.externalId, knowledgeBaseId, err), resp)
+		}
+	}
+	return nil
+}
+
+func getImportedDocuments(documents []interface{}) []importedDocument {
+	result := make([]importedDocument, 0, len(documents))
+	for _, document := range documents {
+		documentMap, ok := document.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		result = append(result, importedDocument{
+			externalId:  documentMap["external_id"].(string),
+			documentId:  documentMap["document_id"].(string),
+			path:        documentMap["path"].(string),
+			title:       documentMap["title"].(string),
+			contentHash: documentMap["content_hash"].(string),
+		})
+	}
+	return result
+}
+
+func flattenImportedDocuments(documents []importedDocument) []interface{} {
+	result := make([]interface{}, len(documents))
+	for i, document := range documents {
+		result[i] = map[string]interface{}{
+			"external_id":  document.externalId,
+			"document_id":  document.documentId,
+			"path":         document.path,
+			"title":        document.title,
+			"content_hash": document.contentHash,
+		}
+	}
+	return result
+}
@@ -0,0 +1,114 @@
+package knowledge_import
+
+import (
+	"terraform-provider-genesyscloud/genesyscloud/provider"
+	registrar "terraform-provider-genesyscloud/genesyscloud/resource_register"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+const ResourceType = "genesyscloud_knowledge_import"
+
+// SetRegistrar registers all of the resources, datasources and exporters in the package
+func SetRegistrar(regInstance registrar.Registrar) {
+	regInstance.RegisterResource(ResourceType, ResourceKnowledgeImport())
+}
+
+var importedDocumentResource = &schema.Resource{
+	Schema: map[string]*schema.Schema{
+		"external_id": {
+			Description: "External ID of the document. Set by the external_id front matter key or derived from the path of the file.",
+			Type:        schema.TypeString,
+			Computed:    true,
+		},
+		"document_id": {
+			Description: "ID of the knowledge document.",
+			Type:        schema.TypeString,
+			Computed:    true,
+		},
+		"path": {
+			Description: "Path of the file relative to the directory.",
+			Type:        schema.TypeString,
+			Computed:    true,
+		},
+		"title": {
+			Description: "Title of the document.",
+			Type:        schema.TypeString,
+			Computed:    true,
+		},
+		"content_hash": {
+			Description: "Hash of the converted document. Used to import only the documents that changed.",
+			Type:        schema.TypeString,
+			Computed:    true,
+		},
+	},
+}
+
+func ResourceKnowledgeImport() *schema.Resource {
+	return &schema.Resource{
+		Description: `Genesys Cloud Knowledge Import. Syncs the documents of a knowledge base from a directory of Markdown and HTML files using knowledge import jobs.
+A file can start with a YAML front matter block setting the external_id, title, category, labels, alternatives and visible properties of the document.
+Documents are matched by external ID: new and changed files are imported, and documents of removed files are deleted when delete_removed is true.`,
+
+		CreateContext: provider.CreateWithPooledClient(createKnowledgeImport),
+		ReadContext:   provider.ReadWithPooledClient(readKnowledgeImport),
+		UpdateContext: provider.UpdateWithPooledClient(updateKnowledgeImport),
+		DeleteContext: provider.DeleteWithPooledClient(deleteKnowledgeImport),
+		CustomizeDiff: customizeKnowledgeImportDiff,
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(20 * time.Minute),
+			Update: schema.DefaultTimeout(20 * time.Minute),
+			Delete: schema.DefaultTimeout(20 * time.Minute),
+		},
+		SchemaVersion: 1,
+		Schema: map[string]*schema.Schema{
+			"knowledge_base_id": {
+				Description: "ID of the knowledge base the documents are imported to.",
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+			},
+			"directory": {
+				Description:  "Path to the directory of Markdown (.md, .markdown) and HTML (.html, .htm) files. Subdirectories are included and hidden files are ignored.",
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+			"visible": {
+				Description: "Visibility of the documents which do not set visible in their front matter.",
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     true,
+			},
+			"delete_removed": {
+				Description: "If true, the documents of files removed from the directory are deleted from the knowledge base. If false, they are only removed from the state.",
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     true,
+			},
+			"content_hash": {
+				Description: "Hash of the converted documents of the directory. Changes when a file is added, changed or removed.",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"documents": {
+				Description: "Documents imported from the directory, sorted by external ID.",
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem:        importedDocumentResource,
+			},
+			"last_import_job_id": {
+				Description: "ID of the last knowledge import job.",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"last_import_status": {
+				Description: "Status of the last knowledge import job.",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+		},
+	}
+}
@@ -0,0 +1,88 @@
+package knowledge_import
+
+import (
+	"fmt"
+	gcloud "terraform-provider-genesyscloud/genesyscloud"
+	"terraform-provider-genesyscloud/genesyscloud/provider"
+	"terraform-provider-genesyscloud/genesyscloud/util"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/mypurecloud/platform-client-sdk-go/v149/platformclientv2"
+)
+
+func TestAccResourceKnowledgeImport(t *testing.T) {
+	var (
+		knowledgeBaseResourceLabel = "test_knowledgebase"
+		knowledgeBaseName          = "Terraform Knowledge Base " + uuid.NewString()
+		importResourceLabel        = "test_import"
+		importFullPath             = ResourceType + "." + importResourceLabel
+		testDataPath               = "../../test/data/resource/genesyscloud_knowledge_import"
+	)
+
+	knowledgeBaseConfig := gcloud.GenerateKnowledgeKnowledgebaseResource(knowledgeBaseResourceLabel, knowledgeBaseName, "Knowledge import test", "en-US")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { util.TestAccPreCheck(t) },
+		ProviderFactories: provider.GetProviderFactories(providerResources, providerDataSources),
+		Steps: []resource.TestStep{
+			{
+				// Import a Markdown and an HTML document
+				Config: knowledgeBaseConfig + generateKnowledgeImportResource(importResourceLabel, knowledgeBaseResourceLabel, testDataPath+"/v1"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(importFullPath, "documents.#", "2"),
+					resource.TestCheckResourceAttr(importFullPath, "documents.0.external_id", "returns-policy"),
+					resource.TestCheckResourceAttr(importFullPath, "documents.0.title", "Returns policy"),
+					resource.TestCheckResourceAttrSet(importFullPath, "documents.0.document_id"),
+					resource.TestCheckResourceAttr(importFullPath, "documents.1.external_id", "shipping"),
+					resource.TestCheckResourceAttr(importFullPath, "documents.1.title", "Shipping times"),
+					resource.TestCheckResourceAttr(importFullPath, "last_import_status", "Completed"),
+				),
+			},
+			{
+				// Update a document, add a document and delete the document of the removed file
+				Config: knowledgeBaseConfig + generateKnowledgeImportResource(importResourceLabel, knowledgeBaseResourceLabel, testDataPath+"/v2"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(importFullPath, "documents.#", "2"),
+					resource.TestCheckResourceAttr(importFullPath, "documents.0.external_id", "returns-policy"),
+					resource.TestCheckResourceAttr(importFullPath, "documents.1.external_id", "warranty"),
+					resource.TestCheckResourceAttr(importFullPath, "documents.1.path", "warranty.md"),
+					resource.TestCheckResourceAttr(importFullPath, "last_import_status", "Completed"),
+				),
+			},
+		},
+		CheckDestroy: testVerifyKnowledgeImportDocumentsDestroyed,
+	})
+}
+
+func generateKnowledgeImportResource(resourceLabel, knowledgeBaseResourceLabel, directory string) string {
+	return fmt.Sprintf(`resource "%s" "%s" {
+	knowledge_base_id = genesyscloud_knowledge_knowledgebase.%s.id
+	directory         = "%s"
+}
+`, ResourceType, resourceLabel, knowledgeBaseResourceLabel, directory)
+}
+
+func testVerifyKnowledgeImportDocumentsDestroyed(state *terraform.State) error {
+	knowledgeAPI := platformclientv2.NewKnowledgeApi()
+	for _, rs := range state.RootModule().Resources {
+		if rs.Type != ResourceType {
+			continue
+		}
+		knowledgeBaseId := rs.Primary.Attributes["knowledge_base_id"]
+		documents, resp, err := knowledgeAPI.GetKnowledgeKnowledgebaseDocuments(knowledgeBaseId, "", "", "", "", nil, nil, false, true, nil, nil, []string{"returns-policy", "warranty"})
+		if util.IsStatus404(resp) {
+			// The knowledge base was deleted with its documents
+			continue
+		}
+		if err != nil {
+			return fmt.Errorf("unexpected error: %s", err)
+		}
+		if documents.Entities != nil && len(*documents.Entities) > 0 {
+			return fmt.Errorf("documents of knowledge import %s still exist", rs.Primary.ID)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,418 @@
+package knowledge_import
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"terraform-provider-genesyscloud/genesyscloud/provider"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/mypurecloud/platform-client-sdk-go/v149/platformclientv2"
+	"github.com/stretchr/testify/assert"
+)
+
+// describeBlocks renders document blocks as one line per block to keep the assertions readable. Formatted text is written as
+// [text|marks|hyperlink] and images as <url>.
+func describeBlocks(blocks []platformclientv2.Documentbodyblock) []string {
+	describeContent := func(varType *string, text *platformclientv2.Documenttext, image *platformclientv2.Documentbodyimage) string {
+		if *varType == contentTypeImage {
+			return "<" + *image.Url + ">"
+		}
+		var marks []string
+		if text.Marks != nil {
+			marks = *text.Marks
+		}
+		hyperlink := ""
+		if text.Hyperlink != nil {
+			hyperlink = *text.Hyperlink
+		}
+		if len(marks) == 0 && hyperlink == "" {
+			return *text.Text
+		}
+		return fmt.Sprintf("[%s|%s|%s]", *text.Text, strings.Join(marks, ","), hyperlink)
+	}
+
+	var lines []string
+	for _, block := range blocks {
+		switch *block.VarType {
+		case blockTypeParagraph:
+			var line strings.Builder
+			for _, content := range *block.Paragraph.Blocks {
+				line.WriteString(describeContent(content.VarType, content.Text, content.Image))
+			}
+			lines = append(lines, *block.Paragraph.Properties.FontType+": "+line.String())
+		case blockTypeImage:
+			lines = append(lines, "Image: "+*block.Image.Url)
+		default:
+			var items []string
+			for _, item := range *block.List.Blocks {
+				var line strings.Builder
+				for _, content := range *item.Blocks {
+					line.WriteString(describeContent(content.VarType, content.Text, content.Image))
+				}
+				items = append(items, line.String())
+			}
+			lines = append(lines, *block.VarType+": "+strings.Join(items, " / "))
+		}
+	}
+	return lines
+}
+
+func TestUnitConvertMarkdown(t *testing.T) {
+	blocks, title := convertMarkdown(`# Returns *policy*
+
+Items can be returned within **30 days**
+of delivery. See the [returns portal](https://example.com/returns "Portal") or use ` + "`RETURN-1`" + `.
+
+## Steps
+
+1. Open your **order**
+2. Select the items
+   to return
+
+- Refunds use the payment method
+- ~~Exchanges~~ are not supported
+* Labels are free
+
+> Contact support_team for damaged items.
+
+![Return label](https://example.com/label.png)
+
+` + "```" + `
+POST /returns
+{"order": 1}
+` + "```" + `
+
+---
+Escaped \*asterisks\* and 2 * 3 stay as text.`)
+
+	assert.Equal(t, "Returns policy", title)
+	assert.Equal(t, []string{
+		"Heading1: Returns [policy|Italic|]",
+		"Paragraph: Items can be returned within [30 days|Bold|] of delivery. See the [returns portal||https://example.com/returns] or use RETURN-1.",
+		"Heading2: Steps",
+		"OrderedList: Open your [order|Bold|] / Select the items to return",
+		"UnorderedList: Refunds use the payment method / [Exchanges|Strikethrough|] are not supported / Labels are free",
+		"Paragraph: Contact support_team for damaged items.",
+		"Image: https://example.com/label.png",
+		"Preformatted: POST /returns\n{\"order\": 1}",
+		"Paragraph: Escaped *asterisks* and 2 * 3 stay as text.",
+	}, describeBlocks(blocks))
+}
+
+func TestUnitConvertMarkdownNestedEmphasis(t *testing.T) {
+	contents := parseMarkdownInline("*all **of** it* and [![logo](https://example.com/logo.png)](https://example.com)")
+	assert.Equal(t, []string{
+		"Paragraph: [all |Italic|][of|Italic,Bold|][ it|Italic|] and <https://example.com/logo.png>",
+	}, describeBlocks([]platformclientv2.Documentbodyblock{paragraphBlock(fontTypeParagraph, contents)}))
+	assert.Equal(t, "https://example.com", *contents[len(contents)-1].Image.Hyperlink)
+}
+
+func TestUnitConvertHtml(t *testing.T) {
+	blocks, title, err := convertHtml(`<html>
+<head><title> Shipping   times </title><style>p { color: red; }</style></head>
+<body>
+  <h1>Shipping</h1>
+  <p>Orders ship within <strong>2 days</strong>.<br>Express orders
+     ship <em>the same day</em>.</p>
+  <div>Loose <a href="https://example.com/track">tracking <u>link</u></a></div>
+  <ul><li>Standard</li><li><b>Express</b></li></ul>
+  <ol><li>Pack</li><li>Ship</li></ol>
+  <img src="https://example.com/truck.png">
+  <pre>Carrier: DHL
+  Zone: 1</pre>
+  <table><tr><th>Zone</th><th>Days</th></tr><tr><td>1</td><td>2</td></tr></table>
+  <script>alert("ignored")</script>
+</body>
+</html>`)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "Shipping times", title)
+	assert.Equal(t, []string{
+		"Heading1: Shipping",
+		"Paragraph: Orders ship within [2 days|Bold|].\nExpress orders ship [the same day|Italic|].",
+		"Paragraph: Loose [tracking ||https://example.com/track][link|Underline|https://example.com/track]",
+		"UnorderedList: Standard / [Express|Bold|]",
+		"OrderedList: Pack / Ship",
+		"Image: https://example.com/truck.png",
+		"Preformatted: Carrier: DHL\n  Zone: 1",
+		"Paragraph: [Zone|Bold|] | [Days|Bold|]",
+		"Paragraph: 1 | 2",
+	}, describeBlocks(blocks))
+
+	// Without a title element the first h1 is the title
+	_, title, err = convertHtml("<h1>Heading <i>title</i></h1><p>Text</p>")
+	assert.NoError(t, err)
+	assert.Equal(t, "Heading title", title)
+}
+
+func TestUnitParseImportDocument(t *testing.T) {
+	document, err := parseImportDocument("policies/returns.md", []byte(`---
+title: Returns
+category: Policies
+labels: [Returns, Orders]
+alternatives:
+  - How do I return an item?
+  - phrase: Refund
+    autocomplete: true
+visible: false
+---
+# Returns policy
+
+Text.
+`), true)
+	assert.NoError(t, err)
+	assert.Equal(t, "policies/returns", document.externalId)
+	assert.Equal(t, "Returns", document.title)
+	assert.Equal(t, "Policies", document.category)
+	assert.Equal(t, []string{"Returns", "Orders"}, document.labels)
+	assert.Equal(t, []documentAlternative{{Phrase: "How do I return an item?"}, {Phrase: "Refund", Autocomplete: true}}, document.alternatives)
+	assert.False(t, document.visible)
+	assert.NotEmpty(t, document.hash)
+
+	// The title defaults to the first heading and the visibility to the resource setting
+	document, err = parseImportDocument("faq.html", []byte("---\r\nexternal_id: faq-1\r\n---\r\n<h1>FAQ</h1><p>Answer</p>"), true)
+	assert.NoError(t, err)
+	assert.Equal(t, "faq-1", document.externalId)
+	assert.Equal(t, "FAQ", document.title)
+	assert.True(t, document.visible)
+
+	_, err = parseImportDocument("bad.md", []byte("---\ntitel: Typo\n---\nText"), true)
+	assert.ErrorContains(t, err, "field titel not found")
+
+	_, err = parseImportDocument("unterminated.md", []byte("---\ntitle: Title\nText"), true)
+	assert.ErrorContains(t, err, "front matter is not terminated")
+
+	_, err = parseImportDocument("empty.md", []byte("---\ntitle: Title\n---\n"), true)
+	assert.ErrorContains(t, err, "the document has no content")
+}
+
+func writeTestDocuments(t *testing.T, dir string, documents map[string]string) {
+	for name, content := range documents {
+		path := filepath.Join(dir, name)
+		assert.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+		if content == "" {
+			assert.NoError(t, os.Remove(path))
+			continue
+		}
+		assert.NoError(t, os.WriteFile(path, []byte(content), 0644))
+	}
+}
+
+func TestUnitReadImportDocuments(t *testing.T) {
+	dir := t.TempDir()
+	writeTestDocuments(t, dir, map[string]string{
+		"b.md":              "# B",
+		"sub/a.html":        "<p>A</p>",
+		"notes.txt":         "ignored",
+		".drafts/hidden.md": "# Hidden",
+	})
+
+	documents, err := readImportDocuments(dir, true)
+	assert.NoError(t, err)
+	assert.Len(t, documents, 2)
+	assert.Equal(t, "b", documents[0].externalId)
+	assert.Equal(t, "sub/a", documents[1].externalId)
+	assert.Equal(t, "sub/a.html", documents[1].path)
+	assert.Equal(t, "a", documents[1].title)
+
+	writeTestDocuments(t, dir, map[string]string{"c.md": "---\nexternal_id: b\n---\n# C"})
+	_, err = readImportDocuments(dir, true)
+	assert.ErrorContains(t, err, "c.md: external ID 'b' is already used by b.md")
+
+	_, err = readImportDocuments(filepath.Join(dir, "b.md"), true)
+	assert.ErrorContains(t, err, "is not a directory")
+}
+
+// buildImportTestProxy returns a proxy importing the documents of the uploaded files into the documents map, keyed by external ID
+func buildImportTestProxy(t *testing.T, knowledgeBaseId string, documents map[string]string) *knowledgeImportProxy {
+	uploads := make(map[string][]byte)
+	jobs := make(map[string]*platformclientv2.Knowledgeimportjobresponse)
+	p := &knowledgeImportProxy{}
+
+	p.uploadImportFileAttr = func(_ context.Context, _ *knowledgeImportProxy, _ string, content []byte) (string, *platformclientv2.APIResponse, error) {
+		uploadKey := uuid.NewString()
+		uploads[uploadKey] = content
+		return uploadKey, &platformclientv2.APIResponse{StatusCode: http.StatusOK}, nil
+	}
+	p.createImportJobAttr = func(_ context.Context, _ *knowledgeImportProxy, id string, body *platformclientv2.Knowledgeimportjobrequest) (*platformclientv2.Knowledgeimportjobresponse, *platformclientv2.APIResponse, error) {
+		assert.Equal(t, knowledgeBaseId, id)
+		assert.Equal(t, "Json", *body.FileType)
+		assert.False(t, *body.Settings.ImportAsNew)
+
+		var file importFile
+		assert.NoError(t, json.Unmarshal(uploads[*body.UploadKey], &file))
+
+		job := &platformclientv2.Knowledgeimportjobresponse{Id: platformclientv2.String(uuid.NewString()), Status: platformclientv2.String(importJobStatusCompleted)}
+		var errs []platformclientv2.Knowledgeimportjoberror
+		for i, document := range file.Documents {
+			if strings.Contains(document.Title, "Invalid") {
+				errs = append(errs, platformclientv2.Knowledgeimportjoberror{Message: platformclientv2.String("invalid title"), DocumentIndex: platformclientv2.Int(i)})
+				continue
+			}
+			if _, ok := documents[document.ExternalId]; !ok {
+				documents[document.ExternalId] = uuid.NewString()
+			}
+		}
+		if len(errs) > 0 {
+			job.Status = platformclientv2.String(importJobStatusPartialCompleted)
+			job.Report = &platformclientv2.Knowledgeimportjobreport{Errors: &errs}
+		}
+		jobs[*job.Id] = job
+		return job, &platformclientv2.APIResponse{StatusCode: http.StatusOK}, nil
+	}
+	p.getImportJobAttr = func(_ context.Context, _ *knowledgeImportProxy, _ string, jobId string) (*platformclientv2.Knowledgeimportjobresponse, *platformclientv2.APIResponse, error) {
+		return jobs[jobId], &platformclientv2.APIResponse{StatusCode: http.StatusOK}, nil
+	}
+	p.getDocumentsByExternalIdsAttr = func(_ context.Context, _ *knowledgeImportProxy, _ string, externalIds []string) (*[]platformclientv2.Knowledgedocumentresponse, *platformclientv2.APIResponse, error) {
+		var result []platformclientv2.Knowledgedocumentresponse
+		for _, externalId := range externalIds {
+			if id, ok := documents[externalId]; ok {
+				result = append(result, platformclientv2.Knowledgedocumentresponse{Id: platformclientv2.String(id), ExternalId: platformclientv2.String(externalId)})
+			}
+		}
+		return &result, &platformclientv2.APIResponse{StatusCode: http.StatusOK}, nil
+	}
+	p.deleteDocumentAttr = func(_ context.Context, _ *knowledgeImportProxy, _ string, documentId string) (*platformclientv2.APIResponse, error) {
+		for externalId, id := range documents {
+			if id == documentId {
+				delete(documents, externalId)
+				return &platformclientv2.APIResponse{StatusCode: http.StatusNoContent}, nil
+			}
+		}
+		return &platformclientv2.APIResponse{StatusCode: http.StatusNotFound}, fmt.Errorf("document %s not found", documentId)
+	}
+	return p
+}
+
+func TestUnitResourceKnowledgeImportSync(t *testing.T) {
+	knowledgeBaseId := uuid.NewString()
+	documents := make(map[string]string)
+	p := buildImportTestProxy(t, knowledgeBaseId, documents)
+
+	pollInterval := knowledgeImportPollInterval
+	knowledgeImportPollInterval = 0
+	defer func() { knowledgeImportPollInterval = pollInterval }()
+
+	dir := t.TempDir()
+	writeTestDocuments(t, dir, map[string]string{
+		"returns.md":  "# Returns\n\nText.",
+		"shipping.md": "# Shipping\n\nText.",
+	})
+
+	d := schema.TestResourceDataRaw(t, ResourceKnowledgeImport().Schema, map[string]interface{}{
+		"knowledge_base_id": knowledgeBaseId,
+		"directory":         dir,
+		"visible":           true,
+		"delete_removed":    true,
+	})
+	d.SetId(uuid.NewString())
+
+	diagErr := syncKnowledgeDocuments(context.Background(), d, p, nil, d.Timeout(schema.TimeoutCreate))
+	assert.Nil(t, diagErr)
+	assert.Len(t, documents, 2)
+	synced := getImportedDocuments(d.Get("documents").([]interface{}))
+	assert.Equal(t, "returns", synced[0].externalId)
+	assert.Equal(t, documents["returns"], synced[0].documentId)
+	assert.Equal(t, "Shipping", synced[1].title)
+	assert.Equal(t, importJobStatusCompleted, d.Get("last_import_status").(string))
+	firstJobId := d.Get("last_import_job_id").(string)
+
+	// Only the changed documents are imported, and the documents of removed files are deleted
+	returnsId := documents["returns"]
+	writeTestDocuments(t, dir, map[string]string{
+		"shipping.md": "# Shipping\n\nChanged text.",
+		"returns.md":  "",
+		"faq.md":      "# FAQ",
+	})
+	var imported []string
+	createImportJob := p.createImportJobAttr
+	p.createImportJobAttr = func(ctx context.Context, p *knowledgeImportProxy, id string, body *platformclientv2.Knowledgeimportjobrequest) (*platformclientv2.Knowledgeimportjobresponse, *platformclientv2.APIResponse, error) {
+		job, resp, err := createImportJob(ctx, p, id, body)
+		imported = append(imported, *job.Id)
+		return job, resp, err
+	}
+
+	diagErr = syncKnowledgeDocuments(context.Background(), d, p, synced, d.Timeout(schema.TimeoutUpdate))
+	assert.Nil(t, diagErr)
+	assert.Len(t, imported, 1)
+	assert.NotEqual(t, firstJobId, d.Get("last_import_job_id").(string))
+	assert.NotContains(t, documents, "returns")
+	for _, id := range documents {
+		assert.NotEqual(t, returnsId, id)
+	}
+	synced = getImportedDocuments(d.Get("documents").([]interface{}))
+	assert.Equal(t, []string{"faq", "shipping"}, []string{synced[0].externalId, synced[1].externalId})
+
+	// Nothing is imported when no file changed
+	imported = nil
+	diagErr = syncKnowledgeDocuments(context.Background(), d, p, synced, d.Timeout(schema.TimeoutUpdate))
+	assert.Nil(t, diagErr)
+	assert.Empty(t, imported)
+
+	// Import errors are reported with the path of the file and the synced documents are kept
+	writeTestDocuments(t, dir, map[string]string{"faq.md": "# Invalid FAQ"})
+	diagErr = syncKnowledgeDocuments(context.Background(), d, p, synced, d.Timeout(schema.TimeoutUpdate))
+	assert.True(t, diagErr.HasError())
+	assert.Contains(t, diagErr[0].Summary, "faq.md (faq): invalid title")
+	assert.Equal(t, importJobStatusPartialCompleted, d.Get("last_import_status").(string))
+	assert.Equal(t, "", d.Get("content_hash").(string))
+	assert.Equal(t, synced, getImportedDocuments(d.Get("documents").([]interface{})))
+
+	// The documents created by a partially completed import are saved in the state so that they are deleted with the resource
+	writeTestDocuments(t, dir, map[string]string{"faq.md": "# FAQ", "warranty.md": "# Invalid warranty", "returns.md": "# Returns"})
+	diagErr = syncKnowledgeDocuments(context.Background(), d, p, synced, d.Timeout(schema.TimeoutUpdate))
+	assert.True(t, diagErr.HasError())
+	assert.Equal(t, append(synced, importedDocument{externalId: "returns", documentId: documents["returns"], path: "returns.md", title: "Returns"}),
+		getImportedDocuments(d.Get("documents").([]interface{})))
+
+	// The documents are imported again on the next apply
+	writeTestDocuments(t, dir, map[string]string{"warranty.md": "# Warranty"})
+	imported = nil
+	diagErr = syncKnowledgeDocuments(context.Background(), d, p, getImportedDocuments(d.Get("documents").([]interface{})), d.Timeout(schema.TimeoutUpdate))
+	assert.Nil(t, diagErr)
+	assert.Len(t, imported, 1)
+	synced = getImportedDocuments(d.Get("documents").([]interface{}))
+	assert.Equal(t, []string{"faq", "returns", "shipping", "warranty"}, []string{synced[0].externalId, synced[1].externalId, synced[2].externalId, synced[3].externalId})
+	assert.NotEmpty(t, synced[1].contentHash)
+}
+
+func TestUnitResourceKnowledgeImportReadDelete(t *testing.T) {
+	knowledgeBaseId := uuid.NewString()
+	documents := map[string]string{"returns": uuid.NewString(), "shipping": uuid.NewString()}
+	internalProxy = buildImportTestProxy(t, knowledgeBaseId, documents)
+	defer func() { internalProxy = nil }()
+	gcloud := &provider.ProviderMeta{ClientConfig: &platformclientv2.Configuration{}}
+
+	d := schema.TestResourceDataRaw(t, ResourceKnowledgeImport().Schema, map[string]interface{}{
+		"knowledge_base_id": knowledgeBaseId,
+		"directory":         t.TempDir(),
+	})
+	d.SetId(uuid.NewString())
+	_ = d.Set("content_hash", "hash")
+	_ = d.Set("documents", flattenImportedDocuments([]importedDocument{
+		{externalId: "returns", documentId: documents["returns"]},
+		{externalId: "shipping", documentId: documents["shipping"]},
+	}))
+
+	diagErr := readKnowledgeImport(context.Background(), d, gcloud)
+	assert.False(t, diagErr.HasError())
+	assert.Equal(t, "hash", d.Get("content_hash").(string))
+
+	// Documents deleted outside of Terraform are removed from the state and imported again on the next apply
+	delete(documents, "returns")
+	diagErr = readKnowledgeImport(context.Background(), d, gcloud)
+	assert.False(t, diagErr.HasError())
+	assert.Equal(t, "", d.Get("content_hash").(string))
+	assert.Len(t, d.Get("documents").([]interface{}), 1)
+
+	diagErr = deleteKnowledgeImport(context.Background(), d, gcloud)
+	assert.False(t, diagErr.HasError())
+	assert.Empty(t, documents)
+}
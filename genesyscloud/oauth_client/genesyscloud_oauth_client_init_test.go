@@ -25,6 +25,7 @@ func (r *registerTestInstance) registerTestResources() {
 	defer r.resourceMapMutex.Unlock()
 
 	providerResources[ResourceType] = ResourceOAuthClient()
+	providerResources[SecretRotationResourceType] = ResourceOAuthClientSecretRotation()
 }
 
 // registerTestDataSources registers all data sources used in the tests.
@@ -23,6 +23,7 @@ type getAllOauthClientsFunc func(ctx context.Context, o *oauthClientProxy) (*[]p
 type deleteOAuthClientFunc func(context.Context, *oauthClientProxy, string) (*platformclientv2.APIResponse, error)
 type deleteIntegrationCredentialFunc func(context.Context, *oauthClientProxy, string) (*platformclientv2.APIResponse, error)
 type updateIntegrationClientFunc func(context.Context, *oauthClientProxy, string, platformclientv2.Credential) (*platformclientv2.Credentialinfo, *platformclientv2.APIResponse, error)
+type regenerateOAuthClientSecretFunc func(context.Context, *oauthClientProxy, string) (*platformclientv2.Oauthclient, *platformclientv2.APIResponse, error)
 type getAllIntegrationCredentialFunc func(ctx context.Context, o *oauthClientProxy) (*[]platformclientv2.Credentialinfo, *platformclientv2.APIResponse, error)
 
 type oauthClientProxy struct {
@@ -46,6 +47,7 @@ type oauthClientProxy struct {
 	updateOAuthClientAttr           updateOAuthClientFunc
 	deleteOAuthClientAttr           deleteOAuthClientFunc
 	deleteIntegrationCredentialAttr deleteIntegrationCredentialFunc
+	regenerateOAuthClientSecretAttr regenerateOAuthClientSecretFunc
 }
 
 // newAuthClientProxy initializes the proxy with all the data needed to communicate with Genesys Cloud
@@ -79,6 +81,7 @@ func newOAuthClientProxy(clientConfig *platformclientv2.Configuration) *oauthCli
 		getAllOauthClientsAttr:          getAllOauthClientsFn,
 		deleteOAuthClientAttr:           deleteOAuthClientFn,
 		deleteIntegrationCredentialAttr: deleteIntegrationClientFn,
+		regenerateOAuthClientSecretAttr: regenerateOAuthClientSecretFn,
 	}
 }
 
@@ -168,6 +171,22 @@ func (o *oauthClientProxy) getAllOAuthClients(ctx context.Context) (*[]platformc
 	return o.getAllOauthClientsAttr(ctx, o)
 }
 
+func (o *oauthClientProxy) regenerateOAuthClientSecret(ctx context.Context, id string) (*platformclientv2.Oauthclient, *platformclientv2.APIResponse, error) {
+	client, resp, err := o.regenerateOAuthClientSecretAttr(ctx, o, id)
+	if err != nil {
+		return client, resp, err
+	}
+
+	// Integration credentials created later in the run must get the new secret of a client created in the same run
+	o.createdClientCacheLock.Lock()
+	defer o.createdClientCacheLock.Unlock()
+	if _, ok := o.createdClientCache[id]; ok {
+		o.createdClientCache[id] = *client
+		log.Printf("Updated the secret of oauth client %s in cache", id)
+	}
+	return client, resp, err
+}
+
 func (o *oauthClientProxy) getHomeDivisionInfo(ctx context.Context) (*platformclientv2.Authzdivision, *platformclientv2.APIResponse, error) {
 	return o.getHomeDivisionInfo(ctx)
 }
@@ -227,6 +246,10 @@ func updateOAuthClientFn(ctx context.Context, o *oauthClientProxy, id string, re
 	return o.oAuthApi.PutOauthClient(id, request)
 }
 
+func regenerateOAuthClientSecretFn(ctx context.Context, o *oauthClientProxy, id string) (*platformclientv2.Oauthclient, *platformclientv2.APIResponse, error) {
+	return o.oAuthApi.PostOauthClientSecret(id)
+}
+
 func getParentOAuthClientTokenFn(ctx context.Context, o *oauthClientProxy) (*platformclientv2.Tokeninfo, *platformclientv2.APIResponse, error) {
 	return o.tokenApi.GetTokensMe(false)
 }
@@ -10,13 +10,15 @@ import (
 )
 
 const (
-	ResourceType = "genesyscloud_oauth_client"
+	ResourceType               = "genesyscloud_oauth_client"
+	SecretRotationResourceType = "genesyscloud_oauth_client_secret_rotation"
 )
 
 func SetRegistrar(l registrar.Registrar) {
 	l.RegisterDataSource(ResourceType, DataSourceOAuthClient())
 	l.RegisterResource(ResourceType, ResourceOAuthClient())
 	l.RegisterExporter(ResourceType, OauthClientExporter())
+	l.RegisterResource(SecretRotationResourceType, ResourceOAuthClientSecretRotation())
 }
 
 var (
@@ -115,6 +117,61 @@ func ResourceOAuthClient() *schema.Resource {
 	}
 }
 
+func ResourceOAuthClientSecretRotation() *schema.Resource {
+	return &schema.Resource{
+		Description: "Rotates the secret of a Genesys Cloud OAuth Client. The secret is regenerated when the resource is created, every rotation_days and whenever rotate_trigger changes. " +
+			"The new secret is written to the integration credential and exposed in the sensitive client_secret attribute. " +
+			"The API invalidates the previous secret as soon as the new one is generated, so applications using the client must pick up the new secret in the same run. " +
+			"Destroying the resource keeps the current secret.",
+		CreateContext: provider.CreateWithPooledClient(createOAuthClientSecretRotation),
+		ReadContext:   provider.ReadWithPooledClient(readOAuthClientSecretRotation),
+		UpdateContext: provider.UpdateWithPooledClient(updateOAuthClientSecretRotation),
+		DeleteContext: provider.DeleteWithPooledClient(deleteOAuthClientSecretRotation),
+		SchemaVersion: 1,
+		Schema: map[string]*schema.Schema{
+			"oauth_client_id": {
+				Description: "ID of the OAuth client. The OAuth client used by Terraform cannot be rotated.",
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+			},
+			"rotation_days": {
+				Description:  "Number of days after which the secret is rotated on the next apply. If not set, the secret is only rotated when rotate_trigger changes.",
+				Type:         schema.TypeInt,
+				Optional:     true,
+				ValidateFunc: validation.IntAtLeast(1),
+			},
+			"rotate_trigger": {
+				Description: "Arbitrary value that rotates the secret whenever it changes.",
+				Type:        schema.TypeString,
+				Optional:    true,
+			},
+			"integration_credential_id": {
+				Description: "ID of an integration credential (with credential type pureCloudOAuthClient) that the client ID and the new secret are written to.",
+				Type:        schema.TypeString,
+				Optional:    true,
+			},
+			"client_secret": {
+				Description: "The current secret of the OAuth client.",
+				Type:        schema.TypeString,
+				Computed:    true,
+				Sensitive:   true,
+			},
+			"rotated_at": {
+				Description: "Time of the last rotation, in RFC 3339 format.",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"next_rotation_at": {
+				Description: "Time after which the next apply rotates the secret, in RFC 3339 format. Empty if rotation_days is not set.",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+		},
+		CustomizeDiff: customizeOAuthClientSecretRotationDiff,
+	}
+}
+
 func OauthClientExporter() *resourceExporter.ResourceExporter {
 	return &resourceExporter.ResourceExporter{
 		GetResourcesFunc: provider.GetAllWithPooledClient(getAllOAuthClients),
@@ -0,0 +1,176 @@
+package oauth_client
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"terraform-provider-genesyscloud/genesyscloud/provider"
+	"terraform-provider-genesyscloud/genesyscloud/util"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+/*
+The resource_genesyscloud_oauth_client_secret_rotation.go file contains the genesyscloud_oauth_client_secret_rotation
+resource. The secret of the OAuth client is regenerated on create, when rotate_trigger changes and on the first apply
+after next_rotation_at, and is then written to the linked integration credential.
+*/
+
+const pureCloudOAuthClientCredentialType = "pureCloudOAuthClient"
+
+// rotationNow returns the current time. It is replaced in unit tests.
+var rotationNow = time.Now
+
+func createOAuthClientSecretRotation(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	sdkConfig := meta.(*provider.ProviderMeta).ClientConfig
+	op := GetOAuthClientProxy(sdkConfig)
+	clientId := d.Get("oauth_client_id").(string)
+
+	// Rotating the secret of the client Terraform runs with would break the rest of the run
+	token, resp, err := op.getParentOAuthClientToken(ctx)
+	if err != nil {
+		return util.BuildAPIDiagnosticError(SecretRotationResourceType, fmt.Sprintf("Failed to get the oauth client of the provider | error: %s", err), resp)
+	}
+	if token.OAuthClient != nil && token.OAuthClient.Id != nil && *token.OAuthClient.Id == clientId {
+		return util.BuildDiagnosticError(SecretRotationResourceType, fmt.Sprintf("Cannot rotate the secret of oauth client %s", clientId), fmt.Errorf("oauth client %s is the client used by Terraform", clientId))
+	}
+
+	d.SetId(clientId)
+	if diagErr := rotateOAuthClientSecret(ctx, d, op); diagErr != nil {
+		return diagErr
+	}
+	return readOAuthClientSecretRotation(ctx, d, meta)
+}
+
+func readOAuthClientSecretRotation(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	sdkConfig := meta.(*provider.ProviderMeta).ClientConfig
+	op := GetOAuthClientProxy(sdkConfig)
+
+	log.Printf("Reading secret rotation of oauth client %s", d.Id())
+	_, resp, err := op.getOAuthClient(ctx, d.Id())
+	if err != nil {
+		if util.IsStatus404(resp) {
+			log.Printf("Oauth client %s no longer exists", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return util.BuildAPIDiagnosticError(SecretRotationResourceType, fmt.Sprintf("Failed to read oauth client %s | error: %s", d.Id(), err), resp)
+	}
+
+	_ = d.Set("oauth_client_id", d.Id())
+	_ = d.Set("next_rotation_at", nextRotationAt(d))
+	log.Printf("Read secret rotation of oauth client %s", d.Id())
+	return nil
+}
+
+func updateOAuthClientSecretRotation(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	sdkConfig := meta.(*provider.ProviderMeta).ClientConfig
+	op := GetOAuthClientProxy(sdkConfig)
+
+	if d.HasChange("rotate_trigger") || isRotationDue(d) {
+		if diagErr := rotateOAuthClientSecret(ctx, d, op); diagErr != nil {
+			return diagErr
+		}
+	} else if d.HasChange("integration_credential_id") {
+		if diagErr := writeSecretToCredential(ctx, d, op, d.Get("client_secret").(string)); diagErr != nil {
+			return diagErr
+		}
+	}
+	return readOAuthClientSecretRotation(ctx, d, meta)
+}
+
+// deleteOAuthClientSecretRotation only removes the resource from the state, the client keeps its current secret
+func deleteOAuthClientSecretRotation(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	log.Printf("Stopped rotating the secret of oauth client %s", d.Id())
+	return nil
+}
+
+// customizeOAuthClientSecretRotationDiff plans a rotation once next_rotation_at has passed
+func customizeOAuthClientSecretRotationDiff(ctx context.Context, diff *schema.ResourceDiff, meta interface{}) error {
+	if diff.Id() == "" {
+		return nil
+	}
+
+	next, err := time.Parse(time.RFC3339, addRotationDays(diff.Get("rotated_at").(string), diff.Get("rotation_days").(int)))
+	due := err == nil && !rotationNow().Before(next)
+	if due || diff.HasChange("rotate_trigger") {
+		log.Printf("Secret of oauth client %s will be rotated", diff.Id())
+		for _, key := range []string{"client_secret", "rotated_at", "next_rotation_at"} {
+			if err := diff.SetNewComputed(key); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	if diff.HasChange("rotation_days") {
+		return diff.SetNewComputed("next_rotation_at")
+	}
+	return nil
+}
+
+// rotateOAuthClientSecret regenerates the secret and writes it to the state and the integration credential
+func rotateOAuthClientSecret(ctx context.Context, d *schema.ResourceData, op *oauthClientProxy) diag.Diagnostics {
+	log.Printf("Rotating the secret of oauth client %s", d.Id())
+	client, resp, err := op.regenerateOAuthClientSecret(ctx, d.Id())
+	if err != nil {
+		return util.BuildAPIDiagnosticError(SecretRotationResourceType, fmt.Sprintf("Failed to regenerate the secret of oauth client %s | error: %s", d.Id(), err), resp)
+	}
+	if client == nil || client.Secret == nil {
+		return util.BuildAPIDiagnosticError(SecretRotationResourceType, fmt.Sprintf("No secret returned for oauth client %s", d.Id()), resp)
+	}
+
+	// The previous secret is no longer valid, so the new one is kept in the state even if the credential update fails
+	_ = d.Set("client_secret", *client.Secret)
+	_ = d.Set("rotated_at", rotationNow().UTC().Format(time.RFC3339))
+	log.Printf("Rotated the secret of oauth client %s", d.Id())
+
+	return writeSecretToCredential(ctx, d, op, *client.Secret)
+}
+
+// writeSecretToCredential sets the client ID and secret of the integration credential, keeping its name
+func writeSecretToCredential(ctx context.Context, d *schema.ResourceData, op *oauthClientProxy, secret string) diag.Diagnostics {
+	credentialId := d.Get("integration_credential_id").(string)
+	if credentialId == "" {
+		return nil
+	}
+
+	credential, resp, err := op.getIntegrationCredential(ctx, credentialId)
+	if err != nil {
+		return util.BuildAPIDiagnosticError(SecretRotationResourceType, fmt.Sprintf("Failed to get integration credential %s | error: %s", credentialId, err), resp)
+	}
+	if credential.VarType == nil || credential.VarType.Name == nil || *credential.VarType.Name != pureCloudOAuthClientCredentialType {
+		return util.BuildDiagnosticError(SecretRotationResourceType, fmt.Sprintf("Cannot write the secret of oauth client %s", d.Id()), fmt.Errorf("integration credential %s is not a %s credential", credentialId, pureCloudOAuthClientCredentialType))
+	}
+
+	credential.CredentialFields = &map[string]string{
+		"clientId":     d.Id(),
+		"clientSecret": secret,
+	}
+	log.Printf("Writing the secret of oauth client %s to integration credential %s", d.Id(), credentialId)
+	if _, resp, err := op.updateIntegrationClient(ctx, credentialId, *credential); err != nil {
+		return util.BuildAPIDiagnosticError(SecretRotationResourceType, fmt.Sprintf("Failed to update integration credential %s | error: %s", credentialId, err), resp)
+	}
+	return nil
+}
+
+// isRotationDue reports whether rotation_days have passed since the last rotation. The last rotation is read from the
+// state, as the plan marks rotated_at as unknown when a rotation is due.
+func isRotationDue(d *schema.ResourceData) bool {
+	rotatedAt, _ := d.GetChange("rotated_at")
+	next, err := time.Parse(time.RFC3339, addRotationDays(rotatedAt.(string), d.Get("rotation_days").(int)))
+	return err == nil && !rotationNow().Before(next)
+}
+
+func nextRotationAt(d *schema.ResourceData) string {
+	return addRotationDays(d.Get("rotated_at").(string), d.Get("rotation_days").(int))
+}
+
+func addRotationDays(rotatedAt string, rotationDays int) string {
+	rotated, err := time.Parse(time.RFC3339, rotatedAt)
+	if rotationDays == 0 || err != nil {
+		return ""
+	}
+	return rotated.AddDate(0, 0, rotationDays).UTC().Format(time.RFC3339)
+}
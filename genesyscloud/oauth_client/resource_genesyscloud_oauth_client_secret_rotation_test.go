@@ -0,0 +1,79 @@
+package oauth_client
+
+import (
+	"fmt"
+	"strconv"
+	"terraform-provider-genesyscloud/genesyscloud/provider"
+	"terraform-provider-genesyscloud/genesyscloud/util"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestAccResourceOAuthClientSecretRotation(t *testing.T) {
+	var (
+		clientResourceLabel   = "rotated-client"
+		clientName            = "terraform-rotation-" + uuid.NewString()
+		credentialName        = "terraform-rotation-" + uuid.NewString()
+		rotationResourceLabel = "rotation"
+		fullResourcePath      = SecretRotationResourceType + "." + rotationResourceLabel
+		firstSecret           string
+	)
+
+	clientConfig := generateOauthClientWithCredential(
+		clientResourceLabel,
+		clientName,
+		"terraform secret rotation client",
+		"CODE",
+		"300",
+		util.NullValue, // Default state
+		util.GenerateStringArray(strconv.Quote("https://example.com/auth")),
+		util.GenerateStringArray(strconv.Quote("oauth")),
+		credentialName,
+	)
+	generateRotation := func(trigger string) string {
+		return fmt.Sprintf(`resource "%s" "%s" {
+	oauth_client_id           = genesyscloud_oauth_client.%s.id
+	integration_credential_id = genesyscloud_oauth_client.%s.integration_credential_id
+	rotation_days             = 90
+	rotate_trigger            = "%s"
+}
+`, SecretRotationResourceType, rotationResourceLabel, clientResourceLabel, clientResourceLabel, trigger)
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { util.TestAccPreCheck(t) },
+		ProviderFactories: provider.GetProviderFactories(providerResources, providerDataSources),
+		Steps: []resource.TestStep{
+			{
+				// Rotate on create
+				Config: clientConfig + generateRotation("1"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrPair(fullResourcePath, "oauth_client_id", "genesyscloud_oauth_client."+clientResourceLabel, "id"),
+					resource.TestCheckResourceAttrSet(fullResourcePath, "client_secret"),
+					resource.TestCheckResourceAttrSet(fullResourcePath, "rotated_at"),
+					resource.TestCheckResourceAttrSet(fullResourcePath, "next_rotation_at"),
+					func(state *terraform.State) error {
+						firstSecret = state.RootModule().Resources[fullResourcePath].Primary.Attributes["client_secret"]
+						return nil
+					},
+				),
+			},
+			{
+				// Rotate when the trigger changes
+				Config: clientConfig + generateRotation("2"),
+				Check: resource.ComposeTestCheckFunc(
+					func(state *terraform.State) error {
+						secret := state.RootModule().Resources[fullResourcePath].Primary.Attributes["client_secret"]
+						if secret == "" || secret == firstSecret {
+							return fmt.Errorf("secret of oauth client %s was not rotated", clientName)
+						}
+						return nil
+					},
+				),
+			},
+		},
+	})
+}
@@ -0,0 +1,123 @@
+package oauth_client
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"terraform-provider-genesyscloud/genesyscloud/provider"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/mypurecloud/platform-client-sdk-go/v149/platformclientv2"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUnitOAuthClientSecretRotation(t *testing.T) {
+	tClientId := uuid.NewString()
+	tCredentialId := uuid.NewString()
+	tCredentialName := "rotated credential"
+	tCredentialType := pureCloudOAuthClientCredentialType
+	now := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	secrets := 0
+	var credentialFields map[string]string
+	ocproxy := &oauthClientProxy{createdClientCache: map[string]platformclientv2.Oauthclient{tClientId: {Id: &tClientId}}}
+	ocproxy.getParentOAuthClientTokenAttr = func(context.Context, *oauthClientProxy) (*platformclientv2.Tokeninfo, *platformclientv2.APIResponse, error) {
+		return &platformclientv2.Tokeninfo{OAuthClient: &platformclientv2.Orgoauthclient{Id: platformclientv2.String(uuid.NewString())}}, &platformclientv2.APIResponse{StatusCode: http.StatusOK}, nil
+	}
+	ocproxy.regenerateOAuthClientSecretAttr = func(ctx context.Context, p *oauthClientProxy, id string) (*platformclientv2.Oauthclient, *platformclientv2.APIResponse, error) {
+		assert.Equal(t, tClientId, id)
+		secrets++
+		return &platformclientv2.Oauthclient{Id: &id, Secret: platformclientv2.String(fmt.Sprintf("secret-%d", secrets))}, &platformclientv2.APIResponse{StatusCode: http.StatusOK}, nil
+	}
+	ocproxy.getOAuthClientAttr = func(ctx context.Context, p *oauthClientProxy, id string) (*platformclientv2.Oauthclient, *platformclientv2.APIResponse, error) {
+		return &platformclientv2.Oauthclient{Id: &id}, &platformclientv2.APIResponse{StatusCode: http.StatusOK}, nil
+	}
+	ocproxy.getIntegrationCredentialAttr = func(ctx context.Context, p *oauthClientProxy, id string) (*platformclientv2.Credential, *platformclientv2.APIResponse, error) {
+		return &platformclientv2.Credential{Id: &id, Name: &tCredentialName, VarType: &platformclientv2.Credentialtype{Name: &tCredentialType}}, &platformclientv2.APIResponse{StatusCode: http.StatusOK}, nil
+	}
+	ocproxy.updateIntegrationCredentialAttr = func(ctx context.Context, p *oauthClientProxy, id string, credential platformclientv2.Credential) (*platformclientv2.Credentialinfo, *platformclientv2.APIResponse, error) {
+		assert.Equal(t, tCredentialId, id)
+		assert.Equal(t, tCredentialName, *credential.Name)
+		credentialFields = *credential.CredentialFields
+		return &platformclientv2.Credentialinfo{Id: &id, Name: credential.Name}, &platformclientv2.APIResponse{StatusCode: http.StatusOK}, nil
+	}
+	internalProxy = ocproxy
+	defer func() { internalProxy = nil }()
+
+	rotationNow = func() time.Time { return now }
+	defer func() { rotationNow = time.Now }()
+
+	resourceSchema := ResourceOAuthClientSecretRotation().Schema
+	d := schema.TestResourceDataRaw(t, resourceSchema, map[string]interface{}{
+		"oauth_client_id":           tClientId,
+		"rotation_days":             30,
+		"integration_credential_id": tCredentialId,
+	})
+	gcloud := &provider.ProviderMeta{ClientConfig: &platformclientv2.Configuration{}}
+
+	diag := createOAuthClientSecretRotation(context.Background(), d, gcloud)
+	assert.False(t, diag.HasError(), diag)
+	assert.Equal(t, tClientId, d.Id())
+	assert.Equal(t, "secret-1", d.Get("client_secret"))
+	assert.Equal(t, "2026-01-01T12:00:00Z", d.Get("rotated_at"))
+	assert.Equal(t, "2026-01-31T12:00:00Z", d.Get("next_rotation_at"))
+	assert.Equal(t, map[string]string{"clientId": tClientId, "clientSecret": "secret-1"}, credentialFields)
+	assert.Equal(t, "secret-1", *ocproxy.GetCachedOAuthClient(tClientId).Secret)
+
+	stateData := func() *schema.ResourceData {
+		return ResourceOAuthClientSecretRotation().Data(&terraform.InstanceState{
+			ID: tClientId,
+			Attributes: map[string]string{
+				"oauth_client_id":           tClientId,
+				"rotation_days":             "30",
+				"integration_credential_id": tCredentialId,
+				"client_secret":             "secret-1",
+				"rotated_at":                "2026-01-01T12:00:00Z",
+				"next_rotation_at":          "2026-01-31T12:00:00Z",
+			},
+		})
+	}
+
+	// Before rotation_days have passed the secret is kept
+	now = now.AddDate(0, 0, 29)
+	d = stateData()
+	assert.False(t, isRotationDue(d))
+	diag = updateOAuthClientSecretRotation(context.Background(), d, gcloud)
+	assert.False(t, diag.HasError(), diag)
+	assert.Equal(t, 1, secrets)
+
+	// Once they have passed the secret is rotated and written to the credential
+	now = now.AddDate(0, 0, 1)
+	d = stateData()
+	assert.True(t, isRotationDue(d))
+	diag = updateOAuthClientSecretRotation(context.Background(), d, gcloud)
+	assert.False(t, diag.HasError(), diag)
+	assert.Equal(t, "secret-2", d.Get("client_secret"))
+	assert.Equal(t, "2026-01-31T12:00:00Z", d.Get("rotated_at"))
+	assert.Equal(t, "2026-03-02T12:00:00Z", d.Get("next_rotation_at"))
+	assert.Equal(t, "secret-2", credentialFields["clientSecret"])
+}
+
+func TestUnitOAuthClientSecretRotationOfProviderClient(t *testing.T) {
+	tClientId := uuid.NewString()
+
+	ocproxy := &oauthClientProxy{}
+	ocproxy.getParentOAuthClientTokenAttr = func(context.Context, *oauthClientProxy) (*platformclientv2.Tokeninfo, *platformclientv2.APIResponse, error) {
+		return &platformclientv2.Tokeninfo{OAuthClient: &platformclientv2.Orgoauthclient{Id: &tClientId}}, &platformclientv2.APIResponse{StatusCode: http.StatusOK}, nil
+	}
+	ocproxy.regenerateOAuthClientSecretAttr = func(ctx context.Context, p *oauthClientProxy, id string) (*platformclientv2.Oauthclient, *platformclientv2.APIResponse, error) {
+		t.Fatalf("the secret of the provider client must not be regenerated")
+		return nil, nil, nil
+	}
+	internalProxy = ocproxy
+	defer func() { internalProxy = nil }()
+
+	d := schema.TestResourceDataRaw(t, ResourceOAuthClientSecretRotation().Schema, map[string]interface{}{"oauth_client_id": tClientId})
+	diag := createOAuthClientSecretRotation(context.Background(), d, &provider.ProviderMeta{ClientConfig: &platformclientv2.Configuration{}})
+	assert.True(t, diag.HasError())
+	assert.Empty(t, d.Id())
+}
@@ -30,6 +30,7 @@ type getOutboundCampaignIdByNameFunc func(ctx context.Context, p *outboundCampai
 type getOutboundCampaignByIdFunc func(ctx context.Context, p *outboundCampaignProxy, id string) (campaign *platformclientv2.Campaign, response *platformclientv2.APIResponse, err error)
 type updateOutboundCampaignFunc func(ctx context.Context, p *outboundCampaignProxy, id string, campaign *platformclientv2.Campaign) (*platformclientv2.Campaign, *platformclientv2.APIResponse, error)
 type deleteOutboundCampaignFunc func(ctx context.Context, p *outboundCampaignProxy, id string) (response *platformclientv2.APIResponse, err error)
+type getOutboundCampaignProgressFunc func(ctx context.Context, p *outboundCampaignProxy, id string) (*platformclientv2.Campaignprogress, *platformclientv2.APIResponse, error)
 
 // outboundCampaignProxy contains all of the methods that call genesys cloud APIs.
 type outboundCampaignProxy struct {
@@ -41,6 +42,7 @@ type outboundCampaignProxy struct {
 	getOutboundCampaignByIdAttr     getOutboundCampaignByIdFunc
 	updateOutboundCampaignAttr      updateOutboundCampaignFunc
 	deleteOutboundCampaignAttr      deleteOutboundCampaignFunc
+	getOutboundCampaignProgressAttr getOutboundCampaignProgressFunc
 	campaignCache                   rc.CacheInterface[platformclientv2.Campaign]
 }
 
@@ -57,6 +59,7 @@ func newOutboundCampaignProxy(clientConfig *platformclientv2.Configuration) *out
 		getOutboundCampaignByIdAttr:     getOutboundCampaignByIdFn,
 		updateOutboundCampaignAttr:      updateOutboundCampaignFn,
 		deleteOutboundCampaignAttr:      deleteOutboundCampaignFn,
+		getOutboundCampaignProgressAttr: getOutboundCampaignProgressFn,
 		campaignCache:                   campaignCache,
 	}
 }
@@ -103,31 +106,45 @@ func (p *outboundCampaignProxy) deleteOutboundCampaign(ctx context.Context, id s
 	return p.deleteOutboundCampaignAttr(ctx, p, id)
 }
 
+// getOutboundCampaignProgress returns the progress of a Genesys Cloud outbound campaign through its contact list
+func (p *outboundCampaignProxy) getOutboundCampaignProgress(ctx context.Context, id string) (*platformclientv2.Campaignprogress, *platformclientv2.APIResponse, error) {
+	return p.getOutboundCampaignProgressAttr(ctx, p, id)
+}
+
 // turnOffCampaign sets a campaign's campaign_status to 'off' before confirming the update using retry logic and get calls
 func (p *outboundCampaignProxy) turnOffCampaign(ctx context.Context, campaignId string) diag.Diagnostics {
+	return p.setCampaignState(ctx, campaignId, "off", 30*time.Second)
+}
+
+// setCampaignState drives a campaign towards a desired state and polls it until the state is reached or the timeout expires.
+// The campaign is not updated if it already satisfies the desired state.
+func (p *outboundCampaignProxy) setCampaignState(ctx context.Context, campaignId string, desiredState string, timeout time.Duration) diag.Diagnostics {
 	log.Printf("Reading Outbound Campaign %s", campaignId)
 	outboundCampaign, resp, getErr := p.getOutboundCampaignById(ctx, campaignId)
 	if getErr != nil {
 		return util.BuildAPIDiagnosticError(ResourceType, fmt.Sprintf("Failed to read Outbound Campaign %s: %s", campaignId, getErr), resp)
 	}
 	log.Printf("Read Outbound Campaign %s", campaignId)
+	if campaignStateReached(desiredState, *outboundCampaign.CampaignStatus) {
+		return nil
+	}
 
-	log.Printf("Updating campaign '%s' campaign_status to off", *outboundCampaign.Name)
-	if diagErr := updateOutboundCampaignStatus(ctx, campaignId, p, *outboundCampaign, "off"); diagErr != nil {
+	log.Printf("Updating campaign '%s' campaign_status to %s", *outboundCampaign.Name, desiredState)
+	if diagErr := updateOutboundCampaignStatus(ctx, campaignId, p, *outboundCampaign, desiredState); diagErr != nil {
 		return diagErr
 	}
 	log.Printf("Updated campaign '%s'", *outboundCampaign.Name)
 
-	return util.WithRetries(ctx, 30*time.Second, func() *retry.RetryError {
-		log.Printf("Reading Outbound Campaign %s to ensure campaign_status is 'off'", campaignId)
+	return util.WithRetries(ctx, timeout, func() *retry.RetryError {
+		log.Printf("Reading Outbound Campaign %s to ensure it reached state '%s'", campaignId, desiredState)
 		outboundCampaign, resp, getErr := p.getOutboundCampaignById(ctx, campaignId)
 		if getErr != nil {
 			return retry.NonRetryableError(util.BuildWithRetriesApiDiagnosticError(ResourceType, fmt.Sprintf("failed to read Outbound Campaign %s | error: %s", campaignId, getErr), resp))
 		}
 		log.Printf("Read Outbound Campaign %s", campaignId)
-		if *outboundCampaign.CampaignStatus == "on" {
-			time.Sleep(5 * time.Second)
-			return retry.RetryableError(util.BuildWithRetriesApiDiagnosticError(ResourceType, fmt.Sprintf("campaign %s campaign_status is still %s", campaignId, *outboundCampaign.CampaignStatus), resp))
+		if !campaignStateReached(desiredState, *outboundCampaign.CampaignStatus) {
+			time.Sleep(campaignStatePollInterval)
+			return retry.RetryableError(util.BuildWithRetriesApiDiagnosticError(ResourceType, fmt.Sprintf("campaign %s did not reach state '%s' within %v, campaign_status is still %s", campaignId, desiredState, timeout, *outboundCampaign.CampaignStatus), resp))
 		}
 		// Success
 		return nil
@@ -231,3 +248,12 @@ func deleteOutboundCampaignFn(_ context.Context, p *outboundCampaignProxy, id st
 	rc.DeleteCacheItem(p.campaignCache, id)
 	return resp, nil
 }
+
+// getOutboundCampaignProgressFn is an implementation function for retrieving the progress of a Genesys Cloud outbound campaign
+func getOutboundCampaignProgressFn(_ context.Context, p *outboundCampaignProxy, id string) (*platformclientv2.Campaignprogress, *platformclientv2.APIResponse, error) {
+	progress, resp, err := p.outboundApi.GetOutboundCampaignProgress(id)
+	if err != nil {
+		return nil, resp, fmt.Errorf("failed to retrieve progress of campaign %s: %s", id, err)
+	}
+	return progress, resp, nil
+}
@@ -7,6 +7,7 @@ import (
 	"terraform-provider-genesyscloud/genesyscloud/consistency_checker"
 	"terraform-provider-genesyscloud/genesyscloud/provider"
 	resourceExporter "terraform-provider-genesyscloud/genesyscloud/resource_exporter"
+	"terraform-provider-genesyscloud/genesyscloud/tfexporter_state"
 	"terraform-provider-genesyscloud/genesyscloud/util"
 	"terraform-provider-genesyscloud/genesyscloud/util/constants"
 	"terraform-provider-genesyscloud/genesyscloud/util/resourcedata"
@@ -68,6 +69,7 @@ func createOutboundCampaign(ctx context.Context, d *schema.ResourceData, meta in
 	clientConfig := meta.(*provider.ProviderMeta).ClientConfig
 	proxy := getOutboundCampaignProxy(clientConfig)
 	campaignStatus := d.Get("campaign_status").(string)
+	desiredState := d.Get("desired_state").(string)
 
 	campaign := getOutboundCampaignFromResourceData(d)
 
@@ -81,7 +83,11 @@ func createOutboundCampaign(ctx context.Context, d *schema.ResourceData, meta in
 	d.SetId(*outboundCampaign.Id)
 
 	// Campaigns can be enabled after creation
-	if campaignStatus == "on" {
+	if desiredState != "" {
+		if diagErr := proxy.setCampaignState(ctx, d.Id(), desiredState, getWaitForStateTimeout(d)); diagErr != nil {
+			return diagErr
+		}
+	} else if campaignStatus == "on" {
 		_ = d.Set("campaign_status", campaignStatus)
 		diagErr := updateOutboundCampaignStatus(ctx, d.Id(), proxy, *outboundCampaign, campaignStatus)
 		if diagErr != nil {
@@ -122,6 +128,9 @@ func readOutboundCampaign(ctx context.Context, d *schema.ResourceData, meta inte
 		resourcedata.SetNillableReference(d, "edge_group_id", campaign.EdgeGroup)
 		resourcedata.SetNillableReference(d, "site_id", campaign.Site)
 		resourcedata.SetNillableValue(d, "campaign_status", campaign.CampaignStatus)
+		if desiredState := d.Get("desired_state").(string); desiredState != "" && campaign.CampaignStatus != nil {
+			_ = d.Set("desired_state", flattenDesiredState(desiredState, *campaign.CampaignStatus))
+		}
 		resourcedata.SetNillableValueWithInterfaceArrayWithFunc(d, "phone_columns", campaign.PhoneColumns, flattenPhoneColumn)
 		resourcedata.SetNillableValue(d, "abandon_rate", campaign.AbandonRate)
 		resourcedata.SetNillableValue(d, "max_calls_per_agent", campaign.MaxCallsPerAgent)
@@ -153,6 +162,17 @@ func readOutboundCampaign(ctx context.Context, d *schema.ResourceData, meta inte
 			_ = d.Set("skill_columns", *campaign.SkillColumns)
 		}
 		resourcedata.SetNillableValue(d, "auto_answer", campaign.CallbackAutoAnswer)
+
+		// Progress changes while the campaign runs and is not needed to export it
+		if !tfexporter_state.IsExporterActive() {
+			progress, _, progressErr := proxy.getOutboundCampaignProgress(ctx, d.Id())
+			if progressErr != nil {
+				log.Printf("Failed to read progress of Outbound Campaign %s: %s", d.Id(), progressErr)
+				_ = d.Set("progress", nil)
+			} else {
+				_ = d.Set("progress", flattenCampaignProgress(progress))
+			}
+		}
 		log.Printf("Read Outbound Campaign %s %s", d.Id(), *campaign.Name)
 		return cc.CheckState(d)
 	})
@@ -163,18 +183,38 @@ func updateOutboundCampaign(ctx context.Context, d *schema.ResourceData, meta in
 	clientConfig := meta.(*provider.ProviderMeta).ClientConfig
 	proxy := getOutboundCampaignProxy(clientConfig)
 	campaignStatus := d.Get("campaign_status").(string)
+	desiredState := d.Get("desired_state").(string)
+	timeout := getWaitForStateTimeout(d)
 
 	campaign := getOutboundCampaignFromResourceData(d)
 
+	// Stop a running campaign and wait for it to be off before updating it
+	wasRunning := false
+	if d.Get("restart_after_update").(bool) && d.HasChangesExcept(lifecycleAttributes...) {
+		var diagErr diag.Diagnostics
+		wasRunning, diagErr = stopCampaignForUpdate(ctx, d.Id(), proxy, timeout)
+		if diagErr != nil {
+			return diagErr
+		}
+	}
+
 	log.Printf("Updating Outbound Campaign %s", *campaign.Name)
 	campaignSdk, resp, err := proxy.updateOutboundCampaign(ctx, d.Id(), &campaign)
 	if err != nil {
 		return util.BuildAPIDiagnosticError(ResourceType, fmt.Sprintf("Failed to update campaign %s error: %s", *campaign.Name, err), resp)
 	}
 
+	// Restart a campaign stopped for the update unless it is asked to stay off
+	if desiredState == "" && wasRunning && campaignStatus != "off" {
+		desiredState = "on"
+	}
+
 	// Check if Campaign Status needs updated
-	diagErr := updateOutboundCampaignStatus(ctx, d.Id(), proxy, *campaignSdk, campaignStatus)
-	if diagErr != nil {
+	if desiredState != "" {
+		if diagErr := proxy.setCampaignState(ctx, d.Id(), desiredState, timeout); diagErr != nil {
+			return diagErr
+		}
+	} else if diagErr := updateOutboundCampaignStatus(ctx, d.Id(), proxy, *campaignSdk, campaignStatus); diagErr != nil {
 		return diagErr
 	}
 
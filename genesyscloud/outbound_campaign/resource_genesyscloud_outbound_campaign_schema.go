@@ -5,6 +5,7 @@ import (
 	"terraform-provider-genesyscloud/genesyscloud/provider"
 	resourceExporter "terraform-provider-genesyscloud/genesyscloud/resource_exporter"
 	registrar "terraform-provider-genesyscloud/genesyscloud/resource_register"
+	"terraform-provider-genesyscloud/genesyscloud/validators"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
@@ -27,6 +28,36 @@ func SetRegistrar(regInstance registrar.Registrar) {
 	regInstance.RegisterExporter(ResourceType, OutboundCampaignExporter())
 }
 
+var campaignProgressResource = &schema.Resource{
+	Schema: map[string]*schema.Schema{
+		`contacts_called`: {
+			Description: `Number of contacts called during the Campaign.`,
+			Computed:    true,
+			Type:        schema.TypeInt,
+		},
+		`contacts_messaged`: {
+			Description: `Number of contacts messaged during the Campaign.`,
+			Computed:    true,
+			Type:        schema.TypeInt,
+		},
+		`contacts_remaining`: {
+			Description: `Number of contacts of the contact list which have not been called or messaged yet.`,
+			Computed:    true,
+			Type:        schema.TypeInt,
+		},
+		`total_contacts`: {
+			Description: `Total number of contacts in the Campaign.`,
+			Computed:    true,
+			Type:        schema.TypeInt,
+		},
+		`percentage`: {
+			Description: `Percentage of contacts processed during the Campaign.`,
+			Computed:    true,
+			Type:        schema.TypeInt,
+		},
+	},
+}
+
 // ResourceOutboundCampaign registers the genesyscloud_outbound_campaign resource with Terraform
 func ResourceOutboundCampaign() *schema.Resource {
 	outboundcampaignphonecolumnResource := &schema.Resource{
@@ -97,6 +128,32 @@ func ResourceOutboundCampaign() *schema.Resource {
 					return (old == `complete` && new == `off`) || (old == `invalid` && new == `off`) || (old == `stopping` && new == `off` || old == `complete` && new == `on`)
 				},
 			},
+			`desired_state`: {
+				Description:   `The state the Campaign is driven to on every apply. 'on' turns the Campaign on and waits until it is running (or complete), 'off' turns it off immediately and 'stopping' lets the calls in progress finish before the Campaign turns off. The resource waits for the Campaign to reach the state for up to wait_for_state_timeout. A Campaign which completes while desired_state is 'on' is not reported as a change. Conflicts with campaign_status.`,
+				Optional:      true,
+				Type:          schema.TypeString,
+				ValidateFunc:  validation.StringInSlice(desiredStates, false),
+				ConflictsWith: []string{`campaign_status`},
+			},
+			`restart_after_update`: {
+				Description: `If true and the Campaign is running when other attributes are updated, the Campaign is turned off and the resource waits for it to stop before applying the update, then turns the Campaign back on unless desired_state or campaign_status ask for it to stay off.`,
+				Optional:    true,
+				Default:     false,
+				Type:        schema.TypeBool,
+			},
+			`wait_for_state_timeout`: {
+				Description:      `How long to wait for the Campaign to reach its desired state or to stop before an update, e.g. '30s' or '10m'.`,
+				Optional:         true,
+				Default:          defaultWaitForStateTimeout,
+				Type:             schema.TypeString,
+				ValidateDiagFunc: validators.ValidateDuration,
+			},
+			`progress`: {
+				Description: `Progress of the Campaign through its contact list.`,
+				Computed:    true,
+				Type:        schema.TypeList,
+				Elem:        campaignProgressResource,
+			},
 			`phone_columns`: {
 				Description: `The ContactPhoneNumberColumns on the ContactList that this Campaign should dial.`,
 				Required:    true,
@@ -0,0 +1,125 @@
+package outbound_campaign
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/mypurecloud/platform-client-sdk-go/v149/platformclientv2"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUnitFlattenDesiredState(t *testing.T) {
+	testCases := []struct {
+		desiredState   string
+		campaignStatus string
+		expected       string
+	}{
+		{"", "on", ""},
+		{"on", "on", "on"},
+		{"on", "complete", "on"},
+		{"on", "off", "off"},
+		{"on", "invalid", "off"},
+		{"off", "off", "off"},
+		{"off", "complete", "off"},
+		{"off", "on", "on"},
+		{"stopping", "off", "stopping"},
+		{"stopping", "stopping", "on"},
+	}
+	for _, tc := range testCases {
+		assert.Equal(t, tc.expected, flattenDesiredState(tc.desiredState, tc.campaignStatus), "desired state %s with campaign status %s", tc.desiredState, tc.campaignStatus)
+	}
+}
+
+func TestUnitFlattenCampaignProgress(t *testing.T) {
+	progress := flattenCampaignProgress(&platformclientv2.Campaignprogress{
+		NumberOfContactsCalled:   platformclientv2.Int(60),
+		NumberOfContactsMessaged: platformclientv2.Int(15),
+		TotalNumberOfContacts:    platformclientv2.Int(100),
+		Percentage:               platformclientv2.Int(75),
+	})
+	assert.Equal(t, []interface{}{map[string]interface{}{
+		"contacts_called":    60,
+		"contacts_messaged":  15,
+		"total_contacts":     100,
+		"percentage":         75,
+		"contacts_remaining": 25,
+	}}, progress)
+}
+
+// buildLifecycleTestProxy returns a proxy holding a single campaign whose status transitions through the steps of pendingStatuses
+// after each update, simulating a campaign which takes time to reach the requested status
+func buildLifecycleTestProxy(t *testing.T, campaignId, initialStatus string, pendingStatuses map[string][]string, updates *[]string) *outboundCampaignProxy {
+	status := initialStatus
+	var pending []string
+
+	campaignStatePollInterval = 10 * time.Millisecond
+	campaignProxy := &outboundCampaignProxy{}
+	campaignProxy.getOutboundCampaignByIdAttr = func(ctx context.Context, p *outboundCampaignProxy, id string) (*platformclientv2.Campaign, *platformclientv2.APIResponse, error) {
+		assert.Equal(t, campaignId, id)
+		if len(pending) > 0 {
+			status, pending = pending[0], pending[1:]
+		}
+		return &platformclientv2.Campaign{
+			Id:             &campaignId,
+			Name:           platformclientv2.String("campaign"),
+			CampaignStatus: platformclientv2.String(status),
+		}, &platformclientv2.APIResponse{StatusCode: http.StatusOK}, nil
+	}
+	campaignProxy.updateOutboundCampaignAttr = func(ctx context.Context, p *outboundCampaignProxy, id string, campaign *platformclientv2.Campaign) (*platformclientv2.Campaign, *platformclientv2.APIResponse, error) {
+		*updates = append(*updates, *campaign.CampaignStatus)
+		pending = pendingStatuses[*campaign.CampaignStatus]
+		return campaign, &platformclientv2.APIResponse{StatusCode: http.StatusOK}, nil
+	}
+	return campaignProxy
+}
+
+func TestUnitSetCampaignState(t *testing.T) {
+	campaignId := uuid.NewString()
+	pendingStatuses := map[string][]string{
+		"on":       {"off", "on"},
+		"stopping": {"on", "stopping", "off"},
+	}
+
+	var updates []string
+	proxy := buildLifecycleTestProxy(t, campaignId, "off", pendingStatuses, &updates)
+	diagErr := proxy.setCampaignState(context.Background(), campaignId, "on", time.Minute)
+	assert.Nil(t, diagErr)
+	assert.Equal(t, []string{"on"}, updates)
+
+	updates = nil
+	proxy = buildLifecycleTestProxy(t, campaignId, "on", pendingStatuses, &updates)
+	diagErr = proxy.setCampaignState(context.Background(), campaignId, "stopping", time.Minute)
+	assert.Nil(t, diagErr)
+	assert.Equal(t, []string{"stopping"}, updates)
+
+	// A complete campaign satisfies a desired state of on
+	updates = nil
+	proxy = buildLifecycleTestProxy(t, campaignId, "complete", pendingStatuses, &updates)
+	diagErr = proxy.setCampaignState(context.Background(), campaignId, "on", time.Minute)
+	assert.Nil(t, diagErr)
+	assert.Empty(t, updates)
+}
+
+func TestUnitStopCampaignForUpdate(t *testing.T) {
+	campaignId := uuid.NewString()
+	pendingStatuses := map[string][]string{
+		"off": {"stopping", "off"},
+	}
+
+	var updates []string
+	proxy := buildLifecycleTestProxy(t, campaignId, "on", pendingStatuses, &updates)
+	wasRunning, diagErr := stopCampaignForUpdate(context.Background(), campaignId, proxy, time.Minute)
+	assert.Nil(t, diagErr)
+	assert.True(t, wasRunning)
+	assert.Equal(t, []string{"off"}, updates)
+
+	updates = nil
+	proxy = buildLifecycleTestProxy(t, campaignId, "off", pendingStatuses, &updates)
+	wasRunning, diagErr = stopCampaignForUpdate(context.Background(), campaignId, proxy, time.Minute)
+	assert.Nil(t, diagErr)
+	assert.False(t, wasRunning)
+	assert.Empty(t, updates)
+}
@@ -15,6 +15,7 @@ import (
 	"terraform-provider-genesyscloud/genesyscloud/util"
 	"terraform-provider-genesyscloud/genesyscloud/util/lists"
 	"terraform-provider-genesyscloud/genesyscloud/util/resourcedata"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
@@ -27,6 +28,17 @@ The resource_genesyscloud_outbound_campaign_utils.go file contains various helpe
 and unmarshal data into formats consumable by Terraform and/or Genesys Cloud.
 */
 
+const defaultWaitForStateTimeout = "5m"
+
+// desiredStates are the values of the desired_state attribute
+var desiredStates = []string{"on", "off", "stopping"}
+
+// campaignStatePollInterval is the time waited between reads of a campaign which has not reached its desired state
+var campaignStatePollInterval = 5 * time.Second
+
+// lifecycleAttributes only control how the campaign is driven to its state and do not require the campaign to be updated
+var lifecycleAttributes = []string{"campaign_status", "desired_state", "restart_after_update", "wait_for_state_timeout"}
+
 func getOutboundCampaignFromResourceData(d *schema.ResourceData) platformclientv2.Campaign {
 	abandonRate := d.Get("abandon_rate").(float64)
 	outboundLineCount := d.Get("outbound_line_count").(int)
@@ -98,8 +110,8 @@ func updateOutboundCampaignStatus(ctx context.Context, campaignId string, proxy
 	if newCampaignStatus == "" {
 		return nil
 	}
-	// Campaign status can only go from ON -> OFF or OFF, COMPLETE, INVALID, ETC -> ON
-	if (*campaign.CampaignStatus == "on" && newCampaignStatus == "off") || newCampaignStatus == "on" {
+	// Campaign status can only go from ON -> OFF/STOPPING or OFF, COMPLETE, INVALID, ETC -> ON
+	if (*campaign.CampaignStatus == "on" && (newCampaignStatus == "off" || newCampaignStatus == "stopping")) || newCampaignStatus == "on" {
 		campaign.CampaignStatus = &newCampaignStatus
 		log.Printf("Updating Outbound Campaign %s status to %s", *campaign.Name, newCampaignStatus)
 		_, resp, err := proxy.updateOutboundCampaign(ctx, campaignId, &campaign)
@@ -110,6 +122,50 @@ func updateOutboundCampaignStatus(ctx context.Context, campaignId string, proxy
 	return nil
 }
 
+// getWaitForStateTimeout returns the wait_for_state_timeout duration of the resource
+func getWaitForStateTimeout(d *schema.ResourceData) time.Duration {
+	timeout, err := time.ParseDuration(d.Get("wait_for_state_timeout").(string))
+	if err != nil {
+		timeout, _ = time.ParseDuration(defaultWaitForStateTimeout)
+	}
+	return timeout
+}
+
+// campaignStateReached reports whether a campaign status satisfies a desired state. A campaign which is on may complete
+// on its own, and a campaign which is stopping ends up off.
+func campaignStateReached(desiredState, campaignStatus string) bool {
+	if desiredState == "on" {
+		return campaignStatus == "on" || campaignStatus == "complete"
+	}
+	return campaignStatus != "on" && campaignStatus != "stopping"
+}
+
+// flattenDesiredState returns the desired_state to store for a campaign status so that drift from the desired state is
+// planned, while the statuses a desired state settles in are not
+func flattenDesiredState(desiredState, campaignStatus string) string {
+	if desiredState == "" || campaignStateReached(desiredState, campaignStatus) {
+		return desiredState
+	}
+	if campaignStatus == "on" || campaignStatus == "stopping" {
+		return "on"
+	}
+	return "off"
+}
+
+// stopCampaignForUpdate turns a running campaign off and waits for it to stop. It returns whether the campaign was running.
+func stopCampaignForUpdate(ctx context.Context, campaignId string, proxy *outboundCampaignProxy, timeout time.Duration) (bool, diag.Diagnostics) {
+	campaign, resp, err := proxy.getOutboundCampaignById(ctx, campaignId)
+	if err != nil {
+		return false, util.BuildAPIDiagnosticError(ResourceType, fmt.Sprintf("Failed to read Outbound Campaign %s: %s", campaignId, err), resp)
+	}
+	if *campaign.CampaignStatus != "on" {
+		return false, nil
+	}
+
+	log.Printf("Turning off Outbound Campaign %s before updating it", campaignId)
+	return true, proxy.setCampaignState(ctx, campaignId, "off", timeout)
+}
+
 func buildPhoneColumns(phonecolumns []interface{}) *[]platformclientv2.Phonecolumn {
 	if len(phonecolumns) == 0 {
 		return nil
@@ -226,6 +282,26 @@ func flattenLineBalancingSettings(settings *platformclientv2.Dynamiclinebalancin
 	return []interface{}{settingsMap}
 }
 
+func flattenCampaignProgress(progress *platformclientv2.Campaignprogress) []interface{} {
+	progressMap := make(map[string]interface{})
+	resourcedata.SetMapValueIfNotNil(progressMap, "contacts_called", progress.NumberOfContactsCalled)
+	resourcedata.SetMapValueIfNotNil(progressMap, "contacts_messaged", progress.NumberOfContactsMessaged)
+	resourcedata.SetMapValueIfNotNil(progressMap, "total_contacts", progress.TotalNumberOfContacts)
+	resourcedata.SetMapValueIfNotNil(progressMap, "percentage", progress.Percentage)
+
+	if progress.TotalNumberOfContacts != nil {
+		processed := 0
+		if progress.NumberOfContactsCalled != nil {
+			processed += *progress.NumberOfContactsCalled
+		}
+		if progress.NumberOfContactsMessaged != nil {
+			processed += *progress.NumberOfContactsMessaged
+		}
+		progressMap["contacts_remaining"] = max(*progress.TotalNumberOfContacts-processed, 0)
+	}
+	return []interface{}{progressMap}
+}
+
 func GenerateOutboundCampaignBasic(resourceLabel string,
 	name string,
 	contactListResourceLabel string,
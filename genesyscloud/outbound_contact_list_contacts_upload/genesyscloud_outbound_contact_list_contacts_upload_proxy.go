@@ -0,0 +1,113 @@
+package outbound_contact_list_contacts_upload
+
+import (
+	"context"
+	"io"
+	"os"
+	"strings"
+	"terraform-provider-genesyscloud/genesyscloud/util/files"
+
+	"github.com/mypurecloud/platform-client-sdk-go/v149/platformclientv2"
+)
+
+/*
+The genesyscloud_outbound_contact_list_contacts_upload_proxy.go file contains the proxy structures and methods that interact
+with the Genesys Cloud SDK and the contact list upload endpoint. We use composition here for each function on the proxy so
+individual functions can be stubbed out during testing.
+*/
+
+var internalProxy *contactsUploadProxy
+
+type getContactListByIdFunc func(ctx context.Context, p *contactsUploadProxy, contactListId string) (*platformclientv2.Contactlist, *platformclientv2.APIResponse, error)
+type getContactListImportStatusFunc func(ctx context.Context, p *contactsUploadProxy, contactListId string) (*platformclientv2.Importstatus, *platformclientv2.APIResponse, error)
+type uploadContactsFileFunc func(ctx context.Context, p *contactsUploadProxy, contactListId, contactIdName, filePath string) ([]byte, error)
+type clearContactListFunc func(ctx context.Context, p *contactsUploadProxy, contactListId string) (*platformclientv2.APIResponse, error)
+
+// contactsUploadProxy contains all of the methods that call genesys cloud APIs.
+type contactsUploadProxy struct {
+	clientConfig                   *platformclientv2.Configuration
+	outboundApi                    *platformclientv2.OutboundApi
+	basePath                       string
+	accessToken                    string
+	getContactListByIdAttr         getContactListByIdFunc
+	getContactListImportStatusAttr getContactListImportStatusFunc
+	uploadContactsFileAttr         uploadContactsFileFunc
+	clearContactListAttr           clearContactListFunc
+}
+
+// newContactsUploadProxy initializes the contacts upload proxy with all of the data needed to communicate with Genesys Cloud
+func newContactsUploadProxy(clientConfig *platformclientv2.Configuration) *contactsUploadProxy {
+	api := platformclientv2.NewOutboundApiWithConfig(clientConfig)
+	return &contactsUploadProxy{
+		clientConfig:                   clientConfig,
+		outboundApi:                    api,
+		basePath:                       strings.Replace(api.Configuration.BasePath, "api", "apps", -1),
+		accessToken:                    api.Configuration.AccessToken,
+		getContactListByIdAttr:         getContactListByIdFn,
+		getContactListImportStatusAttr: getContactListImportStatusFn,
+		uploadContactsFileAttr:         uploadContactsFileFn,
+		clearContactListAttr:           clearContactListFn,
+	}
+}
+
+// getContactsUploadProxy acts as a singleton to for the internalProxy. It also ensures
+// that we can still proxy our tests by directly setting internalProxy package variable
+func getContactsUploadProxy(clientConfig *platformclientv2.Configuration) *contactsUploadProxy {
+	if internalProxy == nil {
+		internalProxy = newContactsUploadProxy(clientConfig)
+	}
+	return internalProxy
+}
+
+// getContactListById returns the contact list the contacts are uploaded to
+func (p *contactsUploadProxy) getContactListById(ctx context.Context, contactListId string) (*platformclientv2.Contactlist, *platformclientv2.APIResponse, error) {
+	return p.getContactListByIdAttr(ctx, p, contactListId)
+}
+
+// getContactListImportStatus returns the status of the last import job of a contact list
+func (p *contactsUploadProxy) getContactListImportStatus(ctx context.Context, contactListId string) (*platformclientv2.Importstatus, *platformclientv2.APIResponse, error) {
+	return p.getContactListImportStatusAttr(ctx, p, contactListId)
+}
+
+// uploadContactsFile uploads a CSV file of contacts to a contact list, starting an import job
+func (p *contactsUploadProxy) uploadContactsFile(ctx context.Context, contactListId, contactIdName, filePath string) ([]byte, error) {
+	return p.uploadContactsFileAttr(ctx, p, contactListId, contactIdName, filePath)
+}
+
+// clearContactList removes all of the contacts of a contact list
+func (p *contactsUploadProxy) clearContactList(ctx context.Context, contactListId string) (*platformclientv2.APIResponse, error) {
+	return p.clearContactListAttr(ctx, p, contactListId)
+}
+
+func getContactListByIdFn(_ context.Context, p *contactsUploadProxy, contactListId string) (*platformclientv2.Contactlist, *platformclientv2.APIResponse, error) {
+	return p.outboundApi.GetOutboundContactlist(contactListId, false, false)
+}
+
+func getContactListImportStatusFn(_ context.Context, p *contactsUploadProxy, contactListId string) (*platformclientv2.Importstatus, *platformclientv2.APIResponse, error) {
+	return p.outboundApi.GetOutboundContactlistImportstatus(contactListId)
+}
+
+func uploadContactsFileFn(_ context.Context, p *contactsUploadProxy, contactListId, contactIdName, filePath string) ([]byte, error) {
+	file, err := os.Open(filePath)
+	if err != nil {
+		return nil, err
+	}
+
+	formData := make(map[string]io.Reader)
+	formData["id"] = strings.NewReader(contactListId)
+	formData["fileType"] = strings.NewReader("contactlist")
+	formData["file"] = file
+	if contactIdName != "" {
+		formData["contact-id-name"] = strings.NewReader(contactIdName)
+	}
+
+	headers := make(map[string]string)
+	headers["Authorization"] = "Bearer " + p.accessToken
+
+	s3Uploader := files.NewS3Uploader(nil, formData, nil, headers, "POST", p.basePath+"/uploads/v2/contactlist")
+	return s3Uploader.Upload()
+}
+
+func clearContactListFn(_ context.Context, p *contactsUploadProxy, contactListId string) (*platformclientv2.APIResponse, error) {
+	return p.outboundApi.PostOutboundContactlistClear(contactListId)
+}
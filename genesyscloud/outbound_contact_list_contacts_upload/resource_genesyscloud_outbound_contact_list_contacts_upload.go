@@ -0,0 +1,281 @@
+package outbound_contact_list_contacts_upload
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+	"terraform-provider-genesyscloud/genesyscloud/provider"
+	"terraform-provider-genesyscloud/genesyscloud/util"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/retry"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/mypurecloud/platform-client-sdk-go/v149/platformclientv2"
+)
+
+/*
+The resource_genesyscloud_outbound_contact_list_contacts_upload.go contains all of the methods that perform the core logic for a resource.
+*/
+
+const (
+	importStateInProgress = "IN_PROGRESS"
+	importStateFailed     = "FAILED"
+)
+
+var (
+	importStatusPollInterval = 5 * time.Second
+	// The import status reports the previous import until the new import job is registered. An unchanged status is only
+	// accepted as the result of the new import once this time has passed.
+	importRegistrationTimeout = time.Minute
+)
+
+func createOutboundContactListContactsUpload(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	sdkConfig := meta.(*provider.ProviderMeta).ClientConfig
+	proxy := getContactsUploadProxy(sdkConfig)
+	contactListId := d.Get("contact_list_id").(string)
+
+	log.Printf("Uploading contacts to contact list %s", contactListId)
+	diagErr := importContacts(ctx, d, proxy, d.Timeout(schema.TimeoutCreate))
+	if diagErr.HasError() {
+		return diagErr
+	}
+
+	d.SetId(contactListId)
+	log.Printf("Uploaded contacts to contact list %s", contactListId)
+	return append(diagErr, readOutboundContactListContactsUpload(ctx, d, meta)...)
+}
+
+func readOutboundContactListContactsUpload(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	sdkConfig := meta.(*provider.ProviderMeta).ClientConfig
+	proxy := getContactsUploadProxy(sdkConfig)
+
+	log.Printf("Reading contact list %s of contacts upload", d.Id())
+
+	return util.WithRetriesForRead(ctx, d, func() *retry.RetryError {
+		contactList, resp, err := proxy.getContactListById(ctx, d.Id())
+		if err != nil {
+			if util.IsStatus404(resp) {
+				return retry.RetryableError(util.BuildWithRetriesApiDiagnosticError(ResourceType, fmt.Sprintf("failed to read contact list %s | error: %s", d.Id(), err), resp))
+			}
+			return retry.NonRetryableError(util.BuildWithRetriesApiDiagnosticError(ResourceType, fmt.Sprintf("failed to read contact list %s | error: %s", d.Id(), err), resp))
+		}
+
+		_ = d.Set("contact_list_id", *contactList.Id)
+
+		log.Printf("Read contact list %s of contacts upload", d.Id())
+		return nil
+	})
+}
+
+func updateOutboundContactListContactsUpload(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	sdkConfig := meta.(*provider.ProviderMeta).ClientConfig
+	proxy := getContactsUploadProxy(sdkConfig)
+
+	if !d.HasChanges("filepath", "file_content_hash", "column_mapping", "contact_id_name", "skip_invalid_rows") {
+		return readOutboundContactListContactsUpload(ctx, d, meta)
+	}
+
+	log.Printf("Uploading contacts to contact list %s", d.Id())
+	diagErr := importContacts(ctx, d, proxy, d.Timeout(schema.TimeoutUpdate))
+	if diagErr.HasError() {
+		// Clear the hash so the upload is attempted again on the next apply
+		_ = d.Set("file_content_hash", nil)
+		return diagErr
+	}
+
+	log.Printf("Uploaded contacts to contact list %s", d.Id())
+	return append(diagErr, readOutboundContactListContactsUpload(ctx, d, meta)...)
+}
+
+func deleteOutboundContactListContactsUpload(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	sdkConfig := meta.(*provider.ProviderMeta).ClientConfig
+	proxy := getContactsUploadProxy(sdkConfig)
+
+	if !d.Get("clear_contacts_on_destroy").(bool) {
+		log.Printf("Removing contacts upload of contact list %s from state. The uploaded contacts are kept.", d.Id())
+		return nil
+	}
+
+	log.Printf("Clearing the contacts of contact list %s", d.Id())
+	resp, err := proxy.clearContactList(ctx, d.Id())
+	if err != nil {
+		if util.IsStatus404(resp) {
+			log.Printf("Contact list %s already deleted", d.Id())
+			return nil
+		}
+		return util.BuildAPIDiagnosticError(ResourceType, fmt.Sprintf("failed to clear the contacts of contact list %s: %s", d.Id(), err), resp)
+	}
+	log.Printf("Cleared the contacts of contact list %s", d.Id())
+	return nil
+}
+
+// importContacts validates the contacts file against the contact list, uploads the rows to import and waits for the import job to finish.
+// Rows left out of the import are returned as warnings.
+func importContacts(ctx context.Context, d *schema.ResourceData, proxy *contactsUploadProxy, timeout time.Duration) diag.Diagnostics {
+	var diagErr diag.Diagnostics
+
+	contactListId := d.Get("contact_list_id").(string)
+	filePath := d.Get("filepath").(string)
+	contactIdName := d.Get("contact_id_name").(string)
+	skipInvalidRows := d.Get("skip_invalid_rows").(bool)
+
+	columnMapping := make(map[string]string)
+	for csvColumn, column := range d.Get("column_mapping").(map[string]interface{}) {
+		columnMapping[csvColumn] = column.(string)
+	}
+
+	contactList, resp, err := proxy.getContactListById(ctx, contactListId)
+	if err != nil {
+		return util.BuildAPIDiagnosticError(ResourceType, fmt.Sprintf("failed to read contact list %s: %s", contactListId, err), resp)
+	}
+
+	summary, uploadFilePath, err := writeContactsUploadFile(filePath, contactList, columnMapping, contactIdName, skipInvalidRows)
+	if uploadFilePath != "" {
+		defer os.Remove(uploadFilePath)
+	}
+	if err != nil {
+		return util.BuildDiagnosticError(ResourceType, fmt.Sprintf("failed to validate contacts file %s", filePath), err)
+	}
+
+	if summary.rejectedRows > 0 {
+		msg := fmt.Sprintf("%d rows of contacts file %s failed validation against contact list %s", summary.rejectedRows, filePath, contactListId)
+		if !skipInvalidRows {
+			return util.BuildDiagnosticError(ResourceType, msg, fmt.Errorf("%s", summary.rowErrorSummary()))
+		}
+		diagErr = append(diagErr, diag.Diagnostic{
+			Severity: diag.Warning,
+			Summary:  msg + " and were not imported",
+			Detail:   summary.rowErrorSummary(),
+		})
+	}
+	if summary.validRows == 0 {
+		return util.BuildDiagnosticError(ResourceType, fmt.Sprintf("contacts file %s has no rows to import", filePath), fmt.Errorf("no valid rows found"))
+	}
+
+	// The status of the previous import is recorded so it is not mistaken for the result of this upload
+	previousStatus, resp, err := proxy.getContactListImportStatus(ctx, contactListId)
+	if err != nil {
+		if !util.IsStatus404(resp) {
+			return util.BuildAPIDiagnosticError(ResourceType, fmt.Sprintf("failed to read import status of contact list %s: %s", contactListId, err), resp)
+		}
+		previousStatus = nil
+	}
+
+	if _, err := proxy.uploadContactsFile(ctx, contactListId, contactIdName, uploadFilePath); err != nil {
+		return util.BuildDiagnosticError(ResourceType, fmt.Sprintf("failed to upload contacts file %s to contact list %s", filePath, contactListId), err)
+	}
+
+	importStatus, waitErr := waitForContactsImport(ctx, proxy, contactListId, previousStatus, timeout)
+	if waitErr.HasError() {
+		return waitErr
+	}
+	diagErr = append(diagErr, waitErr...)
+
+	_ = d.Set("import_state", importStatus.State)
+	_ = d.Set("total_records", importStatus.TotalRecords)
+	_ = d.Set("completed_records", importStatus.CompletedRecords)
+	_ = d.Set("rejected_row_count", summary.rejectedRows)
+	_ = d.Set("rejected_rows", summary.reportedRowErrors())
+
+	return diagErr
+}
+
+// writeContactsUploadFile validates the contacts file and writes the rows to import to a temporary file.
+// The path of the temporary file is returned so it can be removed by the caller.
+func writeContactsUploadFile(filePath string, contactList *platformclientv2.Contactlist, columnMapping map[string]string, contactIdName string, skipInvalidRows bool) (*contactsFileSummary, string, error) {
+	src, err := os.Open(filePath)
+	if err != nil {
+		return nil, "", err
+	}
+	defer src.Close()
+
+	dst, err := os.CreateTemp("", "contact-list-upload-*.csv")
+	if err != nil {
+		return nil, "", err
+	}
+	defer dst.Close()
+
+	summary, err := prepareContactsFile(src, dst, contactList, columnMapping, contactIdName, skipInvalidRows)
+	return summary, dst.Name(), err
+}
+
+// waitForContactsImport polls the import status of the contact list until the import job completes or fails.
+// A completed or failed status is only accepted once the import of the upload has started, that is once the status is in
+// progress or differs from previousStatus.
+func waitForContactsImport(ctx context.Context, proxy *contactsUploadProxy, contactListId string, previousStatus *platformclientv2.Importstatus, timeout time.Duration) (*platformclientv2.Importstatus, diag.Diagnostics) {
+	var (
+		importStatus *platformclientv2.Importstatus
+		warnings     diag.Diagnostics
+	)
+	importStarted := previousStatus == nil
+	uploadedAt := time.Now()
+
+	diagErr := util.WithRetries(ctx, timeout, func() *retry.RetryError {
+		status, resp, err := proxy.getContactListImportStatus(ctx, contactListId)
+		if err != nil {
+			if util.IsStatus404(resp) {
+				return retry.RetryableError(util.BuildWithRetriesApiDiagnosticError(ResourceType, fmt.Sprintf("import status of contact list %s not found", contactListId), resp))
+			}
+			return retry.NonRetryableError(util.BuildWithRetriesApiDiagnosticError(ResourceType, fmt.Sprintf("failed to read import status of contact list %s: %s", contactListId, err), resp))
+		}
+
+		state := ""
+		if status.State != nil {
+			state = *status.State
+		}
+
+		if !importStarted {
+			if state == importStateInProgress || !isSameImportStatus(previousStatus, status) {
+				importStarted = true
+			} else if time.Since(uploadedAt) < importRegistrationTimeout {
+				log.Printf("Import status of contact list %s still reports the previous import", contactListId)
+				time.Sleep(importStatusPollInterval)
+				return retry.RetryableError(fmt.Errorf("import of contacts into contact list %s did not start within %v", contactListId, timeout))
+			} else {
+				warnings = append(warnings, diag.Diagnostic{
+					Severity: diag.Warning,
+					Summary:  fmt.Sprintf("Import status of contact list %s did not change after the upload", contactListId),
+					Detail:   fmt.Sprintf("The import status did not change within %v of the upload, so the reported records may belong to the previous import.", importRegistrationTimeout),
+				})
+			}
+		}
+
+		switch state {
+		case importStateFailed:
+			failureReason := "no failure reason available"
+			if status.FailureReason != nil {
+				failureReason = *status.FailureReason
+			}
+			return retry.NonRetryableError(fmt.Errorf("import of contacts into contact list %s failed: %s", contactListId, failureReason))
+		case importStateInProgress, "":
+			log.Printf("Import of contacts into contact list %s in progress: %d%% complete", contactListId, valueOrZero(status.PercentComplete))
+			time.Sleep(importStatusPollInterval)
+			return retry.RetryableError(fmt.Errorf("import of contacts into contact list %s did not finish within %v", contactListId, timeout))
+		}
+
+		importStatus = status
+		return nil
+	})
+	if diagErr != nil {
+		return nil, diagErr
+	}
+	return importStatus, warnings
+}
+
+// isSameImportStatus returns true when both statuses report the same import
+func isSameImportStatus(a, b *platformclientv2.Importstatus) bool {
+	return util.StringOrNil(a.State) == util.StringOrNil(b.State) &&
+		valueOrZero(a.TotalRecords) == valueOrZero(b.TotalRecords) &&
+		valueOrZero(a.CompletedRecords) == valueOrZero(b.CompletedRecords) &&
+		valueOrZero(a.PercentComplete) == valueOrZero(b.PercentComplete) &&
+		util.StringOrNil(a.FailureReason) == util.StringOrNil(b.FailureReason)
+}
+
+func valueOrZero(value *int) int {
+	if value == nil {
+		return 0
+	}
+	return *value
+}
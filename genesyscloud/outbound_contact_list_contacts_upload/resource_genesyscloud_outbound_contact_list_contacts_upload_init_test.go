@@ -0,0 +1,43 @@
+package outbound_contact_list_contacts_upload
+
+import (
+	"sync"
+	outboundContactList "terraform-provider-genesyscloud/genesyscloud/outbound_contact_list"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// providerResources holds a map of all registered resources
+var providerResources map[string]*schema.Resource
+
+type registerTestInstance struct {
+	resourceMapMutex sync.RWMutex
+}
+
+// registerTestResources registers all resources used in the tests
+func (r *registerTestInstance) registerTestResources() {
+	r.resourceMapMutex.Lock()
+	defer r.resourceMapMutex.Unlock()
+
+	providerResources[ResourceType] = ResourceOutboundContactListContactsUpload()
+	providerResources[outboundContactList.ResourceType] = outboundContactList.ResourceOutboundContactList()
+}
+
+// initTestResources initializes all test resources.
+func initTestResources() {
+	providerResources = make(map[string]*schema.Resource)
+
+	regInstance := &registerTestInstance{}
+
+	regInstance.registerTestResources()
+}
+
+// TestMain is a "setup" function called by the testing framework when run the test
+func TestMain(m *testing.M) {
+	// Run setup function before starting the test suite for the outbound_contact_list_contacts_upload package
+	initTestResources()
+
+	// Run the test suite for the outbound_contact_list_contacts_upload package
+	m.Run()
+}
@@ -0,0 +1,111 @@
+package outbound_contact_list_contacts_upload
+
+import (
+	"fmt"
+	"terraform-provider-genesyscloud/genesyscloud/provider"
+	registrar "terraform-provider-genesyscloud/genesyscloud/resource_register"
+	"terraform-provider-genesyscloud/genesyscloud/validators"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+/*
+The resource_genesyscloud_outbound_contact_list_contacts_upload_schema.go file contains the schema definition of the
+genesyscloud_outbound_contact_list_contacts_upload resource. The resource is not exported because the uploaded file can not
+be retrieved from Genesys Cloud.
+*/
+
+const ResourceType = "genesyscloud_outbound_contact_list_contacts_upload"
+
+// SetRegistrar registers all of the resources, datasources and exporters in the package
+func SetRegistrar(regInstance registrar.Registrar) {
+	regInstance.RegisterResource(ResourceType, ResourceOutboundContactListContactsUpload())
+}
+
+// ResourceOutboundContactListContactsUpload registers the genesyscloud_outbound_contact_list_contacts_upload resource with Terraform
+func ResourceOutboundContactListContactsUpload() *schema.Resource {
+	return &schema.Resource{
+		Description: `Genesys Cloud Outbound Contact List Contacts Upload. Bulk imports the contacts of a CSV file into a contact list.
+The rows of the file are validated against the columns, phone columns and column data type specifications of the contact list before the file is uploaded, and the resource waits for the import job to finish.
+The contacts are imported again whenever the file content hash or the import settings change.`,
+
+		CreateContext: provider.CreateWithPooledClient(createOutboundContactListContactsUpload),
+		ReadContext:   provider.ReadWithPooledClient(readOutboundContactListContactsUpload),
+		UpdateContext: provider.UpdateWithPooledClient(updateOutboundContactListContactsUpload),
+		DeleteContext: provider.DeleteWithPooledClient(deleteOutboundContactListContactsUpload),
+		SchemaVersion: 1,
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(20 * time.Minute),
+			Update: schema.DefaultTimeout(20 * time.Minute),
+		},
+		Schema: map[string]*schema.Schema{
+			"contact_list_id": {
+				Description: "ID of the contact list the contacts are imported into.",
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+			},
+			"filepath": {
+				Description:  "Path to the CSV file of contacts to import. The first row of the file must contain the column names.",
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validators.ValidatePath,
+			},
+			"file_content_hash": {
+				Description: "Hash value of the CSV file content. Used to detect changes.",
+				Type:        schema.TypeString,
+				Required:    true,
+			},
+			"column_mapping": {
+				Description: "Map of CSV column names to contact list column names. Columns of the file which are not in the map must match a contact list column name.",
+				Type:        schema.TypeMap,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			"contact_id_name": {
+				Description: "Contact list column used as the ID of the imported contacts. Contacts with an existing ID are updated instead of added.",
+				Type:        schema.TypeString,
+				Optional:    true,
+			},
+			"skip_invalid_rows": {
+				Description: "If true, rows which fail validation are left out of the import and reported as warnings. If false, the import fails when any row is invalid.",
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+			},
+			"clear_contacts_on_destroy": {
+				Description: "If true, all of the contacts of the contact list are removed when the resource is destroyed.",
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+			},
+			"import_state": {
+				Description: "State of the last import job.",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"total_records": {
+				Description: "Number of records of the last import job.",
+				Type:        schema.TypeInt,
+				Computed:    true,
+			},
+			"completed_records": {
+				Description: "Number of records imported by the last import job.",
+				Type:        schema.TypeInt,
+				Computed:    true,
+			},
+			"rejected_row_count": {
+				Description: "Number of rows left out of the last import because they failed validation.",
+				Type:        schema.TypeInt,
+				Computed:    true,
+			},
+			"rejected_rows": {
+				Description: fmt.Sprintf("Validation errors of the rows left out of the last import. At most %d errors are kept.", maxReportedRowErrors),
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+		},
+	}
+}
@@ -0,0 +1,88 @@
+package outbound_contact_list_contacts_upload
+
+import (
+	"fmt"
+	"path/filepath"
+	"strconv"
+	"terraform-provider-genesyscloud/genesyscloud/provider"
+	"terraform-provider-genesyscloud/genesyscloud/util"
+	"testing"
+
+	outboundContactList "terraform-provider-genesyscloud/genesyscloud/outbound_contact_list"
+
+	"github.com/google/uuid"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func getTestDataPath(elem ...string) string {
+	basePath := filepath.Join("..", "..", "test", "data")
+	subPath := filepath.Join(elem...)
+	return filepath.Join(basePath, subPath)
+}
+
+func TestAccResourceOutboundContactListContactsUpload(t *testing.T) {
+	var (
+		resourceLabel     = "contacts_upload"
+		fullResourceLabel = ResourceType + "." + resourceLabel
+
+		contactListResourceLabel = "contact_list"
+		contactListName          = "tf test contact list " + uuid.NewString()
+		contactListId            = fmt.Sprintf("%s.%s.id", outboundContactList.ResourceType, contactListResourceLabel)
+
+		filePath        = getTestDataPath("resource", ResourceType, "contacts.csv")
+		filePathUpdated = getTestDataPath("resource", ResourceType, "contacts_updated.csv")
+		columnMapping   = map[string]string{"id": "ContactId"}
+	)
+
+	contactListResource := outboundContactList.GenerateOutboundContactList(
+		contactListResourceLabel,
+		contactListName,
+		util.NullValue,
+		util.NullValue,
+		[]string{},
+		[]string{strconv.Quote("ContactId"), strconv.Quote("Cell"), strconv.Quote("Home")},
+		util.FalseValue,
+		util.NullValue,
+		util.NullValue,
+		outboundContactList.GeneratePhoneColumnsBlock("Cell", "cell", util.NullValue),
+		outboundContactList.GeneratePhoneColumnsBlock("Home", "home", util.NullValue),
+	)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { util.TestAccPreCheck(t) },
+		ProviderFactories: provider.GetProviderFactories(providerResources, nil),
+		Steps: []resource.TestStep{
+			{
+				Config: contactListResource + GenerateOutboundContactListContactsUpload(
+					resourceLabel,
+					contactListId,
+					filePath,
+					strconv.Quote("ContactId"),
+					util.FalseValue,
+					columnMapping,
+				),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrPair(fullResourceLabel, "contact_list_id", outboundContactList.ResourceType+"."+contactListResourceLabel, "id"),
+					resource.TestCheckResourceAttr(fullResourceLabel, "total_records", "3"),
+					resource.TestCheckResourceAttr(fullResourceLabel, "rejected_row_count", "0"),
+				),
+			},
+			{
+				// The invalid row of the updated file is left out of the import
+				Config: contactListResource + GenerateOutboundContactListContactsUpload(
+					resourceLabel,
+					contactListId,
+					filePathUpdated,
+					strconv.Quote("ContactId"),
+					util.TrueValue,
+					columnMapping,
+				),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(fullResourceLabel, "total_records", "4"),
+					resource.TestCheckResourceAttr(fullResourceLabel, "rejected_row_count", "1"),
+					resource.TestCheckResourceAttr(fullResourceLabel, "rejected_rows.0", "row 6, column 'Cell': 'not a number' is not a valid phone number"),
+				),
+			},
+		},
+	})
+}
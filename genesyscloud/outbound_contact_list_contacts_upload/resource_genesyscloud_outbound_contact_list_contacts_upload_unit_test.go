@@ -0,0 +1,303 @@
+package outbound_contact_list_contacts_upload
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"terraform-provider-genesyscloud/genesyscloud/provider"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/mypurecloud/platform-client-sdk-go/v149/platformclientv2"
+	"github.com/stretchr/testify/assert"
+)
+
+func generateTestContactList(id string) *platformclientv2.Contactlist {
+	return &platformclientv2.Contactlist{
+		Id:          &id,
+		ColumnNames: &[]string{"Id", "Cell", "Age", "Joined"},
+		PhoneColumns: &[]platformclientv2.Contactphonenumbercolumn{
+			{ColumnName: platformclientv2.String("Cell")},
+		},
+		ColumnDataTypeSpecifications: &[]platformclientv2.Columndatatypespecification{
+			{ColumnName: platformclientv2.String("Age"), ColumnDataType: platformclientv2.String("NUMERIC"), Min: platformclientv2.Int(18), Max: platformclientv2.Int(99)},
+			{ColumnName: platformclientv2.String("Joined"), ColumnDataType: platformclientv2.String("TIMESTAMP")},
+		},
+	}
+}
+
+func TestUnitPrepareContactsFile(t *testing.T) {
+	contactList := generateTestContactList(uuid.NewString())
+	file := "id,Cell,Age,Joined\n" +
+		"1,+1 (317) 555-0100,30,2024-01-31\n" +
+		"2,not a phone,30,2024-01-31\n" +
+		"3,3175550101,12,yesterday\n" +
+		"1,3175550102,40,\n" +
+		"5,3175550103\n"
+
+	var dst bytes.Buffer
+	summary, err := prepareContactsFile(strings.NewReader(file), &dst, contactList, map[string]string{"id": "Id"}, "Id", true)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, summary.validRows)
+	assert.Equal(t, 4, summary.rejectedRows)
+	assert.Equal(t, []string{
+		"row 3, column 'Cell': 'not a phone' is not a valid phone number",
+		"row 4, column 'Age': 12 is less than the minimum of 18",
+		"row 4, column 'Joined': 'yesterday' is not a valid timestamp",
+		"row 5, column 'Id': contact id '1' is a duplicate of row 2",
+		"row 6: expected 4 values, found 2",
+	}, summary.reportedRowErrors())
+	assert.Equal(t, "Id,Cell,Age,Joined\n1,+1 (317) 555-0100,30,2024-01-31\n", dst.String())
+
+	dst.Reset()
+	summary, err = prepareContactsFile(strings.NewReader(file), &dst, contactList, map[string]string{"id": "Id"}, "Id", false)
+	assert.NoError(t, err)
+	assert.Equal(t, 4, summary.rejectedRows)
+	assert.Equal(t, 6, strings.Count(dst.String(), "\n"), "invalid rows should be kept when skip_invalid_rows is false")
+}
+
+func TestUnitPrepareContactsFileHeader(t *testing.T) {
+	contactList := generateTestContactList(uuid.NewString())
+
+	_, err := prepareContactsFile(strings.NewReader("Id,Cell,Age,Extra,Extra\n"), &bytes.Buffer{}, contactList, nil, "", false)
+	assert.ErrorContains(t, err, "column 'Extra' is not a column of the contact list")
+	assert.ErrorContains(t, err, "column 'Extra' appears more than once")
+	assert.ErrorContains(t, err, "contact list column 'Joined' is missing")
+
+	_, err = prepareContactsFile(strings.NewReader("\ufeffId,Cell,Age,Joined\n"), &bytes.Buffer{}, contactList, nil, "ContactId", false)
+	assert.ErrorContains(t, err, "contact id column 'ContactId' is missing")
+	assert.NotContains(t, err.Error(), "column 'Id'")
+
+	_, err = prepareContactsFile(strings.NewReader(""), &bytes.Buffer{}, contactList, nil, "", false)
+	assert.ErrorContains(t, err, "the contacts file is empty")
+}
+
+func TestUnitRowErrorSummary(t *testing.T) {
+	summary := contactsFileSummary{}
+	for i := 0; i < maxReportedRowErrors+5; i++ {
+		summary.rowErrors = append(summary.rowErrors, contactRowError{row: i + 2, message: "invalid"})
+	}
+
+	assert.Len(t, summary.reportedRowErrors(), maxReportedRowErrors)
+	lines := strings.Split(summary.rowErrorSummary(), "\n")
+	assert.Len(t, lines, maxSummarizedRowErrors+1)
+	assert.Equal(t, "... and 95 more", lines[maxSummarizedRowErrors])
+}
+
+func TestUnitIsValidPhoneNumber(t *testing.T) {
+	for value, expected := range map[string]bool{
+		"+13175550100":      true,
+		"(317) 555-0100":    true,
+		"317.555.0100":      true,
+		"12":                false,
+		"+1234567890123456": false,
+		"317-555-0100 x12":  false,
+		"1+3175550100":      false,
+	} {
+		assert.Equal(t, expected, isValidPhoneNumber(value), value)
+	}
+}
+
+func TestUnitResourceOutboundContactListContactsUploadCreate(t *testing.T) {
+	tId := uuid.NewString()
+	contactList := generateTestContactList(tId)
+	filePath := filepath.Join(t.TempDir(), "contacts.csv")
+	assert.NoError(t, os.WriteFile(filePath, []byte("Id,Cell,Age,Joined\n1,3175550100,30,2024-01-31\n2,bad,30,\n"), 0644))
+
+	uploaded := false
+	uploadProxy := &contactsUploadProxy{}
+	uploadProxy.getContactListByIdAttr = func(ctx context.Context, p *contactsUploadProxy, contactListId string) (*platformclientv2.Contactlist, *platformclientv2.APIResponse, error) {
+		assert.Equal(t, tId, contactListId)
+		return contactList, &platformclientv2.APIResponse{StatusCode: http.StatusOK}, nil
+	}
+	uploadProxy.uploadContactsFileAttr = func(ctx context.Context, p *contactsUploadProxy, contactListId, contactIdName, uploadFilePath string) ([]byte, error) {
+		assert.Equal(t, tId, contactListId)
+		assert.Equal(t, "Id", contactIdName)
+		content, err := os.ReadFile(uploadFilePath)
+		assert.NoError(t, err)
+		assert.Equal(t, "Id,Cell,Age,Joined\n1,3175550100,30,2024-01-31\n", string(content))
+		uploaded = true
+		return nil, nil
+	}
+	statusCalls := 0
+	uploadProxy.getContactListImportStatusAttr = func(ctx context.Context, p *contactsUploadProxy, contactListId string) (*platformclientv2.Importstatus, *platformclientv2.APIResponse, error) {
+		if !uploaded {
+			return nil, &platformclientv2.APIResponse{StatusCode: http.StatusNotFound}, fmt.Errorf("not found")
+		}
+		statusCalls++
+		return &platformclientv2.Importstatus{
+			State:            platformclientv2.String("COMPLETED"),
+			TotalRecords:     platformclientv2.Int(1),
+			CompletedRecords: platformclientv2.Int(1),
+			PercentComplete:  platformclientv2.Int(100),
+		}, &platformclientv2.APIResponse{StatusCode: http.StatusOK}, nil
+	}
+	internalProxy = uploadProxy
+	defer func() { internalProxy = nil }()
+
+	ctx := context.Background()
+	gcloud := &provider.ProviderMeta{ClientConfig: &platformclientv2.Configuration{}}
+
+	resourceDataMap := map[string]interface{}{
+		"contact_list_id":   tId,
+		"filepath":          filePath,
+		"file_content_hash": "hash",
+		"contact_id_name":   "Id",
+		"skip_invalid_rows": true,
+	}
+	d := schema.TestResourceDataRaw(t, ResourceOutboundContactListContactsUpload().Schema, resourceDataMap)
+
+	diags := createOutboundContactListContactsUpload(ctx, d, gcloud)
+	assert.False(t, diags.HasError())
+	assert.Len(t, diags, 1, "rejected rows should be reported as a warning")
+	assert.Equal(t, tId, d.Id())
+	assert.Equal(t, 1, statusCalls)
+	assert.Equal(t, "COMPLETED", d.Get("import_state").(string))
+	assert.Equal(t, 1, d.Get("completed_records").(int))
+	assert.Equal(t, 1, d.Get("rejected_row_count").(int))
+	assert.Equal(t, []interface{}{"row 3, column 'Cell': 'bad' is not a valid phone number"}, d.Get("rejected_rows").([]interface{}))
+}
+
+func TestUnitResourceOutboundContactListContactsUploadImportFailed(t *testing.T) {
+	tId := uuid.NewString()
+	filePath := filepath.Join(t.TempDir(), "contacts.csv")
+	assert.NoError(t, os.WriteFile(filePath, []byte("Id,Cell,Age,Joined\n1,3175550100,30,2024-01-31\n"), 0644))
+
+	uploadProxy := &contactsUploadProxy{}
+	uploadProxy.getContactListByIdAttr = func(ctx context.Context, p *contactsUploadProxy, contactListId string) (*platformclientv2.Contactlist, *platformclientv2.APIResponse, error) {
+		return generateTestContactList(tId), &platformclientv2.APIResponse{StatusCode: http.StatusOK}, nil
+	}
+	uploaded := false
+	uploadProxy.uploadContactsFileAttr = func(ctx context.Context, p *contactsUploadProxy, contactListId, contactIdName, uploadFilePath string) ([]byte, error) {
+		uploaded = true
+		return nil, nil
+	}
+	uploadProxy.getContactListImportStatusAttr = func(ctx context.Context, p *contactsUploadProxy, contactListId string) (*platformclientv2.Importstatus, *platformclientv2.APIResponse, error) {
+		if !uploaded {
+			return nil, &platformclientv2.APIResponse{StatusCode: http.StatusNotFound}, fmt.Errorf("not found")
+		}
+		return &platformclientv2.Importstatus{
+			State:         platformclientv2.String(importStateFailed),
+			FailureReason: platformclientv2.String("Invalid file"),
+		}, &platformclientv2.APIResponse{StatusCode: http.StatusOK}, nil
+	}
+	internalProxy = uploadProxy
+	defer func() { internalProxy = nil }()
+
+	gcloud := &provider.ProviderMeta{ClientConfig: &platformclientv2.Configuration{}}
+	resourceDataMap := map[string]interface{}{
+		"contact_list_id":   tId,
+		"filepath":          filePath,
+		"file_content_hash": "hash",
+	}
+	d := schema.TestResourceDataRaw(t, ResourceOutboundContactListContactsUpload().Schema, resourceDataMap)
+
+	diags := createOutboundContactListContactsUpload(context.Background(), d, gcloud)
+	assert.True(t, diags.HasError())
+	assert.Contains(t, diags[0].Summary, "Invalid file")
+	assert.Equal(t, "", d.Id())
+}
+
+func TestUnitResourceOutboundContactListContactsUploadStaleImportStatus(t *testing.T) {
+	defer func(pollInterval, registrationTimeout time.Duration) {
+		importStatusPollInterval = pollInterval
+		importRegistrationTimeout = registrationTimeout
+	}(importStatusPollInterval, importRegistrationTimeout)
+	importStatusPollInterval = 0
+
+	previousStatus := &platformclientv2.Importstatus{
+		State:            platformclientv2.String("COMPLETED"),
+		TotalRecords:     platformclientv2.Int(5),
+		CompletedRecords: platformclientv2.Int(5),
+		PercentComplete:  platformclientv2.Int(100),
+	}
+	newStatus := &platformclientv2.Importstatus{
+		State:            platformclientv2.String("COMPLETED"),
+		TotalRecords:     platformclientv2.Int(1),
+		CompletedRecords: platformclientv2.Int(1),
+		PercentComplete:  platformclientv2.Int(100),
+	}
+	inProgressStatus := &platformclientv2.Importstatus{
+		State:           platformclientv2.String(importStateInProgress),
+		PercentComplete: platformclientv2.Int(50),
+	}
+
+	testCases := []struct {
+		name                string
+		registrationTimeout time.Duration
+		statuses            []*platformclientv2.Importstatus
+		expectedRecords     int
+		expectedWarnings    int
+	}{
+		{
+			name:                "previous status until in progress",
+			registrationTimeout: time.Minute,
+			statuses:            []*platformclientv2.Importstatus{previousStatus, previousStatus, inProgressStatus, previousStatus},
+			expectedRecords:     5,
+		},
+		{
+			name:                "previous status until changed",
+			registrationTimeout: time.Minute,
+			statuses:            []*platformclientv2.Importstatus{previousStatus, previousStatus, newStatus},
+			expectedRecords:     1,
+		},
+		{
+			name:                "previous status after registration timeout",
+			registrationTimeout: 0,
+			statuses:            []*platformclientv2.Importstatus{previousStatus},
+			expectedRecords:     5,
+			expectedWarnings:    1,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			importRegistrationTimeout = tc.registrationTimeout
+			tId := uuid.NewString()
+			filePath := filepath.Join(t.TempDir(), "contacts.csv")
+			assert.NoError(t, os.WriteFile(filePath, []byte("Id,Cell,Age,Joined\n1,3175550100,30,2024-01-31\n"), 0644))
+
+			uploaded := false
+			statusCalls := 0
+			uploadProxy := &contactsUploadProxy{}
+			uploadProxy.getContactListByIdAttr = func(ctx context.Context, p *contactsUploadProxy, contactListId string) (*platformclientv2.Contactlist, *platformclientv2.APIResponse, error) {
+				return generateTestContactList(tId), &platformclientv2.APIResponse{StatusCode: http.StatusOK}, nil
+			}
+			uploadProxy.uploadContactsFileAttr = func(ctx context.Context, p *contactsUploadProxy, contactListId, contactIdName, uploadFilePath string) ([]byte, error) {
+				uploaded = true
+				return nil, nil
+			}
+			uploadProxy.getContactListImportStatusAttr = func(ctx context.Context, p *contactsUploadProxy, contactListId string) (*platformclientv2.Importstatus, *platformclientv2.APIResponse, error) {
+				if !uploaded {
+					return previousStatus, &platformclientv2.APIResponse{StatusCode: http.StatusOK}, nil
+				}
+				status := tc.statuses[statusCalls]
+				statusCalls++
+				return status, &platformclientv2.APIResponse{StatusCode: http.StatusOK}, nil
+			}
+			internalProxy = uploadProxy
+			defer func() { internalProxy = nil }()
+
+			gcloud := &provider.ProviderMeta{ClientConfig: &platformclientv2.Configuration{}}
+			resourceDataMap := map[string]interface{}{
+				"contact_list_id":   tId,
+				"filepath":          filePath,
+				"file_content_hash": "hash",
+				"contact_id_name":   "Id",
+			}
+			d := schema.TestResourceDataRaw(t, ResourceOutboundContactListContactsUpload().Schema, resourceDataMap)
+
+			diags := createOutboundContactListContactsUpload(context.Background(), d, gcloud)
+			assert.False(t, diags.HasError(), diags)
+			assert.Len(t, diags, tc.expectedWarnings)
+			assert.Equal(t, len(tc.statuses), statusCalls, "the import status should be polled until the import of the upload is reported")
+			assert.Equal(t, tc.expectedRecords, d.Get("completed_records").(int))
+		})
+	}
+}
@@ -0,0 +1,327 @@
+package outbound_contact_list_contacts_upload
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/mypurecloud/platform-client-sdk-go/v149/platformclientv2"
+)
+
+/*
+The resource_genesyscloud_outbound_contact_list_contacts_upload_utils.go file contains the validation of the CSV files
+uploaded to a contact list and the helper methods used to test the resource.
+*/
+
+const (
+	// maxReportedRowErrors is the number of row errors kept in the rejected_rows attribute
+	maxReportedRowErrors = 100
+	// maxSummarizedRowErrors is the number of row errors listed in diagnostics
+	maxSummarizedRowErrors = 10
+
+	minPhoneNumberDigits = 3
+	maxPhoneNumberDigits = 15
+)
+
+// timestampLayouts are the formats accepted for columns with a TIMESTAMP data type specification
+var timestampLayouts = []string{
+	time.RFC3339,
+	"2006-01-02T15:04:05",
+	"2006-01-02T15:04",
+	"2006-01-02 15:04:05",
+	"2006-01-02 15:04",
+	"2006-01-02",
+}
+
+// contactRowError describes why a row of a contacts file can not be imported
+type contactRowError struct {
+	row     int
+	column  string
+	message string
+}
+
+func (e contactRowError) String() string {
+	if e.column == "" {
+		return fmt.Sprintf("row %d: %s", e.row, e.message)
+	}
+	return fmt.Sprintf("row %d, column '%s': %s", e.row, e.column, e.message)
+}
+
+// contactsFileSummary is the result of validating a contacts file
+type contactsFileSummary struct {
+	validRows    int
+	rejectedRows int
+	rowErrors    []contactRowError
+}
+
+// reportedRowErrors returns the row errors kept in the rejected_rows attribute
+func (s contactsFileSummary) reportedRowErrors() []string {
+	reported := make([]string, 0)
+	for _, rowError := range s.rowErrors {
+		if len(reported) == maxReportedRowErrors {
+			break
+		}
+		reported = append(reported, rowError.String())
+	}
+	return reported
+}
+
+// rowErrorSummary lists the first row errors and how many more were found
+func (s contactsFileSummary) rowErrorSummary() string {
+	lines := make([]string, 0)
+	for i, rowError := range s.rowErrors {
+		if i == maxSummarizedRowErrors {
+			lines = append(lines, fmt.Sprintf("... and %d more", len(s.rowErrors)-maxSummarizedRowErrors))
+			break
+		}
+		lines = append(lines, rowError.String())
+	}
+	return strings.Join(lines, "\n")
+}
+
+// contactsFileValidator validates the rows of a contacts file against the columns of a contact list
+type contactsFileValidator struct {
+	columnNames   []string
+	columns       map[string]bool
+	phoneColumns  map[string]bool
+	specs         map[string]platformclientv2.Columndatatypespecification
+	contactIdName string
+	contactIds    map[string]int
+}
+
+func newContactsFileValidator(contactList *platformclientv2.Contactlist, contactIdName string) *contactsFileValidator {
+	v := &contactsFileValidator{
+		columns:       make(map[string]bool),
+		phoneColumns:  make(map[string]bool),
+		specs:         make(map[string]platformclientv2.Columndatatypespecification),
+		contactIdName: contactIdName,
+		contactIds:    make(map[string]int),
+	}
+	if contactList.ColumnNames != nil {
+		v.columnNames = *contactList.ColumnNames
+		for _, column := range v.columnNames {
+			v.columns[column] = true
+		}
+	}
+	if contactList.PhoneColumns != nil {
+		for _, phoneColumn := range *contactList.PhoneColumns {
+			if phoneColumn.ColumnName != nil {
+				v.phoneColumns[*phoneColumn.ColumnName] = true
+			}
+		}
+	}
+	if contactList.ColumnDataTypeSpecifications != nil {
+		for _, spec := range *contactList.ColumnDataTypeSpecifications {
+			if spec.ColumnName != nil {
+				v.specs[*spec.ColumnName] = spec
+			}
+		}
+	}
+	return v
+}
+
+// validateHeader checks that the (mapped) header of a contacts file contains every column of the contact list exactly once
+func (v *contactsFileValidator) validateHeader(header []string) error {
+	var errs []error
+	seen := make(map[string]bool)
+	for _, column := range header {
+		if seen[column] {
+			errs = append(errs, fmt.Errorf("column '%s' appears more than once", column))
+		}
+		seen[column] = true
+		if !v.columns[column] {
+			errs = append(errs, fmt.Errorf("column '%s' is not a column of the contact list", column))
+		}
+	}
+	for _, column := range v.columnNames {
+		if !seen[column] {
+			errs = append(errs, fmt.Errorf("contact list column '%s' is missing", column))
+		}
+	}
+	if v.contactIdName != "" && !seen[v.contactIdName] {
+		errs = append(errs, fmt.Errorf("contact id column '%s' is missing", v.contactIdName))
+	}
+	return errors.Join(errs...)
+}
+
+// validateRow returns the errors of a single row of a contacts file
+func (v *contactsFileValidator) validateRow(row int, header, record []string) []contactRowError {
+	if len(record) != len(header) {
+		return []contactRowError{{row: row, message: fmt.Sprintf("expected %d values, found %d", len(header), len(record))}}
+	}
+
+	var rowErrors []contactRowError
+	for i, column := range header {
+		value := strings.TrimSpace(record[i])
+		if column == v.contactIdName {
+			if value == "" {
+				rowErrors = append(rowErrors, contactRowError{row: row, column: column, message: "contact id is empty"})
+			} else if firstRow, ok := v.contactIds[value]; ok {
+				rowErrors = append(rowErrors, contactRowError{row: row, column: column, message: fmt.Sprintf("contact id '%s' is a duplicate of row %d", value, firstRow)})
+			} else {
+				v.contactIds[value] = row
+			}
+		}
+		if value == "" {
+			continue
+		}
+		if v.phoneColumns[column] && !isValidPhoneNumber(value) {
+			rowErrors = append(rowErrors, contactRowError{row: row, column: column, message: fmt.Sprintf("'%s' is not a valid phone number", value)})
+		}
+		if spec, ok := v.specs[column]; ok {
+			if err := validateColumnDataType(spec, value); err != nil {
+				rowErrors = append(rowErrors, contactRowError{row: row, column: column, message: err.Error()})
+			}
+		}
+	}
+	return rowErrors
+}
+
+// isValidPhoneNumber checks a phone number contains between 3 and 15 digits once formatting characters are removed
+func isValidPhoneNumber(value string) bool {
+	digits := 0
+	for i, r := range value {
+		switch {
+		case r >= '0' && r <= '9':
+			digits++
+		case r == '+' && i == 0:
+		case r == ' ', r == '-', r == '.', r == '(', r == ')':
+		default:
+			return false
+		}
+	}
+	return digits >= minPhoneNumberDigits && digits <= maxPhoneNumberDigits
+}
+
+// validateColumnDataType checks a value against the column data type specification of its column
+func validateColumnDataType(spec platformclientv2.Columndatatypespecification, value string) error {
+	if spec.ColumnDataType == nil {
+		return nil
+	}
+	switch *spec.ColumnDataType {
+	case "NUMERIC":
+		number, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return fmt.Errorf("'%s' is not a number", value)
+		}
+		if spec.Min != nil && number < float64(*spec.Min) {
+			return fmt.Errorf("%s is less than the minimum of %d", value, *spec.Min)
+		}
+		if spec.Max != nil && number > float64(*spec.Max) {
+			return fmt.Errorf("%s is greater than the maximum of %d", value, *spec.Max)
+		}
+	case "TEXT":
+		if spec.MaxLength != nil && utf8.RuneCountInString(value) > *spec.MaxLength {
+			return fmt.Errorf("value is longer than the maximum length of %d", *spec.MaxLength)
+		}
+	case "TIMESTAMP":
+		for _, layout := range timestampLayouts {
+			if _, err := time.Parse(layout, value); err == nil {
+				return nil
+			}
+		}
+		return fmt.Errorf("'%s' is not a valid timestamp", value)
+	}
+	return nil
+}
+
+// mapHeader renames the columns of a contacts file header using the column mapping
+func mapHeader(header []string, columnMapping map[string]string) []string {
+	mapped := make([]string, len(header))
+	for i, column := range header {
+		column = strings.TrimSpace(column)
+		if i == 0 {
+			column = strings.TrimPrefix(column, "\ufeff")
+		}
+		if mappedColumn, ok := columnMapping[column]; ok {
+			column = mappedColumn
+		}
+		mapped[i] = column
+	}
+	return mapped
+}
+
+// prepareContactsFile validates the rows of a contacts file and writes the rows to import, with the mapped header, to dst.
+// Invalid rows are only written when skipInvalidRows is false, in which case the file should not be uploaded if any error is found.
+func prepareContactsFile(src io.Reader, dst io.Writer, contactList *platformclientv2.Contactlist, columnMapping map[string]string, contactIdName string, skipInvalidRows bool) (*contactsFileSummary, error) {
+	reader := csv.NewReader(src)
+	reader.FieldsPerRecord = -1
+
+	header, err := reader.Read()
+	if err == io.EOF {
+		return nil, fmt.Errorf("the contacts file is empty")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the header of the contacts file: %v", err)
+	}
+	header = mapHeader(header, columnMapping)
+
+	validator := newContactsFileValidator(contactList, contactIdName)
+	if err := validator.validateHeader(header); err != nil {
+		return nil, fmt.Errorf("the header of the contacts file does not match contact list '%s': %v", *contactList.Id, err)
+	}
+
+	writer := csv.NewWriter(dst)
+	if err := writer.Write(header); err != nil {
+		return nil, err
+	}
+
+	summary := &contactsFileSummary{}
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read the contacts file: %v", err)
+		}
+		row, _ := reader.FieldPos(0)
+
+		rowErrors := validator.validateRow(row, header, record)
+		if len(rowErrors) > 0 {
+			summary.rejectedRows++
+			summary.rowErrors = append(summary.rowErrors, rowErrors...)
+			if skipInvalidRows {
+				continue
+			}
+		} else {
+			summary.validRows++
+		}
+		if err := writer.Write(record); err != nil {
+			return nil, err
+		}
+	}
+
+	writer.Flush()
+	return summary, writer.Error()
+}
+
+// GenerateOutboundContactListContactsUpload returns the HCL of a genesyscloud_outbound_contact_list_contacts_upload resource
+func GenerateOutboundContactListContactsUpload(
+	resourceLabel,
+	contactListId,
+	filePath,
+	contactIdName,
+	skipInvalidRows string,
+	columnMapping map[string]string) string {
+	mapping := ""
+	for csvColumn, column := range columnMapping {
+		mapping += fmt.Sprintf("\t\t%s = %s\n", strconv.Quote(csvColumn), strconv.Quote(column))
+	}
+	return fmt.Sprintf(`
+resource "%s" "%s" {
+	contact_list_id   = %s
+	filepath          = "%s"
+	file_content_hash = filesha256("%s")
+	contact_id_name   = %s
+	skip_invalid_rows = %s
+	column_mapping = {
+%s	}
+}
+`, ResourceType, resourceLabel, contactListId, filePath, filePath, contactIdName, skipInvalidRows, mapping)
+}
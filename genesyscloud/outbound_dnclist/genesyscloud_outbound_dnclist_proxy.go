@@ -3,7 +3,9 @@ package outbound_dnclist
 import (
 	"context"
 	"fmt"
+	"io"
 	"log"
+	"net/http"
 	"terraform-provider-genesyscloud/genesyscloud/util"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
@@ -20,6 +22,10 @@ type getOutboundDnclistByNameFunc func(ctx context.Context, p *outboundDnclistPr
 type updateOutboundDnclistFunc func(ctx context.Context, p *outboundDnclistProxy, dnclistId string, dnclist *platformclientv2.Dnclist) (*platformclientv2.Dnclist, *platformclientv2.APIResponse, error)
 type deleteOutboundDnclistFunc func(ctx context.Context, p *outboundDnclistProxy, dnclistId string) (*platformclientv2.APIResponse, error)
 type uploadPhoneEntriesToDncListFunc func(p *outboundDnclistProxy, dncList *platformclientv2.Dnclist, entry interface{}) (*platformclientv2.APIResponse, diag.Diagnostics)
+type patchDnclistPhoneNumbersFunc func(ctx context.Context, p *outboundDnclistProxy, dnclistId string, body *platformclientv2.Dncpatchphonenumbersrequest) (*platformclientv2.APIResponse, error)
+type requestDnclistExportFunc func(ctx context.Context, p *outboundDnclistProxy, dnclistId string) (*platformclientv2.APIResponse, error)
+type getDnclistExportFunc func(ctx context.Context, p *outboundDnclistProxy, dnclistId string) (*platformclientv2.Exporturi, *platformclientv2.APIResponse, error)
+type downloadDnclistExportFunc func(ctx context.Context, p *outboundDnclistProxy, uri string) ([]byte, error)
 
 // outboundDnclistProxy contains all the methods that call genesys cloud APIs
 type outboundDnclistProxy struct {
@@ -32,6 +38,10 @@ type outboundDnclistProxy struct {
 	updateOutboundDnclistAttr       updateOutboundDnclistFunc
 	deleteOutboundDnclistAttr       deleteOutboundDnclistFunc
 	uploadPhoneEntriesToDncListAttr uploadPhoneEntriesToDncListFunc
+	patchDnclistPhoneNumbersAttr    patchDnclistPhoneNumbersFunc
+	requestDnclistExportAttr        requestDnclistExportFunc
+	getDnclistExportAttr            getDnclistExportFunc
+	downloadDnclistExportAttr       downloadDnclistExportFunc
 }
 
 // newOutboundDnclistProxy initializes the dnclist proxy with the data needed for communication with the genesys cloud
@@ -47,6 +57,10 @@ func newOutboundDnclistProxy(clientConfig *platformclientv2.Configuration) *outb
 		updateOutboundDnclistAttr:       updateOutboundDnclistFn,
 		deleteOutboundDnclistAttr:       deleteOutboundDnclistFn,
 		uploadPhoneEntriesToDncListAttr: uploadPhoneEntriesToDncListFn,
+		patchDnclistPhoneNumbersAttr:    patchDnclistPhoneNumbersFn,
+		requestDnclistExportAttr:        requestDnclistExportFn,
+		getDnclistExportAttr:            getDnclistExportFn,
+		downloadDnclistExportAttr:       downloadDnclistExportFn,
 	}
 }
 
@@ -91,6 +105,26 @@ func (p *outboundDnclistProxy) uploadPhoneEntriesToDncList(dncList *platformclie
 	return p.uploadPhoneEntriesToDncListAttr(p, dncList, entry)
 }
 
+// patchDnclistPhoneNumbers adds phone numbers to or removes phone numbers from a Genesys Cloud Outbound Dnclist
+func (p *outboundDnclistProxy) patchDnclistPhoneNumbers(ctx context.Context, dnclistId string, body *platformclientv2.Dncpatchphonenumbersrequest) (*platformclientv2.APIResponse, error) {
+	return p.patchDnclistPhoneNumbersAttr(ctx, p, dnclistId, body)
+}
+
+// requestDnclistExport starts an export of the entries of a Genesys Cloud Outbound Dnclist
+func (p *outboundDnclistProxy) requestDnclistExport(ctx context.Context, dnclistId string) (*platformclientv2.APIResponse, error) {
+	return p.requestDnclistExportAttr(ctx, p, dnclistId)
+}
+
+// getDnclistExport returns the URI of the last export of a Genesys Cloud Outbound Dnclist
+func (p *outboundDnclistProxy) getDnclistExport(ctx context.Context, dnclistId string) (*platformclientv2.Exporturi, *platformclientv2.APIResponse, error) {
+	return p.getDnclistExportAttr(ctx, p, dnclistId)
+}
+
+// downloadDnclistExport downloads the CSV file of a Genesys Cloud Outbound Dnclist export
+func (p *outboundDnclistProxy) downloadDnclistExport(ctx context.Context, uri string) ([]byte, error) {
+	return p.downloadDnclistExportAttr(ctx, p, uri)
+}
+
 func createOutboundDnclistFn(ctx context.Context, p *outboundDnclistProxy, dnclist *platformclientv2.Dnclistcreate) (*platformclientv2.Dnclist, *platformclientv2.APIResponse, error) {
 	return p.outboundApi.PostOutboundDnclists(*dnclist)
 }
@@ -172,3 +206,35 @@ func getOutboundDnclistByNameFn(ctx context.Context, p *outboundDnclistProxy, na
 	}
 	return "", true, resp, fmt.Errorf("unable to find dnc list with name %s", name)
 }
+
+func patchDnclistPhoneNumbersFn(ctx context.Context, p *outboundDnclistProxy, dnclistId string, body *platformclientv2.Dncpatchphonenumbersrequest) (*platformclientv2.APIResponse, error) {
+	return p.outboundApi.PatchOutboundDnclistPhonenumbers(dnclistId, *body)
+}
+
+func requestDnclistExportFn(ctx context.Context, p *outboundDnclistProxy, dnclistId string) (*platformclientv2.APIResponse, error) {
+	_, resp, err := p.outboundApi.PostOutboundDnclistExport(dnclistId)
+	return resp, err
+}
+
+func getDnclistExportFn(ctx context.Context, p *outboundDnclistProxy, dnclistId string) (*platformclientv2.Exporturi, *platformclientv2.APIResponse, error) {
+	return p.outboundApi.GetOutboundDnclistExport(dnclistId, "false")
+}
+
+func downloadDnclistExportFn(ctx context.Context, p *outboundDnclistProxy, uri string) ([]byte, error) {
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, uri, nil)
+	if err != nil {
+		return nil, err
+	}
+	if p.clientConfig.AccessToken != "" {
+		request.Header.Set("Authorization", "Bearer "+p.clientConfig.AccessToken)
+	}
+	response, err := http.DefaultClient.Do(request)
+	if err != nil {
+		return nil, fmt.Errorf("failed to download DNC list export: %v", err)
+	}
+	defer response.Body.Close()
+	if response.StatusCode < http.StatusOK || response.StatusCode >= http.StatusMultipleChoices {
+		return nil, fmt.Errorf("failed to download DNC list export: HTTP status %d", response.StatusCode)
+	}
+	return io.ReadAll(response.Body)
+}
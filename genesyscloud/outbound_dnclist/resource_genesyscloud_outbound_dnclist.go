@@ -97,6 +97,11 @@ func createOutboundDncList(ctx context.Context, d *schema.ResourceData, meta int
 			return util.BuildDiagnosticError(ResourceType, "Phone numbers can only be uploaded to internal DNC lists.", fmt.Errorf("phone numbers can only be uploaded to internal DNC Lists"))
 		}
 	}
+	if d.Get("entries_filepath").(string) != "" {
+		if diagErr := syncDncListEntriesFile(ctx, d, proxy, true, d.Timeout(schema.TimeoutCreate)); diagErr != nil {
+			return diagErr
+		}
+	}
 	log.Printf("Created Outbound DNC list %s %s", name, *outboundDncList.Id)
 	return readOutboundDncList(ctx, d, meta)
 }
@@ -167,6 +172,12 @@ func updateOutboundDncList(ctx context.Context, d *schema.ResourceData, meta int
 		return diagErr
 	}
 
+	if d.Get("entries_filepath").(string) != "" && d.HasChanges("entries_filepath", "entries_file_content_hash") {
+		if diagErr := syncDncListEntriesFile(ctx, d, proxy, false, d.Timeout(schema.TimeoutUpdate)); diagErr != nil {
+			return diagErr
+		}
+	}
+
 	log.Printf("Updated Outbound DNC list %s", name)
 	return readOutboundDncList(ctx, d, meta)
 }
@@ -0,0 +1,388 @@
+package outbound_dnclist
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/csv"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"sort"
+	"strings"
+	"terraform-provider-genesyscloud/genesyscloud/util"
+	"terraform-provider-genesyscloud/genesyscloud/util/chunks"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/retry"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/mypurecloud/platform-client-sdk-go/v149/platformclientv2"
+)
+
+/*
+The resource_genesyscloud_outbound_dnclist_entries_file.go file syncs the phone numbers of an rds DNC list with the CSV file
+set in entries_filepath. Only a hash of the normalized entries of the file is kept in the state. When the hash changes, the
+list is exported, the additions and removals are computed against the exported entries and they are applied in chunks.
+*/
+
+const (
+	dncPhoneNumberColumn    = "phone_number"
+	dncExpirationDateColumn = "expiration_date"
+	dncExpirationDateFormat = "2006-01-02T15:04Z"
+
+	// dncEntriesChunkSize is the number of phone numbers added or removed per request
+	dncEntriesChunkSize = 1000
+	// maxDncEntryErrors is the number of invalid rows reported before the rest of the file is ignored
+	maxDncEntryErrors = 20
+)
+
+// dncExportPollInterval is the time waited between checks of the status of a DNC list export
+var dncExportPollInterval = 5 * time.Second
+
+// newDncE164Service returns the service formatting the phone numbers of entries files and exports, which can hold many rows
+func newDncE164Service() *util.UtilE164Service {
+	e164 := util.NewUtilE164Service()
+	e164.Quiet = true
+	return e164
+}
+
+// dncEntries maps normalized phone numbers to their expiration date, which is empty for numbers that do not expire
+type dncEntries map[string]string
+
+// readDncEntriesFile reads the entries of a CSV file with a phone_number column and an optional expiration_date column
+func readDncEntriesFile(path string, e164 *util.UtilE164Service) (dncEntries, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open DNC entries file %s: %w", path, err)
+	}
+	defer file.Close()
+
+	entries, err := parseDncEntries(file, e164)
+	if err != nil {
+		return nil, fmt.Errorf("invalid DNC entries file %s: %w", path, err)
+	}
+	return entries, nil
+}
+
+func parseDncEntries(reader io.Reader, e164 *util.UtilE164Service) (dncEntries, error) {
+	records := csv.NewReader(reader)
+	records.FieldsPerRecord = -1
+	records.TrimLeadingSpace = true
+
+	header, err := records.Read()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read header row: %w", err)
+	}
+	phoneColumn, expirationColumn := -1, -1
+	for i, name := range header {
+		switch strings.ToLower(strings.TrimSpace(strings.TrimPrefix(name, "\ufeff"))) {
+		case dncPhoneNumberColumn:
+			phoneColumn = i
+		case dncExpirationDateColumn:
+			expirationColumn = i
+		}
+	}
+	if phoneColumn < 0 {
+		return nil, fmt.Errorf("header row has no %s column", dncPhoneNumberColumn)
+	}
+
+	entries := make(dncEntries)
+	var errs []error
+	for {
+		record, err := records.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		line, _ := records.FieldPos(0)
+		if len(errs) == maxDncEntryErrors {
+			errs = append(errs, fmt.Errorf("more invalid rows were not reported"))
+			break
+		}
+
+		number := ""
+		if phoneColumn < len(record) {
+			number = strings.TrimSpace(record[phoneColumn])
+		}
+		if number == "" {
+			if strings.TrimSpace(strings.Join(record, "")) != "" {
+				errs = append(errs, fmt.Errorf("line %d: missing phone number", line))
+			}
+			continue
+		}
+		formatted, diagErr := e164.FormatAsValidE164Number(number)
+		if diagErr != nil {
+			errs = append(errs, fmt.Errorf("line %d: invalid phone number %s", line, number))
+			continue
+		}
+
+		expiration := ""
+		if expirationColumn >= 0 && expirationColumn < len(record) {
+			expiration = strings.TrimSpace(record[expirationColumn])
+		}
+		if expiration != "" {
+			if _, err := time.Parse(dncExpirationDateFormat, expiration); err != nil {
+				errs = append(errs, fmt.Errorf("line %d: expiration date %s is not in yyyy-MM-ddTHH:mmZ format", line, expiration))
+				continue
+			}
+		}
+
+		if previous, ok := entries[formatted]; ok && previous != expiration {
+			errs = append(errs, fmt.Errorf("line %d: phone number %s is listed again with a different expiration date", line, formatted))
+			continue
+		}
+		entries[formatted] = expiration
+	}
+	return entries, errors.Join(errs...)
+}
+
+// parseDncExport parses the CSV file of a DNC list export. The phone number column is the first column whose name contains
+// "phone", and the expiration column the first one whose name contains "expir".
+func parseDncExport(content []byte, e164 *util.UtilE164Service) (dncEntries, error) {
+	records := csv.NewReader(bytes.NewReader(content))
+	records.FieldsPerRecord = -1
+
+	entries := make(dncEntries)
+	header, err := records.Read()
+	if err == io.EOF {
+		return entries, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read header row of DNC list export: %w", err)
+	}
+	phoneColumn, expirationColumn := -1, -1
+	for i, name := range header {
+		name = strings.ToLower(strings.TrimPrefix(name, "\ufeff"))
+		if phoneColumn < 0 && strings.Contains(name, "phone") {
+			phoneColumn = i
+		}
+		if expirationColumn < 0 && strings.Contains(name, "expir") {
+			expirationColumn = i
+		}
+	}
+	if phoneColumn < 0 {
+		return nil, fmt.Errorf("DNC list export has no phone number column: %s", strings.Join(header, ","))
+	}
+
+	for {
+		record, err := records.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read DNC list export: %w", err)
+		}
+		if phoneColumn >= len(record) || strings.TrimSpace(record[phoneColumn]) == "" {
+			continue
+		}
+		expiration := ""
+		if expirationColumn >= 0 && expirationColumn < len(record) {
+			expiration = normalizeDncExpirationDate(strings.TrimSpace(record[expirationColumn]))
+		}
+		entries[e164.FormatAsCalculatedE164Number(strings.TrimSpace(record[phoneColumn]))] = expiration
+	}
+	return entries, nil
+}
+
+// normalizeDncExpirationDate formats the expiration dates of exports like the ones of entries files
+func normalizeDncExpirationDate(date string) string {
+	for _, layout := range []string{dncExpirationDateFormat, time.RFC3339Nano, "2006-01-02T15:04:05.000Z0700", "2006-01-02 15:04:05"} {
+		if parsed, err := time.Parse(layout, date); err == nil {
+			return parsed.UTC().Format(dncExpirationDateFormat)
+		}
+	}
+	return date
+}
+
+// hash returns the hash of the sorted entries
+func (entries dncEntries) hash() string {
+	numbers := make([]string, 0, len(entries))
+	for number := range entries {
+		numbers = append(numbers, number)
+	}
+	sort.Strings(numbers)
+
+	hash := sha256.New()
+	for _, number := range numbers {
+		_, _ = fmt.Fprintf(hash, "%s,%s\n", number, entries[number])
+	}
+	return hex.EncodeToString(hash.Sum(nil))
+}
+
+// diffDncEntries returns the phone numbers to add, grouped by expiration date, and the phone numbers to remove. Phone numbers
+// with a changed expiration date are added again.
+func diffDncEntries(current, desired dncEntries) (map[string][]string, []string) {
+	additions := make(map[string][]string)
+	for number, expiration := range desired {
+		if currentExpiration, ok := current[number]; !ok || currentExpiration != expiration {
+			additions[expiration] = append(additions[expiration], number)
+		}
+	}
+	for _, numbers := range additions {
+		sort.Strings(numbers)
+	}
+
+	var removals []string
+	for number := range current {
+		if _, ok := desired[number]; !ok {
+			removals = append(removals, number)
+		}
+	}
+	sort.Strings(removals)
+	return additions, removals
+}
+
+// customizeDncListEntriesFileDiff plans entries_file_content_hash from the content of entries_filepath, so that changes to the
+// file are planned as updates. The file may not exist until other resources are applied, so errors reading it are reported when
+// the resource is applied.
+func customizeDncListEntriesFileDiff(_ context.Context, d *schema.ResourceDiff, _ interface{}) error {
+	path := d.Get("entries_filepath").(string)
+	if path == "" {
+		if d.Get("entries_file_content_hash").(string) != "" {
+			return d.SetNew("entries_file_content_hash", "")
+		}
+		return nil
+	}
+	if sourceType := d.Get("dnc_source_type").(string); sourceType != "rds" {
+		return fmt.Errorf("entries_filepath can only be used with rds DNC lists, not %s", sourceType)
+	}
+
+	entries, err := readDncEntriesFile(path, newDncE164Service())
+	if err != nil {
+		log.Printf("Failed to read DNC entries file %s during plan: %v", path, err)
+		return d.SetNewComputed("entries_file_content_hash")
+	}
+	if hash := entries.hash(); hash != d.Get("entries_file_content_hash").(string) {
+		return d.SetNew("entries_file_content_hash", hash)
+	}
+	return nil
+}
+
+// syncDncListEntriesFile syncs the phone numbers of the DNC list with the entries file. New lists are not exported as they are
+// known to be empty.
+func syncDncListEntriesFile(ctx context.Context, d *schema.ResourceData, proxy *outboundDnclistProxy, isNew bool, timeout time.Duration) diag.Diagnostics {
+	path := d.Get("entries_filepath").(string)
+	e164 := newDncE164Service()
+
+	desired, err := readDncEntriesFile(path, e164)
+	if err != nil {
+		return util.BuildDiagnosticError(ResourceType, fmt.Sprintf("Failed to read entries of Outbound DNC list %s", d.Id()), err)
+	}
+
+	current := make(dncEntries)
+	if !isNew {
+		var diagErr diag.Diagnostics
+		current, diagErr = exportDncListEntries(ctx, proxy, d.Id(), e164, timeout)
+		if diagErr != nil {
+			_ = d.Set("entries_file_content_hash", "")
+			return diagErr
+		}
+	}
+
+	additions, removals := diffDncEntries(current, desired)
+	if diagErr := applyDncEntryChanges(ctx, proxy, d.Id(), additions, removals); diagErr != nil {
+		// Clear the hash so that the next apply syncs the list again
+		_ = d.Set("entries_file_content_hash", "")
+		return diagErr
+	}
+
+	_ = d.Set("entries_file_content_hash", desired.hash())
+	log.Printf("Synced Outbound DNC list %s with %s: %d phone numbers", d.Id(), path, len(desired))
+	return nil
+}
+
+// exportDncListEntries exports the DNC list and returns its entries
+func exportDncListEntries(ctx context.Context, proxy *outboundDnclistProxy, dncListId string, e164 *util.UtilE164Service, timeout time.Duration) (dncEntries, diag.Diagnostics) {
+	// An export is complete when its timestamp is later than the one of the previous export
+	var previousTimestamp time.Time
+	previous, resp, err := proxy.getDnclistExport(ctx, dncListId)
+	if err != nil && !util.IsStatus404(resp) {
+		return nil, util.BuildAPIDiagnosticError(ResourceType, fmt.Sprintf("Failed to get export of Outbound DNC list %s error: %s", dncListId, err), resp)
+	}
+	if err == nil && previous.ExportTimestamp != nil {
+		previousTimestamp = *previous.ExportTimestamp
+	}
+
+	log.Printf("Exporting Outbound DNC list %s", dncListId)
+	if resp, err := proxy.requestDnclistExport(ctx, dncListId); err != nil {
+		return nil, util.BuildAPIDiagnosticError(ResourceType, fmt.Sprintf("Failed to export Outbound DNC list %s error: %s", dncListId, err), resp)
+	}
+
+	var uri string
+	diagErr := util.WithRetries(ctx, timeout, func() *retry.RetryError {
+		export, resp, err := proxy.getDnclistExport(ctx, dncListId)
+		if err != nil && !util.IsStatus404(resp) {
+			return retry.NonRetryableError(util.BuildWithRetriesApiDiagnosticError(ResourceType, fmt.Sprintf("Failed to get export of Outbound DNC list %s error: %s", dncListId, err), resp))
+		}
+		if err == nil && export.Uri != nil && export.ExportTimestamp != nil && export.ExportTimestamp.After(previousTimestamp) {
+			uri = *export.Uri
+			return nil
+		}
+		time.Sleep(dncExportPollInterval)
+		return retry.RetryableError(fmt.Errorf("export of Outbound DNC list %s did not complete within %v", dncListId, timeout))
+	})
+	if diagErr != nil {
+		return nil, diagErr
+	}
+
+	content, err := proxy.downloadDnclistExport(ctx, uri)
+	if err != nil {
+		return nil, util.BuildDiagnosticError(ResourceType, fmt.Sprintf("Failed to download export of Outbound DNC list %s", dncListId), err)
+	}
+	entries, err := parseDncExport(content, e164)
+	if err != nil {
+		return nil, util.BuildDiagnosticError(ResourceType, fmt.Sprintf("Failed to parse export of Outbound DNC list %s", dncListId), err)
+	}
+	return entries, nil
+}
+
+// applyDncEntryChanges removes and then adds the phone numbers in chunks
+func applyDncEntryChanges(ctx context.Context, proxy *outboundDnclistProxy, dncListId string, additions map[string][]string, removals []string) diag.Diagnostics {
+	patch := func(action string, numbers []string, expiration string) diag.Diagnostics {
+		body := &platformclientv2.Dncpatchphonenumbersrequest{
+			Action:       platformclientv2.String(action),
+			PhoneNumbers: &numbers,
+		}
+		if expiration != "" {
+			body.ExpirationDateTime = platformclientv2.String(expiration)
+		}
+		if resp, err := proxy.patchDnclistPhoneNumbers(ctx, dncListId, body); err != nil {
+			return util.BuildAPIDiagnosticError(ResourceType, fmt.Sprintf("Failed to %s %d phone numbers of Outbound DNC list %s error: %s", strings.ToLower(action), len(numbers), dncListId, err), resp)
+		}
+		return nil
+	}
+
+	if len(removals) > 0 {
+		for _, chunk := range chunks.ChunkBy(removals, dncEntriesChunkSize) {
+			if diagErr := patch("Remove", chunk, ""); diagErr != nil {
+				return diagErr
+			}
+		}
+	}
+
+	expirations := make([]string, 0, len(additions))
+	for expiration := range additions {
+		expirations = append(expirations, expiration)
+	}
+	sort.Strings(expirations)
+	for _, expiration := range expirations {
+		for _, chunk := range chunks.ChunkBy(additions[expiration], dncEntriesChunkSize) {
+			if diagErr := patch("Add", chunk, expiration); diagErr != nil {
+				return diagErr
+			}
+		}
+	}
+
+	added := 0
+	for _, numbers := range additions {
+		added += len(numbers)
+	}
+	log.Printf("Added %d and removed %d phone numbers of Outbound DNC list %s", added, len(removals), dncListId)
+	return nil
+}
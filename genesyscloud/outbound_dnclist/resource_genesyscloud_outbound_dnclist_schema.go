@@ -5,6 +5,7 @@ import (
 	resourceExporter "terraform-provider-genesyscloud/genesyscloud/resource_exporter"
 	registrar "terraform-provider-genesyscloud/genesyscloud/resource_register"
 	"terraform-provider-genesyscloud/genesyscloud/validators"
+	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
@@ -27,9 +28,14 @@ func ResourceOutboundDncList() *schema.Resource {
 		ReadContext:   provider.ReadWithPooledClient(readOutboundDncList),
 		UpdateContext: provider.UpdateWithPooledClient(updateOutboundDncList),
 		DeleteContext: provider.DeleteWithPooledClient(deleteOutboundDncList),
+		CustomizeDiff: customizeDncListEntriesFileDiff,
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(20 * time.Minute),
+			Update: schema.DefaultTimeout(20 * time.Minute),
+		},
 		SchemaVersion: 1,
 		Schema: map[string]*schema.Schema{
 			`name`: {
@@ -89,9 +95,10 @@ func ResourceOutboundDncList() *schema.Resource {
 				ForceNew: true,
 			},
 			`entries`: {
-				Description: `Rows to add to the DNC list. To emulate removing phone numbers, you can set expiration_date to a date in the past.`,
-				Optional:    true,
-				Type:        schema.TypeList,
+				Description:   `Rows to add to the DNC list. To emulate removing phone numbers, you can set expiration_date to a date in the past.`,
+				Optional:      true,
+				Type:          schema.TypeList,
+				ConflictsWith: []string{`entries_filepath`},
 				Elem: &schema.Resource{
 					Schema: map[string]*schema.Schema{
 						`expiration_date`: {
@@ -112,6 +119,18 @@ func ResourceOutboundDncList() *schema.Resource {
 					},
 				},
 			},
+			`entries_filepath`: {
+				Description:   `Path to a CSV file of the phone numbers of the DNC list. Only possible if the dncSourceType is rds. The first row of the file must contain a phone_number column and can contain an expiration_date column in yyyy-MM-ddTHH:mmZ format. Phone numbers are normalized to E.164 using the default country code of the organization. When the file changes, the list is exported and synced to the file: phone numbers missing from the list or with a different expiration date are added, and phone numbers not in the file are removed.`,
+				Optional:      true,
+				Type:          schema.TypeString,
+				ConflictsWith: []string{`entries`},
+				ValidateFunc:  validators.ValidatePath,
+			},
+			`entries_file_content_hash`: {
+				Description: `Hash of the normalized entries of entries_filepath. Only this hash is kept in the state.`,
+				Computed:    true,
+				Type:        schema.TypeString,
+			},
 		},
 	}
 }
@@ -3,10 +3,12 @@ package outbound_dnclist
 import (
 	"fmt"
 	"os"
+	"path/filepath"
 	"strconv"
 	"strings"
 	"terraform-provider-genesyscloud/genesyscloud/provider"
 	"terraform-provider-genesyscloud/genesyscloud/util"
+	"terraform-provider-genesyscloud/genesyscloud/util/testrunner"
 	"testing"
 
 	"github.com/google/uuid"
@@ -354,6 +356,49 @@ func TestAccResourceOutboundDncListCustomExclusionColumn(t *testing.T) {
 	})
 }
 
+func TestAccResourceOutboundDncListEntriesFile(t *testing.T) {
+	t.Parallel()
+	var (
+		resourceLabel = "dnc_list"
+		name          = "Test DNC List " + uuid.NewString()
+		testDataPath  = testrunner.GetTestDataPath("resource", ResourceType)
+	)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { util.TestAccPreCheck(t) },
+		ProviderFactories: provider.GetProviderFactories(providerResources, providerDataSources),
+		Steps: []resource.TestStep{
+			{
+				Config: generateOutboundDncListWithEntriesFile(resourceLabel, name, filepath.Join(testDataPath, "entries_v1.csv")),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet("genesyscloud_outbound_dnclist."+resourceLabel, "entries_file_content_hash"),
+					checkPhoneNumbersAddedToDncList("genesyscloud_outbound_dnclist."+resourceLabel, 3),
+				),
+			},
+			{
+				// One number is kept, two are removed and one is added
+				Config: generateOutboundDncListWithEntriesFile(resourceLabel, name, filepath.Join(testDataPath, "entries_v2.csv")),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet("genesyscloud_outbound_dnclist."+resourceLabel, "entries_file_content_hash"),
+					checkPhoneNumbersAddedToDncList("genesyscloud_outbound_dnclist."+resourceLabel, 2),
+				),
+			},
+		},
+		CheckDestroy: testVerifyDncListDestroyed,
+	})
+}
+
+func generateOutboundDncListWithEntriesFile(resourceLabel, name, entriesFilepath string) string {
+	return fmt.Sprintf(`
+resource "genesyscloud_outbound_dnclist" "%s" {
+	name             = "%s"
+	dnc_source_type  = "rds"
+	contact_method   = "Phone"
+	entries_filepath = "%s"
+}
+`, resourceLabel, name, entriesFilepath)
+}
+
 func generateOutboundDncListEntriesBlock(phoneNumbers []string, expirationDate string) string {
 	return fmt.Sprintf(`
 	entries {
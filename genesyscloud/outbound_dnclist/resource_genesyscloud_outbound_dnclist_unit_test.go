@@ -0,0 +1,161 @@
+package outbound_dnclist
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"terraform-provider-genesyscloud/genesyscloud/util"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/mypurecloud/platform-client-sdk-go/v149/platformclientv2"
+	"github.com/stretchr/testify/assert"
+)
+
+var testE164 = &util.UtilE164Service{GetDefaultCountryCodeFunc: func() string { return "US" }}
+
+func TestUnitParseDncEntries(t *testing.T) {
+	entries, err := parseDncEntries(strings.NewReader(strings.Join([]string{
+		"Expiration_Date,Phone_Number,name",
+		",(317) 555-0100,a",
+		"2030-01-01T00:00Z,+44 20 7946 0958,b",
+		",3175550100,duplicate",
+		"",
+	}, "\n")), testE164)
+	assert.Nil(t, err)
+	assert.Equal(t, dncEntries{"+13175550100": "", "+442079460958": "2030-01-01T00:00Z"}, entries)
+
+	_, err = parseDncEntries(strings.NewReader(strings.Join([]string{
+		"phone_number,expiration_date",
+		"not a number,",
+		"3175550101,tomorrow",
+		"3175550102,",
+		"3175550102,2030-01-01T00:00Z",
+		",2030-01-01T00:00Z",
+	}, "\n")), testE164)
+	assert.EqualError(t, err, strings.Join([]string{
+		"line 2: invalid phone number not a number",
+		"line 3: expiration date tomorrow is not in yyyy-MM-ddTHH:mmZ format",
+		"line 5: phone number +13175550102 is listed again with a different expiration date",
+		"line 6: missing phone number",
+	}, "\n"))
+
+	_, err = parseDncEntries(strings.NewReader("number\n3175550100\n"), testE164)
+	assert.EqualError(t, err, "header row has no phone_number column")
+}
+
+func TestUnitDiffDncEntries(t *testing.T) {
+	current := dncEntries{"+13175550100": "", "+13175550101": "", "+13175550102": "2030-01-01T00:00Z"}
+	desired := dncEntries{"+13175550100": "", "+13175550102": "2031-01-01T00:00Z", "+13175550103": "", "+13175550104": "2031-01-01T00:00Z"}
+
+	additions, removals := diffDncEntries(current, desired)
+	assert.Equal(t, map[string][]string{
+		"":                  {"+13175550103"},
+		"2031-01-01T00:00Z": {"+13175550102", "+13175550104"},
+	}, additions)
+	assert.Equal(t, []string{"+13175550101"}, removals)
+
+	assert.NotEqual(t, current.hash(), desired.hash())
+	assert.Equal(t, dncEntries{"+13175550100": ""}.hash(), dncEntries{"+13175550100": ""}.hash())
+}
+
+func TestUnitSyncDncListEntriesFile(t *testing.T) {
+	dncExportPollInterval = 0
+	defer func() { dncExportPollInterval = 5 * time.Second }()
+
+	path := filepath.Join(t.TempDir(), "dnc.csv")
+	assert.Nil(t, os.WriteFile(path, []byte("phone_number,expiration_date\n3175550100,\n3175550103,2030-01-01T00:00Z\n"), 0644))
+
+	previousExport := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	exportChecks := 0
+	var patches []string
+
+	internalProxy = &outboundDnclistProxy{
+		getDnclistExportAttr: func(ctx context.Context, p *outboundDnclistProxy, dnclistId string) (*platformclientv2.Exporturi, *platformclientv2.APIResponse, error) {
+			exportChecks++
+			timestamp := previousExport
+			if exportChecks > 2 {
+				timestamp = previousExport.Add(time.Hour)
+			}
+			return &platformclientv2.Exporturi{Uri: platformclientv2.String("https://example.com/export.csv"), ExportTimestamp: &timestamp}, &platformclientv2.APIResponse{StatusCode: http.StatusOK}, nil
+		},
+		requestDnclistExportAttr: func(ctx context.Context, p *outboundDnclistProxy, dnclistId string) (*platformclientv2.APIResponse, error) {
+			return &platformclientv2.APIResponse{StatusCode: http.StatusOK}, nil
+		},
+		downloadDnclistExportAttr: func(ctx context.Context, p *outboundDnclistProxy, uri string) ([]byte, error) {
+			return []byte("\"dncPhoneNumber\",\"expirationDateTime\"\n\"+13175550100\",\"\"\n\"+13175550101\",\"\"\n\"+13175550103\",\"2029-01-01T00:00:00.000Z\"\n"), nil
+		},
+		patchDnclistPhoneNumbersAttr: func(ctx context.Context, p *outboundDnclistProxy, dnclistId string, body *platformclientv2.Dncpatchphonenumbersrequest) (*platformclientv2.APIResponse, error) {
+			expiration := ""
+			if body.ExpirationDateTime != nil {
+				expiration = *body.ExpirationDateTime
+			}
+			patches = append(patches, fmt.Sprintf("%s %s %s", *body.Action, strings.Join(*body.PhoneNumbers, ","), expiration))
+			return &platformclientv2.APIResponse{StatusCode: http.StatusOK}, nil
+		},
+	}
+	defer func() { internalProxy = nil }()
+
+	d := schema.TestResourceDataRaw(t, ResourceOutboundDncList().Schema, map[string]interface{}{
+		"name":             "DNC",
+		"dnc_source_type":  "rds",
+		"entries_filepath": path,
+	})
+	d.SetId("dnc-list-id")
+
+	diags := syncDncListEntriesFile(context.Background(), d, internalProxy, false, time.Minute)
+	assert.False(t, diags.HasError(), diags)
+	assert.Equal(t, 3, exportChecks)
+	assert.Equal(t, []string{
+		"Remove +13175550101 ",
+		"Add +13175550103 2030-01-01T00:00Z",
+	}, patches)
+	assert.Equal(t, dncEntries{"+13175550100": "", "+13175550103": "2030-01-01T00:00Z"}.hash(), d.Get("entries_file_content_hash"))
+
+	// New lists are not exported
+	patches = nil
+	exportChecks = 0
+	diags = syncDncListEntriesFile(context.Background(), d, internalProxy, true, time.Minute)
+	assert.False(t, diags.HasError(), diags)
+	assert.Equal(t, 0, exportChecks)
+	assert.Equal(t, []string{"Add +13175550100 ", "Add +13175550103 2030-01-01T00:00Z"}, patches)
+
+	// A failed sync clears the hash so that the next apply syncs the list again
+	internalProxy.patchDnclistPhoneNumbersAttr = func(ctx context.Context, p *outboundDnclistProxy, dnclistId string, body *platformclientv2.Dncpatchphonenumbersrequest) (*platformclientv2.APIResponse, error) {
+		return &platformclientv2.APIResponse{StatusCode: http.StatusBadRequest}, fmt.Errorf("bad request")
+	}
+	diags = syncDncListEntriesFile(context.Background(), d, internalProxy, true, time.Minute)
+	assert.True(t, diags.HasError())
+	assert.Equal(t, "", d.Get("entries_file_content_hash"))
+}
+
+func TestUnitCustomizeDncListEntriesFileDiff(t *testing.T) {
+	resourceSchema := ResourceOutboundDncList().Schema
+	path := filepath.Join(t.TempDir(), "dnc.csv")
+	config := map[string]interface{}{
+		"name":             "DNC",
+		"dnc_source_type":  "rds",
+		"entries_filepath": path,
+	}
+
+	// The file may be created by another resource, so a missing file is only reported when the resource is applied
+	diff, err := schema.InternalMap(resourceSchema).Diff(context.Background(), nil, terraform.NewResourceConfigRaw(config), customizeDncListEntriesFileDiff, nil, true)
+	assert.NoError(t, err)
+	assert.True(t, diff.Attributes["entries_file_content_hash"].NewComputed)
+
+	d := schema.TestResourceDataRaw(t, resourceSchema, config)
+	d.SetId("dnc-list-id")
+	diags := syncDncListEntriesFile(context.Background(), d, &outboundDnclistProxy{}, true, time.Minute)
+	assert.True(t, diags.HasError())
+	assert.Contains(t, diags[0].Detail, "failed to open DNC entries file")
+
+	assert.Nil(t, os.WriteFile(path, []byte("phone_number\n3175550100\n"), 0644))
+	diff, err = schema.InternalMap(resourceSchema).Diff(context.Background(), nil, terraform.NewResourceConfigRaw(config), customizeDncListEntriesFileDiff, nil, true)
+	assert.NoError(t, err)
+	assert.Equal(t, dncEntries{"+13175550100": ""}.hash(), diff.Attributes["entries_file_content_hash"].New)
+}
@@ -0,0 +1,145 @@
+package recording_media_retention_policy
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"log"
+	"terraform-provider-genesyscloud/genesyscloud/provider"
+	"terraform-provider-genesyscloud/genesyscloud/util"
+	lists "terraform-provider-genesyscloud/genesyscloud/util/lists"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/mypurecloud/platform-client-sdk-go/v149/platformclientv2"
+)
+
+/*
+   The data_source_genesyscloud_recording_media_retention_policy_evaluation.go contains the
+   genesyscloud_recording_media_retention_policy_evaluation data source. It reads the policy from the API or builds it
+   from the configuration, and evaluates it against the sample conversations with
+   genesyscloud_recording_media_retention_policy_evaluation.go.
+*/
+
+// dataSourceRecordingMediaRetentionPolicyEvaluationRead evaluates the policy against each of the sample conversations
+func dataSourceRecordingMediaRetentionPolicyEvaluationRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	sdkConfig := meta.(*provider.ProviderMeta).ClientConfig
+	pp := getPolicyProxy(sdkConfig)
+
+	var diags diag.Diagnostics
+	policyId := d.Get("policy_id").(string)
+	policy, diagErr := buildEvaluatedPolicy(ctx, pp, d)
+	if diagErr != nil {
+		return diagErr
+	}
+	if policy.Enabled != nil && !*policy.Enabled {
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Warning,
+			Summary:  fmt.Sprintf("Media retention policy %s is disabled", policyId),
+			Detail:   "The policy is evaluated as if it were enabled, but none of its actions fire until it is enabled.",
+		})
+	}
+
+	conversations, err := buildSampleConversations(d.Get("conversations").([]interface{}))
+	if err != nil {
+		return util.BuildDiagnosticError(EvaluationResourceType, "invalid conversations", err)
+	}
+
+	results := make([]interface{}, 0, len(conversations))
+	matchedCount := 0
+	for i, conversation := range conversations {
+		evaluation, err := evaluateMediaRetentionPolicy(policy, conversation)
+		if err != nil {
+			return util.BuildDiagnosticError(EvaluationResourceType, fmt.Sprintf("failed to evaluate conversation %d", i), err)
+		}
+		if evaluation.matched {
+			matchedCount++
+		}
+		results = append(results, flattenConversationEvaluation(evaluation))
+	}
+
+	if policyId != "" {
+		d.SetId(policyId)
+	} else {
+		policyJson, err := json.Marshal(policy)
+		if err != nil {
+			return util.BuildDiagnosticError(EvaluationResourceType, "failed to marshal the media retention policy", err)
+		}
+		d.SetId(fmt.Sprintf("%x", sha256.Sum256(policyJson)))
+	}
+	_ = d.Set("results", results)
+
+	log.Printf("Evaluated media retention policy %s against %d conversations: %d matched", d.Id(), len(conversations), matchedCount)
+	return diags
+}
+
+// buildEvaluatedPolicy reads the policy with the id set on the data source, or builds the policy from the configuration
+func buildEvaluatedPolicy(ctx context.Context, pp *policyProxy, d *schema.ResourceData) (*platformclientv2.Policy, diag.Diagnostics) {
+	if policyId := d.Get("policy_id").(string); policyId != "" {
+		policy, resp, err := pp.getPolicyById(ctx, policyId)
+		if err != nil {
+			return nil, util.BuildAPIDiagnosticError(EvaluationResourceType, fmt.Sprintf("failed to read media retention policy %s | error: %s", policyId, err), resp)
+		}
+		return policy, nil
+	}
+
+	err, mediaPolicies := buildMediaPolicies(d, pp, ctx)
+	if err != nil {
+		return nil, util.BuildDiagnosticError(EvaluationResourceType, "failed to build media_policies", err)
+	}
+	err, actions := buildPolicyActionsFromResource(d, pp, ctx)
+	if err != nil {
+		return nil, util.BuildDiagnosticError(EvaluationResourceType, "failed to build actions", err)
+	}
+	return &platformclientv2.Policy{
+		MediaPolicies: mediaPolicies,
+		Conditions:    buildConditions(d),
+		Actions:       actions,
+	}, nil
+}
+
+func buildSampleConversations(conversations []interface{}) ([]sampleConversation, error) {
+	result := make([]sampleConversation, 0, len(conversations))
+	for i, conversation := range conversations {
+		conversationMap, ok := conversation.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		date, err := time.Parse(time.RFC3339, conversationMap["date"].(string))
+		if err != nil {
+			return nil, fmt.Errorf("invalid date of conversation %d: %v", i, err)
+		}
+		result = append(result, sampleConversation{
+			name:         conversationMap["name"].(string),
+			mediaType:    conversationMap["media_type"].(string),
+			queueId:      conversationMap["queue_id"].(string),
+			userId:       conversationMap["user_id"].(string),
+			direction:    conversationMap["direction"].(string),
+			wrapupCodeId: conversationMap["wrapup_code_id"].(string),
+			languageId:   conversationMap["language_id"].(string),
+			duration:     time.Duration(conversationMap["duration_seconds"].(int)) * time.Second,
+			date:         date,
+		})
+	}
+	return result, nil
+}
+
+func flattenConversationEvaluation(evaluation *conversationEvaluation) map[string]interface{} {
+	return map[string]interface{}{
+		"name":                 evaluation.conversation.name,
+		"media_type":           evaluation.conversation.mediaType,
+		"media_policy":         evaluation.mediaPolicy,
+		"matched":              evaluation.matched,
+		"matched_conditions":   lists.StringListToInterfaceList(evaluation.matchedConditions),
+		"unmatched_conditions": lists.StringListToInterfaceList(evaluation.unmatchedConditions),
+		"fired_actions":        lists.StringListToInterfaceList(evaluation.firedActions),
+		"delete_recording":     evaluation.deleteRecording,
+		"archive_after_days":   evaluation.archiveAfterDays,
+		"delete_after_days":    evaluation.deleteAfterDays,
+		"evaluation_form_ids":  lists.StringListToInterfaceList(evaluation.evaluationFormIds),
+		"calibration_form_ids": lists.StringListToInterfaceList(evaluation.calibrationFormIds),
+		"survey_form_names":    lists.StringListToInterfaceList(evaluation.surveyFormNames),
+	}
+}
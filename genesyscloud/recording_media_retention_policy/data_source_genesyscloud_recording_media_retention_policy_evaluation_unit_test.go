@@ -0,0 +1,261 @@
+package recording_media_retention_policy
+
+import (
+	"context"
+	"net/http"
+	"terraform-provider-genesyscloud/genesyscloud/provider"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/mypurecloud/platform-client-sdk-go/v149/platformclientv2"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUnitParseIsoDuration(t *testing.T) {
+	testCases := map[string]time.Duration{
+		"PT30S":        30 * time.Second,
+		"PT5M":         5 * time.Minute,
+		"P0DT1H30M0S":  90 * time.Minute,
+		"P1W":          7 * 24 * time.Hour,
+		"PT1.5S":       1500 * time.Millisecond,
+		"P1DT2H":       26 * time.Hour,
+		" PT10M ":      10 * time.Minute,
+		"P0DT0H0M0.5S": 500 * time.Millisecond,
+	}
+	for value, expected := range testCases {
+		duration, err := parseIsoDuration(value)
+		if assert.Nil(t, err, value) {
+			assert.Equal(t, expected, duration, value)
+		}
+	}
+
+	for _, value := range []string{"", "P", "PT", "5M", "PT5X", "P1M2"} {
+		_, err := parseIsoDuration(value)
+		assert.NotNil(t, err, value)
+	}
+}
+
+func TestUnitEvaluateMediaRetentionPolicy(t *testing.T) {
+	policy := &platformclientv2.Policy{
+		MediaPolicies: &platformclientv2.Mediapolicies{
+			CallPolicy: &platformclientv2.Callmediapolicy{
+				Conditions: &platformclientv2.Callmediapolicyconditions{
+					ForQueues:  &[]platformclientv2.Queue{{Id: platformclientv2.String("queue-1")}, {Id: platformclientv2.String("queue-2")}},
+					Directions: &[]string{"INBOUND"},
+					DateRanges: &[]string{"2024-05-01T00:00:00.000Z/2024-06-01T00:00:00.000Z"},
+					TimeAllowed: &platformclientv2.Timeallowed{
+						TimeZoneId: platformclientv2.String("Europe/Paris"),
+						TimeSlots: &[]platformclientv2.Timeslot{
+							{StartTime: platformclientv2.String("10:00:00.000"), StopTime: platformclientv2.String("11:00:00.000"), Day: platformclientv2.Int(3)},
+						},
+					},
+					Duration: &platformclientv2.Durationcondition{
+						DurationTarget: platformclientv2.String("DURATION_RANGE"),
+						DurationRange:  platformclientv2.String("PT1M/PT10M"),
+						DurationMode:   platformclientv2.String("Between"),
+					},
+				},
+				Actions: &platformclientv2.Policyactions{
+					RetainRecording: platformclientv2.Bool(true),
+					DeleteRecording: platformclientv2.Bool(true),
+					AlwaysDelete:    platformclientv2.Bool(false),
+					AssignEvaluations: &[]platformclientv2.Evaluationassignment{
+						{EvaluationForm: &platformclientv2.Evaluationform{Id: platformclientv2.String("form-1")}},
+					},
+					AssignMeteredEvaluations: &[]platformclientv2.Meteredevaluationassignment{
+						{EvaluationForm: &platformclientv2.Evaluationform{Id: platformclientv2.String("form-1")}},
+						{EvaluationForm: &platformclientv2.Evaluationform{Id: platformclientv2.String("form-2")}},
+					},
+					AssignCalibrations: &[]platformclientv2.Calibrationassignment{
+						{EvaluationForm: &platformclientv2.Evaluationform{Id: platformclientv2.String("form-3")}},
+					},
+					RetentionDuration: &platformclientv2.Retentionduration{
+						ArchiveRetention: &platformclientv2.Archiveretention{Days: platformclientv2.Int(30)},
+						DeleteRetention:  &platformclientv2.Deleteretention{Days: platformclientv2.Int(365)},
+					},
+				},
+			},
+		},
+		Conditions: &platformclientv2.Policyconditions{
+			MediaTypes: &[]string{"EMAIL"},
+		},
+		Actions: &platformclientv2.Policyactions{
+			AlwaysDelete: platformclientv2.Bool(true),
+			AssignSurveys: &[]platformclientv2.Surveyassignment{
+				{SurveyForm: &platformclientv2.Publishedsurveyformreference{Name: platformclientv2.String("Survey")}},
+			},
+		},
+	}
+
+	// Wednesday 15 May 2024, 10:30 in Paris
+	date := time.Date(2024, time.May, 15, 8, 30, 0, 0, time.UTC)
+	call := sampleConversation{mediaType: mediaTypeCall, queueId: "queue-2", direction: "inbound", duration: 5 * time.Minute, date: date}
+
+	evaluation, err := evaluateMediaRetentionPolicy(policy, call)
+	if !assert.Nil(t, err) {
+		return
+	}
+	assert.Equal(t, "call_policy", evaluation.mediaPolicy)
+	assert.True(t, evaluation.matched)
+	assert.Equal(t, []string{"for_queue_ids", "directions", "date_ranges", "time_allowed", "duration"}, evaluation.matchedConditions)
+	assert.Empty(t, evaluation.unmatchedConditions)
+	assert.Equal(t, []string{"retain_recording", "assign_evaluations", "assign_metered_evaluations", "assign_calibrations", "retention_duration"}, evaluation.firedActions)
+	assert.False(t, evaluation.deleteRecording)
+	assert.Equal(t, 30, evaluation.archiveAfterDays)
+	assert.Equal(t, 365, evaluation.deleteAfterDays)
+	assert.Equal(t, []string{"form-1", "form-2"}, evaluation.evaluationFormIds)
+	assert.Equal(t, []string{"form-3"}, evaluation.calibrationFormIds)
+
+	// Out of the time slot, too long and on another queue: nothing fires
+	late := call
+	late.date = date.Add(2 * time.Hour)
+	late.duration = 20 * time.Minute
+	late.queueId = "queue-3"
+	evaluation, err = evaluateMediaRetentionPolicy(policy, late)
+	if !assert.Nil(t, err) {
+		return
+	}
+	assert.False(t, evaluation.matched)
+	assert.Equal(t, []string{"directions", "date_ranges"}, evaluation.matchedConditions)
+	assert.Equal(t, []string{"for_queue_ids", "time_allowed", "duration"}, evaluation.unmatchedConditions)
+	assert.Empty(t, evaluation.firedActions)
+	assert.Empty(t, evaluation.evaluationFormIds)
+
+	// Emails have no media policy and fall back to the top-level conditions and actions
+	email := sampleConversation{mediaType: mediaTypeEmail, date: date}
+	evaluation, err = evaluateMediaRetentionPolicy(policy, email)
+	if !assert.Nil(t, err) {
+		return
+	}
+	assert.Equal(t, topLevelPolicy, evaluation.mediaPolicy)
+	assert.True(t, evaluation.matched)
+	assert.Equal(t, []string{"media_types"}, evaluation.matchedConditions)
+	assert.Equal(t, []string{"always_delete", "assign_surveys"}, evaluation.firedActions)
+	assert.True(t, evaluation.deleteRecording)
+	assert.Equal(t, []string{"Survey"}, evaluation.surveyFormNames)
+
+	chat := sampleConversation{mediaType: mediaTypeChat, date: date}
+	evaluation, err = evaluateMediaRetentionPolicy(policy, chat)
+	if !assert.Nil(t, err) {
+		return
+	}
+	assert.False(t, evaluation.matched)
+	assert.Equal(t, []string{"media_types"}, evaluation.unmatchedConditions)
+
+	// A policy without a part for the media type applies to none of its conversations
+	evaluation, err = evaluateMediaRetentionPolicy(&platformclientv2.Policy{MediaPolicies: policy.MediaPolicies}, chat)
+	if !assert.Nil(t, err) {
+		return
+	}
+	assert.Equal(t, "", evaluation.mediaPolicy)
+	assert.False(t, evaluation.matched)
+
+	// Invalid conditions are reported
+	invalid := &platformclientv2.Policy{Conditions: &platformclientv2.Policyconditions{DateRanges: &[]string{"2024-05-01"}}}
+	_, err = evaluateMediaRetentionPolicy(invalid, email)
+	assert.NotNil(t, err)
+}
+
+func TestUnitDataSourceMediaRetentionPolicyEvaluationRead(t *testing.T) {
+	policyId := "policy-id"
+	internalProxy = &policyProxy{
+		getPolicyByIdAttr: func(ctx context.Context, p *policyProxy, id string) (*platformclientv2.Policy, *platformclientv2.APIResponse, error) {
+			assert.Equal(t, policyId, id)
+			return &platformclientv2.Policy{
+				Id:      &policyId,
+				Enabled: platformclientv2.Bool(false),
+				MediaPolicies: &platformclientv2.Mediapolicies{
+					MessagePolicy: &platformclientv2.Messagemediapolicy{
+						Conditions: &platformclientv2.Messagemediapolicyconditions{
+							ForUsers: &[]platformclientv2.User{{Id: platformclientv2.String("user-1")}},
+						},
+						Actions: &platformclientv2.Policyactions{
+							DeleteRecording: platformclientv2.Bool(true),
+						},
+					},
+				},
+			}, &platformclientv2.APIResponse{StatusCode: http.StatusOK}, nil
+		},
+		getFormsEvaluationAttr: func(ctx context.Context, p *policyProxy, formId string) (*platformclientv2.Evaluationformresponse, *platformclientv2.APIResponse, error) {
+			return &platformclientv2.Evaluationformresponse{Id: &formId, ContextId: platformclientv2.String("context-id")}, &platformclientv2.APIResponse{StatusCode: http.StatusOK}, nil
+		},
+	}
+	defer func() { internalProxy = nil }()
+
+	meta := &provider.ProviderMeta{ClientConfig: &platformclientv2.Configuration{}}
+	dataSource := DataSourceRecordingMediaRetentionPolicyEvaluation()
+
+	// An existing policy, which is disabled
+	d := schema.TestResourceDataRaw(t, dataSource.Schema, map[string]interface{}{
+		"policy_id": policyId,
+		"conversations": []interface{}{
+			map[string]interface{}{"name": "agent message", "media_type": "message", "user_id": "user-1", "date": "2024-05-15T08:30:00Z"},
+			map[string]interface{}{"name": "other message", "media_type": "message", "user_id": "user-2", "date": "2024-05-15T08:30:00Z"},
+		},
+	})
+	diags := dataSourceRecordingMediaRetentionPolicyEvaluationRead(context.Background(), d, meta)
+	assert.False(t, diags.HasError(), diags)
+	if assert.Len(t, diags, 1) {
+		assert.Equal(t, diag.Warning, diags[0].Severity)
+	}
+	assert.Equal(t, policyId, d.Id())
+	assert.Equal(t, "agent message", d.Get("results.0.name"))
+	assert.Equal(t, "message_policy", d.Get("results.0.media_policy"))
+	assert.Equal(t, true, d.Get("results.0.matched"))
+	assert.Equal(t, []interface{}{"delete_recording"}, d.Get("results.0.fired_actions"))
+	assert.Equal(t, true, d.Get("results.0.delete_recording"))
+	assert.Equal(t, false, d.Get("results.1.matched"))
+	assert.Equal(t, []interface{}{"for_user_ids"}, d.Get("results.1.unmatched_conditions"))
+	assert.Equal(t, false, d.Get("results.1.delete_recording"))
+
+	// An inline policy
+	d = schema.TestResourceDataRaw(t, dataSource.Schema, map[string]interface{}{
+		"media_policies": []interface{}{
+			map[string]interface{}{
+				"call_policy": []interface{}{
+					map[string]interface{}{
+						"conditions": []interface{}{
+							map[string]interface{}{
+								"for_queue_ids": []interface{}{"queue-1"},
+								"duration": []interface{}{
+									map[string]interface{}{"duration_target": "DURATION", "duration_range": "PT5M", "duration_mode": "Over"},
+								},
+							},
+						},
+						"actions": []interface{}{
+							map[string]interface{}{
+								"retain_recording": true,
+								"assign_evaluations": []interface{}{
+									map[string]interface{}{"evaluation_form_id": "form-1"},
+								},
+								"retention_duration": []interface{}{
+									map[string]interface{}{
+										"delete_retention": []interface{}{map[string]interface{}{"days": 90}},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		"conversations": []interface{}{
+			map[string]interface{}{"media_type": "call", "queue_id": "queue-1", "duration_seconds": 600, "date": "2024-05-15T08:30:00Z"},
+			map[string]interface{}{"media_type": "call", "queue_id": "queue-1", "duration_seconds": 60, "date": "2024-05-15T08:30:00Z"},
+		},
+	})
+	diags = dataSourceRecordingMediaRetentionPolicyEvaluationRead(context.Background(), d, meta)
+	assert.False(t, diags.HasError(), diags)
+	assert.Empty(t, diags)
+	assert.NotEmpty(t, d.Id())
+	assert.Equal(t, "call_policy", d.Get("results.0.media_policy"))
+	assert.Equal(t, true, d.Get("results.0.matched"))
+	assert.Equal(t, []interface{}{"for_queue_ids", "duration"}, d.Get("results.0.matched_conditions"))
+	assert.Equal(t, []interface{}{"retain_recording", "assign_evaluations", "retention_duration"}, d.Get("results.0.fired_actions"))
+	assert.Equal(t, []interface{}{"form-1"}, d.Get("results.0.evaluation_form_ids"))
+	assert.Equal(t, 90, d.Get("results.0.delete_after_days"))
+	assert.Equal(t, false, d.Get("results.1.matched"))
+	assert.Equal(t, []interface{}{"duration"}, d.Get("results.1.unmatched_conditions"))
+}
@@ -0,0 +1,516 @@
+package recording_media_retention_policy
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"terraform-provider-genesyscloud/genesyscloud/util"
+	lists "terraform-provider-genesyscloud/genesyscloud/util/lists"
+	"time"
+
+	"github.com/mypurecloud/platform-client-sdk-go/v149/platformclientv2"
+)
+
+/*
+   The genesyscloud_recording_media_retention_policy_evaluation.go file evaluates a media retention policy against
+   sample conversations for the genesyscloud_recording_media_retention_policy_evaluation data source.
+
+   The media policy of the media type of a conversation takes precedence over the top-level conditions and actions of
+   the policy. Within a condition a conversation has to match one of the listed values, and it has to match every
+   condition set on the policy for the actions to fire. Empty conditions do not restrict the conversations.
+*/
+
+const (
+	mediaTypeCall    = "call"
+	mediaTypeChat    = "chat"
+	mediaTypeEmail   = "email"
+	mediaTypeMessage = "message"
+
+	// topLevelPolicy is reported for conversations evaluated against the top-level conditions and actions of the policy
+	topLevelPolicy = "policy"
+)
+
+var evaluationMediaTypes = []string{mediaTypeCall, mediaTypeChat, mediaTypeEmail, mediaTypeMessage}
+
+// timeSlotLayouts are the layouts of the start and stop times of time slots
+var timeSlotLayouts = []string{"15:04:05.000", "15:04:05", "15:04"}
+
+var isoDurationPattern = regexp.MustCompile(`^P(?:(\d+)W)?(?:(\d+)D)?(?:T(?:(\d+)H)?(?:(\d+)M)?(?:(\d+(?:\.\d+)?)S)?)?$`)
+
+// sampleConversation is a synthetic conversation the policy is evaluated against
+type sampleConversation struct {
+	name         string
+	mediaType    string
+	queueId      string
+	userId       string
+	direction    string
+	wrapupCodeId string
+	languageId   string
+	duration     time.Duration
+	date         time.Time
+}
+
+// retentionConditions are the conditions of a media policy or of the top-level policy, whatever their media type
+type retentionConditions struct {
+	userIds       []string
+	queueIds      []string
+	wrapupCodeIds []string
+	languageIds   []string
+	directions    []string
+	mediaTypes    []string
+	dateRanges    []string
+	timeAllowed   *platformclientv2.Timeallowed
+	duration      *platformclientv2.Durationcondition
+}
+
+// conversationEvaluation is the outcome of evaluating the policy against a sample conversation
+type conversationEvaluation struct {
+	conversation        sampleConversation
+	mediaPolicy         string
+	matched             bool
+	matchedConditions   []string
+	unmatchedConditions []string
+	firedActions        []string
+	deleteRecording     bool
+	archiveAfterDays    int
+	deleteAfterDays     int
+	evaluationFormIds   []string
+	calibrationFormIds  []string
+	surveyFormNames     []string
+}
+
+// evaluateMediaRetentionPolicy evaluates the policy against a sample conversation
+func evaluateMediaRetentionPolicy(policy *platformclientv2.Policy, conversation sampleConversation) (*conversationEvaluation, error) {
+	evaluation := &conversationEvaluation{conversation: conversation}
+
+	mediaPolicy, conditions, actions := selectPolicyForMediaType(policy, conversation.mediaType)
+	if mediaPolicy == "" {
+		return evaluation, nil
+	}
+	evaluation.mediaPolicy = mediaPolicy
+
+	matched, unmatched, err := evaluateConditions(conditions, conversation)
+	if err != nil {
+		return nil, fmt.Errorf("failed to evaluate the conditions of %s: %v", mediaPolicy, err)
+	}
+	evaluation.matchedConditions = matched
+	evaluation.unmatchedConditions = unmatched
+	evaluation.matched = len(unmatched) == 0
+
+	if evaluation.matched {
+		applyActions(evaluation, actions)
+	}
+	return evaluation, nil
+}
+
+// selectPolicyForMediaType returns the conditions and actions that apply to a media type, and the name of the policy they come from.
+// The name is empty when the policy has neither a media policy for the media type nor top-level conditions or actions.
+func selectPolicyForMediaType(policy *platformclientv2.Policy, mediaType string) (string, *retentionConditions, *platformclientv2.Policyactions) {
+	if mediaPolicies := policy.MediaPolicies; mediaPolicies != nil {
+		switch {
+		case mediaType == mediaTypeCall && mediaPolicies.CallPolicy != nil:
+			return "call_policy", callConditions(mediaPolicies.CallPolicy.Conditions), mediaPolicies.CallPolicy.Actions
+		case mediaType == mediaTypeChat && mediaPolicies.ChatPolicy != nil:
+			return "chat_policy", chatConditions(mediaPolicies.ChatPolicy.Conditions), mediaPolicies.ChatPolicy.Actions
+		case mediaType == mediaTypeEmail && mediaPolicies.EmailPolicy != nil:
+			return "email_policy", emailConditions(mediaPolicies.EmailPolicy.Conditions), mediaPolicies.EmailPolicy.Actions
+		case mediaType == mediaTypeMessage && mediaPolicies.MessagePolicy != nil:
+			return "message_policy", messageConditions(mediaPolicies.MessagePolicy.Conditions), mediaPolicies.MessagePolicy.Actions
+		}
+	}
+
+	if policy.Conditions != nil || policy.Actions != nil {
+		return topLevelPolicy, policyConditions(policy.Conditions), policy.Actions
+	}
+	return "", nil, nil
+}
+
+func callConditions(conditions *platformclientv2.Callmediapolicyconditions) *retentionConditions {
+	if conditions == nil {
+		return &retentionConditions{}
+	}
+	return &retentionConditions{
+		userIds:       userIds(conditions.ForUsers),
+		queueIds:      queueIds(conditions.ForQueues),
+		wrapupCodeIds: wrapupCodeIds(conditions.WrapupCodes),
+		languageIds:   languageIds(conditions.Languages),
+		directions:    stringList(conditions.Directions),
+		dateRanges:    stringList(conditions.DateRanges),
+		timeAllowed:   conditions.TimeAllowed,
+		duration:      conditions.Duration,
+	}
+}
+
+func chatConditions(conditions *platformclientv2.Chatmediapolicyconditions) *retentionConditions {
+	if conditions == nil {
+		return &retentionConditions{}
+	}
+	return &retentionConditions{
+		userIds:       userIds(conditions.ForUsers),
+		queueIds:      queueIds(conditions.ForQueues),
+		wrapupCodeIds: wrapupCodeIds(conditions.WrapupCodes),
+		languageIds:   languageIds(conditions.Languages),
+		dateRanges:    stringList(conditions.DateRanges),
+		timeAllowed:   conditions.TimeAllowed,
+		duration:      conditions.Duration,
+	}
+}
+
+func emailConditions(conditions *platformclientv2.Emailmediapolicyconditions) *retentionConditions {
+	if conditions == nil {
+		return &retentionConditions{}
+	}
+	return &retentionConditions{
+		userIds:       userIds(conditions.ForUsers),
+		queueIds:      queueIds(conditions.ForQueues),
+		wrapupCodeIds: wrapupCodeIds(conditions.WrapupCodes),
+		languageIds:   languageIds(conditions.Languages),
+		dateRanges:    stringList(conditions.DateRanges),
+		timeAllowed:   conditions.TimeAllowed,
+	}
+}
+
+func messageConditions(conditions *platformclientv2.Messagemediapolicyconditions) *retentionConditions {
+	if conditions == nil {
+		return &retentionConditions{}
+	}
+	return &retentionConditions{
+		userIds:       userIds(conditions.ForUsers),
+		queueIds:      queueIds(conditions.ForQueues),
+		wrapupCodeIds: wrapupCodeIds(conditions.WrapupCodes),
+		languageIds:   languageIds(conditions.Languages),
+		dateRanges:    stringList(conditions.DateRanges),
+		timeAllowed:   conditions.TimeAllowed,
+	}
+}
+
+func policyConditions(conditions *platformclientv2.Policyconditions) *retentionConditions {
+	if conditions == nil {
+		return &retentionConditions{}
+	}
+	return &retentionConditions{
+		userIds:       userIds(conditions.ForUsers),
+		queueIds:      queueIds(conditions.ForQueues),
+		wrapupCodeIds: wrapupCodeIds(conditions.WrapupCodes),
+		directions:    stringList(conditions.Directions),
+		mediaTypes:    stringList(conditions.MediaTypes),
+		dateRanges:    stringList(conditions.DateRanges),
+		timeAllowed:   conditions.TimeAllowed,
+		duration:      conditions.Duration,
+	}
+}
+
+// evaluateConditions returns the names of the conditions set on the policy that the conversation matches and does not match
+func evaluateConditions(conditions *retentionConditions, conversation sampleConversation) (matched []string, unmatched []string, err error) {
+	check := func(name string, isSet bool, matches bool) {
+		if !isSet {
+			return
+		}
+		if matches {
+			matched = append(matched, name)
+		} else {
+			unmatched = append(unmatched, name)
+		}
+	}
+
+	check("media_types", len(conditions.mediaTypes) > 0, containsFold(conditions.mediaTypes, conversation.mediaType))
+	check("for_user_ids", len(conditions.userIds) > 0, lists.ItemInSlice(conversation.userId, conditions.userIds))
+	check("for_queue_ids", len(conditions.queueIds) > 0, lists.ItemInSlice(conversation.queueId, conditions.queueIds))
+	check("wrapup_code_ids", len(conditions.wrapupCodeIds) > 0, lists.ItemInSlice(conversation.wrapupCodeId, conditions.wrapupCodeIds))
+	check("language_ids", len(conditions.languageIds) > 0, lists.ItemInSlice(conversation.languageId, conditions.languageIds))
+	check("directions", len(conditions.directions) > 0, containsFold(conditions.directions, conversation.direction))
+
+	if len(conditions.dateRanges) > 0 {
+		inRange, err := inDateRanges(conditions.dateRanges, conversation.date)
+		if err != nil {
+			return nil, nil, err
+		}
+		check("date_ranges", true, inRange)
+	}
+
+	if conditions.timeAllowed != nil && conditions.timeAllowed.TimeSlots != nil && len(*conditions.timeAllowed.TimeSlots) > 0 {
+		allowed, err := inTimeAllowed(conditions.timeAllowed, conversation.date)
+		if err != nil {
+			return nil, nil, err
+		}
+		check("time_allowed", true, allowed)
+	}
+
+	if conditions.duration != nil && (util.StringOrEmpty(conditions.duration.DurationMode) != "" || util.StringOrEmpty(conditions.duration.DurationRange) != "") {
+		inDuration, err := inDurationCondition(conditions.duration, conversation.duration)
+		if err != nil {
+			return nil, nil, err
+		}
+		check("duration", true, inDuration)
+	}
+	return matched, unmatched, nil
+}
+
+// inDateRanges checks that the date is in one of the ISO 8601 intervals, such as 2022-05-12T04:00:00.000Z/2022-05-13T04:00:00.000Z
+func inDateRanges(dateRanges []string, date time.Time) (bool, error) {
+	for _, dateRange := range dateRanges {
+		start, end, found := strings.Cut(dateRange, "/")
+		if !found {
+			return false, fmt.Errorf("date range %s is not an interval in the format start/end", dateRange)
+		}
+		startTime, err := time.Parse(time.RFC3339, start)
+		if err != nil {
+			return false, fmt.Errorf("invalid start of date range %s: %v", dateRange, err)
+		}
+		endTime, err := time.Parse(time.RFC3339, end)
+		if err != nil {
+			return false, fmt.Errorf("invalid end of date range %s: %v", dateRange, err)
+		}
+		if !date.Before(startTime) && date.Before(endTime) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// inTimeAllowed checks that the date falls in one of the time slots, read in the time zone of the condition.
+// Days run from Monday = 1 to Sunday = 7, and a slot whose stop time is before its start time runs past midnight.
+func inTimeAllowed(timeAllowed *platformclientv2.Timeallowed, date time.Time) (bool, error) {
+	timeZone := util.StringOrEmpty(timeAllowed.TimeZoneId)
+	if timeZone == "" {
+		timeZone = "UTC"
+	}
+	loc, err := time.LoadLocation(timeZone)
+	if err != nil {
+		return false, fmt.Errorf("invalid time zone %s: %v", timeZone, err)
+	}
+
+	local := date.In(loc)
+	day := int(local.Weekday())
+	if day == 0 {
+		day = 7
+	}
+	timeOfDay := time.Duration(local.Hour())*time.Hour + time.Duration(local.Minute())*time.Minute + time.Duration(local.Second())*time.Second + time.Duration(local.Nanosecond())
+
+	for _, slot := range *timeAllowed.TimeSlots {
+		if slot.Day == nil || *slot.Day != day {
+			continue
+		}
+		start, err := parseTimeOfDay(util.StringOrEmpty(slot.StartTime))
+		if err != nil {
+			return false, err
+		}
+		stop, err := parseTimeOfDay(util.StringOrEmpty(slot.StopTime))
+		if err != nil {
+			return false, err
+		}
+		if stop > start && timeOfDay >= start && timeOfDay < stop {
+			return true, nil
+		}
+		if stop <= start && (timeOfDay >= start || timeOfDay < stop) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func parseTimeOfDay(value string) (time.Duration, error) {
+	for _, layout := range timeSlotLayouts {
+		if t, err := time.Parse(layout, value); err == nil {
+			return t.Sub(time.Date(0, 1, 1, 0, 0, 0, 0, time.UTC)), nil
+		}
+	}
+	return 0, fmt.Errorf("invalid time slot time %s, expected the format 15:04:05.000", value)
+}
+
+// inDurationCondition checks the duration of the conversation against the ISO 8601 durations of the condition.
+// Over and Under take a single duration, such as PT5M. Between takes a range, such as PT1M/PT5M.
+func inDurationCondition(condition *platformclientv2.Durationcondition, duration time.Duration) (bool, error) {
+	mode := util.StringOrEmpty(condition.DurationMode)
+	durationRange := util.StringOrEmpty(condition.DurationRange)
+
+	switch mode {
+	case "Over", "Under":
+		limit, err := parseIsoDuration(durationRange)
+		if err != nil {
+			return false, err
+		}
+		if mode == "Over" {
+			return duration > limit, nil
+		}
+		return duration < limit, nil
+	case "Between":
+		from, to, found := strings.Cut(durationRange, "/")
+		if !found {
+			from, to, found = strings.Cut(durationRange, "-")
+		}
+		if !found {
+			return false, fmt.Errorf("duration range %s is not a range in the format PT1M/PT5M", durationRange)
+		}
+		min, err := parseIsoDuration(from)
+		if err != nil {
+			return false, err
+		}
+		max, err := parseIsoDuration(to)
+		if err != nil {
+			return false, err
+		}
+		return duration >= min && duration <= max, nil
+	}
+	return false, fmt.Errorf("duration_mode %q is not one of Between, Over or Under", mode)
+}
+
+// parseIsoDuration reads an ISO 8601 duration such as PT30S or P0DT1H30M0S
+func parseIsoDuration(value string) (time.Duration, error) {
+	value = strings.TrimSpace(value)
+	match := isoDurationPattern.FindStringSubmatch(value)
+	if match == nil || value == "P" || strings.HasSuffix(value, "T") {
+		return 0, fmt.Errorf("invalid ISO 8601 duration %s", value)
+	}
+	units := []time.Duration{7 * 24 * time.Hour, 24 * time.Hour, time.Hour, time.Minute}
+	var duration time.Duration
+	for i, unit := range units {
+		if match[i+1] != "" {
+			n, _ := strconv.Atoi(match[i+1])
+			duration += time.Duration(n) * unit
+		}
+	}
+	if match[5] != "" {
+		seconds, _ := strconv.ParseFloat(match[5], 64)
+		duration += time.Duration(seconds * float64(time.Second))
+	}
+	return duration, nil
+}
+
+// applyActions records the actions of the policy that fire for a matched conversation
+func applyActions(evaluation *conversationEvaluation, actions *platformclientv2.Policyactions) {
+	if actions == nil {
+		return
+	}
+	fire := func(name string, fires bool) {
+		if fires {
+			evaluation.firedActions = append(evaluation.firedActions, name)
+		}
+	}
+
+	retainRecording := actions.RetainRecording != nil && *actions.RetainRecording
+	deleteRecording := actions.DeleteRecording != nil && *actions.DeleteRecording
+	alwaysDelete := actions.AlwaysDelete != nil && *actions.AlwaysDelete
+	fire("retain_recording", retainRecording)
+	fire("delete_recording", deleteRecording && !retainRecording)
+	fire("always_delete", alwaysDelete)
+	evaluation.deleteRecording = alwaysDelete || (deleteRecording && !retainRecording)
+
+	if actions.AssignEvaluations != nil && len(*actions.AssignEvaluations) > 0 {
+		fire("assign_evaluations", true)
+		for _, assignment := range *actions.AssignEvaluations {
+			evaluation.evaluationFormIds = appendFormId(evaluation.evaluationFormIds, assignment.EvaluationForm)
+		}
+	}
+	if actions.AssignMeteredEvaluations != nil && len(*actions.AssignMeteredEvaluations) > 0 {
+		fire("assign_metered_evaluations", true)
+		for _, assignment := range *actions.AssignMeteredEvaluations {
+			evaluation.evaluationFormIds = appendFormId(evaluation.evaluationFormIds, assignment.EvaluationForm)
+		}
+	}
+	if actions.AssignMeteredAssignmentByAgent != nil && len(*actions.AssignMeteredAssignmentByAgent) > 0 {
+		fire("assign_metered_assignment_by_agent", true)
+		for _, assignment := range *actions.AssignMeteredAssignmentByAgent {
+			evaluation.evaluationFormIds = appendFormId(evaluation.evaluationFormIds, assignment.EvaluationForm)
+		}
+	}
+	if actions.AssignCalibrations != nil && len(*actions.AssignCalibrations) > 0 {
+		fire("assign_calibrations", true)
+		for _, assignment := range *actions.AssignCalibrations {
+			evaluation.calibrationFormIds = appendFormId(evaluation.calibrationFormIds, assignment.EvaluationForm)
+		}
+	}
+	if actions.AssignSurveys != nil && len(*actions.AssignSurveys) > 0 {
+		fire("assign_surveys", true)
+		for _, assignment := range *actions.AssignSurveys {
+			if assignment.SurveyForm != nil && assignment.SurveyForm.Name != nil && !lists.ItemInSlice(*assignment.SurveyForm.Name, evaluation.surveyFormNames) {
+				evaluation.surveyFormNames = append(evaluation.surveyFormNames, *assignment.SurveyForm.Name)
+			}
+		}
+	}
+
+	if retention := actions.RetentionDuration; retention != nil && (retention.ArchiveRetention != nil || retention.DeleteRetention != nil) {
+		fire("retention_duration", true)
+		if retention.ArchiveRetention != nil && retention.ArchiveRetention.Days != nil {
+			evaluation.archiveAfterDays = *retention.ArchiveRetention.Days
+		}
+		if retention.DeleteRetention != nil && retention.DeleteRetention.Days != nil {
+			evaluation.deleteAfterDays = *retention.DeleteRetention.Days
+		}
+	}
+	fire("initiate_screen_recording", actions.InitiateScreenRecording != nil)
+	fire("media_transcriptions", actions.MediaTranscriptions != nil && len(*actions.MediaTranscriptions) > 0)
+	fire("integration_export", actions.IntegrationExport != nil && actions.IntegrationExport.Integration != nil)
+}
+
+func appendFormId(formIds []string, form *platformclientv2.Evaluationform) []string {
+	if form == nil || form.Id == nil || lists.ItemInSlice(*form.Id, formIds) {
+		return formIds
+	}
+	return append(formIds, *form.Id)
+}
+
+func userIds(users *[]platformclientv2.User) []string {
+	var ids []string
+	if users != nil {
+		for _, user := range *users {
+			if user.Id != nil {
+				ids = append(ids, *user.Id)
+			}
+		}
+	}
+	return ids
+}
+
+func queueIds(queues *[]platformclientv2.Queue) []string {
+	var ids []string
+	if queues != nil {
+		for _, queue := range *queues {
+			if queue.Id != nil {
+				ids = append(ids, *queue.Id)
+			}
+		}
+	}
+	return ids
+}
+
+func wrapupCodeIds(wrapupCodes *[]platformclientv2.Wrapupcode) []string {
+	var ids []string
+	if wrapupCodes != nil {
+		for _, wrapupCode := range *wrapupCodes {
+			if wrapupCode.Id != nil {
+				ids = append(ids, *wrapupCode.Id)
+			}
+		}
+	}
+	return ids
+}
+
+func languageIds(languages *[]platformclientv2.Language) []string {
+	var ids []string
+	if languages != nil {
+		for _, language := range *languages {
+			if language.Id != nil {
+				ids = append(ids, *language.Id)
+			}
+		}
+	}
+	return ids
+}
+
+func stringList(values *[]string) []string {
+	if values == nil {
+		return nil
+	}
+	return *values
+}
+
+func containsFold(values []string, value string) bool {
+	for _, v := range values {
+		if strings.EqualFold(v, value) {
+			return true
+		}
+	}
+	return false
+}
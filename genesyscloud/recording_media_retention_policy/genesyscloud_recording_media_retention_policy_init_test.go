@@ -69,6 +69,7 @@ func (r *registerTestInstance) registerTestDataSources() {
 	defer r.datasourceMapMutex.Unlock()
 
 	providerDataSources[ResourceType] = DataSourceRecordingMediaRetentionPolicy()
+	providerDataSources[EvaluationResourceType] = DataSourceRecordingMediaRetentionPolicyEvaluation()
 }
 
 // initTestResources initializes all test resources and data sources.
@@ -18,11 +18,15 @@ The genesyscloud_recording_media_retention_policy_schema.go should hold four typ
 4.  The resource exporter configuration for the genesyscloud_recording_media_retention_policy exporter.
 */
 
-const ResourceType = "genesyscloud_recording_media_retention_policy"
+const (
+	ResourceType           = "genesyscloud_recording_media_retention_policy"
+	EvaluationResourceType = "genesyscloud_recording_media_retention_policy_evaluation"
+)
 
 // SetRegistrar registers all of the resources, datasources and exporters in the package
 func SetRegistrar(l registrar.Registrar) {
 	l.RegisterDataSource(ResourceType, DataSourceRecordingMediaRetentionPolicy())
+	l.RegisterDataSource(EvaluationResourceType, DataSourceRecordingMediaRetentionPolicyEvaluation())
 	l.RegisterResource(ResourceType, ResourceMediaRetentionPolicy())
 	l.RegisterExporter(ResourceType, MediaRetentionPolicyExporter())
 }
@@ -1054,3 +1058,184 @@ func DataSourceRecordingMediaRetentionPolicy() *schema.Resource {
 		},
 	}
 }
+
+// DataSourceRecordingMediaRetentionPolicyEvaluation registers the genesyscloud_recording_media_retention_policy_evaluation data source
+func DataSourceRecordingMediaRetentionPolicyEvaluation() *schema.Resource {
+	policySchema := ResourceMediaRetentionPolicy().Schema
+
+	return &schema.Resource{
+		Description: "Data source that evaluates a Genesys Cloud media retention policy against sample conversations, without creating or changing the policy. " +
+			"The media policy of the media type of a conversation takes precedence over the top-level conditions and actions of the policy. " +
+			"A conversation has to match one of the values of every condition set on the policy for its actions to fire. Use it to cover policies with `terraform test` assertions.",
+		ReadContext: provider.ReadWithPooledClient(dataSourceRecordingMediaRetentionPolicyEvaluationRead),
+		Schema: map[string]*schema.Schema{
+			"policy_id": {
+				Description:   "ID of the media retention policy to evaluate.",
+				Type:          schema.TypeString,
+				Optional:      true,
+				ConflictsWith: []string{"media_policies", "conditions", "actions"},
+				AtLeastOneOf:  []string{"policy_id", "media_policies", "conditions", "actions"},
+			},
+			"media_policies": {
+				Description: "Conditions and actions per media type of a policy to evaluate in place of an existing policy. See the genesyscloud_recording_media_retention_policy resource.",
+				Type:        schema.TypeList,
+				MaxItems:    1,
+				Optional:    true,
+				Elem:        policySchema["media_policies"].Elem,
+			},
+			"conditions": {
+				Description: "Top-level conditions of a policy to evaluate in place of an existing policy. See the genesyscloud_recording_media_retention_policy resource.",
+				Type:        schema.TypeList,
+				MaxItems:    1,
+				Optional:    true,
+				Elem:        policySchema["conditions"].Elem,
+			},
+			"actions": {
+				Description: "Top-level actions of a policy to evaluate in place of an existing policy. See the genesyscloud_recording_media_retention_policy resource.",
+				Type:        schema.TypeList,
+				MaxItems:    1,
+				Optional:    true,
+				Elem:        policySchema["actions"].Elem,
+			},
+			"conversations": {
+				Description: "The sample conversations to evaluate the policy against.",
+				Type:        schema.TypeList,
+				Required:    true,
+				MinItems:    1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": {
+							Description: "Name of the conversation, to tell the results apart.",
+							Type:        schema.TypeString,
+							Optional:    true,
+						},
+						"media_type": {
+							Description:  "Media type of the conversation.",
+							Type:         schema.TypeString,
+							Required:     true,
+							ValidateFunc: validation.StringInSlice(evaluationMediaTypes, false),
+						},
+						"date": {
+							Description:  "Date of the conversation, as an RFC 3339 timestamp (2006-01-02T15:04:05Z07:00). Evaluated against date_ranges and time_allowed.",
+							Type:         schema.TypeString,
+							Required:     true,
+							ValidateFunc: validation.IsRFC3339Time,
+						},
+						"queue_id": {
+							Description: "ID of the queue of the conversation.",
+							Type:        schema.TypeString,
+							Optional:    true,
+						},
+						"user_id": {
+							Description: "ID of the user of the conversation.",
+							Type:        schema.TypeString,
+							Optional:    true,
+						},
+						"direction": {
+							Description:  "Direction of the conversation.",
+							Type:         schema.TypeString,
+							Optional:     true,
+							ValidateFunc: validation.StringInSlice([]string{"INBOUND", "OUTBOUND"}, false),
+						},
+						"wrapup_code_id": {
+							Description: "ID of the wrap-up code of the conversation.",
+							Type:        schema.TypeString,
+							Optional:    true,
+						},
+						"language_id": {
+							Description: "ID of the language of the conversation.",
+							Type:        schema.TypeString,
+							Optional:    true,
+						},
+						"duration_seconds": {
+							Description:  "Duration of the conversation in seconds. Evaluated against duration conditions, whose duration_range is an ISO 8601 duration such as PT5M for the Over and Under modes, and a range such as PT1M/PT5M for the Between mode.",
+							Type:         schema.TypeInt,
+							Optional:     true,
+							ValidateFunc: validation.IntAtLeast(0),
+						},
+					},
+				},
+			},
+			"results": {
+				Description: "The evaluation of each conversation, in the order of `conversations`.",
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": {
+							Description: "Name of the conversation.",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"media_type": {
+							Description: "Media type of the conversation.",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"media_policy": {
+							Description: "The part of the policy the conversation was evaluated against: call_policy, chat_policy, email_policy, message_policy, or policy for the top-level conditions and actions. Empty when no part of the policy applies to the media type of the conversation.",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"matched": {
+							Description: "True if the conversation matches all of the conditions of the policy, so that its actions fire.",
+							Type:        schema.TypeBool,
+							Computed:    true,
+						},
+						"matched_conditions": {
+							Description: "Conditions of the policy that the conversation matches, such as for_queue_ids or time_allowed.",
+							Type:        schema.TypeList,
+							Computed:    true,
+							Elem:        &schema.Schema{Type: schema.TypeString},
+						},
+						"unmatched_conditions": {
+							Description: "Conditions of the policy that the conversation does not match.",
+							Type:        schema.TypeList,
+							Computed:    true,
+							Elem:        &schema.Schema{Type: schema.TypeString},
+						},
+						"fired_actions": {
+							Description: "Actions of the policy that fire for the conversation, such as retain_recording, assign_evaluations or retention_duration. Empty when the conversation does not match.",
+							Type:        schema.TypeList,
+							Computed:    true,
+							Elem:        &schema.Schema{Type: schema.TypeString},
+						},
+						"delete_recording": {
+							Description: "True if the recording of the conversation is deleted, either because always_delete is set, or because delete_recording is set and retain_recording is not.",
+							Type:        schema.TypeBool,
+							Computed:    true,
+						},
+						"archive_after_days": {
+							Description: "Days after which the recording is archived by the retention_duration action. 0 when it is not archived.",
+							Type:        schema.TypeInt,
+							Computed:    true,
+						},
+						"delete_after_days": {
+							Description: "Days after which the recording is deleted by the retention_duration action. 0 when it is not deleted.",
+							Type:        schema.TypeInt,
+							Computed:    true,
+						},
+						"evaluation_form_ids": {
+							Description: "Evaluation forms assigned by the assign_evaluations, assign_metered_evaluations and assign_metered_assignment_by_agent actions.",
+							Type:        schema.TypeList,
+							Computed:    true,
+							Elem:        &schema.Schema{Type: schema.TypeString},
+						},
+						"calibration_form_ids": {
+							Description: "Evaluation forms assigned by the assign_calibrations action.",
+							Type:        schema.TypeList,
+							Computed:    true,
+							Elem:        &schema.Schema{Type: schema.TypeString},
+						},
+						"survey_form_names": {
+							Description: "Survey forms sent by the assign_surveys action.",
+							Type:        schema.TypeList,
+							Computed:    true,
+							Elem:        &schema.Schema{Type: schema.TypeString},
+						},
+					},
+				},
+			},
+		},
+	}
+}
@@ -165,7 +165,11 @@ type ResourceExporter struct {
 	FilterResource func(resourceIdMetaMap ResourceIDMetaMap, resourceType string, filter []string) ResourceIDMetaMap
 	// Attributes that are mentioned with custom exports like e164 numbers,rrule  should be ensured to export in the correct format (remove hyphens, whitespace, etc.)
 	CustomValidateExports map[string][]string
-	mutex                 sync.RWMutex
+
+	// OptIn exporters are only used when their resource type is named in include_filter_resources or resource_types.
+	// Set it for resources which manage objects that another exporter already exports, e.g. genesyscloud_routing_queue_members
+	OptIn bool
+	mutex sync.RWMutex
 }
 
 func (r *ResourceExporter) LoadSanitizedResourceMap(ctx context.Context, resourceType string, filter []string) diag.Diagnostics {
@@ -189,6 +193,13 @@ func (r *ResourceExporter) LoadSanitizedResourceMap(ctx context.Context, resourc
 	return nil
 }
 
+// RestoreSanitizedResourceMap sets a resource map that was already loaded and sanitized, for example by a previous export
+func (r *ResourceExporter) RestoreSanitizedResourceMap(result ResourceIDMetaMap) {
+	r.mutex.Lock()
+	r.SanitizedResourceMap = result
+	r.mutex.Unlock()
+}
+
 func (r *ResourceExporter) GetRefAttrSettings(attribute string) *RefAttrSettings {
 	if r.RefAttrs == nil {
 		return nil
@@ -38,6 +38,8 @@ func (r *registerTestInstance) registerTestResources() {
 	defer r.resourceMapMutex.Unlock()
 
 	providerResources[ResourceType] = ResourceRoutingQueue()
+	providerResources[ResourceTypeQueueMember] = ResourceRoutingQueueMember()
+	providerResources[ResourceTypeQueueMembers] = ResourceRoutingQueueMembers()
 	providerResources[user.ResourceType] = user.ResourceUser()
 	providerResources[routingSkill.ResourceType] = routingSkill.ResourceRoutingSkill()
 	providerResources[group.ResourceType] = group.ResourceGroup()
@@ -133,18 +133,19 @@ func createRoutingQueue(ctx context.Context, d *schema.ResourceData, meta interf
 
 	d.SetId(*queue.Id)
 
-	diagErr := updateQueueMembers(d, sdkConfig)
-	if diagErr != nil {
-		return diagErr
+	// Conflicts with the queue member resources are returned as warnings
+	memberWarnings := updateQueueMembers(d, sdkConfig)
+	if memberWarnings.HasError() {
+		return memberWarnings
 	}
 
-	diagErr = updateQueueWrapupCodes(d, sdkConfig)
+	diagErr := updateQueueWrapupCodes(d, sdkConfig)
 	if diagErr != nil {
 		return diagErr
 	}
 
 	log.Printf("Created Routing Queue %s", d.Id())
-	return readRoutingQueue(ctx, d, meta)
+	return append(memberWarnings, readRoutingQueue(ctx, d, meta)...)
 }
 
 func readRoutingQueue(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
@@ -346,9 +347,10 @@ func updateRoutingQueue(ctx context.Context, d *schema.ResourceData, meta interf
 		return diagErr
 	}
 
-	diagErr = updateQueueMembers(d, sdkConfig)
-	if diagErr != nil {
-		return diagErr
+	// Conflicts with the queue member resources are returned as warnings
+	memberWarnings := updateQueueMembers(d, sdkConfig)
+	if memberWarnings.HasError() {
+		return memberWarnings
 	}
 
 	diagErr = updateQueueWrapupCodes(d, sdkConfig)
@@ -357,7 +359,7 @@ func updateRoutingQueue(ctx context.Context, d *schema.ResourceData, meta interf
 	}
 
 	log.Printf("Updated queue %s", *updateQueue.Name)
-	return readRoutingQueue(ctx, d, meta)
+	return append(memberWarnings, readRoutingQueue(ctx, d, meta)...)
 }
 
 /*
@@ -0,0 +1,354 @@
+package routing_queue
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+	"terraform-provider-genesyscloud/genesyscloud/provider"
+	resourceExporter "terraform-provider-genesyscloud/genesyscloud/resource_exporter"
+	"terraform-provider-genesyscloud/genesyscloud/util"
+	"terraform-provider-genesyscloud/genesyscloud/util/lists"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/retry"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/mypurecloud/platform-client-sdk-go/v149/platformclientv2"
+)
+
+/*
+The resource_genesyscloud_routing_queue_member.go file contains the core logic of the genesyscloud_routing_queue_member and
+genesyscloud_routing_queue_members resources. Members are added and removed in batches with postRoutingQueueMembers and ring
+numbers are updated with updateQueueUserRingNum, the same as for the members block of genesyscloud_routing_queue.
+*/
+
+// The ID of a genesyscloud_routing_queue_member is the queue ID and the user ID separated by a comma
+func buildQueueMemberId(queueId, userId string) string {
+	return fmt.Sprintf("%s,%s", queueId, userId)
+}
+
+func parseQueueMemberId(id string) (queueId string, userId string, err error) {
+	parts := strings.Split(id, ",")
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return "", "", fmt.Errorf("invalid %s ID %s. Expected <queue_id>,<user_id>", ResourceTypeQueueMember, id)
+	}
+	return parts[0], parts[1], nil
+}
+
+func getAllRoutingQueueMembers(ctx context.Context, clientConfig *platformclientv2.Configuration) (resourceExporter.ResourceIDMetaMap, diag.Diagnostics) {
+	resources := make(resourceExporter.ResourceIDMetaMap)
+	proxy := GetRoutingQueueProxy(clientConfig)
+
+	queues, resp, err := proxy.GetAllRoutingQueues(ctx, "")
+	if err != nil {
+		return nil, util.BuildAPIDiagnosticError(ResourceTypeQueueMembers, fmt.Sprintf("failed to get routing queues: %s", err), resp)
+	}
+	if queues == nil {
+		return resources, nil
+	}
+
+	for _, queue := range *queues {
+		if queue.MemberCount == nil || *queue.MemberCount == 0 {
+			continue
+		}
+		// The member count includes the users added through groups, which are not managed by this resource
+		hasUserMembers, diagErr := queueHasUserMembers(*queue.Id, clientConfig)
+		if diagErr != nil {
+			return nil, diagErr
+		}
+		if !hasUserMembers {
+			log.Printf("Skipping queue %s as it has no user members", *queue.Id)
+			continue
+		}
+		resources[*queue.Id] = &resourceExporter.ResourceMeta{BlockLabel: *queue.Name + "_members"}
+	}
+	return resources, nil
+}
+
+// queueHasUserMembers returns true when users are members of the queue directly rather than through a group
+func queueHasUserMembers(queueId string, sdkConfig *platformclientv2.Configuration) (bool, diag.Diagnostics) {
+	users, resp, err := sdkGetRoutingQueueMembers(queueId, "user", 1, 1, sdkConfig)
+	if err != nil {
+		return false, util.BuildAPIDiagnosticError(ResourceTypeQueueMembers, fmt.Sprintf("failed to get user members of queue %s: %s", queueId, err), resp)
+	}
+	return users != nil && users.Entities != nil && len(*users.Entities) > 0, nil
+}
+
+func createRoutingQueueMember(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	sdkConfig := meta.(*provider.ProviderMeta).ClientConfig
+	proxy := GetRoutingQueueProxy(sdkConfig)
+	queueId := d.Get("queue_id").(string)
+	userId := d.Get("user_id").(string)
+	ringNum := d.Get("ring_num").(int)
+
+	if err := checkUserMembership(queueId, []string{userId}, sdkConfig); err != nil {
+		return util.BuildDiagnosticError(ResourceTypeQueueMember, "failed to add queue member", err)
+	}
+
+	log.Printf("Adding user %s to queue %s", userId, queueId)
+	if diagErr := postRoutingQueueMembers(queueId, []string{userId}, false, proxy); diagErr != nil {
+		return diagErr
+	}
+	if ringNum != 1 {
+		if diagErr := updateQueueUserRingNum(queueId, userId, ringNum, sdkConfig); diagErr != nil {
+			return diagErr
+		}
+	}
+
+	d.SetId(buildQueueMemberId(queueId, userId))
+	log.Printf("Added user %s to queue %s", userId, queueId)
+	return readRoutingQueueMember(ctx, d, meta)
+}
+
+func readRoutingQueueMember(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	sdkConfig := meta.(*provider.ProviderMeta).ClientConfig
+	proxy := GetRoutingQueueProxy(sdkConfig)
+
+	queueId, userId, err := parseQueueMemberId(d.Id())
+	if err != nil {
+		return util.BuildDiagnosticError(ResourceTypeQueueMember, "failed to read queue member", err)
+	}
+
+	log.Printf("Reading user %s of queue %s", userId, queueId)
+	return util.WithRetriesForRead(ctx, d, func() *retry.RetryError {
+		_, resp, getErr := proxy.getRoutingQueueById(ctx, queueId, false)
+		if getErr != nil {
+			if util.IsStatus404(resp) {
+				return retry.RetryableError(util.BuildWithRetriesApiDiagnosticError(ResourceTypeQueueMember, fmt.Sprintf("failed to read queue %s | error: %s", queueId, getErr), resp))
+			}
+			return retry.NonRetryableError(util.BuildWithRetriesApiDiagnosticError(ResourceTypeQueueMember, fmt.Sprintf("failed to read queue %s | error: %s", queueId, getErr), resp))
+		}
+
+		_, ringNums, diagErr := getExistingUsersAndRingNums(queueId, sdkConfig)
+		if diagErr != nil {
+			return retry.NonRetryableError(fmt.Errorf("%v", diagErr))
+		}
+
+		ringNum, ok := ringNums[userId]
+		if !ok {
+			log.Printf("User %s is no longer a member of queue %s", userId, queueId)
+			d.SetId("")
+			return nil
+		}
+
+		_ = d.Set("queue_id", queueId)
+		_ = d.Set("user_id", userId)
+		_ = d.Set("ring_num", ringNum)
+
+		log.Printf("Read user %s of queue %s", userId, queueId)
+		return nil
+	})
+}
+
+func updateRoutingQueueMember(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	sdkConfig := meta.(*provider.ProviderMeta).ClientConfig
+	queueId := d.Get("queue_id").(string)
+	userId := d.Get("user_id").(string)
+
+	if d.HasChange("ring_num") {
+		if diagErr := updateQueueUserRingNum(queueId, userId, d.Get("ring_num").(int), sdkConfig); diagErr != nil {
+			return diagErr
+		}
+	}
+	return readRoutingQueueMember(ctx, d, meta)
+}
+
+func deleteRoutingQueueMember(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	sdkConfig := meta.(*provider.ProviderMeta).ClientConfig
+	proxy := GetRoutingQueueProxy(sdkConfig)
+	queueId := d.Get("queue_id").(string)
+	userId := d.Get("user_id").(string)
+
+	// The member is removed along with the queue
+	if _, resp, err := proxy.getRoutingQueueById(ctx, queueId, false); err != nil {
+		if util.IsStatus404(resp) {
+			log.Printf("Queue %s of member %s already deleted", queueId, userId)
+			return nil
+		}
+		return util.BuildAPIDiagnosticError(ResourceTypeQueueMember, fmt.Sprintf("failed to read queue %s | error: %s", queueId, err), resp)
+	}
+
+	log.Printf("Removing user %s from queue %s", userId, queueId)
+	if diagErr := postRoutingQueueMembers(queueId, []string{userId}, true, proxy); diagErr != nil {
+		return diagErr
+	}
+	log.Printf("Removed user %s from queue %s", userId, queueId)
+	return nil
+}
+
+func createRoutingQueueMembers(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	queueId := d.Get("queue_id").(string)
+	d.SetId(queueId)
+
+	newUserIds, newUserRingNums := getNewUsersAndRingNums(d.Get("members").(*schema.Set))
+	if diagErr := syncQueueMembers(queueId, nil, newUserIds, newUserRingNums, d.Get("authoritative").(bool), meta); diagErr != nil {
+		d.SetId("")
+		return diagErr
+	}
+	return readRoutingQueueMembers(ctx, d, meta)
+}
+
+func readRoutingQueueMembers(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	sdkConfig := meta.(*provider.ProviderMeta).ClientConfig
+	proxy := GetRoutingQueueProxy(sdkConfig)
+	queueId := d.Id()
+
+	log.Printf("Reading members of queue %s", queueId)
+	return util.WithRetriesForRead(ctx, d, func() *retry.RetryError {
+		_, resp, getErr := proxy.getRoutingQueueById(ctx, queueId, false)
+		if getErr != nil {
+			if util.IsStatus404(resp) {
+				return retry.RetryableError(util.BuildWithRetriesApiDiagnosticError(ResourceTypeQueueMembers, fmt.Sprintf("failed to read queue %s | error: %s", queueId, getErr), resp))
+			}
+			return retry.NonRetryableError(util.BuildWithRetriesApiDiagnosticError(ResourceTypeQueueMembers, fmt.Sprintf("failed to read queue %s | error: %s", queueId, getErr), resp))
+		}
+
+		existingUserIds, existingRingNums, diagErr := getExistingUsersAndRingNums(queueId, sdkConfig)
+		if diagErr != nil {
+			return retry.NonRetryableError(fmt.Errorf("%v", diagErr))
+		}
+
+		managedUserIds, _ := getNewUsersAndRingNums(d.Get("members").(*schema.Set))
+		userIds := queueMembersInState(existingUserIds, managedUserIds, d.Get("authoritative").(bool))
+
+		_ = d.Set("queue_id", queueId)
+		_ = d.Set("members", flattenQueueMemberRingNums(userIds, existingRingNums))
+
+		log.Printf("Read members of queue %s", queueId)
+		return nil
+	})
+}
+
+func updateRoutingQueueMembersResource(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	queueId := d.Id()
+
+	oldMembers, newMembers := d.GetChange("members")
+	managedUserIds, _ := getNewUsersAndRingNums(oldMembers.(*schema.Set))
+	newUserIds, newUserRingNums := getNewUsersAndRingNums(newMembers.(*schema.Set))
+
+	if diagErr := syncQueueMembers(queueId, managedUserIds, newUserIds, newUserRingNums, d.Get("authoritative").(bool), meta); diagErr != nil {
+		return diagErr
+	}
+	return readRoutingQueueMembers(ctx, d, meta)
+}
+
+func deleteRoutingQueueMembers(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	sdkConfig := meta.(*provider.ProviderMeta).ClientConfig
+	proxy := GetRoutingQueueProxy(sdkConfig)
+	queueId := d.Id()
+
+	// The members are removed along with the queue
+	if _, resp, err := proxy.getRoutingQueueById(ctx, queueId, false); err != nil {
+		if util.IsStatus404(resp) {
+			log.Printf("Queue %s already deleted", queueId)
+			return nil
+		}
+		return util.BuildAPIDiagnosticError(ResourceTypeQueueMembers, fmt.Sprintf("failed to read queue %s | error: %s", queueId, err), resp)
+	}
+
+	managedUserIds, _ := getNewUsersAndRingNums(d.Get("members").(*schema.Set))
+	existingUserIds, _, diagErr := getExistingUsersAndRingNums(queueId, sdkConfig)
+	if diagErr != nil {
+		return diagErr
+	}
+
+	usersToRemove := lists.Intersection(existingUserIds, managedUserIds)
+	log.Printf("Removing %d members from queue %s", len(usersToRemove), queueId)
+	if diagErr := postRoutingQueueMembers(queueId, usersToRemove, true, proxy); diagErr != nil {
+		return diagErr
+	}
+	log.Printf("Removed members from queue %s", queueId)
+	return nil
+}
+
+// syncQueueMembers adds the new users to the queue, removes the users which are no longer declared and updates ring numbers.
+// managedUserIds are the users declared before the change.
+func syncQueueMembers(queueId string, managedUserIds, newUserIds []string, newUserRingNums map[string]int, authoritative bool, meta interface{}) diag.Diagnostics {
+	sdkConfig := meta.(*provider.ProviderMeta).ClientConfig
+	proxy := GetRoutingQueueProxy(sdkConfig)
+
+	existingUserIds, existingRingNums, diagErr := getExistingUsersAndRingNums(queueId, sdkConfig)
+	if diagErr != nil {
+		return diagErr
+	}
+
+	usersToAdd, usersToRemove := queueMembershipChanges(existingUserIds, managedUserIds, newUserIds, authoritative)
+
+	if err := checkUserMembership(queueId, usersToAdd, sdkConfig); err != nil {
+		return util.BuildDiagnosticError(ResourceTypeQueueMembers, "failed to update queue members", err)
+	}
+
+	log.Printf("Updating members of queue %s: adding %d, removing %d", queueId, len(usersToAdd), len(usersToRemove))
+	if diagErr := postRoutingQueueMembers(queueId, usersToRemove, true, proxy); diagErr != nil {
+		return diagErr
+	}
+	if diagErr := postRoutingQueueMembers(queueId, usersToAdd, false, proxy); diagErr != nil {
+		return diagErr
+	}
+	if diagErr := updateRingNumbers(queueId, newUserRingNums, existingRingNums, sdkConfig); diagErr != nil {
+		return diagErr
+	}
+	log.Printf("Updated members of queue %s", queueId)
+	return nil
+}
+
+// queueMembershipChanges returns the users to add to and remove from a queue. A non authoritative resource only removes users
+// which it declared before (managedUserIds) while an authoritative resource removes every user which is not declared.
+func queueMembershipChanges(existingUserIds, managedUserIds, newUserIds []string, authoritative bool) (usersToAdd []string, usersToRemove []string) {
+	usersToAdd = lists.SliceDifference(newUserIds, existingUserIds)
+	if authoritative {
+		usersToRemove = lists.SliceDifference(existingUserIds, newUserIds)
+	} else {
+		usersToRemove = lists.SliceDifference(lists.Intersection(existingUserIds, managedUserIds), newUserIds)
+	}
+	return usersToAdd, usersToRemove
+}
+
+// queueMembersInState returns the users read into the state. A non authoritative resource only reads the users it
+// declares (managedUserIds) while an authoritative resource reads every user member of the queue.
+func queueMembersInState(existingUserIds, managedUserIds []string, authoritative bool) []string {
+	if authoritative {
+		return existingUserIds
+	}
+	return lists.Intersection(existingUserIds, managedUserIds)
+}
+
+// importRoutingQueueMembers imports every user member of the queue, so the imported resource is authoritative
+func importRoutingQueueMembers(_ context.Context, d *schema.ResourceData, _ interface{}) ([]*schema.ResourceData, error) {
+	_ = d.Set("authoritative", true)
+	return []*schema.ResourceData{d}, nil
+}
+
+func flattenQueueMemberRingNums(userIds []string, ringNums map[string]int) *schema.Set {
+	memberSet := schema.NewSet(schema.HashResource(queueMemberResource), []interface{}{})
+	for _, userId := range userIds {
+		memberSet.Add(map[string]interface{}{
+			"user_id":  userId,
+			"ring_num": ringNums[userId],
+		})
+	}
+	return memberSet
+}
+
+// queueMembersConflictWarning warns when the authoritative members block of a queue is about to remove users which were not added
+// by the queue itself. This happens when the queue declares members while genesyscloud_routing_queue_member or
+// genesyscloud_routing_queue_members resources manage the same queue.
+func queueMembersConflictWarning(d *schema.ResourceData, existingUserIds, newUserIds []string) diag.Diagnostics {
+	oldMembers, _ := d.GetChange("members")
+	stateUserIds := make([]string, 0)
+	if oldSet, ok := oldMembers.(*schema.Set); ok {
+		stateUserIds, _ = getNewUsersAndRingNums(oldSet)
+	}
+
+	externalUserIds := lists.SliceDifference(lists.SliceDifference(existingUserIds, newUserIds), stateUserIds)
+	if len(externalUserIds) == 0 {
+		return nil
+	}
+	return diag.Diagnostics{{
+		Severity: diag.Warning,
+		Summary:  fmt.Sprintf("Queue %s removes %d member(s) which were not added by its members attribute", d.Id(), len(externalUserIds)),
+		Detail: fmt.Sprintf("The members attribute of %s is authoritative and conflicts with %s and %s resources managing the same queue. "+
+			"Remove the members attribute from the queue to manage membership with those resources. Removed users: %s",
+			ResourceType, ResourceTypeQueueMember, ResourceTypeQueueMembers, strings.Join(externalUserIds, ", ")),
+	}}
+}
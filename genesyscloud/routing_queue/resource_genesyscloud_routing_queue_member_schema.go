@@ -0,0 +1,112 @@
+package routing_queue
+
+import (
+	"terraform-provider-genesyscloud/genesyscloud/provider"
+	resourceExporter "terraform-provider-genesyscloud/genesyscloud/resource_exporter"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+/*
+The resource_genesyscloud_routing_queue_member_schema.go file contains the schemas of the genesyscloud_routing_queue_member and
+genesyscloud_routing_queue_members resources. Both manage the user members of a queue outside of the members block of
+genesyscloud_routing_queue so that queue configuration and queue staffing can be owned by different configurations.
+*/
+
+const (
+	ResourceTypeQueueMember  = "genesyscloud_routing_queue_member"
+	ResourceTypeQueueMembers = "genesyscloud_routing_queue_members"
+)
+
+func ResourceRoutingQueueMember() *schema.Resource {
+	return &schema.Resource{
+		Description: `Genesys Cloud Routing Queue Member. Manages the membership of a single user in a queue without affecting the other members of the queue.
+Do not use this resource for a queue which declares the members attribute in genesyscloud_routing_queue, as that attribute is authoritative and will remove the user.`,
+
+		CreateContext: provider.CreateWithPooledClient(createRoutingQueueMember),
+		ReadContext:   provider.ReadWithPooledClient(readRoutingQueueMember),
+		UpdateContext: provider.UpdateWithPooledClient(updateRoutingQueueMember),
+		DeleteContext: provider.DeleteWithPooledClient(deleteRoutingQueueMember),
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+		SchemaVersion: 1,
+		Schema: map[string]*schema.Schema{
+			"queue_id": {
+				Description: "ID of the queue.",
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+			},
+			"user_id": {
+				Description: "ID of the user to add to the queue. If the user is already assigned to the queue via a group, an error will be thrown.",
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+			},
+			"ring_num": {
+				Description:  "Ring number between 1 and 6 for this user in the queue.",
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      1,
+				ValidateFunc: validation.IntBetween(1, 6),
+			},
+		},
+	}
+}
+
+func ResourceRoutingQueueMembers() *schema.Resource {
+	return &schema.Resource{
+		Description: `Genesys Cloud Routing Queue Members. Manages a set of user members of a queue.
+When authoritative is false only the members declared by this resource are added, updated and removed. When authoritative is true any user member of the queue not declared by this resource is removed.
+An imported or exported resource is authoritative, as it holds every user member of the queue.
+Do not use this resource for a queue which declares the members attribute in genesyscloud_routing_queue.
+This resource is only exported when it is named in include_filter_resources or resource_types of genesyscloud_tf_export, and only for queues with user members. Add genesyscloud_routing_queue.members to exclude_attributes so the exported queues do not declare the same members.`,
+
+		CreateContext: provider.CreateWithPooledClient(createRoutingQueueMembers),
+		ReadContext:   provider.ReadWithPooledClient(readRoutingQueueMembers),
+		UpdateContext: provider.UpdateWithPooledClient(updateRoutingQueueMembersResource),
+		DeleteContext: provider.DeleteWithPooledClient(deleteRoutingQueueMembers),
+		Importer: &schema.ResourceImporter{
+			StateContext: importRoutingQueueMembers,
+		},
+		SchemaVersion: 1,
+		Schema: map[string]*schema.Schema{
+			"queue_id": {
+				Description: "ID of the queue.",
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+			},
+			"authoritative": {
+				Description: "If true, user members of the queue which are not declared in this resource are removed. If false, only the members declared in this resource are managed.",
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+			},
+			"members": {
+				Description: "Users in the queue. If a user is already assigned to this queue via a group, attempting to assign them using this field will cause an error to be thrown.",
+				Type:        schema.TypeSet,
+				Required:    true,
+				ConfigMode:  schema.SchemaConfigModeAttr,
+				Elem:        queueMemberResource,
+			},
+		},
+	}
+}
+
+func RoutingQueueMembersExporter() *resourceExporter.ResourceExporter {
+	return &resourceExporter.ResourceExporter{
+		GetResourcesFunc: provider.GetAllWithPooledClient(getAllRoutingQueueMembers),
+		// Queue membership is exported with the members attribute of genesyscloud_routing_queue unless this resource is included
+		OptIn: true,
+		RefAttrs: map[string]*resourceExporter.RefAttrSettings{
+			"queue_id":        {RefType: ResourceType},
+			"members.user_id": {RefType: "genesyscloud_user"},
+		},
+		RemoveIfMissing: map[string][]string{
+			"members": {"user_id"},
+		},
+	}
+}
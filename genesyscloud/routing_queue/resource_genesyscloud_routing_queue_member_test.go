@@ -0,0 +1,143 @@
+package routing_queue
+
+import (
+	"terraform-provider-genesyscloud/genesyscloud/provider"
+	"terraform-provider-genesyscloud/genesyscloud/user"
+	"terraform-provider-genesyscloud/genesyscloud/util"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccResourceRoutingQueueMember(t *testing.T) {
+	var (
+		queueResourceLabel  = "test-queue-member"
+		queueName           = "Terraform Test Queue Member-" + uuid.NewString()
+		userResourceLabel1  = "test-queue-member-user1"
+		userResourceLabel2  = "test-queue-member-user2"
+		userEmail1          = "terraform1-" + uuid.NewString() + "@queuemember.com"
+		userEmail2          = "terraform2-" + uuid.NewString() + "@queuemember.com"
+		memberResourceLabel = "test-member"
+		membersLabel        = "test-members"
+	)
+
+	baseConfig := user.GenerateBasicUserResource(userResourceLabel1, userEmail1, "Terraform Queue Member 1") +
+		user.GenerateBasicUserResource(userResourceLabel2, userEmail2, "Terraform Queue Member 2") +
+		GenerateRoutingQueueResourceBasic(queueResourceLabel, queueName)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { util.TestAccPreCheck(t) },
+		ProviderFactories: provider.GetProviderFactories(providerResources, providerDataSources),
+		Steps: []resource.TestStep{
+			{
+				// Both resources manage a different user of the same queue
+				Config: baseConfig + GenerateRoutingQueueMemberResource(
+					memberResourceLabel,
+					"genesyscloud_routing_queue."+queueResourceLabel+".id",
+					"genesyscloud_user."+userResourceLabel1+".id",
+					"2",
+				) + GenerateRoutingQueueMembersResource(
+					membersLabel,
+					"genesyscloud_routing_queue."+queueResourceLabel+".id",
+					util.FalseValue,
+					GenerateMemberBlock("genesyscloud_user."+userResourceLabel2+".id", "3"),
+				),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(ResourceTypeQueueMember+"."+memberResourceLabel, "ring_num", "2"),
+					resource.TestCheckResourceAttr(ResourceTypeQueueMembers+"."+membersLabel, "members.#", "1"),
+					resource.TestCheckResourceAttrPair(ResourceTypeQueueMembers+"."+membersLabel, "members.0.user_id", "genesyscloud_user."+userResourceLabel2, "id"),
+					resource.TestCheckResourceAttr(ResourceTypeQueueMembers+"."+membersLabel, "members.0.ring_num", "3"),
+				),
+			},
+			{
+				PreConfig: func() {
+					// Wait for the members to be indexed
+					time.Sleep(30 * time.Second)
+				},
+				// Update the ring number of the single member
+				Config: baseConfig + GenerateRoutingQueueMemberResource(
+					memberResourceLabel,
+					"genesyscloud_routing_queue."+queueResourceLabel+".id",
+					"genesyscloud_user."+userResourceLabel1+".id",
+					"4",
+				) + GenerateRoutingQueueMembersResource(
+					membersLabel,
+					"genesyscloud_routing_queue."+queueResourceLabel+".id",
+					util.FalseValue,
+					GenerateMemberBlock("genesyscloud_user."+userResourceLabel2+".id", "3"),
+				),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(ResourceTypeQueueMember+"."+memberResourceLabel, "ring_num", "4"),
+					resource.TestCheckResourceAttr(ResourceTypeQueueMembers+"."+membersLabel, "members.#", "1"),
+				),
+			},
+			{
+				// Import/Read
+				ResourceName:      ResourceTypeQueueMember + "." + memberResourceLabel,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+		CheckDestroy: testVerifyQueuesDestroyed,
+	})
+}
+
+func TestAccResourceRoutingQueueMembersAuthoritative(t *testing.T) {
+	var (
+		queueResourceLabel = "test-queue-members-authoritative"
+		queueName          = "Terraform Test Queue Members-" + uuid.NewString()
+		userResourceLabel1 = "test-queue-members-user1"
+		userResourceLabel2 = "test-queue-members-user2"
+		userEmail1         = "terraform1-" + uuid.NewString() + "@queuemembers.com"
+		userEmail2         = "terraform2-" + uuid.NewString() + "@queuemembers.com"
+		membersLabel       = "test-members"
+	)
+
+	config := user.GenerateBasicUserResource(userResourceLabel1, userEmail1, "Terraform Queue Members 1") +
+		user.GenerateBasicUserResource(userResourceLabel2, userEmail2, "Terraform Queue Members 2") +
+		GenerateRoutingQueueResourceBasic(queueResourceLabel, queueName) +
+		GenerateRoutingQueueMembersResource(
+			membersLabel,
+			"genesyscloud_routing_queue."+queueResourceLabel+".id",
+			util.TrueValue,
+			GenerateMemberBlock("genesyscloud_user."+userResourceLabel1+".id", "1"),
+		)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { util.TestAccPreCheck(t) },
+		ProviderFactories: provider.GetProviderFactories(providerResources, providerDataSources),
+		Steps: []resource.TestStep{
+			{
+				// Add a user outside of the resource after creating it
+				Config:             config,
+				ExpectNonEmptyPlan: true,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(ResourceTypeQueueMembers+"."+membersLabel, "members.#", "1"),
+					addMemberToQueue("genesyscloud_routing_queue."+queueResourceLabel, "genesyscloud_user."+userResourceLabel2),
+				),
+			},
+			{
+				// The authoritative resource removes the user which was not declared
+				PreConfig: func() {
+					// Wait for the members to be indexed
+					time.Sleep(30 * time.Second)
+				},
+				Config: config,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(ResourceTypeQueueMembers+"."+membersLabel, "members.#", "1"),
+					resource.TestCheckResourceAttrPair(ResourceTypeQueueMembers+"."+membersLabel, "members.0.user_id", "genesyscloud_user."+userResourceLabel1, "id"),
+				),
+			},
+			{
+				// Import/Read
+				ResourceName:            ResourceTypeQueueMembers + "." + membersLabel,
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"authoritative"},
+			},
+		},
+		CheckDestroy: testVerifyQueuesDestroyed,
+	})
+}
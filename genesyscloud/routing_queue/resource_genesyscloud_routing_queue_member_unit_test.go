@@ -0,0 +1,81 @@
+package routing_queue
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUnitQueueMemberId(t *testing.T) {
+	id := buildQueueMemberId("queue-id", "user-id")
+	queueId, userId, err := parseQueueMemberId(id)
+	assert.NoError(t, err)
+	assert.Equal(t, "queue-id", queueId)
+	assert.Equal(t, "user-id", userId)
+
+	for _, invalid := range []string{"queue-id", "queue-id,", ",user-id", "a,b,c"} {
+		_, _, err := parseQueueMemberId(invalid)
+		assert.Error(t, err, invalid)
+	}
+}
+
+func TestUnitQueueMembershipChanges(t *testing.T) {
+	existing := []string{"user-1", "user-2", "user-3"}
+	managed := []string{"user-1", "user-2"}
+	declared := []string{"user-2", "user-4"}
+
+	usersToAdd, usersToRemove := queueMembershipChanges(existing, managed, declared, false)
+	assert.Equal(t, []string{"user-4"}, usersToAdd)
+	assert.Equal(t, []string{"user-1"}, usersToRemove)
+
+	usersToAdd, usersToRemove = queueMembershipChanges(existing, managed, declared, true)
+	assert.Equal(t, []string{"user-4"}, usersToAdd)
+	assert.Equal(t, []string{"user-1", "user-3"}, usersToRemove)
+
+	// Users declared before but already removed from the queue are not removed again
+	_, usersToRemove = queueMembershipChanges([]string{"user-2"}, managed, declared, false)
+	assert.Empty(t, usersToRemove)
+}
+
+func TestUnitQueueMembersInState(t *testing.T) {
+	existing := []string{"user-1", "user-2", "user-3"}
+
+	// A non authoritative resource only reads the users it declares, even when it declares none
+	assert.Equal(t, []string{"user-2"}, queueMembersInState(existing, []string{"user-2", "user-4"}, false))
+	assert.Empty(t, queueMembersInState(existing, nil, false))
+
+	assert.Equal(t, existing, queueMembersInState(existing, nil, true))
+}
+
+func TestUnitImportRoutingQueueMembers(t *testing.T) {
+	d := schema.TestResourceDataRaw(t, ResourceRoutingQueueMembers().Schema, map[string]interface{}{})
+	d.SetId("queue-id")
+
+	imported, err := importRoutingQueueMembers(context.Background(), d, nil)
+	assert.NoError(t, err)
+	if assert.Len(t, imported, 1) {
+		assert.Equal(t, "queue-id", imported[0].Id())
+		assert.True(t, imported[0].Get("authoritative").(bool))
+	}
+}
+
+func TestUnitQueueMembersConflictWarning(t *testing.T) {
+	d := schema.TestResourceDataRaw(t, ResourceRoutingQueue().Schema, map[string]interface{}{
+		"name": "queue",
+		"members": []interface{}{
+			map[string]interface{}{"user_id": "user-1", "ring_num": 1},
+		},
+	})
+	d.SetId("queue-id")
+
+	assert.Nil(t, queueMembersConflictWarning(d, []string{"user-1"}, []string{"user-1"}))
+
+	warnings := queueMembersConflictWarning(d, []string{"user-1", "user-2"}, []string{"user-1"})
+	assert.Len(t, warnings, 1)
+	assert.Equal(t, diag.Warning, warnings[0].Severity)
+	assert.Contains(t, warnings[0].Detail, "user-2")
+	assert.False(t, warnings.HasError())
+}
@@ -86,39 +86,44 @@ func updateQueueMembers(d *schema.ResourceData, sdkConfig *platformclientv2.Conf
 		return nil
 	}
 
-	membersSet, ok := d.Get("members").(*schema.Set)
-	if !ok || membersSet.Len() == 0 {
-		if err := removeAllExistingUserMembersFromQueue(d.Id(), sdkConfig); err != nil {
-			return diag.FromErr(err)
-		}
-		return nil
-	}
-
 	log.Printf("Updating members for Queue %s", d.Get("name"))
 
 	// Get new and Existing users and ring nums
-	newUserIds, newUserRingNums := getNewUsersAndRingNums(membersSet)
+	newUserIds, newUserRingNums := getNewUsersAndRingNums(d.Get("members").(*schema.Set))
 	oldUserIds, oldUserRingNums, err := getExistingUsersAndRingNums(d.Id(), sdkConfig)
 	if err != nil {
 		return err
 	}
 
+	// The conflict is raised before any member is removed so it is reported even if the update fails
+	warnings := queueMembersConflictWarning(d, oldUserIds, newUserIds)
+	for _, warning := range warnings {
+		log.Printf("%s: %s", warning.Summary, warning.Detail)
+	}
+
+	if len(newUserIds) == 0 {
+		if err := removeAllExistingUserMembersFromQueue(d.Id(), sdkConfig); err != nil {
+			return append(warnings, diag.FromErr(err)...)
+		}
+		return warnings
+	}
+
 	if diagErr := checkUserMembership(d.Id(), newUserIds, sdkConfig); diagErr != nil {
-		return util.BuildDiagnosticError(ResourceType, "failed to update queue member: ", diagErr)
+		return append(warnings, util.BuildDiagnosticError(ResourceType, "failed to update queue member: ", diagErr)...)
 	}
 
 	// Check for members to add or remove
-	if diagErr := addOrRemoveMembers(d.Id(), oldUserIds, newUserIds, proxy); err != nil {
-		return diagErr
+	if diagErr := addOrRemoveMembers(d.Id(), oldUserIds, newUserIds, proxy); diagErr != nil {
+		return append(warnings, diagErr...)
 	}
 
 	// Check for ring numbers to update
 	if diagErr := updateRingNumbers(d.Id(), newUserRingNums, oldUserRingNums, sdkConfig); diagErr != nil {
-		return diagErr
+		return append(warnings, diagErr...)
 	}
 
 	log.Printf("Members updated for Queue %s", d.Get("name"))
-	return nil
+	return warnings
 }
 
 // removeAllExistingUserMembersFromQueue get all existing user members of a given queue and remove them from the queue
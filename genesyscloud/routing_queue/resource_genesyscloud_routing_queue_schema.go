@@ -15,6 +15,10 @@ func SetRegistrar(regInstance registrar.Registrar) {
 	regInstance.RegisterResource(ResourceType, ResourceRoutingQueue())
 	regInstance.RegisterDataSource(ResourceType, DataSourceRoutingQueue())
 	regInstance.RegisterExporter(ResourceType, RoutingQueueExporter())
+
+	regInstance.RegisterResource(ResourceTypeQueueMember, ResourceRoutingQueueMember())
+	regInstance.RegisterResource(ResourceTypeQueueMembers, ResourceRoutingQueueMembers())
+	regInstance.RegisterExporter(ResourceTypeQueueMembers, RoutingQueueMembersExporter())
 }
 
 var (
@@ -525,7 +529,7 @@ func ResourceRoutingQueue() *schema.Resource {
 				},
 			},
 			"members": {
-				Description: "Users in the queue. If not set, this resource will not manage members. If a user is already assigned to this queue via a group, attempting to assign them using this field will cause an error to be thrown.",
+				Description: "Users in the queue. If not set, this resource will not manage members. If a user is already assigned to this queue via a group, attempting to assign them using this field will cause an error to be thrown. This field is authoritative and should not be set when the queue membership is managed with genesyscloud_routing_queue_member or genesyscloud_routing_queue_members.",
 				Type:        schema.TypeSet,
 				Optional:    true,
 				Computed:    true,
@@ -738,6 +738,24 @@ func GenerateRoutingQueueResourceBasic(resourceLabel string, name string, nested
 	`, resourceLabel, name, strings.Join(nestedBlocks, "\n"))
 }
 
+func GenerateRoutingQueueMemberResource(resourceLabel, queueId, userId, ringNum string) string {
+	return fmt.Sprintf(`resource "%s" "%s" {
+		queue_id = %s
+		user_id  = %s
+		ring_num = %s
+	}
+	`, ResourceTypeQueueMember, resourceLabel, queueId, userId, ringNum)
+}
+
+func GenerateRoutingQueueMembersResource(resourceLabel, queueId, authoritative string, memberBlocks ...string) string {
+	return fmt.Sprintf(`resource "%s" "%s" {
+		queue_id      = %s
+		authoritative = %s
+		%s
+	}
+	`, ResourceTypeQueueMembers, resourceLabel, queueId, authoritative, strings.Join(memberBlocks, "\n"))
+}
+
 func GenerateRoutingQueueResource(
 	resourceLabel string,
 	name string,
@@ -40,17 +40,22 @@ func createScript(ctx context.Context, d *schema.ResourceData, meta interface{})
 	sdkConfig := meta.(*provider.ProviderMeta).ClientConfig
 	scriptsProxy := getScriptsProxy(sdkConfig)
 
-	filePath := d.Get("filepath").(string)
 	scriptName := d.Get("script_name").(string)
-	substitutions := d.Get("substitutions").(map[string]interface{})
+
+	upload, err := prepareScriptUpload(d)
+	if err != nil {
+		return util.BuildDiagnosticError(ResourceType, fmt.Sprintf("failed to render script file %s", d.Get("filepath").(string)), err)
+	}
+	defer upload.cleanup()
 
 	log.Printf("Creating script %s", scriptName)
-	scriptId, err := scriptsProxy.createScript(ctx, filePath, scriptName, substitutions)
+	scriptId, err := scriptsProxy.createScript(ctx, upload.filePath, scriptName, upload.substitutions)
 	if err != nil {
 		return diag.FromErr(err)
 	}
 
 	d.SetId(scriptId)
+	_ = d.Set("rendered_content_hash", upload.renderedContentHash)
 
 	log.Printf("Created script %s. ", d.Id())
 	return readScript(ctx, d, meta)
@@ -60,16 +65,24 @@ func updateScript(ctx context.Context, d *schema.ResourceData, meta interface{})
 	sdkConfig := meta.(*provider.ProviderMeta).ClientConfig
 	scriptsProxy := getScriptsProxy(sdkConfig)
 
-	filePath := d.Get("filepath").(string)
 	scriptName := d.Get("script_name").(string)
-	substitutions := d.Get("substitutions").(map[string]interface{})
+
+	upload, err := prepareScriptUpload(d)
+	if err != nil {
+		_ = d.Set("rendered_content_hash", "")
+		return util.BuildDiagnosticError(ResourceType, fmt.Sprintf("failed to render script file %s", d.Get("filepath").(string)), err)
+	}
+	defer upload.cleanup()
 
 	log.Printf("Updating script '%s' %s", scriptName, d.Id())
 
-	scriptId, err := scriptsProxy.updateScript(ctx, filePath, scriptName, d.Id(), substitutions)
+	scriptId, err := scriptsProxy.updateScript(ctx, upload.filePath, scriptName, d.Id(), upload.substitutions)
 	if err != nil {
+		// Clear the hash so that the next apply attempts the update again
+		_ = d.Set("rendered_content_hash", "")
 		return diag.FromErr(err)
 	}
+	_ = d.Set("rendered_content_hash", upload.renderedContentHash)
 	if scriptId != d.Id() {
 		log.Printf("ID of script '%s' changed from '%s' to '%s' after update.", scriptName, d.Id(), scriptId)
 		d.SetId(scriptId)
@@ -0,0 +1,47 @@
+package scripts
+
+import (
+	"os"
+	"path/filepath"
+	"terraform-provider-genesyscloud/genesyscloud/util/templates"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUnitPrepareScriptUpload(t *testing.T) {
+	dir := t.TempDir()
+	filePath := filepath.Join(dir, "script.json")
+	assert.NoError(t, os.WriteFile(filePath, []byte(`{"name": "${script_name}", "queues": ${jsonencode(queues)}, "description": "{{description}}"}`), 0644))
+
+	d := schema.TestResourceDataRaw(t, ResourceScript().Schema, map[string]interface{}{
+		"script_name":   "Script",
+		"filepath":      filePath,
+		"substitutions": map[string]interface{}{"description": "A script"},
+	})
+	upload, err := prepareScriptUpload(d)
+	assert.NoError(t, err)
+	assert.Equal(t, filePath, upload.filePath)
+	assert.Equal(t, map[string]interface{}{"description": "A script"}, upload.substitutions)
+	assert.Empty(t, upload.renderedContentHash)
+	upload.cleanup()
+
+	assert.NoError(t, d.Set("template_engine", templates.EngineHcl))
+	assert.NoError(t, d.Set("template_variables", `{"script_name": "Script", "queues": ["sales"]}`))
+	upload, err = prepareScriptUpload(d)
+	assert.NoError(t, err)
+	defer upload.cleanup()
+
+	expectedContent := `{"name": "Script", "queues": ["sales"], "description": "A script"}`
+	content, err := os.ReadFile(upload.filePath)
+	assert.NoError(t, err)
+	assert.Equal(t, expectedContent, string(content))
+	assert.Equal(t, "script.json", filepath.Base(upload.filePath))
+	assert.Empty(t, upload.substitutions, "substitutions are applied when the file is rendered")
+	assert.Equal(t, templates.HashContent(expectedContent), upload.renderedContentHash)
+
+	upload.cleanup()
+	_, err = os.Stat(upload.filePath)
+	assert.True(t, os.IsNotExist(err))
+}
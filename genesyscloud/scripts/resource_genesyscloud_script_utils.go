@@ -6,11 +6,67 @@ import (
 	"log"
 	"os"
 	"path"
+	"path/filepath"
 	"terraform-provider-genesyscloud/genesyscloud/provider"
 	resourceExporter "terraform-provider-genesyscloud/genesyscloud/resource_exporter"
 	"terraform-provider-genesyscloud/genesyscloud/util/files"
+	"terraform-provider-genesyscloud/genesyscloud/util/templates"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
+// scriptUpload holds the file uploaded for a script and the substitutions left to apply during the upload
+type scriptUpload struct {
+	filePath            string
+	substitutions       map[string]interface{}
+	renderedContentHash string
+	tempDir             string
+}
+
+// prepareScriptUpload renders the script file when a template engine is set. The rendered content is written to a temporary file
+// with the name of the template because the file name is part of the upload. cleanup must be called once the file is uploaded.
+func prepareScriptUpload(d *schema.ResourceData) (*scriptUpload, error) {
+	upload := &scriptUpload{
+		filePath:      d.Get("filepath").(string),
+		substitutions: d.Get("substitutions").(map[string]interface{}),
+	}
+
+	templateEngine := d.Get("template_engine").(string)
+	if templateEngine == "" {
+		return upload, nil
+	}
+
+	content, err := templates.Render(upload.filePath, templateEngine, d.Get("template_variables").(string), upload.substitutions)
+	if err != nil {
+		return nil, err
+	}
+
+	tempDir, err := os.MkdirTemp("", "genesyscloud_script")
+	if err != nil {
+		return nil, fmt.Errorf("failed to create a temporary directory for the rendered script: %w", err)
+	}
+	renderedPath := filepath.Join(tempDir, path.Base(upload.filePath))
+	if err := os.WriteFile(renderedPath, []byte(content), 0600); err != nil {
+		_ = os.RemoveAll(tempDir)
+		return nil, fmt.Errorf("failed to write the rendered script: %w", err)
+	}
+
+	return &scriptUpload{
+		filePath:            renderedPath,
+		renderedContentHash: templates.HashContent(content),
+		tempDir:             tempDir,
+	}, nil
+}
+
+func (u *scriptUpload) cleanup() {
+	if u.tempDir == "" {
+		return
+	}
+	if err := os.RemoveAll(u.tempDir); err != nil {
+		log.Printf("Failed to remove the rendered script %s: %v", u.filePath, err)
+	}
+}
+
 // ScriptResolver is used to download all Genesys Cloud scripts from Genesys Cloud
 func ScriptResolver(scriptId, exportDirectory, subDirectory string, configMap map[string]interface{}, meta interface{}, resource resourceExporter.ResourceInfo) error {
 	sdkConfig := meta.(*provider.ProviderMeta).ClientConfig
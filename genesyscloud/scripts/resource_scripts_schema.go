@@ -1,12 +1,16 @@
 package scripts
 
 import (
+	"strings"
 	"terraform-provider-genesyscloud/genesyscloud/provider"
 	resourceExporter "terraform-provider-genesyscloud/genesyscloud/resource_exporter"
 	registrar "terraform-provider-genesyscloud/genesyscloud/resource_register"
+	"terraform-provider-genesyscloud/genesyscloud/util"
+	"terraform-provider-genesyscloud/genesyscloud/util/templates"
 	"terraform-provider-genesyscloud/genesyscloud/validators"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 /*
@@ -48,6 +52,7 @@ func ResourceScript() *schema.Resource {
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
+		CustomizeDiff: templates.CustomizeRenderedContentHashDiff,
 		SchemaVersion: 1,
 		Schema: map[string]*schema.Schema{
 			"script_name": {
@@ -72,6 +77,24 @@ func ResourceScript() *schema.Resource {
 				Type:        schema.TypeMap,
 				Optional:    true,
 			},
+			"template_engine": {
+				Description:  "Template engine used to render the script file before the substitutions are applied. Valid options: " + strings.Join(templates.Engines, ", ") + ". Go templates reference variables as `{{ .name }}` and include fragments with `{{ include \"path\" }}`. HCL templates reference variables as `${name}` and include fragments with `${include(\"path\")}`. Paths of includes are relative to the file including them. Substitution placeholders like `{{key}}` are kept as is by both engines. If not set, the file is not rendered.",
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.StringInSlice(templates.Engines, false),
+			},
+			"template_variables": {
+				Description:      "JSON encoded object of the variables passed to the template, e.g. `jsonencode({ queues = [\"sales\", \"support\"] })`. Variables keep their JSON types.",
+				Type:             schema.TypeString,
+				Optional:         true,
+				ValidateFunc:     validation.StringIsJSON,
+				DiffSuppressFunc: util.SuppressEquivalentJsonDiffs,
+			},
+			"rendered_content_hash": {
+				Description: "Hash value of the rendered script file content after the substitutions are applied. Changes of the template variables or of included fragments update the script. Empty if no template engine is set.",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
 		},
 	}
 }
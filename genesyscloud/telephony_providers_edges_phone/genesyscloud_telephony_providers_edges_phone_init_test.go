@@ -46,6 +46,7 @@ func (r *registerTestInstance) registerTestResources() {
 	defer r.resourceMapMutex.Unlock()
 
 	providerResources[ResourceType] = ResourcePhone()
+	providerResources[PhonesInventoryResourceType] = ResourcePhonesInventory()
 	providerResources[user.ResourceType] = user.ResourceUser()
 	providerResources[phoneBaseSettings.ResourceType] = phoneBaseSettings.ResourcePhoneBaseSettings()
 	providerResources[location.ResourceType] = location.ResourceLocation()
@@ -44,7 +44,9 @@ type updatePhoneFunc func(ctx context.Context, p *phoneProxy, phoneId string, ph
 type deletePhoneFunc func(ctx context.Context, p *phoneProxy, phoneId string) (response *platformclientv2.APIResponse, err error)
 
 type getPhoneBaseSettingFunc func(ctx context.Context, p *phoneProxy, phoneBaseSettingsId string) (*platformclientv2.Phonebase, *platformclientv2.APIResponse, error)
+type getAllPhoneBaseSettingsFunc func(ctx context.Context, p *phoneProxy) (*[]platformclientv2.Phonebase, *platformclientv2.APIResponse, error)
 type getStationOfUserFunc func(ctx context.Context, p *phoneProxy, userId string) (station *platformclientv2.Station, retryable bool, resp *platformclientv2.APIResponse, err error)
+type getStationOfLineFunc func(ctx context.Context, p *phoneProxy, lineId string) (station *platformclientv2.Station, retryable bool, resp *platformclientv2.APIResponse, err error)
 type unassignUserFromStationFunc func(ctx context.Context, p *phoneProxy, stationId string) (*platformclientv2.APIResponse, error)
 type assignUserToStationFunc func(ctx context.Context, p *phoneProxy, userId string, stationId string) (*platformclientv2.APIResponse, error)
 type assignStationAsDefaultFunc func(ctx context.Context, p *phoneProxy, userId string, stationId string) (*platformclientv2.APIResponse, error)
@@ -65,7 +67,9 @@ type phoneProxy struct {
 	deletePhoneAttr    deletePhoneFunc
 
 	getPhoneBaseSettingAttr     getPhoneBaseSettingFunc
+	getAllPhoneBaseSettingsAttr getAllPhoneBaseSettingsFunc
 	getStationOfUserAttr        getStationOfUserFunc
+	getStationOfLineAttr        getStationOfLineFunc
 	unassignUserFromStationAttr unassignUserFromStationFunc
 	assignUserToStationAttr     assignUserToStationFunc
 	assignStationAsDefaultAttr  assignStationAsDefaultFunc
@@ -93,7 +97,9 @@ func newPhoneProxy(clientConfig *platformclientv2.Configuration) *phoneProxy {
 		deletePhoneAttr:    deletePhoneFn,
 
 		getPhoneBaseSettingAttr:     getPhoneBaseSettingFn,
+		getAllPhoneBaseSettingsAttr: getAllPhoneBaseSettingsFn,
 		getStationOfUserAttr:        getStationOfUserFn,
+		getStationOfLineAttr:        getStationOfLineFn,
 		unassignUserFromStationAttr: unassignUserFromStationFn,
 		assignUserToStationAttr:     assignUserToStationFn,
 		assignStationAsDefaultAttr:  assignStationAsDefaultFn,
@@ -147,11 +153,21 @@ func (p *phoneProxy) getPhoneBaseSetting(ctx context.Context, phoneBaseSettingsI
 	return p.getPhoneBaseSettingAttr(ctx, p, phoneBaseSettingsId)
 }
 
+// getAllPhoneBaseSettings retrieves all Genesys Cloud Phone Base Settings
+func (p *phoneProxy) getAllPhoneBaseSettings(ctx context.Context) (*[]platformclientv2.Phonebase, *platformclientv2.APIResponse, error) {
+	return p.getAllPhoneBaseSettingsAttr(ctx, p)
+}
+
 // getStationOfUser retrieves the station of a user
 func (p *phoneProxy) getStationOfUser(ctx context.Context, userId string) (*platformclientv2.Station, bool, *platformclientv2.APIResponse, error) {
 	return p.getStationOfUserAttr(ctx, p, userId)
 }
 
+// getStationOfLine retrieves the station of a phone line
+func (p *phoneProxy) getStationOfLine(ctx context.Context, lineId string) (*platformclientv2.Station, bool, *platformclientv2.APIResponse, error) {
+	return p.getStationOfLineAttr(ctx, p, lineId)
+}
+
 // unassignUserFromStation unassigns a user from the station
 func (p *phoneProxy) unassignUserFromStation(ctx context.Context, stationId string) (*platformclientv2.APIResponse, error) {
 	return p.unassignUserFromStationAttr(ctx, p, stationId)
@@ -320,6 +336,47 @@ func getStationOfUserFn(ctx context.Context, p *phoneProxy, userId string) (stat
 	return &(*stations.Entities)[0], false, resp, err
 }
 
+// getAllPhoneBaseSettingsFn is an implementation function for retrieving all Genesys Cloud Phone Base Settings
+func getAllPhoneBaseSettingsFn(ctx context.Context, p *phoneProxy) (*[]platformclientv2.Phonebase, *platformclientv2.APIResponse, error) {
+	var allPhoneBaseSettings []platformclientv2.Phonebase
+	const pageSize = 100
+
+	phoneBaseSettings, resp, err := p.edgesApi.GetTelephonyProvidersEdgesPhonebasesettings(pageSize, 1, "", "", nil, "")
+	if err != nil {
+		return nil, resp, err
+	}
+	if phoneBaseSettings.Entities == nil || len(*phoneBaseSettings.Entities) == 0 {
+		return &allPhoneBaseSettings, resp, nil
+	}
+	allPhoneBaseSettings = append(allPhoneBaseSettings, *phoneBaseSettings.Entities...)
+
+	for pageNum := 2; pageNum <= *phoneBaseSettings.PageCount; pageNum++ {
+		phoneBaseSettings, resp, err := p.edgesApi.GetTelephonyProvidersEdgesPhonebasesettings(pageSize, pageNum, "", "", nil, "")
+		if err != nil {
+			return nil, resp, err
+		}
+		if phoneBaseSettings.Entities == nil || len(*phoneBaseSettings.Entities) == 0 {
+			break
+		}
+		allPhoneBaseSettings = append(allPhoneBaseSettings, *phoneBaseSettings.Entities...)
+	}
+	return &allPhoneBaseSettings, resp, nil
+}
+
+// getStationOfLineFn is an implementation function for retrieving the Genesys Cloud Station of a phone line
+func getStationOfLineFn(ctx context.Context, p *phoneProxy, lineId string) (station *platformclientv2.Station, retryable bool, resp *platformclientv2.APIResponse, err error) {
+	const pageSize = 100
+	const pageNum = 1
+	stations, resp, err := p.stationsApi.GetStations(pageSize, pageNum, "", "", "", "", "", lineId)
+	if err != nil {
+		return nil, false, resp, err
+	}
+	if stations.Entities == nil || len(*stations.Entities) == 0 {
+		return nil, true, resp, nil
+	}
+	return &(*stations.Entities)[0], false, resp, err
+}
+
 // unassignUserFromStationFn is an implementation function for unassigning a Genesys Cloud User from a Station
 func unassignUserFromStationFn(ctx context.Context, p *phoneProxy, stationId string) (*platformclientv2.APIResponse, error) {
 	return p.stationsApi.DeleteStationAssociateduser(stationId)
@@ -0,0 +1,192 @@
+package telephony_providers_edges_phone
+
+import (
+	"crypto/sha256"
+	"encoding/csv"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+	"terraform-provider-genesyscloud/genesyscloud/util"
+)
+
+/*
+The genesyscloud_telephony_providers_edges_phones_inventory_file.go file reads the inventory file of the
+genesyscloud_telephony_providers_edges_phones_inventory resource. Each row of the CSV file describes one phone, which is
+identified by its hardware ID (usually the MAC address of the phone).
+*/
+
+const (
+	inventoryHardwareIdColumn = "hardware_id"
+	inventoryModelColumn      = "model"
+	inventorySiteIdColumn     = "site_id"
+	inventoryUserIdColumn     = "user_id"
+	inventoryExtensionColumn  = "extension"
+	inventoryNameColumn       = "name"
+
+	// maxInventoryErrors is the number of invalid rows reported before the rest of the file is ignored
+	maxInventoryErrors = 20
+)
+
+// inventoryColumnAliases are other names accepted for the columns of the inventory file
+var inventoryColumnAliases = map[string]string{
+	"mac":         inventoryHardwareIdColumn,
+	"mac_address": inventoryHardwareIdColumn,
+	"site":        inventorySiteIdColumn,
+	"user":        inventoryUserIdColumn,
+}
+
+// hardwareIdSeparators are removed from hardware IDs, so that 00:04:F2:AA:BB:CC and 0004f2aabbcc are the same phone
+var hardwareIdSeparators = strings.NewReplacer(":", "", "-", "", ".", "", " ", "")
+
+// phoneInventoryEntry is a phone of the inventory
+type phoneInventoryEntry struct {
+	hardwareId string
+	name       string
+	model      string
+	siteId     string
+	userId     string
+	extension  string
+}
+
+func (e phoneInventoryEntry) sameContent(other phoneInventoryEntry) bool {
+	return e.name == other.name && e.model == other.model && e.siteId == other.siteId && e.userId == other.userId && e.extension == other.extension
+}
+
+// normalizeHardwareId returns the hardware ID in lower case without separators
+func normalizeHardwareId(hardwareId string) string {
+	return strings.ToLower(hardwareIdSeparators.Replace(strings.TrimSpace(hardwareId)))
+}
+
+// readPhonesInventoryFile reads the phones of a CSV file with hardware_id, model and site_id columns, and optional
+// user_id, extension and name columns. Phones without a name are named after the name prefix and their hardware ID.
+func readPhonesInventoryFile(path string, namePrefix string) ([]phoneInventoryEntry, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open inventory file %s: %w", path, err)
+	}
+	defer file.Close()
+
+	entries, err := parsePhonesInventory(file, namePrefix)
+	if err != nil {
+		return nil, fmt.Errorf("invalid inventory file %s: %w", path, err)
+	}
+	return entries, nil
+}
+
+func parsePhonesInventory(reader io.Reader, namePrefix string) ([]phoneInventoryEntry, error) {
+	records := csv.NewReader(reader)
+	records.FieldsPerRecord = -1
+	records.TrimLeadingSpace = true
+
+	header, err := records.Read()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read header row: %w", err)
+	}
+	columns := make(map[string]int)
+	for i, name := range header {
+		name = strings.ToLower(strings.TrimSpace(strings.TrimPrefix(name, "\ufeff")))
+		if alias, ok := inventoryColumnAliases[name]; ok {
+			name = alias
+		}
+		if _, ok := columns[name]; !ok {
+			columns[name] = i
+		}
+	}
+	for _, required := range []string{inventoryHardwareIdColumn, inventoryModelColumn, inventorySiteIdColumn} {
+		if _, ok := columns[required]; !ok {
+			return nil, fmt.Errorf("header row has no %s column", required)
+		}
+	}
+
+	e164 := util.NewUtilE164Service()
+	var entries []phoneInventoryEntry
+	lines := make(map[string]int)
+	names := make(map[string]int)
+	var errs []error
+	for {
+		record, err := records.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		line, _ := records.FieldPos(0)
+		if len(errs) == maxInventoryErrors {
+			errs = append(errs, fmt.Errorf("more invalid rows were not reported"))
+			break
+		}
+		if strings.TrimSpace(strings.Join(record, "")) == "" {
+			continue
+		}
+
+		value := func(column string) string {
+			if i, ok := columns[column]; ok && i < len(record) {
+				return strings.TrimSpace(record[i])
+			}
+			return ""
+		}
+		entry := phoneInventoryEntry{
+			hardwareId: normalizeHardwareId(value(inventoryHardwareIdColumn)),
+			name:       value(inventoryNameColumn),
+			model:      value(inventoryModelColumn),
+			siteId:     value(inventorySiteIdColumn),
+			userId:     value(inventoryUserIdColumn),
+			extension:  value(inventoryExtensionColumn),
+		}
+
+		var missing []string
+		for column, v := range map[string]string{inventoryHardwareIdColumn: entry.hardwareId, inventoryModelColumn: entry.model, inventorySiteIdColumn: entry.siteId} {
+			if v == "" {
+				missing = append(missing, column)
+			}
+		}
+		if len(missing) > 0 {
+			sort.Strings(missing)
+			errs = append(errs, fmt.Errorf("line %d: missing %s", line, strings.Join(missing, ", ")))
+			continue
+		}
+		if previous, ok := lines[entry.hardwareId]; ok {
+			errs = append(errs, fmt.Errorf("line %d: hardware ID %s is already listed on line %d", line, entry.hardwareId, previous))
+			continue
+		}
+
+		if entry.name == "" {
+			entry.name = namePrefix + entry.hardwareId
+		}
+		if previous, ok := names[entry.name]; ok {
+			errs = append(errs, fmt.Errorf("line %d: phone name %s is already used on line %d", line, entry.name, previous))
+			continue
+		}
+		// Extensions are kept as they are, direct numbers are formatted like the API returns them
+		if strings.HasPrefix(entry.extension, "+") {
+			formatted, diagErr := e164.FormatAsValidE164Number(entry.extension)
+			if diagErr != nil {
+				errs = append(errs, fmt.Errorf("line %d: invalid phone number %s", line, entry.extension))
+				continue
+			}
+			entry.extension = formatted
+		}
+
+		lines[entry.hardwareId] = line
+		names[entry.name] = line
+		entries = append(entries, entry)
+	}
+	return entries, errors.Join(errs...)
+}
+
+// hashPhonesInventory returns the hash of the entries, sorted by hardware ID
+func hashPhonesInventory(entries []phoneInventoryEntry) string {
+	sorted := append([]phoneInventoryEntry(nil), entries...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].hardwareId < sorted[j].hardwareId })
+
+	hash := sha256.New()
+	for _, e := range sorted {
+		_, _ = fmt.Fprintf(hash, "%s\n%s\n%s\n%s\n%s\n%s\n", e.hardwareId, e.name, e.model, e.siteId, e.userId, e.extension)
+	}
+	return hex.EncodeToString(hash.Sum(nil))
+}
@@ -19,12 +19,16 @@ resource_genesyscloud_telephony_providers_edges_phone_schema.go should hold four
 3.  The datasource schema definitions for the telephony_providers_edges_phone datasource.
 4.  The resource exporter configuration for the telephony_providers_edges_phone exporter.
 */
-const ResourceType = "genesyscloud_telephony_providers_edges_phone"
+const (
+	ResourceType                = "genesyscloud_telephony_providers_edges_phone"
+	PhonesInventoryResourceType = "genesyscloud_telephony_providers_edges_phones_inventory"
+)
 
 // SetRegistrar registers all of the resources, datasources and exporters in the package
 func SetRegistrar(l registrar.Registrar) {
 	l.RegisterDataSource(ResourceType, DataSourcePhone())
 	l.RegisterResource(ResourceType, ResourcePhone())
+	l.RegisterResource(PhonesInventoryResourceType, ResourcePhonesInventory())
 	l.RegisterExporter(ResourceType, PhoneExporter())
 }
 
@@ -180,6 +184,145 @@ func ResourcePhone() *schema.Resource {
 	}
 }
 
+// ResourcePhonesInventory registers the genesyscloud_telephony_providers_edges_phones_inventory resource with Terraform
+func ResourcePhonesInventory() *schema.Resource {
+	return &schema.Resource{
+		Description: "Genesys Cloud Phones provisioned from an inventory file. Each row of the CSV file is a phone identified by its hardware ID (MAC address). " +
+			"Phones removed from the file are deleted, and phones of the org that are not in the file are reported in unmanaged_phones. " +
+			"Phones of the org with the hardware ID of a new row fail the apply, unless adopt_existing is true. " +
+			"A phone must not be managed by both this resource and a genesyscloud_telephony_providers_edges_phone resource, as each resource would overwrite the changes of the other.",
+		CreateContext: provider.CreateWithPooledClient(createPhonesInventory),
+		ReadContext:   provider.ReadWithPooledClient(readPhonesInventory),
+		UpdateContext: provider.UpdateWithPooledClient(updatePhonesInventory),
+		DeleteContext: provider.DeleteWithPooledClient(deletePhonesInventory),
+		SchemaVersion: 1,
+		Schema: map[string]*schema.Schema{
+			"inventory_file": {
+				Description: "Path to a CSV file with a header row and the columns `hardware_id` (or `mac`), `model` and `site_id`, and optionally `user_id`, `extension` and `name`. " +
+					"Hardware IDs are compared in lower case without separators. The extension is set as the line address of the phone, which makes it a standalone phone. " +
+					"The phone is assigned to the user as their default station.",
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validators.ValidatePath,
+			},
+			"name_prefix": {
+				Description: "Prefix of the names of the phones without a name in the inventory file, which are named after their hardware ID.",
+				Type:        schema.TypeString,
+				Optional:    true,
+			},
+			"phone_base_settings_ids": {
+				Description: "Phone base settings ID of each phone model of the inventory file. Models that are not set resolve to the phone base settings whose name or phone model is the model.",
+				Type:        schema.TypeMap,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			"batch_size": {
+				Description:  "Number of phones read, created, updated or deleted concurrently.",
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      10,
+				ValidateFunc: validation.IntBetween(1, 50),
+			},
+			"adopt_existing": {
+				Description: "If true, phones of the org with the hardware ID of a row of the inventory file are adopted: they are updated to match the row and are deleted when the row is removed. " +
+					"If false, such phones fail the apply before any phone is changed. Do not adopt phones managed by a genesyscloud_telephony_providers_edges_phone resource.",
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+			"phones": {
+				Description: "Phones provisioned from the inventory file.",
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"hardware_id": {
+							Description: "Hardware ID of the phone.",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"phone_id": {
+							Description: "ID of the phone.",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"name": {
+							Description: "Name of the phone.",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"model": {
+							Description: "Model of the phone in the inventory file.",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"site_id": {
+							Description: "Site of the phone.",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"user_id": {
+							Description: "User the phone is assigned to.",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"extension": {
+							Description: "Line address of the phone.",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"phone_base_settings_id": {
+							Description: "Phone base settings of the phone.",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"line_base_settings_id": {
+							Description: "Line base settings of the phone.",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+					},
+				},
+			},
+			"inventory_hash": {
+				Description: "Hash of the phones of the inventory file.",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"unmanaged_phones": {
+				Description: "Phones of the org with a hardware ID that are not managed by the inventory, as of the last create or update. They are not refreshed on read.",
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"phone_id": {
+							Description: "ID of the phone.",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"name": {
+							Description: "Name of the phone.",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"hardware_id": {
+							Description: "Hardware ID of the phone.",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"site_id": {
+							Description: "Site of the phone.",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+					},
+				},
+			},
+		},
+		CustomizeDiff: customizePhonesInventoryDiff,
+	}
+}
+
 // PhoneExporter returns the resourceExporter object used to hold the genesyscloud_telephony_providers_edges_phone exporter's config
 func PhoneExporter() *resourceExporter.ResourceExporter {
 	return &resourceExporter.ResourceExporter{
@@ -123,6 +123,11 @@ func assignUserToWebRtcPhone(ctx context.Context, pp *phoneProxy, userId string)
 		return retryErr
 	}
 
+	return associateUserWithStation(ctx, pp, userId, stationId, stationIsAssociated)
+}
+
+// associateUserWithStation makes the station the associated and default station of the user
+func associateUserWithStation(ctx context.Context, pp *phoneProxy, userId string, stationId string, stationIsAssociated bool) diag.Diagnostics {
 	diagErr := util.RetryWhen(util.IsStatus400, func() (*platformclientv2.APIResponse, diag.Diagnostics) {
 		if stationIsAssociated {
 			log.Printf("Disassociating user from phone station %s", stationId)
@@ -0,0 +1,687 @@
+package telephony_providers_edges_phone
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+	"sync"
+	"terraform-provider-genesyscloud/genesyscloud/provider"
+	"terraform-provider-genesyscloud/genesyscloud/util"
+	"terraform-provider-genesyscloud/genesyscloud/util/chunks"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/retry"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/mypurecloud/platform-client-sdk-go/v149/platformclientv2"
+)
+
+/*
+   The resource_genesyscloud_telephony_providers_edges_phones_inventory.go file contains the
+   genesyscloud_telephony_providers_edges_phones_inventory resource. It provisions one phone per row of the inventory file
+   and reconciles the phones of the org with the inventory by hardware ID: phones of the org with the hardware ID of a row
+   are adopted when adopt_existing is set and are a conflict otherwise, phones removed from the inventory are deleted. Reads
+   only get the phones in the state, the phones of the org are listed when the inventory is applied. The inventory file is
+   read by genesyscloud_telephony_providers_edges_phones_inventory_file.go.
+*/
+
+const (
+	hardwareIdProperty      = "phone_hardwareId"
+	lineAddressProperty     = "station_identity_address"
+	phoneStandaloneProperty = "phone_standalone"
+)
+
+// inventoryPhone is a phone managed by the resource, as kept in the phones attribute
+type inventoryPhone struct {
+	phoneInventoryEntry
+	phoneId             string
+	phoneBaseSettingsId string
+	lineBaseSettingsId  string
+}
+
+// resolvedPhoneBaseSettings holds the settings a phone is created with for a phone model
+type resolvedPhoneBaseSettings struct {
+	id                 string
+	lineBaseSettingsId string
+	phoneMetaBaseId    string
+}
+
+// phoneBaseSettingsResolver resolves the phone base settings of phone models, from the phone_base_settings_ids of the
+// resource or else from the phone base settings of the org whose phone model or name is the model
+type phoneBaseSettingsResolver struct {
+	pp       *phoneProxy
+	ids      map[string]string
+	all      *[]platformclientv2.Phonebase
+	resolved map[string]*resolvedPhoneBaseSettings
+}
+
+// phoneInventoryChange is a phone to create, update or delete
+type phoneInventoryChange struct {
+	entry          phoneInventoryEntry
+	baseSettings   *resolvedPhoneBaseSettings
+	phone          *platformclientv2.Phone
+	previousUserId string
+	remove         *inventoryPhone
+}
+
+// customizePhonesInventoryDiff plans inventory_hash from the inventory file, so that changes to the file are planned as updates
+func customizePhonesInventoryDiff(_ context.Context, d *schema.ResourceDiff, _ interface{}) error {
+	if !d.NewValueKnown("inventory_file") || !d.NewValueKnown("name_prefix") {
+		return d.SetNewComputed("inventory_hash")
+	}
+
+	entries, err := readPhonesInventoryFile(d.Get("inventory_file").(string), d.Get("name_prefix").(string))
+	if err != nil {
+		return err
+	}
+	if hash := hashPhonesInventory(entries); hash != d.Get("inventory_hash").(string) {
+		if err := d.SetNew("inventory_hash", hash); err != nil {
+			return err
+		}
+		return d.SetNewComputed("phones")
+	}
+	return nil
+}
+
+func createPhonesInventory(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	sdkConfig := meta.(*provider.ProviderMeta).ClientConfig
+	pp := getPhoneProxy(sdkConfig)
+
+	entries, err := readPhonesInventoryFile(d.Get("inventory_file").(string), d.Get("name_prefix").(string))
+	if err != nil {
+		return util.BuildDiagnosticError(PhonesInventoryResourceType, "failed to read the inventory", err)
+	}
+
+	d.SetId(uuid.NewString())
+	log.Printf("Provisioning %d phones of inventory %s", len(entries), d.Get("inventory_file").(string))
+	if diagErr := syncPhonesInventory(ctx, d, pp, entries); diagErr != nil {
+		return diagErr
+	}
+
+	log.Printf("Provisioned phones inventory %s", d.Id())
+	return readPhonesInventory(ctx, d, meta)
+}
+
+func readPhonesInventory(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	sdkConfig := meta.(*provider.ProviderMeta).ClientConfig
+	pp := getPhoneProxy(sdkConfig)
+
+	log.Printf("Reading phones inventory %s", d.Id())
+	statePhones := inventoryPhonesFromState(d)
+	phonesById, diagErr := getInventoryPhones(ctx, pp, statePhones, d.Get("batch_size").(int))
+	if diagErr != nil {
+		return diagErr
+	}
+
+	var current []inventoryPhone
+	for _, p := range statePhones {
+		phone, ok := phonesById[p.phoneId]
+		if !ok {
+			log.Printf("Phone %s %s of the inventory no longer exists", p.hardwareId, p.phoneId)
+			continue
+		}
+		current = append(current, flattenInventoryPhone(p, phone))
+	}
+
+	entries := make([]phoneInventoryEntry, 0, len(current))
+	for _, p := range current {
+		entries = append(entries, p.phoneInventoryEntry)
+	}
+	setInventoryPhones(d, current)
+	_ = d.Set("inventory_hash", hashPhonesInventory(entries))
+
+	log.Printf("Read %d phones of phones inventory %s", len(current), d.Id())
+	return nil
+}
+
+// getInventoryPhones gets the phones of the inventory by ID, in batches of batchSize concurrent requests. Phones that no
+// longer exist are not in the result.
+func getInventoryPhones(ctx context.Context, pp *phoneProxy, phones []inventoryPhone, batchSize int) (map[string]*platformclientv2.Phone, diag.Diagnostics) {
+	var mutex sync.Mutex
+	phonesById := make(map[string]*platformclientv2.Phone)
+	diagErr := chunks.ProcessChunks(chunks.ChunkBy(phones, batchSize), func(batch []inventoryPhone) diag.Diagnostics {
+		var wg sync.WaitGroup
+		var diagErr diag.Diagnostics
+		for _, p := range batch {
+			wg.Add(1)
+			go func(p inventoryPhone) {
+				defer wg.Done()
+				phone, resp, err := pp.getPhoneById(ctx, p.phoneId)
+				mutex.Lock()
+				defer mutex.Unlock()
+				if err != nil {
+					if !util.IsStatus404(resp) {
+						diagErr = append(diagErr, util.BuildAPIDiagnosticError(PhonesInventoryResourceType, fmt.Sprintf("failed to read phone %s %s | error: %s", p.hardwareId, p.phoneId, err), resp)...)
+					}
+					return
+				}
+				phonesById[p.phoneId] = phone
+			}(p)
+		}
+		wg.Wait()
+		return diagErr
+	})
+	return phonesById, diagErr
+}
+
+func updatePhonesInventory(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	sdkConfig := meta.(*provider.ProviderMeta).ClientConfig
+	pp := getPhoneProxy(sdkConfig)
+
+	entries, err := readPhonesInventoryFile(d.Get("inventory_file").(string), d.Get("name_prefix").(string))
+	if err != nil {
+		return util.BuildDiagnosticError(PhonesInventoryResourceType, "failed to read the inventory", err)
+	}
+
+	log.Printf("Updating phones inventory %s", d.Id())
+	if diagErr := syncPhonesInventory(ctx, d, pp, entries); diagErr != nil {
+		return diagErr
+	}
+
+	log.Printf("Updated phones inventory %s", d.Id())
+	return readPhonesInventory(ctx, d, meta)
+}
+
+func deletePhonesInventory(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	sdkConfig := meta.(*provider.ProviderMeta).ClientConfig
+	pp := getPhoneProxy(sdkConfig)
+
+	var changes []phoneInventoryChange
+	for _, p := range inventoryPhonesFromState(d) {
+		p := p
+		changes = append(changes, phoneInventoryChange{remove: &p})
+	}
+
+	synced := make(map[string]inventoryPhone)
+	if diagErr := applyPhoneInventoryChanges(ctx, pp, changes, d.Get("batch_size").(int), synced); diagErr != nil {
+		return diagErr
+	}
+
+	log.Printf("Deleted %d phones of phones inventory %s", len(changes), d.Id())
+	return nil
+}
+
+// syncPhonesInventory deletes the phones removed from the inventory, and creates or updates the phones of the inventory
+// that are new or changed. Phones of the org with the hardware ID of a new row are adopted when adopt_existing is set, and
+// fail the sync before any change is made otherwise. The phones attribute is set to the phones that exist, also when the
+// sync fails, and unmanaged_phones to the phones of the org that are not managed by the inventory.
+func syncPhonesInventory(ctx context.Context, d *schema.ResourceData, pp *phoneProxy, desired []phoneInventoryEntry) diag.Diagnostics {
+	// The plan marks phones as unknown when the inventory changes, so the current phones are read from the state
+	oldPhones, _ := d.GetChange("phones")
+	current := make(map[string]inventoryPhone)
+	for _, p := range buildInventoryPhones(oldPhones.([]interface{})) {
+		current[p.hardwareId] = p
+	}
+
+	synced := make(map[string]inventoryPhone)
+	for hardwareId, p := range current {
+		synced[hardwareId] = p
+	}
+	defer func() {
+		phones := make([]inventoryPhone, 0, len(synced))
+		for _, p := range synced {
+			phones = append(phones, p)
+		}
+		setInventoryPhones(d, phones)
+	}()
+
+	orgPhones, resp, err := pp.getAllPhones(ctx)
+	if err != nil {
+		return util.BuildAPIDiagnosticError(PhonesInventoryResourceType, fmt.Sprintf("failed to get phones | error: %s", err), resp)
+	}
+	phonesById := make(map[string]*platformclientv2.Phone)
+	phonesByHardwareId := make(map[string]*platformclientv2.Phone)
+	for i, phone := range *orgPhones {
+		if phone.Id == nil {
+			continue
+		}
+		phonesById[*phone.Id] = &(*orgPhones)[i]
+		if hardwareId := normalizeHardwareId(phoneProperty(phone.Properties, hardwareIdProperty)); hardwareId != "" {
+			phonesByHardwareId[hardwareId] = &(*orgPhones)[i]
+		}
+	}
+
+	var removals, changes []phoneInventoryChange
+	adoptExisting := d.Get("adopt_existing").(bool)
+	desiredIds := make(map[string]bool)
+	for _, e := range desired {
+		desiredIds[e.hardwareId] = true
+	}
+	for hardwareId, p := range current {
+		if desiredIds[hardwareId] {
+			continue
+		}
+		if _, exists := phonesById[p.phoneId]; !exists {
+			delete(synced, hardwareId)
+			continue
+		}
+		p := p
+		removals = append(removals, phoneInventoryChange{remove: &p})
+	}
+
+	resolver := &phoneBaseSettingsResolver{
+		pp:       pp,
+		ids:      make(map[string]string),
+		resolved: make(map[string]*resolvedPhoneBaseSettings),
+	}
+	for model, id := range d.Get("phone_base_settings_ids").(map[string]interface{}) {
+		resolver.ids[model] = id.(string)
+	}
+	baseSettingsChanged := d.HasChange("phone_base_settings_ids")
+
+	managedIds := make(map[string]bool)
+	for _, p := range current {
+		managedIds[p.phoneId] = true
+	}
+	var conflicts []string
+	for _, e := range desired {
+		existing, isManaged := current[e.hardwareId]
+		var phone *platformclientv2.Phone
+		if isManaged {
+			phone = phonesById[existing.phoneId]
+		}
+		// A managed phone that no longer exists is created again
+		isManaged = phone != nil
+		if phone == nil {
+			if orgPhone := phonesByHardwareId[e.hardwareId]; orgPhone != nil && !managedIds[*orgPhone.Id] {
+				if !adoptExisting {
+					conflicts = append(conflicts, fmt.Sprintf("phone %s %s has the hardware ID %s", util.StringOrEmpty(orgPhone.Name), *orgPhone.Id, e.hardwareId))
+					continue
+				}
+				log.Printf("Adopting phone %s %s with hardware ID %s", util.StringOrEmpty(orgPhone.Name), *orgPhone.Id, e.hardwareId)
+				managedIds[*orgPhone.Id] = true
+				phone = orgPhone
+			}
+		}
+		if isManaged && existing.sameContent(e) && !baseSettingsChanged {
+			continue
+		}
+
+		baseSettings, diagErr := resolver.resolve(ctx, e.model)
+		if diagErr != nil {
+			return diagErr
+		}
+		if isManaged && existing.sameContent(e) && existing.phoneBaseSettingsId == baseSettings.id {
+			continue
+		}
+
+		change := phoneInventoryChange{entry: e, baseSettings: baseSettings, phone: phone}
+		if isManaged {
+			change.previousUserId = existing.userId
+		}
+		changes = append(changes, change)
+	}
+
+	_ = d.Set("unmanaged_phones", flattenUnmanagedPhones(*orgPhones, managedIds))
+	if len(conflicts) > 0 {
+		return util.BuildDiagnosticError(PhonesInventoryResourceType, "failed to sync the phones inventory",
+			fmt.Errorf("phones of the org have the hardware ID of rows of the inventory, set adopt_existing to manage them with the inventory or remove the rows: %s", strings.Join(conflicts, "; ")))
+	}
+
+	log.Printf("Syncing phones inventory %s: %d phones to create or update, %d phones to delete", d.Id(), len(changes), len(removals))
+	batchSize := d.Get("batch_size").(int)
+	if diagErr := applyPhoneInventoryChanges(ctx, pp, removals, batchSize, synced); diagErr != nil {
+		return diagErr
+	}
+	return applyPhoneInventoryChanges(ctx, pp, changes, batchSize, synced)
+}
+
+// flattenUnmanagedPhones returns the phones of the org with a hardware ID that are not managed by the inventory
+func flattenUnmanagedPhones(orgPhones []platformclientv2.Phone, managedIds map[string]bool) []interface{} {
+	unmanaged := make([]interface{}, 0)
+	for _, phone := range orgPhones {
+		hardwareId := normalizeHardwareId(phoneProperty(phone.Properties, hardwareIdProperty))
+		if phone.Id == nil || managedIds[*phone.Id] || hardwareId == "" {
+			continue
+		}
+		unmanaged = append(unmanaged, map[string]interface{}{
+			"phone_id":    *phone.Id,
+			"name":        util.StringOrEmpty(phone.Name),
+			"hardware_id": hardwareId,
+			"site_id":     entityRefId(phone.Site),
+		})
+	}
+	sort.Slice(unmanaged, func(i, j int) bool {
+		return unmanaged[i].(map[string]interface{})["name"].(string) < unmanaged[j].(map[string]interface{})["name"].(string)
+	})
+	return unmanaged
+}
+
+// applyPhoneInventoryChanges applies the changes in batches. The changes of a batch are applied concurrently, and the
+// next batch is started when all of them are done. synced is updated with the result of each change.
+func applyPhoneInventoryChanges(ctx context.Context, pp *phoneProxy, changes []phoneInventoryChange, batchSize int, synced map[string]inventoryPhone) diag.Diagnostics {
+	if len(changes) == 0 {
+		return nil
+	}
+
+	var mutex sync.Mutex
+	return chunks.ProcessChunks(chunks.ChunkBy(changes, batchSize), func(batch []phoneInventoryChange) diag.Diagnostics {
+		var wg sync.WaitGroup
+		var diagErr diag.Diagnostics
+		for _, change := range batch {
+			wg.Add(1)
+			go func(change phoneInventoryChange) {
+				defer wg.Done()
+				if change.remove != nil {
+					err := deleteInventoryPhone(ctx, pp, *change.remove)
+					mutex.Lock()
+					defer mutex.Unlock()
+					if err != nil {
+						diagErr = append(diagErr, err...)
+						return
+					}
+					delete(synced, change.remove.hardwareId)
+					return
+				}
+
+				p, err := applyPhoneInventoryEntry(ctx, pp, change)
+				mutex.Lock()
+				defer mutex.Unlock()
+				if p != nil {
+					synced[p.hardwareId] = *p
+				}
+				diagErr = append(diagErr, err...)
+			}(change)
+		}
+		wg.Wait()
+		return diagErr
+	})
+}
+
+// applyPhoneInventoryEntry creates or updates the phone of an inventory entry and assigns it to the user of the entry. It
+// returns the phone when it has been created or updated, also when the assignment fails.
+func applyPhoneInventoryEntry(ctx context.Context, pp *phoneProxy, change phoneInventoryChange) (*inventoryPhone, diag.Diagnostics) {
+	e := change.entry
+	phoneConfig := buildInventoryPhone(change)
+
+	var phone *platformclientv2.Phone
+	if change.phone == nil {
+		log.Printf("Creating phone %s %s", e.name, e.hardwareId)
+		diagErr := util.RetryWhen(util.IsStatus404, func() (*platformclientv2.APIResponse, diag.Diagnostics) {
+			created, resp, err := pp.createPhone(ctx, phoneConfig)
+			if err != nil {
+				return resp, util.BuildAPIDiagnosticError(PhonesInventoryResourceType, fmt.Sprintf("failed to create phone %s %s | error: %s", e.name, e.hardwareId, err), resp)
+			}
+			phone = created
+			return resp, nil
+		})
+		if diagErr != nil {
+			return nil, diagErr
+		}
+	} else {
+		log.Printf("Updating phone %s %s %s", e.name, e.hardwareId, *change.phone.Id)
+		updated, resp, err := pp.updatePhone(ctx, *change.phone.Id, phoneConfig)
+		if err != nil {
+			return nil, util.BuildAPIDiagnosticError(PhonesInventoryResourceType, fmt.Sprintf("failed to update phone %s %s | error: %s", e.name, e.hardwareId, err), resp)
+		}
+		phone = updated
+	}
+
+	result := &inventoryPhone{
+		phoneInventoryEntry: e,
+		phoneId:             util.StringOrEmpty(phone.Id),
+		phoneBaseSettingsId: change.baseSettings.id,
+		lineBaseSettingsId:  change.baseSettings.lineBaseSettingsId,
+	}
+	if change.phone == nil || e.userId != change.previousUserId {
+		if diagErr := assignInventoryPhoneUser(ctx, pp, phone, e.userId); diagErr != nil {
+			// Keep the previous user in the state so that the assignment is retried
+			result.userId = change.previousUserId
+			return result, diagErr
+		}
+	}
+	return result, nil
+}
+
+// buildInventoryPhone builds the phone of an inventory entry, keeping the line and the other properties of the phone
+// it replaces
+func buildInventoryPhone(change phoneInventoryChange) *platformclientv2.Phone {
+	e := change.entry
+	lineBaseSettings := &platformclientv2.Domainentityref{Id: platformclientv2.String(change.baseSettings.lineBaseSettingsId)}
+
+	phone := &platformclientv2.Phone{State: platformclientv2.String("active")}
+	line := platformclientv2.Line{
+		Name: platformclientv2.String("line_" + change.baseSettings.lineBaseSettingsId + "_" + util.GetUniqueString()),
+	}
+	properties := make(map[string]interface{})
+	lineProperties := make(map[string]interface{})
+	if existing := change.phone; existing != nil {
+		phone.Capabilities = existing.Capabilities
+		if existing.State != nil {
+			phone.State = existing.State
+		}
+		if existing.Properties != nil {
+			for key, value := range *existing.Properties {
+				properties[key] = value
+			}
+		}
+		if existing.Lines != nil && len(*existing.Lines) > 0 {
+			line = (*existing.Lines)[0]
+			if line.Properties != nil {
+				for key, value := range *line.Properties {
+					lineProperties[key] = value
+				}
+			}
+		}
+	}
+
+	properties[hardwareIdProperty] = map[string]interface{}{
+		"value": map[string]interface{}{"instance": e.hardwareId},
+	}
+	delete(properties, phoneStandaloneProperty)
+	delete(lineProperties, lineAddressProperty)
+	if e.extension != "" {
+		properties[phoneStandaloneProperty] = map[string]interface{}{
+			"value": map[string]interface{}{"instance": true},
+		}
+		lineProperties[lineAddressProperty] = map[string]interface{}{
+			"value": map[string]interface{}{"instance": e.extension},
+		}
+	}
+
+	line.LineBaseSettings = lineBaseSettings
+	line.Properties = &lineProperties
+	phone.Name = platformclientv2.String(e.name)
+	phone.Site = &platformclientv2.Domainentityref{Id: platformclientv2.String(e.siteId)}
+	phone.PhoneBaseSettings = &platformclientv2.Phonebasesettings{Id: platformclientv2.String(change.baseSettings.id)}
+	phone.LineBaseSettings = lineBaseSettings
+	phone.PhoneMetaBase = &platformclientv2.Domainentityref{Id: platformclientv2.String(change.baseSettings.phoneMetaBaseId)}
+	phone.Lines = &[]platformclientv2.Line{line}
+	phone.Properties = &properties
+	return phone
+}
+
+// assignInventoryPhoneUser makes the station of the phone the default station of the user, or unassigns the user of the
+// station when userId is empty
+func assignInventoryPhoneUser(ctx context.Context, pp *phoneProxy, phone *platformclientv2.Phone, userId string) diag.Diagnostics {
+	if phone.Lines == nil || len(*phone.Lines) == 0 || (*phone.Lines)[0].Id == nil {
+		if userId == "" {
+			return nil
+		}
+		return util.BuildDiagnosticError(PhonesInventoryResourceType, fmt.Sprintf("failed to assign phone %s to user %s", util.StringOrEmpty(phone.Name), userId), fmt.Errorf("the phone has no line"))
+	}
+	lineId := *(*phone.Lines)[0].Id
+
+	var station *platformclientv2.Station
+	retryErr := util.WithRetries(ctx, 60*time.Second, func() *retry.RetryError {
+		lineStation, retryable, resp, err := pp.getStationOfLine(ctx, lineId)
+		if err != nil && !retryable {
+			return retry.NonRetryableError(util.BuildWithRetriesApiDiagnosticError(PhonesInventoryResourceType, fmt.Sprintf("error requesting stations: %s", err), resp))
+		}
+		if retryable {
+			return retry.RetryableError(util.BuildWithRetriesApiDiagnosticError(PhonesInventoryResourceType, fmt.Sprintf("no station found for line %s of phone %s", lineId, util.StringOrEmpty(phone.Name)), resp))
+		}
+		station = lineStation
+		return nil
+	})
+	if retryErr != nil {
+		return retryErr
+	}
+
+	stationIsAssociated := station.Status != nil && *station.Status == "ASSOCIATED"
+	if userId == "" {
+		if !stationIsAssociated {
+			return nil
+		}
+		log.Printf("Disassociating user from phone station %s", *station.Id)
+		if resp, err := pp.unassignUserFromStation(ctx, *station.Id); err != nil {
+			return util.BuildAPIDiagnosticError(PhonesInventoryResourceType, fmt.Sprintf("failed to unassign user from station %s | error: %s", *station.Id, err), resp)
+		}
+		return nil
+	}
+	if stationIsAssociated && station.UserId != nil && *station.UserId == userId {
+		return nil
+	}
+	return associateUserWithStation(ctx, pp, userId, *station.Id, stationIsAssociated)
+}
+
+func deleteInventoryPhone(ctx context.Context, pp *phoneProxy, p inventoryPhone) diag.Diagnostics {
+	log.Printf("Deleting phone %s %s %s", p.name, p.hardwareId, p.phoneId)
+	resp, err := pp.deletePhone(ctx, p.phoneId)
+	if err != nil && !util.IsStatus404(resp) {
+		return util.BuildAPIDiagnosticError(PhonesInventoryResourceType, fmt.Sprintf("failed to delete phone %s %s | error: %s", p.name, p.phoneId, err), resp)
+	}
+	return nil
+}
+
+// resolve returns the phone base settings of a phone model
+func (r *phoneBaseSettingsResolver) resolve(ctx context.Context, model string) (*resolvedPhoneBaseSettings, diag.Diagnostics) {
+	if settings, ok := r.resolved[model]; ok {
+		return settings, nil
+	}
+
+	id, ok := r.ids[model]
+	if !ok {
+		if r.all == nil {
+			all, resp, err := r.pp.getAllPhoneBaseSettings(ctx)
+			if err != nil {
+				return nil, util.BuildAPIDiagnosticError(PhonesInventoryResourceType, fmt.Sprintf("failed to get phone base settings | error: %s", err), resp)
+			}
+			r.all = all
+		}
+
+		var matches []string
+		for _, settings := range *r.all {
+			if settings.Id == nil || (settings.State != nil && *settings.State == "deleted") {
+				continue
+			}
+			if strings.EqualFold(util.StringOrEmpty(settings.Name), model) || (settings.PhoneMetaBase != nil && strings.EqualFold(util.StringOrEmpty(settings.PhoneMetaBase.Name), model)) {
+				matches = append(matches, *settings.Id)
+			}
+		}
+		if len(matches) != 1 {
+			return nil, util.BuildDiagnosticError(PhonesInventoryResourceType, fmt.Sprintf("failed to resolve the phone base settings of model %s", model),
+				fmt.Errorf("%d phone base settings have the name or phone model %s, set the phone base settings of the model in phone_base_settings_ids", len(matches), model))
+		}
+		id = matches[0]
+	}
+
+	phoneBase, resp, err := r.pp.getPhoneBaseSetting(ctx, id)
+	if err != nil {
+		return nil, util.BuildAPIDiagnosticError(PhonesInventoryResourceType, fmt.Sprintf("failed to read phone base settings %s of model %s | error: %s", id, model, err), resp)
+	}
+	if phoneBase.Lines == nil || len(*phoneBase.Lines) == 0 || (*phoneBase.Lines)[0].Id == nil || phoneBase.PhoneMetaBase == nil || phoneBase.PhoneMetaBase.Id == nil {
+		return nil, util.BuildDiagnosticError(PhonesInventoryResourceType, fmt.Sprintf("failed to read phone base settings %s of model %s", id, model), fmt.Errorf("the phone base settings have no line or phone meta base"))
+	}
+
+	settings := &resolvedPhoneBaseSettings{
+		id:                 id,
+		lineBaseSettingsId: *(*phoneBase.Lines)[0].Id,
+		phoneMetaBaseId:    *phoneBase.PhoneMetaBase.Id,
+	}
+	r.resolved[model] = settings
+	return settings, nil
+}
+
+// flattenInventoryPhone reads a managed phone. The model and the user are kept from the state as the phone does not
+// report them.
+func flattenInventoryPhone(p inventoryPhone, phone *platformclientv2.Phone) inventoryPhone {
+	p.name = util.StringOrEmpty(phone.Name)
+	p.siteId = entityRefId(phone.Site)
+	if hardwareId := normalizeHardwareId(phoneProperty(phone.Properties, hardwareIdProperty)); hardwareId != "" {
+		p.hardwareId = hardwareId
+	}
+	if phone.PhoneBaseSettings != nil {
+		p.phoneBaseSettingsId = util.StringOrEmpty(phone.PhoneBaseSettings.Id)
+	}
+	p.lineBaseSettingsId = entityRefId(phone.LineBaseSettings)
+	p.extension = ""
+	if phone.Lines != nil && len(*phone.Lines) > 0 {
+		p.extension = phoneProperty((*phone.Lines)[0].Properties, lineAddressProperty)
+	}
+	return p
+}
+
+// phoneProperty returns the instance value of a phone or line property
+func phoneProperty(properties *map[string]interface{}, key string) string {
+	if properties == nil {
+		return ""
+	}
+	property, ok := (*properties)[key].(map[string]interface{})
+	if !ok {
+		return ""
+	}
+	value, ok := property["value"].(map[string]interface{})
+	if !ok {
+		return ""
+	}
+	instance, _ := value["instance"].(string)
+	return instance
+}
+
+func inventoryPhonesFromState(d *schema.ResourceData) []inventoryPhone {
+	return buildInventoryPhones(d.Get("phones").([]interface{}))
+}
+
+func buildInventoryPhones(items []interface{}) []inventoryPhone {
+	var phones []inventoryPhone
+	for _, item := range items {
+		m, ok := item.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		phones = append(phones, inventoryPhone{
+			phoneInventoryEntry: phoneInventoryEntry{
+				hardwareId: m["hardware_id"].(string),
+				name:       m["name"].(string),
+				model:      m["model"].(string),
+				siteId:     m["site_id"].(string),
+				userId:     m["user_id"].(string),
+				extension:  m["extension"].(string),
+			},
+			phoneId:             m["phone_id"].(string),
+			phoneBaseSettingsId: m["phone_base_settings_id"].(string),
+			lineBaseSettingsId:  m["line_base_settings_id"].(string),
+		})
+	}
+	return phones
+}
+
+func setInventoryPhones(d *schema.ResourceData, phones []inventoryPhone) {
+	sort.Slice(phones, func(i, j int) bool { return phones[i].hardwareId < phones[j].hardwareId })
+	items := make([]interface{}, 0, len(phones))
+	for _, p := range phones {
+		items = append(items, map[string]interface{}{
+			"hardware_id":            p.hardwareId,
+			"phone_id":               p.phoneId,
+			"name":                   p.name,
+			"model":                  p.model,
+			"site_id":                p.siteId,
+			"user_id":                p.userId,
+			"extension":              p.extension,
+			"phone_base_settings_id": p.phoneBaseSettingsId,
+			"line_base_settings_id":  p.lineBaseSettingsId,
+		})
+	}
+	_ = d.Set("phones", items)
+}
+
+func entityRefId(ref *platformclientv2.Domainentityref) string {
+	if ref == nil {
+		return ""
+	}
+	return util.StringOrEmpty(ref.Id)
+}
@@ -0,0 +1,132 @@
+package telephony_providers_edges_phone
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"terraform-provider-genesyscloud/genesyscloud/provider"
+	phoneBaseSettings "terraform-provider-genesyscloud/genesyscloud/telephony_providers_edges_phonebasesettings"
+	edgeSite "terraform-provider-genesyscloud/genesyscloud/telephony_providers_edges_site"
+	"terraform-provider-genesyscloud/genesyscloud/util"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/mypurecloud/platform-client-sdk-go/v149/platformclientv2"
+)
+
+func TestAccResourcePhonesInventory(t *testing.T) {
+	var (
+		resourceLabel                  = "inventory"
+		phoneBaseSettingsResourceLabel = "inventoryBaseSettings"
+		phoneBaseSettingsName          = "phoneBaseSettings " + uuid.NewString()
+		namePrefix                     = "test-inventory-phone_" + uuid.NewString()[:8] + "_"
+		model                          = "Polycom VVX 500"
+		inventoryFile                  = filepath.Join(t.TempDir(), "phones.csv")
+	)
+
+	siteId, err := edgeSite.GetOrganizationDefaultSiteId(sdkConfig)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Random locally administered MAC addresses, so that no phone of the org is adopted
+	hardwareIds := make([]string, 3)
+	for i := range hardwareIds {
+		hardwareIds[i] = "02" + strings.ReplaceAll(uuid.NewString(), "-", "")[:10]
+	}
+	writeInventory := func(rows ...string) {
+		content := "mac,model,site_id,name\n" + strings.Join(rows, "\n") + "\n"
+		if err := os.WriteFile(inventoryFile, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	config := phoneBaseSettings.GeneratePhoneBaseSettingsResourceWithCustomAttrs(
+		phoneBaseSettingsResourceLabel,
+		phoneBaseSettingsName,
+		"phoneBaseSettings description",
+		"polycom_vvx_500.json",
+	) + fmt.Sprintf(`resource "%s" "%s" {
+	inventory_file = "%s"
+	name_prefix    = "%s"
+	batch_size     = 2
+	phone_base_settings_ids = {
+		"%s" = genesyscloud_telephony_providers_edges_phonebasesettings.%s.id
+	}
+}
+`, PhonesInventoryResourceType, resourceLabel, filepath.ToSlash(inventoryFile), namePrefix, model, phoneBaseSettingsResourceLabel)
+	fullResourcePath := PhonesInventoryResourceType + "." + resourceLabel
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { util.TestAccPreCheck(t) },
+		ProviderFactories: provider.GetProviderFactories(providerResources, nil),
+		Steps: []resource.TestStep{
+			{
+				// Create two phones
+				PreConfig: func() {
+					writeInventory(
+						fmt.Sprintf("%s,%s,%s,", hardwareIds[0], model, siteId),
+						fmt.Sprintf("%s,%s,%s,", hardwareIds[1], model, siteId),
+					)
+				},
+				Config: config,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(fullResourcePath, "phones.#", "2"),
+					resource.TestCheckTypeSetElemNestedAttrs(fullResourcePath, "phones.*", map[string]string{
+						"hardware_id": hardwareIds[0],
+						"name":        namePrefix + hardwareIds[0],
+						"site_id":     siteId,
+					}),
+					resource.TestCheckResourceAttrPair(fullResourcePath, "phones.0.phone_base_settings_id", "genesyscloud_telephony_providers_edges_phonebasesettings."+phoneBaseSettingsResourceLabel, "id"),
+				),
+			},
+			{
+				// Rename a phone, remove a phone and add a phone
+				PreConfig: func() {
+					writeInventory(
+						fmt.Sprintf("%s,%s,%s,%s", hardwareIds[0], model, siteId, namePrefix+"renamed"),
+						fmt.Sprintf("%s,%s,%s,", hardwareIds[2], model, siteId),
+					)
+				},
+				Config: config,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(fullResourcePath, "phones.#", "2"),
+					resource.TestCheckTypeSetElemNestedAttrs(fullResourcePath, "phones.*", map[string]string{
+						"hardware_id": hardwareIds[0],
+						"name":        namePrefix + "renamed",
+					}),
+					resource.TestCheckTypeSetElemNestedAttrs(fullResourcePath, "phones.*", map[string]string{
+						"hardware_id": hardwareIds[2],
+						"name":        namePrefix + hardwareIds[2],
+					}),
+				),
+			},
+		},
+		CheckDestroy: testVerifyPhonesInventoryDestroyed,
+	})
+}
+
+func testVerifyPhonesInventoryDestroyed(state *terraform.State) error {
+	edgesAPI := platformclientv2.NewTelephonyProvidersEdgeApi()
+	for _, rs := range state.RootModule().Resources {
+		if rs.Type != PhonesInventoryResourceType {
+			continue
+		}
+
+		for key, phoneId := range rs.Primary.Attributes {
+			if !strings.HasSuffix(key, ".phone_id") || !strings.HasPrefix(key, "phones.") {
+				continue
+			}
+			phone, resp, err := edgesAPI.GetTelephonyProvidersEdgesPhone(phoneId)
+			if phone != nil {
+				return fmt.Errorf("phone (%s) still exists", phoneId)
+			} else if !util.IsStatus404(resp) {
+				return fmt.Errorf("unexpected error: %s", err)
+			}
+		}
+	}
+	return nil
+}
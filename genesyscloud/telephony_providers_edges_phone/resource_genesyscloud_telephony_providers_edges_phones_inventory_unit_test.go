@@ -0,0 +1,376 @@
+package telephony_providers_edges_phone
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"terraform-provider-genesyscloud/genesyscloud/provider"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/mypurecloud/platform-client-sdk-go/v149/platformclientv2"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUnitParsePhonesInventory(t *testing.T) {
+	entries, err := parsePhonesInventory(strings.NewReader("\ufeffMAC,Model,Site_Id,User_Id,Extension,Name\n"+
+		"00:04:F2:00:00:01,Polycom VVX 410,site-1,user-1,+1 (317) 555-0100,Reception\n"+
+		"0004f2-000002,Polycom VVX 410,site-1,,,\n"+
+		",,,,,\n"), "desk-")
+	assert.Nil(t, err)
+	assert.Equal(t, []phoneInventoryEntry{
+		{hardwareId: "0004f2000001", name: "Reception", model: "Polycom VVX 410", siteId: "site-1", userId: "user-1", extension: "+13175550100"},
+		{hardwareId: "0004f2000002", name: "desk-0004f2000002", model: "Polycom VVX 410", siteId: "site-1"},
+	}, entries)
+
+	_, err = parsePhonesInventory(strings.NewReader("hardware_id,model\n0004f2000001,Polycom VVX 410\n"), "")
+	assert.ErrorContains(t, err, "no site_id column")
+
+	_, err = parsePhonesInventory(strings.NewReader("hardware_id,model,site_id,name\n"+
+		"0004f2000001,Polycom VVX 410,site-1,Desk\n"+
+		"00:04:f2:00:00:01,Polycom VVX 410,site-1,Other desk\n"+
+		"0004f2000003,Polycom VVX 410,site-1,Desk\n"+
+		"0004f2000004,,site-1,\n"), "")
+	assert.ErrorContains(t, err, "line 3: hardware ID 0004f2000001 is already listed on line 2")
+	assert.ErrorContains(t, err, "line 4: phone name Desk is already used on line 2")
+	assert.ErrorContains(t, err, "line 5: missing model")
+}
+
+func TestUnitSyncPhonesInventory(t *testing.T) {
+	hardwareIdProperties := func(hardwareId string) *map[string]interface{} {
+		return &map[string]interface{}{
+			hardwareIdProperty: map[string]interface{}{"value": map[string]interface{}{"instance": hardwareId}},
+		}
+	}
+	orgPhone := func(id, name, hardwareId string) platformclientv2.Phone {
+		return platformclientv2.Phone{
+			Id:                &id,
+			Name:              &name,
+			State:             platformclientv2.String("active"),
+			Site:              &platformclientv2.Domainentityref{Id: platformclientv2.String("site-1")},
+			PhoneBaseSettings: &platformclientv2.Phonebasesettings{Id: platformclientv2.String("base-1")},
+			LineBaseSettings:  &platformclientv2.Domainentityref{Id: platformclientv2.String("line-base-1")},
+			Lines:             &[]platformclientv2.Line{{Id: platformclientv2.String("line-" + id)}},
+			Properties:        hardwareIdProperties(hardwareId),
+		}
+	}
+
+	var mutex sync.Mutex
+	phones := map[string]platformclientv2.Phone{
+		"phone-a": orgPhone("phone-a", "desk-a", "0004f200000a"),
+		"phone-b": orgPhone("phone-b", "desk-b", "0004f200000b"),
+		"phone-c": orgPhone("phone-c", "old name", "00:04:F2:00:00:0C"),
+		"phone-x": orgPhone("phone-x", "lobby", "0004f200000f"),
+		"webrtc":  {Id: platformclientv2.String("webrtc"), Name: platformclientv2.String("WebRTC phone")},
+	}
+	var created, updated, deleted, assigned, getById []string
+	listed := 0
+
+	internalProxy = &phoneProxy{
+		getAllPhonesAttr: func(ctx context.Context, p *phoneProxy) (*[]platformclientv2.Phone, *platformclientv2.APIResponse, error) {
+			mutex.Lock()
+			defer mutex.Unlock()
+			listed++
+			all := make([]platformclientv2.Phone, 0, len(phones))
+			for _, phone := range phones {
+				all = append(all, phone)
+			}
+			return &all, &platformclientv2.APIResponse{StatusCode: http.StatusOK}, nil
+		},
+		getPhoneByIdAttr: func(ctx context.Context, p *phoneProxy, phoneId string) (*platformclientv2.Phone, *platformclientv2.APIResponse, error) {
+			mutex.Lock()
+			defer mutex.Unlock()
+			phone, ok := phones[phoneId]
+			if !ok {
+				return nil, &platformclientv2.APIResponse{StatusCode: http.StatusNotFound}, fmt.Errorf("phone %s not found", phoneId)
+			}
+			getById = append(getById, phoneId)
+			return &phone, &platformclientv2.APIResponse{StatusCode: http.StatusOK}, nil
+		},
+		createPhoneAttr: func(ctx context.Context, p *phoneProxy, phoneConfig *platformclientv2.Phone) (*platformclientv2.Phone, *platformclientv2.APIResponse, error) {
+			mutex.Lock()
+			defer mutex.Unlock()
+			phone := *phoneConfig
+			phone.Id = platformclientv2.String("phone-" + *phone.Name)
+			lines := []platformclientv2.Line{(*phone.Lines)[0]}
+			lines[0].Id = platformclientv2.String("line-" + *phone.Id)
+			phone.Lines = &lines
+			phones[*phone.Id] = phone
+			created = append(created, *phone.Name)
+			return &phone, &platformclientv2.APIResponse{StatusCode: http.StatusOK}, nil
+		},
+		updatePhoneAttr: func(ctx context.Context, p *phoneProxy, phoneId string, phoneConfig *platformclientv2.Phone) (*platformclientv2.Phone, *platformclientv2.APIResponse, error) {
+			mutex.Lock()
+			defer mutex.Unlock()
+			phone := *phoneConfig
+			phone.Id = &phoneId
+			phones[phoneId] = phone
+			updated = append(updated, phoneId)
+			return &phone, &platformclientv2.APIResponse{StatusCode: http.StatusOK}, nil
+		},
+		deletePhoneAttr: func(ctx context.Context, p *phoneProxy, phoneId string) (*platformclientv2.APIResponse, error) {
+			mutex.Lock()
+			defer mutex.Unlock()
+			delete(phones, phoneId)
+			deleted = append(deleted, phoneId)
+			return &platformclientv2.APIResponse{StatusCode: http.StatusNoContent}, nil
+		},
+		getAllPhoneBaseSettingsAttr: func(ctx context.Context, p *phoneProxy) (*[]platformclientv2.Phonebase, *platformclientv2.APIResponse, error) {
+			return &[]platformclientv2.Phonebase{
+				{Id: platformclientv2.String("base-1"), Name: platformclientv2.String("Desk phones"), PhoneMetaBase: &platformclientv2.Domainentityref{Name: platformclientv2.String("Polycom VVX 410")}},
+				{Id: platformclientv2.String("base-2"), Name: platformclientv2.String("Lobby phones"), PhoneMetaBase: &platformclientv2.Domainentityref{Name: platformclientv2.String("Polycom VVX 500")}},
+			}, &platformclientv2.APIResponse{StatusCode: http.StatusOK}, nil
+		},
+		getPhoneBaseSettingAttr: func(ctx context.Context, p *phoneProxy, phoneBaseSettingsId string) (*platformclientv2.Phonebase, *platformclientv2.APIResponse, error) {
+			return &platformclientv2.Phonebase{
+				Id:            &phoneBaseSettingsId,
+				Lines:         &[]platformclientv2.Linebase{{Id: platformclientv2.String("line-" + phoneBaseSettingsId)}},
+				PhoneMetaBase: &platformclientv2.Domainentityref{Id: platformclientv2.String("polycom_vvx.json")},
+			}, &platformclientv2.APIResponse{StatusCode: http.StatusOK}, nil
+		},
+		getStationOfLineAttr: func(ctx context.Context, p *phoneProxy, lineId string) (*platformclientv2.Station, bool, *platformclientv2.APIResponse, error) {
+			return &platformclientv2.Station{Id: platformclientv2.String("station-" + lineId), Status: platformclientv2.String("AVAILABLE")}, false, &platformclientv2.APIResponse{StatusCode: http.StatusOK}, nil
+		},
+		assignUserToStationAttr: func(ctx context.Context, p *phoneProxy, userId string, stationId string) (*platformclientv2.APIResponse, error) {
+			mutex.Lock()
+			defer mutex.Unlock()
+			assigned = append(assigned, fmt.Sprintf("%s=%s", userId, stationId))
+			return &platformclientv2.APIResponse{StatusCode: http.StatusOK}, nil
+		},
+		assignStationAsDefaultAttr: func(ctx context.Context, p *phoneProxy, userId string, stationId string) (*platformclientv2.APIResponse, error) {
+			return &platformclientv2.APIResponse{StatusCode: http.StatusOK}, nil
+		},
+	}
+	defer func() { internalProxy = nil }()
+
+	statePhone := func(i int, hardwareId, phoneId, name string) map[string]string {
+		prefix := fmt.Sprintf("phones.%d.", i)
+		return map[string]string{
+			prefix + "hardware_id":            hardwareId,
+			prefix + "phone_id":               phoneId,
+			prefix + "name":                   name,
+			prefix + "model":                  "Polycom VVX 410",
+			prefix + "site_id":                "site-1",
+			prefix + "user_id":                "",
+			prefix + "extension":              "",
+			prefix + "phone_base_settings_id": "base-1",
+			prefix + "line_base_settings_id":  "line-base-1",
+		}
+	}
+	attributes := map[string]string{
+		"inventory_file": "inventory.csv",
+		"name_prefix":    "desk-",
+		"batch_size":     "2",
+		"adopt_existing": "true",
+		"phones.#":       "2",
+	}
+	for _, phone := range []map[string]string{statePhone(0, "0004f200000a", "phone-a", "desk-a"), statePhone(1, "0004f200000b", "phone-b", "desk-b")} {
+		for key, value := range phone {
+			attributes[key] = value
+		}
+	}
+	d := ResourcePhonesInventory().Data(&terraform.InstanceState{ID: "inventory-id", Attributes: attributes})
+	meta := &provider.ProviderMeta{ClientConfig: &platformclientv2.Configuration{}}
+
+	desired := []phoneInventoryEntry{
+		{hardwareId: "0004f200000a", name: "desk-a", model: "Polycom VVX 410", siteId: "site-1"},
+		{hardwareId: "0004f200000c", name: "desk-c", model: "Polycom VVX 410", siteId: "site-1", userId: "user-c"},
+		{hardwareId: "0004f200000d", name: "desk-d", model: "polycom vvx 500", siteId: "site-1", extension: "+13175550100"},
+	}
+	diags := syncPhonesInventory(context.Background(), d, internalProxy, desired)
+	assert.False(t, diags.HasError(), diags)
+	assert.Equal(t, []string{"desk-d"}, created)
+	assert.Equal(t, []string{"phone-c"}, updated)
+	assert.Equal(t, []string{"phone-b"}, deleted)
+	assert.Equal(t, []string{"user-c=station-line-phone-c"}, assigned)
+
+	// Adopted phones keep their line, new phones are standalone when they have an extension
+	assert.Equal(t, "line-phone-c", *(*phones["phone-c"].Lines)[0].Id)
+	assert.Equal(t, "0004f200000c", phoneProperty(phones["phone-c"].Properties, hardwareIdProperty))
+	assert.Equal(t, "+13175550100", phoneProperty((*phones["phone-desk-d"].Lines)[0].Properties, lineAddressProperty))
+	assert.Equal(t, "base-2", *phones["phone-desk-d"].PhoneBaseSettings.Id)
+	assert.Equal(t, "line-base-2", *phones["phone-desk-d"].LineBaseSettings.Id)
+
+	assert.Equal(t, 1, listed)
+	assert.Equal(t, []interface{}{
+		map[string]interface{}{"phone_id": "phone-x", "name": "lobby", "hardware_id": "0004f200000f", "site_id": "site-1"},
+	}, d.Get("unmanaged_phones"))
+
+	// Reads only get the phones of the inventory and keep the unmanaged phones of the last sync
+	diags = readPhonesInventory(context.Background(), d, meta)
+	assert.False(t, diags.HasError(), diags)
+	assert.Equal(t, 1, listed)
+	assert.ElementsMatch(t, []string{"phone-a", "phone-c", "phone-desk-d"}, getById)
+	assert.Equal(t, hashPhonesInventory(desired), d.Get("inventory_hash"))
+	var phoneIds []string
+	for _, phone := range d.Get("phones").([]interface{}) {
+		phoneIds = append(phoneIds, phone.(map[string]interface{})["phone_id"].(string))
+	}
+	assert.Equal(t, []string{"phone-a", "phone-c", "phone-desk-d"}, phoneIds)
+	assert.Equal(t, []interface{}{
+		map[string]interface{}{"phone_id": "phone-x", "name": "lobby", "hardware_id": "0004f200000f", "site_id": "site-1"},
+	}, d.Get("unmanaged_phones"))
+
+	// Models that match several phone base settings must be set in phone_base_settings_ids
+	created = nil
+	d = ResourcePhonesInventory().Data(d.State())
+	desired = append(desired, phoneInventoryEntry{hardwareId: "0004f200000e", name: "desk-e", model: "Polycom", siteId: "site-1"})
+	diags = syncPhonesInventory(context.Background(), d, internalProxy, desired)
+	assert.True(t, diags.HasError())
+	assert.Empty(t, created)
+
+	// Without adopt_existing, phones of the org with the hardware ID of a new row fail the sync before any change
+	created, updated, deleted = nil, nil, nil
+	_ = d.Set("adopt_existing", false)
+	desired = []phoneInventoryEntry{
+		{hardwareId: "0004f200000a", name: "desk-a", model: "Polycom VVX 410", siteId: "site-1"},
+		{hardwareId: "0004f200000f", name: "desk-f", model: "Polycom VVX 410", siteId: "site-1"},
+		{hardwareId: "0004f2000010", name: "desk-10", model: "Polycom VVX 410", siteId: "site-1"},
+	}
+	diags = syncPhonesInventory(context.Background(), d, internalProxy, desired)
+	assert.True(t, diags.HasError())
+	assert.Contains(t, fmt.Sprint(diags), "phone lobby phone-x has the hardware ID 0004f200000f")
+	assert.Empty(t, created)
+	assert.Empty(t, updated)
+	assert.Empty(t, deleted)
+
+	// Phones of the inventory deleted outside of Terraform are removed from the state
+	mutex.Lock()
+	delete(phones, "phone-c")
+	mutex.Unlock()
+	diags = readPhonesInventory(context.Background(), d, meta)
+	assert.False(t, diags.HasError(), diags)
+	phoneIds = nil
+	for _, p := range inventoryPhonesFromState(d) {
+		phoneIds = append(phoneIds, p.phoneId)
+	}
+	assert.Equal(t, []string{"phone-a", "phone-desk-d"}, phoneIds)
+}
+
+func TestUnitUpdatePhonesInventoryComputedPhones(t *testing.T) {
+	inventoryFile := filepath.Join(t.TempDir(), "inventory.csv")
+	err := os.WriteFile(inventoryFile, []byte("hardware_id,model,site_id,name\n"+
+		"0004f200000a,Polycom VVX 410,site-1,desk-a\n"+
+		"0004f200000c,Polycom VVX 410,site-1,desk-c\n"), 0644)
+	assert.Nil(t, err)
+
+	orgPhone := func(id, name, hardwareId string) platformclientv2.Phone {
+		return platformclientv2.Phone{
+			Id:                &id,
+			Name:              &name,
+			State:             platformclientv2.String("active"),
+			Site:              &platformclientv2.Domainentityref{Id: platformclientv2.String("site-1")},
+			PhoneBaseSettings: &platformclientv2.Phonebasesettings{Id: platformclientv2.String("base-1")},
+			LineBaseSettings:  &platformclientv2.Domainentityref{Id: platformclientv2.String("line-base-1")},
+			Lines:             &[]platformclientv2.Line{{Id: platformclientv2.String("line-" + id)}},
+			Properties: &map[string]interface{}{
+				hardwareIdProperty: map[string]interface{}{"value": map[string]interface{}{"instance": hardwareId}},
+			},
+		}
+	}
+
+	var mutex sync.Mutex
+	phones := map[string]platformclientv2.Phone{
+		"phone-a": orgPhone("phone-a", "desk-a", "0004f200000a"),
+		"phone-b": orgPhone("phone-b", "desk-b", "0004f200000b"),
+	}
+	var calls []string
+	internalProxy = &phoneProxy{
+		getPhoneByIdAttr: func(ctx context.Context, p *phoneProxy, phoneId string) (*platformclientv2.Phone, *platformclientv2.APIResponse, error) {
+			mutex.Lock()
+			defer mutex.Unlock()
+			phone, ok := phones[phoneId]
+			if !ok {
+				return nil, &platformclientv2.APIResponse{StatusCode: http.StatusNotFound}, fmt.Errorf("phone %s not found", phoneId)
+			}
+			return &phone, &platformclientv2.APIResponse{StatusCode: http.StatusOK}, nil
+		},
+		getAllPhonesAttr: func(ctx context.Context, p *phoneProxy) (*[]platformclientv2.Phone, *platformclientv2.APIResponse, error) {
+			mutex.Lock()
+			defer mutex.Unlock()
+			all := make([]platformclientv2.Phone, 0, len(phones))
+			for _, phone := range phones {
+				all = append(all, phone)
+			}
+			return &all, &platformclientv2.APIResponse{StatusCode: http.StatusOK}, nil
+		},
+		createPhoneAttr: func(ctx context.Context, p *phoneProxy, phoneConfig *platformclientv2.Phone) (*platformclientv2.Phone, *platformclientv2.APIResponse, error) {
+			mutex.Lock()
+			defer mutex.Unlock()
+			phone := *phoneConfig
+			phone.Id = platformclientv2.String("phone-" + *phone.Name)
+			phones[*phone.Id] = phone
+			calls = append(calls, "create "+*phone.Name)
+			return &phone, &platformclientv2.APIResponse{StatusCode: http.StatusOK}, nil
+		},
+		updatePhoneAttr: func(ctx context.Context, p *phoneProxy, phoneId string, phoneConfig *platformclientv2.Phone) (*platformclientv2.Phone, *platformclientv2.APIResponse, error) {
+			mutex.Lock()
+			defer mutex.Unlock()
+			calls = append(calls, "update "+phoneId)
+			return phoneConfig, &platformclientv2.APIResponse{StatusCode: http.StatusOK}, nil
+		},
+		deletePhoneAttr: func(ctx context.Context, p *phoneProxy, phoneId string) (*platformclientv2.APIResponse, error) {
+			mutex.Lock()
+			defer mutex.Unlock()
+			delete(phones, phoneId)
+			calls = append(calls, "delete "+phoneId)
+			return &platformclientv2.APIResponse{StatusCode: http.StatusNoContent}, nil
+		},
+		getAllPhoneBaseSettingsAttr: func(ctx context.Context, p *phoneProxy) (*[]platformclientv2.Phonebase, *platformclientv2.APIResponse, error) {
+			return &[]platformclientv2.Phonebase{
+				{Id: platformclientv2.String("base-1"), Name: platformclientv2.String("Desk phones"), PhoneMetaBase: &platformclientv2.Domainentityref{Name: platformclientv2.String("Polycom VVX 410")}},
+			}, &platformclientv2.APIResponse{StatusCode: http.StatusOK}, nil
+		},
+		getPhoneBaseSettingAttr: func(ctx context.Context, p *phoneProxy, phoneBaseSettingsId string) (*platformclientv2.Phonebase, *platformclientv2.APIResponse, error) {
+			return &platformclientv2.Phonebase{
+				Id:            &phoneBaseSettingsId,
+				Lines:         &[]platformclientv2.Linebase{{Id: platformclientv2.String("line-" + phoneBaseSettingsId)}},
+				PhoneMetaBase: &platformclientv2.Domainentityref{Id: platformclientv2.String("polycom_vvx.json")},
+			}, &platformclientv2.APIResponse{StatusCode: http.StatusOK}, nil
+		},
+		getStationOfLineAttr: func(ctx context.Context, p *phoneProxy, lineId string) (*platformclientv2.Station, bool, *platformclientv2.APIResponse, error) {
+			return &platformclientv2.Station{Id: platformclientv2.String("station-" + lineId), Status: platformclientv2.String("AVAILABLE")}, false, &platformclientv2.APIResponse{StatusCode: http.StatusOK}, nil
+		},
+	}
+	defer func() { internalProxy = nil }()
+
+	current := []inventoryPhone{
+		{phoneInventoryEntry: phoneInventoryEntry{hardwareId: "0004f200000a", name: "desk-a", model: "Polycom VVX 410", siteId: "site-1"}, phoneId: "phone-a", phoneBaseSettingsId: "base-1", lineBaseSettingsId: "line-base-1"},
+		{phoneInventoryEntry: phoneInventoryEntry{hardwareId: "0004f200000b", name: "desk-b", model: "Polycom VVX 410", siteId: "site-1"}, phoneId: "phone-b", phoneBaseSettingsId: "base-1", lineBaseSettingsId: "line-base-1"},
+	}
+	stateData := ResourcePhonesInventory().Data(&terraform.InstanceState{ID: "inventory-id", Attributes: map[string]string{
+		"inventory_file": inventoryFile,
+		"batch_size":     "2",
+		"inventory_hash": hashPhonesInventory([]phoneInventoryEntry{current[0].phoneInventoryEntry, current[1].phoneInventoryEntry}),
+	}})
+	setInventoryPhones(stateData, current)
+	state := stateData.State()
+
+	// The inventory file changed, so the plan marks phones as unknown
+	resourceSchema := ResourcePhonesInventory().Schema
+	config := terraform.NewResourceConfigRaw(map[string]interface{}{
+		"inventory_file": inventoryFile,
+		"batch_size":     2,
+	})
+	diff, err := schema.InternalMap(resourceSchema).Diff(context.Background(), state, config, customizePhonesInventoryDiff, nil, true)
+	assert.Nil(t, err)
+	assert.True(t, diff.Attributes["phones.#"].NewComputed)
+
+	d, err := schema.InternalMap(resourceSchema).Data(state, diff)
+	assert.Nil(t, err)
+	diags := updatePhonesInventory(context.Background(), d, &provider.ProviderMeta{ClientConfig: &platformclientv2.Configuration{}})
+	assert.False(t, diags.HasError(), diags)
+
+	// The unchanged phone is kept as it is, and the phone removed from the inventory is deleted
+	assert.Equal(t, []string{"delete phone-b", "create desk-c"}, calls)
+	var phoneIds []string
+	for _, p := range inventoryPhonesFromState(d) {
+		phoneIds = append(phoneIds, p.phoneId)
+	}
+	assert.Equal(t, []string{"phone-a", "phone-desk-c"}, phoneIds)
+}
@@ -0,0 +1,197 @@
+package telephony_providers_edges_site
+
+import (
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"log"
+	"strings"
+	"terraform-provider-genesyscloud/genesyscloud/provider"
+	"terraform-provider-genesyscloud/genesyscloud/util"
+	lists "terraform-provider-genesyscloud/genesyscloud/util/lists"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/nyaruka/phonenumbers"
+)
+
+/*
+   The data_source_genesyscloud_telephony_providers_edges_site_dial_plan_evaluation.go contains the
+   genesyscloud_telephony_providers_edges_site_dial_plan_evaluation data source. It builds the dial plan of a site from
+   the configuration or the API and evaluates the dialed strings with genesyscloud_telephony_providers_edges_site_dial_plan.go.
+*/
+
+// dataSourceSiteDialPlanEvaluationRead classifies and routes each of the dialed strings
+func dataSourceSiteDialPlanEvaluationRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	sdkConfig := meta.(*provider.ProviderMeta).ClientConfig
+	sp := GetSiteProxy(sdkConfig)
+
+	plan, diagErr := buildDialPlan(ctx, sp, d)
+	if diagErr != nil {
+		return diagErr
+	}
+
+	dialedStrings := lists.InterfaceListToStrings(d.Get("dialed_strings").([]interface{}))
+	results := make([]interface{}, 0, len(dialedStrings))
+	for _, dialedString := range dialedStrings {
+		result, err := plan.dial(dialedString)
+		if err != nil {
+			return util.BuildDiagnosticError(DialPlanEvaluationResourceType, fmt.Sprintf("failed to evaluate dialed string %s", dialedString), err)
+		}
+		results = append(results, flattenDialResult(result))
+	}
+
+	siteId := d.Get("site_id").(string)
+	if siteId != "" {
+		d.SetId(siteId)
+	} else {
+		d.SetId(fmt.Sprintf("%x", sha256.Sum256([]byte(strings.Join(dialedStrings, "\n")))))
+	}
+	_ = d.Set("country", plan.region)
+	_ = d.Set("results", results)
+
+	log.Printf("Evaluated %d dialed strings against %d number plans and %d outbound routes", len(dialedStrings), len(plan.numberPlans), len(plan.outboundRoutes))
+	return nil
+}
+
+// buildDialPlan reads the number plans and outbound routes from the configuration, falling back to those of the site
+func buildDialPlan(ctx context.Context, sp *SiteProxy, d *schema.ResourceData) (*dialPlan, diag.Diagnostics) {
+	siteId := d.Get("site_id").(string)
+	plan := &dialPlan{region: strings.ToUpper(d.Get("country").(string))}
+
+	if configPlans := d.Get("number_plans").([]interface{}); len(configPlans) > 0 {
+		numberPlans, err := buildDialPlanNumberPlans(configPlans)
+		if err != nil {
+			return nil, util.BuildDiagnosticError(DialPlanEvaluationResourceType, "invalid number_plans", err)
+		}
+		plan.numberPlans = numberPlans
+	} else {
+		numberPlans, resp, err := sp.getSiteNumberPlans(ctx, siteId)
+		if err != nil {
+			return nil, util.BuildAPIDiagnosticError(DialPlanEvaluationResourceType, fmt.Sprintf("failed to get number plans for site %s | error: %s", siteId, err), resp)
+		}
+		if plan.numberPlans, err = numberPlansFromSdk(*numberPlans); err != nil {
+			return nil, util.BuildDiagnosticError(DialPlanEvaluationResourceType, fmt.Sprintf("failed to read number plans for site %s", siteId), err)
+		}
+	}
+
+	if configRoutes := d.Get("outbound_routes").([]interface{}); len(configRoutes) > 0 {
+		plan.outboundRoutes = buildDialPlanOutboundRoutes(configRoutes)
+	} else if siteId != "" {
+		outboundRoutes, resp, err := sp.getSiteOutboundRoutes(ctx, siteId)
+		if err != nil {
+			return nil, util.BuildAPIDiagnosticError(DialPlanEvaluationResourceType, fmt.Sprintf("failed to get outbound routes for site %s | error: %s", siteId, err), resp)
+		}
+		plan.outboundRoutes = outboundRoutesFromSdk(*outboundRoutes)
+	}
+
+	if plan.region == "" && siteId != "" {
+		region, diagErr := getSiteCountry(ctx, sp, siteId)
+		if diagErr != nil {
+			return nil, diagErr
+		}
+		plan.region = region
+	}
+	if plan.region != "" && phonenumbers.GetCountryCodeForRegion(plan.region) == 0 {
+		return nil, util.BuildDiagnosticError(DialPlanEvaluationResourceType, "invalid country", fmt.Errorf("%s is not an ISO 3166 country code with a calling code", plan.region))
+	}
+	return plan, nil
+}
+
+// getSiteCountry returns the country of the location of the site
+func getSiteCountry(ctx context.Context, sp *SiteProxy, siteId string) (string, diag.Diagnostics) {
+	site, resp, err := sp.getSiteById(ctx, siteId)
+	if err != nil {
+		return "", util.BuildAPIDiagnosticError(DialPlanEvaluationResourceType, fmt.Sprintf("failed to read site %s | error: %s", siteId, err), resp)
+	}
+	if site.Location == nil || site.Location.Id == nil {
+		return "", nil
+	}
+
+	location := site.Location
+	if location.Address == nil || location.Address.Country == nil {
+		location, resp, err = sp.getLocation(ctx, *site.Location.Id)
+		if err != nil {
+			return "", util.BuildAPIDiagnosticError(DialPlanEvaluationResourceType, fmt.Sprintf("failed to read location %s of site %s | error: %s", *site.Location.Id, siteId, err), resp)
+		}
+	}
+	if location.Address == nil || location.Address.Country == nil {
+		return "", nil
+	}
+	return strings.ToUpper(*location.Address.Country), nil
+}
+
+func buildDialPlanNumberPlans(configPlans []interface{}) ([]*dialPlanNumberPlan, error) {
+	plans := make([]*dialPlanNumberPlan, 0, len(configPlans))
+	for _, configPlan := range configPlans {
+		planMap := configPlan.(map[string]interface{})
+		plan := dialPlanNumberPlan{
+			name:             planMap["name"].(string),
+			matchType:        planMap["match_type"].(string),
+			match:            planMap["match_format"].(string),
+			normalizedFormat: planMap["normalized_format"].(string),
+			classification:   planMap["classification"].(string),
+		}
+		if numbers, ok := planMap["numbers"].([]interface{}); ok {
+			for _, number := range numbers {
+				if numberMap, ok := number.(map[string]interface{}); ok {
+					plan.numbers = append(plan.numbers, numberRange{start: numberMap["start"].(string), end: numberMap["end"].(string)})
+				}
+			}
+		}
+		if digitLength, ok := planMap["digit_length"].([]interface{}); ok && len(digitLength) > 0 && digitLength[0] != nil {
+			digitLengthMap := digitLength[0].(map[string]interface{})
+			plan.digitLength = &numberRange{start: digitLengthMap["start"].(string), end: digitLengthMap["end"].(string)}
+		}
+
+		compiled, err := newDialPlanNumberPlan(plan)
+		if err != nil {
+			return nil, err
+		}
+		plans = append(plans, compiled)
+	}
+	return plans, nil
+}
+
+func buildDialPlanOutboundRoutes(configRoutes []interface{}) []*dialPlanOutboundRoute {
+	routes := make([]*dialPlanOutboundRoute, 0, len(configRoutes))
+	for _, configRoute := range configRoutes {
+		routeMap := configRoute.(map[string]interface{})
+		routes = append(routes, &dialPlanOutboundRoute{
+			name:                 routeMap["name"].(string),
+			classificationTypes:  lists.InterfaceListToStrings(routeMap["classification_types"].([]interface{})),
+			enabled:              routeMap["enabled"].(bool),
+			distribution:         routeMap["distribution"].(string),
+			externalTrunkBaseIds: lists.InterfaceListToStrings(routeMap["external_trunk_base_ids"].([]interface{})),
+		})
+	}
+	return routes
+}
+
+func flattenDialResult(result *dialResult) map[string]interface{} {
+	resultMap := map[string]interface{}{
+		"dialed_string":           result.dialedString,
+		"number_plan_name":        "",
+		"match_type":              "",
+		"classification":          "",
+		"normalized_number":       "",
+		"outbound_route_id":       "",
+		"outbound_route_name":     "",
+		"distribution":            "",
+		"external_trunk_base_ids": []interface{}{},
+		"routable":                result.outboundRoute != nil,
+	}
+	if result.numberPlan != nil {
+		resultMap["number_plan_name"] = result.numberPlan.name
+		resultMap["match_type"] = result.numberPlan.matchType
+		resultMap["classification"] = result.numberPlan.classification
+		resultMap["normalized_number"] = result.normalizedNumber
+	}
+	if result.outboundRoute != nil {
+		resultMap["outbound_route_id"] = result.outboundRoute.id
+		resultMap["outbound_route_name"] = result.outboundRoute.name
+		resultMap["distribution"] = result.outboundRoute.distribution
+		resultMap["external_trunk_base_ids"] = lists.StringListToInterfaceList(result.outboundRoute.externalTrunkBaseIds)
+	}
+	return resultMap
+}
@@ -0,0 +1,196 @@
+package telephony_providers_edges_site
+
+import (
+	"context"
+	"net/http"
+	"terraform-provider-genesyscloud/genesyscloud/provider"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/mypurecloud/platform-client-sdk-go/v149/platformclientv2"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUnitDataSourceSiteDialPlanEvaluation(t *testing.T) {
+	numberRanges := func(ranges ...[2]string) *[]platformclientv2.Number {
+		numbers := make([]platformclientv2.Number, 0, len(ranges))
+		for _, r := range ranges {
+			number := platformclientv2.Number{Start: platformclientv2.String(r[0])}
+			if r[1] != "" {
+				number.End = platformclientv2.String(r[1])
+			}
+			numbers = append(numbers, number)
+		}
+		return &numbers
+	}
+	numberPlans := []platformclientv2.Numberplan{
+		{
+			Name:           platformclientv2.String("International"),
+			MatchType:      platformclientv2.String("interCountryCode"),
+			Classification: platformclientv2.String("International"),
+			Priority:       platformclientv2.Int(5),
+		},
+		{
+			Name:           platformclientv2.String("Emergency"),
+			MatchType:      platformclientv2.String("numberList"),
+			Numbers:        numberRanges([2]string{"911", ""}, [2]string{"933", ""}),
+			Classification: platformclientv2.String("Emergency"),
+			Priority:       platformclientv2.Int(1),
+		},
+		{
+			Name:           platformclientv2.String("Extension"),
+			MatchType:      platformclientv2.String("digitLength"),
+			DigitLength:    &platformclientv2.Digitlength{Start: platformclientv2.String("4"), End: platformclientv2.String("6")},
+			Classification: platformclientv2.String("Extension"),
+			Priority:       platformclientv2.Int(2),
+		},
+		{
+			Name:             platformclientv2.String("Outside line"),
+			MatchType:        platformclientv2.String("regex"),
+			Match:            platformclientv2.String(`9(1\d{10})`),
+			NormalizedFormat: platformclientv2.String("+$1"),
+			Classification:   platformclientv2.String("National"),
+			Priority:         platformclientv2.Int(3),
+		},
+		{
+			Name:           platformclientv2.String("National"),
+			MatchType:      platformclientv2.String("intraCountryCode"),
+			Classification: platformclientv2.String("National"),
+			Priority:       platformclientv2.Int(4),
+		},
+	}
+	outboundRoutes := []platformclientv2.Outboundroutebase{
+		{
+			Id:                  platformclientv2.String("disabled-route-id"),
+			Name:                platformclientv2.String("Disabled"),
+			ClassificationTypes: &[]string{"National", "International"},
+			Enabled:             platformclientv2.Bool(false),
+			Distribution:        platformclientv2.String("SEQUENTIAL"),
+		},
+		{
+			Id:                  platformclientv2.String("route-id"),
+			Name:                platformclientv2.String("PSTN"),
+			ClassificationTypes: &[]string{"National", "International", "Emergency"},
+			Enabled:             platformclientv2.Bool(true),
+			Distribution:        platformclientv2.String("SEQUENTIAL"),
+			ExternalTrunkBases:  &[]platformclientv2.Domainentityref{{Id: platformclientv2.String("trunk-a")}, {Id: platformclientv2.String("trunk-b")}},
+		},
+	}
+
+	internalProxy = &SiteProxy{
+		getSiteByIdAttr: func(ctx context.Context, p *SiteProxy, siteId string) (*platformclientv2.Site, *platformclientv2.APIResponse, error) {
+			return &platformclientv2.Site{
+				Id:       &siteId,
+				Location: &platformclientv2.Locationdefinition{Id: platformclientv2.String("location-id")},
+			}, &platformclientv2.APIResponse{StatusCode: http.StatusOK}, nil
+		},
+		getLocationAttr: func(ctx context.Context, p *SiteProxy, locationId string) (*platformclientv2.Locationdefinition, *platformclientv2.APIResponse, error) {
+			return &platformclientv2.Locationdefinition{
+				Id:      &locationId,
+				Address: &platformclientv2.Locationaddress{Country: platformclientv2.String("US")},
+			}, &platformclientv2.APIResponse{StatusCode: http.StatusOK}, nil
+		},
+		getSiteNumberPlansAttr: func(ctx context.Context, p *SiteProxy, siteId string) (*[]platformclientv2.Numberplan, *platformclientv2.APIResponse, error) {
+			return &numberPlans, &platformclientv2.APIResponse{StatusCode: http.StatusOK}, nil
+		},
+		getSiteOutboundRoutesAttr: func(ctx context.Context, p *SiteProxy, siteId string) (*[]platformclientv2.Outboundroutebase, *platformclientv2.APIResponse, error) {
+			return &outboundRoutes, &platformclientv2.APIResponse{StatusCode: http.StatusOK}, nil
+		},
+	}
+	defer func() { internalProxy = nil }()
+
+	dataSource := DataSourceSiteDialPlanEvaluation()
+	meta := &provider.ProviderMeta{ClientConfig: &platformclientv2.Configuration{}}
+
+	result := func(dialedString, planName, matchType, classification, normalized string, routed bool) map[string]interface{} {
+		resultMap := map[string]interface{}{
+			"dialed_string":           dialedString,
+			"number_plan_name":        planName,
+			"match_type":              matchType,
+			"classification":          classification,
+			"normalized_number":       normalized,
+			"outbound_route_id":       "",
+			"outbound_route_name":     "",
+			"distribution":            "",
+			"external_trunk_base_ids": []interface{}{},
+			"routable":                routed,
+		}
+		if routed {
+			resultMap["outbound_route_id"] = "route-id"
+			resultMap["outbound_route_name"] = "PSTN"
+			resultMap["distribution"] = "SEQUENTIAL"
+			resultMap["external_trunk_base_ids"] = []interface{}{"trunk-a", "trunk-b"}
+		}
+		return resultMap
+	}
+
+	d := schema.TestResourceDataRaw(t, dataSource.Schema, map[string]interface{}{
+		"site_id":        "site-id",
+		"dialed_strings": []interface{}{"911", "1234", "913175550100", "(317) 555-0100", "+44 20 7946 0000", "12"},
+	})
+	diags := dataSourceSiteDialPlanEvaluationRead(context.Background(), d, meta)
+	assert.False(t, diags.HasError(), diags)
+	assert.Equal(t, "site-id", d.Id())
+	assert.Equal(t, "US", d.Get("country"))
+	assert.Equal(t, []interface{}{
+		result("911", "Emergency", "numberList", "Emergency", "911", true),
+		result("1234", "Extension", "digitLength", "Extension", "1234", false),
+		result("913175550100", "Outside line", "regex", "National", "+13175550100", true),
+		result("(317) 555-0100", "National", "intraCountryCode", "National", "+13175550100", true),
+		result("+44 20 7946 0000", "International", "interCountryCode", "International", "+442079460000", true),
+		result("12", "", "", "", "", false),
+	}, d.Get("results"))
+
+	// Plans and routes set on the data source replace those of the site
+	d = schema.TestResourceDataRaw(t, dataSource.Schema, map[string]interface{}{
+		"country": "gb",
+		"number_plans": []interface{}{
+			map[string]interface{}{
+				"name":           "Premium",
+				"match_type":     "e164NumberList",
+				"numbers":        []interface{}{map[string]interface{}{"start": "+449000000000", "end": "+449099999999"}},
+				"classification": "Premium",
+			},
+			map[string]interface{}{
+				"name":           "National",
+				"match_type":     "intraCountryCode",
+				"classification": "National",
+			},
+		},
+		"outbound_routes": []interface{}{
+			map[string]interface{}{
+				"name":                 "UK",
+				"classification_types": []interface{}{"National"},
+				"enabled":              true,
+				"distribution":         "RANDOM",
+			},
+		},
+		"dialed_strings": []interface{}{"09001234567", "020 7946 0000"},
+	})
+	diags = dataSourceSiteDialPlanEvaluationRead(context.Background(), d, meta)
+	assert.False(t, diags.HasError(), diags)
+	assert.Equal(t, "GB", d.Get("country"))
+	national := result("020 7946 0000", "National", "intraCountryCode", "National", "+442079460000", false)
+	national["outbound_route_name"] = "UK"
+	national["distribution"] = "RANDOM"
+	national["routable"] = true
+	assert.Equal(t, []interface{}{
+		result("09001234567", "Premium", "e164NumberList", "Premium", "+449001234567", false),
+		national,
+	}, d.Get("results"))
+
+	// Invalid regular expressions are reported
+	d = schema.TestResourceDataRaw(t, dataSource.Schema, map[string]interface{}{
+		"number_plans": []interface{}{
+			map[string]interface{}{
+				"name":           "Broken",
+				"match_type":     "regex",
+				"match_format":   "(1",
+				"classification": "National",
+			},
+		},
+		"dialed_strings": []interface{}{"1"},
+	})
+	diags = dataSourceSiteDialPlanEvaluationRead(context.Background(), d, meta)
+	assert.True(t, diags.HasError())
+}
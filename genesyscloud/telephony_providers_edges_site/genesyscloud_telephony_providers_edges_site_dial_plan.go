@@ -0,0 +1,286 @@
+package telephony_providers_edges_site
+
+import (
+	"fmt"
+	"math"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"terraform-provider-genesyscloud/genesyscloud/util"
+	lists "terraform-provider-genesyscloud/genesyscloud/util/lists"
+
+	"github.com/mypurecloud/platform-client-sdk-go/v149/platformclientv2"
+	"github.com/nyaruka/phonenumbers"
+)
+
+/*
+   The genesyscloud_telephony_providers_edges_site_dial_plan.go file evaluates dialed strings against the number plans
+   and outbound routes of a site the way the edges do: the number plans are tried in priority order, the first plan
+   that matches normalizes the number and classifies it, and the first enabled outbound route carrying that
+   classification selects the external trunks the call is placed on.
+*/
+
+const (
+	matchTypeDigitLength      = "digitLength"
+	matchTypeE164NumberList   = "e164NumberList"
+	matchTypeInterCountryCode = "interCountryCode"
+	matchTypeIntraCountryCode = "intraCountryCode"
+	matchTypeNumberList       = "numberList"
+	matchTypeRegex            = "regex"
+)
+
+var (
+	// dialedStringSeparators are the characters people put in phone numbers for readability
+	dialedStringSeparators = strings.NewReplacer(" ", "", "-", "", ".", "", "(", "", ")", "")
+
+	digitsRegex = regexp.MustCompile(`^[0-9]+$`)
+
+	// captureGroupRegex finds the $1 style references of a normalized format, which Go reads as named groups
+	// when they are followed by letters or digits
+	captureGroupRegex = regexp.MustCompile(`\$([0-9]+)`)
+)
+
+type numberRange struct {
+	start string
+	end   string
+}
+
+type dialPlanNumberPlan struct {
+	name             string
+	matchType        string
+	match            string
+	normalizedFormat string
+	classification   string
+	numbers          []numberRange
+	digitLength      *numberRange
+
+	regex          *regexp.Regexp
+	minDigitLength int
+	maxDigitLength int
+}
+
+type dialPlanOutboundRoute struct {
+	id                   string
+	name                 string
+	classificationTypes  []string
+	enabled              bool
+	distribution         string
+	externalTrunkBaseIds []string
+}
+
+// dialPlan holds the number plans in priority order and the outbound routes of a site
+type dialPlan struct {
+	numberPlans    []*dialPlanNumberPlan
+	outboundRoutes []*dialPlanOutboundRoute
+
+	// region is the ISO 3166 country of the site, which intra and inter country code plans are relative to
+	region string
+}
+
+type dialResult struct {
+	dialedString     string
+	numberPlan       *dialPlanNumberPlan
+	normalizedNumber string
+	outboundRoute    *dialPlanOutboundRoute
+}
+
+// newDialPlanNumberPlan validates a number plan and compiles its match
+func newDialPlanNumberPlan(plan dialPlanNumberPlan) (*dialPlanNumberPlan, error) {
+	switch plan.matchType {
+	case matchTypeRegex:
+		if plan.match == "" {
+			return nil, fmt.Errorf("number plan %s has no match_format", plan.name)
+		}
+		regex, err := regexp.Compile(`^(?:` + plan.match + `)$`)
+		if err != nil {
+			return nil, fmt.Errorf("number plan %s has an invalid match_format %s: %v", plan.name, plan.match, err)
+		}
+		plan.regex = regex
+	case matchTypeDigitLength:
+		if plan.digitLength == nil || plan.digitLength.start == "" {
+			return nil, fmt.Errorf("number plan %s has no digit_length", plan.name)
+		}
+		var err error
+		if plan.minDigitLength, err = strconv.Atoi(plan.digitLength.start); err != nil {
+			return nil, fmt.Errorf("number plan %s has an invalid digit_length start %s", plan.name, plan.digitLength.start)
+		}
+		plan.maxDigitLength = plan.minDigitLength
+		if plan.digitLength.end != "" {
+			if plan.maxDigitLength, err = strconv.Atoi(plan.digitLength.end); err != nil {
+				return nil, fmt.Errorf("number plan %s has an invalid digit_length end %s", plan.name, plan.digitLength.end)
+			}
+		}
+	case matchTypeNumberList, matchTypeE164NumberList:
+		for _, number := range plan.numbers {
+			start, end := strings.TrimPrefix(number.start, "+"), strings.TrimPrefix(number.end, "+")
+			if !digitsRegex.MatchString(start) || (end != "" && (!digitsRegex.MatchString(end) || len(end) != len(start))) {
+				return nil, fmt.Errorf("number plan %s has an invalid number range %s-%s", plan.name, number.start, number.end)
+			}
+		}
+	case matchTypeInterCountryCode, matchTypeIntraCountryCode:
+	default:
+		return nil, fmt.Errorf("number plan %s has an unsupported match_type %s", plan.name, plan.matchType)
+	}
+	return &plan, nil
+}
+
+// numberPlansFromSdk converts the number plans of a site, ordering them by priority
+func numberPlansFromSdk(numberPlans []platformclientv2.Numberplan) ([]*dialPlanNumberPlan, error) {
+	sorted := make([]platformclientv2.Numberplan, len(numberPlans))
+	copy(sorted, numberPlans)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return priorityOf(sorted[i]) < priorityOf(sorted[j])
+	})
+
+	plans := make([]*dialPlanNumberPlan, 0, len(sorted))
+	for _, numberPlan := range sorted {
+		plan := dialPlanNumberPlan{
+			name:             util.StringOrEmpty(numberPlan.Name),
+			matchType:        util.StringOrEmpty(numberPlan.MatchType),
+			match:            util.StringOrEmpty(numberPlan.Match),
+			normalizedFormat: util.StringOrEmpty(numberPlan.NormalizedFormat),
+			classification:   util.StringOrEmpty(numberPlan.Classification),
+		}
+		if numberPlan.Numbers != nil {
+			for _, number := range *numberPlan.Numbers {
+				plan.numbers = append(plan.numbers, numberRange{start: util.StringOrEmpty(number.Start), end: util.StringOrEmpty(number.End)})
+			}
+		}
+		if numberPlan.DigitLength != nil {
+			plan.digitLength = &numberRange{start: util.StringOrEmpty(numberPlan.DigitLength.Start), end: util.StringOrEmpty(numberPlan.DigitLength.End)}
+		}
+
+		compiled, err := newDialPlanNumberPlan(plan)
+		if err != nil {
+			return nil, err
+		}
+		plans = append(plans, compiled)
+	}
+	return plans, nil
+}
+
+// outboundRoutesFromSdk converts the outbound routes of a site
+func outboundRoutesFromSdk(outboundRoutes []platformclientv2.Outboundroutebase) []*dialPlanOutboundRoute {
+	routes := make([]*dialPlanOutboundRoute, 0, len(outboundRoutes))
+	for _, outboundRoute := range outboundRoutes {
+		route := &dialPlanOutboundRoute{
+			id:           util.StringOrEmpty(outboundRoute.Id),
+			name:         util.StringOrEmpty(outboundRoute.Name),
+			enabled:      outboundRoute.Enabled != nil && *outboundRoute.Enabled,
+			distribution: util.StringOrEmpty(outboundRoute.Distribution),
+		}
+		if outboundRoute.ClassificationTypes != nil {
+			route.classificationTypes = *outboundRoute.ClassificationTypes
+		}
+		if outboundRoute.ExternalTrunkBases != nil {
+			for _, trunkBase := range *outboundRoute.ExternalTrunkBases {
+				if trunkBase.Id != nil {
+					route.externalTrunkBaseIds = append(route.externalTrunkBaseIds, *trunkBase.Id)
+				}
+			}
+		}
+		routes = append(routes, route)
+	}
+	return routes
+}
+
+// dial classifies a dialed string and selects the outbound route it is placed on
+func (p *dialPlan) dial(dialedString string) (*dialResult, error) {
+	result := &dialResult{dialedString: dialedString}
+	number := dialedStringSeparators.Replace(strings.TrimSpace(dialedString))
+
+	for _, plan := range p.numberPlans {
+		normalized, matched, err := p.match(plan, number)
+		if err != nil {
+			return nil, err
+		}
+		if matched {
+			result.numberPlan = plan
+			result.normalizedNumber = normalized
+			break
+		}
+	}
+	if result.numberPlan == nil {
+		return result, nil
+	}
+
+	for _, route := range p.outboundRoutes {
+		if route.enabled && lists.ItemInSlice(result.numberPlan.classification, route.classificationTypes) {
+			result.outboundRoute = route
+			break
+		}
+	}
+	return result, nil
+}
+
+// match reports whether the number plan matches the number and returns the normalized number
+func (p *dialPlan) match(plan *dialPlanNumberPlan, number string) (string, bool, error) {
+	switch plan.matchType {
+	case matchTypeRegex:
+		indexes := plan.regex.FindStringSubmatchIndex(number)
+		if indexes == nil {
+			return "", false, nil
+		}
+		if plan.normalizedFormat == "" {
+			return number, true, nil
+		}
+		template := captureGroupRegex.ReplaceAllString(plan.normalizedFormat, `$${${1}}`)
+		return string(plan.regex.ExpandString(nil, template, number, indexes)), true, nil
+	case matchTypeDigitLength:
+		matched := digitsRegex.MatchString(number) && len(number) >= plan.minDigitLength && len(number) <= plan.maxDigitLength
+		return number, matched, nil
+	case matchTypeNumberList:
+		return number, digitsRegex.MatchString(number) && inNumberRanges(number, plan.numbers), nil
+	case matchTypeE164NumberList:
+		e164, ok := p.toE164(number)
+		return e164, ok && inNumberRanges(strings.TrimPrefix(e164, "+"), plan.numbers), nil
+	case matchTypeInterCountryCode, matchTypeIntraCountryCode:
+		if p.region == "" {
+			return "", false, fmt.Errorf("number plan %s is relative to the country of the site, set country to evaluate it", plan.name)
+		}
+		phoneNumber, err := phonenumbers.Parse(number, p.region)
+		if err != nil || !phonenumbers.IsPossibleNumber(phoneNumber) {
+			return "", false, nil
+		}
+		sameCountry := int(phoneNumber.GetCountryCode()) == phonenumbers.GetCountryCodeForRegion(p.region)
+		return phonenumbers.Format(phoneNumber, phonenumbers.E164), sameCountry == (plan.matchType == matchTypeIntraCountryCode), nil
+	}
+	return "", false, nil
+}
+
+// toE164 formats a number dialed with a leading + or in the national format of the site
+func (p *dialPlan) toE164(number string) (string, bool) {
+	if !strings.HasPrefix(number, "+") && p.region == "" {
+		return "", false
+	}
+	phoneNumber, err := phonenumbers.Parse(number, p.region)
+	if err != nil || !phonenumbers.IsPossibleNumber(phoneNumber) {
+		return "", false
+	}
+	return phonenumbers.Format(phoneNumber, phonenumbers.E164), true
+}
+
+// inNumberRanges reports whether the digits equal a number or fall within a range of numbers of the same length
+func inNumberRanges(digits string, ranges []numberRange) bool {
+	for _, r := range ranges {
+		start, end := strings.TrimPrefix(r.start, "+"), strings.TrimPrefix(r.end, "+")
+		if end == "" {
+			if digits == start {
+				return true
+			}
+			continue
+		}
+		if len(digits) == len(start) && digits >= start && digits <= end {
+			return true
+		}
+	}
+	return false
+}
+
+func priorityOf(numberPlan platformclientv2.Numberplan) int {
+	if numberPlan.Priority == nil {
+		return math.MaxInt
+	}
+	return *numberPlan.Priority
+}
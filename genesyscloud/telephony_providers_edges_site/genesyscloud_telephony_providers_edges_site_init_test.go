@@ -47,6 +47,7 @@ func (r *registerTestInstance) registerTestDataSources() {
 	defer r.datasourceMapMutex.Unlock()
 
 	providerDataSources[ResourceType] = DataSourceSite()
+	providerDataSources[DialPlanEvaluationResourceType] = DataSourceSiteDialPlanEvaluation()
 	providerDataSources["genesyscloud_organizations_me"] = gcloud.DataSourceOrganizationsMe()
 }
 
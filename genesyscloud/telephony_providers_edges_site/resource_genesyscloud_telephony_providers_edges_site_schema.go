@@ -20,7 +20,10 @@ resource_genesyscloud_telephony_providers_edges_site_schema.go should hold four
 3.  The datasource schema definitions for the telephony_providers_edges_site datasource.
 4.  The resource exporter configuration for the telephony_providers_edges_site exporter.
 */
-const ResourceType = "genesyscloud_telephony_providers_edges_site"
+const (
+	ResourceType                   = "genesyscloud_telephony_providers_edges_site"
+	DialPlanEvaluationResourceType = "genesyscloud_telephony_providers_edges_site_dial_plan_evaluation"
+)
 
 // used in sdk authorization for tests
 var (
@@ -29,7 +32,7 @@ var (
 )
 
 var (
-	// This is outside the ResourceSite because it is used in a utility function.
+	// These are outside the ResourceSite because they are used in a utility function and the dial plan evaluation data source.
 	outboundRouteSchema = &schema.Resource{
 		Schema: map[string]*schema.Schema{
 			"name": {
@@ -69,44 +72,8 @@ var (
 			},
 		},
 	}
-)
 
-// SetRegistrar registers all of the resources, datasources and exporters in the package
-func SetRegistrar(l registrar.Registrar) {
-	l.RegisterDataSource(ResourceType, DataSourceSite())
-	l.RegisterResource(ResourceType, ResourceSite())
-	l.RegisterExporter(ResourceType, SiteExporter())
-}
-
-// ResourceSite registers the genesyscloud_telephony_providers_edges_site resource with Terraform
-func ResourceSite() *schema.Resource {
-	edgeAutoUpdateConfigSchema := &schema.Resource{
-		Schema: map[string]*schema.Schema{
-			"time_zone": {
-				Description: "The timezone of the window in which any updates to the edges assigned to the site can be applied. The minimum size of the window is 2 hours.",
-				Type:        schema.TypeString,
-				Required:    true,
-			},
-			"rrule": {
-				Description:      "A reoccurring rule for updating the Edges assigned to the site. The only supported frequencies are daily and weekly. Weekly frequencies require a day list with at least oneday specified. All other configurations are not supported.",
-				Type:             schema.TypeString,
-				Required:         true,
-				ValidateDiagFunc: validators.ValidateRrule,
-			},
-			"start": {
-				Description: "Date time is represented as an ISO-8601 string without a timezone. For example: yyyy-MM-ddTHH:mm:ss.SSS",
-				Type:        schema.TypeString,
-				Required:    true,
-			},
-			"end": {
-				Description: "Date time is represented as an ISO-8601 string without a timezone. For example: yyyy-MM-ddTHH:mm:ss.SSS",
-				Type:        schema.TypeString,
-				Required:    true,
-			},
-		},
-	}
-
-	numberPlansSchema := &schema.Resource{
+	numberPlansSchema = &schema.Resource{
 		Schema: map[string]*schema.Schema{
 			"name": {
 				Description: "The name of the entity.",
@@ -170,6 +137,43 @@ func ResourceSite() *schema.Resource {
 			},
 		},
 	}
+)
+
+// SetRegistrar registers all of the resources, datasources and exporters in the package
+func SetRegistrar(l registrar.Registrar) {
+	l.RegisterDataSource(ResourceType, DataSourceSite())
+	l.RegisterDataSource(DialPlanEvaluationResourceType, DataSourceSiteDialPlanEvaluation())
+	l.RegisterResource(ResourceType, ResourceSite())
+	l.RegisterExporter(ResourceType, SiteExporter())
+}
+
+// ResourceSite registers the genesyscloud_telephony_providers_edges_site resource with Terraform
+func ResourceSite() *schema.Resource {
+	edgeAutoUpdateConfigSchema := &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"time_zone": {
+				Description: "The timezone of the window in which any updates to the edges assigned to the site can be applied. The minimum size of the window is 2 hours.",
+				Type:        schema.TypeString,
+				Required:    true,
+			},
+			"rrule": {
+				Description:      "A reoccurring rule for updating the Edges assigned to the site. The only supported frequencies are daily and weekly. Weekly frequencies require a day list with at least oneday specified. All other configurations are not supported.",
+				Type:             schema.TypeString,
+				Required:         true,
+				ValidateDiagFunc: validators.ValidateRrule,
+			},
+			"start": {
+				Description: "Date time is represented as an ISO-8601 string without a timezone. For example: yyyy-MM-ddTHH:mm:ss.SSS",
+				Type:        schema.TypeString,
+				Required:    true,
+			},
+			"end": {
+				Description: "Date time is represented as an ISO-8601 string without a timezone. For example: yyyy-MM-ddTHH:mm:ss.SSS",
+				Type:        schema.TypeString,
+				Required:    true,
+			},
+		},
+	}
 
 	return &schema.Resource{
 		Description: "Genesys Cloud Site",
@@ -307,3 +311,104 @@ func DataSourceSite() *schema.Resource {
 		},
 	}
 }
+
+// DataSourceSiteDialPlanEvaluation registers the genesyscloud_telephony_providers_edges_site_dial_plan_evaluation data source
+func DataSourceSiteDialPlanEvaluation() *schema.Resource {
+	return &schema.Resource{
+		Description: "Data source that evaluates dialed strings against the number plans and outbound routes of a Genesys Cloud Site. Each dialed string is matched against the number plans in priority order, and the first enabled outbound route with the classification of the matched plan is selected. Use it to cover dial plans with `terraform test` assertions.",
+		ReadContext: provider.ReadWithPooledClient(dataSourceSiteDialPlanEvaluationRead),
+		Schema: map[string]*schema.Schema{
+			"site_id": {
+				Description:  "ID of the site whose number plans and outbound routes are evaluated. The number plans and outbound routes set on this data source take precedence over those of the site.",
+				Type:         schema.TypeString,
+				Optional:     true,
+				AtLeastOneOf: []string{"site_id", "number_plans"},
+			},
+			"number_plans": {
+				Description: "Number plans to evaluate in place of those of the site. The order of the plans determines their priority.",
+				Type:        schema.TypeList,
+				Optional:    true,
+				Elem:        numberPlansSchema,
+			},
+			"outbound_routes": {
+				Description: "Outbound routes to evaluate in place of those of the site. When several enabled routes have the classification of a number, the first one is selected.",
+				Type:        schema.TypeList,
+				Optional:    true,
+				Elem:        outboundRouteSchema,
+			},
+			"country": {
+				Description: "ISO 3166 country code that intraCountryCode and interCountryCode number plans are relative to, and that numbers dialed without a country code are read in. Defaults to the country of the location of the site.",
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+			},
+			"dialed_strings": {
+				Description: "The dialed strings to evaluate.",
+				Type:        schema.TypeList,
+				Required:    true,
+				MinItems:    1,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			"results": {
+				Description: "The evaluation of each dialed string, in the order of `dialed_strings`.",
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"dialed_string": {
+							Description: "The dialed string.",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"number_plan_name": {
+							Description: "Name of the number plan that matched the dialed string. Empty when no plan matched.",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"match_type": {
+							Description: "Match type of the number plan that matched the dialed string.",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"classification": {
+							Description: "Classification of the number plan that matched the dialed string.",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"normalized_number": {
+							Description: "The dialed string normalized by the number plan. Country code plans and E.164 number lists normalize to E.164, regex plans apply their normalized format.",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"outbound_route_id": {
+							Description: "ID of the selected outbound route. Empty for routes set on this data source.",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"outbound_route_name": {
+							Description: "Name of the selected outbound route.",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"distribution": {
+							Description: "Distribution of the selected outbound route over its trunks.",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"external_trunk_base_ids": {
+							Description: "External trunk base settings of the selected outbound route, in the order they are tried for SEQUENTIAL distribution.",
+							Type:        schema.TypeList,
+							Computed:    true,
+							Elem:        &schema.Schema{Type: schema.TypeString},
+						},
+						"routable": {
+							Description: "True if an enabled outbound route was selected for the dialed string.",
+							Type:        schema.TypeBool,
+							Computed:    true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
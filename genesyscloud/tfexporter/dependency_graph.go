@@ -0,0 +1,415 @@
+package tfexporter
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"path/filepath"
+	"sort"
+	"strings"
+	resourceExporter "terraform-provider-genesyscloud/genesyscloud/resource_exporter"
+	"terraform-provider-genesyscloud/genesyscloud/util/files"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+)
+
+/*
+   This file contains the dependency graph of an export. The graph is built from the references between the exported resources
+   (RefAttrs resolved to resource references), the depends_on dependencies discovered through the flow dependent consumers and
+   the references to resources that were not exported because they were filtered out. The graph can be written as DOT, Mermaid
+   and JSON files. Resources that depend on each other in a cycle are highlighted.
+*/
+
+const (
+	dependencyGraphFormatDOT     = "dot"
+	dependencyGraphFormatMermaid = "mermaid"
+	dependencyGraphFormatJSON    = "json"
+
+	dependencyGraphFileName = "dependency_graph"
+
+	dependencyEdgeReference = "reference"
+	dependencyEdgeDependsOn = "depends_on"
+)
+
+var dependencyGraphFormats = []string{dependencyGraphFormatDOT, dependencyGraphFormatMermaid, dependencyGraphFormatJSON}
+
+var dependencyGraphFileExtensions = map[string]string{
+	dependencyGraphFormatDOT:     "dot",
+	dependencyGraphFormatMermaid: "mmd",
+	dependencyGraphFormatJSON:    "json",
+}
+
+type DependencyGraph struct {
+	Nodes []DependencyGraphNode `json:"nodes"`
+	Edges []DependencyGraphEdge `json:"edges"`
+	// Groups of node addresses that depend on each other
+	Cycles [][]string `json:"cycles"`
+	// Cyclic flow dependencies reported by the dependent consumers
+	ReportedCycles []string `json:"reported_cycles,omitempty"`
+}
+
+type DependencyGraphNode struct {
+	// Resource address, or <type>::<id> for resources that were filtered out of the export
+	Address     string `json:"address"`
+	Type        string `json:"type"`
+	Id          string `json:"id,omitempty"`
+	DataSource  bool   `json:"data_source,omitempty"`
+	FilteredOut bool   `json:"filtered_out,omitempty"`
+	Cyclic      bool   `json:"cyclic,omitempty"`
+}
+
+type DependencyGraphEdge struct {
+	From   string `json:"from"`
+	To     string `json:"to"`
+	Kind   string `json:"kind"`
+	Cyclic bool   `json:"cyclic,omitempty"`
+}
+
+// graphReference is a reference from an exported resource to a resource ID that could not be resolved
+type graphReference struct {
+	from    string
+	refType string
+	refId   string
+}
+
+func filteredOutAddress(resType, id string) string {
+	return fmt.Sprintf("%s::%s", resType, id)
+}
+
+/*
+buildDependencyGraph builds the dependency graph of the exported resources.
+
+  - resourceTypesMaps and dataSourceTypesMaps hold the exported config which contains the resolved references and depends_on placeholders.
+  - resourceAddresses maps the ID of every exported resource to its address.
+  - dependsList holds the flow dependencies keyed by ID with values of the form <type>.<id>.
+  - unresolvedRefs holds the references which could not be resolved to an exported resource.
+*/
+func buildDependencyGraph(resourceTypesMaps map[string]resourceJSONMaps, dataSourceTypesMaps map[string]resourceJSONMaps, resourceAddresses map[string]string, dependsList map[string][]string, unresolvedRefs []graphReference, cyclicDependsList []string) *DependencyGraph {
+	nodes := make(map[string]*DependencyGraphNode)
+	for id, address := range resourceAddresses {
+		nodes[address] = &DependencyGraphNode{
+			Address:    address,
+			Type:       addressType(address),
+			Id:         id,
+			DataSource: strings.HasPrefix(address, "data."),
+		}
+	}
+	for _, address := range exportedAddresses(resourceTypesMaps, dataSourceTypesMaps) {
+		if _, ok := nodes[address]; !ok {
+			nodes[address] = &DependencyGraphNode{
+				Address:    address,
+				Type:       addressType(address),
+				DataSource: strings.HasPrefix(address, "data."),
+			}
+		}
+	}
+
+	edges := make(map[DependencyGraphEdge]bool)
+	addEdge := func(from, to, kind string) {
+		if _, ok := nodes[from]; !ok {
+			return
+		}
+		if _, ok := nodes[to]; !ok {
+			return
+		}
+		edges[DependencyGraphEdge{From: from, To: to, Kind: kind}] = true
+	}
+	addFilteredOutEdge := func(from, resType, id, kind string) {
+		to := filteredOutAddress(resType, id)
+		if _, ok := nodes[to]; !ok {
+			nodes[to] = &DependencyGraphNode{
+				Address:     to,
+				Type:        resType,
+				Id:          id,
+				FilteredOut: true,
+			}
+		}
+		addEdge(from, to, kind)
+	}
+
+	addConfigEdges := func(typesMaps map[string]resourceJSONMaps, isDataSource bool) {
+		for resType, resMaps := range typesMaps {
+			for resLabel, config := range resMaps {
+				from := resourceAddress(isDataSource, resType, resLabel)
+				for _, referenced := range findReferencedAddresses(config) {
+					addEdge(from, referenced.address, referenced.kind)
+				}
+			}
+		}
+	}
+	addConfigEdges(resourceTypesMaps, false)
+	addConfigEdges(dataSourceTypesMaps, true)
+
+	for id, dependencies := range dependsList {
+		from, ok := resourceAddresses[id]
+		if !ok {
+			continue
+		}
+		for _, dependency := range dependencies {
+			i := strings.LastIndex(dependency, ".")
+			if i < 0 {
+				continue
+			}
+			depType, depId := dependency[:i], dependency[i+1:]
+			if to, ok := resourceAddresses[depId]; ok {
+				addEdge(from, to, dependencyEdgeDependsOn)
+			} else {
+				addFilteredOutEdge(from, depType, depId, dependencyEdgeDependsOn)
+			}
+		}
+	}
+
+	for _, ref := range unresolvedRefs {
+		if _, ok := resourceAddresses[ref.refId]; ok {
+			// Resolved by a later pass of the dependency resolution
+			continue
+		}
+		addFilteredOutEdge(ref.from, ref.refType, ref.refId, dependencyEdgeReference)
+	}
+
+	graph := &DependencyGraph{
+		Nodes:          make([]DependencyGraphNode, 0, len(nodes)),
+		Edges:          make([]DependencyGraphEdge, 0, len(edges)),
+		Cycles:         make([][]string, 0),
+		ReportedCycles: cyclicDependsList,
+	}
+	for edge := range edges {
+		graph.Edges = append(graph.Edges, edge)
+	}
+	sort.Slice(graph.Edges, func(i, j int) bool {
+		a, b := graph.Edges[i], graph.Edges[j]
+		if a.From != b.From {
+			return a.From < b.From
+		}
+		if a.To != b.To {
+			return a.To < b.To
+		}
+		return a.Kind < b.Kind
+	})
+
+	cycleOf := make(map[string]int)
+	for _, cycle := range findCycles(graph.Edges) {
+		for _, address := range cycle {
+			cycleOf[address] = len(graph.Cycles)
+			nodes[address].Cyclic = true
+		}
+		graph.Cycles = append(graph.Cycles, cycle)
+	}
+	for i, edge := range graph.Edges {
+		fromCycle, fromOk := cycleOf[edge.From]
+		toCycle, toOk := cycleOf[edge.To]
+		graph.Edges[i].Cyclic = fromOk && toOk && fromCycle == toCycle
+	}
+
+	for _, node := range nodes {
+		graph.Nodes = append(graph.Nodes, *node)
+	}
+	sort.Slice(graph.Nodes, func(i, j int) bool {
+		return graph.Nodes[i].Address < graph.Nodes[j].Address
+	})
+	return graph
+}
+
+// findCycles returns the strongly connected components of the graph which contain a cycle, using Tarjan's algorithm.
+// The addresses of each cycle and the cycles themselves are sorted.
+func findCycles(edges []DependencyGraphEdge) [][]string {
+	adjacent := make(map[string][]string)
+	vertices := make([]string, 0)
+	seen := make(map[string]bool)
+	selfLoops := make(map[string]bool)
+	for _, edge := range edges {
+		adjacent[edge.From] = append(adjacent[edge.From], edge.To)
+		if edge.From == edge.To {
+			selfLoops[edge.From] = true
+		}
+		for _, v := range []string{edge.From, edge.To} {
+			if !seen[v] {
+				seen[v] = true
+				vertices = append(vertices, v)
+			}
+		}
+	}
+
+	index := 0
+	indexes := make(map[string]int)
+	lowLinks := make(map[string]int)
+	onStack := make(map[string]bool)
+	stack := make([]string, 0)
+	cycles := make([][]string, 0)
+
+	var strongConnect func(v string)
+	strongConnect = func(v string) {
+		indexes[v] = index
+		lowLinks[v] = index
+		index++
+		stack = append(stack, v)
+		onStack[v] = true
+
+		for _, w := range adjacent[v] {
+			if _, visited := indexes[w]; !visited {
+				strongConnect(w)
+				lowLinks[v] = min(lowLinks[v], lowLinks[w])
+			} else if onStack[w] {
+				lowLinks[v] = min(lowLinks[v], indexes[w])
+			}
+		}
+
+		if lowLinks[v] == indexes[v] {
+			component := make([]string, 0)
+			for {
+				w := stack[len(stack)-1]
+				stack = stack[:len(stack)-1]
+				onStack[w] = false
+				component = append(component, w)
+				if w == v {
+					break
+				}
+			}
+			if len(component) > 1 || selfLoops[v] {
+				sort.Strings(component)
+				cycles = append(cycles, component)
+			}
+		}
+	}
+
+	for _, v := range vertices {
+		if _, visited := indexes[v]; !visited {
+			strongConnect(v)
+		}
+	}
+
+	sort.Slice(cycles, func(i, j int) bool {
+		return cycles[i][0] < cycles[j][0]
+	})
+	return cycles
+}
+
+func (graph *DependencyGraph) toDOT() string {
+	var b strings.Builder
+	b.WriteString("digraph genesyscloud {\n")
+	b.WriteString("  rankdir = \"LR\";\n")
+	b.WriteString("  node [shape = \"box\"];\n\n")
+	for _, node := range graph.Nodes {
+		attrs := make([]string, 0)
+		if node.DataSource {
+			attrs = append(attrs, `shape = "ellipse"`)
+		}
+		if node.FilteredOut {
+			attrs = append(attrs, `style = "dashed"`, `color = "gray"`, fmt.Sprintf("label = %q", node.Address+"\n(filtered out)"))
+		}
+		if node.Cyclic {
+			attrs = append(attrs, `color = "red"`)
+		}
+		if len(attrs) > 0 {
+			b.WriteString(fmt.Sprintf("  %q [%s];\n", node.Address, strings.Join(attrs, ", ")))
+		} else {
+			b.WriteString(fmt.Sprintf("  %q;\n", node.Address))
+		}
+	}
+	b.WriteString("\n")
+	for _, edge := range graph.Edges {
+		attrs := make([]string, 0)
+		if edge.Kind == dependencyEdgeDependsOn {
+			attrs = append(attrs, `style = "dashed"`)
+		}
+		if edge.Cyclic {
+			attrs = append(attrs, `color = "red"`)
+		}
+		if len(attrs) > 0 {
+			b.WriteString(fmt.Sprintf("  %q -> %q [%s];\n", edge.From, edge.To, strings.Join(attrs, ", ")))
+		} else {
+			b.WriteString(fmt.Sprintf("  %q -> %q;\n", edge.From, edge.To))
+		}
+	}
+	b.WriteString("}\n")
+	return b.String()
+}
+
+func (graph *DependencyGraph) toMermaid() string {
+	var b strings.Builder
+	b.WriteString("flowchart LR\n")
+
+	// Mermaid node IDs can not contain dots so every node is given a generated ID
+	nodeIds := make(map[string]string, len(graph.Nodes))
+	for i, node := range graph.Nodes {
+		nodeId := fmt.Sprintf("n%d", i)
+		nodeIds[node.Address] = nodeId
+
+		label := strings.ReplaceAll(node.Address, `"`, "#quot;")
+		if node.FilteredOut {
+			label += " (filtered out)"
+		}
+		shape := fmt.Sprintf(`["%s"]`, label)
+		if node.DataSource {
+			shape = fmt.Sprintf(`(["%s"])`, label)
+		}
+		class := ""
+		if node.FilteredOut {
+			class = ":::filteredOut"
+		} else if node.Cyclic {
+			class = ":::cyclic"
+		}
+		b.WriteString(fmt.Sprintf("  %s%s%s\n", nodeId, shape, class))
+	}
+
+	cyclicLinks := make([]string, 0)
+	for i, edge := range graph.Edges {
+		arrow := "-->"
+		if edge.Kind == dependencyEdgeDependsOn {
+			arrow = "-.->"
+		}
+		b.WriteString(fmt.Sprintf("  %s %s %s\n", nodeIds[edge.From], arrow, nodeIds[edge.To]))
+		if edge.Cyclic {
+			cyclicLinks = append(cyclicLinks, fmt.Sprint(i))
+		}
+	}
+	if len(cyclicLinks) > 0 {
+		b.WriteString(fmt.Sprintf("  linkStyle %s stroke:red\n", strings.Join(cyclicLinks, ",")))
+	}
+
+	b.WriteString("  classDef filteredOut stroke-dasharray: 5 5,color:gray\n")
+	b.WriteString("  classDef cyclic stroke:red\n")
+	return b.String()
+}
+
+// writeDependencyGraph writes the dependency graph of the export in each of the requested formats
+func (g *GenesysCloudResourceExporter) writeDependencyGraph() diag.Diagnostics {
+	graph := buildDependencyGraph(g.resourceTypesMaps, g.dataSourceTypesMaps, g.resourceAddresses, g.dependsList, g.unresolvedRefs, g.cyclicDependsList)
+	for _, format := range g.dependencyGraphFormats {
+		var data []byte
+		switch format {
+		case dependencyGraphFormatDOT:
+			data = []byte(graph.toDOT())
+		case dependencyGraphFormatMermaid:
+			data = []byte(graph.toMermaid())
+		case dependencyGraphFormatJSON:
+			jsonData, err := json.MarshalIndent(graph, "", "  ")
+			if err != nil {
+				return diag.Errorf("Failed to encode dependency graph as JSON: %v", err)
+			}
+			data = jsonData
+		default:
+			return diag.Errorf("Unsupported dependency graph format %s", format)
+		}
+
+		path := filepath.Join(g.exportDirPath, fmt.Sprintf("%s.%s", dependencyGraphFileName, dependencyGraphFileExtensions[format]))
+		log.Printf("Writing %s dependency graph to %s", format, path)
+		if err := files.WriteToFile(data, path); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// addUnresolvedGraphReference records a reference from an exported resource to an ID which could not be resolved to an exported resource
+func (g *GenesysCloudResourceExporter) addUnresolvedGraphReference(resource resourceExporter.ResourceInfo, refType string, refId string) {
+	if len(g.dependencyGraphFormats) == 0 {
+		return
+	}
+	isDataSource := g.isDataSource(resource.Type, resource.BlockLabel, resource.OriginalLabel)
+	g.unresolvedRefs = append(g.unresolvedRefs, graphReference{
+		from:    resourceAddress(isDataSource, resource.Type, resource.BlockLabel),
+		refType: refType,
+		refId:   refId,
+	})
+}
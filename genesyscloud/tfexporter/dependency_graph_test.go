@@ -0,0 +1,97 @@
+package tfexporter
+
+import (
+	"encoding/json"
+	"strings"
+	"terraform-provider-genesyscloud/genesyscloud/util"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUnitBuildDependencyGraph(t *testing.T) {
+	resourceTypesMaps := map[string]resourceJSONMaps{
+		"genesyscloud_flow": {
+			"flow_a": util.JsonMap{
+				"name":       "Flow A",
+				"depends_on": []string{"$dep$genesyscloud_flow.flow_b$dep$"},
+			},
+			"flow_b": util.JsonMap{
+				"name":       "Flow B",
+				"depends_on": []string{"$dep$genesyscloud_flow.flow_a$dep$"},
+			},
+		},
+		"genesyscloud_routing_queue": {
+			"queue_1": util.JsonMap{
+				"name":      "Queue 1",
+				"skill_ids": []interface{}{"${genesyscloud_routing_skill.skill_1.id}"},
+				"settings":  "decoded-settings",
+			},
+		},
+		"genesyscloud_routing_skill": {
+			"skill_1": util.JsonMap{
+				"name": "Skill $${literal}",
+			},
+		},
+	}
+	resourceAddresses := map[string]string{
+		"flow-a-id":  "genesyscloud_flow.flow_a",
+		"flow-b-id":  "genesyscloud_flow.flow_b",
+		"queue-1-id": "genesyscloud_routing_queue.queue_1",
+		"skill-1-id": "genesyscloud_routing_skill.skill_1",
+	}
+	dependsList := map[string][]string{
+		"flow-a-id": {"genesyscloud_flow.flow-b-id", "genesyscloud_routing_queue.queue-2-id"},
+	}
+	unresolvedRefs := []graphReference{
+		{from: "genesyscloud_routing_queue.queue_1", refType: "genesyscloud_user", refId: "user-1-id"},
+		{from: "genesyscloud_routing_queue.queue_1", refType: "genesyscloud_routing_skill", refId: "skill-1-id"},
+	}
+
+	// References inside jsonencode attributes are found in their decoded value, which is left as a placeholder
+	attributesDecoded = map[string]string{"decoded-settings": `{"flow_id": "${genesyscloud_flow.flow_b.id}"}`}
+	defer func() { attributesDecoded = make(map[string]string) }()
+
+	graph := buildDependencyGraph(resourceTypesMaps, map[string]resourceJSONMaps{}, resourceAddresses, dependsList, unresolvedRefs, nil)
+
+	assert.Len(t, graph.Nodes, 6)
+	assert.Equal(t, [][]string{{"genesyscloud_flow.flow_a", "genesyscloud_flow.flow_b"}}, graph.Cycles)
+	assert.Equal(t, []DependencyGraphEdge{
+		{From: "genesyscloud_flow.flow_a", To: "genesyscloud_flow.flow_b", Kind: dependencyEdgeDependsOn, Cyclic: true},
+		{From: "genesyscloud_flow.flow_a", To: "genesyscloud_routing_queue::queue-2-id", Kind: dependencyEdgeDependsOn},
+		{From: "genesyscloud_flow.flow_b", To: "genesyscloud_flow.flow_a", Kind: dependencyEdgeDependsOn, Cyclic: true},
+		{From: "genesyscloud_routing_queue.queue_1", To: "genesyscloud_flow.flow_b", Kind: dependencyEdgeReference},
+		{From: "genesyscloud_routing_queue.queue_1", To: "genesyscloud_routing_skill.skill_1", Kind: dependencyEdgeReference},
+		{From: "genesyscloud_routing_queue.queue_1", To: "genesyscloud_user::user-1-id", Kind: dependencyEdgeReference},
+	}, graph.Edges)
+	assert.Equal(t, "decoded-settings", resourceTypesMaps["genesyscloud_routing_queue"]["queue_1"]["settings"])
+
+	for _, node := range graph.Nodes {
+		switch node.Address {
+		case "genesyscloud_user::user-1-id", "genesyscloud_routing_queue::queue-2-id":
+			assert.True(t, node.FilteredOut, node.Address)
+		case "genesyscloud_flow.flow_a", "genesyscloud_flow.flow_b":
+			assert.True(t, node.Cyclic, node.Address)
+		default:
+			assert.False(t, node.FilteredOut || node.Cyclic, node.Address)
+		}
+	}
+
+	dot := graph.toDOT()
+	assert.True(t, strings.HasPrefix(dot, "digraph genesyscloud {"))
+	assert.Contains(t, dot, `"genesyscloud_flow.flow_a" -> "genesyscloud_flow.flow_b" [style = "dashed", color = "red"];`)
+	assert.Contains(t, dot, `"genesyscloud_routing_queue.queue_1" -> "genesyscloud_routing_skill.skill_1";`)
+	assert.Contains(t, dot, `"genesyscloud_user::user-1-id" [style = "dashed", color = "gray", label = "genesyscloud_user::user-1-id\n(filtered out)"];`)
+
+	mermaid := graph.toMermaid()
+	assert.True(t, strings.HasPrefix(mermaid, "flowchart LR\n"))
+	assert.Contains(t, mermaid, `n0["genesyscloud_flow.flow_a"]:::cyclic`)
+	assert.Contains(t, mermaid, "n0 -.-> n1")
+	assert.Contains(t, mermaid, "linkStyle 0,2 stroke:red")
+
+	data, err := json.Marshal(graph)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Contains(t, string(data), `"filtered_out":true`)
+}
@@ -0,0 +1,258 @@
+package tfexporter
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	resourceExporter "terraform-provider-genesyscloud/genesyscloud/resource_exporter"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+/*
+   This file contains the checkpointing of an export. Every resource type that has been fully read is written to its own file in
+   the checkpoint directory of the export. When an export is started with resume = true the completed resource types are loaded
+   from the checkpoint rather than read from Genesys Cloud again. The checkpoint is removed once the export succeeds.
+*/
+
+const exportCheckpointDirectory = ".export_checkpoint"
+
+// Attributes of the genesyscloud_tf_export resource that affect which resources are exported and how their state is read.
+// A checkpoint written with different values for these attributes can not be resumed.
+var checkpointFingerprintAttributes = []string{
+	"resource_types",
+	"include_filter_resources",
+	"exclude_filter_resources",
+	"replace_with_datasource",
+	"exclude_attributes",
+	"include_state_file",
+	"export_computed",
+	"enable_dependency_resolution",
+	"log_permission_errors",
+	"on_error",
+}
+
+type exportCheckpoint struct {
+	dirPath     string
+	fingerprint string
+	// Resource types loaded from an existing checkpoint when resuming
+	completed map[string]*checkpointResourceType
+	// Checkpoints are only used for the first retrieval of the export and not for the dependency resolution passes
+	active bool
+	mutex  sync.RWMutex
+}
+
+type checkpointResourceType struct {
+	Fingerprint          string                                    `json:"fingerprint"`
+	ResourceType         string                                    `json:"resource_type"`
+	SanitizedResourceMap map[string]*resourceExporter.ResourceMeta `json:"sanitized_resource_map"`
+	Resources            []checkpointResource                      `json:"resources"`
+}
+
+type checkpointResource struct {
+	Id            string                 `json:"id"`
+	Attributes    map[string]string      `json:"attributes"`
+	Meta          map[string]interface{} `json:"meta,omitempty"`
+	BlockLabel    string                 `json:"block_label"`
+	OriginalLabel string                 `json:"original_label"`
+	Type          string                 `json:"type"`
+	ResourceType  string                 `json:"resource_type"`
+}
+
+func newExportCheckpoint(d *schema.ResourceData, exportDirPath string, version string) (*exportCheckpoint, diag.Diagnostics) {
+	fingerprint, err := checkpointFingerprint(d, version)
+	if err != nil {
+		return nil, diag.Errorf("Failed to compute the export checkpoint fingerprint: %v", err)
+	}
+
+	checkpoint := &exportCheckpoint{
+		dirPath:     filepath.Join(exportDirPath, exportCheckpointDirectory),
+		fingerprint: fingerprint,
+		completed:   make(map[string]*checkpointResourceType),
+	}
+
+	if !d.Get("resume").(bool) {
+		// Starting over. Discard any checkpoint left behind by a previous run.
+		if err := os.RemoveAll(checkpoint.dirPath); err != nil {
+			return nil, diag.Errorf("Failed to remove export checkpoint %s: %v", checkpoint.dirPath, err)
+		}
+	} else if diagErr := checkpoint.load(); diagErr != nil {
+		return nil, diagErr
+	}
+	return checkpoint, nil
+}
+
+func checkpointFingerprint(d *schema.ResourceData, version string) (string, error) {
+	values := map[string]interface{}{
+		"version": version,
+	}
+	for _, attr := range checkpointFingerprintAttributes {
+		values[attr] = d.Get(attr)
+	}
+	data, err := json.Marshal(values)
+	if err != nil {
+		return "", err
+	}
+	hash := sha256.Sum256(data)
+	return hex.EncodeToString(hash[:]), nil
+}
+
+// load reads the completed resource types of a previous export run. Resource types written with a different export configuration are ignored.
+func (c *exportCheckpoint) load() diag.Diagnostics {
+	entries, err := os.ReadDir(c.dirPath)
+	if os.IsNotExist(err) {
+		log.Printf("No export checkpoint found in %s. Starting a new export.", c.dirPath)
+		return nil
+	}
+	if err != nil {
+		return diag.Errorf("Failed to read export checkpoint directory %s: %v", c.dirPath, err)
+	}
+
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.HasSuffix(entry.Name(), ".json") {
+			continue
+		}
+		path := filepath.Join(c.dirPath, entry.Name())
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return diag.Errorf("Failed to read export checkpoint file %s: %v", path, err)
+		}
+		var typeCheckpoint checkpointResourceType
+		if err := json.Unmarshal(data, &typeCheckpoint); err != nil {
+			log.Printf("Ignoring unreadable export checkpoint file %s: %v", path, err)
+			continue
+		}
+		if typeCheckpoint.Fingerprint != c.fingerprint {
+			log.Printf("Ignoring export checkpoint for %s as it was written with a different export configuration", typeCheckpoint.ResourceType)
+			continue
+		}
+		c.completed[typeCheckpoint.ResourceType] = &typeCheckpoint
+	}
+
+	log.Printf("Resuming export with %d completed resource types from the checkpoint", len(c.completed))
+	return nil
+}
+
+// completedType returns the checkpoint of a resource type if it was completed by a previous run
+func (c *exportCheckpoint) completedType(resType string) (*checkpointResourceType, bool) {
+	if c == nil {
+		return nil, false
+	}
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+	if !c.active {
+		return nil, false
+	}
+	typeCheckpoint, ok := c.completed[resType]
+	return typeCheckpoint, ok
+}
+
+func (c *exportCheckpoint) setActive(active bool) {
+	if c == nil {
+		return
+	}
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.active = active
+}
+
+// save writes the exported instances of a completed resource type to the checkpoint
+func (c *exportCheckpoint) save(resType string, sanitizedResourceMap resourceExporter.ResourceIDMetaMap, resources []resourceExporter.ResourceInfo) diag.Diagnostics {
+	if c == nil {
+		return nil
+	}
+	c.mutex.RLock()
+	active := c.active
+	c.mutex.RUnlock()
+	if !active {
+		return nil
+	}
+
+	typeCheckpoint := checkpointResourceType{
+		Fingerprint:          c.fingerprint,
+		ResourceType:         resType,
+		SanitizedResourceMap: sanitizedResourceMap,
+		Resources:            make([]checkpointResource, 0, len(resources)),
+	}
+	for _, resource := range resources {
+		typeCheckpoint.Resources = append(typeCheckpoint.Resources, checkpointResource{
+			Id:            resource.State.ID,
+			Attributes:    resource.State.Attributes,
+			Meta:          resource.State.Meta,
+			BlockLabel:    resource.BlockLabel,
+			OriginalLabel: resource.OriginalLabel,
+			Type:          resource.Type,
+			ResourceType:  resource.ResourceType,
+		})
+	}
+
+	data, err := json.Marshal(typeCheckpoint)
+	if err != nil {
+		return diag.Errorf("Failed to encode export checkpoint for %s: %v", resType, err)
+	}
+
+	if err := os.MkdirAll(c.dirPath, os.ModePerm); err != nil {
+		return diag.Errorf("Failed to create export checkpoint directory %s: %v", c.dirPath, err)
+	}
+
+	// Write to a temporary file first so an interrupted write does not leave a corrupt checkpoint behind
+	path := filepath.Join(c.dirPath, fmt.Sprintf("%s.json", resType))
+	tmpPath := path + ".tmp"
+	if err := os.WriteFile(tmpPath, data, os.ModePerm); err != nil {
+		return diag.Errorf("Failed to write export checkpoint file %s: %v", tmpPath, err)
+	}
+	if err := os.Rename(tmpPath, path); err != nil {
+		return diag.Errorf("Failed to write export checkpoint file %s: %v", path, err)
+	}
+	log.Printf("Saved export checkpoint for %s with %d resources", resType, len(resources))
+	return nil
+}
+
+// remove deletes the checkpoint once the export has completed
+func (c *exportCheckpoint) remove() {
+	if c == nil {
+		return
+	}
+	if err := os.RemoveAll(c.dirPath); err != nil {
+		log.Printf("Failed to remove export checkpoint %s: %v", c.dirPath, err)
+	}
+}
+
+// toResourceInfo restores the checkpointed resources of a type. The cty type is taken from the provider schema.
+func (t *checkpointResourceType) toResourceInfo(provider *schema.Provider) ([]resourceExporter.ResourceInfo, diag.Diagnostics) {
+	resources := make([]resourceExporter.ResourceInfo, 0, len(t.Resources))
+	for _, resource := range t.Resources {
+		var res *schema.Resource
+		if resource.ResourceType == "data." {
+			res = provider.DataSourcesMap[resource.Type]
+		} else {
+			res = provider.ResourcesMap[resource.Type]
+		}
+		if res == nil {
+			return nil, diag.Errorf("Resource type %v from the export checkpoint is not defined", resource.Type)
+		}
+
+		resources = append(resources, resourceExporter.ResourceInfo{
+			State: &terraform.InstanceState{
+				ID:         resource.Id,
+				Attributes: resource.Attributes,
+				Meta:       resource.Meta,
+			},
+			BlockLabel:    resource.BlockLabel,
+			OriginalLabel: resource.OriginalLabel,
+			Type:          resource.Type,
+			CtyType:       res.CoreConfigSchema().ImpliedType(),
+			ResourceType:  resource.ResourceType,
+		})
+	}
+	return resources, nil
+}
@@ -0,0 +1,144 @@
+package tfexporter
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"terraform-provider-genesyscloud/genesyscloud/provider"
+	resourceExporter "terraform-provider-genesyscloud/genesyscloud/resource_exporter"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUnitExportCheckpointResume(t *testing.T) {
+	dir := t.TempDir()
+	testProvider := &schema.Provider{
+		ResourcesMap: map[string]*schema.Resource{
+			"genesyscloud_routing_skill": {
+				Schema: map[string]*schema.Schema{
+					"name": {Type: schema.TypeString, Required: true},
+				},
+			},
+		},
+	}
+	exportConfig := map[string]interface{}{
+		"directory":                dir,
+		"include_filter_resources": []interface{}{"genesyscloud_routing_skill"},
+	}
+
+	checkpoint, diagErr := newExportCheckpoint(schema.TestResourceDataRaw(t, ResourceTfExport().Schema, exportConfig), dir, "0.1.0")
+	if diagErr != nil {
+		t.Fatalf("%v", diagErr)
+	}
+
+	sanitizedResourceMap := resourceExporter.ResourceIDMetaMap{
+		"skill-1": {BlockLabel: "skill_1"},
+	}
+	resources := []resourceExporter.ResourceInfo{
+		{
+			State: &terraform.InstanceState{
+				ID:         "skill-1",
+				Attributes: map[string]string{"id": "skill-1", "name": "Skill 1"},
+			},
+			BlockLabel: "skill_1",
+			Type:       "genesyscloud_routing_skill",
+		},
+	}
+
+	// Nothing is saved while the checkpoint is not active
+	if diagErr := checkpoint.save("genesyscloud_routing_skill", sanitizedResourceMap, resources); diagErr != nil {
+		t.Fatalf("%v", diagErr)
+	}
+	checkpoint.setActive(true)
+	if diagErr := checkpoint.save("genesyscloud_routing_skill", sanitizedResourceMap, resources); diagErr != nil {
+		t.Fatalf("%v", diagErr)
+	}
+
+	// Resuming with the same configuration restores the completed resource type
+	exportConfig["resume"] = true
+	resumed, diagErr := newExportCheckpoint(schema.TestResourceDataRaw(t, ResourceTfExport().Schema, exportConfig), dir, "0.1.0")
+	if diagErr != nil {
+		t.Fatalf("%v", diagErr)
+	}
+	_, ok := resumed.completedType("genesyscloud_routing_skill")
+	assert.False(t, ok, "checkpoint should only be used while active")
+	resumed.setActive(true)
+	typeCheckpoint, ok := resumed.completedType("genesyscloud_routing_skill")
+	if !assert.True(t, ok) {
+		return
+	}
+	assert.Equal(t, "skill_1", typeCheckpoint.SanitizedResourceMap["skill-1"].BlockLabel)
+	restored, diagErr := typeCheckpoint.toResourceInfo(testProvider)
+	if diagErr != nil {
+		t.Fatalf("%v", diagErr)
+	}
+	assert.Len(t, restored, 1)
+	assert.Equal(t, "Skill 1", restored[0].State.Attributes["name"])
+	assert.Equal(t, testProvider.ResourcesMap["genesyscloud_routing_skill"].CoreConfigSchema().ImpliedType(), restored[0].CtyType)
+
+	// A checkpoint written with a different configuration is ignored
+	exportConfig["include_filter_resources"] = []interface{}{"genesyscloud_routing_queue"}
+	different, diagErr := newExportCheckpoint(schema.TestResourceDataRaw(t, ResourceTfExport().Schema, exportConfig), dir, "0.1.0")
+	if diagErr != nil {
+		t.Fatalf("%v", diagErr)
+	}
+	different.setActive(true)
+	_, ok = different.completedType("genesyscloud_routing_skill")
+	assert.False(t, ok)
+
+	// Starting without resume discards the checkpoint
+	exportConfig["resume"] = false
+	if _, diagErr := newExportCheckpoint(schema.TestResourceDataRaw(t, ResourceTfExport().Schema, exportConfig), dir, "0.1.0"); diagErr != nil {
+		t.Fatalf("%v", diagErr)
+	}
+	exportConfig["resume"] = true
+	exportConfig["include_filter_resources"] = []interface{}{"genesyscloud_routing_skill"}
+	discarded, _ := newExportCheckpoint(schema.TestResourceDataRaw(t, ResourceTfExport().Schema, exportConfig), dir, "0.1.0")
+	discarded.setActive(true)
+	_, ok = discarded.completedType("genesyscloud_routing_skill")
+	assert.False(t, ok)
+}
+
+func TestUnitExportCheckpointFingerprintErrorHandling(t *testing.T) {
+	exportConfig := map[string]interface{}{
+		"directory": t.TempDir(),
+	}
+	fingerprint, err := checkpointFingerprint(schema.TestResourceDataRaw(t, ResourceTfExport().Schema, exportConfig), "0.1.0")
+	assert.NoError(t, err)
+
+	// A checkpoint written with a different error handling is not reused
+	exportConfig["on_error"] = onErrorContinue
+	onErrorFingerprint, err := checkpointFingerprint(schema.TestResourceDataRaw(t, ResourceTfExport().Schema, exportConfig), "0.1.0")
+	assert.NoError(t, err)
+	assert.NotEqual(t, fingerprint, onErrorFingerprint)
+
+	exportConfig["log_permission_errors"] = true
+	permissionErrorsFingerprint, err := checkpointFingerprint(schema.TestResourceDataRaw(t, ResourceTfExport().Schema, exportConfig), "0.1.0")
+	assert.NoError(t, err)
+	assert.NotEqual(t, onErrorFingerprint, permissionErrorsFingerprint)
+}
+
+func TestUnitCreateTfExportUnreadableCheckpoint(t *testing.T) {
+	dir := t.TempDir()
+	// The checkpoint cannot be read when its directory is a file
+	if err := os.WriteFile(filepath.Join(dir, exportCheckpointDirectory), []byte("{}"), 0644); err != nil {
+		t.Fatalf("Failed to write checkpoint: %v", err)
+	}
+
+	meta := &provider.ProviderMeta{Version: "0.1.0"}
+	for name, exportConfig := range map[string]map[string]interface{}{
+		"include": {"directory": dir, "resume": true, "include_filter_resources": []interface{}{"genesyscloud_routing_skill"}},
+		"exclude": {"directory": dir, "resume": true, "exclude_filter_resources": []interface{}{"genesyscloud_routing_skill"}},
+		"legacy":  {"directory": dir, "resume": true, "resource_types": []interface{}{"genesyscloud_routing_skill"}},
+	} {
+		d := schema.TestResourceDataRaw(t, ResourceTfExport().Schema, exportConfig)
+		diagErr := createTfExport(context.Background(), d, meta)
+		if assert.True(t, diagErr.HasError(), name) {
+			assert.Contains(t, diagErr[0].Summary, "Failed to read export checkpoint directory", name)
+		}
+		assert.Empty(t, d.Id(), name)
+	}
+}
@@ -0,0 +1,144 @@
+package tfexporter
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+)
+
+/*
+   This file contains the handling of resources that could not be exported when the export runs with on_error = "continue".
+   Failed instances are left out of the config, recorded in the export report and written as commented placeholders in the
+   exported config so they can be found and added manually.
+*/
+
+const (
+	onErrorFail     = "fail"
+	onErrorContinue = "continue"
+
+	// Terraform ignores properties named "//" in JSON configuration files
+	jsonCommentProperty = "//"
+)
+
+var onErrorOptions = []string{onErrorFail, onErrorContinue}
+
+type resourceReadError struct {
+	id         string
+	blockLabel string
+	err        diag.Diagnostics
+}
+
+// failedResourceWarnings returns a warning for every resource that could not be exported
+func (g *GenesysCloudResourceExporter) failedResourceWarnings() diag.Diagnostics {
+	var warnings diag.Diagnostics
+	for _, failed := range g.report.failedResources() {
+		summary := fmt.Sprintf("Failed to export resources of type %s", failed.ResourceType)
+		if failed.Id != "" {
+			summary = fmt.Sprintf("Failed to export %s instance %s", failed.ResourceType, failed.Id)
+		}
+		warnings = append(warnings, diag.Diagnostic{
+			Severity: diag.Warning,
+			Summary:  summary,
+			Detail:   strings.Join(failed.Diagnostics, "\n"),
+		})
+	}
+	return warnings
+}
+
+// failedResourceComment describes a failed resource as comment lines without the comment prefix
+func failedResourceComment(failed FailedResourceReport) []string {
+	lines := make([]string, 0)
+	if failed.Id == "" {
+		lines = append(lines, fmt.Sprintf("Failed to export resources of type %s:", failed.ResourceType))
+	} else {
+		lines = append(lines, fmt.Sprintf("Failed to export %s %q (ID: %s):", failed.ResourceType, failed.BlockLabel, failed.Id))
+	}
+	for _, message := range failed.Diagnostics {
+		lines = append(lines, strings.Split(message, "\n")...)
+	}
+	if failed.Id != "" {
+		lines = append(lines, fmt.Sprintf(`resource "%s" "%s" {`, failed.ResourceType, failed.BlockLabel), "}")
+	}
+	return lines
+}
+
+// failedResourceConfigFile returns the config file the placeholder of a failed resource is written to
+func (g *GenesysCloudResourceExporter) failedResourceConfigFile(failed FailedResourceReport) string {
+	if g.exportAsHCL {
+		if g.splitFilesByResource {
+			return fmt.Sprintf("%s.%s", failed.ResourceType, resourceHCLFileExt)
+		}
+		return defaultTfHCLFile
+	}
+	if g.splitFilesByResource {
+		return fmt.Sprintf("%s.%s", failed.ResourceType, resourceJSONFileExt)
+	}
+	return defaultTfJSONFile
+}
+
+// writeFailedResourcePlaceholders adds a commented placeholder for every failed resource to the exported config
+func (g *GenesysCloudResourceExporter) writeFailedResourcePlaceholders() diag.Diagnostics {
+	commentsByFile := make(map[string][]string)
+	fileOrder := make([]string, 0)
+	for _, failed := range g.report.failedResources() {
+		file := g.failedResourceConfigFile(failed)
+		if _, ok := commentsByFile[file]; !ok {
+			fileOrder = append(fileOrder, file)
+		}
+		commentsByFile[file] = append(commentsByFile[file], failedResourceComment(failed)...)
+	}
+
+	for _, file := range fileOrder {
+		path := filepath.Join(g.exportDirPath, file)
+		log.Printf("Writing placeholders for resources that failed to export to %s", path)
+
+		var diagErr diag.Diagnostics
+		if g.exportAsHCL {
+			diagErr = appendHCLComments(path, commentsByFile[file])
+		} else {
+			diagErr = addJSONComments(path, commentsByFile[file])
+		}
+		if diagErr != nil {
+			return diagErr
+		}
+	}
+	return nil
+}
+
+func appendHCLComments(path string, lines []string) diag.Diagnostics {
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return diag.Errorf("Error opening/creating file %s: %v", path, err)
+	}
+	defer f.Close()
+
+	var comment strings.Builder
+	comment.WriteString("\n")
+	for _, line := range lines {
+		comment.WriteString("# " + line + "\n")
+	}
+	if _, err := f.WriteString(comment.String()); err != nil {
+		return diag.Errorf("Error writing file %s: %v", path, err)
+	}
+	return nil
+}
+
+func addJSONComments(path string, lines []string) diag.Diagnostics {
+	config := make(map[string]interface{})
+	data, err := os.ReadFile(path)
+	if err != nil && !os.IsNotExist(err) {
+		return diag.Errorf("Error reading file %s: %v", path, err)
+	}
+	if len(data) > 0 {
+		if err := json.Unmarshal(data, &config); err != nil {
+			return diag.Errorf("Error parsing file %s: %v", path, err)
+		}
+	}
+	config[jsonCommentProperty] = strings.Join(lines, "\n")
+	return writeConfig(config, path)
+}
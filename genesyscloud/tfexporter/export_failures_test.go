@@ -0,0 +1,63 @@
+package tfexporter
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUnitFailedResourcePlaceholders(t *testing.T) {
+	report := NewExportReport()
+	report.addFailedResource("genesyscloud_routing_queue", "queue-id-2", "queue_2", diag.Errorf("Failed to read queue"))
+	report.addFailedResource("genesyscloud_user", "", "", diag.Errorf("Failed to get page of users"))
+
+	assert.True(t, report.hasFailedResources("genesyscloud_routing_queue"))
+	assert.False(t, report.hasFailedResources("genesyscloud_routing_skill"))
+
+	g := &GenesysCloudResourceExporter{
+		report:        report,
+		exportDirPath: t.TempDir(),
+		exportAsHCL:   true,
+	}
+
+	warnings := g.failedResourceWarnings()
+	assert.Len(t, warnings, 2)
+	assert.False(t, warnings.HasError())
+	assert.Equal(t, "Failed to export genesyscloud_routing_queue instance queue-id-2", warnings[0].Summary)
+	assert.Equal(t, "Failed to export resources of type genesyscloud_user", warnings[1].Summary)
+
+	hclPath := filepath.Join(g.exportDirPath, defaultTfHCLFile)
+	if err := os.WriteFile(hclPath, []byte("resource \"genesyscloud_routing_queue\" \"queue_1\" {\n}\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if diagErr := g.writeFailedResourcePlaceholders(); diagErr != nil {
+		t.Fatalf("%v", diagErr)
+	}
+	hcl, err := os.ReadFile(hclPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.True(t, strings.HasPrefix(string(hcl), "resource \"genesyscloud_routing_queue\" \"queue_1\" {\n}\n"))
+	assert.Contains(t, string(hcl), "# resource \"genesyscloud_routing_queue\" \"queue_2\" {\n# }\n")
+	assert.Contains(t, string(hcl), "# Failed to export resources of type genesyscloud_user:\n# Failed to get page of users\n")
+
+	g.exportAsHCL = false
+	g.splitFilesByResource = true
+	if diagErr := g.writeFailedResourcePlaceholders(); diagErr != nil {
+		t.Fatalf("%v", diagErr)
+	}
+	data, err := os.ReadFile(filepath.Join(g.exportDirPath, "genesyscloud_user."+resourceJSONFileExt))
+	if err != nil {
+		t.Fatal(err)
+	}
+	config := make(map[string]interface{})
+	if err := json.Unmarshal(data, &config); err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, "Failed to export resources of type genesyscloud_user:\nFailed to get page of users", config[jsonCommentProperty])
+}
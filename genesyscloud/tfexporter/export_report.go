@@ -0,0 +1,298 @@
+package tfexporter
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"path/filepath"
+	"sort"
+	"sync"
+	"terraform-provider-genesyscloud/genesyscloud/util/files"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+)
+
+/*
+   This file contains the export report written along with the exported config. The report records how long each resource type
+   took to export, how many instances were discovered, exported and skipped, the permission errors which were logged rather than
+   failing the export, the references which could not be resolved and the slowest individual resource reads.
+*/
+
+const (
+	defaultExportReportFile = "export_report.json"
+
+	// Number of individual resource reads kept in the report
+	maxSlowestReads = 10
+)
+
+type ExportReport struct {
+	StartTime            time.Time                      `json:"start_time"`
+	EndTime              time.Time                      `json:"end_time"`
+	DurationMs           int64                          `json:"duration_ms"`
+	Error                string                         `json:"error,omitempty"`
+	ResourceTypes        map[string]*ResourceTypeReport `json:"resource_types"`
+	PermissionErrors     []PermissionErrorReport        `json:"permission_errors"`
+	UnresolvedReferences []UnresolvedReferenceReport    `json:"unresolved_references"`
+	SlowestReads         []ResourceReadReport           `json:"slowest_reads"`
+	FailedResources      []FailedResourceReport         `json:"failed_resources"`
+
+	discoveredIds map[string]map[string]bool
+	reads         []ResourceReadReport
+	mutex         sync.Mutex
+}
+
+type ResourceTypeReport struct {
+	// Time spent listing the resource IDs of the type
+	DiscoveryDurationMs int64 `json:"discovery_duration_ms"`
+	// Time spent reading the state of every instance of the type
+	ReadDurationMs int64 `json:"read_duration_ms"`
+	Discovered     int   `json:"discovered"`
+	Exported       int   `json:"exported"`
+	Skipped        int   `json:"skipped"`
+}
+
+type PermissionErrorReport struct {
+	ResourceType string `json:"resource_type"`
+	Message      string `json:"message"`
+}
+
+type UnresolvedReferenceReport struct {
+	ResourceType string `json:"resource_type"`
+	Id           string `json:"id"`
+}
+
+// FailedResourceReport is an instance that could not be read when exporting with on_error = "continue".
+// Id is empty when listing the resources of the type failed.
+type FailedResourceReport struct {
+	ResourceType string   `json:"resource_type"`
+	Id           string   `json:"id,omitempty"`
+	BlockLabel   string   `json:"block_label,omitempty"`
+	Diagnostics  []string `json:"diagnostics"`
+}
+
+type ResourceReadReport struct {
+	ResourceType string `json:"resource_type"`
+	Id           string `json:"id"`
+	BlockLabel   string `json:"block_label"`
+	DurationMs   int64  `json:"duration_ms"`
+}
+
+func NewExportReport() *ExportReport {
+	return &ExportReport{
+		StartTime:            time.Now(),
+		ResourceTypes:        make(map[string]*ResourceTypeReport),
+		PermissionErrors:     make([]PermissionErrorReport, 0),
+		UnresolvedReferences: make([]UnresolvedReferenceReport, 0),
+		SlowestReads:         make([]ResourceReadReport, 0),
+		FailedResources:      make([]FailedResourceReport, 0),
+		discoveredIds:        make(map[string]map[string]bool),
+		reads:                make([]ResourceReadReport, 0),
+	}
+}
+
+// resourceType returns the report of the resource type. The caller must hold the mutex.
+func (r *ExportReport) resourceType(resType string) *ResourceTypeReport {
+	typeReport, ok := r.ResourceTypes[resType]
+	if !ok {
+		typeReport = &ResourceTypeReport{}
+		r.ResourceTypes[resType] = typeReport
+	}
+	return typeReport
+}
+
+// addDiscovery records the IDs found for a resource type. The recording methods are no-ops on a nil report
+// so exporters created without a report can still be used.
+func (r *ExportReport) addDiscovery(resType string, ids []string, duration time.Duration) {
+	if r == nil {
+		return
+	}
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	typeReport := r.resourceType(resType)
+	typeReport.DiscoveryDurationMs += duration.Milliseconds()
+	if r.discoveredIds[resType] == nil {
+		r.discoveredIds[resType] = make(map[string]bool)
+	}
+	for _, id := range ids {
+		r.discoveredIds[resType][id] = true
+	}
+	typeReport.Discovered = len(r.discoveredIds[resType])
+}
+
+func (r *ExportReport) addTypeReadDuration(resType string, duration time.Duration) {
+	if r == nil {
+		return
+	}
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	r.resourceType(resType).ReadDurationMs += duration.Milliseconds()
+}
+
+func (r *ExportReport) addRead(resType, id, blockLabel string, duration time.Duration) {
+	if r == nil {
+		return
+	}
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	r.reads = append(r.reads, ResourceReadReport{
+		ResourceType: resType,
+		Id:           id,
+		BlockLabel:   blockLabel,
+		DurationMs:   duration.Milliseconds(),
+	})
+}
+
+func (r *ExportReport) addSkipped(resType string, count int) {
+	if r == nil {
+		return
+	}
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	r.resourceType(resType).Skipped += count
+}
+
+func (r *ExportReport) addPermissionErrors(resType string, err diag.Diagnostics) {
+	if r == nil {
+		return
+	}
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	r.resourceType(resType)
+	for _, d := range err {
+		r.PermissionErrors = append(r.PermissionErrors, PermissionErrorReport{
+			ResourceType: resType,
+			Message:      d.Summary,
+		})
+	}
+}
+
+func (r *ExportReport) addUnresolvedReference(resType, id string) {
+	if r == nil {
+		return
+	}
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	for _, ref := range r.UnresolvedReferences {
+		if ref.ResourceType == resType && ref.Id == id {
+			return
+		}
+	}
+	r.UnresolvedReferences = append(r.UnresolvedReferences, UnresolvedReferenceReport{
+		ResourceType: resType,
+		Id:           id,
+	})
+}
+
+func (r *ExportReport) addFailedResource(resType, id, blockLabel string, err diag.Diagnostics) {
+	if r == nil {
+		return
+	}
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	r.resourceType(resType)
+	failed := FailedResourceReport{
+		ResourceType: resType,
+		Id:           id,
+		BlockLabel:   blockLabel,
+		Diagnostics:  make([]string, 0, len(err)),
+	}
+	for _, d := range err {
+		message := d.Summary
+		if d.Detail != "" {
+			message = fmt.Sprintf("%s: %s", d.Summary, d.Detail)
+		}
+		failed.Diagnostics = append(failed.Diagnostics, message)
+	}
+	r.FailedResources = append(r.FailedResources, failed)
+}
+
+func (r *ExportReport) hasFailedResources(resType string) bool {
+	if r == nil {
+		return false
+	}
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	for _, failed := range r.FailedResources {
+		if failed.ResourceType == resType {
+			return true
+		}
+	}
+	return false
+}
+
+// failedResources returns a copy of the failed resources sorted by type and ID
+func (r *ExportReport) failedResources() []FailedResourceReport {
+	if r == nil {
+		return nil
+	}
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	failed := make([]FailedResourceReport, len(r.FailedResources))
+	copy(failed, r.FailedResources)
+	sort.SliceStable(failed, func(i, j int) bool {
+		if failed[i].ResourceType == failed[j].ResourceType {
+			return failed[i].Id < failed[j].Id
+		}
+		return failed[i].ResourceType < failed[j].ResourceType
+	})
+	return failed
+}
+
+// finalize completes the report once the export has finished. exportedCounts holds the number of exported instances by type and
+// exportedIds the IDs which ended up in the export. References that were unresolved at one point but exported later, for example
+// by the dependency resolution, are dropped from the report.
+func (r *ExportReport) finalize(exportedCounts map[string]int, exportedIds map[string]bool, exportErr diag.Diagnostics) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	r.EndTime = time.Now()
+	r.DurationMs = r.EndTime.Sub(r.StartTime).Milliseconds()
+	if exportErr != nil && exportErr.HasError() {
+		r.Error = exportErr[0].Summary
+	}
+
+	for resType, count := range exportedCounts {
+		r.resourceType(resType).Exported = count
+	}
+
+	unresolved := make([]UnresolvedReferenceReport, 0)
+	for _, ref := range r.UnresolvedReferences {
+		if !exportedIds[ref.Id] {
+			unresolved = append(unresolved, ref)
+		}
+	}
+	sort.Slice(unresolved, func(i, j int) bool {
+		if unresolved[i].ResourceType == unresolved[j].ResourceType {
+			return unresolved[i].Id < unresolved[j].Id
+		}
+		return unresolved[i].ResourceType < unresolved[j].ResourceType
+	})
+	r.UnresolvedReferences = unresolved
+
+	sort.SliceStable(r.reads, func(i, j int) bool {
+		return r.reads[i].DurationMs > r.reads[j].DurationMs
+	})
+	r.SlowestReads = r.reads
+	if len(r.SlowestReads) > maxSlowestReads {
+		r.SlowestReads = r.reads[:maxSlowestReads]
+	}
+}
+
+func (r *ExportReport) toJSON() ([]byte, error) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	return json.MarshalIndent(r, "", "  ")
+}
+
+func (r *ExportReport) write(dirPath string) diag.Diagnostics {
+	data, err := r.toJSON()
+	if err != nil {
+		return diag.Errorf("Failed to encode export report as JSON: %v", err)
+	}
+
+	reportPath := filepath.Join(dirPath, defaultExportReportFile)
+	log.Printf("Writing export report to %s", reportPath)
+	return files.WriteToFile(data, reportPath)
+}
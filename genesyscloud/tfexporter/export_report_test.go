@@ -0,0 +1,64 @@
+package tfexporter
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUnitExportReport(t *testing.T) {
+	report := NewExportReport()
+
+	report.addDiscovery("genesyscloud_routing_queue", []string{"queue-1", "queue-2", "queue-3"}, 2*time.Second)
+	// A second discovery pass of the same IDs, e.g. during dependency resolution, must not double count
+	report.addDiscovery("genesyscloud_routing_queue", []string{"queue-1"}, time.Second)
+	report.addSkipped("genesyscloud_routing_queue", 1)
+	report.addTypeReadDuration("genesyscloud_routing_queue", 5*time.Second)
+	for i := 0; i < maxSlowestReads+5; i++ {
+		report.addRead("genesyscloud_routing_queue", fmt.Sprintf("queue-%d", i), fmt.Sprintf("queue_%d", i), time.Duration(i)*time.Millisecond)
+	}
+	report.addPermissionErrors("genesyscloud_user", diag.Errorf("API Error: 403 - missing permission"))
+	report.addUnresolvedReference("genesyscloud_routing_skill", "skill-1")
+	report.addUnresolvedReference("genesyscloud_routing_skill", "skill-1")
+	report.addUnresolvedReference("genesyscloud_routing_wrapupcode", "wrapupcode-1")
+
+	report.finalize(map[string]int{"genesyscloud_routing_queue": 2}, map[string]bool{"wrapupcode-1": true}, diag.Errorf("export failed"))
+
+	queueReport := report.ResourceTypes["genesyscloud_routing_queue"]
+	assert.Equal(t, 3, queueReport.Discovered)
+	assert.Equal(t, 2, queueReport.Exported)
+	assert.Equal(t, 1, queueReport.Skipped)
+	assert.Equal(t, int64(3000), queueReport.DiscoveryDurationMs)
+	assert.Equal(t, int64(5000), queueReport.ReadDurationMs)
+	assert.Contains(t, report.ResourceTypes, "genesyscloud_user")
+	assert.Len(t, report.PermissionErrors, 1)
+	assert.Equal(t, []UnresolvedReferenceReport{{ResourceType: "genesyscloud_routing_skill", Id: "skill-1"}}, report.UnresolvedReferences)
+	assert.Len(t, report.SlowestReads, maxSlowestReads)
+	assert.Equal(t, "queue-14", report.SlowestReads[0].Id)
+	assert.Equal(t, "export failed", report.Error)
+
+	dir := t.TempDir()
+	if diagErr := report.write(dir); diagErr != nil {
+		t.Fatalf("%v", diagErr)
+	}
+	data, err := os.ReadFile(filepath.Join(dir, defaultExportReportFile))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var written map[string]interface{}
+	if err := json.Unmarshal(data, &written); err != nil {
+		t.Fatal(err)
+	}
+	assert.Contains(t, written, "resource_types")
+	assert.Contains(t, written, "slowest_reads")
+
+	// Recording on a nil report is a no-op
+	var nilReport *ExportReport
+	nilReport.addRead("genesyscloud_routing_queue", "queue-1", "queue_1", time.Second)
+}
@@ -94,6 +94,14 @@ type GenesysCloudResourceExporter struct {
 	ignoreCyclicDeps       bool
 	flowResourcesList      []string
 	exportComputed         bool
+	moduleLayout           string
+	resourceDivisions      map[string]string
+	resourceAddresses      map[string]string
+	report                 *ExportReport
+	checkpoint             *exportCheckpoint
+	continueOnError        bool
+	dependencyGraphFormats []string
+	unresolvedRefs         []graphReference
 }
 
 func configureExporterType(ctx context.Context, d *schema.ResourceData, gre *GenesysCloudResourceExporter, filterType ExporterFilterType) {
@@ -138,19 +146,23 @@ func NewGenesysCloudResourceExporter(ctx context.Context, d *schema.ResourceData
 	}
 
 	gre := &GenesysCloudResourceExporter{
-		exportAsHCL:          d.Get("export_as_hcl").(bool),
-		splitFilesByResource: d.Get("split_files_by_resource").(bool),
-		logPermissionErrors:  d.Get("log_permission_errors").(bool),
-		exportComputed:       d.Get("export_computed").(bool),
-		addDependsOn:         computeDependsOn(d),
-		filterType:           filterType,
-		includeStateFile:     d.Get("include_state_file").(bool),
-		ignoreCyclicDeps:     d.Get("ignore_cyclic_deps").(bool),
-		version:              meta.(*provider.ProviderMeta).Version,
-		provider:             provider.New(meta.(*provider.ProviderMeta).Version, providerResources, providerDataSources)(),
-		d:                    d,
-		ctx:                  ctx,
-		meta:                 meta,
+		exportAsHCL:            d.Get("export_as_hcl").(bool),
+		splitFilesByResource:   d.Get("split_files_by_resource").(bool),
+		logPermissionErrors:    d.Get("log_permission_errors").(bool),
+		exportComputed:         d.Get("export_computed").(bool),
+		moduleLayout:           d.Get("module_layout").(string),
+		continueOnError:        d.Get("on_error").(string) == onErrorContinue,
+		dependencyGraphFormats: lists.InterfaceListToStrings(d.Get("dependency_graph_formats").([]interface{})),
+		addDependsOn:           computeDependsOn(d),
+		filterType:             filterType,
+		includeStateFile:       d.Get("include_state_file").(bool),
+		ignoreCyclicDeps:       d.Get("ignore_cyclic_deps").(bool),
+		version:                meta.(*provider.ProviderMeta).Version,
+		provider:               provider.New(meta.(*provider.ProviderMeta).Version, providerResources, providerDataSources)(),
+		d:                      d,
+		ctx:                    ctx,
+		meta:                   meta,
+		report:                 NewExportReport(),
 	}
 
 	err := gre.setUpExportDirPath()
@@ -158,6 +170,11 @@ func NewGenesysCloudResourceExporter(ctx context.Context, d *schema.ResourceData
 		return nil, err
 	}
 
+	gre.checkpoint, err = newExportCheckpoint(d, gre.exportDirPath, gre.version)
+	if err != nil {
+		return nil, err
+	}
+
 	gre.setupDataSource()
 
 	//Setting up the filter
@@ -179,11 +196,27 @@ func computeDependsOn(d *schema.ResourceData) bool {
 }
 
 func (g *GenesysCloudResourceExporter) Export() (diagErr diag.Diagnostics) {
+	// The export report is written whether the export succeeds or not. A successful export writes it before the
+	// export directory is compressed, so that it is included in the archive.
+	reportWritten := false
+	defer func() {
+		if reportWritten && !diagErr.HasError() {
+			return
+		}
+		if reportErr := g.writeExportReport(diagErr); reportErr != nil {
+			log.Printf("Failed to write the export report: %v", reportErr)
+		}
+	}()
+
 	// Step #1 Retrieve the exporters we are have registered and have been requested by the user
 	diagErr = g.retrieveExporters()
 	if diagErr != nil {
 		return diagErr
 	}
+	// Resource types completed by a previous run are restored from the checkpoint when resuming, and
+	// every resource type completed by this run is added to the checkpoint
+	g.checkpoint.setActive(true)
+
 	// Step #2 Retrieve all the individual resources we are going to export
 	diagErr = g.retrieveSanitizedResourceMaps()
 	if diagErr != nil {
@@ -195,6 +228,7 @@ func (g *GenesysCloudResourceExporter) Export() (diagErr diag.Diagnostics) {
 	if diagErr != nil {
 		return diagErr
 	}
+	g.checkpoint.setActive(false)
 
 	// Step #4 export dependent resources for the flows
 	diagErr = g.buildAndExportDependsOnResourcesForFlows()
@@ -223,7 +257,32 @@ func (g *GenesysCloudResourceExporter) Export() (diagErr diag.Diagnostics) {
 	// step #8 Verify the terraform state file with Exporter Resources
 	g.verifyTerraformState()
 
-	return nil
+	// Step #9 Write the export report and compress the export directory
+	reportWritten = true
+	if reportErr := g.writeExportReport(nil); reportErr != nil {
+		log.Printf("Failed to write the export report: %v", reportErr)
+	}
+	diagErr = g.generateZipForExporter()
+	if diagErr != nil {
+		return diagErr
+	}
+
+	// The export is complete so there is nothing left to resume
+	g.checkpoint.remove()
+
+	return g.failedResourceWarnings()
+}
+
+// writeExportReport completes the export report with the exported resources and writes it to the export directory
+func (g *GenesysCloudResourceExporter) writeExportReport(exportErr diag.Diagnostics) diag.Diagnostics {
+	exportedCounts := make(map[string]int)
+	exportedIds := make(map[string]bool)
+	for _, resource := range g.resources {
+		exportedCounts[resource.Type]++
+		exportedIds[resource.State.ID] = true
+	}
+	g.report.finalize(exportedCounts, exportedIds, exportErr)
+	return g.report.write(g.exportDirPath)
 }
 
 func (g *GenesysCloudResourceExporter) setUpExportDirPath() (diagErr diag.Diagnostics) {
@@ -258,6 +317,8 @@ func (g *GenesysCloudResourceExporter) retrieveExporters() (diagErr diag.Diagnos
 		exports = g.resourceTypeFilter(exports, *g.filterList)
 	}
 
+	g.removeOptInExporters(exports)
+
 	g.exporters = &exports
 
 	// Assign excluded attributes to the config Map
@@ -269,6 +330,24 @@ func (g *GenesysCloudResourceExporter) retrieveExporters() (diagErr diag.Diagnos
 	return nil
 }
 
+// removeOptInExporters removes the opt in exporters whose resource type is not included explicitly
+func (g *GenesysCloudResourceExporter) removeOptInExporters(exports map[string]*resourceExporter.ResourceExporter) {
+	for resType, exporter := range exports {
+		if exporter.OptIn && !g.isResourceTypeIncluded(resType) {
+			log.Printf("Skipping resource type %s as it is only exported when it is included explicitly", resType)
+			delete(exports, resType)
+		}
+	}
+}
+
+// isResourceTypeIncluded returns true when the resource type is named in include_filter_resources or resource_types
+func (g *GenesysCloudResourceExporter) isResourceTypeIncluded(resType string) bool {
+	if g.filterType == ExcludeResources || g.filterList == nil {
+		return false
+	}
+	return lists.ItemInSlice(resType, formatFilter(*g.filterList))
+}
+
 // Removes the ::resource_label from the resource_types list
 func formatFilter(filter []string) []string {
 	newFilter := make([]string, 0)
@@ -333,8 +412,21 @@ func (g *GenesysCloudResourceExporter) retrieveGenesysCloudObjectInstances() dia
 		go func(resType string, exporter *resourceExporter.ResourceExporter) {
 			defer wg.Done()
 
-			log.Printf("Getting exported resources for [%s]", resType)
-			typeResources, err := g.getResourcesForType(resType, g.provider, exporter, g.meta)
+			var typeResources []resourceExporter.ResourceInfo
+			var err diag.Diagnostics
+			if typeCheckpoint, ok := g.checkpoint.completedType(resType); ok {
+				log.Printf("Restoring exported resources for [%s] from the export checkpoint", resType)
+				typeResources, err = typeCheckpoint.toResourceInfo(g.provider)
+			} else {
+				log.Printf("Getting exported resources for [%s]", resType)
+				typeResources, err = g.getResourcesForType(resType, g.provider, exporter, g.meta)
+				// Resource types with failed instances are not checkpointed so they are read again when resuming
+				if err == nil && !g.report.hasFailedResources(resType) {
+					if saveErr := g.checkpoint.save(resType, exporter.SanitizedResourceMap, typeResources); saveErr != nil {
+						log.Printf("Failed to save the export checkpoint for %s: %v", resType, saveErr)
+					}
+				}
+			}
 
 			if err != nil {
 				select {
@@ -370,6 +462,9 @@ func (g *GenesysCloudResourceExporter) buildResourceConfigMap() diag.Diagnostics
 	g.dataSourceTypesMaps = make(map[string]resourceJSONMaps)
 	g.resourceTypesHCLBlocks = make(map[string]resourceHCLBlock, 0)
 	g.unresolvedAttrs = make([]unresolvableAttributeInfo, 0)
+	g.resourceDivisions = make(map[string]string)
+	g.resourceAddresses = make(map[string]string)
+	g.unresolvedRefs = make([]graphReference, 0)
 
 	for _, resource := range g.resources {
 		jsonResult, diagErr := g.instanceStateToMap(resource.State, resource.CtyType)
@@ -413,6 +508,11 @@ func (g *GenesysCloudResourceExporter) buildResourceConfigMap() diag.Diagnostics
 			g.resourceTypesMaps[resource.Type][resource.BlockLabel] = jsonResult
 		}
 
+		address := resourceAddress(isDataSource, resource.Type, resource.BlockLabel)
+		g.resourceAddresses[resource.State.ID] = address
+		if divisionId, ok := resource.State.Attributes["division_id"]; ok {
+			g.resourceDivisions[address] = divisionId
+		}
 	}
 
 	return nil
@@ -472,15 +572,24 @@ func (g *GenesysCloudResourceExporter) instanceStateToMap(state *terraform.Insta
 // generateOutputFiles is used to generate the tfStateFile and either the tf export or the json based export
 func (g *GenesysCloudResourceExporter) generateOutputFiles() diag.Diagnostics {
 	providerSource := g.sourceForVersion(g.version)
+
+	var moduleAssignments map[string]string
+	if g.moduleLayout != moduleLayoutNone {
+		moduleAssignments = g.buildModuleAssignments()
+	}
+
 	if g.includeStateFile {
-		t := NewTFStateWriter(g.ctx, g.resources, g.d, providerSource)
+		t := NewTFStateWriter(g.ctx, g.resources, g.d, providerSource, moduleAssignments)
 		if err := t.writeTfState(); err != nil {
 			return err
 		}
 	}
 
 	var err diag.Diagnostics
-	if g.exportAsHCL {
+	if g.moduleLayout != moduleLayoutNone {
+		moduleExporter := NewModuleExporter(g.moduleLayout, g.resourceTypesMaps, g.dataSourceTypesMaps, g.unresolvedAttrs, moduleAssignments, providerSource, g.version, g.exportDirPath, g.exportAsHCL)
+		err = moduleExporter.exportModules()
+	} else if g.exportAsHCL {
 		hclExporter := NewHClExporter(g.resourceTypesHCLBlocks, g.unresolvedAttrs, providerSource, g.version, g.exportDirPath, g.splitFilesByResource)
 		err = hclExporter.exportHCLConfig()
 	} else {
@@ -492,6 +601,11 @@ func (g *GenesysCloudResourceExporter) generateOutputFiles() diag.Diagnostics {
 		return err
 	}
 
+	err = g.writeFailedResourcePlaceholders()
+	if err != nil {
+		return err
+	}
+
 	if g.cyclicDependsList != nil && len(g.cyclicDependsList) > 0 {
 		err = files.WriteToFile([]byte(strings.Join(g.cyclicDependsList, "\n")), filepath.Join(g.exportDirPath, "cyclicDepends.txt"))
 
@@ -500,20 +614,66 @@ func (g *GenesysCloudResourceExporter) generateOutputFiles() diag.Diagnostics {
 		}
 	}
 
-	err = g.generateZipForExporter()
-	if err != nil {
-		return err
+	if len(g.dependencyGraphFormats) > 0 {
+		if err = g.writeDependencyGraph(); err != nil {
+			return err
+		}
 	}
 
 	return nil
 }
 
+// buildModuleAssignments maps the address of every exported resource to the child module it will be written to
+func (g *GenesysCloudResourceExporter) buildModuleAssignments() map[string]string {
+	addresses := exportedAddresses(g.resourceTypesMaps, g.dataSourceTypesMaps)
+
+	divisionLabels := make(map[string]string)
+	if divisionExporter, ok := (*g.exporters)["genesyscloud_auth_division"]; ok {
+		for id, meta := range divisionExporter.SanitizedResourceMap {
+			divisionLabels[id] = meta.BlockLabel
+		}
+	}
+
+	edges := make(map[string][]string)
+	addReferenceEdges := func(typesMaps map[string]resourceJSONMaps, isDataSource bool) {
+		for resType, resMaps := range typesMaps {
+			for resLabel, config := range resMaps {
+				address := resourceAddress(isDataSource, resType, resLabel)
+				for _, referenced := range findReferencedAddresses(config) {
+					edges[address] = append(edges[address], referenced.address)
+				}
+			}
+		}
+	}
+	addReferenceEdges(g.resourceTypesMaps, false)
+	addReferenceEdges(g.dataSourceTypesMaps, true)
+
+	// Flow dependencies discovered through the dependent consumers are keyed by ID
+	for id, dependencies := range g.dependsList {
+		source, ok := g.resourceAddresses[id]
+		if !ok {
+			continue
+		}
+		for _, dependency := range dependencies {
+			parts := strings.Split(dependency, ".")
+			if target, ok := g.resourceAddresses[parts[len(parts)-1]]; ok {
+				edges[source] = append(edges[source], target)
+			}
+		}
+	}
+
+	return assignModules(g.moduleLayout, addresses, g.resourceDivisions, divisionLabels, edges)
+}
+
 func (g *GenesysCloudResourceExporter) generateZipForExporter() diag.Diagnostics {
 	zipFileName := "../archive_genesyscloud_tf_export" + uuid.NewString() + ".zip"
 	if compress := g.d.Get("compress").(bool); compress { //if true, compress directory name of where the export is going to occur
 		// read all the files
 		var files []fileMeta
 		ferr := filepath.Walk(g.exportDirPath, func(path string, info os.FileInfo, ferr error) error {
+			if info.IsDir() && info.Name() == exportCheckpointDirectory {
+				return filepath.SkipDir
+			}
 			files = append(files, fileMeta{Path: path, IsDir: info.IsDir()})
 			return nil
 		})
@@ -532,7 +692,12 @@ func (g *GenesysCloudResourceExporter) generateZipForExporter() diag.Diagnostics
 			if !f.IsDir {
 				fPath := f.Path
 
-				w, ferr := zipWriter.Create(path.Base(fPath))
+				zipPath := path.Base(fPath)
+				if g.moduleLayout != moduleLayoutNone {
+					// Keep the module directories as each module contains a file with the same name
+					zipPath, _ = filepath.Rel(g.exportDirPath, fPath)
+				}
+				w, ferr := zipWriter.Create(zipPath)
 				if ferr != nil {
 					return diag.Errorf("Failed to create base path for zip %s", ferr)
 				}
@@ -895,9 +1060,21 @@ func (g *GenesysCloudResourceExporter) buildSanitizedResourceMaps(exporters map[
 		wg.Add(1)
 		go func(resourceType string, exporter *resourceExporter.ResourceExporter) {
 			defer wg.Done()
+			if typeCheckpoint, ok := g.checkpoint.completedType(resourceType); ok {
+				log.Printf("Restoring all resources for type %s from the export checkpoint", resourceType)
+				exporter.RestoreSanitizedResourceMap(typeCheckpoint.SanitizedResourceMap)
+				ids := make([]string, 0, len(typeCheckpoint.SanitizedResourceMap))
+				for id := range typeCheckpoint.SanitizedResourceMap {
+					ids = append(ids, id)
+				}
+				g.report.addDiscovery(resourceType, ids, 0)
+				return
+			}
+
 			log.Printf("Getting all resources for type %s", resourceType)
 			exporter.FilterResource = g.resourceFilter
 
+			startTime := time.Now()
 			err := exporter.LoadSanitizedResourceMap(ctx, resourceType, filter)
 
 			// Used in tests
@@ -907,6 +1084,12 @@ func (g *GenesysCloudResourceExporter) buildSanitizedResourceMaps(exporters map[
 			if containsPermissionsErrorOnly(err) && logErrors {
 				log.Printf("%v", err[0].Summary)
 				log.Printf("Logging permission error for %s. Resuming export...", resourceType)
+				g.report.addPermissionErrors(resourceType, err)
+				return
+			}
+			if err != nil && g.continueOnError {
+				log.Printf("Failed to get all resources for type %s. Resuming export...: %v", resourceType, err)
+				g.report.addFailedResource(resourceType, "", "", err)
 				return
 			}
 			if err != nil {
@@ -920,6 +1103,11 @@ func (g *GenesysCloudResourceExporter) buildSanitizedResourceMaps(exporters map[
 				cancel()
 				return
 			}
+			ids := make([]string, 0, len(exporter.SanitizedResourceMap))
+			for id := range exporter.SanitizedResourceMap {
+				ids = append(ids, id)
+			}
+			g.report.addDiscovery(resourceType, ids, time.Since(startTime))
 			log.Printf("Found %d resources for type %s", len(exporter.SanitizedResourceMap), resourceType)
 		}(resourceType, exporter)
 	}
@@ -1012,7 +1200,7 @@ func addLogAttrInfoToErrorSummary(err diag.Diagnostics) diag.Diagnostics {
 
 func (g *GenesysCloudResourceExporter) getResourcesForType(resType string, provider *schema.Provider, exporter *resourceExporter.ResourceExporter, meta interface{}) ([]resourceExporter.ResourceInfo, diag.Diagnostics) {
 	lenResources := len(exporter.SanitizedResourceMap)
-	errorChan := make(chan diag.Diagnostics, lenResources)
+	errorChan := make(chan resourceReadError, lenResources)
 	resourceChan := make(chan resourceExporter.ResourceInfo, lenResources)
 	removeChan := make(chan string, lenResources)
 
@@ -1024,6 +1212,11 @@ func (g *GenesysCloudResourceExporter) getResourcesForType(resType string, provi
 
 	exportComputed := g.exportComputed
 
+	startTime := time.Now()
+	defer func() {
+		g.report.addTypeReadDuration(resType, time.Since(startTime))
+	}()
+
 	var wg sync.WaitGroup
 	wg.Add(lenResources)
 	for id, resMeta := range exporter.SanitizedResourceMap {
@@ -1089,12 +1282,18 @@ func (g *GenesysCloudResourceExporter) getResourcesForType(resType string, provi
 
 			var err error
 			for ok := true; ok; ok = isTimeoutError(err) {
+				readStartTime := time.Now()
 				err = fetchResourceState()
 				if err == nil {
+					g.report.addRead(resType, id, resMeta.BlockLabel, time.Since(readStartTime))
 					return
 				}
 				if !isTimeoutError(err) {
-					errorChan <- diag.Errorf("Failed to get state for %s instance %s: %v", resType, id, err)
+					errorChan <- resourceReadError{
+						id:         id,
+						blockLabel: resMeta.BlockLabel,
+						err:        diag.Errorf("Failed to get state for %s instance %s: %v", resType, id, err),
+					}
 				}
 			}
 		}(id, resMeta)
@@ -1104,6 +1303,7 @@ func (g *GenesysCloudResourceExporter) getResourcesForType(resType string, provi
 		wg.Wait()
 		close(resourceChan)
 		close(removeChan)
+		close(errorChan)
 	}()
 
 	var resources []resourceExporter.ResourceInfo
@@ -1112,18 +1312,26 @@ func (g *GenesysCloudResourceExporter) getResourcesForType(resType string, provi
 	}
 
 	// Remove resources that weren't found in this pass
+	skipped := 0
 	for id := range removeChan {
 		log.Printf("Deleted resource %v", id)
 		delete(exporter.SanitizedResourceMap, id)
+		skipped++
 	}
+	g.report.addSkipped(resType, skipped)
 
-	// Return the first error if one was received
-	select {
-	case err := <-errorChan:
-		return nil, err
-	default:
-		return resources, nil
+	for readErr := range errorChan {
+		if !g.continueOnError {
+			// Return the first error if one was received
+			return nil, readErr.err
+		}
+		// Keep the instances that were read and drop the failed instance so no references to it are exported
+		log.Printf("Skipping %s instance %s after error: %v", resType, readErr.id, readErr.err)
+		g.report.addFailedResource(resType, readErr.id, readErr.blockLabel, readErr.err)
+		delete(exporter.SanitizedResourceMap, readErr.id)
 	}
+
+	return resources, nil
 }
 
 func getResourceState(ctx context.Context, resource *schema.Resource, resID string, resMeta *resourceExporter.ResourceMeta, meta interface{}, exportComputed bool) (*terraform.InstanceState, diag.Diagnostics) {
@@ -1297,7 +1505,7 @@ func (g *GenesysCloudResourceExporter) sanitizeConfigMap(
 		case string:
 			// Check if string contains nested Ref Attributes (can occur if the string is escaped json)
 			if _, ok := exporter.ContainsNestedRefAttrs(currAttr); ok {
-				resolvedJsonString, err := g.resolveRefAttributesInJsonString(currAttr, val.(string), exporter, exporters, exportingState)
+				resolvedJsonString, err := g.resolveRefAttributesInJsonString(resource, currAttr, val.(string), exporter, exporters, exportingState)
 				if err != nil {
 					log.Println(err)
 				} else {
@@ -1315,7 +1523,7 @@ func (g *GenesysCloudResourceExporter) sanitizeConfigMap(
 			}
 
 			if refSettings != nil {
-				configMap[key] = g.resolveReference(refSettings, val.(string), exporters, exportingState)
+				configMap[key] = g.resolveReference(resource, refSettings, val.(string), exporters, exportingState)
 			} else {
 				configMap[key] = escapeString(val.(string))
 			}
@@ -1511,7 +1719,7 @@ func (g *GenesysCloudResourceExporter) sanitizeConfigArray(
 			// Check if we are on a reference attribute and update value in array
 
 			if refSettings := exporter.GetRefAttrSettings(currAttr); refSettings != nil {
-				referenceVal := g.resolveReference(refSettings, val.(string), exporters, exportingState)
+				referenceVal := g.resolveReference(resource, refSettings, val.(string), exporters, exportingState)
 				if referenceVal != "" {
 					result = append(result, referenceVal)
 				}
@@ -1571,7 +1779,7 @@ func (g *GenesysCloudResourceExporter) populateConfigExcluded(exporters map[stri
 	return nil
 }
 
-func (g *GenesysCloudResourceExporter) resolveReference(refSettings *resourceExporter.RefAttrSettings, refID string, exporters map[string]*resourceExporter.ResourceExporter, exportingState bool) string {
+func (g *GenesysCloudResourceExporter) resolveReference(resource resourceExporter.ResourceInfo, refSettings *resourceExporter.RefAttrSettings, refID string, exporters map[string]*resourceExporter.ResourceExporter, exportingState bool) string {
 	if lists.ItemInSlice(refID, refSettings.AltValues) {
 		// This is not actually a reference to another object. Keep the value
 		return refID
@@ -1591,6 +1799,9 @@ func (g *GenesysCloudResourceExporter) resolveReference(refSettings *resourceExp
 			}
 		}
 	}
+	g.report.addUnresolvedReference(refSettings.RefType, refID)
+	g.addUnresolvedGraphReference(resource, refSettings.RefType, refID)
+
 	if g.buildSecondDeps == nil || len(g.buildSecondDeps) == 0 {
 		g.buildSecondDeps = make(map[string][]string)
 	}
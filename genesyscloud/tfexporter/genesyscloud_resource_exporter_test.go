@@ -505,6 +505,39 @@ func TestUnitTfExportMergeExporters(t *testing.T) {
 	}
 }
 
+func TestUnitTfExportRemoveOptInExporters(t *testing.T) {
+	filter := []string{"genesyscloud_routing_queue", "genesyscloud_routing_queue_members::queue_1"}
+	testCases := []struct {
+		name       string
+		filterType ExporterFilterType
+		filterList *[]string
+		expected   []string
+	}{
+		{name: "no filter", filterType: IncludeResources, expected: []string{"genesyscloud_routing_queue"}},
+		{name: "include filter", filterType: IncludeResources, filterList: &filter, expected: []string{"genesyscloud_routing_queue", "genesyscloud_routing_queue_members"}},
+		{name: "legacy filter", filterType: LegacyInclude, filterList: &filter, expected: []string{"genesyscloud_routing_queue", "genesyscloud_routing_queue_members"}},
+		{name: "exclude filter", filterType: ExcludeResources, filterList: &filter, expected: []string{"genesyscloud_routing_queue"}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			gre := &GenesysCloudResourceExporter{filterType: tc.filterType, filterList: tc.filterList}
+			exports := map[string]*resourceExporter.ResourceExporter{
+				"genesyscloud_routing_queue":         {},
+				"genesyscloud_routing_queue_members": {OptIn: true},
+			}
+
+			gre.removeOptInExporters(exports)
+
+			actual := make([]string, 0, len(exports))
+			for resType := range exports {
+				actual = append(actual, resType)
+			}
+			assert.ElementsMatch(t, tc.expected, actual)
+		})
+	}
+}
+
 func TestUnitResolveValueToDataSource(t *testing.T) {
 	var (
 		originalValueOfScriptId            = "1234"
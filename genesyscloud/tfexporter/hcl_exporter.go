@@ -199,15 +199,18 @@ func writeHCLToFile(bytes [][]byte, path string) diag.Diagnostics {
 }
 
 func instanceStateToHCLBlock(resType, resLabel string, json util.JsonMap, isDataSource bool) []byte {
+	if isDataSource {
+		return jsonMapToHCLBlock("data", []string{resType, resLabel}, json)
+	}
+	return jsonMapToHCLBlock("resource", []string{resType, resLabel}, json)
+}
+
+// Creates an HCL block of the given type e.g. resource, module or output with the attributes and nested blocks of the JSON map
+func jsonMapToHCLBlock(blockType string, labels []string, json util.JsonMap) []byte {
 	f := hclwrite.NewEmptyFile()
 	rootBody := f.Body()
 
-	var block *hclwrite.Block
-	if isDataSource {
-		block = rootBody.AppendNewBlock("data", []string{resType, resLabel})
-	} else {
-		block = rootBody.AppendNewBlock("resource", []string{resType, resLabel})
-	}
+	block := rootBody.AppendNewBlock(blockType, labels)
 
 	body := block.Body()
 
@@ -207,7 +207,7 @@ func getDecodedData(jsonString string, currAttr string) (string, error) {
 	return formattedJsonStr, nil
 }
 
-func (g *GenesysCloudResourceExporter) resolveRefAttributesInJsonString(currAttr string, currVal string, exporter *resourceExporter.ResourceExporter, exporters map[string]*resourceExporter.ResourceExporter, exportingState bool) (string, error) {
+func (g *GenesysCloudResourceExporter) resolveRefAttributesInJsonString(resource resourceExporter.ResourceInfo, currAttr string, currVal string, exporter *resourceExporter.ResourceExporter, exporters map[string]*resourceExporter.ResourceExporter, exportingState bool) (string, error) {
 	var jsonData interface{}
 	err := json.Unmarshal([]byte(currVal), &jsonData)
 	if err != nil {
@@ -220,11 +220,11 @@ func (g *GenesysCloudResourceExporter) resolveRefAttributesInJsonString(currAttr
 		if data, ok := jsonData.(map[string]interface{}); ok {
 			switch data[value].(type) {
 			case string:
-				data[value] = g.resolveReference(refSettings, data[value].(string), exporters, exportingState)
+				data[value] = g.resolveReference(resource, refSettings, data[value].(string), exporters, exportingState)
 			case []interface{}:
 				array := data[value].([]interface{})
 				for k, v := range array {
-					array[k] = g.resolveReference(refSettings, v.(string), exporters, exportingState)
+					array[k] = g.resolveReference(resource, refSettings, v.(string), exporters, exportingState)
 				}
 				data[value] = array
 			}
@@ -0,0 +1,642 @@
+package tfexporter
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"terraform-provider-genesyscloud/genesyscloud/util"
+
+	"github.com/hashicorp/hcl/v2/hclwrite"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/mohae/deepcopy"
+	zclconfCty "github.com/zclconf/go-cty/cty"
+)
+
+/*
+   This file contains all of the functions used to export the configuration as a root module with a set of child modules.
+   Resources are grouped into child modules according to the module_layout attribute. References between resources
+   living in different child modules are rewritten to module variables, which the root module wires to the outputs of the
+   owning module.
+*/
+
+const (
+	moduleLayoutNone       = "none"
+	moduleLayoutDivision   = "division"
+	moduleLayoutDependency = "dependency"
+	moduleLayoutFamily     = "resource_family"
+
+	moduleDirectory = "modules"
+
+	// Module holding resources without a division or resources which are not connected to any other resource
+	sharedModuleName = "shared"
+)
+
+var (
+	moduleLayouts = []string{moduleLayoutNone, moduleLayoutDivision, moduleLayoutDependency, moduleLayoutFamily}
+
+	// Matches the interpolations in a string which have not been escaped with a leading '$'
+	interpolationRegex = regexp.MustCompile(`(^|[^$])\$\{([^}]*)\}`)
+	// Matches a resource or data source attribute reference e.g. genesyscloud_routing_queue.my_queue.id
+	resourceReferenceRegex = regexp.MustCompile(`(data\.)?(genesyscloud_[A-Za-z0-9_]+)\.([A-Za-z0-9_-]+)\.([A-Za-z0-9_]+)`)
+	// Matches a variable reference e.g. var.genesyscloud_flow_my_flow_filepath
+	variableReferenceRegex = regexp.MustCompile(`var\.([A-Za-z0-9_-]+)`)
+	// Matches a depends_on placeholder e.g. $dep$genesyscloud_routing_queue.my_queue$dep$
+	dependsOnPlaceholderRegex = regexp.MustCompile(`^\$dep\$([^$]+)\$dep\$$`)
+	invalidModuleNameChars    = regexp.MustCompile(`[^a-z0-9_-]+`)
+)
+
+type exportModule struct {
+	name        string
+	resources   map[string]resourceJSONMaps
+	dataSources map[string]resourceJSONMaps
+	// Module variable name -> expression assigned to it in the root module
+	inputs map[string]string
+	// Module output name -> expression of the output value
+	outputs   map[string]string
+	dependsOn map[string]bool
+}
+
+type ModuleExporter struct {
+	layout              string
+	resourceTypesMaps   map[string]resourceJSONMaps
+	dataSourceTypesMaps map[string]resourceJSONMaps
+	unresolvedAttrs     []unresolvableAttributeInfo
+	providerSource      string
+	version             string
+	dirPath             string
+	exportAsHCL         bool
+	assignments         map[string]string
+	modules             map[string]*exportModule
+}
+
+func NewModuleExporter(layout string, resourceTypesMaps map[string]resourceJSONMaps, dataSourceTypesMaps map[string]resourceJSONMaps, unresolvedAttrs []unresolvableAttributeInfo, assignments map[string]string, providerSource string, version string, dirPath string, exportAsHCL bool) *ModuleExporter {
+	moduleExporter := &ModuleExporter{
+		layout:              layout,
+		resourceTypesMaps:   resourceTypesMaps,
+		dataSourceTypesMaps: dataSourceTypesMaps,
+		unresolvedAttrs:     unresolvedAttrs,
+		providerSource:      providerSource,
+		version:             version,
+		dirPath:             dirPath,
+		exportAsHCL:         exportAsHCL,
+		assignments:         assignments,
+		modules:             make(map[string]*exportModule),
+	}
+	return moduleExporter
+}
+
+// resourceAddress returns the terraform address of a resource or data source block
+func resourceAddress(isDataSource bool, resType, resLabel string) string {
+	if isDataSource {
+		return fmt.Sprintf("data.%s.%s", resType, resLabel)
+	}
+	return fmt.Sprintf("%s.%s", resType, resLabel)
+}
+
+// exportedAddresses returns the sorted addresses of all resources and data sources being exported
+func exportedAddresses(resourceTypesMaps map[string]resourceJSONMaps, dataSourceTypesMaps map[string]resourceJSONMaps) []string {
+	addresses := make([]string, 0)
+	for resType, resMaps := range resourceTypesMaps {
+		for resLabel := range resMaps {
+			addresses = append(addresses, resourceAddress(false, resType, resLabel))
+		}
+	}
+	for resType, resMaps := range dataSourceTypesMaps {
+		for resLabel := range resMaps {
+			addresses = append(addresses, resourceAddress(true, resType, resLabel))
+		}
+	}
+	sort.Strings(addresses)
+	return addresses
+}
+
+// referencedAddress is the address of a resource referenced from inside a config map, and whether it is referenced
+// through depends_on or through an attribute
+type referencedAddress struct {
+	address string
+	kind    string
+}
+
+// findReferencedAddresses returns the addresses of all resources referenced from inside a config map,
+// including references in depends_on and in decoded jsonencode attributes. The config is left unchanged.
+func findReferencedAddresses(configMap util.JsonMap) []referencedAddress {
+	referenced := make([]referencedAddress, 0)
+	walkConfigStrings(configMap, func(s string) string {
+		if match := dependsOnPlaceholderRegex.FindStringSubmatch(s); match != nil {
+			referenced = append(referenced, referencedAddress{address: match[1], kind: dependencyEdgeDependsOn})
+			return s
+		}
+		value := s
+		if decoded, ok := attributesDecoded[s]; ok {
+			value = decoded
+		}
+		for _, interpolation := range interpolationRegex.FindAllStringSubmatch(value, -1) {
+			for _, ref := range resourceReferenceRegex.FindAllStringSubmatch(interpolation[2], -1) {
+				referenced = append(referenced, referencedAddress{address: ref[1] + ref[2] + "." + ref[3], kind: dependencyEdgeReference})
+			}
+		}
+		return s
+	})
+	return referenced
+}
+
+// walkConfigStrings calls fn for every string value inside of the config and replaces the value with the result
+func walkConfigStrings(value interface{}, fn func(string) string) interface{} {
+	switch v := value.(type) {
+	case util.JsonMap:
+		for key, val := range v {
+			v[key] = walkConfigStrings(val, fn)
+		}
+	case map[string]interface{}:
+		for key, val := range v {
+			v[key] = walkConfigStrings(val, fn)
+		}
+	case []interface{}:
+		for i, val := range v {
+			v[i] = walkConfigStrings(val, fn)
+		}
+	case []string:
+		for i, val := range v {
+			v[i] = fn(val)
+		}
+	case string:
+		return fn(v)
+	}
+	return value
+}
+
+// sanitizeModuleName converts a label into a valid, lower case terraform module name
+func sanitizeModuleName(name string) string {
+	sanitized := strings.Trim(invalidModuleNameChars.ReplaceAllString(strings.ToLower(name), "_"), "_")
+	if sanitized == "" {
+		return sharedModuleName
+	}
+	if sanitized[0] >= '0' && sanitized[0] <= '9' {
+		sanitized = "_" + sanitized
+	}
+	return sanitized
+}
+
+// resourceFamily returns the family of a resource type, e.g. genesyscloud_routing_queue -> routing
+func resourceFamily(resType string) string {
+	family := strings.TrimPrefix(resType, "genesyscloud_")
+	if i := strings.Index(family, "_"); i > 0 {
+		family = family[:i]
+	}
+	return family
+}
+
+// addressType returns the resource type of a resource or data source address
+func addressType(address string) string {
+	return strings.Split(strings.TrimPrefix(address, "data."), ".")[0]
+}
+
+/*
+assignModules determines the child module each exported address belongs to.
+
+  - division: resources are grouped by their division. divisionLabels maps a division ID to the label used for the module.
+    Resources without a division are placed in the shared module.
+  - dependency: resources connected through references (edges) are grouped into the same module. The module is named after
+    the first resource of the group. Resources with no connections are placed in the shared module.
+  - resource_family: resources are grouped by the family of their resource type, e.g. routing, outbound or architect.
+*/
+func assignModules(layout string, addresses []string, divisions map[string]string, divisionLabels map[string]string, edges map[string][]string) map[string]string {
+	assignments := make(map[string]string, len(addresses))
+
+	switch layout {
+	case moduleLayoutDivision:
+		for _, address := range addresses {
+			divisionId, ok := divisions[address]
+			if !ok || divisionId == "" {
+				assignments[address] = sharedModuleName
+				continue
+			}
+			label, ok := divisionLabels[divisionId]
+			if !ok || label == "" {
+				label = "division_" + divisionId
+			}
+			assignments[address] = sanitizeModuleName(label)
+		}
+	case moduleLayoutDependency:
+		parents := make(map[string]string, len(addresses))
+		for _, address := range addresses {
+			parents[address] = address
+		}
+		var find func(string) string
+		find = func(address string) string {
+			if parents[address] != address {
+				parents[address] = find(parents[address])
+			}
+			return parents[address]
+		}
+		for source, targets := range edges {
+			if _, ok := parents[source]; !ok {
+				continue
+			}
+			for _, target := range targets {
+				if _, ok := parents[target]; !ok {
+					continue
+				}
+				rootSource, rootTarget := find(source), find(target)
+				// Keep the lowest address as the root so the component name is deterministic
+				if rootSource < rootTarget {
+					parents[rootTarget] = rootSource
+				} else if rootTarget < rootSource {
+					parents[rootSource] = rootTarget
+				}
+			}
+		}
+
+		componentSizes := make(map[string]int)
+		for _, address := range addresses {
+			componentSizes[find(address)]++
+		}
+		for _, address := range addresses {
+			root := find(address)
+			if componentSizes[root] == 1 {
+				assignments[address] = sharedModuleName
+				continue
+			}
+			parts := strings.Split(strings.TrimPrefix(root, "data."), ".")
+			assignments[address] = sanitizeModuleName(resourceFamily(parts[0]) + "_" + parts[1])
+		}
+	case moduleLayoutFamily:
+		for _, address := range addresses {
+			assignments[address] = sanitizeModuleName(resourceFamily(addressType(address)))
+		}
+	}
+
+	return assignments
+}
+
+func (m *ModuleExporter) getModule(name string) *exportModule {
+	module, ok := m.modules[name]
+	if !ok {
+		module = &exportModule{
+			name:        name,
+			resources:   make(map[string]resourceJSONMaps),
+			dataSources: make(map[string]resourceJSONMaps),
+			inputs:      make(map[string]string),
+			outputs:     make(map[string]string),
+			dependsOn:   make(map[string]bool),
+		}
+		m.modules[name] = module
+	}
+	return module
+}
+
+func (m *ModuleExporter) moduleForAddress(address string) string {
+	if name, ok := m.assignments[address]; ok {
+		return name
+	}
+	return sharedModuleName
+}
+
+// buildModules distributes the exported config between the child modules and rewrites cross module references
+func (m *ModuleExporter) buildModules() {
+	place := func(typesMaps map[string]resourceJSONMaps, isDataSource bool) {
+		for resType, resMaps := range typesMaps {
+			for resLabel, config := range resMaps {
+				module := m.getModule(m.moduleForAddress(resourceAddress(isDataSource, resType, resLabel)))
+				target := module.resources
+				if isDataSource {
+					target = module.dataSources
+				}
+				if target[resType] == nil {
+					target[resType] = make(resourceJSONMaps)
+				}
+				configCopy, _ := deepcopy.Copy(config).(util.JsonMap)
+				target[resType][resLabel] = configCopy
+			}
+		}
+	}
+	place(m.resourceTypesMaps, false)
+	place(m.dataSourceTypesMaps, true)
+
+	for _, module := range m.modules {
+		for _, typesMaps := range []map[string]resourceJSONMaps{module.resources, module.dataSources} {
+			for _, resMaps := range typesMaps {
+				for _, config := range resMaps {
+					m.rewriteDependsOn(module, config)
+					walkConfigStrings(config, func(s string) string {
+						if decoded, ok := attributesDecoded[s]; ok {
+							attributesDecoded[s] = m.rewriteReferences(module, decoded)
+							return s
+						}
+						return m.rewriteReferences(module, s)
+					})
+				}
+			}
+		}
+	}
+}
+
+// rewriteDependsOn removes depends_on entries pointing at other modules and records them as module dependencies
+func (m *ModuleExporter) rewriteDependsOn(module *exportModule, config util.JsonMap) {
+	dependsOn, ok := config["depends_on"].([]string)
+	if !ok {
+		return
+	}
+	local := make([]string, 0, len(dependsOn))
+	for _, dep := range dependsOn {
+		match := dependsOnPlaceholderRegex.FindStringSubmatch(dep)
+		if match == nil {
+			local = append(local, dep)
+			continue
+		}
+		if target := m.moduleForAddress(match[1]); target != module.name {
+			module.dependsOn[target] = true
+			continue
+		}
+		local = append(local, dep)
+	}
+	if len(local) == 0 {
+		delete(config, "depends_on")
+		return
+	}
+	config["depends_on"] = local
+}
+
+// rewriteReferences replaces references to resources owned by other modules with module variables and
+// records the variables which must be passed through from the root module
+func (m *ModuleExporter) rewriteReferences(module *exportModule, s string) string {
+	matches := interpolationRegex.FindAllStringSubmatchIndex(s, -1)
+	if matches == nil {
+		return s
+	}
+
+	var result strings.Builder
+	last := 0
+	for _, match := range matches {
+		// match[4]:match[5] is the expression inside of the interpolation
+		exprStart, exprEnd := match[4], match[5]
+		result.WriteString(s[last:exprStart])
+
+		expr := s[exprStart:exprEnd]
+		for _, variable := range variableReferenceRegex.FindAllStringSubmatch(expr, -1) {
+			module.inputs[variable[1]] = fmt.Sprintf("${var.%s}", variable[1])
+		}
+		expr = resourceReferenceRegex.ReplaceAllStringFunc(expr, func(ref string) string {
+			parts := resourceReferenceRegex.FindStringSubmatch(ref)
+			address := parts[1] + parts[2] + "." + parts[3]
+			owner := m.moduleForAddress(address)
+			if owner == module.name {
+				return ref
+			}
+			varName := strings.ReplaceAll(strings.Join([]string{parts[1] + parts[2], parts[3], parts[4]}, "_"), ".", "_")
+			m.getModule(owner).outputs[varName] = fmt.Sprintf("${%s}", ref)
+			module.inputs[varName] = fmt.Sprintf("${module.%s.%s}", owner, varName)
+			return "var." + varName
+		})
+		result.WriteString(expr)
+		last = exprEnd
+	}
+	result.WriteString(s[last:])
+	return result.String()
+}
+
+// resolveModuleDependencies returns the sorted list of modules the module should declare in depends_on.
+// A module dependency is skipped if it would introduce a cycle between modules.
+func (m *ModuleExporter) resolveModuleDependencies() map[string][]string {
+	graph := make(map[string]map[string]bool)
+	for name, module := range m.modules {
+		graph[name] = make(map[string]bool)
+		for _, expr := range module.inputs {
+			if strings.HasPrefix(expr, "${module.") {
+				graph[name][strings.Split(strings.TrimPrefix(expr, "${module."), ".")[0]] = true
+			}
+		}
+	}
+
+	var reachable func(from, to string, visited map[string]bool) bool
+	reachable = func(from, to string, visited map[string]bool) bool {
+		if from == to {
+			return true
+		}
+		visited[from] = true
+		for next := range graph[from] {
+			if !visited[next] && reachable(next, to, visited) {
+				return true
+			}
+		}
+		return false
+	}
+
+	dependencies := make(map[string][]string)
+	for _, name := range m.sortedModuleNames() {
+		targets := make([]string, 0)
+		for target := range m.modules[name].dependsOn {
+			targets = append(targets, target)
+		}
+		sort.Strings(targets)
+		for _, target := range targets {
+			if reachable(target, name, make(map[string]bool)) {
+				log.Printf("Skipping depends_on from module %s to module %s as it would create a cycle", name, target)
+				continue
+			}
+			graph[name][target] = true
+			dependencies[name] = append(dependencies[name], target)
+		}
+	}
+	return dependencies
+}
+
+func (m *ModuleExporter) sortedModuleNames() []string {
+	names := make([]string, 0, len(m.modules))
+	for name := range m.modules {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func sortedKeys(values map[string]string) []string {
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func (m *ModuleExporter) exportModules() diag.Diagnostics {
+	m.buildModules()
+	dependencies := m.resolveModuleDependencies()
+
+	for _, name := range m.sortedModuleNames() {
+		module := m.modules[name]
+		moduleDirPath := filepath.Join(m.dirPath, moduleDirectory, name)
+		if err := os.MkdirAll(moduleDirPath, os.ModePerm); err != nil {
+			return diag.Errorf("Failed to create module directory %s: %v", moduleDirPath, err)
+		}
+		var diagErr diag.Diagnostics
+		if m.exportAsHCL {
+			diagErr = m.writeHCLModule(module, filepath.Join(moduleDirPath, defaultTfHCLFile))
+		} else {
+			diagErr = m.writeJSONModule(module, filepath.Join(moduleDirPath, defaultTfJSONFile))
+		}
+		if diagErr != nil {
+			return diagErr
+		}
+	}
+
+	var diagErr diag.Diagnostics
+	if m.exportAsHCL {
+		diagErr = m.writeHCLRoot(dependencies, filepath.Join(m.dirPath, defaultTfHCLFile))
+	} else {
+		diagErr = m.writeJSONRoot(dependencies, filepath.Join(m.dirPath, defaultTfJSONFile))
+	}
+	if diagErr != nil {
+		return diagErr
+	}
+
+	// Optional tfvars file creation for unresolved attributes. These are declared in the root module and passed to child modules.
+	if len(m.unresolvedAttrs) > 0 {
+		tfVars := make(map[string]interface{})
+		for _, attr := range m.unresolvedAttrs {
+			tfVars[createUnresolvedAttrKey(attr)] = determineVarValue(attr.Schema)
+		}
+		if diagErr := writeTfVars(tfVars, filepath.Join(m.dirPath, defaultTfVarsFile)); diagErr != nil {
+			return diagErr
+		}
+	}
+
+	log.Printf("Exported %d modules using the %s module layout", len(m.modules), m.layout)
+	return nil
+}
+
+func moduleBlockConfig(module *exportModule, dependencies []string) util.JsonMap {
+	config := util.JsonMap{
+		"source": fmt.Sprintf("./%s/%s", moduleDirectory, module.name),
+	}
+	for varName, expr := range module.inputs {
+		config[varName] = expr
+	}
+	if len(dependencies) > 0 {
+		dependsOn := make([]string, 0, len(dependencies))
+		for _, dep := range dependencies {
+			dependsOn = append(dependsOn, fmt.Sprintf("$dep$module.%s$dep$", dep))
+		}
+		config["depends_on"] = dependsOn
+	}
+	return config
+}
+
+func (m *ModuleExporter) writeJSONModule(module *exportModule, path string) diag.Diagnostics {
+	rootJSONObject := util.JsonMap{
+		"terraform": createModuleProviderJsonMap(m.providerSource),
+	}
+	if len(module.resources) > 0 {
+		rootJSONObject["resource"] = module.resources
+	}
+	if len(module.dataSources) > 0 {
+		rootJSONObject["data"] = module.dataSources
+	}
+	if len(module.inputs) > 0 {
+		variables := make(map[string]util.JsonMap)
+		for varName := range module.inputs {
+			variables[varName] = util.JsonMap{}
+		}
+		rootJSONObject["variable"] = variables
+	}
+	if len(module.outputs) > 0 {
+		outputs := make(map[string]util.JsonMap)
+		for outputName, expr := range module.outputs {
+			outputs[outputName] = util.JsonMap{"value": expr}
+		}
+		rootJSONObject["output"] = outputs
+	}
+	return writeConfig(rootJSONObject, path)
+}
+
+func (m *ModuleExporter) writeJSONRoot(dependencies map[string][]string, path string) diag.Diagnostics {
+	modules := make(map[string]util.JsonMap)
+	for name, module := range m.modules {
+		modules[name] = moduleBlockConfig(module, dependencies[name])
+	}
+	rootJSONObject := util.JsonMap{
+		"terraform": createProviderJsonMap(m.providerSource, m.version),
+		"module":    modules,
+	}
+	if variablesJsonMap := createVariablesJsonMap(m.unresolvedAttrs); len(variablesJsonMap) > 0 {
+		rootJSONObject["variable"] = variablesJsonMap
+	}
+	return writeConfig(rootJSONObject, path)
+}
+
+func (m *ModuleExporter) writeHCLModule(module *exportModule, path string) diag.Diagnostics {
+	blocks := [][]byte{createHCLModuleProviderBlock(m.providerSource)}
+	for _, resType := range sortedTypes(module.dataSources) {
+		for _, resLabel := range sortedLabels(module.dataSources[resType]) {
+			blocks = append(blocks, instanceStateToHCLBlock(resType, resLabel, module.dataSources[resType][resLabel], true))
+		}
+	}
+	for _, resType := range sortedTypes(module.resources) {
+		for _, resLabel := range sortedLabels(module.resources[resType]) {
+			blocks = append(blocks, instanceStateToHCLBlock(resType, resLabel, module.resources[resType][resLabel], false))
+		}
+	}
+
+	variablesFile := hclwrite.NewEmptyFile()
+	for _, varName := range sortedKeys(module.inputs) {
+		variablesFile.Body().AppendNewBlock("variable", []string{varName})
+	}
+	blocks = append(blocks, variablesFile.Bytes())
+	for _, outputName := range sortedKeys(module.outputs) {
+		blocks = append(blocks, jsonMapToHCLBlock("output", []string{outputName}, util.JsonMap{"value": module.outputs[outputName]}))
+	}
+
+	return writeHCLToFile(blocks, path)
+}
+
+func (m *ModuleExporter) writeHCLRoot(dependencies map[string][]string, path string) diag.Diagnostics {
+	blocks := [][]byte{createHCLProviderBlock(m.providerSource, m.version)}
+	for _, name := range m.sortedModuleNames() {
+		blocks = append(blocks, jsonMapToHCLBlock("module", []string{name}, moduleBlockConfig(m.modules[name], dependencies[name])))
+	}
+	blocks = append(blocks, createHCLVariablesBlock(m.unresolvedAttrs))
+	return writeHCLToFile(blocks, path)
+}
+
+// Child modules declare the provider source without a version constraint as the root module pins the version
+func createModuleProviderJsonMap(providerSource string) util.JsonMap {
+	return util.JsonMap{
+		"required_providers": util.JsonMap{
+			"genesyscloud": util.JsonMap{
+				"source": providerSource,
+			},
+		},
+	}
+}
+
+func createHCLModuleProviderBlock(providerSource string) []byte {
+	rootFile := hclwrite.NewEmptyFile()
+	tfBlock := rootFile.Body().AppendNewBlock("terraform", nil)
+	requiredProvidersBlock := tfBlock.Body().AppendNewBlock("required_providers", nil)
+	requiredProvidersBlock.Body().SetAttributeValue("genesyscloud", zclconfCty.ObjectVal(map[string]zclconfCty.Value{
+		"source": zclconfCty.StringVal(providerSource),
+	}))
+	return rootFile.Bytes()
+}
+
+func sortedTypes(typesMaps map[string]resourceJSONMaps) []string {
+	types := make([]string, 0, len(typesMaps))
+	for resType := range typesMaps {
+		types = append(types, resType)
+	}
+	sort.Strings(types)
+	return types
+}
+
+func sortedLabels(resMaps resourceJSONMaps) []string {
+	labels := make([]string, 0, len(resMaps))
+	for resLabel := range resMaps {
+		labels = append(labels, resLabel)
+	}
+	sort.Strings(labels)
+	return labels
+}
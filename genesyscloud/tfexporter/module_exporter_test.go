@@ -0,0 +1,118 @@
+package tfexporter
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"terraform-provider-genesyscloud/genesyscloud/util"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUnitAssignModules(t *testing.T) {
+	addresses := []string{
+		"genesyscloud_routing_queue.queue_1",
+		"genesyscloud_routing_skill.skill_1",
+		"genesyscloud_outbound_campaign.campaign_1",
+		"genesyscloud_user.user_1",
+		"data.genesyscloud_auth_division_home.home",
+	}
+	divisions := map[string]string{
+		"genesyscloud_routing_queue.queue_1":        "division-id-1",
+		"genesyscloud_outbound_campaign.campaign_1": "division-id-2",
+		"genesyscloud_user.user_1":                  "division-id-1",
+	}
+	divisionLabels := map[string]string{
+		"division-id-1": "Sales Division",
+	}
+	edges := map[string][]string{
+		"genesyscloud_routing_queue.queue_1":        {"genesyscloud_routing_skill.skill_1"},
+		"genesyscloud_outbound_campaign.campaign_1": {"genesyscloud_routing_queue.queue_1", "genesyscloud_unknown.missing"},
+	}
+
+	byDivision := assignModules(moduleLayoutDivision, addresses, divisions, divisionLabels, edges)
+	assert.Equal(t, "sales_division", byDivision["genesyscloud_routing_queue.queue_1"])
+	assert.Equal(t, "sales_division", byDivision["genesyscloud_user.user_1"])
+	assert.Equal(t, "division_division-id-2", byDivision["genesyscloud_outbound_campaign.campaign_1"])
+	assert.Equal(t, sharedModuleName, byDivision["genesyscloud_routing_skill.skill_1"])
+
+	byDependency := assignModules(moduleLayoutDependency, addresses, divisions, divisionLabels, edges)
+	assert.Equal(t, "outbound_campaign_1", byDependency["genesyscloud_routing_queue.queue_1"])
+	assert.Equal(t, "outbound_campaign_1", byDependency["genesyscloud_routing_skill.skill_1"])
+	assert.Equal(t, "outbound_campaign_1", byDependency["genesyscloud_outbound_campaign.campaign_1"])
+	assert.Equal(t, sharedModuleName, byDependency["genesyscloud_user.user_1"])
+	assert.Equal(t, sharedModuleName, byDependency["data.genesyscloud_auth_division_home.home"])
+
+	byFamily := assignModules(moduleLayoutFamily, addresses, divisions, divisionLabels, edges)
+	assert.Equal(t, "routing", byFamily["genesyscloud_routing_queue.queue_1"])
+	assert.Equal(t, "routing", byFamily["genesyscloud_routing_skill.skill_1"])
+	assert.Equal(t, "outbound", byFamily["genesyscloud_outbound_campaign.campaign_1"])
+	assert.Equal(t, "user", byFamily["genesyscloud_user.user_1"])
+	assert.Equal(t, "auth", byFamily["data.genesyscloud_auth_division_home.home"])
+}
+
+func TestUnitModuleExporterCrossModuleReferences(t *testing.T) {
+	resourceTypesMaps := map[string]resourceJSONMaps{
+		"genesyscloud_routing_queue": {
+			"queue_1": util.JsonMap{
+				"name":       "Queue 1",
+				"skill_ids":  []interface{}{"${genesyscloud_routing_skill.skill_1.id}"},
+				"depends_on": []string{"$dep$genesyscloud_routing_skill.skill_1$dep$"},
+			},
+		},
+		"genesyscloud_routing_skill": {
+			"skill_1": util.JsonMap{
+				"name":        "Skill $${literal}",
+				"description": "${var.genesyscloud_routing_skill_skill_1_description}",
+			},
+		},
+	}
+	assignments := map[string]string{
+		"genesyscloud_routing_queue.queue_1": "queues",
+		"genesyscloud_routing_skill.skill_1": "skills",
+	}
+
+	dir := t.TempDir()
+	exporter := NewModuleExporter(moduleLayoutFamily, resourceTypesMaps, map[string]resourceJSONMaps{}, nil, assignments, "genesys.com/mypurecloud/genesyscloud", "0.1.0", dir, false)
+	exporter.buildModules()
+
+	queues := exporter.modules["queues"]
+	skills := exporter.modules["skills"]
+	assert.Equal(t, []interface{}{"${var.genesyscloud_routing_skill_skill_1_id}"}, queues.resources["genesyscloud_routing_queue"]["queue_1"]["skill_ids"])
+	assert.NotContains(t, queues.resources["genesyscloud_routing_queue"]["queue_1"], "depends_on")
+	assert.Equal(t, "${module.skills.genesyscloud_routing_skill_skill_1_id}", queues.inputs["genesyscloud_routing_skill_skill_1_id"])
+	assert.True(t, queues.dependsOn["skills"])
+	assert.Equal(t, "${genesyscloud_routing_skill.skill_1.id}", skills.outputs["genesyscloud_routing_skill_skill_1_id"])
+	assert.Equal(t, "${var.genesyscloud_routing_skill_skill_1_description}", skills.inputs["genesyscloud_routing_skill_skill_1_description"])
+	assert.Equal(t, "Skill $${literal}", skills.resources["genesyscloud_routing_skill"]["skill_1"]["name"])
+
+	// The original config must not be modified
+	assert.Equal(t, []interface{}{"${genesyscloud_routing_skill.skill_1.id}"}, resourceTypesMaps["genesyscloud_routing_queue"]["queue_1"]["skill_ids"])
+
+	// The queues module consumes an output of the skills module so skills can not depend on queues
+	skills.dependsOn["queues"] = true
+	dependencies := exporter.resolveModuleDependencies()
+	assert.Equal(t, []string{"skills"}, dependencies["queues"])
+	assert.Empty(t, dependencies["skills"])
+
+	exporter = NewModuleExporter(moduleLayoutFamily, resourceTypesMaps, map[string]resourceJSONMaps{}, nil, assignments, "genesys.com/mypurecloud/genesyscloud", "0.1.0", dir, false)
+	if diagErr := exporter.exportModules(); diagErr != nil {
+		t.Fatalf("%v", diagErr)
+	}
+	for _, path := range []string{
+		filepath.Join(dir, defaultTfJSONFile),
+		filepath.Join(dir, moduleDirectory, "queues", defaultTfJSONFile),
+		filepath.Join(dir, moduleDirectory, "skills", defaultTfJSONFile),
+	} {
+		if _, err := os.Stat(path); err != nil {
+			t.Errorf("expected file %s to be exported: %v", path, err)
+		}
+	}
+	root, err := os.ReadFile(filepath.Join(dir, defaultTfJSONFile))
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.True(t, strings.Contains(string(root), `"source": "./modules/queues"`))
+	assert.True(t, strings.Contains(string(root), `"module.skills"`))
+}
@@ -15,6 +15,7 @@ import (
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 type fileMeta struct {
@@ -112,6 +113,25 @@ func ResourceTfExport() *schema.Resource {
 				Default:     false,
 				ForceNew:    true,
 			},
+			"module_layout": {
+				Description:   "Organize the exported resources into child modules. 'division' creates a module per division, 'dependency' creates a module per group of resources referencing each other and 'resource_family' creates a module per resource family (e.g. routing, outbound). References between modules are wired through generated module variables and outputs. Defaults to 'none' which exports a single root module.",
+				Type:          schema.TypeString,
+				Optional:      true,
+				Default:       moduleLayoutNone,
+				ForceNew:      true,
+				ValidateFunc:  validation.StringInSlice(moduleLayouts, false),
+				ConflictsWith: []string{"split_files_by_resource"},
+			},
+			"dependency_graph_formats": {
+				Description: fmt.Sprintf("Write the dependency graph of the exported resources to '%s.<ext>' files in the export directory. Supported formats are 'dot', 'mermaid' and 'json'. The graph contains the references between resources, the flow dependencies found by the dependency resolution and the references to resources that were filtered out of the export. Resources that depend on each other in a cycle are highlighted.", dependencyGraphFileName),
+				Type:        schema.TypeList,
+				Optional:    true,
+				Elem: &schema.Schema{
+					Type:         schema.TypeString,
+					ValidateFunc: validation.StringInSlice(dependencyGraphFormats, false),
+				},
+				ForceNew: true,
+			},
 			"log_permission_errors": {
 				Description: "Log permission/product issues rather than fail.",
 				Type:        schema.TypeBool,
@@ -147,6 +167,26 @@ func ResourceTfExport() *schema.Resource {
 				Default:     false,
 				ForceNew:    true,
 			},
+			"on_error": {
+				Description:  "Behavior when a resource can not be read. 'fail' stops the export on the first error. 'continue' exports every resource that could be read, records the failed resources in the export report and as commented placeholders in the exported config, and returns warnings instead of an error.",
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      onErrorFail,
+				ForceNew:     true,
+				ValidateFunc: validation.StringInSlice(onErrorOptions, false),
+			},
+			"resume": {
+				Description: fmt.Sprintf("Resume a previous export that did not complete. Every resource type read during an export is checkpointed in the '%s' folder of the export directory. When `true`, the resource types completed by the previous run with the same export configuration are restored from the checkpoint instead of being read again. The checkpoint is removed once an export completes.", exportCheckpointDirectory),
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				ForceNew:    true,
+			},
+			"export_report": {
+				Description: fmt.Sprintf("JSON encoded report of the export run, also written to '%s' in the export directory. Contains the duration and the number of discovered, exported and skipped instances per resource type, logged permission errors, unresolved references and the slowest resource reads.", defaultExportReportFile),
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
 			"export_computed": {
 				Description: "Export attributes that are marked as being Computed. Defaults to true to match existing functionality. This attribute's default value will likely switch to false in a future release.",
 				Default:     true,
@@ -162,37 +202,66 @@ func createTfExport(ctx context.Context, d *schema.ResourceData, meta interface{
 	tfexporter_state.ActivateExporterState()
 
 	if _, ok := d.GetOk("include_filter_resources"); ok {
-		gre, _ := NewGenesysCloudResourceExporter(ctx, d, meta, IncludeResources)
-		diagErr := gre.Export()
+		gre, diagErr := NewGenesysCloudResourceExporter(ctx, d, meta, IncludeResources)
 		if diagErr != nil {
 			return diagErr
 		}
+		diagErr = gre.Export()
+		if diagErr.HasError() {
+			return diagErr
+		}
 
+		if reportErr := setExportReport(d, gre); reportErr != nil {
+			return reportErr
+		}
 		d.SetId(gre.exportDirPath)
-		return nil
+		return diagErr
 	}
 
 	if _, ok := d.GetOk("exclude_filter_resources"); ok {
-		gre, _ := NewGenesysCloudResourceExporter(ctx, d, meta, ExcludeResources)
-		diagErr := gre.Export()
+		gre, diagErr := NewGenesysCloudResourceExporter(ctx, d, meta, ExcludeResources)
 		if diagErr != nil {
 			return diagErr
 		}
+		diagErr = gre.Export()
+		if diagErr.HasError() {
+			return diagErr
+		}
 
+		if reportErr := setExportReport(d, gre); reportErr != nil {
+			return reportErr
+		}
 		d.SetId(gre.exportDirPath)
-		return nil
+		return diagErr
 	}
 
 	//Dealing with the traditional resource
-	gre, _ := NewGenesysCloudResourceExporter(ctx, d, meta, LegacyInclude)
-	diagErr := gre.Export()
-
+	gre, diagErr := NewGenesysCloudResourceExporter(ctx, d, meta, LegacyInclude)
 	if diagErr != nil {
 		return diagErr
 	}
+	diagErr = gre.Export()
 
+	if diagErr.HasError() {
+		return diagErr
+	}
+
+	if reportErr := setExportReport(d, gre); reportErr != nil {
+		return reportErr
+	}
 	d.SetId(gre.exportDirPath)
 
+	return diagErr
+}
+
+func setExportReport(d *schema.ResourceData, gre *GenesysCloudResourceExporter) diag.Diagnostics {
+	report, err := gre.report.toJSON()
+	if err != nil {
+		return diag.Errorf("Failed to encode export report as JSON: %v", err)
+	}
+	if err := d.Set("export_report", string(report)); err != nil {
+		return diag.FromErr(err)
+	}
 	return nil
 }
 
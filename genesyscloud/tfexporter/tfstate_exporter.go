@@ -8,6 +8,7 @@ import (
 	"os"
 	"os/exec"
 	"path/filepath"
+	"reflect"
 	"strings"
 	resourceExporter "terraform-provider-genesyscloud/genesyscloud/resource_exporter"
 	"terraform-provider-genesyscloud/genesyscloud/util/files"
@@ -26,14 +27,17 @@ type TFStateFileWriter struct {
 	resources      []resourceExporter.ResourceInfo
 	d              *schema.ResourceData
 	providerSource string
+	// Resource address -> child module name. Empty when the export is written as a single root module.
+	moduleAssignments map[string]string
 }
 
-func NewTFStateWriter(ctx context.Context, resources []resourceExporter.ResourceInfo, d *schema.ResourceData, providerSource string) *TFStateFileWriter {
+func NewTFStateWriter(ctx context.Context, resources []resourceExporter.ResourceInfo, d *schema.ResourceData, providerSource string, moduleAssignments map[string]string) *TFStateFileWriter {
 	tfwriter := &TFStateFileWriter{
-		ctx:            ctx,
-		resources:      resources,
-		d:              d,
-		providerSource: providerSource,
+		ctx:               ctx,
+		resources:         resources,
+		d:                 d,
+		providerSource:    providerSource,
+		moduleAssignments: moduleAssignments,
 	}
 
 	return tfwriter
@@ -52,7 +56,12 @@ func (t *TFStateFileWriter) writeTfState() diag.Diagnostics {
 			Primary:  resource.State,
 			Provider: "provider.genesyscloud",
 		}
-		tfstate.RootModule().Resources[resource.ResourceType+resource.Type+"."+resource.BlockLabel] = resourceState
+		address := resource.ResourceType + resource.Type + "." + resource.BlockLabel
+		if moduleName, ok := t.moduleAssignments[address]; ok {
+			t.moduleState(tfstate, moduleName).Resources[address] = resourceState
+			continue
+		}
+		tfstate.RootModule().Resources[address] = resourceState
 	}
 
 	data, err := json.MarshalIndent(tfstate, "", "  ")
@@ -115,6 +124,22 @@ func (t *TFStateFileWriter) writeTfState() diag.Diagnostics {
 	return nil
 }
 
+// moduleState returns the state of the child module, adding it to the state if it does not exist yet
+func (t *TFStateFileWriter) moduleState(tfstate *terraform.State, moduleName string) *terraform.ModuleState {
+	path := []string{"root", moduleName}
+	for _, mod := range tfstate.Modules {
+		if reflect.DeepEqual(mod.Path, path) {
+			return mod
+		}
+	}
+	mod := &terraform.ModuleState{
+		Path:      path,
+		Resources: make(map[string]*terraform.ResourceState),
+	}
+	tfstate.AddModuleState(mod)
+	return mod
+}
+
 func generateTfVarsContent(vars map[string]interface{}) string {
 	tfVarsContent := ""
 	for k, v := range vars {
@@ -53,6 +53,21 @@ func SliceDifference(a, b []string) []string {
 	return diff
 }
 
+// Intersection returns the elements in a that are also in b
+func Intersection(a, b []string) []string {
+	var intersection []string
+	mb := make(map[string]struct{}, len(b))
+	for _, x := range b {
+		mb[x] = struct{}{}
+	}
+	for _, x := range a {
+		if _, found := mb[x]; found {
+			intersection = append(intersection, x)
+		}
+	}
+	return intersection
+}
+
 // AreEquivalent takes two string lists and returns true if they are equivalent, ignoring the ordering of the items.
 func AreEquivalent(a []string, b []string) bool {
 	if len(a) != len(b) {
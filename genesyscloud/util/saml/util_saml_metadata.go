@@ -0,0 +1,412 @@
+package saml
+
+import (
+	"context"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"log"
+	"strings"
+	"terraform-provider-genesyscloud/genesyscloud/util/files"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+/*
+util_saml_metadata.go contains the SAML metadata support shared by the genesyscloud_idp_* resources. The IdP metadata,
+given inline with metadata_xml or as a path or URL with metadata_file, is parsed at plan time to fill in the issuer URI,
+the SSO and SLO endpoints and the signing certificates that are not set in the configuration.
+*/
+
+const (
+	bindingHttpRedirect = "urn:oasis:names:tc:SAML:2.0:bindings:HTTP-Redirect"
+	bindingHttpPost     = "urn:oasis:names:tc:SAML:2.0:bindings:HTTP-POST"
+
+	sloBindingRedirect = "HTTP Redirect"
+	sloBindingPost     = "HTTP Post"
+
+	// CertificateExpiryWarningDays is how long before their expiry certificates are reported
+	CertificateExpiryWarningDays = 30
+)
+
+// DerivedAttributes are the attributes of the IdP resources filled in from the metadata when not set in the configuration
+var DerivedAttributes = []string{"issuer_uri", "target_uri", "slo_uri", "slo_binding", "certificates"}
+
+// Metadata holds the values of a SAML EntityDescriptor used to configure an identity provider
+type Metadata struct {
+	EntityId     string
+	SsoUri       string
+	SloUri       string
+	SloBinding   string
+	Certificates []string
+	Hash         string
+}
+
+type entitiesDescriptor struct {
+	EntityDescriptors []entityDescriptor `xml:"EntityDescriptor"`
+}
+
+type entityDescriptor struct {
+	EntityId          string             `xml:"entityID,attr"`
+	IdpSsoDescriptors []idpSsoDescriptor `xml:"IDPSSODescriptor"`
+}
+
+type idpSsoDescriptor struct {
+	KeyDescriptors       []keyDescriptor `xml:"KeyDescriptor"`
+	SingleLogoutServices []endpoint      `xml:"SingleLogoutService"`
+	SingleSignOnServices []endpoint      `xml:"SingleSignOnService"`
+}
+
+type keyDescriptor struct {
+	Use          string   `xml:"use,attr"`
+	Certificates []string `xml:"KeyInfo>X509Data>X509Certificate"`
+}
+
+type endpoint struct {
+	Binding  string `xml:"Binding,attr"`
+	Location string `xml:"Location,attr"`
+}
+
+// MetadataXmlSchema returns the schema of the metadata_xml attribute
+func MetadataXmlSchema() *schema.Schema {
+	return &schema.Schema{
+		Description:   "SAML metadata XML of the identity provider. The issuer URI, target URI, SLO URI and binding, and certificates that are not set are read from its EntityDescriptor.",
+		Type:          schema.TypeString,
+		Optional:      true,
+		ConflictsWith: []string{"metadata_file"},
+	}
+}
+
+// MetadataFileSchema returns the schema of the metadata_file attribute
+func MetadataFileSchema() *schema.Schema {
+	return &schema.Schema{
+		Description:   "Path or URL of the SAML metadata XML of the identity provider. The file is read on every plan, so that a rotation of the metadata is applied.",
+		Type:          schema.TypeString,
+		Optional:      true,
+		ConflictsWith: []string{"metadata_xml"},
+	}
+}
+
+// MetadataHashSchema returns the schema of the metadata_hash attribute
+func MetadataHashSchema() *schema.Schema {
+	return &schema.Schema{
+		Description: "SHA-256 hash of the SAML metadata last applied. A change means that the metadata of the identity provider has rotated, which is reported as a warning on apply.",
+		Type:        schema.TypeString,
+		Computed:    true,
+	}
+}
+
+// MetadataAlternatives returns the attributes of which at least one must be set with the given derived attribute
+func MetadataAlternatives(key string) []string {
+	return []string{key, "metadata_xml", "metadata_file"}
+}
+
+// CustomizeMetadataDiff plans the derived attributes that are not set in the configuration from the metadata. Without
+// metadata, the derived attributes removed from the configuration are cleared rather than kept as computed; Terraform
+// shows them as known after apply, and they are sent empty. The metadata file is read on every plan to detect when it
+// has rotated.
+func CustomizeMetadataDiff(_ context.Context, diff *schema.ResourceDiff, _ interface{}) error {
+	if !diff.NewValueKnown("metadata_xml") || !diff.NewValueKnown("metadata_file") {
+		for _, key := range append([]string{"metadata_hash"}, unsetDerivedAttributes(diff)...) {
+			if err := diff.SetNewComputed(key); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	metadata, err := loadMetadata(diff.Get("metadata_xml").(string), diff.Get("metadata_file").(string))
+	if err != nil {
+		return err
+	}
+
+	planned := plannedValues(metadata)
+	for _, key := range unsetDerivedAttributes(diff) {
+		if equalDerivedValue(key, diff.Get(key), planned[key]) {
+			continue
+		}
+		if err := diff.SetNew(key, planned[key]); err != nil {
+			return err
+		}
+	}
+
+	if oldHash := diff.Get("metadata_hash").(string); oldHash != planned["metadata_hash"] {
+		if oldHash != "" && planned["metadata_hash"] != "" {
+			log.Printf("SAML metadata of %s has rotated", diff.Id())
+		}
+		return diff.SetNew("metadata_hash", planned["metadata_hash"])
+	}
+	return nil
+}
+
+// MetadataRotationWarnings reports that the metadata of the identity provider has rotated since it was last applied,
+// and which derived attributes changed with it. It is called on update, as warnings cannot be reported from a plan.
+func MetadataRotationWarnings(d *schema.ResourceData) diag.Diagnostics {
+	oldHash, newHash := d.GetChange("metadata_hash")
+	if oldHash.(string) == "" || newHash.(string) == "" || oldHash.(string) == newHash.(string) {
+		return nil
+	}
+
+	detail := "The SAML metadata of the identity provider has changed since it was last applied."
+	var changed []string
+	for _, key := range DerivedAttributes {
+		if d.HasChange(key) {
+			changed = append(changed, key)
+		}
+	}
+	if len(changed) > 0 {
+		detail += fmt.Sprintf(" Updated %s from the new metadata.", strings.Join(changed, ", "))
+	}
+	return diag.Diagnostics{{
+		Severity: diag.Warning,
+		Summary:  fmt.Sprintf("SAML metadata of %s has rotated", d.Id()),
+		Detail:   detail,
+	}}
+}
+
+// CertificateExpiryWarnings reports the certificates of the resource that expire within CertificateExpiryWarningDays.
+// Only resources configured from metadata are checked.
+func CertificateExpiryWarnings(d *schema.ResourceData) diag.Diagnostics {
+	if d.Get("metadata_xml").(string) == "" && d.Get("metadata_file").(string) == "" {
+		return nil
+	}
+
+	var certificates []string
+	for _, certificate := range d.Get("certificates").([]interface{}) {
+		if certificate, ok := certificate.(string); ok {
+			certificates = append(certificates, certificate)
+		}
+	}
+	return certificateExpiryWarnings(certificates, time.Now())
+}
+
+// ParseMetadata reads the issuer, endpoints and signing certificates of the first IdP of the metadata
+func ParseMetadata(data []byte) (*Metadata, error) {
+	var root struct {
+		XMLName xml.Name
+	}
+	if err := xml.Unmarshal(data, &root); err != nil {
+		return nil, fmt.Errorf("failed to parse SAML metadata: %v", err)
+	}
+
+	var entities []entityDescriptor
+	switch root.XMLName.Local {
+	case "EntityDescriptor":
+		var entity entityDescriptor
+		if err := xml.Unmarshal(data, &entity); err != nil {
+			return nil, fmt.Errorf("failed to parse SAML EntityDescriptor: %v", err)
+		}
+		entities = []entityDescriptor{entity}
+	case "EntitiesDescriptor":
+		var descriptor entitiesDescriptor
+		if err := xml.Unmarshal(data, &descriptor); err != nil {
+			return nil, fmt.Errorf("failed to parse SAML EntitiesDescriptor: %v", err)
+		}
+		entities = descriptor.EntityDescriptors
+	default:
+		return nil, fmt.Errorf("SAML metadata root element is %s, expected EntityDescriptor or EntitiesDescriptor", root.XMLName.Local)
+	}
+
+	for _, entity := range entities {
+		if len(entity.IdpSsoDescriptors) == 0 {
+			continue
+		}
+		idp := entity.IdpSsoDescriptors[0]
+
+		metadata := &Metadata{
+			EntityId:     strings.TrimSpace(entity.EntityId),
+			Certificates: signingCertificates(idp.KeyDescriptors),
+			Hash:         hashMetadata(data),
+		}
+		if sso, ok := preferredEndpoint(idp.SingleSignOnServices); ok {
+			metadata.SsoUri = sso.Location
+		}
+		if slo, ok := preferredEndpoint(idp.SingleLogoutServices); ok {
+			metadata.SloUri = slo.Location
+			metadata.SloBinding = sloBinding(slo.Binding)
+		}
+
+		if metadata.EntityId == "" {
+			return nil, fmt.Errorf("SAML EntityDescriptor has no entityID")
+		}
+		if len(metadata.Certificates) == 0 {
+			return nil, fmt.Errorf("SAML IDPSSODescriptor of %s has no signing certificate", metadata.EntityId)
+		}
+		return metadata, nil
+	}
+	return nil, fmt.Errorf("SAML metadata has no IDPSSODescriptor")
+}
+
+// loadMetadata parses the inline metadata or the metadata file. It returns nil if neither is set.
+func loadMetadata(metadataXml string, metadataFile string) (*Metadata, error) {
+	if metadataXml != "" {
+		return ParseMetadata([]byte(metadataXml))
+	}
+	if metadataFile == "" {
+		return nil, nil
+	}
+
+	reader, file, err := files.DownloadOrOpenFile(metadataFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open SAML metadata file %s: %v", metadataFile, err)
+	}
+	if file != nil {
+		defer file.Close()
+	}
+	data, err := io.ReadAll(reader)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read SAML metadata file %s: %v", metadataFile, err)
+	}
+	return ParseMetadata(data)
+}
+
+// plannedValues returns the values of the derived attributes and metadata_hash for the metadata
+func plannedValues(metadata *Metadata) map[string]interface{} {
+	if metadata == nil {
+		return map[string]interface{}{
+			"issuer_uri":    "",
+			"target_uri":    "",
+			"slo_uri":       "",
+			"slo_binding":   "",
+			"certificates":  []interface{}{},
+			"metadata_hash": "",
+		}
+	}
+
+	certificates := make([]interface{}, 0, len(metadata.Certificates))
+	for _, certificate := range metadata.Certificates {
+		certificates = append(certificates, certificate)
+	}
+	return map[string]interface{}{
+		"issuer_uri":    metadata.EntityId,
+		"target_uri":    metadata.SsoUri,
+		"slo_uri":       metadata.SloUri,
+		"slo_binding":   metadata.SloBinding,
+		"certificates":  certificates,
+		"metadata_hash": metadata.Hash,
+	}
+}
+
+// unsetDerivedAttributes returns the derived attributes of the resource that are not set in the configuration
+func unsetDerivedAttributes(diff *schema.ResourceDiff) []string {
+	config := diff.GetRawConfig()
+	var keys []string
+	for _, key := range DerivedAttributes {
+		if config.IsNull() || !config.Type().HasAttribute(key) || config.GetAttr(key).IsNull() {
+			keys = append(keys, key)
+		}
+	}
+	return keys
+}
+
+// equalDerivedValue compares certificates regardless of their PEM armor and line breaks, as Genesys Cloud may not
+// return them as they were sent
+func equalDerivedValue(key string, current interface{}, planned interface{}) bool {
+	if key != "certificates" {
+		return current == planned
+	}
+
+	currentList, _ := current.([]interface{})
+	plannedList, _ := planned.([]interface{})
+	if len(currentList) != len(plannedList) {
+		return false
+	}
+	for i := range currentList {
+		currentCertificate, _ := currentList[i].(string)
+		plannedCertificate, _ := plannedList[i].(string)
+		if normalizeCertificate(currentCertificate) != normalizeCertificate(plannedCertificate) {
+			return false
+		}
+	}
+	return true
+}
+
+// signingCertificates returns the distinct certificates of the key descriptors used for signing
+func signingCertificates(keyDescriptors []keyDescriptor) []string {
+	var certificates []string
+	seen := make(map[string]bool)
+	for _, keyDescriptor := range keyDescriptors {
+		if keyDescriptor.Use != "" && keyDescriptor.Use != "signing" {
+			continue
+		}
+		for _, certificate := range keyDescriptor.Certificates {
+			certificate = normalizeCertificate(certificate)
+			if certificate == "" || seen[certificate] {
+				continue
+			}
+			seen[certificate] = true
+			certificates = append(certificates, certificate)
+		}
+	}
+	return certificates
+}
+
+// preferredEndpoint returns the HTTP-Redirect endpoint, then the HTTP-POST endpoint. Other bindings are not supported.
+func preferredEndpoint(endpoints []endpoint) (endpoint, bool) {
+	for _, binding := range []string{bindingHttpRedirect, bindingHttpPost} {
+		for _, e := range endpoints {
+			if e.Binding == binding && e.Location != "" {
+				return e, true
+			}
+		}
+	}
+	return endpoint{}, false
+}
+
+func sloBinding(binding string) string {
+	if binding == bindingHttpPost {
+		return sloBindingPost
+	}
+	return sloBindingRedirect
+}
+
+// normalizeCertificate returns the base64 DER of a PEM or base64 DER certificate
+func normalizeCertificate(certificate string) string {
+	certificate = strings.ReplaceAll(certificate, "-----BEGIN CERTIFICATE-----", "")
+	certificate = strings.ReplaceAll(certificate, "-----END CERTIFICATE-----", "")
+	return strings.Join(strings.Fields(certificate), "")
+}
+
+func hashMetadata(data []byte) string {
+	hash := sha256.Sum256(data)
+	return hex.EncodeToString(hash[:])
+}
+
+func certificateExpiryWarnings(certificates []string, at time.Time) diag.Diagnostics {
+	var diags diag.Diagnostics
+	warnAfter := at.AddDate(0, 0, CertificateExpiryWarningDays)
+	for _, certificate := range certificates {
+		der, err := base64.StdEncoding.DecodeString(normalizeCertificate(certificate))
+		if err != nil {
+			log.Printf("Skipping expiry check of a certificate that is not base64 encoded: %v", err)
+			continue
+		}
+		parsed, err := x509.ParseCertificate(der)
+		if err != nil {
+			log.Printf("Skipping expiry check of a certificate that cannot be parsed: %v", err)
+			continue
+		}
+
+		switch {
+		case at.After(parsed.NotAfter):
+			diags = append(diags, diag.Diagnostic{
+				Severity: diag.Warning,
+				Summary:  fmt.Sprintf("SAML certificate %s has expired", parsed.Subject.CommonName),
+				Detail:   fmt.Sprintf("The certificate expired on %s. Update the metadata of the identity provider.", parsed.NotAfter.UTC().Format(time.RFC3339)),
+			})
+		case warnAfter.After(parsed.NotAfter):
+			diags = append(diags, diag.Diagnostic{
+				Severity: diag.Warning,
+				Summary:  fmt.Sprintf("SAML certificate %s expires soon", parsed.Subject.CommonName),
+				Detail:   fmt.Sprintf("The certificate expires on %s. Add the next certificate of the identity provider before then.", parsed.NotAfter.UTC().Format(time.RFC3339)),
+			})
+		}
+	}
+	return diags
+}
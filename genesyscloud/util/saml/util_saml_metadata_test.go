@@ -0,0 +1,321 @@
+package saml
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/base64"
+	"encoding/pem"
+	"math/big"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+const testMetadataTemplate = `<?xml version="1.0" encoding="UTF-8"?>
+<md:EntityDescriptor xmlns:md="urn:oasis:names:tc:SAML:2.0:metadata" xmlns:ds="http://www.w3.org/2000/09/xmldsig#" entityID="https://idp.example.com/entity">
+  <md:IDPSSODescriptor protocolSupportEnumeration="urn:oasis:names:tc:SAML:2.0:protocol">
+    <md:KeyDescriptor use="signing">
+      <ds:KeyInfo><ds:X509Data><ds:X509Certificate>
+        SIGNING_CERTIFICATE
+      </ds:X509Certificate></ds:X509Data></ds:KeyInfo>
+    </md:KeyDescriptor>
+    <md:KeyDescriptor use="encryption">
+      <ds:KeyInfo><ds:X509Data><ds:X509Certificate>ENCRYPTION_CERTIFICATE</ds:X509Certificate></ds:X509Data></ds:KeyInfo>
+    </md:KeyDescriptor>
+    <md:SingleLogoutService Binding="urn:oasis:names:tc:SAML:2.0:bindings:HTTP-POST" Location="https://idp.example.com/slo/post"/>
+    <md:SingleSignOnService Binding="urn:oasis:names:tc:SAML:2.0:bindings:HTTP-POST" Location="https://idp.example.com/sso/post"/>
+    <md:SingleSignOnService Binding="urn:oasis:names:tc:SAML:2.0:bindings:HTTP-Redirect" Location="https://idp.example.com/sso/redirect"/>
+  </md:IDPSSODescriptor>
+</md:EntityDescriptor>`
+
+func testMetadata(signingCertificate string) string {
+	metadata := strings.ReplaceAll(testMetadataTemplate, "SIGNING_CERTIFICATE", signingCertificate)
+	return strings.ReplaceAll(metadata, "ENCRYPTION_CERTIFICATE", "ZW5jcnlwdGlvbg==")
+}
+
+func TestUnitParseSamlMetadata(t *testing.T) {
+	metadata, err := ParseMetadata([]byte(testMetadata("c2lnbmluZw==")))
+	if err != nil {
+		t.Fatalf("Failed to parse metadata: %v", err)
+	}
+
+	if metadata.EntityId != "https://idp.example.com/entity" {
+		t.Errorf("Expected entity ID https://idp.example.com/entity, got %s", metadata.EntityId)
+	}
+	if metadata.SsoUri != "https://idp.example.com/sso/redirect" {
+		t.Errorf("Expected the HTTP-Redirect SSO endpoint, got %s", metadata.SsoUri)
+	}
+	if metadata.SloUri != "https://idp.example.com/slo/post" || metadata.SloBinding != sloBindingPost {
+		t.Errorf("Expected the HTTP-POST SLO endpoint, got %s with binding %s", metadata.SloUri, metadata.SloBinding)
+	}
+	if len(metadata.Certificates) != 1 || metadata.Certificates[0] != "c2lnbmluZw==" {
+		t.Errorf("Expected only the signing certificate, got %v", metadata.Certificates)
+	}
+	if metadata.Hash == "" {
+		t.Error("Expected the hash of the metadata to be set")
+	}
+
+	// An aggregate with an SP before the IdP
+	aggregate := `<EntitiesDescriptor xmlns="urn:oasis:names:tc:SAML:2.0:metadata">
+  <EntityDescriptor entityID="https://sp.example.com"><SPSSODescriptor/></EntityDescriptor>
+  ` + strings.TrimPrefix(testMetadata("c2lnbmluZw=="), `<?xml version="1.0" encoding="UTF-8"?>`) + `
+</EntitiesDescriptor>`
+	metadata, err = ParseMetadata([]byte(aggregate))
+	if err != nil {
+		t.Fatalf("Failed to parse aggregate metadata: %v", err)
+	}
+	if metadata.EntityId != "https://idp.example.com/entity" {
+		t.Errorf("Expected the entity ID of the IdP, got %s", metadata.EntityId)
+	}
+
+	for name, invalid := range map[string]string{
+		"not xml":         "not xml",
+		"wrong root":      `<SPSSODescriptor/>`,
+		"no idp":          `<EntityDescriptor entityID="https://sp.example.com"><SPSSODescriptor/></EntityDescriptor>`,
+		"no certificates": `<EntityDescriptor entityID="https://idp.example.com"><IDPSSODescriptor/></EntityDescriptor>`,
+	} {
+		if _, err := ParseMetadata([]byte(invalid)); err == nil {
+			t.Errorf("Expected an error parsing metadata with %s", name)
+		}
+	}
+}
+
+func TestUnitCustomizeSamlMetadataDiff(t *testing.T) {
+	resourceSchema := map[string]*schema.Schema{
+		"issuer_uri":    {Type: schema.TypeString, Optional: true, Computed: true},
+		"target_uri":    {Type: schema.TypeString, Optional: true, Computed: true},
+		"slo_uri":       {Type: schema.TypeString, Optional: true, Computed: true},
+		"slo_binding":   {Type: schema.TypeString, Optional: true, Computed: true},
+		"certificates":  {Type: schema.TypeList, Optional: true, Computed: true, Elem: &schema.Schema{Type: schema.TypeString}},
+		"metadata_xml":  MetadataXmlSchema(),
+		"metadata_file": MetadataFileSchema(),
+		"metadata_hash": MetadataHashSchema(),
+	}
+
+	metadataFile := filepath.Join(t.TempDir(), "metadata.xml")
+	if err := os.WriteFile(metadataFile, []byte(testMetadata("c2lnbmluZw==")), 0644); err != nil {
+		t.Fatalf("Failed to write metadata file: %v", err)
+	}
+
+	diff, err := schema.InternalMap(resourceSchema).Diff(context.Background(), nil, terraform.NewResourceConfigRaw(map[string]interface{}{
+		"metadata_file": metadataFile,
+	}), CustomizeMetadataDiff, nil, true)
+	if err != nil {
+		t.Fatalf("Failed to diff: %v", err)
+	}
+	for key, expected := range map[string]string{
+		"issuer_uri":     "https://idp.example.com/entity",
+		"target_uri":     "https://idp.example.com/sso/redirect",
+		"slo_uri":        "https://idp.example.com/slo/post",
+		"slo_binding":    sloBindingPost,
+		"certificates.#": "1",
+		"certificates.0": "c2lnbmluZw==",
+	} {
+		if attr, ok := diff.Attributes[key]; !ok || attr.New != expected {
+			t.Errorf("Expected %s to be planned as %s, got %v", key, expected, diff.Attributes[key])
+		}
+	}
+	if attr, ok := diff.Attributes["metadata_hash"]; !ok || attr.New == "" {
+		t.Error("Expected metadata_hash to be planned")
+	}
+
+	// Rotating the metadata file plans the new certificate, and the certificate stored with PEM armor is not a change
+	state := &terraform.InstanceState{
+		ID: "generic",
+		Attributes: map[string]string{
+			"id":             "generic",
+			"issuer_uri":     "https://idp.example.com/entity",
+			"target_uri":     "https://idp.example.com/sso/redirect",
+			"slo_uri":        "https://idp.example.com/slo/post",
+			"slo_binding":    sloBindingPost,
+			"certificates.#": "1",
+			"certificates.0": "-----BEGIN CERTIFICATE-----\nc2lnbmluZw==\n-----END CERTIFICATE-----",
+			"metadata_file":  metadataFile,
+			"metadata_hash":  diff.Attributes["metadata_hash"].New,
+		},
+	}
+	diff, err = schema.InternalMap(resourceSchema).Diff(context.Background(), state, terraform.NewResourceConfigRaw(map[string]interface{}{
+		"metadata_file": metadataFile,
+	}), CustomizeMetadataDiff, nil, true)
+	if err != nil {
+		t.Fatalf("Failed to diff: %v", err)
+	}
+	if diff != nil && len(diff.Attributes) > 0 {
+		t.Errorf("Expected no changes for unchanged metadata, got %v", diff.Attributes)
+	}
+
+	if err := os.WriteFile(metadataFile, []byte(testMetadata("cm90YXRlZA==")), 0644); err != nil {
+		t.Fatalf("Failed to write metadata file: %v", err)
+	}
+	diff, err = schema.InternalMap(resourceSchema).Diff(context.Background(), state, terraform.NewResourceConfigRaw(map[string]interface{}{
+		"metadata_file": metadataFile,
+	}), CustomizeMetadataDiff, nil, true)
+	if err != nil {
+		t.Fatalf("Failed to diff: %v", err)
+	}
+	if attr, ok := diff.Attributes["certificates.0"]; !ok || attr.New != "cm90YXRlZA==" {
+		t.Errorf("Expected the rotated certificate to be planned, got %v", diff.Attributes["certificates.0"])
+	}
+	if attr, ok := diff.Attributes["metadata_hash"]; !ok || attr.New == state.Attributes["metadata_hash"] {
+		t.Error("Expected metadata_hash to change after the rotation")
+	}
+	if _, ok := diff.Attributes["issuer_uri"]; ok {
+		t.Error("Expected issuer_uri to be unchanged after the rotation")
+	}
+
+	// The rotation is reported on apply with the attributes it changed
+	d, err := schema.InternalMap(resourceSchema).Data(state, diff)
+	if err != nil {
+		t.Fatalf("Failed to apply the diff: %v", err)
+	}
+	warnings := MetadataRotationWarnings(d)
+	if len(warnings) != 1 || warnings[0].Severity != diag.Warning {
+		t.Fatalf("Expected a rotation warning, got %v", warnings)
+	}
+	if warnings[0].Summary != "SAML metadata of generic has rotated" || !strings.Contains(warnings[0].Detail, "Updated certificates from the new metadata") {
+		t.Errorf("Expected the rotation warning to list the certificates, got %s: %s", warnings[0].Summary, warnings[0].Detail)
+	}
+	if warnings := MetadataRotationWarnings(schema.TestResourceDataRaw(t, resourceSchema, map[string]interface{}{"metadata_file": metadataFile})); len(warnings) > 0 {
+		t.Errorf("Expected no rotation warning without a previous metadata hash, got %v", warnings)
+	}
+}
+
+func TestUnitCustomizeSamlMetadataDiffWithoutMetadata(t *testing.T) {
+	resourceSchema := map[string]*schema.Schema{
+		"issuer_uri":    {Type: schema.TypeString, Optional: true, Computed: true},
+		"target_uri":    {Type: schema.TypeString, Optional: true, Computed: true},
+		"slo_uri":       {Type: schema.TypeString, Optional: true, Computed: true},
+		"slo_binding":   {Type: schema.TypeString, Optional: true, Computed: true},
+		"certificates":  {Type: schema.TypeList, Optional: true, Computed: true, Elem: &schema.Schema{Type: schema.TypeString}},
+		"metadata_xml":  MetadataXmlSchema(),
+		"metadata_file": MetadataFileSchema(),
+		"metadata_hash": MetadataHashSchema(),
+	}
+	config := map[string]interface{}{
+		"issuer_uri":   "https://idp.example.com/entity",
+		"target_uri":   "https://idp.example.com/sso/redirect",
+		"certificates": []interface{}{"c2lnbmluZw=="},
+	}
+
+	// The raw configuration tells the attributes removed from the configuration from the ones set in it
+	rawConfig := make(map[string]cty.Value)
+	for key, attributeType := range schema.InternalMap(resourceSchema).CoreConfigSchema().ImpliedType().AttributeTypes() {
+		rawConfig[key] = cty.NullVal(attributeType)
+	}
+	rawConfig["issuer_uri"] = cty.StringVal("https://idp.example.com/entity")
+	rawConfig["target_uri"] = cty.StringVal("https://idp.example.com/sso/redirect")
+	rawConfig["certificates"] = cty.ListVal([]cty.Value{cty.StringVal("c2lnbmluZw==")})
+
+	state := &terraform.InstanceState{
+		ID: "generic",
+		Attributes: map[string]string{
+			"id":             "generic",
+			"issuer_uri":     "https://idp.example.com/entity",
+			"target_uri":     "https://idp.example.com/sso/redirect",
+			"slo_uri":        "https://idp.example.com/slo/post",
+			"slo_binding":    sloBindingPost,
+			"certificates.#": "1",
+			"certificates.0": "c2lnbmluZw==",
+		},
+		RawConfig: cty.ObjectVal(rawConfig),
+	}
+	diff, err := schema.InternalMap(resourceSchema).Diff(context.Background(), state, terraform.NewResourceConfigRaw(config), CustomizeMetadataDiff, nil, true)
+	if err != nil {
+		t.Fatalf("Failed to diff: %v", err)
+	}
+	for _, key := range []string{"slo_uri", "slo_binding"} {
+		if attr, ok := diff.Attributes[key]; !ok || attr.Old == "" || !attr.NewComputed {
+			t.Errorf("Expected %s removed from the configuration to be cleared, got %v", key, diff.Attributes[key])
+		}
+	}
+	for _, key := range []string{"issuer_uri", "target_uri", "certificates.#", "certificates.0", "metadata_hash"} {
+		if attr, ok := diff.Attributes[key]; ok {
+			t.Errorf("Expected %s to be unchanged, got %v", key, attr)
+		}
+	}
+
+	// Once cleared, the attributes are not planned again
+	d, err := schema.InternalMap(resourceSchema).Data(state, diff)
+	if err != nil {
+		t.Fatalf("Failed to apply the diff: %v", err)
+	}
+	for _, key := range []string{"slo_uri", "slo_binding"} {
+		if value := d.Get(key).(string); value != "" {
+			t.Errorf("Expected %s to be sent empty, got %s", key, value)
+		}
+	}
+	state.Attributes["slo_uri"] = ""
+	state.Attributes["slo_binding"] = ""
+	diff, err = schema.InternalMap(resourceSchema).Diff(context.Background(), state, terraform.NewResourceConfigRaw(config), CustomizeMetadataDiff, nil, true)
+	if err != nil {
+		t.Fatalf("Failed to diff: %v", err)
+	}
+	if diff != nil && len(diff.Attributes) > 0 {
+		t.Errorf("Expected no changes once cleared, got %v", diff.Attributes)
+	}
+}
+
+func TestUnitSamlCertificateExpiryWarnings(t *testing.T) {
+	at := time.Date(2026, 6, 1, 0, 0, 0, 0, time.UTC)
+	valid := generateTestCertificate(t, "valid", at.AddDate(1, 0, 0))
+	expiring := generateTestCertificate(t, "expiring", at.AddDate(0, 0, 10))
+	expired := generateTestCertificate(t, "expired", at.AddDate(0, 0, -1))
+
+	diags := certificateExpiryWarnings([]string{valid, expiring, expired, "not a certificate"}, at)
+	if len(diags) != 2 {
+		t.Fatalf("Expected 2 warnings, got %d: %v", len(diags), diags)
+	}
+	for _, d := range diags {
+		if d.Severity != diag.Warning {
+			t.Errorf("Expected a warning, got %v", d.Severity)
+		}
+	}
+	if !strings.Contains(diags[0].Summary, "expiring expires soon") {
+		t.Errorf("Expected a warning for the expiring certificate, got %s", diags[0].Summary)
+	}
+	if !strings.Contains(diags[1].Summary, "expired has expired") {
+		t.Errorf("Expected a warning for the expired certificate, got %s", diags[1].Summary)
+	}
+
+	// PEM certificates are checked too
+	pemCertificate := string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: mustDecode(t, expiring)}))
+	if diags := certificateExpiryWarnings([]string{pemCertificate}, at); len(diags) != 1 {
+		t.Errorf("Expected a warning for the expiring PEM certificate, got %v", diags)
+	}
+}
+
+func generateTestCertificate(t *testing.T, commonName string, notAfter time.Time) string {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("Failed to generate key: %v", err)
+	}
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: commonName},
+		NotBefore:    notAfter.AddDate(-2, 0, 0),
+		NotAfter:     notAfter,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatalf("Failed to create certificate: %v", err)
+	}
+	return base64.StdEncoding.EncodeToString(der)
+}
+
+func mustDecode(t *testing.T, certificate string) []byte {
+	der, err := base64.StdEncoding.DecodeString(certificate)
+	if err != nil {
+		t.Fatalf("Failed to decode certificate: %v", err)
+	}
+	return der
+}
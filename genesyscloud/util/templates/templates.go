@@ -0,0 +1,368 @@
+package templates
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net/url"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"terraform-provider-genesyscloud/genesyscloud/util/files"
+	"text/template"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/zclconf/go-cty/cty"
+	"github.com/zclconf/go-cty/cty/convert"
+	"github.com/zclconf/go-cty/cty/function"
+	"github.com/zclconf/go-cty/cty/function/stdlib"
+	ctyjson "github.com/zclconf/go-cty/cty/json"
+	"gopkg.in/yaml.v3"
+)
+
+/*
+The templates package renders the files of the genesyscloud_flow and genesyscloud_script resources with the Go or HCL template
+engine before they are uploaded. Both engines support includes of shared fragments: the path of an include is relative to the
+file including it, and the fragment is rendered with the same engine. Template variables are passed as a JSON object so they
+keep their types (numbers, booleans, lists and objects).
+*/
+
+const (
+	EngineGo  = "go"
+	EngineHcl = "hcl"
+
+	// maxIncludeDepth limits the nesting of includes
+	maxIncludeDepth = 10
+)
+
+// Engines lists the supported template engines
+var Engines = []string{EngineGo, EngineHcl}
+
+var (
+	// goActionStart matches the start of a Go template action beginning with a name, like {{include or {{key}}
+	goActionStart = regexp.MustCompile(`\{\{([A-Za-z_][A-Za-z0-9_]*)`)
+
+	// goTemplateNames lists the keywords and built-in functions of Go templates
+	goTemplateNames = []string{
+		"if", "else", "end", "range", "with", "define", "template", "block", "break", "continue", "nil", "true", "false",
+		"and", "or", "not", "call", "html", "index", "slice", "js", "len", "print", "printf", "println", "urlquery",
+		"eq", "ne", "lt", "le", "gt", "ge",
+	}
+)
+
+type renderer struct {
+	engine    string
+	variables map[string]interface{}
+	// stack holds the paths of the files being rendered to detect include cycles
+	stack []string
+}
+
+// Render renders the file at filePath with the template engine and the JSON encoded variables, then applies the substitutions to
+// the rendered content. The {{key}} placeholders are kept as is by both engines, so they can be used in templates.
+func Render(filePath, engine, variablesJson string, substitutions map[string]interface{}) (string, error) {
+	variables, err := DecodeVariables(variablesJson)
+	if err != nil {
+		return "", err
+	}
+	content, err := RenderFile(filePath, engine, variables)
+	if err != nil {
+		return "", err
+	}
+	return ApplySubstitutions(content, substitutions), nil
+}
+
+// RenderFile renders the file at filePath with the template engine and variables
+func RenderFile(filePath, engine string, variables map[string]interface{}) (string, error) {
+	if engine != EngineGo && engine != EngineHcl {
+		return "", fmt.Errorf("unknown template engine '%s'. Valid engines: %s", engine, strings.Join(Engines, ", "))
+	}
+	if variables == nil {
+		variables = make(map[string]interface{})
+	}
+	r := &renderer{engine: engine, variables: variables}
+	return r.renderFile(filePath, variables)
+}
+
+// DecodeVariables decodes template variables encoded as a JSON object. An empty string decodes to no variables.
+func DecodeVariables(variablesJson string) (map[string]interface{}, error) {
+	variables := make(map[string]interface{})
+	if strings.TrimSpace(variablesJson) == "" {
+		return variables, nil
+	}
+	if err := json.Unmarshal([]byte(variablesJson), &variables); err != nil {
+		return nil, fmt.Errorf("template variables must be a JSON object: %w", err)
+	}
+	return variables, nil
+}
+
+// ApplySubstitutions replaces the {{key}} placeholders of the content with the values of the substitutions
+func ApplySubstitutions(content string, substitutions map[string]interface{}) string {
+	for k, v := range substitutions {
+		content = strings.Replace(content, fmt.Sprintf("{{%s}}", k), v.(string), -1)
+	}
+	return content
+}
+
+// HashContent returns the hex encoded SHA-256 hash of the rendered content
+func HashContent(content string) string {
+	hash := sha256.Sum256([]byte(content))
+	return hex.EncodeToString(hash[:])
+}
+
+// CustomizeRenderedContentHashDiff plans the rendered_content_hash attribute of resources supporting templates. The file is rendered
+// during the plan so that changes of the template variables or of the included fragments update the resource even when the
+// file_content_hash of the template does not change. The attribute is empty when no template engine is set.
+func CustomizeRenderedContentHashDiff(_ context.Context, diff *schema.ResourceDiff, _ interface{}) error {
+	engine := diff.Get("template_engine").(string)
+	if engine == "" {
+		if diff.Get("rendered_content_hash").(string) != "" {
+			return diff.SetNew("rendered_content_hash", "")
+		}
+		return nil
+	}
+
+	if !diff.NewValueKnown("filepath") || !diff.NewValueKnown("template_variables") || !diff.NewValueKnown("substitutions") {
+		return diff.SetNewComputed("rendered_content_hash")
+	}
+
+	filePath := diff.Get("filepath").(string)
+	content, err := Render(filePath, engine, diff.Get("template_variables").(string), diff.Get("substitutions").(map[string]interface{}))
+	if err != nil {
+		// The file may not exist until other resources are applied. Rendering errors are reported when the resource is applied.
+		log.Printf("Failed to render template %s during plan: %v", filePath, err)
+		return diff.SetNewComputed("rendered_content_hash")
+	}
+
+	if hash := HashContent(content); hash != diff.Get("rendered_content_hash").(string) {
+		return diff.SetNew("rendered_content_hash", hash)
+	}
+	return nil
+}
+
+func (r *renderer) renderFile(filePath string, data interface{}) (string, error) {
+	for _, path := range r.stack {
+		if path == filePath {
+			return "", fmt.Errorf("include cycle detected: %s -> %s", strings.Join(r.stack, " -> "), filePath)
+		}
+	}
+	if len(r.stack) >= maxIncludeDepth {
+		return "", fmt.Errorf("includes are nested more than %d levels deep in %s", maxIncludeDepth, r.stack[0])
+	}
+
+	content, err := readFile(filePath)
+	if err != nil {
+		return "", err
+	}
+
+	r.stack = append(r.stack, filePath)
+	defer func() { r.stack = r.stack[:len(r.stack)-1] }()
+
+	if r.engine == EngineHcl {
+		return r.renderHcl(filePath, content)
+	}
+	return r.renderGo(filePath, content, data)
+}
+
+func (r *renderer) renderGo(filePath, content string, data interface{}) (string, error) {
+	funcs := r.goFuncs(filePath)
+	content = escapePlaceholders(content, funcs)
+	tmpl, err := template.New(filepath.Base(filePath)).Option("missingkey=error").Funcs(funcs).Parse(content)
+	if err != nil {
+		return "", fmt.Errorf("failed to parse template %s: %w", filePath, err)
+	}
+
+	var rendered strings.Builder
+	if err := tmpl.Execute(&rendered, data); err != nil {
+		return "", fmt.Errorf("failed to render template %s: %w", filePath, err)
+	}
+	return rendered.String(), nil
+}
+
+// escapePlaceholders escapes the {{key}} substitution placeholders and the {{Scripter.Name}} tokens of the content so that the Go
+// template engine outputs them as is. They are told apart from the actions of the template by their first name, which is neither a
+// keyword nor a function of Go templates.
+func escapePlaceholders(content string, funcs template.FuncMap) string {
+	return goActionStart.ReplaceAllStringFunc(content, func(action string) string {
+		name := action[2:]
+		if _, ok := funcs[name]; ok {
+			return action
+		}
+		for _, templateName := range goTemplateNames {
+			if name == templateName {
+				return action
+			}
+		}
+		return `{{"{{"}}` + name
+	})
+}
+
+// goFuncs returns the functions available to Go templates in addition to the built-in functions
+func (r *renderer) goFuncs(filePath string) template.FuncMap {
+	return template.FuncMap{
+		// include renders a fragment with the variables of the template, or with the data passed as second argument
+		"include": func(include string, data ...interface{}) (string, error) {
+			var fragmentData interface{} = r.variables
+			if len(data) > 0 {
+				fragmentData = data[0]
+			}
+			return r.renderFile(resolveIncludePath(filePath, include), fragmentData)
+		},
+		"indent": indent,
+		"nindent": func(spaces int, content string) string {
+			return "\n" + indent(spaces, content)
+		},
+		"toYaml": func(value interface{}) (string, error) {
+			out, err := yaml.Marshal(value)
+			return strings.TrimSuffix(string(out), "\n"), err
+		},
+		"toJson": func(value interface{}) (string, error) {
+			out, err := json.Marshal(value)
+			return string(out), err
+		},
+		"default": func(defaultValue, value interface{}) interface{} {
+			if value == nil || value == "" {
+				return defaultValue
+			}
+			return value
+		},
+		"join": func(separator string, values []interface{}) string {
+			items := make([]string, len(values))
+			for i, value := range values {
+				items[i] = fmt.Sprint(value)
+			}
+			return strings.Join(items, separator)
+		},
+		"lower": strings.ToLower,
+		"upper": strings.ToUpper,
+		"trim":  strings.TrimSpace,
+	}
+}
+
+func (r *renderer) renderHcl(filePath, content string) (string, error) {
+	expr, diags := hclsyntax.ParseTemplate([]byte(content), filePath, hcl.Pos{Line: 1, Column: 1})
+	if diags.HasErrors() {
+		return "", fmt.Errorf("failed to parse template %s: %s", filePath, diags.Error())
+	}
+
+	variables, err := toCtyVariables(r.variables)
+	if err != nil {
+		return "", err
+	}
+	value, diags := expr.Value(&hcl.EvalContext{
+		Variables: variables,
+		Functions: r.hclFuncs(filePath),
+	})
+	if diags.HasErrors() {
+		return "", fmt.Errorf("failed to render template %s: %s", filePath, diags.Error())
+	}
+
+	value, err = convert.Convert(value, cty.String)
+	if err != nil || value.IsNull() || !value.IsKnown() {
+		return "", fmt.Errorf("template %s did not render to a string", filePath)
+	}
+	return value.AsString(), nil
+}
+
+// hclFuncs returns the functions available to HCL templates
+func (r *renderer) hclFuncs(filePath string) map[string]function.Function {
+	return map[string]function.Function{
+		// include renders a fragment with the variables of the template
+		"include": function.New(&function.Spec{
+			Params: []function.Parameter{{Name: "path", Type: cty.String}},
+			Type:   function.StaticReturnType(cty.String),
+			Impl: func(args []cty.Value, _ cty.Type) (cty.Value, error) {
+				rendered, err := r.renderFile(resolveIncludePath(filePath, args[0].AsString()), r.variables)
+				if err != nil {
+					return cty.NilVal, err
+				}
+				return cty.StringVal(rendered), nil
+			},
+		}),
+		"coalesce":   stdlib.CoalesceFunc,
+		"concat":     stdlib.ConcatFunc,
+		"contains":   stdlib.ContainsFunc,
+		"format":     stdlib.FormatFunc,
+		"indent":     stdlib.IndentFunc,
+		"join":       stdlib.JoinFunc,
+		"jsonencode": stdlib.JSONEncodeFunc,
+		"keys":       stdlib.KeysFunc,
+		"length":     stdlib.LengthFunc,
+		"lookup":     stdlib.LookupFunc,
+		"lower":      stdlib.LowerFunc,
+		"replace":    stdlib.ReplaceFunc,
+		"split":      stdlib.SplitFunc,
+		"trimspace":  stdlib.TrimSpaceFunc,
+		"upper":      stdlib.UpperFunc,
+	}
+}
+
+// toCtyVariables converts the JSON decoded variables to cty values for the HCL evaluation context
+func toCtyVariables(variables map[string]interface{}) (map[string]cty.Value, error) {
+	result := make(map[string]cty.Value, len(variables))
+	for name, variable := range variables {
+		encoded, err := json.Marshal(variable)
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode template variable '%s': %w", name, err)
+		}
+		impliedType, err := ctyjson.ImpliedType(encoded)
+		if err != nil {
+			return nil, fmt.Errorf("failed to convert template variable '%s': %w", name, err)
+		}
+		value, err := ctyjson.Unmarshal(encoded, impliedType)
+		if err != nil {
+			return nil, fmt.Errorf("failed to convert template variable '%s': %w", name, err)
+		}
+		result[name] = value
+	}
+	return result, nil
+}
+
+// resolveIncludePath resolves the path of an include relative to the file including it. Absolute paths and URLs are used as is.
+func resolveIncludePath(filePath, include string) string {
+	if filepath.IsAbs(include) || isUrl(include) {
+		return include
+	}
+	if isUrl(filePath) {
+		base, err := url.Parse(filePath)
+		if err != nil {
+			return include
+		}
+		ref, err := url.Parse(include)
+		if err != nil {
+			return include
+		}
+		return base.ResolveReference(ref).String()
+	}
+	return filepath.Join(filepath.Dir(filePath), include)
+}
+
+func isUrl(path string) bool {
+	return strings.HasPrefix(path, "http://") || strings.HasPrefix(path, "https://")
+}
+
+func readFile(filePath string) (string, error) {
+	reader, file, err := files.DownloadOrOpenFile(filePath)
+	if err != nil {
+		return "", err
+	}
+	if file != nil {
+		defer file.Close()
+	}
+	content, err := io.ReadAll(reader)
+	if err != nil {
+		return "", fmt.Errorf("failed to read file %s: %w", filePath, err)
+	}
+	return string(content), nil
+}
+
+// indent prefixes every line of the content with the number of spaces
+func indent(spaces int, content string) string {
+	padding := strings.Repeat(" ", spaces)
+	return padding + strings.ReplaceAll(content, "\n", "\n"+padding)
+}
@@ -0,0 +1,155 @@
+package templates
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/stretchr/testify/assert"
+)
+
+func writeTestFiles(t *testing.T, testFiles map[string]string) string {
+	dir := t.TempDir()
+	for name, content := range testFiles {
+		path := filepath.Join(dir, name)
+		assert.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+		assert.NoError(t, os.WriteFile(path, []byte(content), 0644))
+	}
+	return dir
+}
+
+func TestUnitRenderGoTemplate(t *testing.T) {
+	dir := writeTestFiles(t, map[string]string{
+		"flow.yaml": `inboundCall:
+  name: {{ .flow_name }}
+  menus:{{ include "fragments/menu.yaml" | nindent 4 }}
+{{- range .queues }}
+  # {{ . | upper }}
+{{- end }}
+  debug: {{ .debug | default false }}
+`,
+		"fragments/menu.yaml": "- menu:\n    name: {{ .menu_name }}",
+	})
+
+	rendered, err := Render(filepath.Join(dir, "flow.yaml"), EngineGo, `{"flow_name": "Flow", "menu_name": "Main Menu", "queues": ["sales", "support"], "debug": null}`, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, `inboundCall:
+  name: Flow
+  menus:
+    - menu:
+        name: Main Menu
+  # SALES
+  # SUPPORT
+  debug: false
+`, rendered)
+}
+
+func TestUnitRenderGoTemplateWithPlaceholders(t *testing.T) {
+	dir := writeTestFiles(t, map[string]string{
+		"script.json": `{"name": "{{ .script_name | upper }}", "queue": "{{queue_id}}", "greeting": "Hello {{Scripter.Customer Name}}",
+"items": [{{ range $i, $item := .items }}{{ if $i }}, {{ end }}"{{ $item }}"{{ end }}], "fragment": {{ include "fragment.json" }}}`,
+		"fragment.json": `{"agent": "{{Scripter.Agent Name}}", "division": "{{division_id}}"}`,
+	})
+
+	rendered, err := Render(filepath.Join(dir, "script.json"), EngineGo, `{"script_name": "Script", "items": ["a", "b"]}`, map[string]interface{}{
+		"queue_id":    "queue-id",
+		"division_id": "division-id",
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, `{"name": "SCRIPT", "queue": "queue-id", "greeting": "Hello {{Scripter.Customer Name}}",
+"items": ["a", "b"], "fragment": {"agent": "{{Scripter.Agent Name}}", "division": "division-id"}}`, rendered)
+}
+
+func TestUnitRenderHclTemplate(t *testing.T) {
+	dir := writeTestFiles(t, map[string]string{
+		"flows/flow.yaml": `inboundCall:
+  name: ${flow_name}
+  menus:
+    ${indent(4, include("../shared/menu.yaml"))}
+%{ for queue in queues ~}
+  # ${upper(queue)}
+%{ endfor ~}
+  retries: ${retries + 1}
+  description: "{{description}}"
+`,
+		"shared/menu.yaml": "- menu:\n    name: ${menu_name}",
+	})
+
+	rendered, err := Render(filepath.Join(dir, "flows", "flow.yaml"), EngineHcl, `{"flow_name": "Flow", "menu_name": "Main Menu", "queues": ["sales", "support"], "retries": 2}`, map[string]interface{}{"description": "A flow"})
+	assert.NoError(t, err)
+	assert.Equal(t, `inboundCall:
+  name: Flow
+  menus:
+    - menu:
+        name: Main Menu
+  # SALES
+  # SUPPORT
+  retries: 3
+  description: "A flow"
+`, rendered)
+}
+
+func TestUnitRenderTemplateErrors(t *testing.T) {
+	dir := writeTestFiles(t, map[string]string{
+		"missing_variable.yaml": "name: {{ .flow_name }}",
+		"cycle_a.yaml":          `{{ include "cycle_b.yaml" }}`,
+		"cycle_b.yaml":          `{{ include "cycle_a.yaml" }}`,
+		"invalid.yaml":          "name: ${flow_name",
+	})
+
+	_, err := Render(filepath.Join(dir, "missing_variable.yaml"), EngineGo, "", nil)
+	assert.ErrorContains(t, err, `map has no entry for key "flow_name"`)
+
+	_, err = Render(filepath.Join(dir, "cycle_a.yaml"), EngineGo, "", nil)
+	assert.ErrorContains(t, err, "include cycle detected")
+
+	_, err = Render(filepath.Join(dir, "invalid.yaml"), EngineHcl, `{"flow_name": "Flow"}`, nil)
+	assert.ErrorContains(t, err, "failed to parse template")
+
+	_, err = Render(filepath.Join(dir, "invalid.yaml"), "jinja", "", nil)
+	assert.ErrorContains(t, err, "unknown template engine 'jinja'")
+
+	_, err = Render(filepath.Join(dir, "invalid.yaml"), EngineGo, `["not", "an", "object"]`, nil)
+	assert.ErrorContains(t, err, "template variables must be a JSON object")
+}
+
+func TestUnitResolveIncludePath(t *testing.T) {
+	assert.Equal(t, filepath.Join("flows", "fragments", "menu.yaml"), resolveIncludePath(filepath.Join("flows", "flow.yaml"), "fragments/menu.yaml"))
+	assert.Equal(t, "https://example.com/flows/fragments/menu.yaml", resolveIncludePath("https://example.com/flows/flow.yaml", "fragments/menu.yaml"))
+	assert.Equal(t, "https://example.com/shared/menu.yaml", resolveIncludePath("https://example.com/flows/flow.yaml", "../shared/menu.yaml"))
+	assert.Equal(t, "https://example.com/menu.yaml", resolveIncludePath("flow.yaml", "https://example.com/menu.yaml"))
+}
+
+func TestUnitCustomizeRenderedContentHashDiff(t *testing.T) {
+	dir := writeTestFiles(t, map[string]string{"flow.yaml": "name: {{ .flow_name }}"})
+	filePath := filepath.Join(dir, "flow.yaml")
+
+	resourceSchema := map[string]*schema.Schema{
+		"filepath":              {Type: schema.TypeString, Required: true},
+		"substitutions":         {Type: schema.TypeMap, Optional: true},
+		"template_engine":       {Type: schema.TypeString, Optional: true},
+		"template_variables":    {Type: schema.TypeString, Optional: true},
+		"rendered_content_hash": {Type: schema.TypeString, Computed: true},
+	}
+	testResource := &schema.Resource{Schema: resourceSchema, CustomizeDiff: CustomizeRenderedContentHashDiff}
+
+	config := map[string]interface{}{
+		"filepath":           filePath,
+		"template_engine":    EngineGo,
+		"template_variables": `{"flow_name": "Flow"}`,
+	}
+	diff, err := schema.InternalMap(resourceSchema).Diff(context.Background(), nil, terraform.NewResourceConfigRaw(config), testResource.CustomizeDiff, nil, true)
+	assert.NoError(t, err)
+	assert.Equal(t, HashContent("name: Flow"), diff.Attributes["rendered_content_hash"].New)
+
+	// Untemplated files do not have a rendered content hash
+	delete(config, "template_engine")
+	diff, err = schema.InternalMap(resourceSchema).Diff(context.Background(), nil, terraform.NewResourceConfigRaw(config), testResource.CustomizeDiff, nil, true)
+	assert.NoError(t, err)
+	if attribute, ok := diff.Attributes["rendered_content_hash"]; ok {
+		assert.Equal(t, "", attribute.New)
+	}
+}
@@ -10,6 +10,8 @@ import (
 
 type UtilE164Service struct {
 	GetDefaultCountryCodeFunc func() string
+	// Quiet disables the logging of the default country code, for callers formatting many numbers at once
+	Quiet bool
 }
 
 func NewUtilE164Service() *UtilE164Service {
@@ -24,7 +26,9 @@ func (m *UtilE164Service) FormatAsValidE164Number(number string) (string, diag.D
 	if defaultLang == "" {
 		defaultLang = "US"
 	}
-	log.Printf("Default language is %s", defaultLang)
+	if !m.Quiet {
+		log.Printf("Default language is %s", defaultLang)
+	}
 	phoneNumber, err := phonenumbers.Parse(number, defaultLang)
 	if err != nil {
 		return "", diag.Errorf("Failed to format phone number %s: %s", number, err)
@@ -41,7 +45,9 @@ func (m *UtilE164Service) FormatAsCalculatedE164Number(number string) string {
 	if defaultLang == "" {
 		defaultLang = "US"
 	}
-	log.Printf("Default language is %s", defaultLang)
+	if !m.Quiet {
+		log.Printf("Default language is %s", defaultLang)
+	}
 	phoneNumber, _ := phonenumbers.Parse(number, defaultLang)
 	formattedNum := phonenumbers.Format(phoneNumber, phonenumbers.E164)
 	return formattedNum
@@ -53,3 +53,11 @@ func StringOrNil(s *string) string {
 	}
 	return *s
 }
+
+// StringOrEmpty returns the value of s, or an empty string if s is nil
+func StringOrEmpty(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
@@ -171,6 +171,21 @@ func ValidateTimeHHMM(time interface{}, _ cty.Path) diag.Diagnostics {
 	return diag.Errorf("Time %v is not a valid time, must use format HH:mm", time)
 }
 
+// ValidateDuration validates a string is a positive duration such as 30s or 5m
+func ValidateDuration(duration interface{}, _ cty.Path) diag.Diagnostics {
+	if durationStr, ok := duration.(string); ok {
+		parsed, err := time.ParseDuration(durationStr)
+		if err != nil {
+			return diag.Errorf("Failed to parse duration %s: %s", durationStr, err)
+		}
+		if parsed <= 0 {
+			return diag.Errorf("Duration %s must be greater than zero", durationStr)
+		}
+		return nil
+	}
+	return diag.Errorf("Duration %v is not a string", duration)
+}
+
 // ValidateLocalDateTimes validates a date string is in the format 2006-01-02T15:04:05.000000
 func ValidateLocalDateTimes(date interface{}, _ cty.Path) diag.Diagnostics {
 	if dateStr, ok := date.(string); ok {
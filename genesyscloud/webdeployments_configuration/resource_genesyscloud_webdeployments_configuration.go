@@ -22,6 +22,8 @@ import (
 	"github.com/mypurecloud/platform-client-sdk-go/v149/platformclientv2"
 )
 
+const draftVersion = "DRAFT"
+
 func getAllWebDeploymentConfigurations(ctx context.Context, clientConfig *platformclientv2.Configuration) (resourceExporter.ResourceIDMetaMap, diag.Diagnostics) {
 	resources := make(resourceExporter.ResourceIDMetaMap)
 	wp := getWebDeploymentConfigurationsProxy(clientConfig)
@@ -91,7 +93,22 @@ func createWebDeploymentConfiguration(ctx context.Context, d *schema.ResourceDat
 		return util.BuildDiagnosticError(ResourceType, fmt.Sprintf("Web deployment configuration %s did not become active and could not be published", name), fmt.Errorf("%v", activeError))
 	}
 
-	diagErr = util.WithRetries(ctx, 30*time.Second, func() *retry.RetryError {
+	if d.Get("draft_only").(bool) {
+		log.Printf("Created web deployment configuration %s %s as a draft", name, d.Id())
+		return readWebDeploymentConfiguration(ctx, d, meta)
+	}
+
+	if diagErr := publishWebDeploymentConfiguration(ctx, d, wp, name); diagErr != nil {
+		return diagErr
+	}
+	log.Printf("Created web deployment configuration %s %s", name, d.Id())
+
+	return readWebDeploymentConfiguration(ctx, d, meta)
+}
+
+// publishWebDeploymentConfiguration publishes the draft of the configuration as a new version
+func publishWebDeploymentConfiguration(ctx context.Context, d *schema.ResourceData, wp *webDeploymentsConfigurationProxy, name string) diag.Diagnostics {
+	return util.WithRetries(ctx, 30*time.Second, func() *retry.RetryError {
 		configuration, resp, err := wp.createWebdeploymentsConfigurationVersionsDraftPublish(ctx, d.Id())
 		if err != nil {
 			if util.IsStatus400(resp) {
@@ -101,15 +118,8 @@ func createWebDeploymentConfiguration(ctx context.Context, d *schema.ResourceDat
 		}
 		_ = d.Set("version", configuration.Version)
 		_ = d.Set("status", configuration.Status)
-		log.Printf("Created web deployment configuration %s %s", name, *configuration.Id)
-
 		return nil
 	})
-	if diagErr != nil {
-		return diagErr
-	}
-
-	return readWebDeploymentConfiguration(ctx, d, meta)
 }
 
 func readWebDeploymentConfiguration(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
@@ -118,12 +128,18 @@ func readWebDeploymentConfiguration(ctx context.Context, d *schema.ResourceData,
 	cc := consistency_checker.NewConsistencyCheck(ctx, d, meta, ResourceWebDeploymentConfiguration(), constants.ConsistencyChecks(), ResourceType)
 
 	version := d.Get("version").(string)
+	draftOnly := d.Get("draft_only").(bool)
 	log.Printf("Reading web deployment configuration %s", d.Id())
 	return util.WithRetriesForRead(ctx, d, func() *retry.RetryError {
-		if version == "" {
+		if version == "" || draftOnly {
 			version = wp.determineLatestVersion(ctx, d.Id())
 		}
-		configuration, resp, getErr := wp.getWebdeploymentsConfigurationVersion(ctx, d.Id(), version)
+		// The draft holds the changes that have not been published
+		readVersion := version
+		if draftOnly {
+			readVersion = draftVersion
+		}
+		configuration, resp, getErr := wp.getWebdeploymentsConfigurationVersion(ctx, d.Id(), readVersion)
 
 		if getErr != nil {
 			if util.IsStatus404(resp) {
@@ -138,7 +154,14 @@ func readWebDeploymentConfiguration(ctx context.Context, d *schema.ResourceData,
 		resourcedata.SetNillableValue(d, "languages", configuration.Languages)
 		resourcedata.SetNillableValue(d, "default_language", configuration.DefaultLanguage)
 		resourcedata.SetNillableValue(d, "status", configuration.Status)
-		resourcedata.SetNillableValue(d, "version", configuration.Version)
+		if draftOnly {
+			_ = d.Set("version", version)
+		} else {
+			resourcedata.SetNillableValue(d, "version", configuration.Version)
+		}
+		if resourcedata.GetNillableBool(d, "draft_only") == nil {
+			_ = d.Set("draft_only", false)
+		}
 		if configuration.HeadlessMode != nil {
 			resourcedata.SetNillableValue(d, "headless_mode_enabled", configuration.HeadlessMode.Enabled)
 		}
@@ -183,19 +206,12 @@ func updateWebDeploymentConfiguration(ctx context.Context, d *schema.ResourceDat
 		return util.BuildDiagnosticError(ResourceType, fmt.Sprintf("Web deployment configuration %s did not become active and could not be published", name), fmt.Errorf("%v", activeError))
 	}
 
-	diagErr = util.WithRetries(ctx, 30*time.Second, func() *retry.RetryError {
-		configuration, resp, err := wp.createWebdeploymentsConfigurationVersionsDraftPublish(ctx, d.Id())
-		if err != nil {
-			if util.IsStatus400(resp) {
-				return retry.RetryableError(util.BuildWithRetriesApiDiagnosticError(ResourceType, fmt.Sprintf("error publishing web deployment configuration %s | error: %s", name, err), resp))
-			}
-			return retry.NonRetryableError(util.BuildWithRetriesApiDiagnosticError(ResourceType, fmt.Sprintf("error publishing web deployment configuration %s | error: %s", name, err), resp))
-		}
-		_ = d.Set("version", configuration.Version)
-		_ = d.Set("status", configuration.Status)
-		return nil
-	})
-	if diagErr != nil {
+	if d.Get("draft_only").(bool) {
+		log.Printf("Saved the draft of web deployment configuration %s", name)
+		return readWebDeploymentConfiguration(ctx, d, meta)
+	}
+
+	if diagErr := publishWebDeploymentConfiguration(ctx, d, wp, name); diagErr != nil {
 		return diagErr
 	}
 
@@ -833,11 +833,17 @@ func ResourceWebDeploymentConfiguration() *schema.Resource {
 				DiffSuppressFunc: wdcUtils.ValidateConfigurationStatusChange,
 			},
 			"version": {
-				Description: "The version of the configuration.",
+				Description: "The latest published version of the configuration. DRAFT if the configuration has never been published.",
 				Type:        schema.TypeString,
 				Computed:    true,
 				MaxItems:    0,
 			},
+			"draft_only": {
+				Description: "Whether changes are only saved to the draft of the configuration. When false, every change is published as a new version. When true, the version is not changed, and deployments keep the version they use until the draft is published by setting this back to false.",
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+			},
 			"custom_i18n_labels": {
 				Description: "The localization settings for homescreen app",
 				Type:        schema.TypeList,
@@ -136,6 +136,10 @@ func buildAuthenticationSettings(d *schema.ResourceData) *platformclientv2.Authe
 }
 
 func CustomizeConfigurationDiff(ctx context.Context, diff *schema.ResourceDiff, meta interface{}) error {
+	if diff.Get("draft_only").(bool) {
+		// Changes are only saved to the draft, so the published version stays the same
+		return nil
+	}
 	if len(diff.GetChangedKeysPrefix("")) > 0 {
 		// When any change is made to the configuration we automatically publish a new version, so mark the version as updated
 		// so dependent deployments will update appropriately to reference the newest version
@@ -22,31 +22,34 @@ type createWebdeploymentsFunc func(ctx context.Context, p *webDeploymentsProxy,
 type updateWebdeploymentsFunc func(ctx context.Context, p *webDeploymentsProxy, deploymentId string, deployment platformclientv2.Webdeployment) (*platformclientv2.Webdeployment, *platformclientv2.APIResponse, error)
 type deleteWebdeploymentsFunc func(ctx context.Context, p *webDeploymentsProxy, deploymentId string) (*platformclientv2.APIResponse, error)
 type determineLatestVersionFunc func(ctx context.Context, p *webDeploymentsProxy, configurationId string) (string, []string, diag.Diagnostics)
+type getConfigurationVersionsFunc func(ctx context.Context, p *webDeploymentsProxy, configurationId string) (*[]platformclientv2.Webdeploymentconfigurationversion, *platformclientv2.APIResponse, error)
 
 type webDeploymentsProxy struct {
 	clientConfig      *platformclientv2.Configuration
 	webDeploymentsApi *platformclientv2.WebDeploymentsApi
 
-	getAllWebDeploymentsAttr   getAllWebDeploymentsFunc
-	getWebDeploymentAttr       getWebDeploymentsFunc
-	createWebDeploymentAttr    createWebdeploymentsFunc
-	updateWebDeploymentAttr    updateWebdeploymentsFunc
-	deleteWebDeploymentAttr    deleteWebdeploymentsFunc
-	determineLatestVersionAttr determineLatestVersionFunc
+	getAllWebDeploymentsAttr     getAllWebDeploymentsFunc
+	getWebDeploymentAttr         getWebDeploymentsFunc
+	createWebDeploymentAttr      createWebdeploymentsFunc
+	updateWebDeploymentAttr      updateWebdeploymentsFunc
+	deleteWebDeploymentAttr      deleteWebdeploymentsFunc
+	determineLatestVersionAttr   determineLatestVersionFunc
+	getConfigurationVersionsAttr getConfigurationVersionsFunc
 }
 
 func newWebDeploymentsProxy(clientConfig *platformclientv2.Configuration) *webDeploymentsProxy {
 	webDeploymentsApi := platformclientv2.NewWebDeploymentsApiWithConfig(clientConfig)
 
 	return &webDeploymentsProxy{
-		clientConfig:               clientConfig,
-		webDeploymentsApi:          webDeploymentsApi,
-		getAllWebDeploymentsAttr:   getAllWebDeploymentsFn,
-		getWebDeploymentAttr:       getWebDeploymentsFn,
-		createWebDeploymentAttr:    createWebdeploymentsFn,
-		updateWebDeploymentAttr:    updateWebdeploymentsFn,
-		deleteWebDeploymentAttr:    deleteWebdeploymentsFn,
-		determineLatestVersionAttr: determineLatestVersionFn,
+		clientConfig:                 clientConfig,
+		webDeploymentsApi:            webDeploymentsApi,
+		getAllWebDeploymentsAttr:     getAllWebDeploymentsFn,
+		getWebDeploymentAttr:         getWebDeploymentsFn,
+		createWebDeploymentAttr:      createWebdeploymentsFn,
+		updateWebDeploymentAttr:      updateWebdeploymentsFn,
+		deleteWebDeploymentAttr:      deleteWebdeploymentsFn,
+		determineLatestVersionAttr:   determineLatestVersionFn,
+		getConfigurationVersionsAttr: getConfigurationVersionsFn,
 	}
 }
 
@@ -68,6 +71,10 @@ func (p *webDeploymentsProxy) determineLatestVersion(ctx context.Context, config
 	return p.determineLatestVersionAttr(ctx, p, configurationId)
 }
 
+func (p *webDeploymentsProxy) getConfigurationVersions(ctx context.Context, configurationId string) (*[]platformclientv2.Webdeploymentconfigurationversion, *platformclientv2.APIResponse, error) {
+	return p.getConfigurationVersionsAttr(ctx, p, configurationId)
+}
+
 func (p *webDeploymentsProxy) createWebDeployment(ctx context.Context, deployment platformclientv2.Webdeployment) (*platformclientv2.Webdeployment, *platformclientv2.APIResponse, error) {
 	return p.createWebDeploymentAttr(ctx, p, deployment)
 }
@@ -100,6 +107,17 @@ func deleteWebdeploymentsFn(ctx context.Context, p *webDeploymentsProxy, deploym
 	return p.webDeploymentsApi.DeleteWebdeploymentsDeployment(deploymentId)
 }
 
+func getConfigurationVersionsFn(ctx context.Context, p *webDeploymentsProxy, configurationId string) (*[]platformclientv2.Webdeploymentconfigurationversion, *platformclientv2.APIResponse, error) {
+	versions, resp, err := p.webDeploymentsApi.GetWebdeploymentsConfigurationVersions(configurationId)
+	if err != nil {
+		return nil, resp, err
+	}
+	if versions.Entities == nil {
+		return &[]platformclientv2.Webdeploymentconfigurationversion{}, resp, nil
+	}
+	return versions.Entities, resp, nil
+}
+
 func determineLatestVersionFn(ctx context.Context, p *webDeploymentsProxy, configurationId string) (string, []string, diag.Diagnostics) {
 	version := ""
 	draft := "DRAFT"
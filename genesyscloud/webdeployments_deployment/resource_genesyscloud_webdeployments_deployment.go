@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"log"
 	"terraform-provider-genesyscloud/genesyscloud/provider"
+	"terraform-provider-genesyscloud/genesyscloud/tfexporter_state"
 	"terraform-provider-genesyscloud/genesyscloud/util"
 	"terraform-provider-genesyscloud/genesyscloud/util/constants"
 	"time"
@@ -15,6 +16,7 @@ import (
 
 	resourceExporter "terraform-provider-genesyscloud/genesyscloud/resource_exporter"
 	lists "terraform-provider-genesyscloud/genesyscloud/util/lists"
+	"terraform-provider-genesyscloud/genesyscloud/util/resourcedata"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
@@ -109,17 +111,19 @@ func createWebDeployment(ctx context.Context, d *schema.ResourceData, meta inter
 		return diagErr
 	}
 
-	time.Sleep(10 * time.Second)
-	activeError := waitForDeploymentToBeActive(ctx, sdkConfig, d.Id())
-	if activeError != nil {
-		return util.BuildDiagnosticError(ResourceType, fmt.Sprintf("Web deployment %s did not become active and could not be created", name), fmt.Errorf("%v", activeError))
+	if d.Get("wait_for_active").(bool) {
+		time.Sleep(10 * time.Second)
+		activeError := waitForDeploymentToBeActive(ctx, sdkConfig, d.Id(), d.Timeout(schema.TimeoutCreate))
+		if activeError != nil {
+			return util.BuildDiagnosticError(ResourceType, fmt.Sprintf("Web deployment %s did not become active and could not be created", name), fmt.Errorf("%v", activeError))
+		}
 	}
 	return readWebDeployment(ctx, d, meta)
 }
 
-func waitForDeploymentToBeActive(ctx context.Context, sdkConfig *platformclientv2.Configuration, id string) diag.Diagnostics {
+func waitForDeploymentToBeActive(ctx context.Context, sdkConfig *platformclientv2.Configuration, id string, timeout time.Duration) diag.Diagnostics {
 	wd := getWebDeploymentsProxy(sdkConfig)
-	return util.WithRetries(ctx, 60*time.Second, func() *retry.RetryError {
+	return util.WithRetries(ctx, timeout, func() *retry.RetryError {
 		deployment, resp, err := wd.getWebDeployment(ctx, id)
 		if err != nil {
 			if util.IsStatus404(resp) {
@@ -169,6 +173,20 @@ func readWebDeployment(ctx context.Context, d *schema.ResourceData, meta interfa
 		if deployment.Status != nil {
 			_ = d.Set("status", *deployment.Status)
 		}
+		resourcedata.SetNillableValue(d, "snippet", deployment.Snippet)
+		if resourcedata.GetNillableBool(d, "wait_for_active") == nil {
+			_ = d.Set("wait_for_active", true)
+		}
+
+		// The version history is not exported, so the exporter does not read it
+		if deployment.Configuration != nil && deployment.Configuration.Id != nil && !tfexporter_state.IsExporterActive() {
+			versions, _, err := wd.getConfigurationVersions(ctx, *deployment.Configuration.Id)
+			if err != nil {
+				log.Printf("WARNING: failed to read the versions of configuration %s of web deployment %s, keeping the previous version history | error: %s", *deployment.Configuration.Id, d.Id(), err)
+			} else {
+				_ = d.Set("configuration_versions", flattenConfigurationVersions(*versions))
+			}
+		}
 
 		log.Printf("Read web deployment %s %s", d.Id(), *deployment.Name)
 		return cc.CheckState(d)
@@ -196,11 +214,18 @@ func updateWebDeployment(ctx context.Context, d *schema.ResourceData, meta inter
 
 	flow := util.BuildSdkWebdeploymentFlowEntityRef(d, "flow_id")
 
-	// always update to latest version of configuration during update of an existing webdeployment
-	configVersion, _, er := wd.determineLatestVersion(ctx, configId)
-	if err != nil {
+	// update to the latest version of the configuration, unless a version is set to be promoted
+	configVersion, versionList, er := wd.determineLatestVersion(ctx, configId)
+	if er != nil {
 		return er
 	}
+	if promotedVersion := configuredConfigurationVersion(d.GetRawConfig()); promotedVersion != "" {
+		if !util.StringExists(promotedVersion, versionList) {
+			return util.BuildDiagnosticError(ResourceType, fmt.Sprintf("For Web Deployment Resource %v, Configuration Version Input %v does not match with any existing versions %v", name, promotedVersion, versionList), nil)
+		}
+		log.Printf("Promoting version %s of configuration %s to web deployment %s", promotedVersion, configId, name)
+		configVersion = promotedVersion
+	}
 	inputDeployment := platformclientv2.Webdeployment{
 		Name: &name,
 		Configuration: &platformclientv2.Webdeploymentconfigurationversionentityref{
@@ -235,10 +260,11 @@ func updateWebDeployment(ctx context.Context, d *schema.ResourceData, meta inter
 		return diagErr
 	}
 
-	activeError := waitForDeploymentToBeActive(ctx, sdkConfig, d.Id())
-
-	if activeError != nil {
-		return util.BuildDiagnosticError(ResourceType, fmt.Sprintf("Web deployment %s did not become active and could not be created", name), fmt.Errorf("%v", activeError))
+	if d.Get("wait_for_active").(bool) && status != "Inactive" {
+		activeError := waitForDeploymentToBeActive(ctx, sdkConfig, d.Id(), d.Timeout(schema.TimeoutUpdate))
+		if activeError != nil {
+			return util.BuildDiagnosticError(ResourceType, fmt.Sprintf("Web deployment %s did not become active and could not be updated", name), fmt.Errorf("%v", activeError))
+		}
 	}
 
 	log.Printf("Finished updating web deployment %s", name)
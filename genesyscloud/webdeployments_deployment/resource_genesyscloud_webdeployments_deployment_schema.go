@@ -1,6 +1,8 @@
 package webdeployments_deployment
 
 import (
+	"time"
+
 	"terraform-provider-genesyscloud/genesyscloud/provider"
 	resourceExporter "terraform-provider-genesyscloud/genesyscloud/resource_exporter"
 	registrar "terraform-provider-genesyscloud/genesyscloud/resource_register"
@@ -17,6 +19,27 @@ func SetRegistrar(l registrar.Registrar) {
 	l.RegisterResource(ResourceType, ResourceWebDeployment())
 	l.RegisterExporter(ResourceType, WebDeploymentExporter())
 }
+
+var configurationVersionResource = &schema.Resource{
+	Schema: map[string]*schema.Schema{
+		"version": {
+			Description: "The version of the configuration. DRAFT for the unpublished draft.",
+			Type:        schema.TypeString,
+			Computed:    true,
+		},
+		"status": {
+			Description: "The status of the configuration version.",
+			Type:        schema.TypeString,
+			Computed:    true,
+		},
+		"date_published": {
+			Description: "The date the version was published, in RFC3339 format.",
+			Type:        schema.TypeString,
+			Computed:    true,
+		},
+	},
+}
+
 func ResourceWebDeployment() *schema.Resource {
 	return &schema.Resource{
 		Description: "Genesys Cloud Web Deployment",
@@ -29,6 +52,10 @@ func ResourceWebDeployment() *schema.Resource {
 			StateContext: schema.ImportStatePassthroughContext,
 		},
 		SchemaVersion: 1,
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(5 * time.Minute),
+			Update: schema.DefaultTimeout(5 * time.Minute),
+		},
 		Schema: map[string]*schema.Schema{
 			"name": {
 				Description: "Deployment name",
@@ -71,8 +98,25 @@ func ResourceWebDeployment() *schema.Resource {
 				}, false),
 				DiffSuppressFunc: validateDeploymentStatusChange,
 			},
+			"wait_for_active": {
+				Description: "Whether to wait for the status of the deployment to become Active after it is created or updated. The wait is bounded by the create and update timeouts. Deployments set to Inactive are not waited for.",
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     true,
+			},
+			"snippet": {
+				Description: "The JavaScript snippet to embed in web pages to load the deployment.",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"configuration_versions": {
+				Description: "The version history of the configuration used by this deployment. The history is left unchanged when it cannot be read, and is not read by the exporter.",
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem:        configurationVersionResource,
+			},
 			"configuration": {
-				Description: "The published configuration version used by this deployment",
+				Description: "The published configuration version used by this deployment. If version is set, that version is promoted to the deployment, otherwise the latest published version is used.",
 				Type:        schema.TypeList,
 				Required:    true,
 				MaxItems:    1,
@@ -102,7 +146,7 @@ func WebDeploymentExporter() *resourceExporter.ResourceExporter {
 			"flow_id":          {RefType: "genesyscloud_flow"},
 			"configuration.id": {RefType: "genesyscloud_webdeployments_configuration"},
 		},
-		ExcludedAttributes: []string{"configuration.version"},
+		ExcludedAttributes: []string{"configuration.version", "snippet", "configuration_versions"},
 	}
 }
 
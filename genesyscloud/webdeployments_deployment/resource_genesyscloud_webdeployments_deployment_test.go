@@ -157,6 +157,62 @@ func TestAccResourceWebDeploymentsDeployment_Versioning(t *testing.T) {
 	})
 }
 
+func TestAccResourceWebDeploymentsDeployment_Promotion(t *testing.T) {
+	t.Parallel()
+	var (
+		deploymentName         = "Test Deployment " + util.RandString(8)
+		configName             = "Minimal Config " + uuid.NewString()
+		deploymentResourcePath = "genesyscloud_webdeployments_deployment.promotion"
+		configResourcePath     = "genesyscloud_webdeployments_configuration.minimal"
+	)
+
+	cleanupWebDeploymentsDeployment(t, "Test Deployment ")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { util.TestAccPreCheck(t) },
+		ProviderFactories: provider.GetProviderFactories(providerResources, providerDataSources),
+		Steps: []resource.TestStep{
+			{
+				// Publish version 1 and promote it
+				Config: promotionDeploymentResource(configName, "description 1", false, deploymentName, "1"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(configResourcePath, "version", "1"),
+					resource.TestCheckResourceAttr(deploymentResourcePath, "configuration.0.version", "1"),
+					resource.TestCheckResourceAttr(deploymentResourcePath, "status", "Active"),
+					resource.TestCheckResourceAttrSet(deploymentResourcePath, "snippet"),
+					resource.TestCheckResourceAttrSet(deploymentResourcePath, "configuration_versions.#"),
+				),
+			},
+			{
+				// Only save the draft, the published version is unchanged
+				Config: promotionDeploymentResource(configName, "draft description", true, deploymentName, "1"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(configResourcePath, "version", "1"),
+					resource.TestCheckResourceAttr(configResourcePath, "description", "draft description"),
+					resource.TestCheckResourceAttr(deploymentResourcePath, "configuration.0.version", "1"),
+				),
+			},
+			{
+				// Publish the draft as version 2, the deployment keeps version 1
+				Config: promotionDeploymentResource(configName, "draft description", false, deploymentName, "1"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(configResourcePath, "version", "2"),
+					resource.TestCheckResourceAttr(deploymentResourcePath, "configuration.0.version", "1"),
+				),
+			},
+			{
+				// Promote version 2
+				Config: promotionDeploymentResource(configName, "draft description", false, deploymentName, "2"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(deploymentResourcePath, "configuration.0.version", "2"),
+					resource.TestCheckResourceAttr(deploymentResourcePath, "status", "Active"),
+				),
+			},
+		},
+		CheckDestroy: verifyDeploymentDestroyed,
+	})
+}
+
 func basicDeploymentResource(name, description string) string {
 	minimalConfigName := "Minimal Config " + uuid.NewString()
 	return fmt.Sprintf(`
@@ -254,6 +310,28 @@ func deploymentResourceWithoutConfigVersion(t *testing.T, name, description, def
 	`, minimalConfigName, value, defaultLanguage, name, description)
 }
 
+func promotionDeploymentResource(configName, configDescription string, draftOnly bool, name, version string) string {
+	return fmt.Sprintf(`
+	resource "genesyscloud_webdeployments_configuration" "minimal" {
+		name             = "%s"
+		description      = "%s"
+		languages        = ["en-us"]
+		default_language = "en-us"
+		draft_only       = %v
+	}
+
+	resource "genesyscloud_webdeployments_deployment" "promotion" {
+		name = "%s"
+		allow_all_domains = true
+		configuration {
+			id = "${genesyscloud_webdeployments_configuration.minimal.id}"
+			version = "%s"
+		}
+		depends_on = [genesyscloud_webdeployments_configuration.minimal]
+	}
+	`, configName, configDescription, draftOnly, name, version)
+}
+
 func verifyDeploymentDestroyed(state *terraform.State) error {
 	api := platformclientv2.NewWebDeploymentsApi()
 
@@ -0,0 +1,98 @@
+package webdeployments_deployment
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"terraform-provider-genesyscloud/genesyscloud/provider"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/mypurecloud/platform-client-sdk-go/v149/platformclientv2"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUnitWebDeploymentConfiguredConfigurationVersion(t *testing.T) {
+	configType := cty.Object(map[string]cty.Type{
+		"configuration": cty.List(cty.Object(map[string]cty.Type{
+			"id":      cty.String,
+			"version": cty.String,
+		})),
+	})
+	buildConfig := func(version cty.Value) cty.Value {
+		return cty.ObjectVal(map[string]cty.Value{
+			"configuration": cty.ListVal([]cty.Value{cty.ObjectVal(map[string]cty.Value{
+				"id":      cty.StringVal(uuid.NewString()),
+				"version": version,
+			})}),
+		})
+	}
+
+	assert.Equal(t, "3", configuredConfigurationVersion(buildConfig(cty.StringVal("3"))))
+	assert.Equal(t, "", configuredConfigurationVersion(buildConfig(cty.NullVal(cty.String))))
+	assert.Equal(t, "", configuredConfigurationVersion(buildConfig(cty.UnknownVal(cty.String))))
+	assert.Equal(t, "", configuredConfigurationVersion(cty.NullVal(configType)))
+}
+
+func TestUnitWebDeploymentReadSnippetAndVersions(t *testing.T) {
+	var (
+		deploymentId  = uuid.NewString()
+		configId      = uuid.NewString()
+		snippet       = "<script>/* deployment */</script>"
+		datePublished = time.Date(2026, 5, 4, 3, 2, 1, 0, time.UTC)
+	)
+
+	internalProxy = &webDeploymentsProxy{
+		getWebDeploymentAttr: func(ctx context.Context, p *webDeploymentsProxy, deployId string) (*platformclientv2.Webdeployment, *platformclientv2.APIResponse, error) {
+			return &platformclientv2.Webdeployment{
+				Id:              &deploymentId,
+				Name:            platformclientv2.String("Test Deployment"),
+				AllowAllDomains: platformclientv2.Bool(true),
+				Status:          platformclientv2.String("Active"),
+				Snippet:         &snippet,
+				Configuration: &platformclientv2.Webdeploymentconfigurationversionentityref{
+					Id:      &configId,
+					Version: platformclientv2.String("1"),
+				},
+			}, &platformclientv2.APIResponse{StatusCode: http.StatusOK}, nil
+		},
+		getConfigurationVersionsAttr: func(ctx context.Context, p *webDeploymentsProxy, configurationId string) (*[]platformclientv2.Webdeploymentconfigurationversion, *platformclientv2.APIResponse, error) {
+			assert.Equal(t, configId, configurationId)
+			return &[]platformclientv2.Webdeploymentconfigurationversion{
+				{Version: platformclientv2.String("DRAFT"), Status: platformclientv2.String("Active")},
+				{Version: platformclientv2.String("1"), Status: platformclientv2.String("Active"), DatePublished: &datePublished},
+			}, &platformclientv2.APIResponse{StatusCode: http.StatusOK}, nil
+		},
+	}
+	defer func() { internalProxy = nil }()
+
+	meta := &provider.ProviderMeta{ClientConfig: &platformclientv2.Configuration{}}
+	d := schema.TestResourceDataRaw(t, ResourceWebDeployment().Schema, map[string]interface{}{})
+	d.SetId(deploymentId)
+
+	diags := readWebDeployment(context.Background(), d, meta)
+	assert.False(t, diags.HasError(), diags)
+
+	assert.Equal(t, snippet, d.Get("snippet").(string))
+	assert.Equal(t, true, d.Get("wait_for_active").(bool))
+	assert.Equal(t, "1", d.Get("configuration.0.version").(string))
+
+	versions := d.Get("configuration_versions").([]interface{})
+	if assert.Len(t, versions, 2) {
+		assert.Equal(t, "DRAFT", versions[0].(map[string]interface{})["version"])
+		assert.Equal(t, "", versions[0].(map[string]interface{})["date_published"])
+		assert.Equal(t, "1", versions[1].(map[string]interface{})["version"])
+		assert.Equal(t, "2026-05-04T03:02:01Z", versions[1].(map[string]interface{})["date_published"])
+	}
+
+	// A failure to read the version history does not fail the read and keeps the previous history
+	internalProxy.getConfigurationVersionsAttr = func(ctx context.Context, p *webDeploymentsProxy, configurationId string) (*[]platformclientv2.Webdeploymentconfigurationversion, *platformclientv2.APIResponse, error) {
+		return nil, &platformclientv2.APIResponse{StatusCode: http.StatusTooManyRequests}, fmt.Errorf("rate limited")
+	}
+	diags = readWebDeployment(context.Background(), d, meta)
+	assert.False(t, diags.HasError(), diags)
+	assert.Len(t, d.Get("configuration_versions").([]interface{}), 2)
+}
@@ -2,6 +2,9 @@ package webdeployments_deployment
 
 import (
 	"errors"
+	"time"
+
+	"github.com/hashicorp/go-cty/cty"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/mypurecloud/platform-client-sdk-go/v149/platformclientv2"
@@ -24,6 +27,45 @@ func flattenConfiguration(configuration *platformclientv2.Webdeploymentconfigura
 	}}
 }
 
+func flattenConfigurationVersions(versions []platformclientv2.Webdeploymentconfigurationversion) []interface{} {
+	flattened := make([]interface{}, 0, len(versions))
+	for _, version := range versions {
+		versionMap := map[string]interface{}{
+			"version":        "",
+			"status":         "",
+			"date_published": "",
+		}
+		if version.Version != nil {
+			versionMap["version"] = *version.Version
+		}
+		if version.Status != nil {
+			versionMap["status"] = *version.Status
+		}
+		if version.DatePublished != nil {
+			versionMap["date_published"] = version.DatePublished.UTC().Format(time.RFC3339)
+		}
+		flattened = append(flattened, versionMap)
+	}
+	return flattened
+}
+
+// configuredConfigurationVersion returns the configuration version set in the configuration of the resource. The
+// version in the state cannot be used, as it is computed when not set.
+func configuredConfigurationVersion(rawConfig cty.Value) string {
+	if rawConfig.IsNull() || !rawConfig.IsKnown() || !rawConfig.Type().HasAttribute("configuration") {
+		return ""
+	}
+	configuration := rawConfig.GetAttr("configuration")
+	if configuration.IsNull() || !configuration.IsKnown() || configuration.LengthInt() == 0 {
+		return ""
+	}
+	version := configuration.Index(cty.NumberIntVal(0)).GetAttr("version")
+	if version.IsNull() || !version.IsKnown() {
+		return ""
+	}
+	return version.AsString()
+}
+
 func validAllowedDomainsSettings(d *schema.ResourceData) error {
 	allowAllDomains := d.Get("allow_all_domains").(bool)
 	_, allowedDomainsSet := d.GetOk("allowed_domains")